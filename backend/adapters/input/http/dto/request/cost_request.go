@@ -0,0 +1,13 @@
+package request
+
+import "time"
+
+// AddCostEntryRequest - sin incurred_on el coste es de hoy
+type AddCostEntryRequest struct {
+	Type       string    `json:"type" binding:"required"`
+	Amount     float64   `json:"amount" binding:"required,gt=0"`
+	Vendor     string    `json:"vendor"`
+	InvoiceRef string    `json:"invoice_ref"`
+	IncurredOn time.Time `json:"incurred_on"`
+	Notes      string    `json:"notes"`
+}
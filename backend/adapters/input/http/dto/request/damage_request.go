@@ -0,0 +1,18 @@
+package request
+
+// RecordDamageRequest - photo_id opcional: una foto del vehicle subida con purpose damage
+type RecordDamageRequest struct {
+	ZoneID      uint   `json:"zone_id" binding:"required"`
+	Type        string `json:"type" binding:"required"`
+	Severity    string `json:"severity" binding:"required"`
+	Description string `json:"description"`
+	PhotoID     *uint  `json:"photo_id"`
+}
+
+type AttachDamagePhotoRequest struct {
+	PhotoID uint `json:"photo_id" binding:"required"`
+}
+
+type ListDamageRequest struct {
+	IncludeResolved bool `form:"include_resolved"`
+}
@@ -0,0 +1,51 @@
+package request
+
+// InspectionItemRequest - con unit el item es una medición; min_value/max_value su rango aceptable
+type InspectionItemRequest struct {
+	Label         string   `json:"label" binding:"required"`
+	Required      bool     `json:"required"`
+	PhotoRequired bool     `json:"photo_required"`
+	Unit          string   `json:"unit"`
+	MinValue      *float64 `json:"min_value"`
+	MaxValue      *float64 `json:"max_value"`
+}
+
+type InspectionSectionRequest struct {
+	Name  string                  `json:"name" binding:"required"`
+	Items []InspectionItemRequest `json:"items" binding:"required,dive"`
+}
+
+type CreateInspectionTemplateRequest struct {
+	Name        string                     `json:"name" binding:"required"`
+	Kind        string                     `json:"kind" binding:"required"`
+	Description string                     `json:"description"`
+	Sections    []InspectionSectionRequest `json:"sections" binding:"required,dive"`
+}
+
+// UpdateInspectionTemplateRequest - sections reemplaza la estructura completa
+type UpdateInspectionTemplateRequest struct {
+	Name        *string                     `json:"name"`
+	Description *string                     `json:"description"`
+	Sections    *[]InspectionSectionRequest `json:"sections" binding:"omitempty,dive"`
+}
+
+type StartInspectionRequest struct {
+	TemplateID uint `json:"template_id" binding:"required"`
+}
+
+// InspectionResultRequest - en las mediciones con rango result se calcula a partir de value
+type InspectionResultRequest struct {
+	ItemID  uint     `json:"item_id" binding:"required"`
+	Result  string   `json:"result"`
+	Value   *float64 `json:"value"`
+	PhotoID *uint    `json:"photo_id"`
+	Notes   string   `json:"notes"`
+}
+
+type RecordInspectionResultsRequest struct {
+	Results []InspectionResultRequest `json:"results" binding:"required,dive"`
+}
+
+type CompleteInspectionRequest struct {
+	Notes string `json:"notes"`
+}
@@ -22,4 +22,26 @@ type UpdateLocationRequest struct {
 	Latitude  *float64 `json:"latitude"`
 	Longitude *float64 `json:"longitude"`
 	Capacity  *int     `json:"capacity"`
-}
\ No newline at end of file
+}
+
+// SetGeofenceRequest - radius_m o polygon; sin ninguno se quita la geocerca
+type SetGeofenceRequest struct {
+	RadiusM float64           `json:"radius_m"`
+	Polygon []WaypointRequest `json:"polygon"`
+}
+
+// GeoOriginRequest - origen de una búsqueda por distancia: lat y lng, zip (con country si
+// hace falta desambiguar) o from_location_id
+type GeoOriginRequest struct {
+	Lat            *float64 `form:"lat"`
+	Lng            *float64 `form:"lng"`
+	Zip            string   `form:"zip"`
+	Country        string   `form:"country"`
+	FromLocationID uint     `form:"from_location_id"`
+}
+
+type NearestLocationsRequest struct {
+	GeoOriginRequest
+	Type  []string `form:"type"`
+	Limit int      `form:"limit"`
+}
@@ -0,0 +1,21 @@
+package request
+
+type CreateModel3DRequest struct {
+	Name     string `json:"name" binding:"required"`
+	BodyType string `json:"body_type" binding:"required"`
+}
+
+type UpdateModel3DRequest struct {
+	Name *string `json:"name"`
+}
+
+type ModelZoneRequest struct {
+	Code   string `json:"code" binding:"required"`
+	Name   string `json:"name" binding:"required"`
+	MeshID string `json:"mesh_id" binding:"required"`
+}
+
+// AssignModel3DRequest - model_3d_id 0 deja el vehicle sin modelo
+type AssignModel3DRequest struct {
+	Model3DID uint `json:"model_3d_id"`
+}
@@ -0,0 +1,18 @@
+package request
+
+// RepricingRuleRequest - condition y lot_type vacíos valen para todos; interval_days 7 es
+// una rebaja semanal
+type RepricingRuleRequest struct {
+	Name           string  `json:"name" binding:"required"`
+	Condition      string  `json:"condition"`
+	LotType        string  `json:"lot_type"`
+	MinDaysInStock int     `json:"min_days_in_stock" binding:"min=0"`
+	DropPercent    float64 `json:"drop_percent" binding:"required,gt=0"`
+	IntervalDays   int     `json:"interval_days" binding:"required,min=1"`
+	FloorOverCost  float64 `json:"floor_over_cost" binding:"min=0"`
+}
+
+// RepricingPreviewRequest - sin rule_id se simulan todas las reglas activas
+type RepricingPreviewRequest struct {
+	RuleID *uint `form:"rule_id"`
+}
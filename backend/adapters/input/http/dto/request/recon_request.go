@@ -0,0 +1,29 @@
+package request
+
+import "time"
+
+type AddReconTaskRequest struct {
+	Type          string  `json:"type" binding:"required"`
+	Description   string  `json:"description"`
+	AssigneeID    *uint   `json:"assignee_id"`
+	Vendor        string  `json:"vendor"`
+	EstimatedCost float64 `json:"estimated_cost"`
+}
+
+// UpdateReconTaskRequest - assignee_id 0 deja la tarea sin responsable
+type UpdateReconTaskRequest struct {
+	Description   *string  `json:"description"`
+	AssigneeID    *uint    `json:"assignee_id"`
+	Vendor        *string  `json:"vendor"`
+	EstimatedCost *float64 `json:"estimated_cost"`
+}
+
+type CompleteReconTaskRequest struct {
+	ActualCost *float64 `json:"actual_cost" binding:"required"`
+}
+
+// ReconStatsRequest - por defecto los últimos 30 días
+type ReconStatsRequest struct {
+	From time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To   time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+}
@@ -0,0 +1,23 @@
+package request
+
+type WaypointRequest struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// CreateRouteRequest - sin distance_km se calcula en línea recta por los waypoints
+type CreateRouteRequest struct {
+	Name             string            `json:"name" binding:"required"`
+	FromLocationID   uint              `json:"from_location_id" binding:"required"`
+	ToLocationID     uint              `json:"to_location_id" binding:"required"`
+	DistanceKM       float64           `json:"distance_km"`
+	EstimatedMinutes int               `json:"estimated_minutes"`
+	Waypoints        []WaypointRequest `json:"waypoints"`
+}
+
+type UpdateRouteRequest struct {
+	Name             *string            `json:"name"`
+	DistanceKM       *float64           `json:"distance_km"`
+	EstimatedMinutes *int               `json:"estimated_minutes"`
+	Waypoints        *[]WaypointRequest `json:"waypoints"`
+}
@@ -0,0 +1,27 @@
+package request
+
+import "time"
+
+// PingRequest - cada ping se valida en el service para poder rechazarlo sin perder el lote
+type PingRequest struct {
+	VIN        string    `json:"vin"`
+	DeviceID   string    `json:"device_id"`
+	Latitude   float64   `json:"latitude"`
+	Longitude  float64   `json:"longitude"`
+	RouteID    *uint     `json:"route_id"`
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+type IngestPingsRequest struct {
+	Pings []PingRequest `json:"pings" binding:"required"`
+}
+
+// AssignTrackingDeviceRequest - device_id vacío desasigna el dispositivo
+type AssignTrackingDeviceRequest struct {
+	DeviceID string `json:"device_id"`
+}
+
+type TrackRequest struct {
+	From time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To   time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+}
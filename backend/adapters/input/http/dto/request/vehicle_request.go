@@ -1,13 +1,14 @@
 package request
 
+// CreateVehicleRequest - make, model, year y body_type se deducen del VIN si no vienen
 type CreateVehicleRequest struct {
 	StockNumber       string  `json:"stock_number" binding:"required"`
 	VIN               string  `json:"vin" binding:"required"`
 	Plate             string  `json:"plate"`
-	Make              string  `json:"make" binding:"required"`
-	Model             string  `json:"model" binding:"required"`
+	Make              string  `json:"make"`
+	Model             string  `json:"model"`
 	Trim              string  `json:"trim"`
-	Year              int     `json:"year" binding:"required"`
+	Year              int     `json:"year"`
 	Mileage           int     `json:"mileage"`
 	ExteriorColor     string  `json:"exterior_color"`
 	InteriorColor     string  `json:"interior_color"`
@@ -18,6 +19,7 @@ type CreateVehicleRequest struct {
 	LocationID        uint    `json:"location_id"`
 	AcquisitionSource string  `json:"acquisition_source"`
 	AcquisitionCost   float64 `json:"acquisition_cost"`
+	BodyType          string  `json:"body_type"`
 }
 
 type UpdateVehicleRequest struct {
@@ -31,8 +33,34 @@ type UpdateVehicleRequest struct {
 	AskingPrice   *float64 `json:"asking_price"`
 }
 
+type MarkAsSoldRequest struct {
+	SalePrice *float64 `json:"sale_price" binding:"omitempty,gt=0"`
+	LeadID    *uint    `json:"lead_id" binding:"omitempty,gt=0"`
+}
+
+type ChangeStatusRequest struct {
+	Status string `json:"status" binding:"required"`
+	Reason string `json:"reason"`
+}
+
+// UnwindSaleRequest - sin status el vehicle vuelve a ready_for_sale
+type UnwindSaleRequest struct {
+	Status string `json:"status"`
+	Reason string `json:"reason" binding:"required"`
+}
+
 type ChangeLocationRequest struct {
-	LocationID uint `json:"location_id" binding:"required"`
+	LocationID uint   `json:"location_id" binding:"required"`
+	Reason     string `json:"reason"`
+}
+
+// StartTransferRequest - sin transit_location_id se usa la primera ubicación in_transit activa;
+// sin route_id, la ruta activa entre origen y destino si solo hay una
+type StartTransferRequest struct {
+	ToLocationID      uint   `json:"to_location_id" binding:"required"`
+	TransitLocationID uint   `json:"transit_location_id"`
+	RouteID           uint   `json:"route_id"`
+	Reason            string `json:"reason"`
 }
 
 type AddPhotoRequest struct {
@@ -44,4 +72,29 @@ type AddPhotoRequest struct {
 
 type SetPrimaryPhotoRequest struct {
 	PhotoID uint `json:"photo_id" binding:"required"`
-}
\ No newline at end of file
+}
+
+// SearchVehiclesRequest - parámetros de /vehicles/search; los filtros de lista se repiten
+// (?make=Ford&make=Toyota). radius_km va con un origen (lat/lng, zip o from_location_id)
+type SearchVehiclesRequest struct {
+	Q                 string   `form:"q"`
+	Make              []string `form:"make"`
+	Model             []string `form:"model"`
+	Trim              []string `form:"trim"`
+	Color             []string `form:"color"`
+	Condition         []string `form:"condition"`
+	LotType           []string `form:"lot_type"`
+	Status            []string `form:"status"`
+	AcquisitionSource []string `form:"acquisition_source"`
+	LocationID        []uint   `form:"location_id"`
+	YearMin           *int     `form:"year_min"`
+	YearMax           *int     `form:"year_max"`
+	PriceMin          *float64 `form:"price_min"`
+	PriceMax          *float64 `form:"price_max"`
+	MileageMin        *int     `form:"mileage_min"`
+	MileageMax        *int     `form:"mileage_max"`
+	DaysInStockMin    *int     `form:"days_in_stock_min"`
+	DaysInStockMax    *int     `form:"days_in_stock_max"`
+	RadiusKM          *float64 `form:"radius_km"`
+	GeoOriginRequest
+}
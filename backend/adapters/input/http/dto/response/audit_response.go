@@ -0,0 +1,25 @@
+package response
+
+import "time"
+
+type AuditChangeResponse struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+type AuditEntryResponse struct {
+	ID            uint                           `json:"id"`
+	ActorID       *uint                          `json:"actor_id"`
+	Action        string                         `json:"action"`
+	AggregateType string                         `json:"aggregate_type"`
+	AggregateID   uint                           `json:"aggregate_id"`
+	Changes       map[string]AuditChangeResponse `json:"changes"`
+	IP            string                         `json:"ip"`
+	RequestID     string                         `json:"request_id"`
+	CreatedAt     time.Time                      `json:"created_at"`
+}
+
+type AuditEntryListResponse struct {
+	Entries []AuditEntryResponse `json:"entries"`
+	Pagination
+}
@@ -0,0 +1,48 @@
+package response
+
+import "time"
+
+type CostEntryResponse struct {
+	ID         uint       `json:"id"`
+	VehicleID  uint       `json:"vehicle_id"`
+	Type       string     `json:"type"`
+	Amount     float64    `json:"amount"`
+	Vendor     string     `json:"vendor"`
+	InvoiceRef string     `json:"invoice_ref"`
+	IncurredOn time.Time  `json:"incurred_on"`
+	Notes      string     `json:"notes"`
+	CreatedBy  uint       `json:"created_by"`
+	VoidedAt   *time.Time `json:"voided_at,omitempty"`
+	VoidedBy   *uint      `json:"voided_by,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type CostEntryListResponse struct {
+	Entries []CostEntryResponse `json:"entries"`
+	Pagination
+}
+
+// CostLedgerLineResponse - entry_id en las entradas cargadas a mano, recon_task_id en las
+// tareas de recon; la compra del vehicle no lleva ninguno
+type CostLedgerLineResponse struct {
+	Type         string    `json:"type"`
+	Amount       float64   `json:"amount"`
+	Vendor       string    `json:"vendor"`
+	InvoiceRef   string    `json:"invoice_ref"`
+	IncurredOn   time.Time `json:"incurred_on"`
+	Notes        string    `json:"notes"`
+	EntryID      *uint     `json:"entry_id,omitempty"`
+	ReconTaskID  *uint     `json:"recon_task_id,omitempty"`
+	RunningTotal float64   `json:"running_total"`
+}
+
+// CostLedgerResponse - sin sale_price, profit y margin son los esperados al precio publicado
+type CostLedgerResponse struct {
+	VehicleID   uint                     `json:"vehicle_id"`
+	Lines       []CostLedgerLineResponse `json:"lines"`
+	TotalByType map[string]float64       `json:"total_by_type"`
+	TotalCost   float64                  `json:"total_cost"`
+	SalePrice   *float64                 `json:"sale_price"`
+	Profit      float64                  `json:"profit"`
+	Margin      float64                  `json:"margin"`
+}
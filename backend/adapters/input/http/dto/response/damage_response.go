@@ -0,0 +1,43 @@
+package response
+
+import "time"
+
+type DamageMarkResponse struct {
+	ID          uint       `json:"id"`
+	VehicleID   uint       `json:"vehicle_id"`
+	ZoneID      uint       `json:"zone_id"`
+	Type        string     `json:"type"`
+	Severity    string     `json:"severity"`
+	Description string     `json:"description"`
+	PhotoID     *uint      `json:"photo_id"`
+	ReportedBy  uint       `json:"reported_by"`
+	Resolved    bool       `json:"resolved"`
+	ResolvedBy  *uint      `json:"resolved_by"`
+	ResolvedAt  *time.Time `json:"resolved_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+type DamageMarkListResponse struct {
+	Marks []DamageMarkResponse `json:"marks"`
+	Pagination
+}
+
+// ZoneDamageResponse - zone falta si la zona ya no existe; mesh_id es lo que resalta el visor
+type ZoneDamageResponse struct {
+	ZoneID uint                 `json:"zone_id"`
+	Zone   *ModelZoneResponse   `json:"zone,omitempty"`
+	Score  int                  `json:"score"`
+	Marks  []DamageMarkResponse `json:"marks"`
+}
+
+type ConditionReportResponse struct {
+	VehicleID     uint                 `json:"vehicle_id"`
+	Model3DID     *uint                `json:"model_3d_id"`
+	Outstanding   int                  `json:"outstanding"`
+	Resolved      int                  `json:"resolved"`
+	BySeverity    map[string]int       `json:"by_severity"`
+	SeverityScore int                  `json:"severity_score"`
+	Grade         string               `json:"grade"`
+	Zones         []ZoneDamageResponse `json:"zones"`
+	GeneratedAt   time.Time            `json:"generated_at"`
+}
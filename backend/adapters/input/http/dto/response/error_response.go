@@ -0,0 +1,10 @@
+package response
+
+// ErrorResponse - sobre común para todos los errores; "error" se mantiene para los clientes existentes
+type ErrorResponse struct {
+	Error     string            `json:"error"`
+	Code      string            `json:"code"`
+	Fields    map[string]string `json:"fields,omitempty"`
+	Details   interface{}       `json:"details,omitempty"`
+	RequestID string            `json:"request_id,omitempty"`
+}
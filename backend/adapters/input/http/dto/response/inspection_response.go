@@ -0,0 +1,69 @@
+package response
+
+import "time"
+
+type InspectionItemResponse struct {
+	ID            uint     `json:"id"`
+	Label         string   `json:"label"`
+	Required      bool     `json:"required"`
+	PhotoRequired bool     `json:"photo_required"`
+	Unit          string   `json:"unit,omitempty"`
+	MinValue      *float64 `json:"min_value,omitempty"`
+	MaxValue      *float64 `json:"max_value,omitempty"`
+	SortOrder     int      `json:"sort_order"`
+}
+
+type InspectionSectionResponse struct {
+	ID        uint                     `json:"id"`
+	Name      string                   `json:"name"`
+	SortOrder int                      `json:"sort_order"`
+	Items     []InspectionItemResponse `json:"items"`
+}
+
+// InspectionTemplateResponse - los listados no incluyen sections
+type InspectionTemplateResponse struct {
+	ID          uint                        `json:"id"`
+	Name        string                      `json:"name"`
+	Kind        string                      `json:"kind"`
+	Description string                      `json:"description"`
+	Active      bool                        `json:"active"`
+	Sections    []InspectionSectionResponse `json:"sections,omitempty"`
+	Version     uint                        `json:"version"`
+	CreatedAt   time.Time                   `json:"created_at"`
+}
+
+type InspectionTemplateListResponse struct {
+	Templates []InspectionTemplateResponse `json:"templates"`
+	Pagination
+}
+
+type InspectionResultResponse struct {
+	ID         uint      `json:"id"`
+	ItemID     uint      `json:"item_id"`
+	Result     string    `json:"result"`
+	Value      *float64  `json:"value"`
+	PhotoID    *uint     `json:"photo_id"`
+	Notes      string    `json:"notes"`
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+// InspectionResponse - passed solo es significativo con status completed
+type InspectionResponse struct {
+	ID          uint                       `json:"id"`
+	VehicleID   uint                       `json:"vehicle_id"`
+	TemplateID  uint                       `json:"template_id"`
+	Kind        string                     `json:"kind"`
+	InspectorID uint                       `json:"inspector_id"`
+	Status      string                     `json:"status"`
+	Passed      bool                       `json:"passed"`
+	Notes       string                     `json:"notes"`
+	Results     []InspectionResultResponse `json:"results,omitempty"`
+	StartedAt   time.Time                  `json:"started_at"`
+	CompletedAt *time.Time                 `json:"completed_at"`
+	Version     uint                       `json:"version"`
+}
+
+type InspectionListResponse struct {
+	Inspections []InspectionResponse `json:"inspections"`
+	Pagination
+}
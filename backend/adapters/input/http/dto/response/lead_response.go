@@ -3,24 +3,27 @@ package response
 import "time"
 
 type LeadResponse struct {
-	ID            uint      `json:"id"`
-	EntityID      uint      `json:"entity_id"`
-	VehicleID     *uint     `json:"vehicle_id"`
-	InterestType  string    `json:"interest_type"`
-	InterestMake  string    `json:"interest_make"`
-	InterestModel string    `json:"interest_model"`
-	BudgetMin     float64   `json:"budget_min"`
-	BudgetMax     float64   `json:"budget_max"`
-	SourceID      uint      `json:"source_id"`
-	SourceDetail  string    `json:"source_detail"`
-	PresetID      *uint     `json:"preset_id"`
-	CreatedAt     time.Time `json:"created_at"`
-	ModifiedAt    time.Time `json:"modified_at"`
+	ID            uint       `json:"id"`
+	EntityID      uint       `json:"entity_id"`
+	VehicleID     *uint      `json:"vehicle_id"`
+	InterestType  string     `json:"interest_type"`
+	InterestMake  string     `json:"interest_make"`
+	InterestModel string     `json:"interest_model"`
+	BudgetMin     float64    `json:"budget_min"`
+	BudgetMax     float64    `json:"budget_max"`
+	SourceID      uint       `json:"source_id"`
+	SourceDetail  string     `json:"source_detail"`
+	PresetID      *uint      `json:"preset_id"`
+	DeletedAt     *time.Time `json:"deleted_at,omitempty"`
+	DeletedBy     *uint      `json:"deleted_by,omitempty"`
+	Version       uint       `json:"version"`
+	CreatedAt     time.Time  `json:"created_at"`
+	ModifiedAt    time.Time  `json:"modified_at"`
 }
 
 type LeadListResponse struct {
 	Leads []LeadResponse `json:"leads"`
-	Total int            `json:"total"`
+	Pagination
 }
 
 type LeadSourceResponse struct {
@@ -84,24 +87,26 @@ type LeadActivityResponse struct {
 
 type LeadActivityListResponse struct {
 	Activities []LeadActivityResponse `json:"activities"`
-	Total      int                    `json:"total"`
+	Pagination
 }
 
 type LeadStepPresetResponse struct {
-	ID          uint      `json:"id"`
-	Code        string    `json:"code"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	SortOrder   int       `json:"sort_order"`
-	IsPublic    bool      `json:"is_public"`
-	IsShared    bool      `json:"is_shared"`
-	CreatedBy   uint      `json:"created_by"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID          uint       `json:"id"`
+	Code        string     `json:"code"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	SortOrder   int        `json:"sort_order"`
+	IsPublic    bool       `json:"is_public"`
+	IsShared    bool       `json:"is_shared"`
+	CreatedBy   uint       `json:"created_by"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+	DeletedBy   *uint      `json:"deleted_by,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
 }
 
 type LeadStepPresetListResponse struct {
 	Presets []LeadStepPresetResponse `json:"presets"`
-	Total   int                      `json:"total"`
+	Pagination
 }
 
 type LeadStepResponse struct {
@@ -135,4 +140,4 @@ type LeadStepProgressResponse struct {
 type LeadStepProgressListResponse struct {
 	Progress []LeadStepProgressResponse `json:"progress"`
 	Total    int                        `json:"total"`
-}
\ No newline at end of file
+}
@@ -3,22 +3,92 @@ package response
 import "time"
 
 type LocationResponse struct {
-	ID        uint      `json:"id"`
-	Name      string    `json:"name"`
-	Type      string    `json:"type"`
-	Address   string    `json:"address"`
-	City      string    `json:"city"`
-	State     string    `json:"state"`
-	Zip       string    `json:"zip"`
-	CountryID uint      `json:"country_id"`
-	Latitude  float64   `json:"latitude"`
-	Longitude float64   `json:"longitude"`
-	Capacity  int       `json:"capacity"`
-	Active    bool      `json:"active"`
-	CreatedAt time.Time `json:"created_at"`
+	ID        uint              `json:"id"`
+	Name      string            `json:"name"`
+	Type      string            `json:"type"`
+	Address   string            `json:"address"`
+	City      string            `json:"city"`
+	State     string            `json:"state"`
+	Zip       string            `json:"zip"`
+	CountryID uint              `json:"country_id"`
+	Latitude  float64           `json:"latitude"`
+	Longitude float64           `json:"longitude"`
+	Capacity  int               `json:"capacity"`
+	Geofence  *GeofenceResponse `json:"geofence"`
+	Active    bool              `json:"active"`
+	Version   uint              `json:"version"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// GeofenceResponse - radius_m es 0 si la geocerca es un polígono
+type GeofenceResponse struct {
+	RadiusM float64            `json:"radius_m"`
+	Polygon []WaypointResponse `json:"polygon"`
+}
+
+type GeofenceEventResponse struct {
+	ID         uint      `json:"id"`
+	VehicleID  uint      `json:"vehicle_id"`
+	LocationID uint      `json:"location_id"`
+	Type       string    `json:"type"`
+	Latitude   float64   `json:"latitude"`
+	Longitude  float64   `json:"longitude"`
+	RecordedAt time.Time `json:"recorded_at"`
+	Outcome    string    `json:"outcome"`
+	Note       string    `json:"note,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type GeofenceEventListResponse struct {
+	Events []GeofenceEventResponse `json:"events"`
+	Pagination
 }
 
 type LocationListResponse struct {
 	Locations []LocationResponse `json:"locations"`
-	Total     int                `json:"total"`
-}
\ No newline at end of file
+	Pagination
+}
+
+// LocationOccupancyResponse - utilization_percent y overflow son 0 en ubicaciones sin capacidad
+type LocationOccupancyResponse struct {
+	LocationID  uint             `json:"location_id"`
+	Name        string           `json:"name"`
+	Type        string           `json:"type"`
+	Active      bool             `json:"active"`
+	Capacity    int              `json:"capacity"`
+	Parked      int64            `json:"parked"`
+	Inbound     int64            `json:"inbound"`
+	Occupied    int64            `json:"occupied"`
+	Overflow    int64            `json:"overflow"`
+	Utilization float64          `json:"utilization_percent"`
+	Alert       string           `json:"alert,omitempty"`
+	ByStatus    map[string]int64 `json:"by_status"`
+	ByLotType   map[string]int64 `json:"by_lot_type"`
+}
+
+type OccupancyAlertResponse struct {
+	LocationID  uint    `json:"location_id"`
+	Name        string  `json:"name"`
+	Alert       string  `json:"alert"`
+	Utilization float64 `json:"utilization_percent"`
+	Overflow    int64   `json:"overflow"`
+}
+
+type OccupancySummaryResponse struct {
+	Locations   []LocationOccupancyResponse `json:"locations"`
+	Alerts      []OccupancyAlertResponse    `json:"alerts"`
+	Capacity    int64                       `json:"capacity"`
+	Occupied    int64                       `json:"occupied"`
+	Overflow    int64                       `json:"overflow"`
+	Utilization float64                     `json:"utilization_percent"`
+}
+
+type LocationDistanceResponse struct {
+	Location   LocationResponse `json:"location"`
+	DistanceKM float64          `json:"distance_km"`
+}
+
+type NearestLocationsResponse struct {
+	Locations []LocationDistanceResponse `json:"locations"`
+	Pagination
+}
@@ -0,0 +1,34 @@
+package response
+
+import "time"
+
+type Model3DResponse struct {
+	ID           uint      `json:"id"`
+	Name         string    `json:"name"`
+	BodyType     string    `json:"body_type"`
+	FileURL      string    `json:"file_url"`
+	ThumbnailURL string    `json:"thumbnail_url"`
+	IsDefault    bool      `json:"is_default"`
+	Active       bool      `json:"active"`
+	Version      uint      `json:"version"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type Model3DListResponse struct {
+	Models []Model3DResponse `json:"models"`
+	Pagination
+}
+
+type ModelZoneResponse struct {
+	ID        uint      `json:"id"`
+	Model3DID uint      `json:"model_3d_id"`
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	MeshID    string    `json:"mesh_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ModelZoneListResponse struct {
+	Zones []ModelZoneResponse `json:"zones"`
+	Pagination
+}
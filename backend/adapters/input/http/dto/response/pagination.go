@@ -0,0 +1,10 @@
+package response
+
+// Pagination - se embebe en las respuestas de listados paginados; Total cuenta todos los
+// resultados que cumplen los filtros, no solo los de la página
+type Pagination struct {
+	Total      int64  `json:"total"`
+	Limit      int    `json:"limit,omitempty"`
+	Offset     int    `json:"offset"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
@@ -0,0 +1,72 @@
+package response
+
+import "time"
+
+type AgeBucketResponse struct {
+	Bucket      string  `json:"bucket"`
+	Vehicles    int     `json:"vehicles"`
+	TotalCost   float64 `json:"total_cost"`
+	TotalAsking float64 `json:"total_asking"`
+	AvgDays     float64 `json:"avg_days"`
+}
+
+type InventoryAgingResponse struct {
+	AsOf     time.Time           `json:"as_of"`
+	Vehicles int                 `json:"vehicles"`
+	AvgDays  float64             `json:"avg_days"`
+	Buckets  []AgeBucketResponse `json:"buckets"`
+}
+
+type RepricingRuleResponse struct {
+	ID             uint      `json:"id"`
+	Name           string    `json:"name"`
+	Condition      string    `json:"condition"`
+	LotType        string    `json:"lot_type"`
+	MinDaysInStock int       `json:"min_days_in_stock"`
+	DropPercent    float64   `json:"drop_percent"`
+	IntervalDays   int       `json:"interval_days"`
+	FloorOverCost  float64   `json:"floor_over_cost"`
+	Active         bool      `json:"active"`
+	Version        uint      `json:"version"`
+	CreatedAt      time.Time `json:"created_at"`
+	ModifiedAt     time.Time `json:"modified_at"`
+}
+
+type RepricingRuleListResponse struct {
+	Rules []RepricingRuleResponse `json:"rules"`
+	Pagination
+}
+
+type RepricingProposalResponse struct {
+	VehicleID    uint    `json:"vehicle_id"`
+	StockNumber  string  `json:"stock_number"`
+	RuleID       uint    `json:"rule_id"`
+	RuleName     string  `json:"rule_name"`
+	DaysInStock  int     `json:"days_in_stock"`
+	CurrentPrice float64 `json:"current_price"`
+	NewPrice     float64 `json:"new_price"`
+	Floor        float64 `json:"floor"`
+	AtFloor      bool    `json:"at_floor"`
+}
+
+// RepricingPreviewResponse - dry run: nada de esto se ha aplicado
+type RepricingPreviewResponse struct {
+	Proposals      []RepricingProposalResponse `json:"proposals"`
+	TotalReduction float64                     `json:"total_reduction"`
+}
+
+type PriceChangeResponse struct {
+	ID        uint      `json:"id"`
+	VehicleID uint      `json:"vehicle_id"`
+	OldPrice  float64   `json:"old_price"`
+	NewPrice  float64   `json:"new_price"`
+	Source    string    `json:"source"`
+	RuleID    *uint     `json:"rule_id,omitempty"`
+	ChangedBy *uint     `json:"changed_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type PriceChangeListResponse struct {
+	Changes []PriceChangeResponse `json:"changes"`
+	Pagination
+}
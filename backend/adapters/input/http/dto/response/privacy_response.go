@@ -0,0 +1,32 @@
+package response
+
+import "time"
+
+type DataExportResponse struct {
+	Entity      EntityResponse             `json:"entity"`
+	Phones      []PhoneResponse            `json:"phones"`
+	Account     *UserResponse              `json:"account"`
+	Leads       []LeadResponse             `json:"leads"`
+	Notes       []LeadNoteResponse         `json:"notes"`
+	Activities  []LeadActivityResponse     `json:"activities"`
+	Assignments []LeadAssignmentResponse   `json:"assignments"`
+	Progress    []LeadStepProgressResponse `json:"step_progress"`
+	GeneratedAt time.Time                  `json:"generated_at"`
+}
+
+type ErasureResponse struct {
+	EntityID           uint      `json:"entity_id"`
+	PhonesDeleted      int       `json:"phones_deleted"`
+	AccountAnonymized  bool      `json:"account_anonymized"`
+	LeadsRedacted      int       `json:"leads_redacted"`
+	NotesRedacted      int       `json:"notes_redacted"`
+	ActivitiesRedacted int       `json:"activities_redacted"`
+	ErasedAt           time.Time `json:"erased_at"`
+}
+
+type LegalHoldResponse struct {
+	Reason    string    `json:"reason"`
+	LeadID    uint      `json:"lead_id"`
+	VehicleID uint      `json:"vehicle_id"`
+	Until     time.Time `json:"until"`
+}
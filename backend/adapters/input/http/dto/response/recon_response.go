@@ -0,0 +1,56 @@
+package response
+
+import "time"
+
+type ReconTaskResponse struct {
+	ID               uint       `json:"id"`
+	OrderID          uint       `json:"order_id"`
+	Type             string     `json:"type"`
+	Description      string     `json:"description"`
+	Status           string     `json:"status"`
+	AssigneeID       *uint      `json:"assignee_id"`
+	Vendor           string     `json:"vendor"`
+	EstimatedCost    float64    `json:"estimated_cost"`
+	ActualCost       *float64   `json:"actual_cost"`
+	ApprovalRequired bool       `json:"approval_required"`
+	ApprovedBy       *uint      `json:"approved_by"`
+	ApprovedAt       *time.Time `json:"approved_at"`
+	StartedAt        *time.Time `json:"started_at"`
+	CompletedAt      *time.Time `json:"completed_at"`
+	CancelledAt      *time.Time `json:"cancelled_at"`
+	Version          uint       `json:"version"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+// ReconOrderResponse - cycle_time_hours sigue corriendo mientras la orden está abierta
+type ReconOrderResponse struct {
+	ID             uint                `json:"id"`
+	VehicleID      uint                `json:"vehicle_id"`
+	Status         string              `json:"status"`
+	OpenedBy       uint                `json:"opened_by"`
+	Notes          string              `json:"notes"`
+	Tasks          []ReconTaskResponse `json:"tasks"`
+	OpenTasks      int                 `json:"open_tasks"`
+	EstimatedCost  float64             `json:"estimated_cost"`
+	ActualCost     float64             `json:"actual_cost"`
+	CycleTimeHours float64             `json:"cycle_time_hours"`
+	OpenedAt       time.Time           `json:"opened_at"`
+	ClosedAt       *time.Time          `json:"closed_at"`
+	Version        uint                `json:"version"`
+}
+
+type ReconOrderListResponse struct {
+	Orders []ReconOrderResponse `json:"orders"`
+	Pagination
+}
+
+type ReconCycleStatsResponse struct {
+	From              time.Time `json:"from"`
+	To                time.Time `json:"to"`
+	ClosedOrders      int       `json:"closed_orders"`
+	AvgCycleTimeHours float64   `json:"avg_cycle_time_hours"`
+	MaxCycleTimeHours float64   `json:"max_cycle_time_hours"`
+	OpenOrders        int       `json:"open_orders"`
+	AvgOpenAgeHours   float64   `json:"avg_open_age_hours"`
+	TotalReconCost    float64   `json:"total_recon_cost"`
+}
@@ -0,0 +1,51 @@
+package response
+
+import "time"
+
+type WaypointResponse struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+type RouteResponse struct {
+	ID               uint               `json:"id"`
+	Name             string             `json:"name"`
+	FromLocationID   uint               `json:"from_location_id"`
+	ToLocationID     uint               `json:"to_location_id"`
+	DistanceKM       float64            `json:"distance_km"`
+	EstimatedMinutes int                `json:"estimated_minutes"`
+	Waypoints        []WaypointResponse `json:"waypoints"`
+	Active           bool               `json:"active"`
+	Version          uint               `json:"version"`
+	CreatedAt        time.Time          `json:"created_at"`
+}
+
+type RouteListResponse struct {
+	Routes []RouteResponse `json:"routes"`
+	Pagination
+}
+
+// RouteProgressResponse - sin ruta solo se informa la posición; eta y remaining_minutes
+// faltan si la ruta no tiene distancia ni tiempo estimado
+type RouteProgressResponse struct {
+	TransferID       uint                   `json:"transfer_id"`
+	VehicleID        uint                   `json:"vehicle_id"`
+	RouteID          *uint                  `json:"route_id"`
+	FromLocationID   *uint                  `json:"from_location_id"`
+	ToLocationID     uint                   `json:"to_location_id"`
+	DepartedAt       time.Time              `json:"departed_at"`
+	Position         *TrackingPointResponse `json:"position"`
+	TotalKM          float64                `json:"total_km"`
+	CoveredKM        float64                `json:"covered_km"`
+	RemainingKM      float64                `json:"remaining_km"`
+	PercentComplete  float64                `json:"percent_complete"`
+	RemainingMinutes *int                   `json:"remaining_minutes,omitempty"`
+	ETA              *time.Time             `json:"eta,omitempty"`
+	DeviationKM      float64                `json:"deviation_km"`
+	OffRoute         bool                   `json:"off_route"`
+}
+
+type InboundListResponse struct {
+	Inbound []RouteProgressResponse `json:"inbound"`
+	Pagination
+}
@@ -0,0 +1,28 @@
+package response
+
+import "time"
+
+type TrackingPointResponse struct {
+	VehicleID  uint      `json:"vehicle_id"`
+	Latitude   float64   `json:"latitude"`
+	Longitude  float64   `json:"longitude"`
+	RouteID    *uint     `json:"route_id,omitempty"`
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+type TrackResponse struct {
+	Points []TrackingPointResponse `json:"points"`
+	Pagination
+}
+
+type PingRejectionResponse struct {
+	Index  int    `json:"index"`
+	Reason string `json:"reason"`
+}
+
+type IngestPingsResponse struct {
+	Accepted   int                     `json:"accepted"`
+	Duplicates int                     `json:"duplicates"`
+	Rejected   []PingRejectionResponse `json:"rejected"`
+	Crossings  int                     `json:"geofence_crossings"`
+}
@@ -3,36 +3,83 @@ package response
 import "time"
 
 type VehicleResponse struct {
-	ID                uint      `json:"id"`
-	StockNumber       string    `json:"stock_number"`
-	VIN               string    `json:"vin"`
-	Plate             string    `json:"plate"`
-	Make              string    `json:"make"`
-	Model             string    `json:"model"`
-	Trim              string    `json:"trim"`
-	Year              int       `json:"year"`
-	Mileage           int       `json:"mileage"`
-	ExteriorColor     string    `json:"exterior_color"`
-	InteriorColor     string    `json:"interior_color"`
-	MSRP              float64   `json:"msrp"`
-	InvoicePrice      float64   `json:"invoice_price"`
-	AskingPrice       float64   `json:"asking_price"`
-	Condition         string    `json:"condition"`
-	Status            string    `json:"status"`
-	LotType           string    `json:"lot_type"`
-	LocationID        uint      `json:"location_id"`
-	AcquisitionSource string    `json:"acquisition_source"`
-	AcquisitionDate   time.Time `json:"acquisition_date"`
-	AcquisitionCost   float64   `json:"acquisition_cost"`
-	Profit            float64   `json:"profit"`
-	Margin            float64   `json:"margin"`
-	CreatedAt         time.Time `json:"created_at"`
-	ModifiedAt        time.Time `json:"modified_at"`
+	ID                uint       `json:"id"`
+	StockNumber       string     `json:"stock_number"`
+	VIN               string     `json:"vin"`
+	Plate             string     `json:"plate"`
+	Make              string     `json:"make"`
+	Model             string     `json:"model"`
+	Trim              string     `json:"trim"`
+	Year              int        `json:"year"`
+	Mileage           int        `json:"mileage"`
+	ExteriorColor     string     `json:"exterior_color"`
+	InteriorColor     string     `json:"interior_color"`
+	MSRP              float64    `json:"msrp"`
+	InvoicePrice      float64    `json:"invoice_price"`
+	AskingPrice       float64    `json:"asking_price"`
+	Condition         string     `json:"condition"`
+	Status            string     `json:"status"`
+	LotType           string     `json:"lot_type"`
+	LocationID        uint       `json:"location_id"`
+	AcquisitionSource string     `json:"acquisition_source"`
+	AcquisitionDate   time.Time  `json:"acquisition_date"`
+	AcquisitionCost   float64    `json:"acquisition_cost"`
+	ReconCost         float64    `json:"recon_cost"`
+	AdditionalCost    float64    `json:"additional_cost"`
+	SalePrice         *float64   `json:"sale_price"`
+	SoldAt            *time.Time `json:"sold_at"`
+	SoldLeadID        *uint      `json:"sold_lead_id"`
+	CostBasis         float64    `json:"cost_basis"`
+	Profit            float64    `json:"profit"`
+	Margin            float64    `json:"margin"`
+	DaysInStock       int        `json:"days_in_stock"`
+	AgeBucket         string     `json:"age_bucket"`
+	DistanceKM        *float64   `json:"distance_km,omitempty"`
+	TrackingDeviceID  *string    `json:"tracking_device_id,omitempty"`
+	Model3DID         *uint      `json:"model_3d_id"`
+	DeletedAt         *time.Time `json:"deleted_at,omitempty"`
+	DeletedBy         *uint      `json:"deleted_by,omitempty"`
+	Version           uint       `json:"version"`
+	CreatedAt         time.Time  `json:"created_at"`
+	ModifiedAt        time.Time  `json:"modified_at"`
+}
+
+type VINDecodeResponse struct {
+	VIN          string `json:"vin"`
+	WMI          string `json:"wmi"`
+	VDS          string `json:"vds"`
+	VIS          string `json:"vis"`
+	Region       string `json:"region"`
+	Manufacturer string `json:"manufacturer,omitempty"`
+	Make         string `json:"make,omitempty"`
+	Model        string `json:"model,omitempty"`
+	BodyType     string `json:"body_type,omitempty"`
+	ModelYear    int    `json:"model_year"`
+	PlantCode    string `json:"plant_code"`
+	Plant        string `json:"plant,omitempty"`
+	SerialNumber string `json:"serial_number"`
 }
 
 type VehicleListResponse struct {
 	Vehicles []VehicleResponse `json:"vehicles"`
-	Total    int               `json:"total"`
+	Pagination
+}
+
+type FacetCountResponse struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+type VehicleFacetsResponse struct {
+	Makes  []FacetCountResponse `json:"makes"`
+	Models []FacetCountResponse `json:"models"`
+	Years  []FacetCountResponse `json:"years"`
+}
+
+type VehicleSearchResponse struct {
+	Vehicles []VehicleResponse     `json:"vehicles"`
+	Facets   VehicleFacetsResponse `json:"facets"`
+	Pagination
 }
 
 type VehiclePhotoResponse struct {
@@ -49,4 +96,54 @@ type VehiclePhotoResponse struct {
 type VehiclePhotosResponse struct {
 	Photos []VehiclePhotoResponse `json:"photos"`
 	Total  int                    `json:"total"`
-}
\ No newline at end of file
+}
+
+type VehicleTransferResponse struct {
+	ID                uint       `json:"id"`
+	VehicleID         uint       `json:"vehicle_id"`
+	FromLocationID    *uint      `json:"from_location_id"`
+	TransitLocationID uint       `json:"transit_location_id"`
+	ToLocationID      uint       `json:"to_location_id"`
+	RouteID           *uint      `json:"route_id,omitempty"`
+	Status            string     `json:"status"`
+	Reason            string     `json:"reason"`
+	RequestedBy       uint       `json:"requested_by"`
+	ReceivedBy        *uint      `json:"received_by,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	CompletedAt       *time.Time `json:"completed_at,omitempty"`
+}
+
+type StatusTransitionsResponse struct {
+	Status  string   `json:"status"`
+	Allowed []string `json:"allowed"`
+}
+
+type StatusHistoryResponse struct {
+	ID         uint      `json:"id"`
+	VehicleID  uint      `json:"vehicle_id"`
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	ChangedBy  uint      `json:"changed_by"`
+	Reason     string    `json:"reason"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type StatusHistoryListResponse struct {
+	History []StatusHistoryResponse `json:"history"`
+	Pagination
+}
+
+type LocationHistoryResponse struct {
+	ID             uint      `json:"id"`
+	VehicleID      uint      `json:"vehicle_id"`
+	FromLocationID *uint     `json:"from_location_id"`
+	ToLocationID   *uint     `json:"to_location_id"`
+	MovedBy        uint      `json:"moved_by"`
+	Reason         string    `json:"reason"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type LocationHistoryListResponse struct {
+	History []LocationHistoryResponse `json:"history"`
+	Pagination
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"torque-dms/adapters/input/http/dto/response"
+	"torque-dms/core/audit/domain"
+	"torque-dms/core/audit/ports/input"
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+type AuditHandler struct {
+	auditService input.AuditService
+}
+
+func NewAuditHandler(auditService input.AuditService) *AuditHandler {
+	return &AuditHandler{auditService: auditService}
+}
+
+func (h *AuditHandler) ListByAggregate(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	q, err := parseQuery(c)
+	if err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	entries, err := h.auditService.ListByAggregate(c.Request.Context(), c.Param("type"), uint(id), q)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toAuditEntryListResponse(entries))
+}
+
+func (h *AuditHandler) ListByActor(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	q, err := parseQuery(c)
+	if err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	entries, err := h.auditService.ListByActor(c.Request.Context(), uint(id), q)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toAuditEntryListResponse(entries))
+}
+
+// Helpers
+
+func toAuditEntryResponse(e *domain.AuditEntry) *response.AuditEntryResponse {
+	changes := make(map[string]response.AuditChangeResponse, len(e.Changes))
+	for field, change := range e.Changes {
+		changes[field] = response.AuditChangeResponse{
+			From: change.From,
+			To:   change.To,
+		}
+	}
+
+	return &response.AuditEntryResponse{
+		ID:            e.ID,
+		ActorID:       e.ActorID,
+		Action:        string(e.Action),
+		AggregateType: e.AggregateType,
+		AggregateID:   e.AggregateID,
+		Changes:       changes,
+		IP:            e.IP,
+		RequestID:     e.RequestID,
+		CreatedAt:     e.CreatedAt,
+	}
+}
+
+func toAuditEntryListResponse(entries *sharedDomain.Page[*domain.AuditEntry]) response.AuditEntryListResponse {
+	responseList := make([]response.AuditEntryResponse, len(entries.Items))
+	for i, entry := range entries.Items {
+		responseList[i] = *toAuditEntryResponse(entry)
+	}
+
+	return response.AuditEntryListResponse{
+		Entries:    responseList,
+		Pagination: toPagination(entries),
+	}
+}
@@ -20,11 +20,11 @@ func NewAuthHandler(authService input.AuthService) *AuthHandler {
 func (h *AuthHandler) Register(c *gin.Context) {
 	var req request.RegisterRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		badRequest(c, err)
 		return
 	}
 
-	entity, user, err := h.authService.Register(input.RegisterInput{
+	entity, user, err := h.authService.Register(c.Request.Context(), input.RegisterInput{
 		Type:         req.Type,
 		FirstName:    req.FirstName,
 		LastName:     req.LastName,
@@ -35,7 +35,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		Password:     req.Password,
 	})
 	if err != nil {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -63,16 +63,16 @@ func (h *AuthHandler) Register(c *gin.Context) {
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req request.LoginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		badRequest(c, err)
 		return
 	}
 
-	result, err := h.authService.Login(input.LoginInput{
+	result, err := h.authService.Login(c.Request.Context(), input.LoginInput{
 		Username: req.Username,
 		Password: req.Password,
 	})
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -92,9 +92,9 @@ func (h *AuthHandler) Login(c *gin.Context) {
 func (h *AuthHandler) Logout(c *gin.Context) {
 	userID, _ := c.Get("user_id")
 	
-	err := h.authService.Logout(userID.(uint))
+	err := h.authService.Logout(c.Request.Context(), userID.(uint))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -104,19 +104,19 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 func (h *AuthHandler) ChangePassword(c *gin.Context) {
 	var req request.ChangePasswordRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		badRequest(c, err)
 		return
 	}
 
 	userID, _ := c.Get("user_id")
 
-	err := h.authService.ChangePassword(input.ChangePasswordInput{
+	err := h.authService.ChangePassword(c.Request.Context(), input.ChangePasswordInput{
 		UserID:      userID.(uint),
 		OldPassword: req.OldPassword,
 		NewPassword: req.NewPassword,
 	})
 	if err != nil {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
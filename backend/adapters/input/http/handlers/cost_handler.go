@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"torque-dms/adapters/input/http/dto/request"
+	"torque-dms/adapters/input/http/dto/response"
+	"torque-dms/core/inventory/domain"
+	"torque-dms/core/inventory/ports/input"
+)
+
+type CostHandler struct {
+	costService input.CostService
+}
+
+func NewCostHandler(costService input.CostService) *CostHandler {
+	return &CostHandler{costService: costService}
+}
+
+func (h *CostHandler) GetLedger(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	ledger, err := h.costService.GetLedger(c.Request.Context(), uint(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	lines := make([]response.CostLedgerLineResponse, len(ledger.Lines))
+	for i, l := range ledger.Lines {
+		lines[i] = response.CostLedgerLineResponse{
+			Type:         string(l.Type),
+			Amount:       l.Amount,
+			Vendor:       l.Vendor,
+			InvoiceRef:   l.InvoiceRef,
+			IncurredOn:   l.IncurredOn,
+			Notes:        l.Notes,
+			EntryID:      l.EntryID,
+			ReconTaskID:  l.ReconTaskID,
+			RunningTotal: l.RunningTotal,
+		}
+	}
+	totals := make(map[string]float64, len(ledger.TotalByType))
+	for t, total := range ledger.TotalByType {
+		totals[string(t)] = total
+	}
+
+	c.JSON(http.StatusOK, response.CostLedgerResponse{
+		VehicleID:   ledger.VehicleID,
+		Lines:       lines,
+		TotalByType: totals,
+		TotalCost:   ledger.TotalCost,
+		SalePrice:   ledger.SalePrice,
+		Profit:      ledger.Profit,
+		Margin:      ledger.Margin,
+	})
+}
+
+func (h *CostHandler) ListEntries(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	entries, err := h.costService.ListEntries(c.Request.Context(), uint(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	responseList := make([]response.CostEntryResponse, len(entries))
+	for i, entry := range entries {
+		responseList[i] = *toCostEntryResponse(entry)
+	}
+
+	c.JSON(http.StatusOK, response.CostEntryListResponse{
+		Entries:    responseList,
+		Pagination: response.Pagination{Total: int64(len(entries))},
+	})
+}
+
+func (h *CostHandler) AddEntry(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	var req request.AddCostEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	entry, err := h.costService.AddEntry(c.Request.Context(), uint(id), input.AddCostEntryInput{
+		Type:       req.Type,
+		Amount:     req.Amount,
+		Vendor:     req.Vendor,
+		InvoiceRef: req.InvoiceRef,
+		IncurredOn: req.IncurredOn,
+		Notes:      req.Notes,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toCostEntryResponse(entry))
+}
+
+func (h *CostHandler) VoidEntry(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+	entryID, err := strconv.ParseUint(c.Param("entryId"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid entry id"))
+		return
+	}
+
+	entry, err := h.costService.VoidEntry(c.Request.Context(), uint(id), uint(entryID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toCostEntryResponse(entry))
+}
+
+func toCostEntryResponse(e *domain.CostEntry) *response.CostEntryResponse {
+	return &response.CostEntryResponse{
+		ID:         e.ID,
+		VehicleID:  e.VehicleID,
+		Type:       string(e.Type),
+		Amount:     e.Amount,
+		Vendor:     e.Vendor,
+		InvoiceRef: e.InvoiceRef,
+		IncurredOn: e.IncurredOn,
+		Notes:      e.Notes,
+		CreatedBy:  e.CreatedBy,
+		VoidedAt:   e.VoidedAt,
+		VoidedBy:   e.VoidedBy,
+		CreatedAt:  e.CreatedAt,
+	}
+}
@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"torque-dms/adapters/input/http/dto/request"
+	"torque-dms/adapters/input/http/dto/response"
+	"torque-dms/core/inventory/domain"
+	"torque-dms/core/inventory/ports/input"
+)
+
+type DamageHandler struct {
+	damageService input.DamageService
+}
+
+func NewDamageHandler(damageService input.DamageService) *DamageHandler {
+	return &DamageHandler{damageService: damageService}
+}
+
+func (h *DamageHandler) RecordMark(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	var req request.RecordDamageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	reportedBy, _ := c.Get("entity_id")
+
+	mark, err := h.damageService.RecordMark(c.Request.Context(), input.RecordDamageInput{
+		VehicleID:   uint(id),
+		ZoneID:      req.ZoneID,
+		Type:        req.Type,
+		Severity:    req.Severity,
+		Description: req.Description,
+		PhotoID:     req.PhotoID,
+		ReportedBy:  reportedBy.(uint),
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toDamageMarkResponse(mark))
+}
+
+func (h *DamageHandler) ListMarks(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	var req request.ListDamageRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	marks, err := h.damageService.ListMarks(c.Request.Context(), uint(id), req.IncludeResolved)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.DamageMarkListResponse{
+		Marks:      toDamageMarkResponses(marks),
+		Pagination: response.Pagination{Total: int64(len(marks))},
+	})
+}
+
+func (h *DamageHandler) AttachPhoto(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	markID, err := strconv.ParseUint(c.Param("markId"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid mark id"))
+		return
+	}
+
+	var req request.AttachDamagePhotoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	mark, err := h.damageService.AttachPhoto(c.Request.Context(), uint(id), uint(markID), req.PhotoID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toDamageMarkResponse(mark))
+}
+
+func (h *DamageHandler) ResolveMark(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	markID, err := strconv.ParseUint(c.Param("markId"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid mark id"))
+		return
+	}
+
+	resolvedBy, _ := c.Get("entity_id")
+
+	mark, err := h.damageService.ResolveMark(c.Request.Context(), uint(id), uint(markID), resolvedBy.(uint))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toDamageMarkResponse(mark))
+}
+
+func (h *DamageHandler) GetConditionReport(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	report, err := h.damageService.GetConditionReport(c.Request.Context(), uint(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toConditionReportResponse(report))
+}
+
+func toDamageMarkResponse(m *domain.VehicleZoneMark) *response.DamageMarkResponse {
+	return &response.DamageMarkResponse{
+		ID:          m.ID,
+		VehicleID:   m.VehicleID,
+		ZoneID:      m.ZoneID,
+		Type:        string(m.Type),
+		Severity:    string(m.Severity),
+		Description: m.Description,
+		PhotoID:     m.PhotoID,
+		ReportedBy:  m.ReportedBy,
+		Resolved:    m.Resolved,
+		ResolvedBy:  m.ResolvedBy,
+		ResolvedAt:  m.ResolvedAt,
+		CreatedAt:   m.CreatedAt,
+	}
+}
+
+func toDamageMarkResponses(marks []*domain.VehicleZoneMark) []response.DamageMarkResponse {
+	responseList := make([]response.DamageMarkResponse, len(marks))
+	for i, mark := range marks {
+		responseList[i] = *toDamageMarkResponse(mark)
+	}
+	return responseList
+}
+
+func toConditionReportResponse(r *domain.ConditionReport) *response.ConditionReportResponse {
+	bySeverity := make(map[string]int, len(r.BySeverity))
+	for severity, count := range r.BySeverity {
+		bySeverity[string(severity)] = count
+	}
+
+	zones := make([]response.ZoneDamageResponse, len(r.Zones))
+	for i, z := range r.Zones {
+		zones[i] = response.ZoneDamageResponse{
+			ZoneID: z.ZoneID,
+			Score:  z.Score,
+			Marks:  toDamageMarkResponses(z.Marks),
+		}
+		if z.Zone != nil {
+			zones[i].Zone = toModelZoneResponse(z.Zone)
+		}
+	}
+
+	return &response.ConditionReportResponse{
+		VehicleID:     r.VehicleID,
+		Model3DID:     r.Model3DID,
+		Outstanding:   r.Outstanding,
+		Resolved:      r.Resolved,
+		BySeverity:    bySeverity,
+		SeverityScore: r.SeverityScore,
+		Grade:         string(r.Grade),
+		Zones:         zones,
+		GeneratedAt:   r.GeneratedAt,
+	}
+}
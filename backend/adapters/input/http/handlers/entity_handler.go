@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -23,11 +24,11 @@ func NewEntityHandler(entityService input.EntityService) *EntityHandler {
 func (h *EntityHandler) Create(c *gin.Context) {
 	var req request.CreateEntityRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		badRequest(c, err)
 		return
 	}
 
-	entity, err := h.entityService.Create(input.CreateEntityInput{
+	entity, err := h.entityService.Create(c.Request.Context(), input.CreateEntityInput{
 		Type:         req.Type,
 		FirstName:    req.FirstName,
 		LastName:     req.LastName,
@@ -42,7 +43,7 @@ func (h *EntityHandler) Create(c *gin.Context) {
 		CountryID:    req.CountryID,
 	})
 	if err != nil {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -52,89 +53,141 @@ func (h *EntityHandler) Create(c *gin.Context) {
 func (h *EntityHandler) GetByID(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		badRequest(c, errors.New("invalid id"))
 		return
 	}
 
-	entity, err := h.entityService.GetByID(uint(id))
+	entity, err := h.entityService.GetByID(c.Request.Context(), uint(id))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "entity not found"})
+		c.Error(err)
 		return
 	}
 
+	setETag(c, entity.Version)
 	c.JSON(http.StatusOK, toEntityResponse(entity))
 }
 
 func (h *EntityHandler) List(c *gin.Context) {
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
-	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	q, err := parseQuery(c)
+	if err != nil {
+		badRequest(c, err)
+		return
+	}
 
-	entities, err := h.entityService.List(limit, offset)
+	entities, err := h.entityService.List(c.Request.Context(), q)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
-	responseList := make([]response.EntityResponse, len(entities))
-	for i, entity := range entities {
+	responseList := make([]response.EntityResponse, len(entities.Items))
+	for i, entity := range entities.Items {
 		responseList[i] = *toEntityResponse(entity)
 	}
 
 	c.JSON(http.StatusOK, response.EntityListResponse{
-		Entities: responseList,
-		Total:    len(responseList),
+		Entities:   responseList,
+		Pagination: toPagination(entities),
 	})
 }
 
 func (h *EntityHandler) Update(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	version, err := ifMatch(c)
+	if err != nil {
+		badRequest(c, err)
 		return
 	}
 
 	var req request.UpdateEntityRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		badRequest(c, err)
 		return
 	}
 
-	entity, err := h.entityService.Update(uint(id), input.UpdateEntityInput{
-		Field: req.Field,
-		Value: req.Value,
+	entity, err := h.entityService.Update(c.Request.Context(), uint(id), input.UpdateEntityInput{
+		Field:   req.Field,
+		Value:   req.Value,
+		Version: version,
 	})
 	if err != nil {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
+	setETag(c, entity.Version)
 	c.JSON(http.StatusOK, toEntityResponse(entity))
 }
 
 func (h *EntityHandler) Delete(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		badRequest(c, errors.New("invalid id"))
 		return
 	}
 
-	if err := h.entityService.Delete(uint(id)); err != nil {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	if err := h.entityService.Delete(c.Request.Context(), uint(id)); err != nil {
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "entity deleted successfully"})
 }
 
+func (h *EntityHandler) ListDeleted(c *gin.Context) {
+	q, err := parseQuery(c)
+	if err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	entities, err := h.entityService.ListDeleted(c.Request.Context(), q)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	responseList := make([]response.EntityResponse, len(entities.Items))
+	for i, entity := range entities.Items {
+		responseList[i] = *toEntityResponse(entity)
+	}
+
+	c.JSON(http.StatusOK, response.EntityListResponse{
+		Entities:   responseList,
+		Pagination: toPagination(entities),
+	})
+}
+
+func (h *EntityHandler) Restore(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	entity, err := h.entityService.Restore(c.Request.Context(), uint(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toEntityResponse(entity))
+}
+
 func (h *EntityHandler) Suspend(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		badRequest(c, errors.New("invalid id"))
 		return
 	}
 
-	if err := h.entityService.Suspend(uint(id)); err != nil {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	if err := h.entityService.Suspend(c.Request.Context(), uint(id)); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -144,12 +197,12 @@ func (h *EntityHandler) Suspend(c *gin.Context) {
 func (h *EntityHandler) Activate(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		badRequest(c, errors.New("invalid id"))
 		return
 	}
 
-	if err := h.entityService.Activate(uint(id)); err != nil {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	if err := h.entityService.Activate(c.Request.Context(), uint(id)); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -178,6 +231,10 @@ func toEntityResponse(e *domain.Entity) *response.EntityResponse {
 		IsInternal:     e.IsInternal,
 		ParentEntityID: e.ParentEntityID,
 		Status:         string(e.Status),
+		ErasedAt:       e.ErasedAt,
+		DeletedAt:      e.DeletedAt,
+		DeletedBy:      e.DeletedBy,
+		Version:        e.Version,
 		CreatedAt:      e.CreatedAt,
 		ModifiedAt:     e.ModifiedAt,
 	}
@@ -0,0 +1,8 @@
+package handlers
+
+import "github.com/gin-gonic/gin"
+
+// badRequest - body o parámetros mal formados; el middleware de errores responde 400
+func badRequest(c *gin.Context, err error) {
+	c.Error(err).SetType(gin.ErrorTypeBind)
+}
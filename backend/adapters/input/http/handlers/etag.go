@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setETag - la versión del agregado viaja como ETag para usarla luego en If-Match
+func setETag(c *gin.Context, version uint) {
+	c.Header("ETag", fmt.Sprintf(`"%d"`, version))
+}
+
+// ifMatch - versión esperada según el header If-Match; nil si no viene o es "*"
+func ifMatch(c *gin.Context) (*uint, error) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return nil, nil
+	}
+
+	tag := strings.Trim(strings.TrimPrefix(header, "W/"), `"`)
+	version, err := strconv.ParseUint(tag, 10, 32)
+	if err != nil {
+		return nil, errors.New("invalid If-Match header")
+	}
+	v := uint(version)
+	return &v, nil
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"torque-dms/adapters/input/http/dto/response"
+	"torque-dms/core/inventory/domain"
+	"torque-dms/core/inventory/ports/input"
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+type GeofenceHandler struct {
+	geofenceService input.GeofenceService
+}
+
+func NewGeofenceHandler(geofenceService input.GeofenceService) *GeofenceHandler {
+	return &GeofenceHandler{geofenceService: geofenceService}
+}
+
+func (h *GeofenceHandler) ListByVehicle(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	q, err := parseQuery(c)
+	if err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	page, err := h.geofenceService.ListByVehicle(c.Request.Context(), uint(id), q)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toGeofenceEventListResponse(page))
+}
+
+func (h *GeofenceHandler) ListByLocation(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	q, err := parseQuery(c)
+	if err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	page, err := h.geofenceService.ListByLocation(c.Request.Context(), uint(id), q)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toGeofenceEventListResponse(page))
+}
+
+func toGeofenceEventListResponse(page *sharedDomain.Page[*domain.GeofenceEvent]) response.GeofenceEventListResponse {
+	events := make([]response.GeofenceEventResponse, len(page.Items))
+	for i, e := range page.Items {
+		events[i] = response.GeofenceEventResponse{
+			ID:         e.ID,
+			VehicleID:  e.VehicleID,
+			LocationID: e.LocationID,
+			Type:       string(e.Type),
+			Latitude:   e.Latitude,
+			Longitude:  e.Longitude,
+			RecordedAt: e.RecordedAt,
+			Outcome:    string(e.Outcome),
+			Note:       e.Note,
+			CreatedAt:  e.CreatedAt,
+		}
+	}
+
+	return response.GeofenceEventListResponse{
+		Events:     events,
+		Pagination: toPagination(page),
+	}
+}
@@ -0,0 +1,379 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"torque-dms/adapters/input/http/dto/request"
+	"torque-dms/adapters/input/http/dto/response"
+	"torque-dms/core/inventory/domain"
+	"torque-dms/core/inventory/ports/input"
+)
+
+type InspectionHandler struct {
+	inspectionService input.InspectionService
+}
+
+func NewInspectionHandler(inspectionService input.InspectionService) *InspectionHandler {
+	return &InspectionHandler{inspectionService: inspectionService}
+}
+
+// Templates
+
+func (h *InspectionHandler) CreateTemplate(c *gin.Context) {
+	var req request.CreateInspectionTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	template, err := h.inspectionService.CreateTemplate(c.Request.Context(), input.CreateInspectionTemplateInput{
+		Name:        req.Name,
+		Kind:        req.Kind,
+		Description: req.Description,
+		Sections:    toInspectionSectionInputs(req.Sections),
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toInspectionTemplateResponse(template))
+}
+
+func (h *InspectionHandler) GetTemplate(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	template, err := h.inspectionService.GetTemplate(c.Request.Context(), uint(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	setETag(c, template.Version)
+	c.JSON(http.StatusOK, toInspectionTemplateResponse(template))
+}
+
+func (h *InspectionHandler) ListTemplates(c *gin.Context) {
+	q, err := parseQuery(c)
+	if err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	templates, err := h.inspectionService.ListTemplates(c.Request.Context(), q)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	responseList := make([]response.InspectionTemplateResponse, len(templates.Items))
+	for i, template := range templates.Items {
+		responseList[i] = *toInspectionTemplateResponse(template)
+	}
+
+	c.JSON(http.StatusOK, response.InspectionTemplateListResponse{
+		Templates:  responseList,
+		Pagination: toPagination(templates),
+	})
+}
+
+func (h *InspectionHandler) UpdateTemplate(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	version, err := ifMatch(c)
+	if err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	var req request.UpdateInspectionTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	inp := input.UpdateInspectionTemplateInput{
+		Name:        req.Name,
+		Description: req.Description,
+		Version:     version,
+	}
+	if req.Sections != nil {
+		sections := toInspectionSectionInputs(*req.Sections)
+		inp.Sections = &sections
+	}
+
+	template, err := h.inspectionService.UpdateTemplate(c.Request.Context(), uint(id), inp)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	setETag(c, template.Version)
+	c.JSON(http.StatusOK, toInspectionTemplateResponse(template))
+}
+
+func (h *InspectionHandler) DeleteTemplate(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	if err := h.inspectionService.DeleteTemplate(c.Request.Context(), uint(id)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "template deleted successfully"})
+}
+
+func (h *InspectionHandler) DeactivateTemplate(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	if err := h.inspectionService.DeactivateTemplate(c.Request.Context(), uint(id)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "template deactivated"})
+}
+
+func (h *InspectionHandler) ActivateTemplate(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	if err := h.inspectionService.ActivateTemplate(c.Request.Context(), uint(id)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "template activated"})
+}
+
+// Inspections
+
+func (h *InspectionHandler) Start(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	var req request.StartInspectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	inspectorID, _ := c.Get("entity_id")
+
+	inspection, err := h.inspectionService.Start(c.Request.Context(), input.StartInspectionInput{
+		VehicleID:   uint(id),
+		TemplateID:  req.TemplateID,
+		InspectorID: inspectorID.(uint),
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toInspectionResponse(inspection))
+}
+
+func (h *InspectionHandler) GetByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	inspection, err := h.inspectionService.GetByID(c.Request.Context(), uint(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toInspectionResponse(inspection))
+}
+
+func (h *InspectionHandler) ListByVehicle(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	inspections, err := h.inspectionService.ListByVehicle(c.Request.Context(), uint(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	responseList := make([]response.InspectionResponse, len(inspections))
+	for i, inspection := range inspections {
+		responseList[i] = *toInspectionResponse(inspection)
+	}
+
+	c.JSON(http.StatusOK, response.InspectionListResponse{
+		Inspections: responseList,
+		Pagination:  response.Pagination{Total: int64(len(inspections))},
+	})
+}
+
+func (h *InspectionHandler) RecordResults(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	var req request.RecordInspectionResultsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	results := make([]input.RecordResultInput, len(req.Results))
+	for i, r := range req.Results {
+		results[i] = input.RecordResultInput{
+			ItemID:  r.ItemID,
+			Result:  r.Result,
+			Value:   r.Value,
+			PhotoID: r.PhotoID,
+			Notes:   r.Notes,
+		}
+	}
+
+	inspection, err := h.inspectionService.RecordResults(c.Request.Context(), uint(id), results)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toInspectionResponse(inspection))
+}
+
+func (h *InspectionHandler) Complete(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	var req request.CompleteInspectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	inspection, err := h.inspectionService.Complete(c.Request.Context(), uint(id), req.Notes)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toInspectionResponse(inspection))
+}
+
+func toInspectionSectionInputs(list []request.InspectionSectionRequest) []input.InspectionSectionInput {
+	sections := make([]input.InspectionSectionInput, len(list))
+	for i, s := range list {
+		items := make([]input.InspectionItemInput, len(s.Items))
+		for j, item := range s.Items {
+			items[j] = input.InspectionItemInput{
+				Label:         item.Label,
+				Required:      item.Required,
+				PhotoRequired: item.PhotoRequired,
+				Unit:          item.Unit,
+				MinValue:      item.MinValue,
+				MaxValue:      item.MaxValue,
+			}
+		}
+		sections[i] = input.InspectionSectionInput{Name: s.Name, Items: items}
+	}
+	return sections
+}
+
+func toInspectionTemplateResponse(t *domain.InspectionTemplate) *response.InspectionTemplateResponse {
+	sections := make([]response.InspectionSectionResponse, len(t.Sections))
+	for i, s := range t.Sections {
+		items := make([]response.InspectionItemResponse, len(s.Items))
+		for j, item := range s.Items {
+			items[j] = response.InspectionItemResponse{
+				ID:            item.ID,
+				Label:         item.Label,
+				Required:      item.Required,
+				PhotoRequired: item.PhotoRequired,
+				Unit:          item.Unit,
+				MinValue:      item.MinValue,
+				MaxValue:      item.MaxValue,
+				SortOrder:     item.SortOrder,
+			}
+		}
+		sections[i] = response.InspectionSectionResponse{
+			ID:        s.ID,
+			Name:      s.Name,
+			SortOrder: s.SortOrder,
+			Items:     items,
+		}
+	}
+
+	return &response.InspectionTemplateResponse{
+		ID:          t.ID,
+		Name:        t.Name,
+		Kind:        string(t.Kind),
+		Description: t.Description,
+		Active:      t.Active,
+		Sections:    sections,
+		Version:     t.Version,
+		CreatedAt:   t.CreatedAt,
+	}
+}
+
+func toInspectionResponse(i *domain.Inspection) *response.InspectionResponse {
+	results := make([]response.InspectionResultResponse, len(i.Results))
+	for j, r := range i.Results {
+		results[j] = response.InspectionResultResponse{
+			ID:         r.ID,
+			ItemID:     r.ItemID,
+			Result:     string(r.Result),
+			Value:      r.Value,
+			PhotoID:    r.PhotoID,
+			Notes:      r.Notes,
+			RecordedAt: r.RecordedAt,
+		}
+	}
+
+	return &response.InspectionResponse{
+		ID:          i.ID,
+		VehicleID:   i.VehicleID,
+		TemplateID:  i.TemplateID,
+		Kind:        string(i.Kind),
+		InspectorID: i.InspectorID,
+		Status:      string(i.Status),
+		Passed:      i.Passed,
+		Notes:       i.Notes,
+		Results:     results,
+		StartedAt:   i.StartedAt,
+		CompletedAt: i.CompletedAt,
+		Version:     i.Version,
+	}
+}
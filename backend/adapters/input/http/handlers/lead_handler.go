@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -28,11 +29,11 @@ func NewLeadHandler(leadService input.LeadService, stepService input.StepService
 func (h *LeadHandler) Create(c *gin.Context) {
 	var req request.CreateLeadRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		badRequest(c, err)
 		return
 	}
 
-	lead, err := h.leadService.Create(input.CreateLeadInput{
+	lead, err := h.leadService.Create(c.Request.Context(), input.CreateLeadInput{
 		EntityID:      req.EntityID,
 		VehicleID:     req.VehicleID,
 		InterestType:  req.InterestType,
@@ -46,13 +47,13 @@ func (h *LeadHandler) Create(c *gin.Context) {
 		AssignedTo:    req.AssignedTo,
 	})
 	if err != nil {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	// Inicializar progreso si hay preset
 	if req.PresetID != nil {
-		h.stepService.InitializeProgress(lead.ID, *req.PresetID)
+		h.stepService.InitializeProgress(c.Request.Context(), lead.ID, *req.PresetID)
 	}
 
 	c.JSON(http.StatusCreated, toLeadResponse(lead))
@@ -61,54 +62,64 @@ func (h *LeadHandler) Create(c *gin.Context) {
 func (h *LeadHandler) GetByID(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		badRequest(c, errors.New("invalid id"))
 		return
 	}
 
-	lead, err := h.leadService.GetByID(uint(id))
+	lead, err := h.leadService.GetByID(c.Request.Context(), uint(id))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "lead not found"})
+		c.Error(err)
 		return
 	}
 
+	setETag(c, lead.Version)
 	c.JSON(http.StatusOK, toLeadResponse(lead))
 }
 
 func (h *LeadHandler) List(c *gin.Context) {
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
-	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	q, err := parseQuery(c)
+	if err != nil {
+		badRequest(c, err)
+		return
+	}
 
-	leads, err := h.leadService.List(limit, offset)
+	leads, err := h.leadService.List(c.Request.Context(), q)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
-	responseList := make([]response.LeadResponse, len(leads))
-	for i, lead := range leads {
+	responseList := make([]response.LeadResponse, len(leads.Items))
+	for i, lead := range leads.Items {
 		responseList[i] = *toLeadResponse(lead)
 	}
 
 	c.JSON(http.StatusOK, response.LeadListResponse{
-		Leads: responseList,
-		Total: len(responseList),
+		Leads:      responseList,
+		Pagination: toPagination(leads),
 	})
 }
 
 func (h *LeadHandler) Update(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	version, err := ifMatch(c)
+	if err != nil {
+		badRequest(c, err)
 		return
 	}
 
 	var req request.UpdateLeadRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		badRequest(c, err)
 		return
 	}
 
-	lead, err := h.leadService.Update(uint(id), input.UpdateLeadInput{
+	lead, err := h.leadService.Update(c.Request.Context(), uint(id), input.UpdateLeadInput{
 		VehicleID:     req.VehicleID,
 		InterestType:  req.InterestType,
 		InterestMake:  req.InterestMake,
@@ -116,46 +127,88 @@ func (h *LeadHandler) Update(c *gin.Context) {
 		BudgetMin:     req.BudgetMin,
 		BudgetMax:     req.BudgetMax,
 		SourceDetail:  req.SourceDetail,
+		Version:       version,
 	})
 	if err != nil {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
+	setETag(c, lead.Version)
 	c.JSON(http.StatusOK, toLeadResponse(lead))
 }
 
 func (h *LeadHandler) Delete(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		badRequest(c, errors.New("invalid id"))
 		return
 	}
 
-	if err := h.leadService.Delete(uint(id)); err != nil {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	if err := h.leadService.Delete(c.Request.Context(), uint(id)); err != nil {
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "lead deleted successfully"})
 }
 
+func (h *LeadHandler) ListDeleted(c *gin.Context) {
+	q, err := parseQuery(c)
+	if err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	leads, err := h.leadService.ListDeleted(c.Request.Context(), q)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	responseList := make([]response.LeadResponse, len(leads.Items))
+	for i, lead := range leads.Items {
+		responseList[i] = *toLeadResponse(lead)
+	}
+
+	c.JSON(http.StatusOK, response.LeadListResponse{
+		Leads:      responseList,
+		Pagination: toPagination(leads),
+	})
+}
+
+func (h *LeadHandler) Restore(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	lead, err := h.leadService.Restore(c.Request.Context(), uint(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toLeadResponse(lead))
+}
+
 // Sources
 
 func (h *LeadHandler) CreateSource(c *gin.Context) {
 	var req request.CreateLeadSourceRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		badRequest(c, err)
 		return
 	}
 
-	source, err := h.leadService.CreateSource(input.CreateLeadSourceInput{
+	source, err := h.leadService.CreateSource(c.Request.Context(), input.CreateLeadSourceInput{
 		Code:       req.Code,
 		Name:       req.Name,
 		IsExternal: req.IsExternal,
 	})
 	if err != nil {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -163,9 +216,9 @@ func (h *LeadHandler) CreateSource(c *gin.Context) {
 }
 
 func (h *LeadHandler) GetSources(c *gin.Context) {
-	sources, err := h.leadService.GetSources()
+	sources, err := h.leadService.GetSources(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -181,9 +234,9 @@ func (h *LeadHandler) GetSources(c *gin.Context) {
 }
 
 func (h *LeadHandler) GetActiveSources(c *gin.Context) {
-	sources, err := h.leadService.GetActiveSources()
+	sources, err := h.leadService.GetActiveSources(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -201,12 +254,12 @@ func (h *LeadHandler) GetActiveSources(c *gin.Context) {
 func (h *LeadHandler) DeactivateSource(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		badRequest(c, errors.New("invalid id"))
 		return
 	}
 
-	if err := h.leadService.DeactivateSource(uint(id)); err != nil {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	if err := h.leadService.DeactivateSource(c.Request.Context(), uint(id)); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -216,12 +269,12 @@ func (h *LeadHandler) DeactivateSource(c *gin.Context) {
 func (h *LeadHandler) ActivateSource(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		badRequest(c, errors.New("invalid id"))
 		return
 	}
 
-	if err := h.leadService.ActivateSource(uint(id)); err != nil {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	if err := h.leadService.ActivateSource(c.Request.Context(), uint(id)); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -233,19 +286,19 @@ func (h *LeadHandler) ActivateSource(c *gin.Context) {
 func (h *LeadHandler) Assign(c *gin.Context) {
 	leadID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		badRequest(c, errors.New("invalid id"))
 		return
 	}
 
 	var req request.AssignLeadRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		badRequest(c, err)
 		return
 	}
 
 	assignedBy, _ := c.Get("entity_id")
 
-	assignment, err := h.leadService.Assign(input.AssignLeadInput{
+	assignment, err := h.leadService.Assign(c.Request.Context(), input.AssignLeadInput{
 		LeadID:     uint(leadID),
 		EntityID:   req.EntityID,
 		Role:       req.Role,
@@ -253,7 +306,7 @@ func (h *LeadHandler) Assign(c *gin.Context) {
 		AssignedBy: assignedBy.(uint),
 	})
 	if err != nil {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -263,13 +316,13 @@ func (h *LeadHandler) Assign(c *gin.Context) {
 func (h *LeadHandler) GetAssignments(c *gin.Context) {
 	leadID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		badRequest(c, errors.New("invalid id"))
 		return
 	}
 
-	assignments, err := h.leadService.GetAssignments(uint(leadID))
+	assignments, err := h.leadService.GetAssignments(c.Request.Context(), uint(leadID))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -287,12 +340,12 @@ func (h *LeadHandler) GetAssignments(c *gin.Context) {
 func (h *LeadHandler) RemoveAssignment(c *gin.Context) {
 	assignmentID, err := strconv.ParseUint(c.Param("assignmentId"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid assignment id"})
+		badRequest(c, errors.New("invalid assignment id"))
 		return
 	}
 
-	if err := h.leadService.RemoveAssignment(uint(assignmentID)); err != nil {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	if err := h.leadService.RemoveAssignment(c.Request.Context(), uint(assignmentID)); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -302,18 +355,18 @@ func (h *LeadHandler) RemoveAssignment(c *gin.Context) {
 func (h *LeadHandler) SetPrimaryAssignment(c *gin.Context) {
 	leadID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		badRequest(c, errors.New("invalid id"))
 		return
 	}
 
 	var req request.SetPrimaryAssignmentRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		badRequest(c, err)
 		return
 	}
 
-	if err := h.leadService.SetPrimaryAssignment(uint(leadID), req.AssignmentID); err != nil {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	if err := h.leadService.SetPrimaryAssignment(c.Request.Context(), uint(leadID), req.AssignmentID); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -325,25 +378,25 @@ func (h *LeadHandler) SetPrimaryAssignment(c *gin.Context) {
 func (h *LeadHandler) AddNote(c *gin.Context) {
 	leadID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		badRequest(c, errors.New("invalid id"))
 		return
 	}
 
 	var req request.AddNoteRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		badRequest(c, err)
 		return
 	}
 
 	createdBy, _ := c.Get("entity_id")
 
-	note, err := h.leadService.AddNote(input.AddNoteInput{
+	note, err := h.leadService.AddNote(c.Request.Context(), input.AddNoteInput{
 		LeadID:    uint(leadID),
 		Content:   req.Content,
 		CreatedBy: createdBy.(uint),
 	})
 	if err != nil {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -353,13 +406,13 @@ func (h *LeadHandler) AddNote(c *gin.Context) {
 func (h *LeadHandler) GetNotes(c *gin.Context) {
 	leadID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		badRequest(c, errors.New("invalid id"))
 		return
 	}
 
-	notes, err := h.leadService.GetNotes(uint(leadID))
+	notes, err := h.leadService.GetNotes(c.Request.Context(), uint(leadID))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -377,19 +430,19 @@ func (h *LeadHandler) GetNotes(c *gin.Context) {
 func (h *LeadHandler) UpdateNote(c *gin.Context) {
 	noteID, err := strconv.ParseUint(c.Param("noteId"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid note id"})
+		badRequest(c, errors.New("invalid note id"))
 		return
 	}
 
 	var req request.UpdateNoteRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		badRequest(c, err)
 		return
 	}
 
-	note, err := h.leadService.UpdateNote(uint(noteID), req.Content)
+	note, err := h.leadService.UpdateNote(c.Request.Context(), uint(noteID), req.Content)
 	if err != nil {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -399,12 +452,12 @@ func (h *LeadHandler) UpdateNote(c *gin.Context) {
 func (h *LeadHandler) DeleteNote(c *gin.Context) {
 	noteID, err := strconv.ParseUint(c.Param("noteId"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid note id"})
+		badRequest(c, errors.New("invalid note id"))
 		return
 	}
 
-	if err := h.leadService.DeleteNote(uint(noteID)); err != nil {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	if err := h.leadService.DeleteNote(c.Request.Context(), uint(noteID)); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -416,19 +469,19 @@ func (h *LeadHandler) DeleteNote(c *gin.Context) {
 func (h *LeadHandler) AddActivity(c *gin.Context) {
 	leadID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		badRequest(c, errors.New("invalid id"))
 		return
 	}
 
 	var req request.AddActivityRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		badRequest(c, err)
 		return
 	}
 
 	performedBy, _ := c.Get("entity_id")
 
-	activity, err := h.leadService.AddActivity(input.AddActivityInput{
+	activity, err := h.leadService.AddActivity(c.Request.Context(), input.AddActivityInput{
 		LeadID:      uint(leadID),
 		Type:        req.Type,
 		Description: req.Description,
@@ -439,7 +492,7 @@ func (h *LeadHandler) AddActivity(c *gin.Context) {
 		ScheduledAt: req.ScheduledAt,
 	})
 	if err != nil {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -449,36 +502,42 @@ func (h *LeadHandler) AddActivity(c *gin.Context) {
 func (h *LeadHandler) GetActivities(c *gin.Context) {
 	leadID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		badRequest(c, errors.New("invalid id"))
 		return
 	}
 
-	activities, err := h.leadService.GetActivities(uint(leadID))
+	q, err := parseQuery(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		badRequest(c, err)
 		return
 	}
 
-	responseList := make([]response.LeadActivityResponse, len(activities))
-	for i, a := range activities {
+	activities, err := h.leadService.GetActivities(c.Request.Context(), uint(leadID), q)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	responseList := make([]response.LeadActivityResponse, len(activities.Items))
+	for i, a := range activities.Items {
 		responseList[i] = *toLeadActivityResponse(a)
 	}
 
 	c.JSON(http.StatusOK, response.LeadActivityListResponse{
 		Activities: responseList,
-		Total:      len(responseList),
+		Pagination: toPagination(activities),
 	})
 }
 
 func (h *LeadHandler) CompleteActivity(c *gin.Context) {
 	activityID, err := strconv.ParseUint(c.Param("activityId"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid activity id"})
+		badRequest(c, errors.New("invalid activity id"))
 		return
 	}
 
-	if err := h.leadService.CompleteActivity(uint(activityID)); err != nil {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	if err := h.leadService.CompleteActivity(c.Request.Context(), uint(activityID)); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -488,9 +547,9 @@ func (h *LeadHandler) CompleteActivity(c *gin.Context) {
 func (h *LeadHandler) GetMyScheduledActivities(c *gin.Context) {
 	entityID, _ := c.Get("entity_id")
 
-	activities, err := h.leadService.GetScheduledActivities(entityID.(uint))
+	activities, err := h.leadService.GetScheduledActivities(c.Request.Context(), entityID.(uint))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -501,14 +560,14 @@ func (h *LeadHandler) GetMyScheduledActivities(c *gin.Context) {
 
 	c.JSON(http.StatusOK, response.LeadActivityListResponse{
 		Activities: responseList,
-		Total:      len(responseList),
+		Pagination: response.Pagination{Total: int64(len(responseList))},
 	})
 }
 
 func (h *LeadHandler) GetOverdueActivities(c *gin.Context) {
-	activities, err := h.leadService.GetOverdueActivities()
+	activities, err := h.leadService.GetOverdueActivities(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -519,7 +578,7 @@ func (h *LeadHandler) GetOverdueActivities(c *gin.Context) {
 
 	c.JSON(http.StatusOK, response.LeadActivityListResponse{
 		Activities: responseList,
-		Total:      len(responseList),
+		Pagination: response.Pagination{Total: int64(len(responseList))},
 	})
 }
 
@@ -528,13 +587,13 @@ func (h *LeadHandler) GetOverdueActivities(c *gin.Context) {
 func (h *LeadHandler) GetProgress(c *gin.Context) {
 	leadID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		badRequest(c, errors.New("invalid id"))
 		return
 	}
 
-	progress, err := h.stepService.GetProgress(uint(leadID))
+	progress, err := h.stepService.GetProgress(c.Request.Context(), uint(leadID))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -552,25 +611,25 @@ func (h *LeadHandler) GetProgress(c *gin.Context) {
 func (h *LeadHandler) UpdateProgress(c *gin.Context) {
 	leadID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		badRequest(c, errors.New("invalid id"))
 		return
 	}
 
 	stepID, err := strconv.ParseUint(c.Param("stepId"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid step id"})
+		badRequest(c, errors.New("invalid step id"))
 		return
 	}
 
 	var req request.UpdateProgressRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		badRequest(c, err)
 		return
 	}
 
 	completedBy, _ := c.Get("entity_id")
 
-	progress, err := h.stepService.UpdateProgress(input.UpdateProgressInput{
+	progress, err := h.stepService.UpdateProgress(c.Request.Context(), input.UpdateProgressInput{
 		LeadID:      uint(leadID),
 		StepID:      uint(stepID),
 		Status:      req.Status,
@@ -578,7 +637,7 @@ func (h *LeadHandler) UpdateProgress(c *gin.Context) {
 		Notes:       req.Notes,
 	})
 	if err != nil {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -600,6 +659,9 @@ func toLeadResponse(l *domain.Lead) *response.LeadResponse {
 		SourceID:      l.SourceID,
 		SourceDetail:  l.SourceDetail,
 		PresetID:      l.PresetID,
+		DeletedAt:     l.DeletedAt,
+		DeletedBy:     l.DeletedBy,
+		Version:       l.Version,
 		CreatedAt:     l.CreatedAt,
 		ModifiedAt:    l.ModifiedAt,
 	}
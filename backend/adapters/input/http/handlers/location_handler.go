@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"errors"
+	"math"
 	"net/http"
 	"strconv"
 
@@ -9,24 +11,31 @@ import (
 	"torque-dms/adapters/input/http/dto/response"
 	"torque-dms/core/inventory/domain"
 	"torque-dms/core/inventory/ports/input"
+	sharedDomain "torque-dms/core/shared/domain"
 )
 
 type LocationHandler struct {
-	locationService input.LocationService
+	locationService  input.LocationService
+	occupancyService input.OccupancyService
+	geoService       input.GeoService
 }
 
-func NewLocationHandler(locationService input.LocationService) *LocationHandler {
-	return &LocationHandler{locationService: locationService}
+func NewLocationHandler(locationService input.LocationService, occupancyService input.OccupancyService, geoService input.GeoService) *LocationHandler {
+	return &LocationHandler{
+		locationService:  locationService,
+		occupancyService: occupancyService,
+		geoService:       geoService,
+	}
 }
 
 func (h *LocationHandler) Create(c *gin.Context) {
 	var req request.CreateLocationRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		badRequest(c, err)
 		return
 	}
 
-	location, err := h.locationService.Create(input.CreateLocationInput{
+	location, err := h.locationService.Create(c.Request.Context(), input.CreateLocationInput{
 		Name:      req.Name,
 		Type:      req.Type,
 		Address:   req.Address,
@@ -39,79 +48,214 @@ func (h *LocationHandler) Create(c *gin.Context) {
 		Capacity:  req.Capacity,
 	})
 	if err != nil {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusCreated, toLocationResponse(location))
 }
 
+func (h *LocationHandler) Nearest(c *gin.Context) {
+	var req request.NearestLocationsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	origin, err := toGeoOrigin(req.GeoOriginRequest)
+	if err != nil {
+		badRequest(c, err)
+		return
+	}
+	if origin == nil {
+		badRequest(c, errors.New("lat and lng, zip or from_location_id are required"))
+		return
+	}
+
+	nearest, err := h.geoService.NearestLocations(c.Request.Context(), *origin, req.Type, req.Limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	responseList := make([]response.LocationDistanceResponse, len(nearest))
+	for i, d := range nearest {
+		responseList[i] = toLocationDistanceResponse(d)
+	}
+
+	c.JSON(http.StatusOK, response.NearestLocationsResponse{
+		Locations:  responseList,
+		Pagination: response.Pagination{Total: int64(len(responseList))},
+	})
+}
+
+func (h *LocationHandler) Distance(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	var req request.GeoOriginRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	origin, err := toGeoOrigin(req)
+	if err != nil {
+		badRequest(c, err)
+		return
+	}
+	if origin == nil {
+		badRequest(c, errors.New("lat and lng, zip or from_location_id are required"))
+		return
+	}
+
+	distance, err := h.geoService.DistanceToLocation(c.Request.Context(), uint(id), *origin)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toLocationDistanceResponse(distance))
+}
+
+func (h *LocationHandler) GetOccupancy(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	occupancy, err := h.occupancyService.GetOccupancy(c.Request.Context(), uint(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toOccupancyResponse(occupancy))
+}
+
+func (h *LocationHandler) GetOccupancySummary(c *gin.Context) {
+	summary, err := h.occupancyService.GetSummary(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	locations := make([]response.LocationOccupancyResponse, len(summary.Locations))
+	for i, occupancy := range summary.Locations {
+		locations[i] = toOccupancyResponse(occupancy)
+	}
+
+	alerts := make([]response.OccupancyAlertResponse, 0)
+	for _, occupancy := range summary.Alerts() {
+		alerts = append(alerts, response.OccupancyAlertResponse{
+			LocationID:  occupancy.Location.ID,
+			Name:        occupancy.Location.Name,
+			Alert:       string(occupancy.Alert()),
+			Utilization: occupancy.Utilization(),
+			Overflow:    occupancy.Overflow(),
+		})
+	}
+
+	capacity, occupied, overflow := summary.Totals()
+	c.JSON(http.StatusOK, response.OccupancySummaryResponse{
+		Locations:   locations,
+		Alerts:      alerts,
+		Capacity:    capacity,
+		Occupied:    occupied,
+		Overflow:    overflow,
+		Utilization: summary.Utilization(),
+	})
+}
+
 func (h *LocationHandler) GetByID(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		badRequest(c, errors.New("invalid id"))
 		return
 	}
 
-	location, err := h.locationService.GetByID(uint(id))
+	location, err := h.locationService.GetByID(c.Request.Context(), uint(id))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "location not found"})
+		c.Error(err)
 		return
 	}
 
+	setETag(c, location.Version)
 	c.JSON(http.StatusOK, toLocationResponse(location))
 }
 
 func (h *LocationHandler) List(c *gin.Context) {
-	locations, err := h.locationService.List()
+	q, err := parseQuery(c)
+	if err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	locations, err := h.locationService.List(c.Request.Context(), q)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
-	responseList := make([]response.LocationResponse, len(locations))
-	for i, location := range locations {
+	responseList := make([]response.LocationResponse, len(locations.Items))
+	for i, location := range locations.Items {
 		responseList[i] = *toLocationResponse(location)
 	}
 
 	c.JSON(http.StatusOK, response.LocationListResponse{
-		Locations: responseList,
-		Total:     len(responseList),
+		Locations:  responseList,
+		Pagination: toPagination(locations),
 	})
 }
 
 func (h *LocationHandler) ListActive(c *gin.Context) {
-	locations, err := h.locationService.ListActive()
+	q, err := parseQuery(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		badRequest(c, err)
 		return
 	}
 
-	responseList := make([]response.LocationResponse, len(locations))
-	for i, location := range locations {
+	locations, err := h.locationService.ListActive(c.Request.Context(), q)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	responseList := make([]response.LocationResponse, len(locations.Items))
+	for i, location := range locations.Items {
 		responseList[i] = *toLocationResponse(location)
 	}
 
 	c.JSON(http.StatusOK, response.LocationListResponse{
-		Locations: responseList,
-		Total:     len(responseList),
+		Locations:  responseList,
+		Pagination: toPagination(locations),
 	})
 }
 
 func (h *LocationHandler) Update(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	version, err := ifMatch(c)
+	if err != nil {
+		badRequest(c, err)
 		return
 	}
 
 	var req request.UpdateLocationRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		badRequest(c, err)
 		return
 	}
 
-	location, err := h.locationService.Update(uint(id), input.UpdateLocationInput{
+	location, err := h.locationService.Update(c.Request.Context(), uint(id), input.UpdateLocationInput{
 		Name:      req.Name,
 		Address:   req.Address,
 		City:      req.City,
@@ -120,24 +264,59 @@ func (h *LocationHandler) Update(c *gin.Context) {
 		Latitude:  req.Latitude,
 		Longitude: req.Longitude,
 		Capacity:  req.Capacity,
+		Version:   version,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	setETag(c, location.Version)
+	c.JSON(http.StatusOK, toLocationResponse(location))
+}
+
+func (h *LocationHandler) SetGeofence(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	version, err := ifMatch(c)
+	if err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	var req request.SetGeofenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	location, err := h.locationService.SetGeofence(c.Request.Context(), uint(id), input.SetGeofenceInput{
+		RadiusM: req.RadiusM,
+		Polygon: toWaypoints(req.Polygon),
+		Version: version,
 	})
 	if err != nil {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
+	setETag(c, location.Version)
 	c.JSON(http.StatusOK, toLocationResponse(location))
 }
 
 func (h *LocationHandler) Delete(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		badRequest(c, errors.New("invalid id"))
 		return
 	}
 
-	if err := h.locationService.Delete(uint(id)); err != nil {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	if err := h.locationService.Delete(c.Request.Context(), uint(id)); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -147,12 +326,12 @@ func (h *LocationHandler) Delete(c *gin.Context) {
 func (h *LocationHandler) Deactivate(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		badRequest(c, errors.New("invalid id"))
 		return
 	}
 
-	if err := h.locationService.Deactivate(uint(id)); err != nil {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	if err := h.locationService.Deactivate(c.Request.Context(), uint(id)); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -162,12 +341,12 @@ func (h *LocationHandler) Deactivate(c *gin.Context) {
 func (h *LocationHandler) Activate(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		badRequest(c, errors.New("invalid id"))
 		return
 	}
 
-	if err := h.locationService.Activate(uint(id)); err != nil {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	if err := h.locationService.Activate(c.Request.Context(), uint(id)); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -176,6 +355,64 @@ func (h *LocationHandler) Activate(c *gin.Context) {
 
 // Helper
 
+func toLocationDistanceResponse(d *domain.LocationDistance) response.LocationDistanceResponse {
+	return response.LocationDistanceResponse{
+		Location:   *toLocationResponse(d.Location),
+		DistanceKM: roundKM(d.DistanceKM),
+	}
+}
+
+// toGeoOrigin - nil si la request no trae ningún origen
+func toGeoOrigin(req request.GeoOriginRequest) (*domain.GeoOrigin, error) {
+	if (req.Lat == nil) != (req.Lng == nil) {
+		return nil, errors.New("lat and lng must be sent together")
+	}
+
+	origin := &domain.GeoOrigin{
+		PostalCode: req.Zip,
+		Country:    req.Country,
+		LocationID: req.FromLocationID,
+	}
+	if req.Lat != nil {
+		origin.Point = &sharedDomain.GeoPoint{Latitude: *req.Lat, Longitude: *req.Lng}
+	}
+	if origin.IsZero() {
+		return nil, nil
+	}
+	return origin, nil
+}
+
+func roundKM(km float64) float64 {
+	return math.Round(km*100) / 100
+}
+
+func toOccupancyResponse(o *domain.LocationOccupancy) response.LocationOccupancyResponse {
+	byStatus := make(map[string]int64, len(o.ByStatus))
+	for status, count := range o.ByStatus {
+		byStatus[string(status)] = count
+	}
+	byLotType := make(map[string]int64, len(o.ByLotType))
+	for lotType, count := range o.ByLotType {
+		byLotType[string(lotType)] = count
+	}
+
+	return response.LocationOccupancyResponse{
+		LocationID:  o.Location.ID,
+		Name:        o.Location.Name,
+		Type:        string(o.Location.Type),
+		Active:      o.Location.Active,
+		Capacity:    o.Location.Capacity,
+		Parked:      o.Parked,
+		Inbound:     o.Inbound,
+		Occupied:    o.Occupied(),
+		Overflow:    o.Overflow(),
+		Utilization: o.Utilization(),
+		Alert:       string(o.Alert()),
+		ByStatus:    byStatus,
+		ByLotType:   byLotType,
+	}
+}
+
 func toLocationResponse(l *domain.Location) *response.LocationResponse {
 	return &response.LocationResponse{
 		ID:        l.ID,
@@ -189,7 +426,20 @@ func toLocationResponse(l *domain.Location) *response.LocationResponse {
 		Latitude:  l.Latitude,
 		Longitude: l.Longitude,
 		Capacity:  l.Capacity,
+		Geofence:  toGeofenceResponse(l.Geofence),
 		Active:    l.Active,
+		Version:   l.Version,
 		CreatedAt: l.CreatedAt,
 	}
+}
+
+func toGeofenceResponse(g *domain.Geofence) *response.GeofenceResponse {
+	if g == nil {
+		return nil
+	}
+	polygon := make([]response.WaypointResponse, len(g.Polygon))
+	for i, p := range g.Polygon {
+		polygon[i] = response.WaypointResponse{Latitude: p.Latitude, Longitude: p.Longitude}
+	}
+	return &response.GeofenceResponse{RadiusM: g.RadiusM, Polygon: polygon}
 }
\ No newline at end of file
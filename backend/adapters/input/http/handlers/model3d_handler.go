@@ -0,0 +1,373 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"torque-dms/adapters/input/http/dto/request"
+	"torque-dms/adapters/input/http/dto/response"
+	"torque-dms/core/inventory/domain"
+	"torque-dms/core/inventory/ports/input"
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+type Model3DHandler struct {
+	model3DService input.Model3DService
+}
+
+func NewModel3DHandler(model3DService input.Model3DService) *Model3DHandler {
+	return &Model3DHandler{model3DService: model3DService}
+}
+
+func (h *Model3DHandler) Create(c *gin.Context) {
+	var req request.CreateModel3DRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	model, err := h.model3DService.Create(c.Request.Context(), input.CreateModel3DInput{
+		Name:     req.Name,
+		BodyType: req.BodyType,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toModel3DResponse(model))
+}
+
+func (h *Model3DHandler) GetByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	model, err := h.model3DService.GetByID(c.Request.Context(), uint(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	setETag(c, model.Version)
+	c.JSON(http.StatusOK, toModel3DResponse(model))
+}
+
+func (h *Model3DHandler) List(c *gin.Context) {
+	q, err := parseQuery(c)
+	if err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	models, err := h.model3DService.List(c.Request.Context(), q)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	responseList := make([]response.Model3DResponse, len(models.Items))
+	for i, model := range models.Items {
+		responseList[i] = *toModel3DResponse(model)
+	}
+
+	c.JSON(http.StatusOK, response.Model3DListResponse{
+		Models:     responseList,
+		Pagination: toPagination(models),
+	})
+}
+
+func (h *Model3DHandler) Update(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	version, err := ifMatch(c)
+	if err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	var req request.UpdateModel3DRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	model, err := h.model3DService.Update(c.Request.Context(), uint(id), input.UpdateModel3DInput{
+		Name:    req.Name,
+		Version: version,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	setETag(c, model.Version)
+	c.JSON(http.StatusOK, toModel3DResponse(model))
+}
+
+func (h *Model3DHandler) Delete(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	if err := h.model3DService.Delete(c.Request.Context(), uint(id)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "model deleted successfully"})
+}
+
+func (h *Model3DHandler) Deactivate(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	if err := h.model3DService.Deactivate(c.Request.Context(), uint(id)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "model deactivated"})
+}
+
+func (h *Model3DHandler) Activate(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	if err := h.model3DService.Activate(c.Request.Context(), uint(id)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "model activated"})
+}
+
+func (h *Model3DHandler) SetDefault(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	model, err := h.model3DService.SetDefault(c.Request.Context(), uint(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toModel3DResponse(model))
+}
+
+// multipartOverhead - margen para las cabeceras y boundaries del multipart sobre el archivo
+const multipartOverhead = 64 << 10
+
+// UploadFile - multipart con el campo "file"; la ruta decide si es la malla o la miniatura
+func (h *Model3DHandler) UploadFile(kind domain.ModelFileKind) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+		if err != nil {
+			badRequest(c, errors.New("invalid id"))
+			return
+		}
+
+		// Cortar el body antes de parsear: FormFile vuelca a disco todo lo que no cabe en memoria
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, domain.MaxModelUploadBytes(kind)+multipartOverhead)
+		header, err := c.FormFile("file")
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				c.Error(sharedDomain.Invalid("file", "file is too large"))
+				return
+			}
+			badRequest(c, errors.New("file is required"))
+			return
+		}
+		file, err := header.Open()
+		if err != nil {
+			badRequest(c, err)
+			return
+		}
+		defer file.Close()
+
+		model, err := h.model3DService.UploadFile(c.Request.Context(), input.UploadModelFileInput{
+			Model3DID: uint(id),
+			Kind:      string(kind),
+			Filename:  header.Filename,
+			Size:      header.Size,
+			Content:   file,
+		})
+		if err != nil {
+			c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, toModel3DResponse(model))
+	}
+}
+
+func (h *Model3DHandler) AssignToVehicle(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	var req request.AssignModel3DRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	vehicle, err := h.model3DService.AssignToVehicle(c.Request.Context(), uint(id), req.Model3DID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	setETag(c, vehicle.Version)
+	c.JSON(http.StatusOK, toVehicleResponse(vehicle))
+}
+
+func (h *Model3DHandler) ListZones(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	zones, err := h.model3DService.ListZones(c.Request.Context(), uint(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	responseList := make([]response.ModelZoneResponse, len(zones))
+	for i, zone := range zones {
+		responseList[i] = *toModelZoneResponse(zone)
+	}
+
+	c.JSON(http.StatusOK, response.ModelZoneListResponse{
+		Zones:      responseList,
+		Pagination: response.Pagination{Total: int64(len(zones))},
+	})
+}
+
+func (h *Model3DHandler) AddZone(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	var req request.ModelZoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	zone, err := h.model3DService.AddZone(c.Request.Context(), uint(id), toModelZoneInput(req))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toModelZoneResponse(zone))
+}
+
+func (h *Model3DHandler) UpdateZone(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	zoneID, err := strconv.ParseUint(c.Param("zoneId"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid zone id"))
+		return
+	}
+
+	var req request.ModelZoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	zone, err := h.model3DService.UpdateZone(c.Request.Context(), uint(id), uint(zoneID), toModelZoneInput(req))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toModelZoneResponse(zone))
+}
+
+func (h *Model3DHandler) DeleteZone(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	zoneID, err := strconv.ParseUint(c.Param("zoneId"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid zone id"))
+		return
+	}
+
+	if err := h.model3DService.DeleteZone(c.Request.Context(), uint(id), uint(zoneID)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "zone deleted successfully"})
+}
+
+func toModelZoneInput(req request.ModelZoneRequest) input.ModelZoneInput {
+	return input.ModelZoneInput{
+		Code:   req.Code,
+		Name:   req.Name,
+		MeshID: req.MeshID,
+	}
+}
+
+func toModel3DResponse(m *domain.VehicleModel3D) *response.Model3DResponse {
+	return &response.Model3DResponse{
+		ID:           m.ID,
+		Name:         m.Name,
+		BodyType:     string(m.BodyType),
+		FileURL:      m.FileURL,
+		ThumbnailURL: m.ThumbnailURL,
+		IsDefault:    m.IsDefault,
+		Active:       m.Active,
+		Version:      m.Version,
+		CreatedAt:    m.CreatedAt,
+	}
+}
+
+func toModelZoneResponse(z *domain.VehicleModelZone) *response.ModelZoneResponse {
+	return &response.ModelZoneResponse{
+		ID:        z.ID,
+		Model3DID: z.Model3DID,
+		Code:      z.Code,
+		Name:      z.Name,
+		MeshID:    z.MeshID,
+		CreatedAt: z.CreatedAt,
+	}
+}
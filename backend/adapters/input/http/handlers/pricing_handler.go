@@ -0,0 +1,275 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"torque-dms/adapters/input/http/dto/request"
+	"torque-dms/adapters/input/http/dto/response"
+	"torque-dms/core/inventory/domain"
+	"torque-dms/core/inventory/ports/input"
+)
+
+type PricingHandler struct {
+	pricingService input.PricingService
+}
+
+func NewPricingHandler(pricingService input.PricingService) *PricingHandler {
+	return &PricingHandler{pricingService: pricingService}
+}
+
+func (h *PricingHandler) GetAging(c *gin.Context) {
+	aging, err := h.pricingService.GetAging(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	buckets := make([]response.AgeBucketResponse, len(aging.Buckets))
+	for i, b := range aging.Buckets {
+		buckets[i] = response.AgeBucketResponse{
+			Bucket:      string(b.Bucket),
+			Vehicles:    b.Vehicles,
+			TotalCost:   b.TotalCost,
+			TotalAsking: b.TotalAsking,
+			AvgDays:     b.AvgDays,
+		}
+	}
+
+	c.JSON(http.StatusOK, response.InventoryAgingResponse{
+		AsOf:     aging.AsOf,
+		Vehicles: aging.Vehicles,
+		AvgDays:  aging.AvgDays,
+		Buckets:  buckets,
+	})
+}
+
+func (h *PricingHandler) GetPriceHistory(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	q, err := parseQuery(c)
+	if err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	history, err := h.pricingService.GetPriceHistory(c.Request.Context(), uint(id), q)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	responseList := make([]response.PriceChangeResponse, len(history.Items))
+	for i, change := range history.Items {
+		responseList[i] = response.PriceChangeResponse{
+			ID:        change.ID,
+			VehicleID: change.VehicleID,
+			OldPrice:  change.OldPrice,
+			NewPrice:  change.NewPrice,
+			Source:    string(change.Source),
+			RuleID:    change.RuleID,
+			ChangedBy: change.ChangedBy,
+			CreatedAt: change.CreatedAt,
+		}
+	}
+
+	c.JSON(http.StatusOK, response.PriceChangeListResponse{
+		Changes:    responseList,
+		Pagination: toPagination(history),
+	})
+}
+
+// Rules
+
+func (h *PricingHandler) ListRules(c *gin.Context) {
+	rules, err := h.pricingService.ListRules(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	responseList := make([]response.RepricingRuleResponse, len(rules))
+	for i, rule := range rules {
+		responseList[i] = *toRepricingRuleResponse(rule)
+	}
+
+	c.JSON(http.StatusOK, response.RepricingRuleListResponse{
+		Rules:      responseList,
+		Pagination: response.Pagination{Total: int64(len(rules))},
+	})
+}
+
+func (h *PricingHandler) GetRule(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	rule, err := h.pricingService.GetRule(c.Request.Context(), uint(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	setETag(c, rule.Version)
+	c.JSON(http.StatusOK, toRepricingRuleResponse(rule))
+}
+
+func (h *PricingHandler) CreateRule(c *gin.Context) {
+	var req request.RepricingRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	rule, err := h.pricingService.CreateRule(c.Request.Context(), toRepricingRuleInput(req, nil))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toRepricingRuleResponse(rule))
+}
+
+func (h *PricingHandler) UpdateRule(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	version, err := ifMatch(c)
+	if err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	var req request.RepricingRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	rule, err := h.pricingService.UpdateRule(c.Request.Context(), uint(id), toRepricingRuleInput(req, version))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	setETag(c, rule.Version)
+	c.JSON(http.StatusOK, toRepricingRuleResponse(rule))
+}
+
+func (h *PricingHandler) ActivateRule(c *gin.Context) {
+	h.changeRule(c, h.pricingService.ActivateRule)
+}
+
+func (h *PricingHandler) DeactivateRule(c *gin.Context) {
+	h.changeRule(c, h.pricingService.DeactivateRule)
+}
+
+func (h *PricingHandler) DeleteRule(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	if err := h.pricingService.DeleteRule(c.Request.Context(), uint(id)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "repricing rule deleted successfully"})
+}
+
+func (h *PricingHandler) PreviewRepricing(c *gin.Context) {
+	var req request.RepricingPreviewRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	proposals, err := h.pricingService.PreviewRepricing(c.Request.Context(), req.RuleID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	responseList := make([]response.RepricingProposalResponse, len(proposals))
+	reduction := 0.0
+	for i, p := range proposals {
+		responseList[i] = response.RepricingProposalResponse{
+			VehicleID:    p.VehicleID,
+			StockNumber:  p.StockNumber,
+			RuleID:       p.RuleID,
+			RuleName:     p.RuleName,
+			DaysInStock:  p.DaysInStock,
+			CurrentPrice: p.CurrentPrice,
+			NewPrice:     p.NewPrice,
+			Floor:        p.Floor,
+			AtFloor:      p.AtFloor,
+		}
+		reduction += p.CurrentPrice - p.NewPrice
+	}
+
+	c.JSON(http.StatusOK, response.RepricingPreviewResponse{
+		Proposals:      responseList,
+		TotalReduction: reduction,
+	})
+}
+
+func (h *PricingHandler) changeRule(c *gin.Context, change func(context.Context, uint) (*domain.RepricingRule, error)) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	rule, err := change(c.Request.Context(), uint(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	setETag(c, rule.Version)
+	c.JSON(http.StatusOK, toRepricingRuleResponse(rule))
+}
+
+func toRepricingRuleInput(req request.RepricingRuleRequest, version *uint) input.RepricingRuleInput {
+	return input.RepricingRuleInput{
+		Name:           req.Name,
+		Condition:      req.Condition,
+		LotType:        req.LotType,
+		MinDaysInStock: req.MinDaysInStock,
+		DropPercent:    req.DropPercent,
+		IntervalDays:   req.IntervalDays,
+		FloorOverCost:  req.FloorOverCost,
+		Version:        version,
+	}
+}
+
+func toRepricingRuleResponse(r *domain.RepricingRule) *response.RepricingRuleResponse {
+	return &response.RepricingRuleResponse{
+		ID:             r.ID,
+		Name:           r.Name,
+		Condition:      string(r.Condition),
+		LotType:        string(r.LotType),
+		MinDaysInStock: r.MinDaysInStock,
+		DropPercent:    r.DropPercent,
+		IntervalDays:   r.IntervalDays,
+		FloorOverCost:  r.FloorOverCost,
+		Active:         r.Active,
+		Version:        r.Version,
+		CreatedAt:      r.CreatedAt,
+		ModifiedAt:     r.ModifiedAt,
+	}
+}
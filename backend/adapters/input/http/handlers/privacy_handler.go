@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"torque-dms/adapters/input/http/dto/response"
+	identityDomain "torque-dms/core/identity/domain"
+	identityOutput "torque-dms/core/identity/ports/output"
+	"torque-dms/core/privacy/domain"
+	"torque-dms/core/privacy/ports/input"
+)
+
+type PrivacyHandler struct {
+	privacyService input.PrivacyService
+}
+
+func NewPrivacyHandler(privacyService input.PrivacyService) *PrivacyHandler {
+	return &PrivacyHandler{privacyService: privacyService}
+}
+
+func (h *PrivacyHandler) Export(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	export, err := h.privacyService.Export(c.Request.Context(), uint(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=entity-%d-export.json", id))
+	c.JSON(http.StatusOK, toDataExportResponse(export))
+}
+
+func (h *PrivacyHandler) Erase(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	result, err := h.privacyService.Erase(c.Request.Context(), uint(id))
+	if err != nil {
+		var holdErr *domain.LegalHoldError
+		if errors.As(err, &holdErr) {
+			holds := make([]response.LegalHoldResponse, len(holdErr.Holds))
+			for i, hold := range holdErr.Holds {
+				holds[i] = response.LegalHoldResponse{
+					Reason:    string(hold.Reason),
+					LeadID:    hold.LeadID,
+					VehicleID: hold.VehicleID,
+					Until:     hold.Until,
+				}
+			}
+			c.Error(err).SetMeta(gin.H{"holds": holds})
+			return
+		}
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.ErasureResponse{
+		EntityID:           result.EntityID,
+		PhonesDeleted:      result.PhonesDeleted,
+		AccountAnonymized:  result.AccountAnonymized,
+		LeadsRedacted:      result.LeadsRedacted,
+		NotesRedacted:      result.NotesRedacted,
+		ActivitiesRedacted: result.ActivitiesRedacted,
+		ErasedAt:           result.ErasedAt,
+	})
+}
+
+// Helpers
+
+func toDataExportResponse(e *input.DataExport) *response.DataExportResponse {
+	resp := &response.DataExportResponse{
+		Entity:      *toEntityResponse(e.Entity),
+		Phones:      make([]response.PhoneResponse, len(e.Phones)),
+		Account:     toUserResponse(e.Account),
+		Leads:       make([]response.LeadResponse, len(e.Leads)),
+		Notes:       make([]response.LeadNoteResponse, len(e.Notes)),
+		Activities:  make([]response.LeadActivityResponse, len(e.Activities)),
+		Assignments: make([]response.LeadAssignmentResponse, len(e.Assignments)),
+		Progress:    make([]response.LeadStepProgressResponse, len(e.Progress)),
+		GeneratedAt: e.GeneratedAt,
+	}
+	for i, p := range e.Phones {
+		resp.Phones[i] = *toPhoneResponse(p)
+	}
+	for i, l := range e.Leads {
+		resp.Leads[i] = *toLeadResponse(l)
+	}
+	for i, n := range e.Notes {
+		resp.Notes[i] = *toLeadNoteResponse(n)
+	}
+	for i, a := range e.Activities {
+		resp.Activities[i] = *toLeadActivityResponse(a)
+	}
+	for i, a := range e.Assignments {
+		resp.Assignments[i] = *toLeadAssignmentResponse(a)
+	}
+	for i, p := range e.Progress {
+		resp.Progress[i] = *toLeadStepProgressResponse(p)
+	}
+	return resp
+}
+
+func toPhoneResponse(p *identityOutput.Phone) *response.PhoneResponse {
+	return &response.PhoneResponse{
+		ID:        p.ID,
+		EntityID:  p.EntityID,
+		CountryID: p.CountryID,
+		Number:    p.Number,
+		Type:      p.Type,
+		IsPrimary: p.IsPrimary,
+		Verified:  p.Verified,
+	}
+}
+
+func toUserResponse(u *identityDomain.UserAccount) *response.UserResponse {
+	if u == nil {
+		return nil
+	}
+	return &response.UserResponse{
+		ID:        u.ID,
+		EntityID:  u.EntityID,
+		Username:  u.Username,
+		LastLogin: u.LastLogin,
+		Status:    string(u.Status),
+		CreatedAt: u.CreatedAt,
+	}
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"torque-dms/adapters/input/http/dto/response"
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+// parseQuery - ?limit=&offset=&page=&cursor=&sort=-created_at,make&filter=status:eq:available
+// (filter se puede repetir). Los campos permitidos los valida cada repository
+func parseQuery(c *gin.Context) (sharedDomain.Query, error) {
+	var q sharedDomain.Query
+	var err error
+
+	if q.Limit, err = queryInt(c, "limit"); err != nil {
+		return q, err
+	}
+	if q.Offset, err = queryInt(c, "offset"); err != nil {
+		return q, err
+	}
+	if q.Page, err = queryInt(c, "page"); err != nil {
+		return q, err
+	}
+	q.Cursor = c.Query("cursor")
+
+	if q.Sort, err = sharedDomain.ParseSort(c.Query("sort")); err != nil {
+		return q, err
+	}
+	for _, expr := range c.QueryArray("filter") {
+		filter, err := sharedDomain.ParseFilter(expr)
+		if err != nil {
+			return q, err
+		}
+		q.Filters = append(q.Filters, filter)
+	}
+	return q, nil
+}
+
+func queryInt(c *gin.Context, name string) (int, error) {
+	raw := c.Query(name)
+	if raw == "" {
+		return 0, nil
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("invalid %s", name)
+	}
+	return value, nil
+}
+
+func toPagination[T any](page *sharedDomain.Page[T]) response.Pagination {
+	return response.Pagination{
+		Total:      page.Total,
+		Limit:      page.Limit,
+		Offset:     page.Offset,
+		NextCursor: page.NextCursor,
+	}
+}
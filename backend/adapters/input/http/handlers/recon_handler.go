@@ -0,0 +1,303 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"torque-dms/adapters/input/http/dto/request"
+	"torque-dms/adapters/input/http/dto/response"
+	"torque-dms/core/inventory/domain"
+	"torque-dms/core/inventory/ports/input"
+)
+
+type ReconHandler struct {
+	reconService input.ReconService
+}
+
+func NewReconHandler(reconService input.ReconService) *ReconHandler {
+	return &ReconHandler{reconService: reconService}
+}
+
+// Orders
+
+func (h *ReconHandler) List(c *gin.Context) {
+	q, err := parseQuery(c)
+	if err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	orders, err := h.reconService.List(c.Request.Context(), q)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	now := time.Now()
+	responseList := make([]response.ReconOrderResponse, len(orders.Items))
+	for i, order := range orders.Items {
+		responseList[i] = *toReconOrderResponse(order, now)
+	}
+
+	c.JSON(http.StatusOK, response.ReconOrderListResponse{
+		Orders:     responseList,
+		Pagination: toPagination(orders),
+	})
+}
+
+func (h *ReconHandler) ListByVehicle(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	orders, err := h.reconService.ListByVehicle(c.Request.Context(), uint(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	now := time.Now()
+	responseList := make([]response.ReconOrderResponse, len(orders))
+	for i, order := range orders {
+		responseList[i] = *toReconOrderResponse(order, now)
+	}
+
+	c.JSON(http.StatusOK, response.ReconOrderListResponse{
+		Orders:     responseList,
+		Pagination: response.Pagination{Total: int64(len(orders))},
+	})
+}
+
+func (h *ReconHandler) GetByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	order, err := h.reconService.GetByID(c.Request.Context(), uint(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toReconOrderResponse(order, time.Now()))
+}
+
+func (h *ReconHandler) Close(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	order, err := h.reconService.Close(c.Request.Context(), uint(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toReconOrderResponse(order, time.Now()))
+}
+
+func (h *ReconHandler) GetCycleStats(c *gin.Context) {
+	var req request.ReconStatsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	stats, err := h.reconService.GetCycleStats(c.Request.Context(), req.From, req.To)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.ReconCycleStatsResponse{
+		From:              stats.From,
+		To:                stats.To,
+		ClosedOrders:      stats.ClosedOrders,
+		AvgCycleTimeHours: stats.AvgCycleTime.Hours(),
+		MaxCycleTimeHours: stats.MaxCycleTime.Hours(),
+		OpenOrders:        stats.OpenOrders,
+		AvgOpenAgeHours:   stats.AvgOpenAge.Hours(),
+		TotalReconCost:    stats.TotalReconCost,
+	})
+}
+
+// Tasks
+
+func (h *ReconHandler) AddTask(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	var req request.AddReconTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	task, err := h.reconService.AddTask(c.Request.Context(), uint(id), input.AddReconTaskInput{
+		Type:          req.Type,
+		Description:   req.Description,
+		AssigneeID:    req.AssigneeID,
+		Vendor:        req.Vendor,
+		EstimatedCost: req.EstimatedCost,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toReconTaskResponse(task))
+}
+
+func (h *ReconHandler) UpdateTask(c *gin.Context) {
+	id, taskID, ok := reconTaskParams(c)
+	if !ok {
+		return
+	}
+
+	version, err := ifMatch(c)
+	if err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	var req request.UpdateReconTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	task, err := h.reconService.UpdateTask(c.Request.Context(), id, taskID, input.UpdateReconTaskInput{
+		Description:   req.Description,
+		AssigneeID:    req.AssigneeID,
+		Vendor:        req.Vendor,
+		EstimatedCost: req.EstimatedCost,
+		Version:       version,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	setETag(c, task.Version)
+	c.JSON(http.StatusOK, toReconTaskResponse(task))
+}
+
+func (h *ReconHandler) ApproveTask(c *gin.Context) {
+	h.changeTask(c, h.reconService.ApproveTask)
+}
+
+func (h *ReconHandler) StartTask(c *gin.Context) {
+	h.changeTask(c, h.reconService.StartTask)
+}
+
+func (h *ReconHandler) CancelTask(c *gin.Context) {
+	h.changeTask(c, h.reconService.CancelTask)
+}
+
+func (h *ReconHandler) CompleteTask(c *gin.Context) {
+	id, taskID, ok := reconTaskParams(c)
+	if !ok {
+		return
+	}
+
+	var req request.CompleteReconTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	task, err := h.reconService.CompleteTask(c.Request.Context(), id, taskID, *req.ActualCost)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toReconTaskResponse(task))
+}
+
+func (h *ReconHandler) changeTask(c *gin.Context, change func(context.Context, uint, uint) (*domain.ReconTask, error)) {
+	id, taskID, ok := reconTaskParams(c)
+	if !ok {
+		return
+	}
+
+	task, err := change(c.Request.Context(), id, taskID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toReconTaskResponse(task))
+}
+
+func reconTaskParams(c *gin.Context) (uint, uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return 0, 0, false
+	}
+	taskID, err := strconv.ParseUint(c.Param("taskId"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid task id"))
+		return 0, 0, false
+	}
+	return uint(id), uint(taskID), true
+}
+
+func toReconOrderResponse(o *domain.ReconOrder, now time.Time) *response.ReconOrderResponse {
+	tasks := make([]response.ReconTaskResponse, len(o.Tasks))
+	for i, t := range o.Tasks {
+		tasks[i] = *toReconTaskResponse(t)
+	}
+
+	return &response.ReconOrderResponse{
+		ID:             o.ID,
+		VehicleID:      o.VehicleID,
+		Status:         string(o.Status),
+		OpenedBy:       o.OpenedBy,
+		Notes:          o.Notes,
+		Tasks:          tasks,
+		OpenTasks:      len(o.OpenTasks()),
+		EstimatedCost:  o.EstimatedCost(),
+		ActualCost:     o.ActualCost(),
+		CycleTimeHours: o.CycleTime(now).Hours(),
+		OpenedAt:       o.OpenedAt,
+		ClosedAt:       o.ClosedAt,
+		Version:        o.Version,
+	}
+}
+
+func toReconTaskResponse(t *domain.ReconTask) *response.ReconTaskResponse {
+	return &response.ReconTaskResponse{
+		ID:               t.ID,
+		OrderID:          t.OrderID,
+		Type:             string(t.Type),
+		Description:      t.Description,
+		Status:           string(t.Status),
+		AssigneeID:       t.AssigneeID,
+		Vendor:           t.Vendor,
+		EstimatedCost:    t.EstimatedCost,
+		ActualCost:       t.ActualCost,
+		ApprovalRequired: t.ApprovalRequired,
+		ApprovedBy:       t.ApprovedBy,
+		ApprovedAt:       t.ApprovedAt,
+		StartedAt:        t.StartedAt,
+		CompletedAt:      t.CompletedAt,
+		CancelledAt:      t.CancelledAt,
+		Version:          t.Version,
+		CreatedAt:        t.CreatedAt,
+	}
+}
@@ -0,0 +1,274 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"torque-dms/adapters/input/http/dto/request"
+	"torque-dms/adapters/input/http/dto/response"
+	"torque-dms/core/inventory/domain"
+	"torque-dms/core/inventory/ports/input"
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+type RouteHandler struct {
+	routeService input.RouteService
+}
+
+func NewRouteHandler(routeService input.RouteService) *RouteHandler {
+	return &RouteHandler{routeService: routeService}
+}
+
+func (h *RouteHandler) Create(c *gin.Context) {
+	var req request.CreateRouteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	route, err := h.routeService.Create(c.Request.Context(), input.CreateRouteInput{
+		Name:             req.Name,
+		FromLocationID:   req.FromLocationID,
+		ToLocationID:     req.ToLocationID,
+		DistanceKM:       req.DistanceKM,
+		EstimatedMinutes: req.EstimatedMinutes,
+		Waypoints:        toWaypoints(req.Waypoints),
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toRouteResponse(route))
+}
+
+func (h *RouteHandler) GetByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	route, err := h.routeService.GetByID(c.Request.Context(), uint(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	setETag(c, route.Version)
+	c.JSON(http.StatusOK, toRouteResponse(route))
+}
+
+func (h *RouteHandler) List(c *gin.Context) {
+	q, err := parseQuery(c)
+	if err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	routes, err := h.routeService.List(c.Request.Context(), q)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	responseList := make([]response.RouteResponse, len(routes.Items))
+	for i, route := range routes.Items {
+		responseList[i] = *toRouteResponse(route)
+	}
+
+	c.JSON(http.StatusOK, response.RouteListResponse{
+		Routes:     responseList,
+		Pagination: toPagination(routes),
+	})
+}
+
+func (h *RouteHandler) Update(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	version, err := ifMatch(c)
+	if err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	var req request.UpdateRouteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	inp := input.UpdateRouteInput{
+		Name:             req.Name,
+		DistanceKM:       req.DistanceKM,
+		EstimatedMinutes: req.EstimatedMinutes,
+		Version:          version,
+	}
+	if req.Waypoints != nil {
+		waypoints := toWaypoints(*req.Waypoints)
+		inp.Waypoints = &waypoints
+	}
+
+	route, err := h.routeService.Update(c.Request.Context(), uint(id), inp)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	setETag(c, route.Version)
+	c.JSON(http.StatusOK, toRouteResponse(route))
+}
+
+func (h *RouteHandler) Delete(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	if err := h.routeService.Delete(c.Request.Context(), uint(id)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "route deleted successfully"})
+}
+
+func (h *RouteHandler) Deactivate(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	version, err := ifMatch(c)
+	if err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	if err := h.routeService.Deactivate(c.Request.Context(), uint(id), version); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "route deactivated"})
+}
+
+func (h *RouteHandler) Activate(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	version, err := ifMatch(c)
+	if err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	if err := h.routeService.Activate(c.Request.Context(), uint(id), version); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "route activated"})
+}
+
+func (h *RouteHandler) GetProgress(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	progress, err := h.routeService.GetProgress(c.Request.Context(), uint(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toRouteProgressResponse(progress))
+}
+
+func (h *RouteHandler) ListInbound(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	inbound, err := h.routeService.ListInbound(c.Request.Context(), uint(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	responseList := make([]response.RouteProgressResponse, len(inbound))
+	for i, progress := range inbound {
+		responseList[i] = *toRouteProgressResponse(progress)
+	}
+
+	c.JSON(http.StatusOK, response.InboundListResponse{
+		Inbound:    responseList,
+		Pagination: response.Pagination{Total: int64(len(inbound))},
+	})
+}
+
+func toWaypoints(list []request.WaypointRequest) []sharedDomain.GeoPoint {
+	waypoints := make([]sharedDomain.GeoPoint, len(list))
+	for i, w := range list {
+		waypoints[i] = sharedDomain.GeoPoint{Latitude: w.Latitude, Longitude: w.Longitude}
+	}
+	return waypoints
+}
+
+func toRouteResponse(r *domain.Route) *response.RouteResponse {
+	waypoints := make([]response.WaypointResponse, len(r.Waypoints))
+	for i, w := range r.Waypoints {
+		waypoints[i] = response.WaypointResponse{Latitude: w.Latitude, Longitude: w.Longitude}
+	}
+
+	return &response.RouteResponse{
+		ID:               r.ID,
+		Name:             r.Name,
+		FromLocationID:   r.FromLocationID,
+		ToLocationID:     r.ToLocationID,
+		DistanceKM:       r.DistanceKM,
+		EstimatedMinutes: r.EstimatedMinutes,
+		Waypoints:        waypoints,
+		Active:           r.Active,
+		Version:          r.Version,
+		CreatedAt:        r.CreatedAt,
+	}
+}
+
+func toRouteProgressResponse(p *domain.RouteProgress) *response.RouteProgressResponse {
+	res := &response.RouteProgressResponse{
+		TransferID:       p.Transfer.ID,
+		VehicleID:        p.Transfer.VehicleID,
+		RouteID:          p.Transfer.RouteID,
+		FromLocationID:   p.Transfer.FromLocationID,
+		ToLocationID:     p.Transfer.ToLocationID,
+		DepartedAt:       p.Transfer.CreatedAt,
+		TotalKM:          p.TotalKM,
+		CoveredKM:        p.CoveredKM,
+		RemainingKM:      p.RemainingKM,
+		PercentComplete:  p.PercentComplete,
+		RemainingMinutes: p.RemainingMinutes,
+		ETA:              p.ETA,
+		DeviationKM:      p.DeviationKM,
+		OffRoute:         p.OffRoute,
+	}
+	if p.Position != nil {
+		res.Position = toTrackingPointResponse(p.Position)
+	}
+	return res
+}
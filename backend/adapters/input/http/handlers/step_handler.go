@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -24,13 +25,13 @@ func NewStepHandler(stepService input.StepService) *StepHandler {
 func (h *StepHandler) CreatePreset(c *gin.Context) {
 	var req request.CreatePresetRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		badRequest(c, err)
 		return
 	}
 
 	createdBy, _ := c.Get("entity_id")
 
-	preset, err := h.stepService.CreatePreset(input.CreatePresetInput{
+	preset, err := h.stepService.CreatePreset(c.Request.Context(), input.CreatePresetInput{
 		Code:        req.Code,
 		Name:        req.Name,
 		Description: req.Description,
@@ -39,7 +40,7 @@ func (h *StepHandler) CreatePreset(c *gin.Context) {
 		CreatedBy:   createdBy.(uint),
 	})
 	if err != nil {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -49,13 +50,13 @@ func (h *StepHandler) CreatePreset(c *gin.Context) {
 func (h *StepHandler) GetPreset(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		badRequest(c, errors.New("invalid id"))
 		return
 	}
 
-	preset, err := h.stepService.GetPreset(uint(id))
+	preset, err := h.stepService.GetPreset(c.Request.Context(), uint(id))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "preset not found"})
+		c.Error(err)
 		return
 	}
 
@@ -63,85 +64,143 @@ func (h *StepHandler) GetPreset(c *gin.Context) {
 }
 
 func (h *StepHandler) GetPresets(c *gin.Context) {
-	presets, err := h.stepService.GetPresets()
+	q, err := parseQuery(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		badRequest(c, err)
 		return
 	}
 
-	responseList := make([]response.LeadStepPresetResponse, len(presets))
-	for i, p := range presets {
+	presets, err := h.stepService.GetPresets(c.Request.Context(), q)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	responseList := make([]response.LeadStepPresetResponse, len(presets.Items))
+	for i, p := range presets.Items {
 		responseList[i] = *toLeadStepPresetResponse(p)
 	}
 
 	c.JSON(http.StatusOK, response.LeadStepPresetListResponse{
-		Presets: responseList,
-		Total:   len(responseList),
+		Presets:    responseList,
+		Pagination: toPagination(presets),
 	})
 }
 
 func (h *StepHandler) GetPublicPresets(c *gin.Context) {
-	presets, err := h.stepService.GetPublicPresets()
+	q, err := parseQuery(c)
+	if err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	presets, err := h.stepService.GetPublicPresets(c.Request.Context(), q)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
-	responseList := make([]response.LeadStepPresetResponse, len(presets))
-	for i, p := range presets {
+	responseList := make([]response.LeadStepPresetResponse, len(presets.Items))
+	for i, p := range presets.Items {
 		responseList[i] = *toLeadStepPresetResponse(p)
 	}
 
 	c.JSON(http.StatusOK, response.LeadStepPresetListResponse{
-		Presets: responseList,
-		Total:   len(responseList),
+		Presets:    responseList,
+		Pagination: toPagination(presets),
 	})
 }
 
 func (h *StepHandler) GetMyPresets(c *gin.Context) {
 	entityID, _ := c.Get("entity_id")
 
-	presets, err := h.stepService.GetMyPresets(entityID.(uint))
+	q, err := parseQuery(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		badRequest(c, err)
 		return
 	}
 
-	responseList := make([]response.LeadStepPresetResponse, len(presets))
-	for i, p := range presets {
+	presets, err := h.stepService.GetMyPresets(c.Request.Context(), entityID.(uint), q)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	responseList := make([]response.LeadStepPresetResponse, len(presets.Items))
+	for i, p := range presets.Items {
 		responseList[i] = *toLeadStepPresetResponse(p)
 	}
 
 	c.JSON(http.StatusOK, response.LeadStepPresetListResponse{
-		Presets: responseList,
-		Total:   len(responseList),
+		Presets:    responseList,
+		Pagination: toPagination(presets),
 	})
 }
 
 func (h *StepHandler) DeletePreset(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		badRequest(c, errors.New("invalid id"))
 		return
 	}
 
-	if err := h.stepService.DeletePreset(uint(id)); err != nil {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	if err := h.stepService.DeletePreset(c.Request.Context(), uint(id)); err != nil {
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "preset deleted"})
 }
 
+func (h *StepHandler) GetDeletedPresets(c *gin.Context) {
+	q, err := parseQuery(c)
+	if err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	presets, err := h.stepService.ListDeletedPresets(c.Request.Context(), q)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	responseList := make([]response.LeadStepPresetResponse, len(presets.Items))
+	for i, preset := range presets.Items {
+		responseList[i] = *toLeadStepPresetResponse(preset)
+	}
+
+	c.JSON(http.StatusOK, response.LeadStepPresetListResponse{
+		Presets:    responseList,
+		Pagination: toPagination(presets),
+	})
+}
+
+func (h *StepHandler) RestorePreset(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	preset, err := h.stepService.RestorePreset(c.Request.Context(), uint(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toLeadStepPresetResponse(preset))
+}
+
 func (h *StepHandler) MakePresetPublic(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		badRequest(c, errors.New("invalid id"))
 		return
 	}
 
-	if err := h.stepService.MakePresetPublic(uint(id)); err != nil {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	if err := h.stepService.MakePresetPublic(c.Request.Context(), uint(id)); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -151,12 +210,12 @@ func (h *StepHandler) MakePresetPublic(c *gin.Context) {
 func (h *StepHandler) MakePresetShared(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		badRequest(c, errors.New("invalid id"))
 		return
 	}
 
-	if err := h.stepService.MakePresetShared(uint(id)); err != nil {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	if err := h.stepService.MakePresetShared(c.Request.Context(), uint(id)); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -166,12 +225,12 @@ func (h *StepHandler) MakePresetShared(c *gin.Context) {
 func (h *StepHandler) MakePresetPrivate(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		badRequest(c, errors.New("invalid id"))
 		return
 	}
 
-	if err := h.stepService.MakePresetPrivate(uint(id)); err != nil {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	if err := h.stepService.MakePresetPrivate(c.Request.Context(), uint(id)); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -183,17 +242,17 @@ func (h *StepHandler) MakePresetPrivate(c *gin.Context) {
 func (h *StepHandler) CreateStep(c *gin.Context) {
 	presetID, err := strconv.ParseUint(c.Param("presetId"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid preset id"})
+		badRequest(c, errors.New("invalid preset id"))
 		return
 	}
 
 	var req request.CreateStepRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		badRequest(c, err)
 		return
 	}
 
-	step, err := h.stepService.CreateStep(input.CreateStepInput{
+	step, err := h.stepService.CreateStep(c.Request.Context(), input.CreateStepInput{
 		PresetID:  uint(presetID),
 		Code:      req.Code,
 		Name:      req.Name,
@@ -201,7 +260,7 @@ func (h *StepHandler) CreateStep(c *gin.Context) {
 		IsFinal:   req.IsFinal,
 	})
 	if err != nil {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -211,13 +270,13 @@ func (h *StepHandler) CreateStep(c *gin.Context) {
 func (h *StepHandler) GetSteps(c *gin.Context) {
 	presetID, err := strconv.ParseUint(c.Param("presetId"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid preset id"})
+		badRequest(c, errors.New("invalid preset id"))
 		return
 	}
 
-	steps, err := h.stepService.GetSteps(uint(presetID))
+	steps, err := h.stepService.GetSteps(c.Request.Context(), uint(presetID))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -235,12 +294,12 @@ func (h *StepHandler) GetSteps(c *gin.Context) {
 func (h *StepHandler) DeactivateStep(c *gin.Context) {
 	stepID, err := strconv.ParseUint(c.Param("stepId"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid step id"})
+		badRequest(c, errors.New("invalid step id"))
 		return
 	}
 
-	if err := h.stepService.DeactivateStep(uint(stepID)); err != nil {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	if err := h.stepService.DeactivateStep(c.Request.Context(), uint(stepID)); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -250,12 +309,12 @@ func (h *StepHandler) DeactivateStep(c *gin.Context) {
 func (h *StepHandler) ActivateStep(c *gin.Context) {
 	stepID, err := strconv.ParseUint(c.Param("stepId"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid step id"})
+		badRequest(c, errors.New("invalid step id"))
 		return
 	}
 
-	if err := h.stepService.ActivateStep(uint(stepID)); err != nil {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	if err := h.stepService.ActivateStep(c.Request.Context(), uint(stepID)); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -265,12 +324,12 @@ func (h *StepHandler) ActivateStep(c *gin.Context) {
 func (h *StepHandler) DeleteStep(c *gin.Context) {
 	stepID, err := strconv.ParseUint(c.Param("stepId"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid step id"})
+		badRequest(c, errors.New("invalid step id"))
 		return
 	}
 
-	if err := h.stepService.DeleteStep(uint(stepID)); err != nil {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	if err := h.stepService.DeleteStep(c.Request.Context(), uint(stepID)); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -289,6 +348,8 @@ func toLeadStepPresetResponse(p *domain.LeadStepPreset) *response.LeadStepPreset
 		IsPublic:    p.IsPublic,
 		IsShared:    p.IsShared,
 		CreatedBy:   p.CreatedBy,
+		DeletedAt:   p.DeletedAt,
+		DeletedBy:   p.DeletedBy,
 		CreatedAt:   p.CreatedAt,
 	}
 }
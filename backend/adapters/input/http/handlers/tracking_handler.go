@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"torque-dms/adapters/input/http/dto/request"
+	"torque-dms/adapters/input/http/dto/response"
+	"torque-dms/core/inventory/domain"
+	"torque-dms/core/inventory/ports/input"
+)
+
+type TrackingHandler struct {
+	trackingService input.TrackingService
+}
+
+func NewTrackingHandler(trackingService input.TrackingService) *TrackingHandler {
+	return &TrackingHandler{trackingService: trackingService}
+}
+
+func (h *TrackingHandler) Ingest(c *gin.Context) {
+	var req request.IngestPingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	pings := make([]domain.Ping, len(req.Pings))
+	for i, p := range req.Pings {
+		pings[i] = domain.Ping{
+			VIN:        p.VIN,
+			DeviceID:   p.DeviceID,
+			Latitude:   p.Latitude,
+			Longitude:  p.Longitude,
+			RouteID:    p.RouteID,
+			RecordedAt: p.RecordedAt,
+		}
+	}
+
+	result, err := h.trackingService.Ingest(c.Request.Context(), pings)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	rejected := make([]response.PingRejectionResponse, len(result.Rejected))
+	for i, r := range result.Rejected {
+		rejected[i] = response.PingRejectionResponse{Index: r.Index, Reason: r.Reason}
+	}
+
+	c.JSON(http.StatusAccepted, response.IngestPingsResponse{
+		Accepted:   result.Accepted,
+		Duplicates: result.Duplicates,
+		Rejected:   rejected,
+		Crossings:  result.Crossings,
+	})
+}
+
+func (h *TrackingHandler) AssignDevice(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	var req request.AssignTrackingDeviceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	vehicle, err := h.trackingService.AssignDevice(c.Request.Context(), uint(id), req.DeviceID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toVehicleResponse(vehicle))
+}
+
+func (h *TrackingHandler) GetLastPosition(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	point, err := h.trackingService.GetLastPosition(c.Request.Context(), uint(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toTrackingPointResponse(point))
+}
+
+func (h *TrackingHandler) GetTrack(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	var req request.TrackRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	q, err := parseQuery(c)
+	if err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	track, err := h.trackingService.GetTrack(c.Request.Context(), uint(id), req.From, req.To, q)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	points := make([]response.TrackingPointResponse, len(track.Items))
+	for i, point := range track.Items {
+		points[i] = *toTrackingPointResponse(point)
+	}
+
+	c.JSON(http.StatusOK, response.TrackResponse{
+		Points:     points,
+		Pagination: toPagination(track),
+	})
+}
+
+func toTrackingPointResponse(p *domain.TrackingPoint) *response.TrackingPointResponse {
+	return &response.TrackingPointResponse{
+		VehicleID:  p.VehicleID,
+		Latitude:   p.Latitude,
+		Longitude:  p.Longitude,
+		RouteID:    p.RouteID,
+		RecordedAt: p.RecordedAt,
+	}
+}
@@ -1,8 +1,11 @@
 package handlers
 
 import (
+	"errors"
+	"io"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"torque-dms/adapters/input/http/dto/request"
@@ -22,11 +25,11 @@ func NewVehicleHandler(vehicleService input.VehicleService) *VehicleHandler {
 func (h *VehicleHandler) Create(c *gin.Context) {
 	var req request.CreateVehicleRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		badRequest(c, err)
 		return
 	}
 
-	vehicle, err := h.vehicleService.Create(input.CreateVehicleInput{
+	vehicle, err := h.vehicleService.Create(c.Request.Context(), input.CreateVehicleInput{
 		StockNumber:       req.StockNumber,
 		VIN:               req.VIN,
 		Plate:             req.Plate,
@@ -44,9 +47,10 @@ func (h *VehicleHandler) Create(c *gin.Context) {
 		LocationID:        req.LocationID,
 		AcquisitionSource: req.AcquisitionSource,
 		AcquisitionCost:   req.AcquisitionCost,
+		BodyType:          req.BodyType,
 	})
 	if err != nil {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -56,87 +60,190 @@ func (h *VehicleHandler) Create(c *gin.Context) {
 func (h *VehicleHandler) GetByID(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		badRequest(c, errors.New("invalid id"))
 		return
 	}
 
-	vehicle, err := h.vehicleService.GetByID(uint(id))
+	vehicle, err := h.vehicleService.GetByID(c.Request.Context(), uint(id))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "vehicle not found"})
+		c.Error(err)
 		return
 	}
 
+	setETag(c, vehicle.Version)
 	c.JSON(http.StatusOK, toVehicleResponse(vehicle))
 }
 
 func (h *VehicleHandler) GetByVIN(c *gin.Context) {
 	vin := c.Param("vin")
 
-	vehicle, err := h.vehicleService.GetByVIN(vin)
+	vehicle, err := h.vehicleService.GetByVIN(c.Request.Context(), vin)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "vehicle not found"})
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, toVehicleResponse(vehicle))
 }
 
+func (h *VehicleHandler) DecodeVIN(c *gin.Context) {
+	decoded, err := h.vehicleService.DecodeVIN(c.Request.Context(), c.Param("vin"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.VINDecodeResponse{
+		VIN:          decoded.VIN,
+		WMI:          decoded.WMI,
+		VDS:          decoded.VDS,
+		VIS:          decoded.VIS,
+		Region:       decoded.Region,
+		Manufacturer: decoded.Manufacturer,
+		Make:         decoded.Make,
+		Model:        decoded.Model,
+		BodyType:     decoded.BodyType,
+		ModelYear:    decoded.ModelYear,
+		PlantCode:    decoded.PlantCode,
+		Plant:        decoded.Plant,
+		SerialNumber: decoded.SerialNumber,
+	})
+}
+
 func (h *VehicleHandler) List(c *gin.Context) {
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
-	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	q, err := parseQuery(c)
+	if err != nil {
+		badRequest(c, err)
+		return
+	}
 
-	vehicles, err := h.vehicleService.List(limit, offset)
+	vehicles, err := h.vehicleService.List(c.Request.Context(), q)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
-	responseList := make([]response.VehicleResponse, len(vehicles))
-	for i, vehicle := range vehicles {
+	responseList := make([]response.VehicleResponse, len(vehicles.Items))
+	for i, vehicle := range vehicles.Items {
 		responseList[i] = *toVehicleResponse(vehicle)
 	}
 
 	c.JSON(http.StatusOK, response.VehicleListResponse{
-		Vehicles: responseList,
-		Total:    len(responseList),
+		Vehicles:   responseList,
+		Pagination: toPagination(vehicles),
 	})
 }
 
 func (h *VehicleHandler) ListAvailable(c *gin.Context) {
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
-	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	q, err := parseQuery(c)
+	if err != nil {
+		badRequest(c, err)
+		return
+	}
 
-	vehicles, err := h.vehicleService.ListAvailable(limit, offset)
+	vehicles, err := h.vehicleService.ListAvailable(c.Request.Context(), q)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
-	responseList := make([]response.VehicleResponse, len(vehicles))
-	for i, vehicle := range vehicles {
+	responseList := make([]response.VehicleResponse, len(vehicles.Items))
+	for i, vehicle := range vehicles.Items {
 		responseList[i] = *toVehicleResponse(vehicle)
 	}
 
 	c.JSON(http.StatusOK, response.VehicleListResponse{
+		Vehicles:   responseList,
+		Pagination: toPagination(vehicles),
+	})
+}
+
+func (h *VehicleHandler) Search(c *gin.Context) {
+	var req request.SearchVehiclesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	near, err := toGeoOrigin(req.GeoOriginRequest)
+	if err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	q, err := parseQuery(c)
+	if err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	result, err := h.vehicleService.Search(c.Request.Context(), domain.VehicleSearch{
+		Text:               req.Q,
+		Makes:              req.Make,
+		Models:             req.Model,
+		Trims:              req.Trim,
+		Colors:             req.Color,
+		Conditions:         toEnums[domain.VehicleCondition](req.Condition),
+		LotTypes:           toEnums[domain.LotType](req.LotType),
+		Statuses:           toEnums[domain.VehicleStatus](req.Status),
+		AcquisitionSources: toEnums[domain.AcquisitionSource](req.AcquisitionSource),
+		LocationIDs:        req.LocationID,
+		YearMin:            req.YearMin,
+		YearMax:            req.YearMax,
+		PriceMin:           req.PriceMin,
+		PriceMax:           req.PriceMax,
+		MileageMin:         req.MileageMin,
+		MileageMax:         req.MileageMax,
+		DaysInStockMin:     req.DaysInStockMin,
+		DaysInStockMax:     req.DaysInStockMax,
+		Near:               near,
+		RadiusKM:           req.RadiusKM,
+	}, q)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	responseList := make([]response.VehicleResponse, len(result.Vehicles.Items))
+	for i, vehicle := range result.Vehicles.Items {
+		responseList[i] = *toVehicleResponse(vehicle)
+		if distance, ok := result.Distances[vehicle.LocationID]; ok {
+			km := roundKM(distance)
+			responseList[i].DistanceKM = &km
+		}
+	}
+
+	c.JSON(http.StatusOK, response.VehicleSearchResponse{
 		Vehicles: responseList,
-		Total:    len(responseList),
+		Facets: response.VehicleFacetsResponse{
+			Makes:  toFacetCountResponses(result.Facets.Makes),
+			Models: toFacetCountResponses(result.Facets.Models),
+			Years:  toFacetCountResponses(result.Facets.Years),
+		},
+		Pagination: toPagination(result.Vehicles),
 	})
 }
 
 func (h *VehicleHandler) Update(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	version, err := ifMatch(c)
+	if err != nil {
+		badRequest(c, err)
 		return
 	}
 
 	var req request.UpdateVehicleRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		badRequest(c, err)
 		return
 	}
 
-	vehicle, err := h.vehicleService.Update(uint(id), input.UpdateVehicleInput{
+	vehicle, err := h.vehicleService.Update(c.Request.Context(), uint(id), input.UpdateVehicleInput{
 		Plate:         req.Plate,
 		Trim:          req.Trim,
 		Mileage:       req.Mileage,
@@ -145,39 +252,92 @@ func (h *VehicleHandler) Update(c *gin.Context) {
 		MSRP:          req.MSRP,
 		InvoicePrice:  req.InvoicePrice,
 		AskingPrice:   req.AskingPrice,
+		Version:       version,
 	})
 	if err != nil {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
+	setETag(c, vehicle.Version)
 	c.JSON(http.StatusOK, toVehicleResponse(vehicle))
 }
 
 func (h *VehicleHandler) Delete(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		badRequest(c, errors.New("invalid id"))
 		return
 	}
 
-	if err := h.vehicleService.Delete(uint(id)); err != nil {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	if err := h.vehicleService.Delete(c.Request.Context(), uint(id)); err != nil {
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "vehicle deleted successfully"})
 }
 
+func (h *VehicleHandler) ListDeleted(c *gin.Context) {
+	q, err := parseQuery(c)
+	if err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	vehicles, err := h.vehicleService.ListDeleted(c.Request.Context(), q)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	responseList := make([]response.VehicleResponse, len(vehicles.Items))
+	for i, vehicle := range vehicles.Items {
+		responseList[i] = *toVehicleResponse(vehicle)
+	}
+
+	c.JSON(http.StatusOK, response.VehicleListResponse{
+		Vehicles:   responseList,
+		Pagination: toPagination(vehicles),
+	})
+}
+
+func (h *VehicleHandler) Restore(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	vehicle, err := h.vehicleService.Restore(c.Request.Context(), uint(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toVehicleResponse(vehicle))
+}
+
 func (h *VehicleHandler) MarkAsSold(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	// El body es opcional: sin sale_price la venta queda sin precio real y sin lead_id
+	// sin comprador identificado
+	var req request.MarkAsSoldRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		badRequest(c, err)
 		return
 	}
 
-	if err := h.vehicleService.MarkAsSold(uint(id)); err != nil {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	if err := h.vehicleService.MarkAsSold(c.Request.Context(), uint(id), input.MarkAsSoldInput{
+		SalePrice: req.SalePrice,
+		LeadID:    req.LeadID,
+	}); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -187,12 +347,12 @@ func (h *VehicleHandler) MarkAsSold(c *gin.Context) {
 func (h *VehicleHandler) MarkAsReadyForSale(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		badRequest(c, errors.New("invalid id"))
 		return
 	}
 
-	if err := h.vehicleService.MarkAsReadyForSale(uint(id)); err != nil {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	if err := h.vehicleService.MarkAsReadyForSale(c.Request.Context(), uint(id)); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -202,57 +362,250 @@ func (h *VehicleHandler) MarkAsReadyForSale(c *gin.Context) {
 func (h *VehicleHandler) SendToRecon(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		badRequest(c, errors.New("invalid id"))
 		return
 	}
 
-	if err := h.vehicleService.SendToRecon(uint(id)); err != nil {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	if err := h.vehicleService.SendToRecon(c.Request.Context(), uint(id)); err != nil {
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "vehicle sent to recon"})
 }
 
+func (h *VehicleHandler) ChangeStatus(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	var req request.ChangeStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	vehicle, err := h.vehicleService.ChangeStatus(c.Request.Context(), uint(id), req.Status, req.Reason)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	setETag(c, vehicle.Version)
+	c.JSON(http.StatusOK, toVehicleResponse(vehicle))
+}
+
+func (h *VehicleHandler) UnwindSale(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	var req request.UnwindSaleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	vehicle, err := h.vehicleService.UnwindSale(c.Request.Context(), uint(id), req.Status, req.Reason)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	setETag(c, vehicle.Version)
+	c.JSON(http.StatusOK, toVehicleResponse(vehicle))
+}
+
+func (h *VehicleHandler) GetStatusTransitions(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	vehicle, err := h.vehicleService.GetByID(c.Request.Context(), uint(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	transitions := vehicle.AllowedTransitions()
+	allowed := make([]string, len(transitions))
+	for i, to := range transitions {
+		allowed[i] = string(to)
+	}
+
+	c.JSON(http.StatusOK, response.StatusTransitionsResponse{
+		Status:  string(vehicle.Status),
+		Allowed: allowed,
+	})
+}
+
+func (h *VehicleHandler) GetStatusHistory(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	q, err := parseQuery(c)
+	if err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	history, err := h.vehicleService.GetStatusHistory(c.Request.Context(), uint(id), q)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	responseList := make([]response.StatusHistoryResponse, len(history.Items))
+	for i, change := range history.Items {
+		responseList[i] = response.StatusHistoryResponse{
+			ID:         change.ID,
+			VehicleID:  change.VehicleID,
+			FromStatus: string(change.FromStatus),
+			ToStatus:   string(change.ToStatus),
+			ChangedBy:  change.ChangedBy,
+			Reason:     change.Reason,
+			CreatedAt:  change.CreatedAt,
+		}
+	}
+
+	c.JSON(http.StatusOK, response.StatusHistoryListResponse{
+		History:    responseList,
+		Pagination: toPagination(history),
+	})
+}
+
 func (h *VehicleHandler) ChangeLocation(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		badRequest(c, errors.New("invalid id"))
 		return
 	}
 
 	var req request.ChangeLocationRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		badRequest(c, err)
 		return
 	}
 
-	if err := h.vehicleService.ChangeLocation(uint(id), req.LocationID); err != nil {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	if err := h.vehicleService.ChangeLocation(c.Request.Context(), uint(id), req.LocationID, req.Reason); err != nil {
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "vehicle location changed"})
 }
 
+// Transfers
+
+func (h *VehicleHandler) StartTransfer(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	var req request.StartTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	transfer, err := h.vehicleService.StartTransfer(c.Request.Context(), input.StartTransferInput{
+		VehicleID:         uint(id),
+		ToLocationID:      req.ToLocationID,
+		TransitLocationID: req.TransitLocationID,
+		RouteID:           req.RouteID,
+		Reason:            req.Reason,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toTransferResponse(transfer))
+}
+
+func (h *VehicleHandler) ConfirmArrival(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	transfer, err := h.vehicleService.ConfirmArrival(c.Request.Context(), uint(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toTransferResponse(transfer))
+}
+
+func (h *VehicleHandler) GetLocationHistory(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		badRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	q, err := parseQuery(c)
+	if err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	history, err := h.vehicleService.GetLocationHistory(c.Request.Context(), uint(id), q)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	responseList := make([]response.LocationHistoryResponse, len(history.Items))
+	for i, move := range history.Items {
+		responseList[i] = response.LocationHistoryResponse{
+			ID:             move.ID,
+			VehicleID:      move.VehicleID,
+			FromLocationID: move.FromLocationID,
+			ToLocationID:   move.ToLocationID,
+			MovedBy:        move.MovedBy,
+			Reason:         move.Reason,
+			CreatedAt:      move.CreatedAt,
+		}
+	}
+
+	c.JSON(http.StatusOK, response.LocationHistoryListResponse{
+		History:    responseList,
+		Pagination: toPagination(history),
+	})
+}
+
 // Photos
 
 func (h *VehicleHandler) AddPhoto(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		badRequest(c, errors.New("invalid id"))
 		return
 	}
 
 	var req request.AddPhotoRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		badRequest(c, err)
 		return
 	}
 
 	uploadedBy, _ := c.Get("entity_id")
 
-	photo, err := h.vehicleService.AddPhoto(input.AddPhotoInput{
+	photo, err := h.vehicleService.AddPhoto(c.Request.Context(), input.AddPhotoInput{
 		VehicleID:   uint(id),
 		URL:         req.URL,
 		Perspective: req.Perspective,
@@ -261,7 +614,7 @@ func (h *VehicleHandler) AddPhoto(c *gin.Context) {
 		IsPrimary:   req.IsPrimary,
 	})
 	if err != nil {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -271,13 +624,13 @@ func (h *VehicleHandler) AddPhoto(c *gin.Context) {
 func (h *VehicleHandler) GetPhotos(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		badRequest(c, errors.New("invalid id"))
 		return
 	}
 
-	photos, err := h.vehicleService.GetPhotos(uint(id))
+	photos, err := h.vehicleService.GetPhotos(c.Request.Context(), uint(id))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -295,18 +648,18 @@ func (h *VehicleHandler) GetPhotos(c *gin.Context) {
 func (h *VehicleHandler) SetPrimaryPhoto(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		badRequest(c, errors.New("invalid id"))
 		return
 	}
 
 	var req request.SetPrimaryPhotoRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		badRequest(c, err)
 		return
 	}
 
-	if err := h.vehicleService.SetPrimaryPhoto(uint(id), req.PhotoID); err != nil {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	if err := h.vehicleService.SetPrimaryPhoto(c.Request.Context(), uint(id), req.PhotoID); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -316,12 +669,12 @@ func (h *VehicleHandler) SetPrimaryPhoto(c *gin.Context) {
 func (h *VehicleHandler) DeletePhoto(c *gin.Context) {
 	photoID, err := strconv.ParseUint(c.Param("photoId"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid photo id"})
+		badRequest(c, errors.New("invalid photo id"))
 		return
 	}
 
-	if err := h.vehicleService.DeletePhoto(uint(photoID)); err != nil {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	if err := h.vehicleService.DeletePhoto(c.Request.Context(), uint(photoID)); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -331,6 +684,7 @@ func (h *VehicleHandler) DeletePhoto(c *gin.Context) {
 // Helpers
 
 func toVehicleResponse(v *domain.Vehicle) *response.VehicleResponse {
+	days := v.DaysInStock(time.Now())
 	return &response.VehicleResponse{
 		ID:                v.ID,
 		StockNumber:       v.StockNumber,
@@ -353,13 +707,59 @@ func toVehicleResponse(v *domain.Vehicle) *response.VehicleResponse {
 		AcquisitionSource: string(v.AcquisitionSource),
 		AcquisitionDate:   v.AcquisitionDate,
 		AcquisitionCost:   v.AcquisitionCost,
+		ReconCost:         v.ReconCost,
+		AdditionalCost:    v.AdditionalCost,
+		SalePrice:         v.SalePrice,
+		SoldAt:            v.SoldAt,
+		SoldLeadID:        v.SoldLeadID,
+		CostBasis:         v.CostBasis(),
 		Profit:            v.Profit(),
 		Margin:            v.Margin(),
+		DaysInStock:       days,
+		AgeBucket:         string(domain.AgeBucketFor(days)),
+		TrackingDeviceID:  v.TrackingDeviceID,
+		Model3DID:         v.Model3DID,
+		DeletedAt:         v.DeletedAt,
+		DeletedBy:         v.DeletedBy,
+		Version:           v.Version,
 		CreatedAt:         v.CreatedAt,
 		ModifiedAt:        v.ModifiedAt,
 	}
 }
 
+func toTransferResponse(t *domain.VehicleTransfer) *response.VehicleTransferResponse {
+	return &response.VehicleTransferResponse{
+		ID:                t.ID,
+		VehicleID:         t.VehicleID,
+		FromLocationID:    t.FromLocationID,
+		TransitLocationID: t.TransitLocationID,
+		ToLocationID:      t.ToLocationID,
+		RouteID:           t.RouteID,
+		Status:            string(t.Status),
+		Reason:            t.Reason,
+		RequestedBy:       t.RequestedBy,
+		ReceivedBy:        t.ReceivedBy,
+		CreatedAt:         t.CreatedAt,
+		CompletedAt:       t.CompletedAt,
+	}
+}
+
+func toFacetCountResponses(counts []domain.FacetCount) []response.FacetCountResponse {
+	responseList := make([]response.FacetCountResponse, len(counts))
+	for i, count := range counts {
+		responseList[i] = response.FacetCountResponse{Value: count.Value, Count: count.Count}
+	}
+	return responseList
+}
+
+func toEnums[T ~string](values []string) []T {
+	enums := make([]T, len(values))
+	for i, v := range values {
+		enums[i] = T(v)
+	}
+	return enums
+}
+
 func toPhotoResponse(p *domain.VehiclePhoto) *response.VehiclePhotoResponse {
 	return &response.VehiclePhotoResponse{
 		ID:          p.ID,
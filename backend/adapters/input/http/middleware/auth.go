@@ -1,11 +1,11 @@
 package middleware
 
 import (
-	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	sharedDomain "torque-dms/core/shared/domain"
 )
 
 type AuthMiddleware struct {
@@ -20,14 +20,14 @@ func (m *AuthMiddleware) Authenticate() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "authorization header required"})
+			c.Error(sharedDomain.Unauthorized("missing_authorization", "authorization header required"))
 			c.Abort()
 			return
 		}
 
 		parts := strings.Split(authHeader, " ")
 		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid authorization format"})
+			c.Error(sharedDomain.Unauthorized("invalid_authorization_format", "invalid authorization format"))
 			c.Abort()
 			return
 		}
@@ -42,14 +42,14 @@ func (m *AuthMiddleware) Authenticate() gin.HandlerFunc {
 		})
 
 		if err != nil || !token.Valid {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+			c.Error(sharedDomain.Unauthorized("invalid_token", "invalid token"))
 			c.Abort()
 			return
 		}
 
 		claims, ok := token.Claims.(jwt.MapClaims)
 		if !ok {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid token claims"})
+			c.Error(sharedDomain.Unauthorized("invalid_token_claims", "invalid token claims"))
 			c.Abort()
 			return
 		}
@@ -59,6 +59,11 @@ func (m *AuthMiddleware) Authenticate() gin.HandlerFunc {
 		c.Set("entity_id", uint(claims["entity_id"].(float64)))
 		c.Set("username", claims["username"].(string))
 
+		// Completar el actor para que los services sepan quién opera
+		actor := sharedDomain.ActorFromContext(c.Request.Context())
+		actor.EntityID = c.GetUint("entity_id")
+		c.Request = c.Request.WithContext(sharedDomain.WithActor(c.Request.Context(), actor))
+
 		c.Next()
 	}
 }
\ No newline at end of file
@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"torque-dms/adapters/input/http/dto/response"
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+var kindStatus = map[sharedDomain.ErrorKind]int{
+	sharedDomain.KindNotFound:     http.StatusNotFound,
+	sharedDomain.KindConflict:     http.StatusConflict,
+	sharedDomain.KindValidation:   http.StatusUnprocessableEntity,
+	sharedDomain.KindUnauthorized: http.StatusUnauthorized,
+	sharedDomain.KindForbidden:    http.StatusForbidden,
+	sharedDomain.KindInvariant:    http.StatusUnprocessableEntity,
+}
+
+// ErrorHandler - los handlers solo hacen c.Error(err); aquí se decide el status y el cuerpo
+func ErrorHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		err := c.Errors.Last()
+		status, body := renderError(err)
+		body.RequestID = c.GetString("request_id")
+		if status == http.StatusInternalServerError {
+			log.Printf("request %s: %s %s: %v", body.RequestID, c.Request.Method, c.FullPath(), err.Err)
+		}
+
+		c.AbortWithStatusJSON(status, body)
+	}
+}
+
+func renderError(err *gin.Error) (int, response.ErrorResponse) {
+	// Body o parámetros de la URL mal formados
+	if err.IsType(gin.ErrorTypeBind) {
+		return http.StatusBadRequest, response.ErrorResponse{
+			Error: err.Error(),
+			Code:  "invalid_request",
+		}
+	}
+
+	if domainErr, ok := sharedDomain.AsError(err.Err); ok {
+		status, known := kindStatus[domainErr.Kind]
+		if !known {
+			status = http.StatusInternalServerError
+		}
+		return status, response.ErrorResponse{
+			Error:   domainErr.Message,
+			Code:    domainErr.Code,
+			Fields:  domainErr.Fields,
+			Details: err.Meta,
+		}
+	}
+
+	if errors.Is(err.Err, context.DeadlineExceeded) {
+		return http.StatusGatewayTimeout, response.ErrorResponse{
+			Error: "request timed out",
+			Code:  "timeout",
+		}
+	}
+
+	// Cualquier otra cosa es un fallo nuestro: no se filtra el detalle al cliente
+	return http.StatusInternalServerError, response.ErrorResponse{
+		Error: "internal server error",
+		Code:  "internal_error",
+	}
+}
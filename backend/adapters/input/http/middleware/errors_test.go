@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+func TestRenderError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        *gin.Error
+		wantStatus int
+		wantCode   string
+	}{
+		{"not found", &gin.Error{Err: sharedDomain.NotFound("vehicle")}, http.StatusNotFound, "vehicle_not_found"},
+		{"duplicate VIN", &gin.Error{Err: sharedDomain.Conflict("vin_taken", "vehicle with this VIN already exists")}, http.StatusConflict, "vin_taken"},
+		{"wrapped validation", &gin.Error{Err: fmt.Errorf("lead 3: %w", sharedDomain.Invalid("budget_min", "min budget cannot exceed max budget"))}, http.StatusUnprocessableEntity, "validation_failed"},
+		{"invariant", &gin.Error{Err: sharedDomain.Invariant("vehicle_sold", "cannot update sold vehicle")}, http.StatusUnprocessableEntity, "vehicle_sold"},
+		{"forbidden", &gin.Error{Err: sharedDomain.Forbidden("account_inactive", "account is not active")}, http.StatusForbidden, "account_inactive"},
+		{"malformed body", &gin.Error{Err: errors.New("EOF"), Type: gin.ErrorTypeBind}, http.StatusBadRequest, "invalid_request"},
+		{"deadline", &gin.Error{Err: context.DeadlineExceeded}, http.StatusGatewayTimeout, "timeout"},
+		{"database outage", &gin.Error{Err: errors.New("dial tcp: connection refused")}, http.StatusInternalServerError, "internal_error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := renderError(tt.err)
+			if status != tt.wantStatus {
+				t.Errorf("renderError() status = %d, want %d", status, tt.wantStatus)
+			}
+			if body.Code != tt.wantCode {
+				t.Errorf("renderError() code = %s, want %s", body.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestRenderErrorHidesInternalDetails(t *testing.T) {
+	_, body := renderError(&gin.Error{Err: errors.New("pq: password authentication failed")})
+	if body.Error != "internal server error" {
+		t.Errorf("renderError() leaked %q", body.Error)
+	}
+}
@@ -1,10 +1,9 @@
 package middleware
 
 import (
-	"net/http"
-
 	"github.com/gin-gonic/gin"
 	"torque-dms/core/identity/ports/input"
+	sharedDomain "torque-dms/core/shared/domain"
 )
 
 type PermissionMiddleware struct {
@@ -19,7 +18,7 @@ func (m *PermissionMiddleware) Check() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		entityID, exists := c.Get("entity_id")
 		if !exists {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "entity not found in context"})
+			c.Error(sharedDomain.Unauthorized("missing_entity", "entity not found in context"))
 			c.Abort()
 			return
 		}
@@ -1,11 +1,16 @@
 package http
 
 import (
+	"time"
+
 	"github.com/gin-gonic/gin"
 	"torque-dms/adapters/input/http/handlers"
 	"torque-dms/adapters/input/http/middleware"
+	auditInput "torque-dms/core/audit/ports/input"
 	identityInput "torque-dms/core/identity/ports/input"
+	inventoryDomain "torque-dms/core/inventory/domain"
 	inventoryInput "torque-dms/core/inventory/ports/input"
+	privacyInput "torque-dms/core/privacy/ports/input"
 	salesInput "torque-dms/core/sales/ports/input"
 )
 
@@ -16,8 +21,21 @@ type Router struct {
 	permissionService identityInput.PermissionService
 	vehicleService    inventoryInput.VehicleService
 	locationService   inventoryInput.LocationService
+	occupancyService  inventoryInput.OccupancyService
+	geoService        inventoryInput.GeoService
+	trackingService   inventoryInput.TrackingService
+	routeService      inventoryInput.RouteService
+	geofenceService   inventoryInput.GeofenceService
+	model3DService    inventoryInput.Model3DService
+	damageService     inventoryInput.DamageService
+	inspectionService inventoryInput.InspectionService
+	reconService      inventoryInput.ReconService
+	costService       inventoryInput.CostService
+	pricingService    inventoryInput.PricingService
 	leadService       salesInput.LeadService
 	stepService       salesInput.StepService
+	privacyService    privacyInput.PrivacyService
+	auditService      auditInput.AuditService
 }
 
 func NewRouter(
@@ -26,9 +44,23 @@ func NewRouter(
 	permissionService identityInput.PermissionService,
 	vehicleService inventoryInput.VehicleService,
 	locationService inventoryInput.LocationService,
+	occupancyService inventoryInput.OccupancyService,
+	geoService inventoryInput.GeoService,
+	trackingService inventoryInput.TrackingService,
+	routeService inventoryInput.RouteService,
+	geofenceService inventoryInput.GeofenceService,
+	model3DService inventoryInput.Model3DService,
+	damageService inventoryInput.DamageService,
+	inspectionService inventoryInput.InspectionService,
+	reconService inventoryInput.ReconService,
+	costService inventoryInput.CostService,
+	pricingService inventoryInput.PricingService,
 	leadService salesInput.LeadService,
 	stepService salesInput.StepService,
+	privacyService privacyInput.PrivacyService,
+	auditService auditInput.AuditService,
 	jwtSecret string,
+	requestTimeout time.Duration,
 ) *Router {
 	r := &Router{
 		engine:            gin.Default(),
@@ -37,22 +69,46 @@ func NewRouter(
 		permissionService: permissionService,
 		vehicleService:    vehicleService,
 		locationService:   locationService,
+		occupancyService:  occupancyService,
+		geoService:        geoService,
+		trackingService:   trackingService,
+		routeService:      routeService,
+		geofenceService:   geofenceService,
+		model3DService:    model3DService,
+		damageService:     damageService,
+		inspectionService: inspectionService,
+		reconService:      reconService,
+		costService:       costService,
+		pricingService:    pricingService,
 		leadService:       leadService,
 		stepService:       stepService,
+		privacyService:    privacyService,
+		auditService:      auditService,
 	}
 
-	r.setupRoutes(jwtSecret)
+	r.setupRoutes(jwtSecret, requestTimeout)
 	return r
 }
 
-func (r *Router) setupRoutes(jwtSecret string) {
+func (r *Router) setupRoutes(jwtSecret string, requestTimeout time.Duration) {
 	// Handlers
 	authHandler := handlers.NewAuthHandler(r.authService)
 	entityHandler := handlers.NewEntityHandler(r.entityService)
 	vehicleHandler := handlers.NewVehicleHandler(r.vehicleService)
-	locationHandler := handlers.NewLocationHandler(r.locationService)
+	locationHandler := handlers.NewLocationHandler(r.locationService, r.occupancyService, r.geoService)
+	trackingHandler := handlers.NewTrackingHandler(r.trackingService)
+	routeHandler := handlers.NewRouteHandler(r.routeService)
+	geofenceHandler := handlers.NewGeofenceHandler(r.geofenceService)
+	model3DHandler := handlers.NewModel3DHandler(r.model3DService)
+	damageHandler := handlers.NewDamageHandler(r.damageService)
+	inspectionHandler := handlers.NewInspectionHandler(r.inspectionService)
+	reconHandler := handlers.NewReconHandler(r.reconService)
+	costHandler := handlers.NewCostHandler(r.costService)
+	pricingHandler := handlers.NewPricingHandler(r.pricingService)
 	leadHandler := handlers.NewLeadHandler(r.leadService, r.stepService)
 	stepHandler := handlers.NewStepHandler(r.stepService)
+	privacyHandler := handlers.NewPrivacyHandler(r.privacyService)
+	auditHandler := handlers.NewAuditHandler(r.auditService)
 
 	// Middleware
 	authMiddleware := middleware.NewAuthMiddleware(jwtSecret)
@@ -60,6 +116,9 @@ func (r *Router) setupRoutes(jwtSecret string) {
 
 	// Global middleware
 	r.engine.Use(middleware.CORS())
+	r.engine.Use(middleware.RequestContext())
+	r.engine.Use(middleware.Timeout(requestTimeout))
+	r.engine.Use(middleware.ErrorHandler())
 
 	// Health check
 	r.engine.GET("/health", func(c *gin.Context) {
@@ -88,31 +147,145 @@ func (r *Router) setupRoutes(jwtSecret string) {
 		protected.POST("/entities", entityHandler.Create)
 		protected.PUT("/entities/:id", entityHandler.Update)
 		protected.DELETE("/entities/:id", entityHandler.Delete)
+		protected.GET("/entities/trash", entityHandler.ListDeleted)
+		protected.POST("/entities/:id/restore", entityHandler.Restore)
 		protected.POST("/entities/:id/suspend", entityHandler.Suspend)
 		protected.POST("/entities/:id/activate", entityHandler.Activate)
 
+		// Privacy (GDPR/CCPA)
+		protected.GET("/entities/:id/export", privacyHandler.Export)
+		protected.POST("/entities/:id/erase", privacyHandler.Erase)
+
+		// Audit
+		protected.GET("/audit/aggregates/:type/:id", auditHandler.ListByAggregate)
+		protected.GET("/audit/actors/:id", auditHandler.ListByActor)
+
 		// Locations
 		protected.GET("/locations", locationHandler.List)
 		protected.GET("/locations/active", locationHandler.ListActive)
+		protected.GET("/locations/occupancy", locationHandler.GetOccupancySummary)
+		protected.GET("/locations/nearest", locationHandler.Nearest)
 		protected.GET("/locations/:id", locationHandler.GetByID)
+		protected.GET("/locations/:id/occupancy", locationHandler.GetOccupancy)
+		protected.GET("/locations/:id/distance", locationHandler.Distance)
 		protected.POST("/locations", locationHandler.Create)
 		protected.PUT("/locations/:id", locationHandler.Update)
 		protected.DELETE("/locations/:id", locationHandler.Delete)
 		protected.POST("/locations/:id/deactivate", locationHandler.Deactivate)
 		protected.POST("/locations/:id/activate", locationHandler.Activate)
+		protected.PUT("/locations/:id/geofence", locationHandler.SetGeofence)
+		protected.GET("/locations/:id/geofence-events", geofenceHandler.ListByLocation)
+
+		// Routes
+		protected.GET("/routes", routeHandler.List)
+		protected.GET("/routes/:id", routeHandler.GetByID)
+		protected.POST("/routes", routeHandler.Create)
+		protected.PUT("/routes/:id", routeHandler.Update)
+		protected.DELETE("/routes/:id", routeHandler.Delete)
+		protected.POST("/routes/:id/deactivate", routeHandler.Deactivate)
+		protected.POST("/routes/:id/activate", routeHandler.Activate)
+		protected.GET("/locations/:id/inbound", routeHandler.ListInbound)
 
 		// Vehicles
 		protected.GET("/vehicles", vehicleHandler.List)
 		protected.GET("/vehicles/available", vehicleHandler.ListAvailable)
+		protected.GET("/vehicles/search", vehicleHandler.Search)
 		protected.GET("/vehicles/:id", vehicleHandler.GetByID)
 		protected.GET("/vehicles/vin/:vin", vehicleHandler.GetByVIN)
+		protected.GET("/vehicles/decode/:vin", vehicleHandler.DecodeVIN)
 		protected.POST("/vehicles", vehicleHandler.Create)
 		protected.PUT("/vehicles/:id", vehicleHandler.Update)
 		protected.DELETE("/vehicles/:id", vehicleHandler.Delete)
+		protected.GET("/vehicles/trash", vehicleHandler.ListDeleted)
+		protected.POST("/vehicles/:id/restore", vehicleHandler.Restore)
 		protected.POST("/vehicles/:id/sold", vehicleHandler.MarkAsSold)
 		protected.POST("/vehicles/:id/ready", vehicleHandler.MarkAsReadyForSale)
 		protected.POST("/vehicles/:id/recon", vehicleHandler.SendToRecon)
+		protected.POST("/vehicles/:id/status", vehicleHandler.ChangeStatus)
+		protected.GET("/vehicles/:id/status/transitions", vehicleHandler.GetStatusTransitions)
+		protected.GET("/vehicles/:id/status-history", vehicleHandler.GetStatusHistory)
+		protected.POST("/vehicles/:id/unwind-sale", vehicleHandler.UnwindSale)
 		protected.POST("/vehicles/:id/location", vehicleHandler.ChangeLocation)
+		protected.GET("/vehicles/:id/location-history", vehicleHandler.GetLocationHistory)
+		protected.POST("/vehicles/:id/transfer", vehicleHandler.StartTransfer)
+		protected.POST("/vehicles/:id/transfer/arrive", vehicleHandler.ConfirmArrival)
+
+		// Vehicle Tracking
+		protected.POST("/tracking/pings", trackingHandler.Ingest)
+		protected.PUT("/vehicles/:id/tracking-device", trackingHandler.AssignDevice)
+		protected.GET("/vehicles/:id/position", trackingHandler.GetLastPosition)
+		protected.GET("/vehicles/:id/track", trackingHandler.GetTrack)
+		protected.GET("/vehicles/:id/route-progress", routeHandler.GetProgress)
+		protected.GET("/vehicles/:id/geofence-events", geofenceHandler.ListByVehicle)
+
+		// 3D Models
+		protected.GET("/models-3d", model3DHandler.List)
+		protected.GET("/models-3d/:id", model3DHandler.GetByID)
+		protected.POST("/models-3d", model3DHandler.Create)
+		protected.PUT("/models-3d/:id", model3DHandler.Update)
+		protected.DELETE("/models-3d/:id", model3DHandler.Delete)
+		protected.POST("/models-3d/:id/deactivate", model3DHandler.Deactivate)
+		protected.POST("/models-3d/:id/activate", model3DHandler.Activate)
+		protected.POST("/models-3d/:id/default", model3DHandler.SetDefault)
+		protected.PUT("/models-3d/:id/file", model3DHandler.UploadFile(inventoryDomain.ModelFileMesh))
+		protected.PUT("/models-3d/:id/thumbnail", model3DHandler.UploadFile(inventoryDomain.ModelFileThumbnail))
+		protected.GET("/models-3d/:id/zones", model3DHandler.ListZones)
+		protected.POST("/models-3d/:id/zones", model3DHandler.AddZone)
+		protected.PUT("/models-3d/:id/zones/:zoneId", model3DHandler.UpdateZone)
+		protected.DELETE("/models-3d/:id/zones/:zoneId", model3DHandler.DeleteZone)
+		protected.PUT("/vehicles/:id/model-3d", model3DHandler.AssignToVehicle)
+
+		// Vehicle Damage
+		protected.GET("/vehicles/:id/damage-marks", damageHandler.ListMarks)
+		protected.POST("/vehicles/:id/damage-marks", damageHandler.RecordMark)
+		protected.PUT("/vehicles/:id/damage-marks/:markId/photo", damageHandler.AttachPhoto)
+		protected.POST("/vehicles/:id/damage-marks/:markId/resolve", damageHandler.ResolveMark)
+		protected.GET("/vehicles/:id/condition-report", damageHandler.GetConditionReport)
+
+		// Inspections
+		protected.GET("/inspection-templates", inspectionHandler.ListTemplates)
+		protected.POST("/inspection-templates", inspectionHandler.CreateTemplate)
+		protected.GET("/inspection-templates/:id", inspectionHandler.GetTemplate)
+		protected.PUT("/inspection-templates/:id", inspectionHandler.UpdateTemplate)
+		protected.DELETE("/inspection-templates/:id", inspectionHandler.DeleteTemplate)
+		protected.POST("/inspection-templates/:id/deactivate", inspectionHandler.DeactivateTemplate)
+		protected.POST("/inspection-templates/:id/activate", inspectionHandler.ActivateTemplate)
+		protected.GET("/vehicles/:id/inspections", inspectionHandler.ListByVehicle)
+		protected.POST("/vehicles/:id/inspections", inspectionHandler.Start)
+		protected.GET("/inspections/:id", inspectionHandler.GetByID)
+		protected.PUT("/inspections/:id/results", inspectionHandler.RecordResults)
+		protected.POST("/inspections/:id/complete", inspectionHandler.Complete)
+
+		// Recon
+		protected.GET("/recon-orders", reconHandler.List)
+		protected.GET("/recon-orders/stats", reconHandler.GetCycleStats)
+		protected.GET("/recon-orders/:id", reconHandler.GetByID)
+		protected.POST("/recon-orders/:id/close", reconHandler.Close)
+		protected.POST("/recon-orders/:id/tasks", reconHandler.AddTask)
+		protected.PUT("/recon-orders/:id/tasks/:taskId", reconHandler.UpdateTask)
+		protected.POST("/recon-orders/:id/tasks/:taskId/approve", reconHandler.ApproveTask)
+		protected.POST("/recon-orders/:id/tasks/:taskId/start", reconHandler.StartTask)
+		protected.POST("/recon-orders/:id/tasks/:taskId/complete", reconHandler.CompleteTask)
+		protected.POST("/recon-orders/:id/tasks/:taskId/cancel", reconHandler.CancelTask)
+		protected.GET("/vehicles/:id/recon-orders", reconHandler.ListByVehicle)
+
+		// Costes
+		protected.GET("/vehicles/:id/cost-ledger", costHandler.GetLedger)
+		protected.GET("/vehicles/:id/costs", costHandler.ListEntries)
+		protected.POST("/vehicles/:id/costs", costHandler.AddEntry)
+		protected.POST("/vehicles/:id/costs/:entryId/void", costHandler.VoidEntry)
+
+		// Antigüedad y precios
+		protected.GET("/inventory/aging", pricingHandler.GetAging)
+		protected.GET("/vehicles/:id/price-history", pricingHandler.GetPriceHistory)
+		protected.GET("/repricing-rules", pricingHandler.ListRules)
+		protected.GET("/repricing-rules/preview", pricingHandler.PreviewRepricing)
+		protected.GET("/repricing-rules/:id", pricingHandler.GetRule)
+		protected.POST("/repricing-rules", pricingHandler.CreateRule)
+		protected.PUT("/repricing-rules/:id", pricingHandler.UpdateRule)
+		protected.DELETE("/repricing-rules/:id", pricingHandler.DeleteRule)
+		protected.POST("/repricing-rules/:id/activate", pricingHandler.ActivateRule)
+		protected.POST("/repricing-rules/:id/deactivate", pricingHandler.DeactivateRule)
 
 		// Vehicle Photos
 		protected.GET("/vehicles/:id/photos", vehicleHandler.GetPhotos)
@@ -133,6 +306,8 @@ func (r *Router) setupRoutes(jwtSecret string) {
 		protected.POST("/leads", leadHandler.Create)
 		protected.PUT("/leads/:id", leadHandler.Update)
 		protected.DELETE("/leads/:id", leadHandler.Delete)
+		protected.GET("/leads/trash", leadHandler.ListDeleted)
+		protected.POST("/leads/:id/restore", leadHandler.Restore)
 
 		// Lead Assignments
 		protected.GET("/leads/:id/assignments", leadHandler.GetAssignments)
@@ -164,6 +339,8 @@ func (r *Router) setupRoutes(jwtSecret string) {
 		protected.GET("/presets/:id", stepHandler.GetPreset)
 		protected.POST("/presets", stepHandler.CreatePreset)
 		protected.DELETE("/presets/:id", stepHandler.DeletePreset)
+		protected.GET("/presets/trash", stepHandler.GetDeletedPresets)
+		protected.POST("/presets/:id/restore", stepHandler.RestorePreset)
 		protected.POST("/presets/:id/public", stepHandler.MakePresetPublic)
 		protected.POST("/presets/:id/shared", stepHandler.MakePresetShared)
 		protected.POST("/presets/:id/private", stepHandler.MakePresetPrivate)
@@ -177,6 +354,11 @@ func (r *Router) setupRoutes(jwtSecret string) {
 	}
 }
 
+// ServeFiles - sirve los archivos subidos al storage local
+func (r *Router) ServeFiles(prefix string, dir string) {
+	r.engine.Static(prefix, dir)
+}
+
 func (r *Router) Run(addr string) error {
 	return r.engine.Run(addr)
 }
\ No newline at end of file
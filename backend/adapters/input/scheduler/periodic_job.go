@@ -0,0 +1,60 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// PeriodicJob - tarea de mantenimiento que se repite cada intervalo (compactar telemetría,
+// aplicar rebajas...). La tarea recibe la hora de la pasada y devuelve cuántos registros tocó
+type PeriodicJob struct {
+	name     string
+	report   string
+	interval time.Duration
+	task     func(ctx context.Context, now time.Time) (int, error)
+	now      func() time.Time
+}
+
+// NewPeriodicJob - report es el formato del log cuando la pasada toca registros, con un %d
+func NewPeriodicJob(name string, report string, interval time.Duration, task func(ctx context.Context, now time.Time) (int, error)) *PeriodicJob {
+	return &PeriodicJob{
+		name:     name,
+		report:   report,
+		interval: interval,
+		task:     task,
+		now:      time.Now,
+	}
+}
+
+// Run ejecuta la tarea al arrancar y después en cada intervalo hasta que se cancele el contexto
+func (j *PeriodicJob) Run(ctx context.Context) {
+	runEvery(ctx, j.interval, func(ctx context.Context) { j.RunOnce(ctx) })
+}
+
+func (j *PeriodicJob) RunOnce(ctx context.Context) int {
+	n, err := j.task(ctx, j.now())
+	if err != nil {
+		log.Printf("%s: %v", j.name, err)
+	}
+	if n > 0 {
+		log.Printf(j.report, n)
+	}
+	return n
+}
+
+// runEvery - fn al arrancar y en cada tick; común a todos los jobs del scheduler
+func runEvery(ctx context.Context, interval time.Duration, fn func(ctx context.Context)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		fn(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestPeriodicJobRunOncePassesCurrentTime(t *testing.T) {
+	now := time.Date(2024, 3, 31, 12, 0, 0, 0, time.UTC)
+	var got time.Time
+
+	job := NewPeriodicJob("Tracking compaction", "Compacted %d tracking points", time.Hour, func(_ context.Context, at time.Time) (int, error) {
+		got = at
+		return 5, nil
+	})
+	job.now = func() time.Time { return now }
+
+	if n := job.RunOnce(context.Background()); n != 5 {
+		t.Errorf("RunOnce() = %d, want 5", n)
+	}
+	if !got.Equal(now) {
+		t.Errorf("task got %s, want %s", got, now)
+	}
+}
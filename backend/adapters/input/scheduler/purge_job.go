@@ -0,0 +1,53 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// PurgeTarget es un agregado cuya papelera se vacía periódicamente
+type PurgeTarget struct {
+	Name  string
+	Purge func(ctx context.Context, deletedBefore time.Time) (int, error)
+}
+
+type PurgeJob struct {
+	retention time.Duration
+	interval  time.Duration
+	targets   []PurgeTarget
+	now       func() time.Time
+}
+
+func NewPurgeJob(retention, interval time.Duration, targets ...PurgeTarget) *PurgeJob {
+	return &PurgeJob{
+		retention: retention,
+		interval:  interval,
+		targets:   targets,
+		now:       time.Now,
+	}
+}
+
+// Run purga al arrancar y después en cada intervalo hasta que se cancele el contexto
+func (j *PurgeJob) Run(ctx context.Context) {
+	runEvery(ctx, j.interval, func(ctx context.Context) { j.RunOnce(ctx) })
+}
+
+// RunOnce purga los registros borrados antes del corte de retención.
+// Los targets se procesan en orden: los hijos deben ir antes que sus padres
+func (j *PurgeJob) RunOnce(ctx context.Context) map[string]int {
+	cutoff := j.now().Add(-j.retention)
+	purged := make(map[string]int, len(j.targets))
+
+	for _, target := range j.targets {
+		n, err := target.Purge(ctx, cutoff)
+		purged[target.Name] = n
+		if err != nil {
+			log.Printf("Trash purge of %s: %v", target.Name, err)
+		}
+		if n > 0 {
+			log.Printf("Purged %d %s deleted before %s", n, target.Name, cutoff.Format(time.RFC3339))
+		}
+	}
+	return purged
+}
@@ -0,0 +1,44 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRunOncePurgesEveryTargetWithRetentionCutoff(t *testing.T) {
+	now := time.Date(2024, 3, 31, 12, 0, 0, 0, time.UTC)
+	var order []string
+	var cutoffs []time.Time
+
+	target := func(name string, n int, err error) PurgeTarget {
+		return PurgeTarget{Name: name, Purge: func(_ context.Context, before time.Time) (int, error) {
+			order = append(order, name)
+			cutoffs = append(cutoffs, before)
+			return n, err
+		}}
+	}
+
+	job := NewPurgeJob(30*24*time.Hour, time.Hour,
+		target("leads", 2, nil),
+		target("entities", 1, errors.New("entity 7: still referenced")),
+		target("vehicles", 0, nil),
+	)
+	job.now = func() time.Time { return now }
+
+	purged := job.RunOnce(context.Background())
+
+	if got := len(order); got != 3 || order[0] != "leads" || order[1] != "entities" || order[2] != "vehicles" {
+		t.Fatalf("targets ran in order %v, want [leads entities vehicles]", order)
+	}
+	want := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	for i, c := range cutoffs {
+		if !c.Equal(want) {
+			t.Errorf("target %s got cutoff %s, want %s", order[i], c, want)
+		}
+	}
+	if purged["leads"] != 2 || purged["entities"] != 1 || purged["vehicles"] != 0 {
+		t.Errorf("purged = %v", purged)
+	}
+}
@@ -0,0 +1,130 @@
+package geodata
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"torque-dms/core/inventory/ports/output"
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+//go:embed postal_codes.csv
+var embeddedData []byte
+
+type geocoder struct {
+	// código postal -> país -> centroide
+	points map[string]map[string]sharedDomain.GeoPoint
+}
+
+// NewGeocoder - carga el dataset embebido; si path no está vacío, sus filas se añaden
+// encima (mismo país y código postal se sobrescriben) para ampliarlo sin recompilar
+func NewGeocoder(path string) (output.Geocoder, error) {
+	g := &geocoder{points: map[string]map[string]sharedDomain.GeoPoint{}}
+	if err := g.load(bytes.NewReader(embeddedData)); err != nil {
+		return nil, fmt.Errorf("failed to parse embedded postal codes: %w", err)
+	}
+
+	if path != "" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read postal codes: %w", err)
+		}
+		defer f.Close()
+		if err := g.load(f); err != nil {
+			return nil, fmt.Errorf("failed to parse postal codes: %w", err)
+		}
+	}
+
+	return g, nil
+}
+
+func (g *geocoder) GeocodePostalCode(ctx context.Context, country string, postalCode string) (sharedDomain.GeoPoint, error) {
+	byCountry, ok := g.points[normalizePostalCode(postalCode)]
+	if !ok {
+		return sharedDomain.GeoPoint{}, sharedDomain.NotFound("postal code")
+	}
+
+	country = strings.ToUpper(strings.TrimSpace(country))
+	if country == "" {
+		if len(byCountry) > 1 {
+			return sharedDomain.GeoPoint{}, sharedDomain.Invalid("country", "postal code exists in several countries, country is required")
+		}
+		for _, point := range byCountry {
+			return point, nil
+		}
+	}
+
+	point, ok := byCountry[country]
+	if !ok {
+		return sharedDomain.GeoPoint{}, sharedDomain.NotFound("postal code")
+	}
+	return point, nil
+}
+
+func (g *geocoder) load(r io.Reader) error {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+
+	header := true
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if header {
+			header = false
+			continue
+		}
+		if len(record) < 4 {
+			line, _ := reader.FieldPos(0)
+			return fmt.Errorf("line %d: expected country,postal_code,latitude,longitude", line)
+		}
+
+		lat, errLat := strconv.ParseFloat(strings.TrimSpace(record[2]), 64)
+		lng, errLng := strconv.ParseFloat(strings.TrimSpace(record[3]), 64)
+		if errLat != nil || errLng != nil {
+			line, _ := reader.FieldPos(0)
+			return fmt.Errorf("line %d: invalid coordinates", line)
+		}
+		point, err := sharedDomain.NewGeoPoint(lat, lng)
+		if err != nil {
+			line, _ := reader.FieldPos(0)
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+
+		code := normalizePostalCode(record[1])
+		if g.points[code] == nil {
+			g.points[code] = map[string]sharedDomain.GeoPoint{}
+		}
+		g.points[code][strings.ToUpper(strings.TrimSpace(record[0]))] = point
+	}
+}
+
+// normalizePostalCode - sin espacios ni guiones y en mayúsculas; los ZIP+4 se quedan en 5
+func normalizePostalCode(code string) string {
+	code = strings.ToUpper(strings.NewReplacer(" ", "", "-", "").Replace(strings.TrimSpace(code)))
+	if len(code) == 9 && isDigits(code) {
+		code = code[:5]
+	}
+	return code
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
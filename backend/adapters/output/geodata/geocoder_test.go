@@ -0,0 +1,54 @@
+package geodata
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+func TestGeocodeWithEmbeddedData(t *testing.T) {
+	g, err := NewGeocoder("")
+	if err != nil {
+		t.Fatalf("NewGeocoder() error = %v", err)
+	}
+
+	point, err := g.GeocodePostalCode(context.Background(), "us", "10001-2345")
+	if err != nil {
+		t.Fatalf("GeocodePostalCode() error = %v", err)
+	}
+	if point.Latitude != 40.7506 || point.Longitude != -73.9972 {
+		t.Errorf("GeocodePostalCode() = %+v", point)
+	}
+
+	if _, err := g.GeocodePostalCode(context.Background(), "US", "00000"); !sharedDomain.IsNotFound(err) {
+		t.Errorf("GeocodePostalCode() error = %v, want not found", err)
+	}
+}
+
+func TestGeocodeWithOverrideFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "postal.csv")
+	data := "country,postal_code,latitude,longitude\nUS,99999,61.2181,-149.9003\nCA,99999,43.6532,-79.3832\n"
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	g, err := NewGeocoder(path)
+	if err != nil {
+		t.Fatalf("NewGeocoder() error = %v", err)
+	}
+
+	point, err := g.GeocodePostalCode(context.Background(), "CA", "99999")
+	if err != nil {
+		t.Fatalf("GeocodePostalCode() error = %v", err)
+	}
+	if point.Latitude != 43.6532 {
+		t.Errorf("GeocodePostalCode() = %+v, want the CA entry", point)
+	}
+
+	if _, err := g.GeocodePostalCode(context.Background(), "", "99999"); err == nil {
+		t.Error("GeocodePostalCode() expected error for ambiguous postal code without country")
+	}
+}
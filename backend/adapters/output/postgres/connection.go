@@ -10,10 +10,13 @@ import (
 func NewConnection() (*gorm.DB, error) {
 	dsn := os.Getenv("DATABASE_URL")
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{TranslateError: true})
 	if err != nil {
 		return nil, err
 	}
+	if err := RegisterErrorTranslation(db); err != nil {
+		return nil, err
+	}
 
 	return db, nil
 }
\ No newline at end of file
@@ -0,0 +1,41 @@
+package postgres
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+var (
+	// ErrDuplicateKey - 23505: otra fila ya tiene ese valor único
+	ErrDuplicateKey = sharedDomain.ErrDuplicateKey
+	// ErrForeignKey - 23503: la fila apunta a otra que no existe o la borrada sigue referenciada
+	ErrForeignKey = sharedDomain.Invariant("foreign_key_violation", "record references a missing record or is still referenced")
+)
+
+// RegisterErrorTranslation - las violaciones de constraints que se escapan a las comprobaciones
+// de los services salen como errores de dominio y no como un 500. Necesita
+// gorm.Config{TranslateError: true} para que GORM reconozca los códigos de Postgres
+func RegisterErrorTranslation(db *gorm.DB) error {
+	callbacks := db.Callback()
+	if err := callbacks.Create().After("gorm:create").Register("torque:translate_error", translateError); err != nil {
+		return err
+	}
+	if err := callbacks.Update().After("gorm:update").Register("torque:translate_error", translateError); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().After("gorm:delete").Register("torque:translate_error", translateError); err != nil {
+		return err
+	}
+	return callbacks.Raw().After("gorm:raw").Register("torque:translate_error", translateError)
+}
+
+func translateError(db *gorm.DB) {
+	switch {
+	case errors.Is(db.Error, gorm.ErrDuplicatedKey):
+		db.Error = ErrDuplicateKey
+	case errors.Is(db.Error, gorm.ErrForeignKeyViolated):
+		db.Error = ErrForeignKey
+	}
+}
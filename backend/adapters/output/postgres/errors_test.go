@@ -0,0 +1,40 @@
+package postgres
+
+import (
+	"errors"
+	"testing"
+
+	"gorm.io/gorm"
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+func TestTranslateError(t *testing.T) {
+	other := errors.New("connection reset")
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"unique violation", gorm.ErrDuplicatedKey, ErrDuplicateKey},
+		{"foreign key violation", gorm.ErrForeignKeyViolated, ErrForeignKey},
+		{"other error", other, other},
+		{"no error", nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &gorm.DB{Error: tt.err}
+			translateError(db)
+			if db.Error != tt.want {
+				t.Errorf("translateError() = %v, want %v", db.Error, tt.want)
+			}
+		})
+	}
+
+	if err, ok := sharedDomain.AsError(ErrDuplicateKey); !ok || err.Kind != sharedDomain.KindConflict {
+		t.Errorf("ErrDuplicateKey kind = %v, want conflict", err)
+	}
+	if err, ok := sharedDomain.AsError(ErrForeignKey); !ok || err.Kind != sharedDomain.KindInvariant {
+		t.Errorf("ErrForeignKey kind = %v, want invariant", err)
+	}
+}
@@ -0,0 +1,103 @@
+package migrations
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	identityDomain "torque-dms/core/identity/domain"
+	inventoryDomain "torque-dms/core/inventory/domain"
+	salesDomain "torque-dms/core/sales/domain"
+)
+
+var checkPattern = regexp.MustCompile(`ADD CONSTRAINT "(chk_\w+)" CHECK \([^)]*\bIN \(([^)]*)\)\);`)
+
+// checkConstraints devuelve los valores permitidos por cada CHECK, aplicando
+// las migraciones en orden para que la última definición prevalezca
+func checkConstraints(t *testing.T) map[string][]string {
+	t.Helper()
+
+	migrations, err := load(files, "sql")
+	if err != nil {
+		t.Fatalf("load() error = %v", err)
+	}
+
+	checks := make(map[string][]string)
+	for _, m := range migrations {
+		for _, match := range checkPattern.FindAllStringSubmatch(m.Up, -1) {
+			var values []string
+			for _, v := range strings.Split(match[2], ",") {
+				values = append(values, strings.Trim(strings.TrimSpace(v), "'"))
+			}
+			checks[match[1]] = values
+		}
+	}
+	return checks
+}
+
+func values[T ~string](list []T) []string {
+	out := make([]string, len(list))
+	for i, v := range list {
+		out[i] = string(v)
+	}
+	return out
+}
+
+func TestCheckConstraintsMatchDomainEnums(t *testing.T) {
+	expected := map[string][]string{
+		"chk_entities_type":                        values(identityDomain.EntityTypes()),
+		"chk_entities_status":                      values(identityDomain.EntityStatuses()),
+		"chk_user_accounts_status":                 values(identityDomain.EntityStatuses()),
+		"chk_role_resources_scope":                 values(identityDomain.AccessScopes()),
+		"chk_entity_resources_scope":               values(identityDomain.AccessScopes()),
+		"chk_locations_type":                       values(inventoryDomain.LocationTypes()),
+		"chk_vehicles_condition":                   values(inventoryDomain.VehicleConditions()),
+		"chk_vehicles_status":                      values(inventoryDomain.VehicleStatuses()),
+		"chk_vehicle_status_histories_from_status": values(inventoryDomain.VehicleStatuses()),
+		"chk_vehicle_status_histories_to_status":   values(inventoryDomain.VehicleStatuses()),
+		"chk_vehicles_lot_type":                    values(inventoryDomain.LotTypes()),
+		"chk_vehicles_acquisition_source":          values(inventoryDomain.AcquisitionSources()),
+		"chk_vehicle_photos_perspective":           values(inventoryDomain.PhotoPerspectives()),
+		"chk_vehicle_photos_purpose":               values(inventoryDomain.PhotoPurposes()),
+		"chk_vehicle_transfers_status":             values(inventoryDomain.TransferStatuses()),
+		"chk_vehicle_model3_ds_body_type":          values(inventoryDomain.BodyTypes()),
+		"chk_vehicle_zone_marks_type":              values(inventoryDomain.DamageTypes()),
+		"chk_vehicle_zone_marks_severity":          values(inventoryDomain.DamageSeverities()),
+		"chk_inspection_templates_kind":            values(inventoryDomain.InspectionKinds()),
+		"chk_inspections_kind":                     values(inventoryDomain.InspectionKinds()),
+		"chk_inspections_status":                   values(inventoryDomain.InspectionStatuses()),
+		"chk_inspection_results_result":            values(inventoryDomain.ItemResults()),
+		"chk_recon_orders_status":                  values(inventoryDomain.ReconOrderStatuses()),
+		"chk_recon_tasks_type":                     values(inventoryDomain.ReconTaskTypes()),
+		"chk_recon_tasks_status":                   values(inventoryDomain.ReconTaskStatuses()),
+		"chk_vehicle_cost_entries_type":            values(inventoryDomain.CostEntryTypes()),
+		"chk_repricing_rules_condition":            values(inventoryDomain.VehicleConditions()),
+		"chk_repricing_rules_lot_type":             values(inventoryDomain.LotTypes()),
+		"chk_vehicle_price_changes_source":         values(inventoryDomain.PriceChangeSources()),
+		"chk_geofence_events_type":                 values(inventoryDomain.GeofenceEventTypes()),
+		"chk_geofence_events_outcome":              values(inventoryDomain.GeofenceOutcomes()),
+		"chk_lead_step_progresses_status":          values(salesDomain.StepStatuses()),
+		"chk_lead_assignments_role":                values(salesDomain.AssignmentRoles()),
+		"chk_lead_activities_type":                 values(salesDomain.ActivityTypes()),
+	}
+
+	checks := checkConstraints(t)
+	for name, want := range expected {
+		got, ok := checks[name]
+		if !ok {
+			t.Errorf("missing check constraint %s", name)
+			continue
+		}
+		sort.Strings(got)
+		sort.Strings(want)
+		if strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("%s allows %v, domain defines %v", name, got, want)
+		}
+	}
+	for name := range checks {
+		if _, ok := expected[name]; !ok {
+			t.Errorf("check constraint %s is not covered by this test", name)
+		}
+	}
+}
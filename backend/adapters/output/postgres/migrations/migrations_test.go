@@ -0,0 +1,59 @@
+package migrations
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEmbeddedMigrations(t *testing.T) {
+	migrations, err := load(files, "sql")
+	if err != nil {
+		t.Fatalf("load() error = %v", err)
+	}
+	if len(migrations) == 0 {
+		t.Fatal("no embedded migrations")
+	}
+
+	for i, m := range migrations {
+		if i > 0 && m.Version <= migrations[i-1].Version {
+			t.Errorf("migration %d is out of order", m.Version)
+		}
+		if m.Down == "" {
+			t.Errorf("migration %04d_%s has no down script", m.Version, m.Name)
+		}
+	}
+}
+
+func TestCreate(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name     string
+		input    string
+		wantFile string
+		wantErr  bool
+	}{
+		{"first migration", "Add vehicle index", "0001_add_vehicle_index.up.sql", false},
+		{"next version", "drop-legacy", "0002_drop_legacy.up.sql", false},
+		{"empty name", "  ", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upPath, _, err := Create(dir, tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if filepath.Base(upPath) != tt.wantFile {
+				t.Errorf("Create() = %s, want %s", filepath.Base(upPath), tt.wantFile)
+			}
+			if _, err := os.Stat(upPath); err != nil {
+				t.Errorf("up file not written: %v", err)
+			}
+		})
+	}
+}
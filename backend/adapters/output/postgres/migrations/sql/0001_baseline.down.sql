@@ -0,0 +1,30 @@
+DROP TRIGGER IF EXISTS audit_logs_append_only ON audit_logs;
+DROP FUNCTION IF EXISTS audit_logs_append_only();
+
+DROP TABLE IF EXISTS "audit_logs";
+DROP TABLE IF EXISTS "lead_activities";
+DROP TABLE IF EXISTS "lead_notes";
+DROP TABLE IF EXISTS "lead_assignments";
+DROP TABLE IF EXISTS "lead_step_progresses";
+DROP TABLE IF EXISTS "leads";
+DROP TABLE IF EXISTS "lead_steps";
+DROP TABLE IF EXISTS "lead_step_presets";
+DROP TABLE IF EXISTS "lead_sources";
+DROP TABLE IF EXISTS "vehicle_zone_marks";
+DROP TABLE IF EXISTS "vehicle_photos";
+DROP TABLE IF EXISTS "vehicle_trackings";
+DROP TABLE IF EXISTS "vehicle_location_histories";
+DROP TABLE IF EXISTS "vehicles";
+DROP TABLE IF EXISTS "vehicle_model_zones";
+DROP TABLE IF EXISTS "vehicle_model3_ds";
+DROP TABLE IF EXISTS "entity_roles";
+DROP TABLE IF EXISTS "entity_resources";
+DROP TABLE IF EXISTS "role_resources";
+DROP TABLE IF EXISTS "roles";
+DROP TABLE IF EXISTS "resources";
+DROP TABLE IF EXISTS "entity_phones";
+DROP TABLE IF EXISTS "user_accounts";
+DROP TABLE IF EXISTS "entities";
+DROP TABLE IF EXISTS "routes";
+DROP TABLE IF EXISTS "locations";
+DROP TABLE IF EXISTS "countries";
@@ -0,0 +1,434 @@
+-- Baseline: reproduce el esquema que generaba AutoMigrate a partir de models/.
+-- Usa IF NOT EXISTS para poder adoptarse en bases ya creadas con AutoMigrate.
+
+-- Geo
+
+CREATE TABLE IF NOT EXISTS "countries" (
+    "id" bigserial,
+    "iso_code" varchar(2),
+    "iso_code3" varchar(3),
+    "name" text,
+    "phone_code" text,
+    "currency_code" text,
+    "flag_emoji" text,
+    "active" boolean DEFAULT true,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "uni_countries_iso_code" UNIQUE ("iso_code"),
+    CONSTRAINT "uni_countries_iso_code3" UNIQUE ("iso_code3")
+);
+
+CREATE TABLE IF NOT EXISTS "locations" (
+    "id" bigserial,
+    "name" text,
+    "type" text,
+    "address" text,
+    "city" text,
+    "state" text,
+    "zip" text,
+    "country_id" bigint,
+    "latitude" decimal(10,8),
+    "longitude" decimal(11,8),
+    "capacity" bigint,
+    "active" boolean DEFAULT true,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_locations_country" FOREIGN KEY ("country_id") REFERENCES "countries"("id")
+);
+
+CREATE TABLE IF NOT EXISTS "routes" (
+    "id" bigserial,
+    "name" text,
+    "from_location_id" bigint,
+    "to_location_id" bigint,
+    "distance_km" decimal,
+    "estimated_minutes" bigint,
+    "waypoints" json,
+    "active" boolean DEFAULT true,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_routes_from_location" FOREIGN KEY ("from_location_id") REFERENCES "locations"("id"),
+    CONSTRAINT "fk_routes_to_location" FOREIGN KEY ("to_location_id") REFERENCES "locations"("id")
+);
+
+-- Users
+
+CREATE TABLE IF NOT EXISTS "entities" (
+    "id" bigserial,
+    "type" text,
+    "first_name" text,
+    "last_name" text,
+    "business_name" text,
+    "tax_id" text,
+    "email" text,
+    "address" text,
+    "city" text,
+    "state" text,
+    "zip" text,
+    "country_id" bigint,
+    "is_system_user" boolean DEFAULT false,
+    "is_internal" boolean DEFAULT false,
+    "parent_entity_id" bigint,
+    "status" text DEFAULT 'active',
+    "erased_at" timestamptz,
+    "created_at" timestamptz,
+    "modified_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_entities_parent_entity" FOREIGN KEY ("parent_entity_id") REFERENCES "entities"("id"),
+    CONSTRAINT "fk_entities_country" FOREIGN KEY ("country_id") REFERENCES "countries"("id")
+);
+
+CREATE TABLE IF NOT EXISTS "user_accounts" (
+    "id" bigserial,
+    "entity_id" bigint,
+    "username" text,
+    "password_hash" text,
+    "last_login" timestamptz,
+    "status" text DEFAULT 'active',
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_user_accounts_entity" FOREIGN KEY ("entity_id") REFERENCES "entities"("id"),
+    CONSTRAINT "uni_user_accounts_entity_id" UNIQUE ("entity_id"),
+    CONSTRAINT "uni_user_accounts_username" UNIQUE ("username")
+);
+
+CREATE TABLE IF NOT EXISTS "entity_phones" (
+    "id" bigserial,
+    "entity_id" bigint,
+    "country_id" bigint,
+    "number" text,
+    "extension" text,
+    "type" text,
+    "is_primary" boolean DEFAULT false,
+    "verified" boolean DEFAULT false,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_entity_phones_entity" FOREIGN KEY ("entity_id") REFERENCES "entities"("id"),
+    CONSTRAINT "fk_entity_phones_country" FOREIGN KEY ("country_id") REFERENCES "countries"("id")
+);
+
+CREATE TABLE IF NOT EXISTS "resources" (
+    "id" bigserial,
+    "code" text,
+    "name" text,
+    "url_pattern" text,
+    "method" text,
+    "module" text,
+    "ownership_field" text,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "uni_resources_code" UNIQUE ("code")
+);
+
+CREATE TABLE IF NOT EXISTS "roles" (
+    "id" bigserial,
+    "name" text,
+    "description" text,
+    "is_system_role" boolean DEFAULT false,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "uni_roles_name" UNIQUE ("name")
+);
+
+CREATE TABLE IF NOT EXISTS "role_resources" (
+    "id" bigserial,
+    "role_id" bigint,
+    "resource_id" bigint,
+    "scope" text DEFAULT 'none',
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_role_resources_role" FOREIGN KEY ("role_id") REFERENCES "roles"("id"),
+    CONSTRAINT "fk_role_resources_resource" FOREIGN KEY ("resource_id") REFERENCES "resources"("id")
+);
+
+CREATE TABLE IF NOT EXISTS "entity_resources" (
+    "id" bigserial,
+    "entity_id" bigint,
+    "resource_id" bigint,
+    "scope" text DEFAULT 'none',
+    "assigned_by" bigint,
+    "reason" text,
+    "expires_at" timestamptz,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_entity_resources_entity" FOREIGN KEY ("entity_id") REFERENCES "entities"("id"),
+    CONSTRAINT "fk_entity_resources_resource" FOREIGN KEY ("resource_id") REFERENCES "resources"("id")
+);
+
+CREATE TABLE IF NOT EXISTS "entity_roles" (
+    "id" bigserial,
+    "entity_id" bigint,
+    "role_id" bigint,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_entity_roles_entity" FOREIGN KEY ("entity_id") REFERENCES "entities"("id"),
+    CONSTRAINT "fk_entity_roles_role" FOREIGN KEY ("role_id") REFERENCES "roles"("id")
+);
+
+-- Inventory
+
+CREATE TABLE IF NOT EXISTS "vehicle_model3_ds" (
+    "id" bigserial,
+    "name" text,
+    "body_type" text,
+    "file_url" text,
+    "thumbnail_url" text,
+    "active" boolean DEFAULT true,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "vehicle_model_zones" (
+    "id" bigserial,
+    "model3_d_id" bigint,
+    "code" text,
+    "name" text,
+    "mesh_id" text,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_vehicle_model_zones_model3_d" FOREIGN KEY ("model3_d_id") REFERENCES "vehicle_model3_ds"("id")
+);
+
+CREATE TABLE IF NOT EXISTS "vehicles" (
+    "id" bigserial,
+    "stock_number" text,
+    "vin" text,
+    "plate" text,
+    "make" text,
+    "model" text,
+    "trim" text,
+    "year" bigint,
+    "mileage" bigint,
+    "exterior_color" text,
+    "interior_color" text,
+    "msrp" decimal,
+    "invoice_price" decimal,
+    "asking_price" decimal,
+    "condition" text,
+    "status" text,
+    "lot_type" text,
+    "location_id" bigint,
+    "acquisition_source" text,
+    "acquisition_date" timestamptz,
+    "acquisition_cost" decimal,
+    "model3_d_id" bigint,
+    "created_at" timestamptz,
+    "modified_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_vehicles_location" FOREIGN KEY ("location_id") REFERENCES "locations"("id"),
+    CONSTRAINT "fk_vehicles_model3_d" FOREIGN KEY ("model3_d_id") REFERENCES "vehicle_model3_ds"("id"),
+    CONSTRAINT "uni_vehicles_stock_number" UNIQUE ("stock_number"),
+    CONSTRAINT "uni_vehicles_vin" UNIQUE ("vin")
+);
+
+CREATE TABLE IF NOT EXISTS "vehicle_location_histories" (
+    "id" bigserial,
+    "vehicle_id" bigint,
+    "from_location_id" bigint,
+    "to_location_id" bigint,
+    "moved_by" bigint,
+    "reason" text,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_vehicle_location_histories_vehicle" FOREIGN KEY ("vehicle_id") REFERENCES "vehicles"("id"),
+    CONSTRAINT "fk_vehicle_location_histories_from_location" FOREIGN KEY ("from_location_id") REFERENCES "locations"("id"),
+    CONSTRAINT "fk_vehicle_location_histories_to_location" FOREIGN KEY ("to_location_id") REFERENCES "locations"("id")
+);
+
+CREATE TABLE IF NOT EXISTS "vehicle_trackings" (
+    "id" bigserial,
+    "vehicle_id" bigint,
+    "latitude" decimal(10,8),
+    "longitude" decimal(11,8),
+    "route_id" bigint,
+    "recorded_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_vehicle_trackings_route" FOREIGN KEY ("route_id") REFERENCES "routes"("id"),
+    CONSTRAINT "fk_vehicle_trackings_vehicle" FOREIGN KEY ("vehicle_id") REFERENCES "vehicles"("id")
+);
+
+CREATE TABLE IF NOT EXISTS "vehicle_photos" (
+    "id" bigserial,
+    "vehicle_id" bigint,
+    "url" text,
+    "perspective" text,
+    "purpose" text,
+    "sort_order" bigint DEFAULT 0,
+    "is_primary" boolean DEFAULT false,
+    "uploaded_by" bigint,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_vehicle_photos_vehicle" FOREIGN KEY ("vehicle_id") REFERENCES "vehicles"("id")
+);
+
+CREATE TABLE IF NOT EXISTS "vehicle_zone_marks" (
+    "id" bigserial,
+    "vehicle_id" bigint,
+    "zone_id" bigint,
+    "type" text,
+    "severity" text,
+    "description" text,
+    "photo_id" bigint,
+    "reported_by" bigint,
+    "resolved" boolean DEFAULT false,
+    "resolved_by" bigint,
+    "resolved_at" timestamptz,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_vehicle_zone_marks_vehicle" FOREIGN KEY ("vehicle_id") REFERENCES "vehicles"("id"),
+    CONSTRAINT "fk_vehicle_zone_marks_zone" FOREIGN KEY ("zone_id") REFERENCES "vehicle_model_zones"("id"),
+    CONSTRAINT "fk_vehicle_zone_marks_photo" FOREIGN KEY ("photo_id") REFERENCES "vehicle_photos"("id")
+);
+
+-- Leads
+
+CREATE TABLE IF NOT EXISTS "lead_sources" (
+    "id" bigserial,
+    "code" text,
+    "name" text,
+    "is_external" boolean DEFAULT false,
+    "active" boolean DEFAULT true,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "uni_lead_sources_code" UNIQUE ("code")
+);
+
+CREATE TABLE IF NOT EXISTS "lead_step_presets" (
+    "id" bigserial,
+    "code" text,
+    "name" text,
+    "description" text,
+    "sort_order" bigint,
+    "is_public" boolean DEFAULT false,
+    "is_shared" boolean DEFAULT false,
+    "created_by" bigint,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_lead_step_presets_creator" FOREIGN KEY ("created_by") REFERENCES "entities"("id"),
+    CONSTRAINT "uni_lead_step_presets_code" UNIQUE ("code")
+);
+
+CREATE TABLE IF NOT EXISTS "lead_steps" (
+    "id" bigserial,
+    "preset_id" bigint,
+    "code" text,
+    "name" text,
+    "sort_order" bigint,
+    "is_final" boolean DEFAULT false,
+    "active" boolean DEFAULT true,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_lead_steps_preset" FOREIGN KEY ("preset_id") REFERENCES "lead_step_presets"("id")
+);
+
+CREATE TABLE IF NOT EXISTS "leads" (
+    "id" bigserial,
+    "entity_id" bigint,
+    "vehicle_id" bigint,
+    "interest_type" text,
+    "interest_make" text,
+    "interest_model" text,
+    "budget_min" decimal,
+    "budget_max" decimal,
+    "source_id" bigint,
+    "source_detail" text,
+    "preset_id" bigint,
+    "created_at" timestamptz,
+    "modified_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_leads_entity" FOREIGN KEY ("entity_id") REFERENCES "entities"("id"),
+    CONSTRAINT "fk_leads_vehicle" FOREIGN KEY ("vehicle_id") REFERENCES "vehicles"("id"),
+    CONSTRAINT "fk_leads_source" FOREIGN KEY ("source_id") REFERENCES "lead_sources"("id"),
+    CONSTRAINT "fk_leads_preset" FOREIGN KEY ("preset_id") REFERENCES "lead_step_presets"("id")
+);
+
+CREATE TABLE IF NOT EXISTS "lead_step_progresses" (
+    "id" bigserial,
+    "lead_id" bigint,
+    "step_id" bigint,
+    "status" text DEFAULT 'pending',
+    "started_at" timestamptz,
+    "completed_at" timestamptz,
+    "completed_by" bigint,
+    "notes" text,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_lead_step_progresses_lead" FOREIGN KEY ("lead_id") REFERENCES "leads"("id"),
+    CONSTRAINT "fk_lead_step_progresses_step" FOREIGN KEY ("step_id") REFERENCES "lead_steps"("id")
+);
+
+CREATE TABLE IF NOT EXISTS "lead_assignments" (
+    "id" bigserial,
+    "lead_id" bigint,
+    "entity_id" bigint,
+    "role" text,
+    "is_primary" boolean DEFAULT false,
+    "assigned_by" bigint,
+    "active" boolean DEFAULT true,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_lead_assignments_lead" FOREIGN KEY ("lead_id") REFERENCES "leads"("id"),
+    CONSTRAINT "fk_lead_assignments_entity" FOREIGN KEY ("entity_id") REFERENCES "entities"("id")
+);
+
+CREATE TABLE IF NOT EXISTS "lead_notes" (
+    "id" bigserial,
+    "lead_id" bigint,
+    "content" text,
+    "created_by" bigint,
+    "created_at" timestamptz,
+    "modified_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_lead_notes_lead" FOREIGN KEY ("lead_id") REFERENCES "leads"("id"),
+    CONSTRAINT "fk_lead_notes_creator" FOREIGN KEY ("created_by") REFERENCES "entities"("id")
+);
+
+CREATE TABLE IF NOT EXISTS "lead_activities" (
+    "id" bigserial,
+    "lead_id" bigint,
+    "type" text,
+    "description" text,
+    "outcome" text,
+    "phone_id" bigint,
+    "email" text,
+    "performed_by" bigint,
+    "scheduled_at" timestamptz,
+    "completed_at" timestamptz,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_lead_activities_lead" FOREIGN KEY ("lead_id") REFERENCES "leads"("id"),
+    CONSTRAINT "fk_lead_activities_phone" FOREIGN KEY ("phone_id") REFERENCES "entity_phones"("id"),
+    CONSTRAINT "fk_lead_activities_performer" FOREIGN KEY ("performed_by") REFERENCES "entities"("id")
+);
+
+-- Audit
+
+CREATE TABLE IF NOT EXISTS "audit_logs" (
+    "id" bigserial,
+    "actor_id" bigint,
+    "action" varchar(30) NOT NULL,
+    "aggregate_type" varchar(50) NOT NULL,
+    "aggregate_id" bigint NOT NULL,
+    "changes" jsonb,
+    "ip" varchar(45),
+    "request_id" varchar(64),
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_audit_logs_created_at" ON "audit_logs" ("created_at");
+CREATE INDEX IF NOT EXISTS "idx_audit_logs_request_id" ON "audit_logs" ("request_id");
+CREATE INDEX IF NOT EXISTS "idx_audit_aggregate" ON "audit_logs" ("aggregate_type","aggregate_id");
+CREATE INDEX IF NOT EXISTS "idx_audit_logs_actor_id" ON "audit_logs" ("actor_id");
+
+-- El log de auditoría solo admite inserciones
+CREATE OR REPLACE FUNCTION audit_logs_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_logs is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS audit_logs_append_only ON audit_logs;
+
+CREATE TRIGGER audit_logs_append_only
+    BEFORE UPDATE OR DELETE ON audit_logs
+    FOR EACH ROW EXECUTE FUNCTION audit_logs_append_only();
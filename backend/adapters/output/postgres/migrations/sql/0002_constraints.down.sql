@@ -0,0 +1,108 @@
+DROP INDEX IF EXISTS "idx_vehicles_status_created_at";
+DROP INDEX IF EXISTS "idx_lead_activities_schedule";
+DROP INDEX IF EXISTS "idx_lead_assignments_entity_active";
+DROP INDEX IF EXISTS "idx_lead_assignments_lead_id";
+DROP INDEX IF EXISTS "idx_lead_notes_lead_id";
+DROP INDEX IF EXISTS "idx_lead_activities_lead_id";
+DROP INDEX IF EXISTS "idx_lead_step_progresses_lead_id";
+DROP INDEX IF EXISTS "idx_vehicle_photos_vehicle_id";
+DROP INDEX IF EXISTS "idx_entity_phones_entity_id";
+
+ALTER TABLE "entities" DROP CONSTRAINT IF EXISTS "chk_entities_type";
+ALTER TABLE "entities" DROP CONSTRAINT IF EXISTS "chk_entities_status";
+ALTER TABLE "user_accounts" DROP CONSTRAINT IF EXISTS "chk_user_accounts_status";
+ALTER TABLE "role_resources" DROP CONSTRAINT IF EXISTS "chk_role_resources_scope";
+ALTER TABLE "entity_resources" DROP CONSTRAINT IF EXISTS "chk_entity_resources_scope";
+ALTER TABLE "locations" DROP CONSTRAINT IF EXISTS "chk_locations_type";
+ALTER TABLE "vehicles" DROP CONSTRAINT IF EXISTS "chk_vehicles_condition";
+ALTER TABLE "vehicles" DROP CONSTRAINT IF EXISTS "chk_vehicles_status";
+ALTER TABLE "vehicles" DROP CONSTRAINT IF EXISTS "chk_vehicles_lot_type";
+ALTER TABLE "vehicles" DROP CONSTRAINT IF EXISTS "chk_vehicles_acquisition_source";
+ALTER TABLE "vehicle_photos" DROP CONSTRAINT IF EXISTS "chk_vehicle_photos_perspective";
+ALTER TABLE "vehicle_photos" DROP CONSTRAINT IF EXISTS "chk_vehicle_photos_purpose";
+ALTER TABLE "lead_step_progresses" DROP CONSTRAINT IF EXISTS "chk_lead_step_progresses_status";
+ALTER TABLE "lead_assignments" DROP CONSTRAINT IF EXISTS "chk_lead_assignments_role";
+ALTER TABLE "lead_activities" DROP CONSTRAINT IF EXISTS "chk_lead_activities_type";
+
+ALTER TABLE "locations" DROP CONSTRAINT IF EXISTS "fk_locations_country",
+    ADD CONSTRAINT "fk_locations_country" FOREIGN KEY ("country_id") REFERENCES "countries"("id");
+ALTER TABLE "routes" DROP CONSTRAINT IF EXISTS "fk_routes_from_location",
+    ADD CONSTRAINT "fk_routes_from_location" FOREIGN KEY ("from_location_id") REFERENCES "locations"("id");
+ALTER TABLE "routes" DROP CONSTRAINT IF EXISTS "fk_routes_to_location",
+    ADD CONSTRAINT "fk_routes_to_location" FOREIGN KEY ("to_location_id") REFERENCES "locations"("id");
+ALTER TABLE "entities" DROP CONSTRAINT IF EXISTS "fk_entities_parent_entity",
+    ADD CONSTRAINT "fk_entities_parent_entity" FOREIGN KEY ("parent_entity_id") REFERENCES "entities"("id");
+ALTER TABLE "entities" DROP CONSTRAINT IF EXISTS "fk_entities_country",
+    ADD CONSTRAINT "fk_entities_country" FOREIGN KEY ("country_id") REFERENCES "countries"("id");
+ALTER TABLE "user_accounts" DROP CONSTRAINT IF EXISTS "fk_user_accounts_entity",
+    ADD CONSTRAINT "fk_user_accounts_entity" FOREIGN KEY ("entity_id") REFERENCES "entities"("id");
+ALTER TABLE "entity_phones" DROP CONSTRAINT IF EXISTS "fk_entity_phones_entity",
+    ADD CONSTRAINT "fk_entity_phones_entity" FOREIGN KEY ("entity_id") REFERENCES "entities"("id");
+ALTER TABLE "entity_phones" DROP CONSTRAINT IF EXISTS "fk_entity_phones_country",
+    ADD CONSTRAINT "fk_entity_phones_country" FOREIGN KEY ("country_id") REFERENCES "countries"("id");
+ALTER TABLE "role_resources" DROP CONSTRAINT IF EXISTS "fk_role_resources_role",
+    ADD CONSTRAINT "fk_role_resources_role" FOREIGN KEY ("role_id") REFERENCES "roles"("id");
+ALTER TABLE "role_resources" DROP CONSTRAINT IF EXISTS "fk_role_resources_resource",
+    ADD CONSTRAINT "fk_role_resources_resource" FOREIGN KEY ("resource_id") REFERENCES "resources"("id");
+ALTER TABLE "entity_resources" DROP CONSTRAINT IF EXISTS "fk_entity_resources_entity",
+    ADD CONSTRAINT "fk_entity_resources_entity" FOREIGN KEY ("entity_id") REFERENCES "entities"("id");
+ALTER TABLE "entity_resources" DROP CONSTRAINT IF EXISTS "fk_entity_resources_resource",
+    ADD CONSTRAINT "fk_entity_resources_resource" FOREIGN KEY ("resource_id") REFERENCES "resources"("id");
+ALTER TABLE "entity_roles" DROP CONSTRAINT IF EXISTS "fk_entity_roles_entity",
+    ADD CONSTRAINT "fk_entity_roles_entity" FOREIGN KEY ("entity_id") REFERENCES "entities"("id");
+ALTER TABLE "entity_roles" DROP CONSTRAINT IF EXISTS "fk_entity_roles_role",
+    ADD CONSTRAINT "fk_entity_roles_role" FOREIGN KEY ("role_id") REFERENCES "roles"("id");
+ALTER TABLE "vehicle_model_zones" DROP CONSTRAINT IF EXISTS "fk_vehicle_model_zones_model3_d",
+    ADD CONSTRAINT "fk_vehicle_model_zones_model3_d" FOREIGN KEY ("model3_d_id") REFERENCES "vehicle_model3_ds"("id");
+ALTER TABLE "vehicles" DROP CONSTRAINT IF EXISTS "fk_vehicles_location",
+    ADD CONSTRAINT "fk_vehicles_location" FOREIGN KEY ("location_id") REFERENCES "locations"("id");
+ALTER TABLE "vehicles" DROP CONSTRAINT IF EXISTS "fk_vehicles_model3_d",
+    ADD CONSTRAINT "fk_vehicles_model3_d" FOREIGN KEY ("model3_d_id") REFERENCES "vehicle_model3_ds"("id");
+ALTER TABLE "vehicle_location_histories" DROP CONSTRAINT IF EXISTS "fk_vehicle_location_histories_vehicle",
+    ADD CONSTRAINT "fk_vehicle_location_histories_vehicle" FOREIGN KEY ("vehicle_id") REFERENCES "vehicles"("id");
+ALTER TABLE "vehicle_location_histories" DROP CONSTRAINT IF EXISTS "fk_vehicle_location_histories_from_location",
+    ADD CONSTRAINT "fk_vehicle_location_histories_from_location" FOREIGN KEY ("from_location_id") REFERENCES "locations"("id");
+ALTER TABLE "vehicle_location_histories" DROP CONSTRAINT IF EXISTS "fk_vehicle_location_histories_to_location",
+    ADD CONSTRAINT "fk_vehicle_location_histories_to_location" FOREIGN KEY ("to_location_id") REFERENCES "locations"("id");
+ALTER TABLE "vehicle_trackings" DROP CONSTRAINT IF EXISTS "fk_vehicle_trackings_vehicle",
+    ADD CONSTRAINT "fk_vehicle_trackings_vehicle" FOREIGN KEY ("vehicle_id") REFERENCES "vehicles"("id");
+ALTER TABLE "vehicle_trackings" DROP CONSTRAINT IF EXISTS "fk_vehicle_trackings_route",
+    ADD CONSTRAINT "fk_vehicle_trackings_route" FOREIGN KEY ("route_id") REFERENCES "routes"("id");
+ALTER TABLE "vehicle_photos" DROP CONSTRAINT IF EXISTS "fk_vehicle_photos_vehicle",
+    ADD CONSTRAINT "fk_vehicle_photos_vehicle" FOREIGN KEY ("vehicle_id") REFERENCES "vehicles"("id");
+ALTER TABLE "vehicle_zone_marks" DROP CONSTRAINT IF EXISTS "fk_vehicle_zone_marks_vehicle",
+    ADD CONSTRAINT "fk_vehicle_zone_marks_vehicle" FOREIGN KEY ("vehicle_id") REFERENCES "vehicles"("id");
+ALTER TABLE "vehicle_zone_marks" DROP CONSTRAINT IF EXISTS "fk_vehicle_zone_marks_zone",
+    ADD CONSTRAINT "fk_vehicle_zone_marks_zone" FOREIGN KEY ("zone_id") REFERENCES "vehicle_model_zones"("id");
+ALTER TABLE "vehicle_zone_marks" DROP CONSTRAINT IF EXISTS "fk_vehicle_zone_marks_photo",
+    ADD CONSTRAINT "fk_vehicle_zone_marks_photo" FOREIGN KEY ("photo_id") REFERENCES "vehicle_photos"("id");
+ALTER TABLE "lead_step_presets" DROP CONSTRAINT IF EXISTS "fk_lead_step_presets_creator",
+    ADD CONSTRAINT "fk_lead_step_presets_creator" FOREIGN KEY ("created_by") REFERENCES "entities"("id");
+ALTER TABLE "lead_steps" DROP CONSTRAINT IF EXISTS "fk_lead_steps_preset",
+    ADD CONSTRAINT "fk_lead_steps_preset" FOREIGN KEY ("preset_id") REFERENCES "lead_step_presets"("id");
+ALTER TABLE "leads" DROP CONSTRAINT IF EXISTS "fk_leads_entity",
+    ADD CONSTRAINT "fk_leads_entity" FOREIGN KEY ("entity_id") REFERENCES "entities"("id");
+ALTER TABLE "leads" DROP CONSTRAINT IF EXISTS "fk_leads_vehicle",
+    ADD CONSTRAINT "fk_leads_vehicle" FOREIGN KEY ("vehicle_id") REFERENCES "vehicles"("id");
+ALTER TABLE "leads" DROP CONSTRAINT IF EXISTS "fk_leads_source",
+    ADD CONSTRAINT "fk_leads_source" FOREIGN KEY ("source_id") REFERENCES "lead_sources"("id");
+ALTER TABLE "leads" DROP CONSTRAINT IF EXISTS "fk_leads_preset",
+    ADD CONSTRAINT "fk_leads_preset" FOREIGN KEY ("preset_id") REFERENCES "lead_step_presets"("id");
+ALTER TABLE "lead_step_progresses" DROP CONSTRAINT IF EXISTS "fk_lead_step_progresses_lead",
+    ADD CONSTRAINT "fk_lead_step_progresses_lead" FOREIGN KEY ("lead_id") REFERENCES "leads"("id");
+ALTER TABLE "lead_step_progresses" DROP CONSTRAINT IF EXISTS "fk_lead_step_progresses_step",
+    ADD CONSTRAINT "fk_lead_step_progresses_step" FOREIGN KEY ("step_id") REFERENCES "lead_steps"("id");
+ALTER TABLE "lead_assignments" DROP CONSTRAINT IF EXISTS "fk_lead_assignments_lead",
+    ADD CONSTRAINT "fk_lead_assignments_lead" FOREIGN KEY ("lead_id") REFERENCES "leads"("id");
+ALTER TABLE "lead_assignments" DROP CONSTRAINT IF EXISTS "fk_lead_assignments_entity",
+    ADD CONSTRAINT "fk_lead_assignments_entity" FOREIGN KEY ("entity_id") REFERENCES "entities"("id");
+ALTER TABLE "lead_notes" DROP CONSTRAINT IF EXISTS "fk_lead_notes_lead",
+    ADD CONSTRAINT "fk_lead_notes_lead" FOREIGN KEY ("lead_id") REFERENCES "leads"("id");
+ALTER TABLE "lead_notes" DROP CONSTRAINT IF EXISTS "fk_lead_notes_creator",
+    ADD CONSTRAINT "fk_lead_notes_creator" FOREIGN KEY ("created_by") REFERENCES "entities"("id");
+ALTER TABLE "lead_activities" DROP CONSTRAINT IF EXISTS "fk_lead_activities_lead",
+    ADD CONSTRAINT "fk_lead_activities_lead" FOREIGN KEY ("lead_id") REFERENCES "leads"("id");
+ALTER TABLE "lead_activities" DROP CONSTRAINT IF EXISTS "fk_lead_activities_phone",
+    ADD CONSTRAINT "fk_lead_activities_phone" FOREIGN KEY ("phone_id") REFERENCES "entity_phones"("id");
+ALTER TABLE "lead_activities" DROP CONSTRAINT IF EXISTS "fk_lead_activities_performer",
+    ADD CONSTRAINT "fk_lead_activities_performer" FOREIGN KEY ("performed_by") REFERENCES "entities"("id");
@@ -0,0 +1,144 @@
+-- Reglas ON DELETE explícitas, CHECK para las columnas enum e índices
+-- para las consultas más frecuentes.
+
+-- Foreign keys: CASCADE para hijos que no existen sin el padre,
+-- SET NULL para referencias opcionales y RESTRICT para el resto.
+
+-- Geo
+
+ALTER TABLE "locations" DROP CONSTRAINT IF EXISTS "fk_locations_country",
+    ADD CONSTRAINT "fk_locations_country" FOREIGN KEY ("country_id") REFERENCES "countries"("id") ON DELETE RESTRICT;
+ALTER TABLE "routes" DROP CONSTRAINT IF EXISTS "fk_routes_from_location",
+    ADD CONSTRAINT "fk_routes_from_location" FOREIGN KEY ("from_location_id") REFERENCES "locations"("id") ON DELETE RESTRICT;
+ALTER TABLE "routes" DROP CONSTRAINT IF EXISTS "fk_routes_to_location",
+    ADD CONSTRAINT "fk_routes_to_location" FOREIGN KEY ("to_location_id") REFERENCES "locations"("id") ON DELETE RESTRICT;
+
+-- Identity
+
+ALTER TABLE "entities" DROP CONSTRAINT IF EXISTS "fk_entities_parent_entity",
+    ADD CONSTRAINT "fk_entities_parent_entity" FOREIGN KEY ("parent_entity_id") REFERENCES "entities"("id") ON DELETE SET NULL;
+ALTER TABLE "entities" DROP CONSTRAINT IF EXISTS "fk_entities_country",
+    ADD CONSTRAINT "fk_entities_country" FOREIGN KEY ("country_id") REFERENCES "countries"("id") ON DELETE RESTRICT;
+ALTER TABLE "user_accounts" DROP CONSTRAINT IF EXISTS "fk_user_accounts_entity",
+    ADD CONSTRAINT "fk_user_accounts_entity" FOREIGN KEY ("entity_id") REFERENCES "entities"("id") ON DELETE CASCADE;
+ALTER TABLE "entity_phones" DROP CONSTRAINT IF EXISTS "fk_entity_phones_entity",
+    ADD CONSTRAINT "fk_entity_phones_entity" FOREIGN KEY ("entity_id") REFERENCES "entities"("id") ON DELETE CASCADE;
+ALTER TABLE "entity_phones" DROP CONSTRAINT IF EXISTS "fk_entity_phones_country",
+    ADD CONSTRAINT "fk_entity_phones_country" FOREIGN KEY ("country_id") REFERENCES "countries"("id") ON DELETE RESTRICT;
+ALTER TABLE "role_resources" DROP CONSTRAINT IF EXISTS "fk_role_resources_role",
+    ADD CONSTRAINT "fk_role_resources_role" FOREIGN KEY ("role_id") REFERENCES "roles"("id") ON DELETE CASCADE;
+ALTER TABLE "role_resources" DROP CONSTRAINT IF EXISTS "fk_role_resources_resource",
+    ADD CONSTRAINT "fk_role_resources_resource" FOREIGN KEY ("resource_id") REFERENCES "resources"("id") ON DELETE CASCADE;
+ALTER TABLE "entity_resources" DROP CONSTRAINT IF EXISTS "fk_entity_resources_entity",
+    ADD CONSTRAINT "fk_entity_resources_entity" FOREIGN KEY ("entity_id") REFERENCES "entities"("id") ON DELETE CASCADE;
+ALTER TABLE "entity_resources" DROP CONSTRAINT IF EXISTS "fk_entity_resources_resource",
+    ADD CONSTRAINT "fk_entity_resources_resource" FOREIGN KEY ("resource_id") REFERENCES "resources"("id") ON DELETE CASCADE;
+ALTER TABLE "entity_roles" DROP CONSTRAINT IF EXISTS "fk_entity_roles_entity",
+    ADD CONSTRAINT "fk_entity_roles_entity" FOREIGN KEY ("entity_id") REFERENCES "entities"("id") ON DELETE CASCADE;
+ALTER TABLE "entity_roles" DROP CONSTRAINT IF EXISTS "fk_entity_roles_role",
+    ADD CONSTRAINT "fk_entity_roles_role" FOREIGN KEY ("role_id") REFERENCES "roles"("id") ON DELETE CASCADE;
+
+-- Inventory
+
+ALTER TABLE "vehicle_model_zones" DROP CONSTRAINT IF EXISTS "fk_vehicle_model_zones_model3_d",
+    ADD CONSTRAINT "fk_vehicle_model_zones_model3_d" FOREIGN KEY ("model3_d_id") REFERENCES "vehicle_model3_ds"("id") ON DELETE CASCADE;
+ALTER TABLE "vehicles" DROP CONSTRAINT IF EXISTS "fk_vehicles_location",
+    ADD CONSTRAINT "fk_vehicles_location" FOREIGN KEY ("location_id") REFERENCES "locations"("id") ON DELETE RESTRICT;
+ALTER TABLE "vehicles" DROP CONSTRAINT IF EXISTS "fk_vehicles_model3_d",
+    ADD CONSTRAINT "fk_vehicles_model3_d" FOREIGN KEY ("model3_d_id") REFERENCES "vehicle_model3_ds"("id") ON DELETE SET NULL;
+ALTER TABLE "vehicle_location_histories" DROP CONSTRAINT IF EXISTS "fk_vehicle_location_histories_vehicle",
+    ADD CONSTRAINT "fk_vehicle_location_histories_vehicle" FOREIGN KEY ("vehicle_id") REFERENCES "vehicles"("id") ON DELETE CASCADE;
+ALTER TABLE "vehicle_location_histories" DROP CONSTRAINT IF EXISTS "fk_vehicle_location_histories_from_location",
+    ADD CONSTRAINT "fk_vehicle_location_histories_from_location" FOREIGN KEY ("from_location_id") REFERENCES "locations"("id") ON DELETE RESTRICT;
+ALTER TABLE "vehicle_location_histories" DROP CONSTRAINT IF EXISTS "fk_vehicle_location_histories_to_location",
+    ADD CONSTRAINT "fk_vehicle_location_histories_to_location" FOREIGN KEY ("to_location_id") REFERENCES "locations"("id") ON DELETE RESTRICT;
+ALTER TABLE "vehicle_trackings" DROP CONSTRAINT IF EXISTS "fk_vehicle_trackings_vehicle",
+    ADD CONSTRAINT "fk_vehicle_trackings_vehicle" FOREIGN KEY ("vehicle_id") REFERENCES "vehicles"("id") ON DELETE CASCADE;
+ALTER TABLE "vehicle_trackings" DROP CONSTRAINT IF EXISTS "fk_vehicle_trackings_route",
+    ADD CONSTRAINT "fk_vehicle_trackings_route" FOREIGN KEY ("route_id") REFERENCES "routes"("id") ON DELETE SET NULL;
+ALTER TABLE "vehicle_photos" DROP CONSTRAINT IF EXISTS "fk_vehicle_photos_vehicle",
+    ADD CONSTRAINT "fk_vehicle_photos_vehicle" FOREIGN KEY ("vehicle_id") REFERENCES "vehicles"("id") ON DELETE CASCADE;
+ALTER TABLE "vehicle_zone_marks" DROP CONSTRAINT IF EXISTS "fk_vehicle_zone_marks_vehicle",
+    ADD CONSTRAINT "fk_vehicle_zone_marks_vehicle" FOREIGN KEY ("vehicle_id") REFERENCES "vehicles"("id") ON DELETE CASCADE;
+ALTER TABLE "vehicle_zone_marks" DROP CONSTRAINT IF EXISTS "fk_vehicle_zone_marks_zone",
+    ADD CONSTRAINT "fk_vehicle_zone_marks_zone" FOREIGN KEY ("zone_id") REFERENCES "vehicle_model_zones"("id") ON DELETE RESTRICT;
+ALTER TABLE "vehicle_zone_marks" DROP CONSTRAINT IF EXISTS "fk_vehicle_zone_marks_photo",
+    ADD CONSTRAINT "fk_vehicle_zone_marks_photo" FOREIGN KEY ("photo_id") REFERENCES "vehicle_photos"("id") ON DELETE SET NULL;
+
+-- Leads
+
+ALTER TABLE "lead_step_presets" DROP CONSTRAINT IF EXISTS "fk_lead_step_presets_creator",
+    ADD CONSTRAINT "fk_lead_step_presets_creator" FOREIGN KEY ("created_by") REFERENCES "entities"("id") ON DELETE RESTRICT;
+ALTER TABLE "lead_steps" DROP CONSTRAINT IF EXISTS "fk_lead_steps_preset",
+    ADD CONSTRAINT "fk_lead_steps_preset" FOREIGN KEY ("preset_id") REFERENCES "lead_step_presets"("id") ON DELETE CASCADE;
+ALTER TABLE "leads" DROP CONSTRAINT IF EXISTS "fk_leads_entity",
+    ADD CONSTRAINT "fk_leads_entity" FOREIGN KEY ("entity_id") REFERENCES "entities"("id") ON DELETE RESTRICT;
+ALTER TABLE "leads" DROP CONSTRAINT IF EXISTS "fk_leads_vehicle",
+    ADD CONSTRAINT "fk_leads_vehicle" FOREIGN KEY ("vehicle_id") REFERENCES "vehicles"("id") ON DELETE SET NULL;
+ALTER TABLE "leads" DROP CONSTRAINT IF EXISTS "fk_leads_source",
+    ADD CONSTRAINT "fk_leads_source" FOREIGN KEY ("source_id") REFERENCES "lead_sources"("id") ON DELETE RESTRICT;
+ALTER TABLE "leads" DROP CONSTRAINT IF EXISTS "fk_leads_preset",
+    ADD CONSTRAINT "fk_leads_preset" FOREIGN KEY ("preset_id") REFERENCES "lead_step_presets"("id") ON DELETE SET NULL;
+ALTER TABLE "lead_step_progresses" DROP CONSTRAINT IF EXISTS "fk_lead_step_progresses_lead",
+    ADD CONSTRAINT "fk_lead_step_progresses_lead" FOREIGN KEY ("lead_id") REFERENCES "leads"("id") ON DELETE CASCADE;
+ALTER TABLE "lead_step_progresses" DROP CONSTRAINT IF EXISTS "fk_lead_step_progresses_step",
+    ADD CONSTRAINT "fk_lead_step_progresses_step" FOREIGN KEY ("step_id") REFERENCES "lead_steps"("id") ON DELETE RESTRICT;
+ALTER TABLE "lead_assignments" DROP CONSTRAINT IF EXISTS "fk_lead_assignments_lead",
+    ADD CONSTRAINT "fk_lead_assignments_lead" FOREIGN KEY ("lead_id") REFERENCES "leads"("id") ON DELETE CASCADE;
+ALTER TABLE "lead_assignments" DROP CONSTRAINT IF EXISTS "fk_lead_assignments_entity",
+    ADD CONSTRAINT "fk_lead_assignments_entity" FOREIGN KEY ("entity_id") REFERENCES "entities"("id") ON DELETE RESTRICT;
+ALTER TABLE "lead_notes" DROP CONSTRAINT IF EXISTS "fk_lead_notes_lead",
+    ADD CONSTRAINT "fk_lead_notes_lead" FOREIGN KEY ("lead_id") REFERENCES "leads"("id") ON DELETE CASCADE;
+ALTER TABLE "lead_notes" DROP CONSTRAINT IF EXISTS "fk_lead_notes_creator",
+    ADD CONSTRAINT "fk_lead_notes_creator" FOREIGN KEY ("created_by") REFERENCES "entities"("id") ON DELETE RESTRICT;
+ALTER TABLE "lead_activities" DROP CONSTRAINT IF EXISTS "fk_lead_activities_lead",
+    ADD CONSTRAINT "fk_lead_activities_lead" FOREIGN KEY ("lead_id") REFERENCES "leads"("id") ON DELETE CASCADE;
+ALTER TABLE "lead_activities" DROP CONSTRAINT IF EXISTS "fk_lead_activities_phone",
+    ADD CONSTRAINT "fk_lead_activities_phone" FOREIGN KEY ("phone_id") REFERENCES "entity_phones"("id") ON DELETE SET NULL;
+ALTER TABLE "lead_activities" DROP CONSTRAINT IF EXISTS "fk_lead_activities_performer",
+    ADD CONSTRAINT "fk_lead_activities_performer" FOREIGN KEY ("performed_by") REFERENCES "entities"("id") ON DELETE RESTRICT;
+
+-- Enums: deben coincidir con los valores definidos en core/*/domain
+
+ALTER TABLE "entities" DROP CONSTRAINT IF EXISTS "chk_entities_type",
+    ADD CONSTRAINT "chk_entities_type" CHECK ("type" IN ('person', 'company', 'dealer', 'organization'));
+ALTER TABLE "entities" DROP CONSTRAINT IF EXISTS "chk_entities_status",
+    ADD CONSTRAINT "chk_entities_status" CHECK ("status" IN ('active', 'inactive', 'suspended'));
+ALTER TABLE "user_accounts" DROP CONSTRAINT IF EXISTS "chk_user_accounts_status",
+    ADD CONSTRAINT "chk_user_accounts_status" CHECK ("status" IN ('active', 'inactive', 'suspended'));
+ALTER TABLE "role_resources" DROP CONSTRAINT IF EXISTS "chk_role_resources_scope",
+    ADD CONSTRAINT "chk_role_resources_scope" CHECK ("scope" IN ('all', 'own', 'team', 'none'));
+ALTER TABLE "entity_resources" DROP CONSTRAINT IF EXISTS "chk_entity_resources_scope",
+    ADD CONSTRAINT "chk_entity_resources_scope" CHECK ("scope" IN ('all', 'own', 'team', 'none'));
+ALTER TABLE "locations" DROP CONSTRAINT IF EXISTS "chk_locations_type",
+    ADD CONSTRAINT "chk_locations_type" CHECK ("type" IN ('sales_lot', 'storage', 'service', 'offsite', 'in_transit'));
+ALTER TABLE "vehicles" DROP CONSTRAINT IF EXISTS "chk_vehicles_condition",
+    ADD CONSTRAINT "chk_vehicles_condition" CHECK ("condition" IN ('new', 'used', 'certified'));
+ALTER TABLE "vehicles" DROP CONSTRAINT IF EXISTS "chk_vehicles_status",
+    ADD CONSTRAINT "chk_vehicles_status" CHECK ("status" IN ('in_transit', 'in_recon', 'ready_for_sale', 'sold', 'wholesale'));
+ALTER TABLE "vehicles" DROP CONSTRAINT IF EXISTS "chk_vehicles_lot_type",
+    ADD CONSTRAINT "chk_vehicles_lot_type" CHECK ("lot_type" IN ('new', 'used', 'cpo', 'wholesale'));
+ALTER TABLE "vehicles" DROP CONSTRAINT IF EXISTS "chk_vehicles_acquisition_source",
+    ADD CONSTRAINT "chk_vehicles_acquisition_source" CHECK ("acquisition_source" = '' OR "acquisition_source" IN ('factory', 'trade_in', 'auction', 'dealer_transfer', 'consignment'));
+ALTER TABLE "vehicle_photos" DROP CONSTRAINT IF EXISTS "chk_vehicle_photos_perspective",
+    ADD CONSTRAINT "chk_vehicle_photos_perspective" CHECK ("perspective" IN ('front', 'rear', 'left_side', 'right_side', 'interior', 'dashboard', 'engine', 'damage'));
+ALTER TABLE "vehicle_photos" DROP CONSTRAINT IF EXISTS "chk_vehicle_photos_purpose",
+    ADD CONSTRAINT "chk_vehicle_photos_purpose" CHECK ("purpose" IN ('listing', 'service', 'inspection', 'damage', 'internal'));
+ALTER TABLE "lead_step_progresses" DROP CONSTRAINT IF EXISTS "chk_lead_step_progresses_status",
+    ADD CONSTRAINT "chk_lead_step_progresses_status" CHECK ("status" IN ('pending', 'completed', 'skipped', 'failed'));
+ALTER TABLE "lead_assignments" DROP CONSTRAINT IF EXISTS "chk_lead_assignments_role",
+    ADD CONSTRAINT "chk_lead_assignments_role" CHECK ("role" IN ('salesperson', 'manager', 'finance', 'closer'));
+ALTER TABLE "lead_activities" DROP CONSTRAINT IF EXISTS "chk_lead_activities_type",
+    ADD CONSTRAINT "chk_lead_activities_type" CHECK ("type" IN ('call_outbound', 'call_inbound', 'email_sent', 'email_received', 'sms_sent', 'sms_received', 'appointment_scheduled', 'appointment_completed', 'appointment_cancelled', 'demo', 'quote_sent', 'other'));
+
+-- Índices
+
+CREATE INDEX IF NOT EXISTS "idx_vehicles_status_created_at" ON "vehicles" ("status","created_at");
+CREATE INDEX IF NOT EXISTS "idx_lead_activities_schedule" ON "lead_activities" ("scheduled_at","completed_at");
+CREATE INDEX IF NOT EXISTS "idx_lead_assignments_entity_active" ON "lead_assignments" ("entity_id","active");
+CREATE INDEX IF NOT EXISTS "idx_lead_assignments_lead_id" ON "lead_assignments" ("lead_id");
+CREATE INDEX IF NOT EXISTS "idx_lead_notes_lead_id" ON "lead_notes" ("lead_id");
+CREATE INDEX IF NOT EXISTS "idx_lead_activities_lead_id" ON "lead_activities" ("lead_id");
+CREATE INDEX IF NOT EXISTS "idx_lead_step_progresses_lead_id" ON "lead_step_progresses" ("lead_id");
+CREATE INDEX IF NOT EXISTS "idx_vehicle_photos_vehicle_id" ON "vehicle_photos" ("vehicle_id");
+CREATE INDEX IF NOT EXISTS "idx_entity_phones_entity_id" ON "entity_phones" ("entity_id");
@@ -0,0 +1,10 @@
+-- Los registros que estaban en la papelera vuelven a ser visibles
+DROP INDEX IF EXISTS "idx_lead_step_presets_deleted_at";
+DROP INDEX IF EXISTS "idx_leads_deleted_at";
+DROP INDEX IF EXISTS "idx_vehicles_deleted_at";
+DROP INDEX IF EXISTS "idx_entities_deleted_at";
+
+ALTER TABLE "lead_step_presets" DROP COLUMN IF EXISTS "deleted_by", DROP COLUMN IF EXISTS "deleted_at";
+ALTER TABLE "leads" DROP COLUMN IF EXISTS "deleted_by", DROP COLUMN IF EXISTS "deleted_at";
+ALTER TABLE "vehicles" DROP COLUMN IF EXISTS "deleted_by", DROP COLUMN IF EXISTS "deleted_at";
+ALTER TABLE "entities" DROP COLUMN IF EXISTS "deleted_by", DROP COLUMN IF EXISTS "deleted_at";
@@ -0,0 +1,29 @@
+-- Papelera: los agregados principales se marcan como borrados en vez de eliminarse.
+-- El purge los elimina físicamente pasado el periodo de retención.
+
+ALTER TABLE "entities"
+    ADD COLUMN IF NOT EXISTS "deleted_at" timestamptz,
+    ADD COLUMN IF NOT EXISTS "deleted_by" bigint;
+ALTER TABLE "vehicles"
+    ADD COLUMN IF NOT EXISTS "deleted_at" timestamptz,
+    ADD COLUMN IF NOT EXISTS "deleted_by" bigint;
+ALTER TABLE "leads"
+    ADD COLUMN IF NOT EXISTS "deleted_at" timestamptz,
+    ADD COLUMN IF NOT EXISTS "deleted_by" bigint;
+ALTER TABLE "lead_step_presets"
+    ADD COLUMN IF NOT EXISTS "deleted_at" timestamptz,
+    ADD COLUMN IF NOT EXISTS "deleted_by" bigint;
+
+ALTER TABLE "entities" DROP CONSTRAINT IF EXISTS "fk_entities_deleter",
+    ADD CONSTRAINT "fk_entities_deleter" FOREIGN KEY ("deleted_by") REFERENCES "entities"("id") ON DELETE SET NULL;
+ALTER TABLE "vehicles" DROP CONSTRAINT IF EXISTS "fk_vehicles_deleter",
+    ADD CONSTRAINT "fk_vehicles_deleter" FOREIGN KEY ("deleted_by") REFERENCES "entities"("id") ON DELETE SET NULL;
+ALTER TABLE "leads" DROP CONSTRAINT IF EXISTS "fk_leads_deleter",
+    ADD CONSTRAINT "fk_leads_deleter" FOREIGN KEY ("deleted_by") REFERENCES "entities"("id") ON DELETE SET NULL;
+ALTER TABLE "lead_step_presets" DROP CONSTRAINT IF EXISTS "fk_lead_step_presets_deleter",
+    ADD CONSTRAINT "fk_lead_step_presets_deleter" FOREIGN KEY ("deleted_by") REFERENCES "entities"("id") ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS "idx_entities_deleted_at" ON "entities" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_vehicles_deleted_at" ON "vehicles" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_leads_deleted_at" ON "leads" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_lead_step_presets_deleted_at" ON "lead_step_presets" ("deleted_at");
@@ -0,0 +1,4 @@
+ALTER TABLE "locations" DROP COLUMN IF EXISTS "version";
+ALTER TABLE "leads" DROP COLUMN IF EXISTS "version";
+ALTER TABLE "vehicles" DROP COLUMN IF EXISTS "version";
+ALTER TABLE "entities" DROP COLUMN IF EXISTS "version";
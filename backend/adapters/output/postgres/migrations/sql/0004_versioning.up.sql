@@ -0,0 +1,6 @@
+-- Control de concurrencia optimista: cada UPDATE exige la versión leída y la incrementa
+
+ALTER TABLE "entities" ADD COLUMN IF NOT EXISTS "version" bigint NOT NULL DEFAULT 1;
+ALTER TABLE "vehicles" ADD COLUMN IF NOT EXISTS "version" bigint NOT NULL DEFAULT 1;
+ALTER TABLE "leads" ADD COLUMN IF NOT EXISTS "version" bigint NOT NULL DEFAULT 1;
+ALTER TABLE "locations" ADD COLUMN IF NOT EXISTS "version" bigint NOT NULL DEFAULT 1;
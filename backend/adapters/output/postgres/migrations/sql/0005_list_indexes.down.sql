@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS "idx_audit_logs_actor_created";
+DROP INDEX IF EXISTS "idx_vehicles_created_at";
+DROP INDEX IF EXISTS "idx_leads_created_at";
+DROP INDEX IF EXISTS "idx_lead_activities_lead_created";
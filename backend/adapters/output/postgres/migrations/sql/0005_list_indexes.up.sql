@@ -0,0 +1,7 @@
+-- Índices para los listados paginados: el orden por defecto más el id de desempate,
+-- de modo que la paginación por keyset no tenga que ordenar en memoria
+
+CREATE INDEX IF NOT EXISTS "idx_lead_activities_lead_created" ON "lead_activities" ("lead_id","created_at","id");
+CREATE INDEX IF NOT EXISTS "idx_leads_created_at" ON "leads" ("created_at","id");
+CREATE INDEX IF NOT EXISTS "idx_vehicles_created_at" ON "vehicles" ("created_at","id");
+CREATE INDEX IF NOT EXISTS "idx_audit_logs_actor_created" ON "audit_logs" ("actor_id","created_at","id");
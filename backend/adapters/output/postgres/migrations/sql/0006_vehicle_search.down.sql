@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "idx_vehicles_acquisition_date";
+DROP INDEX IF EXISTS "idx_vehicles_asking_price";
+DROP INDEX IF EXISTS "idx_vehicles_search";
@@ -0,0 +1,5 @@
+-- Búsqueda de inventario: make/model se comparan sin distinguir mayúsculas
+
+CREATE INDEX IF NOT EXISTS "idx_vehicles_search" ON "vehicles" (LOWER("make"), LOWER("model"), "year") WHERE "deleted_at" IS NULL;
+CREATE INDEX IF NOT EXISTS "idx_vehicles_asking_price" ON "vehicles" ("asking_price") WHERE "deleted_at" IS NULL;
+CREATE INDEX IF NOT EXISTS "idx_vehicles_acquisition_date" ON "vehicles" ("acquisition_date") WHERE "deleted_at" IS NULL;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS "idx_vehicle_location_histories_vehicle_created";
+DROP TABLE IF EXISTS "vehicle_transfers";
@@ -0,0 +1,31 @@
+-- Traslados entre ubicaciones y el historial de movimientos que ya existía pero no se escribía.
+-- Un vehicle sin ubicación previa deja from_location_id en NULL
+
+CREATE TABLE IF NOT EXISTS "vehicle_transfers" (
+    "id" bigserial,
+    "vehicle_id" bigint NOT NULL,
+    "from_location_id" bigint,
+    "transit_location_id" bigint NOT NULL,
+    "to_location_id" bigint NOT NULL,
+    "previous_status" text NOT NULL,
+    "status" text NOT NULL DEFAULT 'pending',
+    "reason" text,
+    "requested_by" bigint,
+    "received_by" bigint,
+    "created_at" timestamptz,
+    "completed_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_vehicle_transfers_vehicle" FOREIGN KEY ("vehicle_id") REFERENCES "vehicles"("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_vehicle_transfers_from_location" FOREIGN KEY ("from_location_id") REFERENCES "locations"("id") ON DELETE RESTRICT,
+    CONSTRAINT "fk_vehicle_transfers_transit_location" FOREIGN KEY ("transit_location_id") REFERENCES "locations"("id") ON DELETE RESTRICT,
+    CONSTRAINT "fk_vehicle_transfers_to_location" FOREIGN KEY ("to_location_id") REFERENCES "locations"("id") ON DELETE RESTRICT
+);
+
+ALTER TABLE "vehicle_transfers" DROP CONSTRAINT IF EXISTS "chk_vehicle_transfers_status",
+    ADD CONSTRAINT "chk_vehicle_transfers_status" CHECK ("status" IN ('pending', 'completed'));
+
+-- Un solo traslado abierto por vehicle
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_vehicle_transfers_pending" ON "vehicle_transfers" ("vehicle_id") WHERE "status" = 'pending';
+CREATE INDEX IF NOT EXISTS "idx_vehicle_transfers_to_pending" ON "vehicle_transfers" ("to_location_id") WHERE "status" = 'pending';
+
+CREATE INDEX IF NOT EXISTS "idx_vehicle_location_histories_vehicle_created" ON "vehicle_location_histories" ("vehicle_id","created_at","id");
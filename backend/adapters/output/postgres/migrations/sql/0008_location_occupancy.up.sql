@@ -0,0 +1,3 @@
+-- Ocupación por ubicación: el conteo agrupado por estado y tipo de lote se resuelve solo con el índice
+
+CREATE INDEX IF NOT EXISTS "idx_vehicles_location_stock" ON "vehicles" ("location_id","status","lot_type");
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS "idx_vehicle_trackings_recorded";
+DROP INDEX IF EXISTS "uq_vehicle_trackings_vehicle_recorded";
+DROP INDEX IF EXISTS "uq_vehicles_tracking_device";
+ALTER TABLE "vehicles" DROP COLUMN IF EXISTS "tracking_device_id";
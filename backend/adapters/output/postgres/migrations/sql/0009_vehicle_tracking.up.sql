@@ -0,0 +1,13 @@
+-- Telemetría GPS: los dispositivos se asignan al vehicle y cada ping se identifica por
+-- (vehicle_id, recorded_at) para que reenviar un lote no duplique puntos
+
+ALTER TABLE "vehicles" ADD COLUMN IF NOT EXISTS "tracking_device_id" text;
+CREATE UNIQUE INDEX IF NOT EXISTS "uq_vehicles_tracking_device" ON "vehicles" ("tracking_device_id") WHERE "tracking_device_id" IS NOT NULL;
+
+-- Puntos repetidos de antes de la migración: se conserva el primero
+DELETE FROM "vehicle_trackings" t
+    USING "vehicle_trackings" d
+    WHERE t."vehicle_id" = d."vehicle_id" AND t."recorded_at" = d."recorded_at" AND t."id" > d."id";
+
+CREATE UNIQUE INDEX IF NOT EXISTS "uq_vehicle_trackings_vehicle_recorded" ON "vehicle_trackings" ("vehicle_id","recorded_at");
+CREATE INDEX IF NOT EXISTS "idx_vehicle_trackings_recorded" ON "vehicle_trackings" ("recorded_at");
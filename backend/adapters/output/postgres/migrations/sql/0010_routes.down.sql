@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS "idx_vehicle_transfers_route_pending";
+ALTER TABLE "vehicle_transfers" DROP CONSTRAINT IF EXISTS "fk_vehicle_transfers_route";
+ALTER TABLE "vehicle_transfers" DROP COLUMN IF EXISTS "route_id";
+
+DROP INDEX IF EXISTS "idx_routes_from_to";
+ALTER TABLE "routes" DROP CONSTRAINT IF EXISTS "chk_routes_estimate";
+ALTER TABLE "routes" DROP CONSTRAINT IF EXISTS "chk_routes_distinct_locations";
+ALTER TABLE "routes" DROP COLUMN IF EXISTS "version";
@@ -0,0 +1,16 @@
+-- Rutas entre ubicaciones: control de versión como el resto de agregados editables y
+-- enlace opcional del traslado con la ruta que sigue
+
+ALTER TABLE "routes" ADD COLUMN IF NOT EXISTS "version" bigint NOT NULL DEFAULT 1;
+
+ALTER TABLE "routes" DROP CONSTRAINT IF EXISTS "chk_routes_distinct_locations",
+    ADD CONSTRAINT "chk_routes_distinct_locations" CHECK ("from_location_id" <> "to_location_id");
+ALTER TABLE "routes" DROP CONSTRAINT IF EXISTS "chk_routes_estimate",
+    ADD CONSTRAINT "chk_routes_estimate" CHECK ("distance_km" >= 0 AND "estimated_minutes" >= 0);
+
+CREATE INDEX IF NOT EXISTS "idx_routes_from_to" ON "routes" ("from_location_id","to_location_id");
+
+ALTER TABLE "vehicle_transfers" ADD COLUMN IF NOT EXISTS "route_id" bigint;
+ALTER TABLE "vehicle_transfers" DROP CONSTRAINT IF EXISTS "fk_vehicle_transfers_route",
+    ADD CONSTRAINT "fk_vehicle_transfers_route" FOREIGN KEY ("route_id") REFERENCES "routes"("id") ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS "idx_vehicle_transfers_route_pending" ON "vehicle_transfers" ("route_id") WHERE "status" = 'pending';
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS "idx_geofence_events_location_recorded";
+DROP INDEX IF EXISTS "idx_geofence_events_vehicle_recorded";
+DROP TABLE IF EXISTS "geofence_events";
+
+ALTER TABLE "locations" DROP CONSTRAINT IF EXISTS "chk_locations_geofence";
+ALTER TABLE "locations" DROP COLUMN IF EXISTS "geofence_polygon";
+ALTER TABLE "locations" DROP COLUMN IF EXISTS "geofence_radius_m";
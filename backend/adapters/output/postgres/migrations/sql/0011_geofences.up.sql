@@ -0,0 +1,31 @@
+-- Geocercas de las ubicaciones (un radio alrededor de sus coordenadas o un polígono) y los
+-- cruces detectados en los puntos GPS. Al salir de la geocerca el historial no tiene destino
+
+ALTER TABLE "locations" ADD COLUMN IF NOT EXISTS "geofence_radius_m" double precision;
+ALTER TABLE "locations" ADD COLUMN IF NOT EXISTS "geofence_polygon" json;
+ALTER TABLE "locations" DROP CONSTRAINT IF EXISTS "chk_locations_geofence",
+    ADD CONSTRAINT "chk_locations_geofence" CHECK ("geofence_radius_m" IS NULL OR ("geofence_radius_m" > 0 AND "geofence_polygon" IS NULL));
+
+CREATE TABLE IF NOT EXISTS "geofence_events" (
+    "id" bigserial,
+    "vehicle_id" bigint NOT NULL,
+    "location_id" bigint NOT NULL,
+    "type" text NOT NULL,
+    "latitude" decimal(10,8) NOT NULL,
+    "longitude" decimal(11,8) NOT NULL,
+    "recorded_at" timestamptz NOT NULL,
+    "outcome" text NOT NULL,
+    "note" text,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_geofence_events_vehicle" FOREIGN KEY ("vehicle_id") REFERENCES "vehicles"("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_geofence_events_location" FOREIGN KEY ("location_id") REFERENCES "locations"("id") ON DELETE CASCADE
+);
+
+ALTER TABLE "geofence_events" DROP CONSTRAINT IF EXISTS "chk_geofence_events_type",
+    ADD CONSTRAINT "chk_geofence_events_type" CHECK ("type" IN ('enter', 'exit'));
+ALTER TABLE "geofence_events" DROP CONSTRAINT IF EXISTS "chk_geofence_events_outcome",
+    ADD CONSTRAINT "chk_geofence_events_outcome" CHECK ("outcome" IN ('transfer_completed', 'moved', 'recorded', 'ignored'));
+
+CREATE INDEX IF NOT EXISTS "idx_geofence_events_vehicle_recorded" ON "geofence_events" ("vehicle_id","recorded_at","id");
+CREATE INDEX IF NOT EXISTS "idx_geofence_events_location_recorded" ON "geofence_events" ("location_id","recorded_at","id");
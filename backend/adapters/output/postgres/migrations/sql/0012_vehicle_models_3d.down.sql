@@ -0,0 +1,11 @@
+ALTER TABLE "vehicles" DROP CONSTRAINT IF EXISTS "fk_vehicles_model3_d",
+    ADD CONSTRAINT "fk_vehicles_model3_d" FOREIGN KEY ("model3_d_id") REFERENCES "vehicle_model3_ds"("id");
+ALTER TABLE "vehicle_model_zones" DROP CONSTRAINT IF EXISTS "fk_vehicle_model_zones_model3_d",
+    ADD CONSTRAINT "fk_vehicle_model_zones_model3_d" FOREIGN KEY ("model3_d_id") REFERENCES "vehicle_model3_ds"("id");
+
+DROP INDEX IF EXISTS "uq_vehicle_model_zones_model_code";
+DROP INDEX IF EXISTS "uq_vehicle_model3_ds_default";
+
+ALTER TABLE "vehicle_model3_ds" DROP CONSTRAINT IF EXISTS "chk_vehicle_model3_ds_body_type";
+ALTER TABLE "vehicle_model3_ds" DROP COLUMN IF EXISTS "version";
+ALTER TABLE "vehicle_model3_ds" DROP COLUMN IF EXISTS "is_default";
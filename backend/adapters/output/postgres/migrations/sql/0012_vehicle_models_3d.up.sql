@@ -0,0 +1,27 @@
+-- Catálogo de modelos 3D: control de versión, un modelo predeterminado por carrocería y
+-- códigos de zona únicos dentro de cada modelo. Borrar un modelo se lleva sus zonas y deja
+-- los vehicles sin modelo
+
+ALTER TABLE "vehicle_model3_ds" ADD COLUMN IF NOT EXISTS "is_default" boolean NOT NULL DEFAULT false;
+ALTER TABLE "vehicle_model3_ds" ADD COLUMN IF NOT EXISTS "version" bigint NOT NULL DEFAULT 1;
+
+ALTER TABLE "vehicle_model3_ds" DROP CONSTRAINT IF EXISTS "chk_vehicle_model3_ds_body_type",
+    ADD CONSTRAINT "chk_vehicle_model3_ds_body_type" CHECK ("body_type" IN ('sedan', 'suv', 'truck', 'coupe', 'van', 'hatchback', 'convertible', 'wagon'));
+
+CREATE UNIQUE INDEX IF NOT EXISTS "uq_vehicle_model3_ds_default" ON "vehicle_model3_ds" ("body_type") WHERE "is_default";
+
+-- Zonas repetidas de datos cargados a mano: se conserva la más antigua
+UPDATE "vehicle_zone_marks" AS m SET "zone_id" = keep."id"
+FROM "vehicle_model_zones" AS z
+JOIN "vehicle_model_zones" AS keep
+    ON keep."model3_d_id" = z."model3_d_id" AND keep."code" = z."code" AND keep."id" < z."id"
+WHERE m."zone_id" = z."id";
+DELETE FROM "vehicle_model_zones" AS z USING "vehicle_model_zones" AS keep
+WHERE keep."model3_d_id" = z."model3_d_id" AND keep."code" = z."code" AND keep."id" < z."id";
+
+CREATE UNIQUE INDEX IF NOT EXISTS "uq_vehicle_model_zones_model_code" ON "vehicle_model_zones" ("model3_d_id","code");
+
+ALTER TABLE "vehicle_model_zones" DROP CONSTRAINT IF EXISTS "fk_vehicle_model_zones_model3_d",
+    ADD CONSTRAINT "fk_vehicle_model_zones_model3_d" FOREIGN KEY ("model3_d_id") REFERENCES "vehicle_model3_ds"("id") ON DELETE CASCADE;
+ALTER TABLE "vehicles" DROP CONSTRAINT IF EXISTS "fk_vehicles_model3_d",
+    ADD CONSTRAINT "fk_vehicles_model3_d" FOREIGN KEY ("model3_d_id") REFERENCES "vehicle_model3_ds"("id") ON DELETE SET NULL;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS "idx_vehicle_zone_marks_vehicle";
+
+ALTER TABLE "vehicle_zone_marks" DROP CONSTRAINT IF EXISTS "chk_vehicle_zone_marks_resolution";
+ALTER TABLE "vehicle_zone_marks" DROP CONSTRAINT IF EXISTS "chk_vehicle_zone_marks_severity";
+ALTER TABLE "vehicle_zone_marks" DROP CONSTRAINT IF EXISTS "chk_vehicle_zone_marks_type";
@@ -0,0 +1,11 @@
+-- Marcas de daño sobre las zonas del modelo 3D: tipos y severidades cerrados, una marca
+-- resuelta siempre guarda quién y cuándo, y el mapa de daños se lee por vehicle
+
+ALTER TABLE "vehicle_zone_marks" DROP CONSTRAINT IF EXISTS "chk_vehicle_zone_marks_type",
+    ADD CONSTRAINT "chk_vehicle_zone_marks_type" CHECK ("type" IN ('scratch', 'dent', 'chip', 'crack', 'rust', 'scuff', 'broken', 'missing', 'stain', 'tear', 'other'));
+ALTER TABLE "vehicle_zone_marks" DROP CONSTRAINT IF EXISTS "chk_vehicle_zone_marks_severity",
+    ADD CONSTRAINT "chk_vehicle_zone_marks_severity" CHECK ("severity" IN ('minor', 'moderate', 'severe'));
+ALTER TABLE "vehicle_zone_marks" DROP CONSTRAINT IF EXISTS "chk_vehicle_zone_marks_resolution",
+    ADD CONSTRAINT "chk_vehicle_zone_marks_resolution" CHECK (NOT "resolved" OR ("resolved_by" IS NOT NULL AND "resolved_at" IS NOT NULL));
+
+CREATE INDEX IF NOT EXISTS "idx_vehicle_zone_marks_vehicle" ON "vehicle_zone_marks" ("vehicle_id", "resolved", "created_at" DESC);
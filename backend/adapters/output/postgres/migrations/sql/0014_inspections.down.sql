@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "inspection_results";
+DROP TABLE IF EXISTS "inspections";
+DROP TABLE IF EXISTS "inspection_items";
+DROP TABLE IF EXISTS "inspection_sections";
+DROP TABLE IF EXISTS "inspection_templates";
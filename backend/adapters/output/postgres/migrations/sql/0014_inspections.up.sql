@@ -0,0 +1,84 @@
+-- Checklists de inspección: plantillas con secciones e items (pass/fail/na, mediciones con
+-- rango aceptable y fotos obligatorias) y las inspecciones hechas sobre cada vehicle
+
+CREATE TABLE IF NOT EXISTS "inspection_templates" (
+    "id" bigserial,
+    "name" text NOT NULL,
+    "kind" text NOT NULL,
+    "description" text,
+    "active" boolean DEFAULT true,
+    "version" bigint NOT NULL DEFAULT 1,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "inspection_sections" (
+    "id" bigserial,
+    "template_id" bigint NOT NULL,
+    "name" text NOT NULL,
+    "sort_order" bigint DEFAULT 0,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_inspection_sections_template" FOREIGN KEY ("template_id") REFERENCES "inspection_templates"("id") ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS "inspection_items" (
+    "id" bigserial,
+    "section_id" bigint NOT NULL,
+    "label" text NOT NULL,
+    "required" boolean NOT NULL DEFAULT false,
+    "photo_required" boolean NOT NULL DEFAULT false,
+    "unit" text,
+    "min_value" double precision,
+    "max_value" double precision,
+    "sort_order" bigint DEFAULT 0,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_inspection_items_section" FOREIGN KEY ("section_id") REFERENCES "inspection_sections"("id") ON DELETE CASCADE,
+    CONSTRAINT "chk_inspection_items_range" CHECK ("min_value" IS NULL OR "max_value" IS NULL OR "min_value" <= "max_value")
+);
+
+CREATE TABLE IF NOT EXISTS "inspections" (
+    "id" bigserial,
+    "vehicle_id" bigint NOT NULL,
+    "template_id" bigint NOT NULL,
+    "kind" text NOT NULL,
+    "inspector_id" bigint NOT NULL,
+    "status" text NOT NULL,
+    "passed" boolean NOT NULL DEFAULT false,
+    "notes" text,
+    "started_at" timestamptz NOT NULL,
+    "completed_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 1,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_inspections_vehicle" FOREIGN KEY ("vehicle_id") REFERENCES "vehicles"("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_inspections_template" FOREIGN KEY ("template_id") REFERENCES "inspection_templates"("id") ON DELETE RESTRICT
+);
+
+CREATE TABLE IF NOT EXISTS "inspection_results" (
+    "id" bigserial,
+    "inspection_id" bigint NOT NULL,
+    "item_id" bigint NOT NULL,
+    "result" text NOT NULL,
+    "value" double precision,
+    "photo_id" bigint,
+    "notes" text,
+    "recorded_at" timestamptz NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_inspection_results_inspection" FOREIGN KEY ("inspection_id") REFERENCES "inspections"("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_inspection_results_item" FOREIGN KEY ("item_id") REFERENCES "inspection_items"("id") ON DELETE RESTRICT,
+    CONSTRAINT "fk_inspection_results_photo" FOREIGN KEY ("photo_id") REFERENCES "vehicle_photos"("id") ON DELETE SET NULL
+);
+
+ALTER TABLE "inspection_templates" DROP CONSTRAINT IF EXISTS "chk_inspection_templates_kind",
+    ADD CONSTRAINT "chk_inspection_templates_kind" CHECK ("kind" IN ('cpo', 'intake', 'general'));
+ALTER TABLE "inspections" DROP CONSTRAINT IF EXISTS "chk_inspections_kind",
+    ADD CONSTRAINT "chk_inspections_kind" CHECK ("kind" IN ('cpo', 'intake', 'general'));
+ALTER TABLE "inspections" DROP CONSTRAINT IF EXISTS "chk_inspections_status",
+    ADD CONSTRAINT "chk_inspections_status" CHECK ("status" IN ('in_progress', 'completed'));
+ALTER TABLE "inspection_results" DROP CONSTRAINT IF EXISTS "chk_inspection_results_result",
+    ADD CONSTRAINT "chk_inspection_results_result" CHECK ("result" IN ('pass', 'fail', 'na'));
+
+CREATE INDEX IF NOT EXISTS "idx_inspection_sections_template" ON "inspection_sections" ("template_id","sort_order");
+CREATE INDEX IF NOT EXISTS "idx_inspection_items_section" ON "inspection_items" ("section_id","sort_order");
+CREATE UNIQUE INDEX IF NOT EXISTS "uq_inspection_results_item" ON "inspection_results" ("inspection_id","item_id");
+-- Última inspección completada de un tipo, la que decide si un CPO puede salir a la venta
+CREATE INDEX IF NOT EXISTS "idx_inspections_vehicle_kind" ON "inspections" ("vehicle_id","kind","completed_at" DESC);
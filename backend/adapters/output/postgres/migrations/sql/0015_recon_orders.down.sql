@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "recon_tasks";
+DROP TABLE IF EXISTS "recon_orders";
+ALTER TABLE "vehicles" DROP COLUMN IF EXISTS "recon_cost";
@@ -0,0 +1,54 @@
+-- Órdenes de recon con sus tareas (mecánica, chapa, detallado, fotos): responsable o
+-- proveedor, coste estimado y real, aprobación por encima del umbral y tiempos de cada paso.
+-- vehicles.recon_cost acumula lo gastado en tareas completadas para el coste total
+
+ALTER TABLE "vehicles" ADD COLUMN IF NOT EXISTS "recon_cost" decimal DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS "recon_orders" (
+    "id" bigserial,
+    "vehicle_id" bigint NOT NULL,
+    "status" text NOT NULL,
+    "opened_by" bigint,
+    "notes" text,
+    "opened_at" timestamptz NOT NULL,
+    "closed_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 1,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_recon_orders_vehicle" FOREIGN KEY ("vehicle_id") REFERENCES "vehicles"("id") ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS "recon_tasks" (
+    "id" bigserial,
+    "order_id" bigint NOT NULL,
+    "type" text NOT NULL,
+    "description" text,
+    "status" text NOT NULL,
+    "assignee_id" bigint,
+    "vendor" text,
+    "estimated_cost" decimal NOT NULL DEFAULT 0,
+    "actual_cost" decimal,
+    "approval_required" boolean NOT NULL DEFAULT false,
+    "approved_by" bigint,
+    "approved_at" timestamptz,
+    "started_at" timestamptz,
+    "completed_at" timestamptz,
+    "cancelled_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 1,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_recon_tasks_order" FOREIGN KEY ("order_id") REFERENCES "recon_orders"("id") ON DELETE CASCADE,
+    CONSTRAINT "chk_recon_tasks_costs" CHECK ("estimated_cost" >= 0 AND ("actual_cost" IS NULL OR "actual_cost" >= 0))
+);
+
+ALTER TABLE "recon_orders" DROP CONSTRAINT IF EXISTS "chk_recon_orders_status",
+    ADD CONSTRAINT "chk_recon_orders_status" CHECK ("status" IN ('open', 'closed'));
+ALTER TABLE "recon_tasks" DROP CONSTRAINT IF EXISTS "chk_recon_tasks_type",
+    ADD CONSTRAINT "chk_recon_tasks_type" CHECK ("type" IN ('mechanical', 'body', 'detail', 'photos'));
+ALTER TABLE "recon_tasks" DROP CONSTRAINT IF EXISTS "chk_recon_tasks_status",
+    ADD CONSTRAINT "chk_recon_tasks_status" CHECK ("status" IN ('pending', 'in_progress', 'completed', 'cancelled'));
+
+-- Una sola orden abierta por vehicle
+CREATE UNIQUE INDEX IF NOT EXISTS "uq_recon_orders_vehicle_open" ON "recon_orders" ("vehicle_id") WHERE "status" = 'open';
+CREATE INDEX IF NOT EXISTS "idx_recon_orders_vehicle" ON "recon_orders" ("vehicle_id","opened_at" DESC);
+CREATE INDEX IF NOT EXISTS "idx_recon_orders_closed_at" ON "recon_orders" ("closed_at") WHERE "status" = 'closed';
+CREATE INDEX IF NOT EXISTS "idx_recon_tasks_order" ON "recon_tasks" ("order_id","created_at");
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS "vehicle_status_histories";
+
+-- Los tratos pendientes vuelven a estar a la venta
+UPDATE "vehicles" SET "status" = 'ready_for_sale' WHERE "status" = 'pending_sale';
+ALTER TABLE "vehicles" DROP CONSTRAINT IF EXISTS "chk_vehicles_status",
+    ADD CONSTRAINT "chk_vehicles_status" CHECK ("status" IN ('in_transit', 'in_recon', 'ready_for_sale', 'sold', 'wholesale'));
@@ -0,0 +1,24 @@
+-- Máquina de estados del vehicle: nuevo estado pending_sale (trato firmado sin entregar) e
+-- historial de cada cambio de estado con quién lo hizo y por qué
+
+ALTER TABLE "vehicles" DROP CONSTRAINT IF EXISTS "chk_vehicles_status",
+    ADD CONSTRAINT "chk_vehicles_status" CHECK ("status" IN ('in_transit', 'in_recon', 'ready_for_sale', 'pending_sale', 'sold', 'wholesale'));
+
+CREATE TABLE IF NOT EXISTS "vehicle_status_histories" (
+    "id" bigserial,
+    "vehicle_id" bigint NOT NULL,
+    "from_status" text NOT NULL,
+    "to_status" text NOT NULL,
+    "changed_by" bigint,
+    "reason" text,
+    "created_at" timestamptz NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_vehicle_status_histories_vehicle" FOREIGN KEY ("vehicle_id") REFERENCES "vehicles"("id") ON DELETE CASCADE
+);
+
+ALTER TABLE "vehicle_status_histories" DROP CONSTRAINT IF EXISTS "chk_vehicle_status_histories_from_status",
+    ADD CONSTRAINT "chk_vehicle_status_histories_from_status" CHECK ("from_status" IN ('in_transit', 'in_recon', 'ready_for_sale', 'pending_sale', 'sold', 'wholesale'));
+ALTER TABLE "vehicle_status_histories" DROP CONSTRAINT IF EXISTS "chk_vehicle_status_histories_to_status",
+    ADD CONSTRAINT "chk_vehicle_status_histories_to_status" CHECK ("to_status" IN ('in_transit', 'in_recon', 'ready_for_sale', 'pending_sale', 'sold', 'wholesale'));
+
+CREATE INDEX IF NOT EXISTS "idx_vehicle_status_histories_vehicle_created" ON "vehicle_status_histories" ("vehicle_id","created_at");
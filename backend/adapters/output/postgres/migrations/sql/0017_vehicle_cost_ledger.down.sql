@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "vehicle_cost_entries";
+ALTER TABLE "vehicles" DROP COLUMN IF EXISTS "sale_price";
+ALTER TABLE "vehicles" DROP COLUMN IF EXISTS "additional_cost";
@@ -0,0 +1,29 @@
+-- Ledger de costes del vehicle: transporte, recambios y mano de obra de recon, detallado,
+-- fees y pack, con proveedor, factura y fecha. vehicles.additional_cost acumula las entradas
+-- no anuladas para el coste total; vehicles.sale_price guarda el precio real del trato
+
+ALTER TABLE "vehicles" ADD COLUMN IF NOT EXISTS "additional_cost" decimal DEFAULT 0;
+ALTER TABLE "vehicles" ADD COLUMN IF NOT EXISTS "sale_price" decimal;
+
+CREATE TABLE IF NOT EXISTS "vehicle_cost_entries" (
+    "id" bigserial,
+    "vehicle_id" bigint NOT NULL,
+    "type" text NOT NULL,
+    "amount" decimal NOT NULL,
+    "vendor" text,
+    "invoice_ref" text,
+    "incurred_on" timestamptz NOT NULL,
+    "notes" text,
+    "created_by" bigint,
+    "voided_at" timestamptz,
+    "voided_by" bigint,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_vehicle_cost_entries_vehicle" FOREIGN KEY ("vehicle_id") REFERENCES "vehicles"("id") ON DELETE CASCADE,
+    CONSTRAINT "chk_vehicle_cost_entries_amount" CHECK ("amount" > 0)
+);
+
+ALTER TABLE "vehicle_cost_entries" DROP CONSTRAINT IF EXISTS "chk_vehicle_cost_entries_type",
+    ADD CONSTRAINT "chk_vehicle_cost_entries_type" CHECK ("type" IN ('acquisition', 'transport', 'recon_parts', 'recon_labor', 'detail', 'fee', 'pack'));
+
+CREATE INDEX IF NOT EXISTS "idx_vehicle_cost_entries_vehicle_incurred" ON "vehicle_cost_entries" ("vehicle_id","incurred_on");
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "vehicle_price_changes";
+DROP TABLE IF EXISTS "repricing_rules";
@@ -0,0 +1,44 @@
+-- Reglas de rebaja automática del precio publicado por antigüedad en stock, con suelo sobre
+-- el coste, e historial de cambios de precio: manuales (con usuario) y automáticos (con regla)
+
+CREATE TABLE IF NOT EXISTS "repricing_rules" (
+    "id" bigserial,
+    "name" text NOT NULL,
+    "condition" text,
+    "lot_type" text,
+    "min_days_in_stock" bigint NOT NULL DEFAULT 0,
+    "drop_percent" decimal NOT NULL,
+    "interval_days" bigint NOT NULL,
+    "floor_over_cost" decimal NOT NULL DEFAULT 0,
+    "active" boolean DEFAULT true,
+    "version" bigint NOT NULL DEFAULT 1,
+    "created_at" timestamptz,
+    "modified_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "chk_repricing_rules_values" CHECK ("min_days_in_stock" >= 0 AND "drop_percent" > 0 AND "drop_percent" <= 50 AND "interval_days" >= 1 AND "floor_over_cost" >= 0)
+);
+
+ALTER TABLE "repricing_rules" DROP CONSTRAINT IF EXISTS "chk_repricing_rules_condition",
+    ADD CONSTRAINT "chk_repricing_rules_condition" CHECK ("condition" IS NULL OR "condition" IN ('new', 'used', 'certified'));
+ALTER TABLE "repricing_rules" DROP CONSTRAINT IF EXISTS "chk_repricing_rules_lot_type",
+    ADD CONSTRAINT "chk_repricing_rules_lot_type" CHECK ("lot_type" IS NULL OR "lot_type" IN ('new', 'used', 'cpo', 'wholesale'));
+
+CREATE TABLE IF NOT EXISTS "vehicle_price_changes" (
+    "id" bigserial,
+    "vehicle_id" bigint NOT NULL,
+    "old_price" decimal NOT NULL,
+    "new_price" decimal NOT NULL,
+    "source" text NOT NULL,
+    "rule_id" bigint,
+    "changed_by" bigint,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_vehicle_price_changes_vehicle" FOREIGN KEY ("vehicle_id") REFERENCES "vehicles"("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_vehicle_price_changes_rule" FOREIGN KEY ("rule_id") REFERENCES "repricing_rules"("id") ON DELETE SET NULL
+);
+
+ALTER TABLE "vehicle_price_changes" DROP CONSTRAINT IF EXISTS "chk_vehicle_price_changes_source",
+    ADD CONSTRAINT "chk_vehicle_price_changes_source" CHECK ("source" IN ('manual', 'rule'));
+
+CREATE INDEX IF NOT EXISTS "idx_vehicle_price_changes_vehicle_created" ON "vehicle_price_changes" ("vehicle_id","created_at");
+CREATE INDEX IF NOT EXISTS "idx_vehicle_price_changes_rule_drops" ON "vehicle_price_changes" ("vehicle_id","created_at") WHERE "source" = 'rule';
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS "idx_vehicles_sold_lead_id";
+ALTER TABLE "vehicles" DROP CONSTRAINT IF EXISTS "fk_vehicles_sold_lead";
+ALTER TABLE "vehicles" DROP COLUMN IF EXISTS "sold_lead_id";
+ALTER TABLE "vehicles" DROP COLUMN IF EXISTS "sold_at";
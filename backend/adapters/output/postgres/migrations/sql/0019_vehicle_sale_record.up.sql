@@ -0,0 +1,17 @@
+-- Venta del vehicle: fecha del paso a sold y lead del comprador. La retención legal de
+-- privacy cuenta desde sold_at y solo bloquea el borrado del comprador
+
+ALTER TABLE "vehicles" ADD COLUMN IF NOT EXISTS "sold_at" timestamptz;
+ALTER TABLE "vehicles" ADD COLUMN IF NOT EXISTS "sold_lead_id" bigint;
+
+ALTER TABLE "vehicles" DROP CONSTRAINT IF EXISTS "fk_vehicles_sold_lead",
+    ADD CONSTRAINT "fk_vehicles_sold_lead" FOREIGN KEY ("sold_lead_id") REFERENCES "leads"("id") ON DELETE SET NULL;
+
+-- Ventas anteriores: el último paso a sold del historial; sin historial, la última modificación
+UPDATE "vehicles" v SET "sold_at" = COALESCE(
+    (SELECT MAX(h."created_at") FROM "vehicle_status_histories" h WHERE h."vehicle_id" = v."id" AND h."to_status" = 'sold'),
+    v."modified_at"
+)
+WHERE v."status" = 'sold' AND v."sold_at" IS NULL;
+
+CREATE INDEX IF NOT EXISTS "idx_vehicles_sold_lead_id" ON "vehicles" ("sold_lead_id");
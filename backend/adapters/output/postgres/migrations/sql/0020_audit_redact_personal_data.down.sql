@@ -0,0 +1 @@
+-- Los valores redactados no se pueden recuperar
@@ -0,0 +1,27 @@
+-- El audit log dejó de guardar datos personales en los diffs; aquí se limpian los que ya
+-- estaban. El trigger append-only se suspende solo durante esta migración
+
+ALTER TABLE "audit_logs" DISABLE TRIGGER audit_logs_append_only;
+
+DO $$
+DECLARE
+    personal CONSTANT jsonb := '{
+        "entity": ["first_name", "last_name", "business_name", "tax_id", "email", "address", "city", "state", "zip"],
+        "user_account": ["username"],
+        "lead": ["source_detail"],
+        "lead_note": ["content"],
+        "lead_activity": ["description", "outcome", "email"]
+    }';
+    aggregate text;
+    field text;
+BEGIN
+    FOR aggregate IN SELECT jsonb_object_keys(personal) LOOP
+        FOR field IN SELECT jsonb_array_elements_text(personal -> aggregate) LOOP
+            UPDATE "audit_logs"
+            SET "changes" = jsonb_set("changes", ARRAY[field], '{"from": "[redacted]", "to": "[redacted]"}')
+            WHERE "aggregate_type" = aggregate AND "changes" ? field;
+        END LOOP;
+    END LOOP;
+END $$;
+
+ALTER TABLE "audit_logs" ENABLE TRIGGER audit_logs_append_only;
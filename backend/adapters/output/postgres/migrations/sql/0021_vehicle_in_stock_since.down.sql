@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS "idx_vehicles_in_stock_since";
+CREATE INDEX IF NOT EXISTS "idx_vehicles_acquisition_date" ON "vehicles" ("acquisition_date") WHERE "deleted_at" IS NULL;
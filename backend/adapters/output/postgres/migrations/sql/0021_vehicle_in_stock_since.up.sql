@@ -0,0 +1,5 @@
+-- Días en stock: sin fecha de adquisición (guardada como el cero de Go) cuenta el alta,
+-- igual que Vehicle.DaysInStock. El índice cubre la misma expresión que usa la búsqueda
+
+DROP INDEX IF EXISTS "idx_vehicles_acquisition_date";
+CREATE INDEX IF NOT EXISTS "idx_vehicles_in_stock_since" ON "vehicles" ((COALESCE(NULLIF("acquisition_date", '0001-01-01 00:00:00+00'), "created_at"))) WHERE "deleted_at" IS NULL;
@@ -0,0 +1,133 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"gorm.io/gorm"
+	"torque-dms/adapters/output/postgres"
+	"torque-dms/core/audit/domain"
+	"torque-dms/core/audit/ports/output"
+	sharedDomain "torque-dms/core/shared/domain"
+	"torque-dms/models"
+)
+
+var auditColumns = queryColumns{
+	"id":         "id",
+	"action":     "action",
+	"actor_id":   "actor_id",
+	"created_at": "created_at",
+}
+
+// errAuditOutsideTx - la entrada tiene que ir en la transacción del cambio que describe;
+// fuera de ella podría quedar escrita aunque el cambio haga rollback, o al revés
+var errAuditOutsideTx = errors.New("audit entries must be written inside a unit of work")
+
+type auditRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditRepository(db *gorm.DB) output.AuditRepository {
+	return &auditRepository{db: db}
+}
+
+func (r *auditRepository) Append(ctx context.Context, entry *domain.AuditEntry) error {
+	if postgres.TxFromContext(ctx) == nil {
+		return errAuditOutsideTx
+	}
+	model, err := toAuditLogModel(entry)
+	if err != nil {
+		return err
+	}
+	result := dbFrom(ctx, r.db).Create(model)
+	if result.Error != nil {
+		return result.Error
+	}
+	entry.ID = model.ID
+	return nil
+}
+
+func (r *auditRepository) FindByAggregate(ctx context.Context, aggregateType string, aggregateID uint, q sharedDomain.Query) (*sharedDomain.Page[*domain.AuditEntry], error) {
+	db := dbFrom(ctx, r.db).Model(&models.AuditLog{}).
+		Where("aggregate_type = ? AND aggregate_id = ?", aggregateType, aggregateID)
+	return r.findPage(db, q)
+}
+
+func (r *auditRepository) FindByActor(ctx context.Context, actorID uint, q sharedDomain.Query) (*sharedDomain.Page[*domain.AuditEntry], error) {
+	db := dbFrom(ctx, r.db).Model(&models.AuditLog{}).Where("actor_id = ?", actorID)
+	return r.findPage(db, q)
+}
+
+// findPage - el mapper de auditoría puede fallar (changes es JSON), así que se pagina sobre
+// el modelo y se convierte después
+func (r *auditRepository) findPage(db *gorm.DB, q sharedDomain.Query) (*sharedDomain.Page[*domain.AuditEntry], error) {
+	page, err := findPage(db, q, auditColumns, newestFirst, func(m *models.AuditLog) models.AuditLog { return *m })
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := toDomainAuditEntries(page.Items)
+	if err != nil {
+		return nil, err
+	}
+	return &sharedDomain.Page[*domain.AuditEntry]{
+		Items:      entries,
+		Total:      page.Total,
+		Limit:      page.Limit,
+		Offset:     page.Offset,
+		NextCursor: page.NextCursor,
+	}, nil
+}
+
+// Mappers
+
+func toAuditLogModel(e *domain.AuditEntry) (*models.AuditLog, error) {
+	changes, err := json.Marshal(e.Changes)
+	if err != nil {
+		return nil, err
+	}
+	return &models.AuditLog{
+		ID:            e.ID,
+		ActorID:       e.ActorID,
+		Action:        string(e.Action),
+		AggregateType: e.AggregateType,
+		AggregateID:   e.AggregateID,
+		Changes:       changes,
+		IP:            e.IP,
+		RequestID:     e.RequestID,
+		CreatedAt:     e.CreatedAt,
+	}, nil
+}
+
+func toDomainAuditEntry(m *models.AuditLog) (*domain.AuditEntry, error) {
+	changes := map[string]domain.Change{}
+	if len(m.Changes) > 0 {
+		if err := json.Unmarshal(m.Changes, &changes); err != nil {
+			return nil, err
+		}
+	}
+	return &domain.AuditEntry{
+		ID:            m.ID,
+		ActorID:       m.ActorID,
+		Action:        domain.Action(m.Action),
+		AggregateType: m.AggregateType,
+		AggregateID:   m.AggregateID,
+		Changes:       changes,
+		IP:            m.IP,
+		RequestID:     m.RequestID,
+		CreatedAt:     m.CreatedAt,
+	}, nil
+}
+
+func toDomainAuditEntries(modelList []models.AuditLog) ([]*domain.AuditEntry, error) {
+	entries := make([]*domain.AuditEntry, len(modelList))
+	for i := range modelList {
+		entry, err := toDomainAuditEntry(&modelList[i])
+		if err != nil {
+			return nil, err
+		}
+		entries[i] = entry
+	}
+	return entries, nil
+}
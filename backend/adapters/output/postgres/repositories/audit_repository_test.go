@@ -0,0 +1,22 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"torque-dms/core/audit/domain"
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+func TestAuditAppendRequiresTransaction(t *testing.T) {
+	repo := NewAuditRepository(dryRunDB(t))
+	entry, err := domain.NewAuditEntry(sharedDomain.Actor{}, domain.ActionCreate, "vehicle", 1, nil, map[string]int{"id": 1})
+	if err != nil {
+		t.Fatalf("NewAuditEntry() error = %v", err)
+	}
+
+	if err := repo.Append(context.Background(), entry); !errors.Is(err, errAuditOutsideTx) {
+		t.Errorf("Append() error = %v, want errAuditOutsideTx", err)
+	}
+}
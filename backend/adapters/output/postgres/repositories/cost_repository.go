@@ -0,0 +1,105 @@
+package repositories
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"torque-dms/core/inventory/domain"
+	"torque-dms/core/inventory/ports/output"
+	"torque-dms/models"
+)
+
+type costEntryRepository struct {
+	db *gorm.DB
+}
+
+func NewCostEntryRepository(db *gorm.DB) output.CostEntryRepository {
+	return &costEntryRepository{db: db}
+}
+
+func (r *costEntryRepository) Save(ctx context.Context, entry *domain.CostEntry) error {
+	m := toCostEntryModel(entry)
+	result := dbFrom(ctx, r.db).Omit(clause.Associations).Create(m)
+	if result.Error != nil {
+		return result.Error
+	}
+	entry.ID = m.ID
+	return nil
+}
+
+func (r *costEntryRepository) Update(ctx context.Context, entry *domain.CostEntry) error {
+	m := toCostEntryModel(entry)
+	return dbFrom(ctx, r.db).Omit(clause.Associations).Save(m).Error
+}
+
+func (r *costEntryRepository) FindByID(ctx context.Context, id uint) (*domain.CostEntry, error) {
+	var m models.VehicleCostEntry
+	result := dbFrom(ctx, r.db).First(&m, id)
+	if result.Error != nil {
+		return nil, notFound(result.Error, "cost_entry")
+	}
+	return toDomainCostEntry(&m), nil
+}
+
+func (r *costEntryRepository) FindByVehicleID(ctx context.Context, vehicleID uint) ([]*domain.CostEntry, error) {
+	var modelList []models.VehicleCostEntry
+	result := dbFrom(ctx, r.db).
+		Where("vehicle_id = ?", vehicleID).
+		Order("incurred_on ASC, id ASC").
+		Find(&modelList)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	entries := make([]*domain.CostEntry, len(modelList))
+	for i := range modelList {
+		entries[i] = toDomainCostEntry(&modelList[i])
+	}
+	return entries, nil
+}
+
+func (r *costEntryRepository) TotalByVehicle(ctx context.Context, vehicleID uint) (float64, error) {
+	var total float64
+	result := dbFrom(ctx, r.db).Model(&models.VehicleCostEntry{}).
+		Where("vehicle_id = ? AND voided_at IS NULL", vehicleID).
+		Select("COALESCE(SUM(amount), 0)").
+		Scan(&total)
+	return total, result.Error
+}
+
+// Mappers
+
+func toCostEntryModel(e *domain.CostEntry) *models.VehicleCostEntry {
+	return &models.VehicleCostEntry{
+		ID:         e.ID,
+		VehicleID:  e.VehicleID,
+		Type:       string(e.Type),
+		Amount:     e.Amount,
+		Vendor:     e.Vendor,
+		InvoiceRef: e.InvoiceRef,
+		IncurredOn: e.IncurredOn,
+		Notes:      e.Notes,
+		CreatedBy:  e.CreatedBy,
+		VoidedAt:   e.VoidedAt,
+		VoidedBy:   e.VoidedBy,
+		CreatedAt:  e.CreatedAt,
+	}
+}
+
+func toDomainCostEntry(m *models.VehicleCostEntry) *domain.CostEntry {
+	return &domain.CostEntry{
+		ID:         m.ID,
+		VehicleID:  m.VehicleID,
+		Type:       domain.CostEntryType(m.Type),
+		Amount:     m.Amount,
+		Vendor:     m.Vendor,
+		InvoiceRef: m.InvoiceRef,
+		IncurredOn: m.IncurredOn,
+		Notes:      m.Notes,
+		CreatedBy:  m.CreatedBy,
+		VoidedAt:   m.VoidedAt,
+		VoidedBy:   m.VoidedBy,
+		CreatedAt:  m.CreatedAt,
+	}
+}
@@ -0,0 +1,116 @@
+package repositories
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"torque-dms/core/inventory/domain"
+	"torque-dms/core/inventory/ports/output"
+	sharedDomain "torque-dms/core/shared/domain"
+	"torque-dms/models"
+)
+
+type vehicleZoneMarkRepository struct {
+	db *gorm.DB
+}
+
+func NewVehicleZoneMarkRepository(db *gorm.DB) output.VehicleZoneMarkRepository {
+	return &vehicleZoneMarkRepository{db: db}
+}
+
+func (r *vehicleZoneMarkRepository) Save(ctx context.Context, mark *domain.VehicleZoneMark) error {
+	m := toZoneMarkModel(mark)
+	result := dbFrom(ctx, r.db).Omit(clause.Associations).Create(m)
+	if result.Error != nil {
+		return result.Error
+	}
+	mark.ID = m.ID
+	return nil
+}
+
+func (r *vehicleZoneMarkRepository) Update(ctx context.Context, mark *domain.VehicleZoneMark) error {
+	return dbFrom(ctx, r.db).Omit(clause.Associations, "resolved", "resolved_by", "resolved_at").
+		Save(toZoneMarkModel(mark)).Error
+}
+
+func (r *vehicleZoneMarkRepository) Resolve(ctx context.Context, mark *domain.VehicleZoneMark) error {
+	result := dbFrom(ctx, r.db).Model(&models.VehicleZoneMark{}).
+		Where("id = ? AND resolved = ?", mark.ID, false).
+		Updates(map[string]interface{}{
+			"resolved":    true,
+			"resolved_by": mark.ResolvedBy,
+			"resolved_at": mark.ResolvedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return sharedDomain.Invariant("mark_already_resolved", "damage mark is already resolved")
+	}
+	return nil
+}
+
+func (r *vehicleZoneMarkRepository) FindByID(ctx context.Context, id uint) (*domain.VehicleZoneMark, error) {
+	var m models.VehicleZoneMark
+	result := dbFrom(ctx, r.db).First(&m, id)
+	if result.Error != nil {
+		return nil, notFound(result.Error, "damage_mark")
+	}
+	return toDomainZoneMark(&m), nil
+}
+
+func (r *vehicleZoneMarkRepository) FindByVehicleID(ctx context.Context, vehicleID uint, includeResolved bool) ([]*domain.VehicleZoneMark, error) {
+	db := dbFrom(ctx, r.db).Where("vehicle_id = ?", vehicleID)
+	if !includeResolved {
+		db = db.Where("resolved = ?", false)
+	}
+
+	var modelList []models.VehicleZoneMark
+	result := db.Order("created_at DESC, id DESC").Find(&modelList)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	marks := make([]*domain.VehicleZoneMark, len(modelList))
+	for i, m := range modelList {
+		marks[i] = toDomainZoneMark(&m)
+	}
+	return marks, nil
+}
+
+// Mappers
+
+func toZoneMarkModel(m *domain.VehicleZoneMark) *models.VehicleZoneMark {
+	return &models.VehicleZoneMark{
+		ID:          m.ID,
+		VehicleID:   m.VehicleID,
+		ZoneID:      m.ZoneID,
+		Type:        string(m.Type),
+		Severity:    string(m.Severity),
+		Description: m.Description,
+		PhotoID:     m.PhotoID,
+		ReportedBy:  m.ReportedBy,
+		Resolved:    m.Resolved,
+		ResolvedBy:  m.ResolvedBy,
+		ResolvedAt:  m.ResolvedAt,
+		CreatedAt:   m.CreatedAt,
+	}
+}
+
+func toDomainZoneMark(m *models.VehicleZoneMark) *domain.VehicleZoneMark {
+	return &domain.VehicleZoneMark{
+		ID:          m.ID,
+		VehicleID:   m.VehicleID,
+		ZoneID:      m.ZoneID,
+		Type:        domain.DamageType(m.Type),
+		Severity:    domain.DamageSeverity(m.Severity),
+		Description: m.Description,
+		PhotoID:     m.PhotoID,
+		ReportedBy:  m.ReportedBy,
+		Resolved:    m.Resolved,
+		ResolvedBy:  m.ResolvedBy,
+		ResolvedAt:  m.ResolvedAt,
+		CreatedAt:   m.CreatedAt,
+	}
+}
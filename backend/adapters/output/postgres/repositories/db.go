@@ -0,0 +1,104 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"torque-dms/adapters/output/postgres"
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+// dbFrom - usa la transacción del unit of work si la hay, si no la conexión del repository.
+// La query queda atada al ctx para que la cancelación y el deadline del request lleguen a Postgres.
+func dbFrom(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx := postgres.TxFromContext(ctx); tx != nil {
+		db = tx
+	}
+	db = db.WithContext(ctx)
+
+	// Sin Unscoped GORM filtra deleted_at IS NULL en todos los modelos con soft delete
+	if sharedDomain.IncludesDeleted(ctx) {
+		db = db.Unscoped()
+	}
+	return db
+}
+
+// liveLead - excluye las filas hijas de un lead que está en la papelera
+func liveLead(ctx context.Context, table string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if sharedDomain.IncludesDeleted(ctx) {
+			return db
+		}
+		return db.Where("EXISTS (SELECT 1 FROM leads WHERE leads.id = " + table + ".lead_id AND leads.deleted_at IS NULL)")
+	}
+}
+
+// updateVersioned - UPDATE condicionado a la versión leída (el model ya lleva la siguiente).
+// Si no toca filas, o la fila ya no existe (borrada o purgada) o es que otro request escribió antes
+func updateVersioned(ctx context.Context, db *gorm.DB, model interface{}, version uint, resource string) error {
+	result := dbFrom(ctx, db).Model(model).
+		Where("version = ?", version).
+		Select("*").
+		Omit(clause.Associations).
+		Updates(model)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		return nil
+	}
+
+	exists, err := rowExists(ctx, db, model)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return sharedDomain.NotFound(resource)
+	}
+	return sharedDomain.ErrVersionConflict
+}
+
+// rowExists - busca la fila del model por su primary key, con el mismo filtro de papelera
+// que el UPDATE
+func rowExists(ctx context.Context, db *gorm.DB, model interface{}) (bool, error) {
+	tx := dbFrom(ctx, db).Model(model)
+	if err := tx.Statement.Parse(model); err != nil {
+		return false, err
+	}
+	pk := tx.Statement.Schema.PrioritizedPrimaryField
+	id, _ := pk.ValueOf(ctx, reflect.ValueOf(model))
+
+	var count int64
+	if err := tx.Where(clause.Eq{Column: clause.Column{Name: pk.DBName}, Value: id}).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// notFound - traduce el ErrRecordNotFound de GORM al error de dominio; cualquier otro fallo
+// (conexión, timeout) sube tal cual para que no se confunda con un registro inexistente
+func notFound(err error, resource string) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return sharedDomain.NotFound(resource)
+	}
+	return err
+}
+
+func toDeletedAt(t *time.Time) gorm.DeletedAt {
+	if t == nil {
+		return gorm.DeletedAt{}
+	}
+	return gorm.DeletedAt{Time: *t, Valid: true}
+}
+
+func fromDeletedAt(d gorm.DeletedAt) *time.Time {
+	if !d.Valid {
+		return nil
+	}
+	t := d.Time
+	return &t
+}
@@ -1,12 +1,32 @@
 package repositories
 
 import (
+	"context"
+	"time"
+
 	"gorm.io/gorm"
 	"torque-dms/core/identity/domain"
 	"torque-dms/core/identity/ports/output"
+	sharedDomain "torque-dms/core/shared/domain"
 	"torque-dms/models"
 )
 
+var entityColumns = queryColumns{
+	"id":            "id",
+	"type":          "type",
+	"status":        "status",
+	"email":         "email",
+	"first_name":    "first_name",
+	"last_name":     "last_name",
+	"business_name": "business_name",
+	"city":          "city",
+	"state":         "state",
+	"is_internal":   "is_internal",
+	"created_at":    "created_at",
+	"modified_at":   "modified_at",
+	"deleted_at":    "deleted_at",
+}
+
 type entityRepository struct {
 	db *gorm.DB
 }
@@ -15,42 +35,57 @@ func NewEntityRepository(db *gorm.DB) output.EntityRepository {
 	return &entityRepository{db: db}
 }
 
-func (r *entityRepository) Save(entity *domain.Entity) error {
+func (r *entityRepository) Save(ctx context.Context, entity *domain.Entity) error {
 	model := toEntityModel(entity)
-	result := r.db.Create(model)
+	result := dbFrom(ctx, r.db).Create(model)
 	if result.Error != nil {
 		return result.Error
 	}
 	entity.ID = model.ID
+	entity.Version = model.Version
 	return nil
 }
 
-func (r *entityRepository) Update(entity *domain.Entity) error {
+func (r *entityRepository) Update(ctx context.Context, entity *domain.Entity) error {
 	model := toEntityModel(entity)
-	return r.db.Save(model).Error
+	model.Version = entity.Version + 1
+	if err := updateVersioned(ctx, r.db, model, entity.Version, "entity"); err != nil {
+		return err
+	}
+	entity.Version = model.Version
+	return nil
 }
 
-func (r *entityRepository) FindByID(id uint) (*domain.Entity, error) {
+func (r *entityRepository) FindByID(ctx context.Context, id uint) (*domain.Entity, error) {
 	var model models.Entity
-	result := r.db.First(&model, id)
+	result := dbFrom(ctx, r.db).First(&model, id)
 	if result.Error != nil {
-		return nil, result.Error
+		return nil, notFound(result.Error, "entity")
 	}
 	return toDomainEntity(&model), nil
 }
 
-func (r *entityRepository) FindByEmail(email string) (*domain.Entity, error) {
+func (r *entityRepository) FindByEmail(ctx context.Context, email string) (*domain.Entity, error) {
 	var model models.Entity
-	result := r.db.Where("email = ?", email).First(&model)
+	result := dbFrom(ctx, r.db).Where("email = ?", email).First(&model)
 	if result.Error != nil {
-		return nil, result.Error
+		return nil, notFound(result.Error, "entity")
 	}
 	return toDomainEntity(&model), nil
 }
 
-func (r *entityRepository) FindAll(limit int, offset int) ([]*domain.Entity, error) {
+func (r *entityRepository) FindAll(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.Entity], error) {
+	return findPage(dbFrom(ctx, r.db).Model(&models.Entity{}), q, entityColumns, nil, toDomainEntity)
+}
+
+func (r *entityRepository) FindDeleted(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.Entity], error) {
+	db := dbFrom(ctx, r.db).Unscoped().Model(&models.Entity{}).Where("deleted_at IS NOT NULL")
+	return findPage(db, q, entityColumns, deletedSort, toDomainEntity)
+}
+
+func (r *entityRepository) FindDeletedBefore(ctx context.Context, before time.Time) ([]*domain.Entity, error) {
 	var modelList []models.Entity
-	result := r.db.Limit(limit).Offset(offset).Find(&modelList)
+	result := dbFrom(ctx, r.db).Unscoped().Where("deleted_at < ?", before).Order("deleted_at ASC").Find(&modelList)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -62,13 +97,13 @@ func (r *entityRepository) FindAll(limit int, offset int) ([]*domain.Entity, err
 	return entities, nil
 }
 
-func (r *entityRepository) Delete(id uint) error {
-	return r.db.Delete(&models.Entity{}, id).Error
+func (r *entityRepository) Purge(ctx context.Context, id uint) error {
+	return dbFrom(ctx, r.db).Unscoped().Delete(&models.Entity{}, id).Error
 }
 
-func (r *entityRepository) Exists(id uint) (bool, error) {
+func (r *entityRepository) Exists(ctx context.Context, id uint) (bool, error) {
 	var count int64
-	result := r.db.Model(&models.Entity{}).Where("id = ?", id).Count(&count)
+	result := dbFrom(ctx, r.db).Model(&models.Entity{}).Where("id = ?", id).Count(&count)
 	return count > 0, result.Error
 }
 
@@ -92,6 +127,10 @@ func toEntityModel(e *domain.Entity) *models.Entity {
 		IsInternal:     e.IsInternal,
 		ParentEntityID: e.ParentEntityID,
 		Status:         models.EntityStatus(e.Status),
+		ErasedAt:       e.ErasedAt,
+		DeletedAt:      toDeletedAt(e.DeletedAt),
+		DeletedBy:      e.DeletedBy,
+		Version:        e.Version,
 		CreatedAt:      e.CreatedAt,
 		ModifiedAt:     e.ModifiedAt,
 	}
@@ -115,6 +154,10 @@ func toDomainEntity(m *models.Entity) *domain.Entity {
 		IsInternal:     m.IsInternal,
 		ParentEntityID: m.ParentEntityID,
 		Status:         domain.EntityStatus(m.Status),
+		ErasedAt:       m.ErasedAt,
+		DeletedAt:      fromDeletedAt(m.DeletedAt),
+		DeletedBy:      m.DeletedBy,
+		Version:        m.Version,
 		CreatedAt:      m.CreatedAt,
 		ModifiedAt:     m.ModifiedAt,
 	}
@@ -0,0 +1,106 @@
+package repositories
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"torque-dms/core/inventory/domain"
+	"torque-dms/core/inventory/ports/output"
+	sharedDomain "torque-dms/core/shared/domain"
+	"torque-dms/models"
+)
+
+var geofenceEventColumns = queryColumns{
+	"id":          "id",
+	"vehicle_id":  "vehicle_id",
+	"location_id": "location_id",
+	"type":        "type",
+	"outcome":     "outcome",
+	"recorded_at": "recorded_at",
+}
+
+var latestRecordedFirst = []sharedDomain.Sort{{Field: "recorded_at", Desc: true}}
+
+// Último cruce de cada vehicle con una sola lectura del índice (vehicle_id, recorded_at, id)
+const latestEventByVehicleSQL = `SELECT DISTINCT ON ("vehicle_id") * FROM "geofence_events"
+	WHERE "vehicle_id" IN ?
+	ORDER BY "vehicle_id", "recorded_at" DESC, "id" DESC`
+
+type geofenceEventRepository struct {
+	db *gorm.DB
+}
+
+func NewGeofenceEventRepository(db *gorm.DB) output.GeofenceEventRepository {
+	return &geofenceEventRepository{db: db}
+}
+
+func (r *geofenceEventRepository) Save(ctx context.Context, event *domain.GeofenceEvent) error {
+	model := toGeofenceEventModel(event)
+	result := dbFrom(ctx, r.db).Omit(clause.Associations).Create(model)
+	if result.Error != nil {
+		return result.Error
+	}
+	event.ID = model.ID
+	return nil
+}
+
+func (r *geofenceEventRepository) FindLatestByVehicleIDs(ctx context.Context, vehicleIDs []uint) (map[uint]*domain.GeofenceEvent, error) {
+	events := make(map[uint]*domain.GeofenceEvent, len(vehicleIDs))
+	if len(vehicleIDs) == 0 {
+		return events, nil
+	}
+
+	var modelList []models.GeofenceEvent
+	result := dbFrom(ctx, r.db).Raw(latestEventByVehicleSQL, vehicleIDs).Scan(&modelList)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	for i := range modelList {
+		events[modelList[i].VehicleID] = toDomainGeofenceEvent(&modelList[i])
+	}
+	return events, nil
+}
+
+func (r *geofenceEventRepository) FindByVehicleID(ctx context.Context, vehicleID uint, q sharedDomain.Query) (*sharedDomain.Page[*domain.GeofenceEvent], error) {
+	db := dbFrom(ctx, r.db).Model(&models.GeofenceEvent{}).Where("vehicle_id = ?", vehicleID)
+	return findPage(db, q, geofenceEventColumns, latestRecordedFirst, toDomainGeofenceEvent)
+}
+
+func (r *geofenceEventRepository) FindByLocationID(ctx context.Context, locationID uint, q sharedDomain.Query) (*sharedDomain.Page[*domain.GeofenceEvent], error) {
+	db := dbFrom(ctx, r.db).Model(&models.GeofenceEvent{}).Where("location_id = ?", locationID)
+	return findPage(db, q, geofenceEventColumns, latestRecordedFirst, toDomainGeofenceEvent)
+}
+
+// Mappers
+
+func toGeofenceEventModel(e *domain.GeofenceEvent) *models.GeofenceEvent {
+	return &models.GeofenceEvent{
+		ID:         e.ID,
+		VehicleID:  e.VehicleID,
+		LocationID: e.LocationID,
+		Type:       string(e.Type),
+		Latitude:   e.Latitude,
+		Longitude:  e.Longitude,
+		RecordedAt: e.RecordedAt,
+		Outcome:    string(e.Outcome),
+		Note:       e.Note,
+		CreatedAt:  e.CreatedAt,
+	}
+}
+
+func toDomainGeofenceEvent(m *models.GeofenceEvent) *domain.GeofenceEvent {
+	return &domain.GeofenceEvent{
+		ID:         m.ID,
+		VehicleID:  m.VehicleID,
+		LocationID: m.LocationID,
+		Type:       domain.GeofenceEventType(m.Type),
+		Latitude:   m.Latitude,
+		Longitude:  m.Longitude,
+		RecordedAt: m.RecordedAt,
+		Outcome:    domain.GeofenceOutcome(m.Outcome),
+		Note:       m.Note,
+		CreatedAt:  m.CreatedAt,
+	}
+}
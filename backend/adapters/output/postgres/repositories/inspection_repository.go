@@ -0,0 +1,325 @@
+package repositories
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"torque-dms/core/inventory/domain"
+	"torque-dms/core/inventory/ports/output"
+	sharedDomain "torque-dms/core/shared/domain"
+	"torque-dms/models"
+)
+
+var inspectionTemplateColumns = queryColumns{
+	"id":         "id",
+	"name":       "name",
+	"kind":       "kind",
+	"active":     "active",
+	"created_at": "created_at",
+}
+
+type inspectionTemplateRepository struct {
+	db *gorm.DB
+}
+
+func NewInspectionTemplateRepository(db *gorm.DB) output.InspectionTemplateRepository {
+	return &inspectionTemplateRepository{db: db}
+}
+
+func (r *inspectionTemplateRepository) Save(ctx context.Context, template *domain.InspectionTemplate) error {
+	m := toInspectionTemplateModel(template)
+	result := dbFrom(ctx, r.db).Create(m)
+	if result.Error != nil {
+		return result.Error
+	}
+	template.ID = m.ID
+	template.Version = m.Version
+	copySectionIDs(template, m.Sections)
+	return nil
+}
+
+func (r *inspectionTemplateRepository) Update(ctx context.Context, template *domain.InspectionTemplate) error {
+	m := toInspectionTemplateModel(template)
+	m.Version = template.Version + 1
+	if err := updateVersioned(ctx, r.db, m, template.Version, "inspection_template"); err != nil {
+		return err
+	}
+	template.Version = m.Version
+	return nil
+}
+
+// ReplaceStructure - borra las secciones (los items caen en cascada) y las vuelve a crear
+func (r *inspectionTemplateRepository) ReplaceStructure(ctx context.Context, template *domain.InspectionTemplate) error {
+	if err := dbFrom(ctx, r.db).Where("template_id = ?", template.ID).Delete(&models.InspectionSection{}).Error; err != nil {
+		return err
+	}
+
+	sections := toInspectionSectionModels(template.ID, template.Sections)
+	if err := dbFrom(ctx, r.db).Create(&sections).Error; err != nil {
+		return err
+	}
+	copySectionIDs(template, sections)
+	return nil
+}
+
+func (r *inspectionTemplateRepository) FindByID(ctx context.Context, id uint) (*domain.InspectionTemplate, error) {
+	var m models.InspectionTemplate
+	result := dbFrom(ctx, r.db).
+		Preload("Sections", func(db *gorm.DB) *gorm.DB { return db.Order("sort_order ASC, id ASC") }).
+		Preload("Sections.Items", func(db *gorm.DB) *gorm.DB { return db.Order("sort_order ASC, id ASC") }).
+		First(&m, id)
+	if result.Error != nil {
+		return nil, notFound(result.Error, "inspection_template")
+	}
+	return toDomainInspectionTemplate(&m), nil
+}
+
+func (r *inspectionTemplateRepository) FindAll(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.InspectionTemplate], error) {
+	return findPage(dbFrom(ctx, r.db).Model(&models.InspectionTemplate{}), q, inspectionTemplateColumns, byName, toDomainInspectionTemplate)
+}
+
+func (r *inspectionTemplateRepository) IsInUse(ctx context.Context, id uint) (bool, error) {
+	var count int64
+	result := dbFrom(ctx, r.db).Model(&models.Inspection{}).Where("template_id = ?", id).Count(&count)
+	return count > 0, result.Error
+}
+
+func (r *inspectionTemplateRepository) Delete(ctx context.Context, id uint) error {
+	return dbFrom(ctx, r.db).Delete(&models.InspectionTemplate{}, id).Error
+}
+
+type inspectionRepository struct {
+	db *gorm.DB
+}
+
+func NewInspectionRepository(db *gorm.DB) output.InspectionRepository {
+	return &inspectionRepository{db: db}
+}
+
+func (r *inspectionRepository) Save(ctx context.Context, inspection *domain.Inspection) error {
+	m := toInspectionModel(inspection)
+	result := dbFrom(ctx, r.db).Omit(clause.Associations).Create(m)
+	if result.Error != nil {
+		return result.Error
+	}
+	inspection.ID = m.ID
+	inspection.Version = m.Version
+	return nil
+}
+
+func (r *inspectionRepository) Update(ctx context.Context, inspection *domain.Inspection) error {
+	m := toInspectionModel(inspection)
+	m.Version = inspection.Version + 1
+	if err := updateVersioned(ctx, r.db, m, inspection.Version, "inspection"); err != nil {
+		return err
+	}
+	inspection.Version = m.Version
+	return nil
+}
+
+// SaveResults - upsert por (inspection_id, item_id); el RETURNING trae el id de la fila existente
+func (r *inspectionRepository) SaveResults(ctx context.Context, results []*domain.InspectionResult) error {
+	for _, result := range results {
+		m := toInspectionResultModel(result)
+		m.ID = 0
+		err := dbFrom(ctx, r.db).Omit(clause.Associations).Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "inspection_id"}, {Name: "item_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"result", "value", "photo_id", "notes", "recorded_at"}),
+		}).Create(m).Error
+		if err != nil {
+			return err
+		}
+		result.ID = m.ID
+	}
+	return nil
+}
+
+func (r *inspectionRepository) FindByID(ctx context.Context, id uint) (*domain.Inspection, error) {
+	var m models.Inspection
+	result := dbFrom(ctx, r.db).Preload("Results").First(&m, id)
+	if result.Error != nil {
+		return nil, notFound(result.Error, "inspection")
+	}
+	return toDomainInspection(&m), nil
+}
+
+func (r *inspectionRepository) FindByVehicleID(ctx context.Context, vehicleID uint) ([]*domain.Inspection, error) {
+	var modelList []models.Inspection
+	result := dbFrom(ctx, r.db).Where("vehicle_id = ?", vehicleID).Order("started_at DESC, id DESC").Find(&modelList)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	inspections := make([]*domain.Inspection, len(modelList))
+	for i, m := range modelList {
+		inspections[i] = toDomainInspection(&m)
+	}
+	return inspections, nil
+}
+
+func (r *inspectionRepository) FindLatestCompleted(ctx context.Context, vehicleID uint, kind domain.InspectionKind) (*domain.Inspection, error) {
+	var m models.Inspection
+	result := dbFrom(ctx, r.db).
+		Where("vehicle_id = ? AND kind = ? AND status = ?", vehicleID, string(kind), string(domain.InspectionStatusCompleted)).
+		Order("completed_at DESC, id DESC").
+		First(&m)
+	if result.Error != nil {
+		return nil, notFound(result.Error, "inspection")
+	}
+	return toDomainInspection(&m), nil
+}
+
+// copySectionIDs - los IDs generados vuelven al dominio en el mismo orden en que se enviaron
+func copySectionIDs(template *domain.InspectionTemplate, sections []models.InspectionSection) {
+	for i, section := range template.Sections {
+		section.ID = sections[i].ID
+		section.TemplateID = sections[i].TemplateID
+		for j, item := range section.Items {
+			item.ID = sections[i].Items[j].ID
+			item.SectionID = sections[i].Items[j].SectionID
+		}
+	}
+}
+
+// Mappers
+
+func toInspectionTemplateModel(t *domain.InspectionTemplate) *models.InspectionTemplate {
+	return &models.InspectionTemplate{
+		ID:          t.ID,
+		Name:        t.Name,
+		Kind:        string(t.Kind),
+		Description: t.Description,
+		Active:      t.Active,
+		Sections:    toInspectionSectionModels(t.ID, t.Sections),
+		Version:     t.Version,
+		CreatedAt:   t.CreatedAt,
+	}
+}
+
+func toInspectionSectionModels(templateID uint, sections []*domain.InspectionSection) []models.InspectionSection {
+	list := make([]models.InspectionSection, len(sections))
+	for i, s := range sections {
+		items := make([]models.InspectionItem, len(s.Items))
+		for j, item := range s.Items {
+			items[j] = models.InspectionItem{
+				Label:         item.Label,
+				Required:      item.Required,
+				PhotoRequired: item.PhotoRequired,
+				Unit:          item.Unit,
+				MinValue:      item.MinValue,
+				MaxValue:      item.MaxValue,
+				SortOrder:     item.SortOrder,
+			}
+		}
+		list[i] = models.InspectionSection{
+			TemplateID: templateID,
+			Name:       s.Name,
+			SortOrder:  s.SortOrder,
+			Items:      items,
+		}
+	}
+	return list
+}
+
+func toDomainInspectionTemplate(m *models.InspectionTemplate) *domain.InspectionTemplate {
+	sections := make([]*domain.InspectionSection, len(m.Sections))
+	for i, s := range m.Sections {
+		items := make([]*domain.InspectionItem, len(s.Items))
+		for j, item := range s.Items {
+			items[j] = &domain.InspectionItem{
+				ID:            item.ID,
+				SectionID:     item.SectionID,
+				Label:         item.Label,
+				Required:      item.Required,
+				PhotoRequired: item.PhotoRequired,
+				Unit:          item.Unit,
+				MinValue:      item.MinValue,
+				MaxValue:      item.MaxValue,
+				SortOrder:     item.SortOrder,
+			}
+		}
+		sections[i] = &domain.InspectionSection{
+			ID:         s.ID,
+			TemplateID: s.TemplateID,
+			Name:       s.Name,
+			SortOrder:  s.SortOrder,
+			Items:      items,
+		}
+	}
+
+	return &domain.InspectionTemplate{
+		ID:          m.ID,
+		Name:        m.Name,
+		Kind:        domain.InspectionKind(m.Kind),
+		Description: m.Description,
+		Active:      m.Active,
+		Sections:    sections,
+		Version:     m.Version,
+		CreatedAt:   m.CreatedAt,
+	}
+}
+
+func toInspectionModel(i *domain.Inspection) *models.Inspection {
+	return &models.Inspection{
+		ID:          i.ID,
+		VehicleID:   i.VehicleID,
+		TemplateID:  i.TemplateID,
+		Kind:        string(i.Kind),
+		InspectorID: i.InspectorID,
+		Status:      string(i.Status),
+		Passed:      i.Passed,
+		Notes:       i.Notes,
+		StartedAt:   i.StartedAt,
+		CompletedAt: i.CompletedAt,
+		Version:     i.Version,
+	}
+}
+
+func toDomainInspection(m *models.Inspection) *domain.Inspection {
+	results := make([]*domain.InspectionResult, len(m.Results))
+	for i := range m.Results {
+		results[i] = toDomainInspectionResult(&m.Results[i])
+	}
+
+	return &domain.Inspection{
+		ID:          m.ID,
+		VehicleID:   m.VehicleID,
+		TemplateID:  m.TemplateID,
+		Kind:        domain.InspectionKind(m.Kind),
+		InspectorID: m.InspectorID,
+		Status:      domain.InspectionStatus(m.Status),
+		Passed:      m.Passed,
+		Notes:       m.Notes,
+		Results:     results,
+		StartedAt:   m.StartedAt,
+		CompletedAt: m.CompletedAt,
+		Version:     m.Version,
+	}
+}
+
+func toInspectionResultModel(r *domain.InspectionResult) *models.InspectionResult {
+	return &models.InspectionResult{
+		ID:           r.ID,
+		InspectionID: r.InspectionID,
+		ItemID:       r.ItemID,
+		Result:       string(r.Result),
+		Value:        r.Value,
+		PhotoID:      r.PhotoID,
+		Notes:        r.Notes,
+		RecordedAt:   r.RecordedAt,
+	}
+}
+
+func toDomainInspectionResult(m *models.InspectionResult) *domain.InspectionResult {
+	return &domain.InspectionResult{
+		ID:           m.ID,
+		InspectionID: m.InspectionID,
+		ItemID:       m.ItemID,
+		Result:       domain.ItemResult(m.Result),
+		Value:        m.Value,
+		PhotoID:      m.PhotoID,
+		Notes:        m.Notes,
+		RecordedAt:   m.RecordedAt,
+	}
+}
@@ -1,14 +1,25 @@
 package repositories
 
 import (
+	"context"
 	"time"
 
 	"gorm.io/gorm"
 	"torque-dms/core/sales/domain"
 	"torque-dms/core/sales/ports/output"
+	sharedDomain "torque-dms/core/shared/domain"
 	"torque-dms/models"
 )
 
+var activityColumns = queryColumns{
+	"id":           "id",
+	"type":         "type",
+	"performed_by": "performed_by",
+	"scheduled_at": "scheduled_at",
+	"completed_at": "completed_at",
+	"created_at":   "created_at",
+}
+
 type leadActivityRepository struct {
 	db *gorm.DB
 }
@@ -17,9 +28,9 @@ func NewLeadActivityRepository(db *gorm.DB) output.LeadActivityRepository {
 	return &leadActivityRepository{db: db}
 }
 
-func (r *leadActivityRepository) Save(activity *domain.LeadActivity) error {
+func (r *leadActivityRepository) Save(ctx context.Context, activity *domain.LeadActivity) error {
 	model := toLeadActivityModel(activity)
-	result := r.db.Create(model)
+	result := dbFrom(ctx, r.db).Create(model)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -27,23 +38,23 @@ func (r *leadActivityRepository) Save(activity *domain.LeadActivity) error {
 	return nil
 }
 
-func (r *leadActivityRepository) Update(activity *domain.LeadActivity) error {
+func (r *leadActivityRepository) Update(ctx context.Context, activity *domain.LeadActivity) error {
 	model := toLeadActivityModel(activity)
-	return r.db.Save(model).Error
+	return dbFrom(ctx, r.db).Save(model).Error
 }
 
-func (r *leadActivityRepository) FindByID(id uint) (*domain.LeadActivity, error) {
+func (r *leadActivityRepository) FindByID(ctx context.Context, id uint) (*domain.LeadActivity, error) {
 	var model models.LeadActivity
-	result := r.db.First(&model, id)
+	result := dbFrom(ctx, r.db).First(&model, id)
 	if result.Error != nil {
-		return nil, result.Error
+		return nil, notFound(result.Error, "activity")
 	}
 	return toDomainLeadActivity(&model), nil
 }
 
-func (r *leadActivityRepository) FindByLeadID(leadID uint) ([]*domain.LeadActivity, error) {
+func (r *leadActivityRepository) FindByLeadID(ctx context.Context, leadID uint) ([]*domain.LeadActivity, error) {
 	var modelList []models.LeadActivity
-	result := r.db.Where("lead_id = ?", leadID).Order("created_at DESC").Find(&modelList)
+	result := dbFrom(ctx, r.db).Where("lead_id = ?", leadID).Order("created_at DESC").Find(&modelList)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -55,9 +66,16 @@ func (r *leadActivityRepository) FindByLeadID(leadID uint) ([]*domain.LeadActivi
 	return activities, nil
 }
 
-func (r *leadActivityRepository) FindScheduledByEntityID(entityID uint) ([]*domain.LeadActivity, error) {
+// FindPageByLeadID - pensado para keyset: el índice (lead_id, created_at, id) cubre el orden
+func (r *leadActivityRepository) FindPageByLeadID(ctx context.Context, leadID uint, q sharedDomain.Query) (*sharedDomain.Page[*domain.LeadActivity], error) {
+	db := dbFrom(ctx, r.db).Model(&models.LeadActivity{}).Where("lead_id = ?", leadID)
+	return findPage(db, q, activityColumns, newestFirst, toDomainLeadActivity)
+}
+
+func (r *leadActivityRepository) FindScheduledByEntityID(ctx context.Context, entityID uint) ([]*domain.LeadActivity, error) {
 	var modelList []models.LeadActivity
-	result := r.db.Where("performed_by = ? AND scheduled_at IS NOT NULL AND completed_at IS NULL", entityID).
+	result := dbFrom(ctx, r.db).Scopes(liveLead(ctx, "lead_activities")).
+		Where("performed_by = ? AND scheduled_at IS NOT NULL AND completed_at IS NULL", entityID).
 		Order("scheduled_at ASC").Find(&modelList)
 	if result.Error != nil {
 		return nil, result.Error
@@ -70,9 +88,10 @@ func (r *leadActivityRepository) FindScheduledByEntityID(entityID uint) ([]*doma
 	return activities, nil
 }
 
-func (r *leadActivityRepository) FindOverdue() ([]*domain.LeadActivity, error) {
+func (r *leadActivityRepository) FindOverdue(ctx context.Context) ([]*domain.LeadActivity, error) {
 	var modelList []models.LeadActivity
-	result := r.db.Where("scheduled_at < ? AND completed_at IS NULL", time.Now()).
+	result := dbFrom(ctx, r.db).Scopes(liveLead(ctx, "lead_activities")).
+		Where("scheduled_at < ? AND completed_at IS NULL", time.Now()).
 		Order("scheduled_at ASC").Find(&modelList)
 	if result.Error != nil {
 		return nil, result.Error
@@ -85,8 +104,8 @@ func (r *leadActivityRepository) FindOverdue() ([]*domain.LeadActivity, error) {
 	return activities, nil
 }
 
-func (r *leadActivityRepository) Delete(id uint) error {
-	return r.db.Delete(&models.LeadActivity{}, id).Error
+func (r *leadActivityRepository) Delete(ctx context.Context, id uint) error {
+	return dbFrom(ctx, r.db).Delete(&models.LeadActivity{}, id).Error
 }
 
 // Mappers
@@ -1,6 +1,8 @@
 package repositories
 
 import (
+	"context"
+
 	"gorm.io/gorm"
 	"torque-dms/core/sales/domain"
 	"torque-dms/core/sales/ports/output"
@@ -15,9 +17,9 @@ func NewLeadAssignmentRepository(db *gorm.DB) output.LeadAssignmentRepository {
 	return &leadAssignmentRepository{db: db}
 }
 
-func (r *leadAssignmentRepository) Save(assignment *domain.LeadAssignment) error {
+func (r *leadAssignmentRepository) Save(ctx context.Context, assignment *domain.LeadAssignment) error {
 	model := toLeadAssignmentModel(assignment)
-	result := r.db.Create(model)
+	result := dbFrom(ctx, r.db).Create(model)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -25,23 +27,23 @@ func (r *leadAssignmentRepository) Save(assignment *domain.LeadAssignment) error
 	return nil
 }
 
-func (r *leadAssignmentRepository) Update(assignment *domain.LeadAssignment) error {
+func (r *leadAssignmentRepository) Update(ctx context.Context, assignment *domain.LeadAssignment) error {
 	model := toLeadAssignmentModel(assignment)
-	return r.db.Save(model).Error
+	return dbFrom(ctx, r.db).Save(model).Error
 }
 
-func (r *leadAssignmentRepository) FindByID(id uint) (*domain.LeadAssignment, error) {
+func (r *leadAssignmentRepository) FindByID(ctx context.Context, id uint) (*domain.LeadAssignment, error) {
 	var model models.LeadAssignment
-	result := r.db.First(&model, id)
+	result := dbFrom(ctx, r.db).First(&model, id)
 	if result.Error != nil {
-		return nil, result.Error
+		return nil, notFound(result.Error, "assignment")
 	}
 	return toDomainLeadAssignment(&model), nil
 }
 
-func (r *leadAssignmentRepository) FindByLeadID(leadID uint) ([]*domain.LeadAssignment, error) {
+func (r *leadAssignmentRepository) FindByLeadID(ctx context.Context, leadID uint) ([]*domain.LeadAssignment, error) {
 	var modelList []models.LeadAssignment
-	result := r.db.Where("lead_id = ? AND active = ?", leadID, true).Find(&modelList)
+	result := dbFrom(ctx, r.db).Where("lead_id = ? AND active = ?", leadID, true).Find(&modelList)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -53,18 +55,18 @@ func (r *leadAssignmentRepository) FindByLeadID(leadID uint) ([]*domain.LeadAssi
 	return assignments, nil
 }
 
-func (r *leadAssignmentRepository) FindPrimaryByLeadID(leadID uint) (*domain.LeadAssignment, error) {
+func (r *leadAssignmentRepository) FindPrimaryByLeadID(ctx context.Context, leadID uint) (*domain.LeadAssignment, error) {
 	var model models.LeadAssignment
-	result := r.db.Where("lead_id = ? AND is_primary = ? AND active = ?", leadID, true, true).First(&model)
+	result := dbFrom(ctx, r.db).Where("lead_id = ? AND is_primary = ? AND active = ?", leadID, true, true).First(&model)
 	if result.Error != nil {
-		return nil, result.Error
+		return nil, notFound(result.Error, "assignment")
 	}
 	return toDomainLeadAssignment(&model), nil
 }
 
-func (r *leadAssignmentRepository) FindByEntityID(entityID uint) ([]*domain.LeadAssignment, error) {
+func (r *leadAssignmentRepository) FindByEntityID(ctx context.Context, entityID uint) ([]*domain.LeadAssignment, error) {
 	var modelList []models.LeadAssignment
-	result := r.db.Where("entity_id = ? AND active = ?", entityID, true).Find(&modelList)
+	result := dbFrom(ctx, r.db).Scopes(liveLead(ctx, "lead_assignments")).Where("entity_id = ? AND active = ?", entityID, true).Find(&modelList)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -76,8 +78,8 @@ func (r *leadAssignmentRepository) FindByEntityID(entityID uint) ([]*domain.Lead
 	return assignments, nil
 }
 
-func (r *leadAssignmentRepository) Delete(id uint) error {
-	return r.db.Delete(&models.LeadAssignment{}, id).Error
+func (r *leadAssignmentRepository) Delete(ctx context.Context, id uint) error {
+	return dbFrom(ctx, r.db).Delete(&models.LeadAssignment{}, id).Error
 }
 
 // Mappers
@@ -1,6 +1,8 @@
 package repositories
 
 import (
+	"context"
+
 	"gorm.io/gorm"
 	"torque-dms/core/sales/domain"
 	"torque-dms/core/sales/ports/output"
@@ -15,9 +17,9 @@ func NewLeadNoteRepository(db *gorm.DB) output.LeadNoteRepository {
 	return &leadNoteRepository{db: db}
 }
 
-func (r *leadNoteRepository) Save(note *domain.LeadNote) error {
+func (r *leadNoteRepository) Save(ctx context.Context, note *domain.LeadNote) error {
 	model := toLeadNoteModel(note)
-	result := r.db.Create(model)
+	result := dbFrom(ctx, r.db).Create(model)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -25,23 +27,23 @@ func (r *leadNoteRepository) Save(note *domain.LeadNote) error {
 	return nil
 }
 
-func (r *leadNoteRepository) Update(note *domain.LeadNote) error {
+func (r *leadNoteRepository) Update(ctx context.Context, note *domain.LeadNote) error {
 	model := toLeadNoteModel(note)
-	return r.db.Save(model).Error
+	return dbFrom(ctx, r.db).Save(model).Error
 }
 
-func (r *leadNoteRepository) FindByID(id uint) (*domain.LeadNote, error) {
+func (r *leadNoteRepository) FindByID(ctx context.Context, id uint) (*domain.LeadNote, error) {
 	var model models.LeadNote
-	result := r.db.First(&model, id)
+	result := dbFrom(ctx, r.db).First(&model, id)
 	if result.Error != nil {
-		return nil, result.Error
+		return nil, notFound(result.Error, "note")
 	}
 	return toDomainLeadNote(&model), nil
 }
 
-func (r *leadNoteRepository) FindByLeadID(leadID uint) ([]*domain.LeadNote, error) {
+func (r *leadNoteRepository) FindByLeadID(ctx context.Context, leadID uint) ([]*domain.LeadNote, error) {
 	var modelList []models.LeadNote
-	result := r.db.Where("lead_id = ?", leadID).Order("created_at DESC").Find(&modelList)
+	result := dbFrom(ctx, r.db).Where("lead_id = ?", leadID).Order("created_at DESC").Find(&modelList)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -53,8 +55,8 @@ func (r *leadNoteRepository) FindByLeadID(leadID uint) ([]*domain.LeadNote, erro
 	return notes, nil
 }
 
-func (r *leadNoteRepository) Delete(id uint) error {
-	return r.db.Delete(&models.LeadNote{}, id).Error
+func (r *leadNoteRepository) Delete(ctx context.Context, id uint) error {
+	return dbFrom(ctx, r.db).Delete(&models.LeadNote{}, id).Error
 }
 
 // Mappers
@@ -1,12 +1,32 @@
 package repositories
 
 import (
+	"context"
+	"time"
+
 	"gorm.io/gorm"
 	"torque-dms/core/sales/domain"
 	"torque-dms/core/sales/ports/output"
+	sharedDomain "torque-dms/core/shared/domain"
 	"torque-dms/models"
 )
 
+var leadColumns = queryColumns{
+	"id":             "id",
+	"entity_id":      "entity_id",
+	"vehicle_id":     "vehicle_id",
+	"source_id":      "source_id",
+	"preset_id":      "preset_id",
+	"interest_type":  "interest_type",
+	"interest_make":  "interest_make",
+	"interest_model": "interest_model",
+	"budget_min":     "budget_min",
+	"budget_max":     "budget_max",
+	"created_at":     "created_at",
+	"modified_at":    "modified_at",
+	"deleted_at":     "deleted_at",
+}
+
 type leadRepository struct {
 	db *gorm.DB
 }
@@ -15,33 +35,39 @@ func NewLeadRepository(db *gorm.DB) output.LeadRepository {
 	return &leadRepository{db: db}
 }
 
-func (r *leadRepository) Save(lead *domain.Lead) error {
+func (r *leadRepository) Save(ctx context.Context, lead *domain.Lead) error {
 	model := toLeadModel(lead)
-	result := r.db.Create(model)
+	result := dbFrom(ctx, r.db).Create(model)
 	if result.Error != nil {
 		return result.Error
 	}
 	lead.ID = model.ID
+	lead.Version = model.Version
 	return nil
 }
 
-func (r *leadRepository) Update(lead *domain.Lead) error {
+func (r *leadRepository) Update(ctx context.Context, lead *domain.Lead) error {
 	model := toLeadModel(lead)
-	return r.db.Save(model).Error
+	model.Version = lead.Version + 1
+	if err := updateVersioned(ctx, r.db, model, lead.Version, "lead"); err != nil {
+		return err
+	}
+	lead.Version = model.Version
+	return nil
 }
 
-func (r *leadRepository) FindByID(id uint) (*domain.Lead, error) {
+func (r *leadRepository) FindByID(ctx context.Context, id uint) (*domain.Lead, error) {
 	var model models.Lead
-	result := r.db.First(&model, id)
+	result := dbFrom(ctx, r.db).First(&model, id)
 	if result.Error != nil {
-		return nil, result.Error
+		return nil, notFound(result.Error, "lead")
 	}
 	return toDomainLead(&model), nil
 }
 
-func (r *leadRepository) FindByEntityID(entityID uint) ([]*domain.Lead, error) {
+func (r *leadRepository) FindByEntityID(ctx context.Context, entityID uint) ([]*domain.Lead, error) {
 	var modelList []models.Lead
-	result := r.db.Where("entity_id = ?", entityID).Order("created_at DESC").Find(&modelList)
+	result := dbFrom(ctx, r.db).Where("entity_id = ?", entityID).Order("created_at DESC").Find(&modelList)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -53,9 +79,18 @@ func (r *leadRepository) FindByEntityID(entityID uint) ([]*domain.Lead, error) {
 	return leads, nil
 }
 
-func (r *leadRepository) FindAll(limit int, offset int) ([]*domain.Lead, error) {
+func (r *leadRepository) FindAll(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.Lead], error) {
+	return findPage(dbFrom(ctx, r.db).Model(&models.Lead{}), q, leadColumns, newestFirst, toDomainLead)
+}
+
+func (r *leadRepository) FindDeleted(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.Lead], error) {
+	db := dbFrom(ctx, r.db).Unscoped().Model(&models.Lead{}).Where("deleted_at IS NOT NULL")
+	return findPage(db, q, leadColumns, deletedSort, toDomainLead)
+}
+
+func (r *leadRepository) FindDeletedBefore(ctx context.Context, before time.Time) ([]*domain.Lead, error) {
 	var modelList []models.Lead
-	result := r.db.Limit(limit).Offset(offset).Order("created_at DESC").Find(&modelList)
+	result := dbFrom(ctx, r.db).Unscoped().Where("deleted_at < ?", before).Order("deleted_at ASC").Find(&modelList)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -67,13 +102,13 @@ func (r *leadRepository) FindAll(limit int, offset int) ([]*domain.Lead, error)
 	return leads, nil
 }
 
-func (r *leadRepository) Delete(id uint) error {
-	return r.db.Delete(&models.Lead{}, id).Error
+func (r *leadRepository) Purge(ctx context.Context, id uint) error {
+	return dbFrom(ctx, r.db).Unscoped().Delete(&models.Lead{}, id).Error
 }
 
-func (r *leadRepository) Exists(id uint) (bool, error) {
+func (r *leadRepository) Exists(ctx context.Context, id uint) (bool, error) {
 	var count int64
-	result := r.db.Model(&models.Lead{}).Where("id = ?", id).Count(&count)
+	result := dbFrom(ctx, r.db).Model(&models.Lead{}).Where("id = ?", id).Count(&count)
 	return count > 0, result.Error
 }
 
@@ -92,6 +127,9 @@ func toLeadModel(l *domain.Lead) *models.Lead {
 		SourceID:      l.SourceID,
 		SourceDetail:  l.SourceDetail,
 		PresetID:      l.PresetID,
+		DeletedAt:     toDeletedAt(l.DeletedAt),
+		DeletedBy:     l.DeletedBy,
+		Version:       l.Version,
 		CreatedAt:     l.CreatedAt,
 		ModifiedAt:    l.ModifiedAt,
 	}
@@ -110,6 +148,9 @@ func toDomainLead(m *models.Lead) *domain.Lead {
 		SourceID:      m.SourceID,
 		SourceDetail:  m.SourceDetail,
 		PresetID:      m.PresetID,
+		DeletedAt:     fromDeletedAt(m.DeletedAt),
+		DeletedBy:     m.DeletedBy,
+		Version:       m.Version,
 		CreatedAt:     m.CreatedAt,
 		ModifiedAt:    m.ModifiedAt,
 	}
@@ -1,6 +1,8 @@
 package repositories
 
 import (
+	"context"
+
 	"gorm.io/gorm"
 	"torque-dms/core/sales/domain"
 	"torque-dms/core/sales/ports/output"
@@ -15,9 +17,9 @@ func NewLeadSourceRepository(db *gorm.DB) output.LeadSourceRepository {
 	return &leadSourceRepository{db: db}
 }
 
-func (r *leadSourceRepository) Save(source *domain.LeadSource) error {
+func (r *leadSourceRepository) Save(ctx context.Context, source *domain.LeadSource) error {
 	model := toLeadSourceModel(source)
-	result := r.db.Create(model)
+	result := dbFrom(ctx, r.db).Create(model)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -25,32 +27,32 @@ func (r *leadSourceRepository) Save(source *domain.LeadSource) error {
 	return nil
 }
 
-func (r *leadSourceRepository) Update(source *domain.LeadSource) error {
+func (r *leadSourceRepository) Update(ctx context.Context, source *domain.LeadSource) error {
 	model := toLeadSourceModel(source)
-	return r.db.Save(model).Error
+	return dbFrom(ctx, r.db).Save(model).Error
 }
 
-func (r *leadSourceRepository) FindByID(id uint) (*domain.LeadSource, error) {
+func (r *leadSourceRepository) FindByID(ctx context.Context, id uint) (*domain.LeadSource, error) {
 	var model models.LeadSource
-	result := r.db.First(&model, id)
+	result := dbFrom(ctx, r.db).First(&model, id)
 	if result.Error != nil {
-		return nil, result.Error
+		return nil, notFound(result.Error, "source")
 	}
 	return toDomainLeadSource(&model), nil
 }
 
-func (r *leadSourceRepository) FindByCode(code string) (*domain.LeadSource, error) {
+func (r *leadSourceRepository) FindByCode(ctx context.Context, code string) (*domain.LeadSource, error) {
 	var model models.LeadSource
-	result := r.db.Where("code = ?", code).First(&model)
+	result := dbFrom(ctx, r.db).Where("code = ?", code).First(&model)
 	if result.Error != nil {
-		return nil, result.Error
+		return nil, notFound(result.Error, "source")
 	}
 	return toDomainLeadSource(&model), nil
 }
 
-func (r *leadSourceRepository) FindAll() ([]*domain.LeadSource, error) {
+func (r *leadSourceRepository) FindAll(ctx context.Context) ([]*domain.LeadSource, error) {
 	var modelList []models.LeadSource
-	result := r.db.Order("name ASC").Find(&modelList)
+	result := dbFrom(ctx, r.db).Order("name ASC").Find(&modelList)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -62,9 +64,9 @@ func (r *leadSourceRepository) FindAll() ([]*domain.LeadSource, error) {
 	return sources, nil
 }
 
-func (r *leadSourceRepository) FindActive() ([]*domain.LeadSource, error) {
+func (r *leadSourceRepository) FindActive(ctx context.Context) ([]*domain.LeadSource, error) {
 	var modelList []models.LeadSource
-	result := r.db.Where("active = ?", true).Order("name ASC").Find(&modelList)
+	result := dbFrom(ctx, r.db).Where("active = ?", true).Order("name ASC").Find(&modelList)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -76,13 +78,13 @@ func (r *leadSourceRepository) FindActive() ([]*domain.LeadSource, error) {
 	return sources, nil
 }
 
-func (r *leadSourceRepository) Delete(id uint) error {
-	return r.db.Delete(&models.LeadSource{}, id).Error
+func (r *leadSourceRepository) Delete(ctx context.Context, id uint) error {
+	return dbFrom(ctx, r.db).Delete(&models.LeadSource{}, id).Error
 }
 
-func (r *leadSourceRepository) Exists(id uint) (bool, error) {
+func (r *leadSourceRepository) Exists(ctx context.Context, id uint) (bool, error) {
 	var count int64
-	result := r.db.Model(&models.LeadSource{}).Where("id = ?", id).Count(&count)
+	result := dbFrom(ctx, r.db).Model(&models.LeadSource{}).Where("id = ?", id).Count(&count)
 	return count > 0, result.Error
 }
 
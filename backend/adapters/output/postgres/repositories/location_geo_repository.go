@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"context"
+	"sort"
+
+	"gorm.io/gorm"
+	"torque-dms/core/inventory/domain"
+	"torque-dms/core/inventory/ports/output"
+	sharedDomain "torque-dms/core/shared/domain"
+	"torque-dms/models"
+)
+
+// locationGeoRepository - distancias calculadas en Go con haversine. SQL solo descarta
+// por rectángulo; son pocas ubicaciones, así que no hace falta PostGIS para que sea rápido
+type locationGeoRepository struct {
+	db *gorm.DB
+}
+
+func NewLocationGeoRepository(db *gorm.DB) output.LocationGeoRepository {
+	return &locationGeoRepository{db: db}
+}
+
+func (r *locationGeoRepository) FindNearest(ctx context.Context, origin sharedDomain.GeoPoint, types []domain.LocationType, limit int) ([]*domain.LocationDistance, error) {
+	db := locatedLocations(dbFrom(ctx, r.db))
+	if len(types) > 0 {
+		db = db.Where("type IN ?", types)
+	}
+
+	distances, err := r.distances(db, origin)
+	if err != nil {
+		return nil, err
+	}
+	if limit > 0 && len(distances) > limit {
+		distances = distances[:limit]
+	}
+	return distances, nil
+}
+
+func (r *locationGeoRepository) FindWithin(ctx context.Context, origin sharedDomain.GeoPoint, radiusKM float64) ([]*domain.LocationDistance, error) {
+	southWest, northEast := origin.BoundingBox(radiusKM)
+	db := locatedLocations(dbFrom(ctx, r.db)).
+		Where("latitude BETWEEN ? AND ?", southWest.Latitude, northEast.Latitude).
+		Where("longitude BETWEEN ? AND ?", southWest.Longitude, northEast.Longitude)
+
+	distances, err := r.distances(db, origin)
+	if err != nil {
+		return nil, err
+	}
+
+	// El rectángulo incluye las esquinas; aquí se queda solo el círculo
+	within := distances[:0]
+	for _, d := range distances {
+		if d.DistanceKM <= radiusKM {
+			within = append(within, d)
+		}
+	}
+	return within, nil
+}
+
+func (r *locationGeoRepository) distances(db *gorm.DB, origin sharedDomain.GeoPoint) ([]*domain.LocationDistance, error) {
+	var modelList []models.Location
+	if err := db.Find(&modelList).Error; err != nil {
+		return nil, err
+	}
+
+	distances := make([]*domain.LocationDistance, 0, len(modelList))
+	for i := range modelList {
+		location := toDomainLocation(&modelList[i])
+		point, _ := location.Point()
+		distances = append(distances, &domain.LocationDistance{
+			Location:   location,
+			DistanceKM: origin.DistanceKM(point),
+		})
+	}
+
+	sort.SliceStable(distances, func(i, j int) bool {
+		return distances[i].DistanceKM < distances[j].DistanceKM
+	})
+	return distances, nil
+}
+
+// locatedLocations - activas y con coordenadas; 0,0 es el valor sin rellenar
+func locatedLocations(db *gorm.DB) *gorm.DB {
+	return db.Model(&models.Location{}).
+		Where("active = ?", true).
+		Where("NOT (latitude = 0 AND longitude = 0)").
+		Order("id")
+}
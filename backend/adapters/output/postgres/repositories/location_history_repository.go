@@ -0,0 +1,68 @@
+package repositories
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"torque-dms/core/inventory/domain"
+	"torque-dms/core/inventory/ports/output"
+	sharedDomain "torque-dms/core/shared/domain"
+	"torque-dms/models"
+)
+
+var locationHistoryColumns = queryColumns{
+	"id":               "id",
+	"from_location_id": "from_location_id",
+	"to_location_id":   "to_location_id",
+	"moved_by":         "moved_by",
+	"created_at":       "created_at",
+}
+
+type vehicleLocationHistoryRepository struct {
+	db *gorm.DB
+}
+
+func NewVehicleLocationHistoryRepository(db *gorm.DB) output.VehicleLocationHistoryRepository {
+	return &vehicleLocationHistoryRepository{db: db}
+}
+
+func (r *vehicleLocationHistoryRepository) Save(ctx context.Context, history *domain.VehicleLocationHistory) error {
+	model := toLocationHistoryModel(history)
+	result := dbFrom(ctx, r.db).Create(model)
+	if result.Error != nil {
+		return result.Error
+	}
+	history.ID = model.ID
+	return nil
+}
+
+func (r *vehicleLocationHistoryRepository) FindByVehicleID(ctx context.Context, vehicleID uint, q sharedDomain.Query) (*sharedDomain.Page[*domain.VehicleLocationHistory], error) {
+	db := dbFrom(ctx, r.db).Model(&models.VehicleLocationHistory{}).Where("vehicle_id = ?", vehicleID)
+	return findPage(db, q, locationHistoryColumns, newestFirst, toDomainLocationHistory)
+}
+
+// Mappers
+
+func toLocationHistoryModel(h *domain.VehicleLocationHistory) *models.VehicleLocationHistory {
+	return &models.VehicleLocationHistory{
+		ID:             h.ID,
+		VehicleID:      h.VehicleID,
+		FromLocationID: h.FromLocationID,
+		ToLocationID:   h.ToLocationID,
+		MovedBy:        h.MovedBy,
+		Reason:         h.Reason,
+		CreatedAt:      h.CreatedAt,
+	}
+}
+
+func toDomainLocationHistory(m *models.VehicleLocationHistory) *domain.VehicleLocationHistory {
+	return &domain.VehicleLocationHistory{
+		ID:             m.ID,
+		VehicleID:      m.VehicleID,
+		FromLocationID: m.FromLocationID,
+		ToLocationID:   m.ToLocationID,
+		MovedBy:        m.MovedBy,
+		Reason:         m.Reason,
+		CreatedAt:      m.CreatedAt,
+	}
+}
@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"torque-dms/core/inventory/domain"
+	"torque-dms/core/inventory/ports/output"
+	sharedDomain "torque-dms/core/shared/domain"
+	"torque-dms/models"
+)
+
+// Distancia geodésica sobre el elipsoide en km. Requiere la extensión postgis
+const postgisDistance = `ST_Distance(geography(ST_SetSRID(ST_MakePoint(longitude, latitude), 4326)), geography(ST_SetSRID(ST_MakePoint(?, ?), 4326))) / 1000`
+
+// locationPostGISRepository - misma interfaz que el de haversine pero el cálculo y el orden
+// los hace Postgres. Para instalaciones con PostGIS (GEO_BACKEND=postgis)
+type locationPostGISRepository struct {
+	db *gorm.DB
+}
+
+func NewLocationPostGISRepository(db *gorm.DB) output.LocationGeoRepository {
+	return &locationPostGISRepository{db: db}
+}
+
+func (r *locationPostGISRepository) FindNearest(ctx context.Context, origin sharedDomain.GeoPoint, types []domain.LocationType, limit int) ([]*domain.LocationDistance, error) {
+	db := r.withDistance(dbFrom(ctx, r.db), origin)
+	if len(types) > 0 {
+		db = db.Where("type IN ?", types)
+	}
+	if limit > 0 {
+		db = db.Limit(limit)
+	}
+	return r.find(db)
+}
+
+func (r *locationPostGISRepository) FindWithin(ctx context.Context, origin sharedDomain.GeoPoint, radiusKM float64) ([]*domain.LocationDistance, error) {
+	db := r.withDistance(dbFrom(ctx, r.db), origin).
+		Where("ST_DWithin(geography(ST_SetSRID(ST_MakePoint(longitude, latitude), 4326)), geography(ST_SetSRID(ST_MakePoint(?, ?), 4326)), ?)",
+			origin.Longitude, origin.Latitude, radiusKM*1000)
+	return r.find(db)
+}
+
+func (r *locationPostGISRepository) withDistance(db *gorm.DB, origin sharedDomain.GeoPoint) *gorm.DB {
+	return db.Model(&models.Location{}).
+		Select("locations.*, "+postgisDistance+" AS distance_km", origin.Longitude, origin.Latitude).
+		Where("active = ?", true).
+		Where("NOT (latitude = 0 AND longitude = 0)").
+		Order("distance_km, id")
+}
+
+func (r *locationPostGISRepository) find(db *gorm.DB) ([]*domain.LocationDistance, error) {
+	var rows []struct {
+		models.Location
+		DistanceKM float64
+	}
+	if err := db.Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	distances := make([]*domain.LocationDistance, len(rows))
+	for i := range rows {
+		distances[i] = &domain.LocationDistance{
+			Location:   toDomainLocation(&rows[i].Location),
+			DistanceKM: rows[i].DistanceKM,
+		}
+	}
+	return distances, nil
+}
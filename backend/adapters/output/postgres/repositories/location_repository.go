@@ -1,12 +1,36 @@
 package repositories
 
 import (
+	"context"
+	"encoding/json"
+
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"torque-dms/core/inventory/domain"
 	"torque-dms/core/inventory/ports/output"
+	sharedDomain "torque-dms/core/shared/domain"
 	"torque-dms/models"
 )
 
+// Tablas que referencian locations con ON DELETE RESTRICT, aparte de vehicles
+const locationHistorySQL = `SELECT
+	EXISTS (SELECT 1 FROM "routes" WHERE "from_location_id" = @id OR "to_location_id" = @id) OR
+	EXISTS (SELECT 1 FROM "vehicle_transfers" WHERE "from_location_id" = @id OR "transit_location_id" = @id OR "to_location_id" = @id) OR
+	EXISTS (SELECT 1 FROM "vehicle_location_histories" WHERE "from_location_id" = @id OR "to_location_id" = @id)`
+
+var locationColumns = queryColumns{
+	"id":         "id",
+	"name":       "name",
+	"type":       "type",
+	"city":       "city",
+	"state":      "state",
+	"active":     "active",
+	"capacity":   "capacity",
+	"created_at": "created_at",
+}
+
+var byName = []sharedDomain.Sort{{Field: "name"}}
+
 type locationRepository struct {
 	db *gorm.DB
 }
@@ -15,56 +39,61 @@ func NewLocationRepository(db *gorm.DB) output.LocationRepository {
 	return &locationRepository{db: db}
 }
 
-func (r *locationRepository) Save(location *domain.Location) error {
+func (r *locationRepository) Save(ctx context.Context, location *domain.Location) error {
 	model := toLocationModel(location)
-	result := r.db.Create(model)
+	result := dbFrom(ctx, r.db).Create(model)
 	if result.Error != nil {
 		return result.Error
 	}
 	location.ID = model.ID
+	location.Version = model.Version
 	return nil
 }
 
-func (r *locationRepository) Update(location *domain.Location) error {
+func (r *locationRepository) Update(ctx context.Context, location *domain.Location) error {
 	model := toLocationModel(location)
-	return r.db.Save(model).Error
+	model.Version = location.Version + 1
+	if err := updateVersioned(ctx, r.db, model, location.Version, "location"); err != nil {
+		return err
+	}
+	location.Version = model.Version
+	return nil
 }
 
-func (r *locationRepository) FindByID(id uint) (*domain.Location, error) {
+func (r *locationRepository) FindByID(ctx context.Context, id uint) (*domain.Location, error) {
 	var model models.Location
-	result := r.db.First(&model, id)
+	result := dbFrom(ctx, r.db).First(&model, id)
 	if result.Error != nil {
-		return nil, result.Error
+		return nil, notFound(result.Error, "location")
 	}
 	return toDomainLocation(&model), nil
 }
 
-func (r *locationRepository) FindByName(name string) (*domain.Location, error) {
+func (r *locationRepository) FindByIDForUpdate(ctx context.Context, id uint) (*domain.Location, error) {
 	var model models.Location
-	result := r.db.Where("name = ?", name).First(&model)
+	result := dbFrom(ctx, r.db).Clauses(clause.Locking{Strength: "UPDATE"}).First(&model, id)
 	if result.Error != nil {
-		return nil, result.Error
+		return nil, notFound(result.Error, "location")
 	}
 	return toDomainLocation(&model), nil
 }
 
-func (r *locationRepository) FindAll() ([]*domain.Location, error) {
-	var modelList []models.Location
-	result := r.db.Order("name ASC").Find(&modelList)
+func (r *locationRepository) FindByName(ctx context.Context, name string) (*domain.Location, error) {
+	var model models.Location
+	result := dbFrom(ctx, r.db).Where("name = ?", name).First(&model)
 	if result.Error != nil {
-		return nil, result.Error
+		return nil, notFound(result.Error, "location")
 	}
+	return toDomainLocation(&model), nil
+}
 
-	locations := make([]*domain.Location, len(modelList))
-	for i, model := range modelList {
-		locations[i] = toDomainLocation(&model)
-	}
-	return locations, nil
+func (r *locationRepository) FindAll(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.Location], error) {
+	return findPage(dbFrom(ctx, r.db).Model(&models.Location{}), q, locationColumns, byName, toDomainLocation)
 }
 
-func (r *locationRepository) FindByType(locationType domain.LocationType) ([]*domain.Location, error) {
+func (r *locationRepository) FindByType(ctx context.Context, locationType domain.LocationType) ([]*domain.Location, error) {
 	var modelList []models.Location
-	result := r.db.Where("type = ?", locationType).Order("name ASC").Find(&modelList)
+	result := dbFrom(ctx, r.db).Where("type = ?", locationType).Order("name ASC").Find(&modelList)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -76,9 +105,11 @@ func (r *locationRepository) FindByType(locationType domain.LocationType) ([]*do
 	return locations, nil
 }
 
-func (r *locationRepository) FindActive() ([]*domain.Location, error) {
+func (r *locationRepository) FindWithGeofence(ctx context.Context) ([]*domain.Location, error) {
 	var modelList []models.Location
-	result := r.db.Where("active = ?", true).Order("name ASC").Find(&modelList)
+	result := dbFrom(ctx, r.db).
+		Where("active = ? AND (geofence_radius_m IS NOT NULL OR geofence_polygon IS NOT NULL)", true).
+		Find(&modelList)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -90,20 +121,26 @@ func (r *locationRepository) FindActive() ([]*domain.Location, error) {
 	return locations, nil
 }
 
-func (r *locationRepository) Delete(id uint) error {
-	return r.db.Delete(&models.Location{}, id).Error
+func (r *locationRepository) Delete(ctx context.Context, id uint) error {
+	return dbFrom(ctx, r.db).Delete(&models.Location{}, id).Error
 }
 
-func (r *locationRepository) Exists(id uint) (bool, error) {
+func (r *locationRepository) Exists(ctx context.Context, id uint) (bool, error) {
 	var count int64
-	result := r.db.Model(&models.Location{}).Where("id = ?", id).Count(&count)
+	result := dbFrom(ctx, r.db).Model(&models.Location{}).Where("id = ?", id).Count(&count)
 	return count > 0, result.Error
 }
 
+func (r *locationRepository) HasHistory(ctx context.Context, id uint) (bool, error) {
+	var exists bool
+	result := dbFrom(ctx, r.db).Raw(locationHistorySQL, map[string]interface{}{"id": id}).Scan(&exists)
+	return exists, result.Error
+}
+
 // Mappers
 
 func toLocationModel(l *domain.Location) *models.Location {
-	return &models.Location{
+	model := &models.Location{
 		ID:        l.ID,
 		Name:      l.Name,
 		Type:      models.LocationType(l.Type),
@@ -116,12 +153,28 @@ func toLocationModel(l *domain.Location) *models.Location {
 		Longitude: l.Longitude,
 		Capacity:  l.Capacity,
 		Active:    l.Active,
+		Version:   l.Version,
 		CreatedAt: l.CreatedAt,
 	}
+
+	if l.Geofence != nil {
+		if l.Geofence.RadiusM > 0 {
+			radius := l.Geofence.RadiusM
+			model.GeofenceRadiusM = &radius
+		}
+		if len(l.Geofence.Polygon) > 0 {
+			points := make([]waypoint, len(l.Geofence.Polygon))
+			for i, p := range l.Geofence.Polygon {
+				points[i] = waypoint{Latitude: p.Latitude, Longitude: p.Longitude}
+			}
+			model.GeofencePolygon, _ = json.Marshal(points)
+		}
+	}
+	return model
 }
 
 func toDomainLocation(m *models.Location) *domain.Location {
-	return &domain.Location{
+	location := &domain.Location{
 		ID:        m.ID,
 		Name:      m.Name,
 		Type:      domain.LocationType(m.Type),
@@ -134,6 +187,29 @@ func toDomainLocation(m *models.Location) *domain.Location {
 		Longitude: m.Longitude,
 		Capacity:  m.Capacity,
 		Active:    m.Active,
+		Version:   m.Version,
 		CreatedAt: m.CreatedAt,
 	}
+	location.Geofence = toDomainGeofence(m)
+	return location
+}
+
+// toDomainGeofence - un polígono ilegible se trata como ubicación sin geocerca
+func toDomainGeofence(m *models.Location) *domain.Geofence {
+	if m.GeofenceRadiusM != nil {
+		return &domain.Geofence{RadiusM: *m.GeofenceRadiusM}
+	}
+	if len(m.GeofencePolygon) == 0 {
+		return nil
+	}
+
+	var points []waypoint
+	if err := json.Unmarshal(m.GeofencePolygon, &points); err != nil || len(points) == 0 {
+		return nil
+	}
+	polygon := make([]sharedDomain.GeoPoint, len(points))
+	for i, p := range points {
+		polygon[i] = sharedDomain.GeoPoint{Latitude: p.Latitude, Longitude: p.Longitude}
+	}
+	return &domain.Geofence{Polygon: polygon}
 }
\ No newline at end of file
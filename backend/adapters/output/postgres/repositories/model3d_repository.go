@@ -0,0 +1,227 @@
+package repositories
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"torque-dms/core/inventory/domain"
+	"torque-dms/core/inventory/ports/output"
+	sharedDomain "torque-dms/core/shared/domain"
+	"torque-dms/models"
+)
+
+var model3DColumns = queryColumns{
+	"id":         "id",
+	"name":       "name",
+	"body_type":  "body_type",
+	"is_default": "is_default",
+	"active":     "active",
+	"created_at": "created_at",
+}
+
+type vehicleModel3DRepository struct {
+	db *gorm.DB
+}
+
+func NewVehicleModel3DRepository(db *gorm.DB) output.VehicleModel3DRepository {
+	return &vehicleModel3DRepository{db: db}
+}
+
+func (r *vehicleModel3DRepository) Save(ctx context.Context, model *domain.VehicleModel3D) error {
+	m := toModel3DModel(model)
+	result := dbFrom(ctx, r.db).Create(m)
+	if result.Error != nil {
+		return result.Error
+	}
+	model.ID = m.ID
+	model.Version = m.Version
+	return nil
+}
+
+func (r *vehicleModel3DRepository) Update(ctx context.Context, model *domain.VehicleModel3D) error {
+	m := toModel3DModel(model)
+	m.Version = model.Version + 1
+	if err := updateVersioned(ctx, r.db, m, model.Version, "model_3d"); err != nil {
+		return err
+	}
+	model.Version = m.Version
+	return nil
+}
+
+func (r *vehicleModel3DRepository) FindByID(ctx context.Context, id uint) (*domain.VehicleModel3D, error) {
+	var m models.VehicleModel3D
+	result := dbFrom(ctx, r.db).First(&m, id)
+	if result.Error != nil {
+		return nil, notFound(result.Error, "model_3d")
+	}
+	return toDomainModel3D(&m), nil
+}
+
+func (r *vehicleModel3DRepository) FindAll(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.VehicleModel3D], error) {
+	return findPage(dbFrom(ctx, r.db).Model(&models.VehicleModel3D{}), q, model3DColumns, byName, toDomainModel3D)
+}
+
+func (r *vehicleModel3DRepository) FindDefault(ctx context.Context, bodyType domain.BodyType) (*domain.VehicleModel3D, error) {
+	var m models.VehicleModel3D
+	result := dbFrom(ctx, r.db).
+		Where("body_type = ? AND is_default = ? AND active = ?", string(bodyType), true, true).
+		First(&m)
+	if result.Error != nil {
+		return nil, notFound(result.Error, "model_3d")
+	}
+	return toDomainModel3D(&m), nil
+}
+
+// ClearDefault - sube la versión para que una edición concurrente del antiguo predeterminado
+// no le devuelva la marca
+func (r *vehicleModel3DRepository) ClearDefault(ctx context.Context, bodyType domain.BodyType) error {
+	return dbFrom(ctx, r.db).Model(&models.VehicleModel3D{}).
+		Where("body_type = ? AND is_default = ?", string(bodyType), true).
+		Updates(map[string]interface{}{"is_default": false, "version": gorm.Expr("version + 1")}).Error
+}
+
+func (r *vehicleModel3DRepository) HasMarks(ctx context.Context, id uint) (bool, error) {
+	var count int64
+	result := dbFrom(ctx, r.db).Model(&models.VehicleZoneMark{}).
+		Where("zone_id IN (?)", dbFrom(ctx, r.db).Model(&models.VehicleModelZone{}).Select("id").Where("model3_d_id = ?", id)).
+		Count(&count)
+	return count > 0, result.Error
+}
+
+func (r *vehicleModel3DRepository) Delete(ctx context.Context, id uint) error {
+	return dbFrom(ctx, r.db).Delete(&models.VehicleModel3D{}, id).Error
+}
+
+type vehicleModelZoneRepository struct {
+	db *gorm.DB
+}
+
+func NewVehicleModelZoneRepository(db *gorm.DB) output.VehicleModelZoneRepository {
+	return &vehicleModelZoneRepository{db: db}
+}
+
+func (r *vehicleModelZoneRepository) Save(ctx context.Context, zone *domain.VehicleModelZone) error {
+	m := toModelZoneModel(zone)
+	result := dbFrom(ctx, r.db).Omit(clause.Associations).Create(m)
+	if result.Error != nil {
+		return result.Error
+	}
+	zone.ID = m.ID
+	return nil
+}
+
+func (r *vehicleModelZoneRepository) Update(ctx context.Context, zone *domain.VehicleModelZone) error {
+	return dbFrom(ctx, r.db).Omit(clause.Associations).Save(toModelZoneModel(zone)).Error
+}
+
+func (r *vehicleModelZoneRepository) FindByID(ctx context.Context, id uint) (*domain.VehicleModelZone, error) {
+	var m models.VehicleModelZone
+	result := dbFrom(ctx, r.db).First(&m, id)
+	if result.Error != nil {
+		return nil, notFound(result.Error, "zone")
+	}
+	return toDomainModelZone(&m), nil
+}
+
+func (r *vehicleModelZoneRepository) FindByModelID(ctx context.Context, model3DID uint) ([]*domain.VehicleModelZone, error) {
+	var modelList []models.VehicleModelZone
+	result := dbFrom(ctx, r.db).Where("model3_d_id = ?", model3DID).Order("code ASC").Find(&modelList)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	zones := make([]*domain.VehicleModelZone, len(modelList))
+	for i, m := range modelList {
+		zones[i] = toDomainModelZone(&m)
+	}
+	return zones, nil
+}
+
+func (r *vehicleModelZoneRepository) FindByIDs(ctx context.Context, ids []uint) ([]*domain.VehicleModelZone, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var modelList []models.VehicleModelZone
+	result := dbFrom(ctx, r.db).Where("id IN ?", ids).Find(&modelList)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	zones := make([]*domain.VehicleModelZone, len(modelList))
+	for i, m := range modelList {
+		zones[i] = toDomainModelZone(&m)
+	}
+	return zones, nil
+}
+
+func (r *vehicleModelZoneRepository) ExistsByCode(ctx context.Context, model3DID uint, code string) (bool, error) {
+	var count int64
+	result := dbFrom(ctx, r.db).Model(&models.VehicleModelZone{}).
+		Where("model3_d_id = ? AND code = ?", model3DID, code).
+		Count(&count)
+	return count > 0, result.Error
+}
+
+func (r *vehicleModelZoneRepository) HasMarks(ctx context.Context, id uint) (bool, error) {
+	var count int64
+	result := dbFrom(ctx, r.db).Model(&models.VehicleZoneMark{}).Where("zone_id = ?", id).Count(&count)
+	return count > 0, result.Error
+}
+
+func (r *vehicleModelZoneRepository) Delete(ctx context.Context, id uint) error {
+	return dbFrom(ctx, r.db).Delete(&models.VehicleModelZone{}, id).Error
+}
+
+// Mappers
+
+func toModel3DModel(m *domain.VehicleModel3D) *models.VehicleModel3D {
+	return &models.VehicleModel3D{
+		ID:           m.ID,
+		Name:         m.Name,
+		BodyType:     models.BodyType(m.BodyType),
+		FileURL:      m.FileURL,
+		ThumbnailURL: m.ThumbnailURL,
+		IsDefault:    m.IsDefault,
+		Active:       m.Active,
+		Version:      m.Version,
+		CreatedAt:    m.CreatedAt,
+	}
+}
+
+func toDomainModel3D(m *models.VehicleModel3D) *domain.VehicleModel3D {
+	return &domain.VehicleModel3D{
+		ID:           m.ID,
+		Name:         m.Name,
+		BodyType:     domain.BodyType(m.BodyType),
+		FileURL:      m.FileURL,
+		ThumbnailURL: m.ThumbnailURL,
+		IsDefault:    m.IsDefault,
+		Active:       m.Active,
+		Version:      m.Version,
+		CreatedAt:    m.CreatedAt,
+	}
+}
+
+func toModelZoneModel(z *domain.VehicleModelZone) *models.VehicleModelZone {
+	return &models.VehicleModelZone{
+		ID:        z.ID,
+		Model3DID: z.Model3DID,
+		Code:      z.Code,
+		Name:      z.Name,
+		MeshID:    z.MeshID,
+		CreatedAt: z.CreatedAt,
+	}
+}
+
+func toDomainModelZone(m *models.VehicleModelZone) *domain.VehicleModelZone {
+	return &domain.VehicleModelZone{
+		ID:        m.ID,
+		Model3DID: m.Model3DID,
+		Code:      m.Code,
+		Name:      m.Name,
+		MeshID:    m.MeshID,
+		CreatedAt: m.CreatedAt,
+	}
+}
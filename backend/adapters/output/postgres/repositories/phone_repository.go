@@ -1,6 +1,8 @@
 package repositories
 
 import (
+	"context"
+
 	"gorm.io/gorm"
 	"torque-dms/core/identity/ports/output"
 	"torque-dms/models"
@@ -14,9 +16,9 @@ func NewPhoneRepository(db *gorm.DB) output.PhoneRepository {
 	return &phoneRepository{db: db}
 }
 
-func (r *phoneRepository) Save(phone *output.Phone) error {
+func (r *phoneRepository) Save(ctx context.Context, phone *output.Phone) error {
 	model := toPhoneModel(phone)
-	result := r.db.Create(model)
+	result := dbFrom(ctx, r.db).Create(model)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -24,23 +26,23 @@ func (r *phoneRepository) Save(phone *output.Phone) error {
 	return nil
 }
 
-func (r *phoneRepository) Update(phone *output.Phone) error {
+func (r *phoneRepository) Update(ctx context.Context, phone *output.Phone) error {
 	model := toPhoneModel(phone)
-	return r.db.Save(model).Error
+	return dbFrom(ctx, r.db).Save(model).Error
 }
 
-func (r *phoneRepository) FindByID(id uint) (*output.Phone, error) {
+func (r *phoneRepository) FindByID(ctx context.Context, id uint) (*output.Phone, error) {
 	var model models.EntityPhone
-	result := r.db.First(&model, id)
+	result := dbFrom(ctx, r.db).First(&model, id)
 	if result.Error != nil {
-		return nil, result.Error
+		return nil, notFound(result.Error, "phone")
 	}
 	return toOutputPhone(&model), nil
 }
 
-func (r *phoneRepository) FindByEntityID(entityID uint) ([]*output.Phone, error) {
+func (r *phoneRepository) FindByEntityID(ctx context.Context, entityID uint) ([]*output.Phone, error) {
 	var modelList []models.EntityPhone
-	result := r.db.Where("entity_id = ?", entityID).Find(&modelList)
+	result := dbFrom(ctx, r.db).Where("entity_id = ?", entityID).Find(&modelList)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -52,17 +54,17 @@ func (r *phoneRepository) FindByEntityID(entityID uint) ([]*output.Phone, error)
 	return phones, nil
 }
 
-func (r *phoneRepository) FindPrimaryByEntityID(entityID uint) (*output.Phone, error) {
+func (r *phoneRepository) FindPrimaryByEntityID(ctx context.Context, entityID uint) (*output.Phone, error) {
 	var model models.EntityPhone
-	result := r.db.Where("entity_id = ? AND is_primary = ?", entityID, true).First(&model)
+	result := dbFrom(ctx, r.db).Where("entity_id = ? AND is_primary = ?", entityID, true).First(&model)
 	if result.Error != nil {
-		return nil, result.Error
+		return nil, notFound(result.Error, "phone")
 	}
 	return toOutputPhone(&model), nil
 }
 
-func (r *phoneRepository) Delete(id uint) error {
-	return r.db.Delete(&models.EntityPhone{}, id).Error
+func (r *phoneRepository) Delete(ctx context.Context, id uint) error {
+	return dbFrom(ctx, r.db).Delete(&models.EntityPhone{}, id).Error
 }
 
 // Mappers
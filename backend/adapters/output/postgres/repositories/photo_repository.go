@@ -1,6 +1,8 @@
 package repositories
 
 import (
+	"context"
+
 	"gorm.io/gorm"
 	"torque-dms/core/inventory/domain"
 	"torque-dms/core/inventory/ports/output"
@@ -15,9 +17,9 @@ func NewVehiclePhotoRepository(db *gorm.DB) output.VehiclePhotoRepository {
 	return &vehiclePhotoRepository{db: db}
 }
 
-func (r *vehiclePhotoRepository) Save(photo *domain.VehiclePhoto) error {
+func (r *vehiclePhotoRepository) Save(ctx context.Context, photo *domain.VehiclePhoto) error {
 	model := toPhotoModel(photo)
-	result := r.db.Create(model)
+	result := dbFrom(ctx, r.db).Create(model)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -25,23 +27,23 @@ func (r *vehiclePhotoRepository) Save(photo *domain.VehiclePhoto) error {
 	return nil
 }
 
-func (r *vehiclePhotoRepository) Update(photo *domain.VehiclePhoto) error {
+func (r *vehiclePhotoRepository) Update(ctx context.Context, photo *domain.VehiclePhoto) error {
 	model := toPhotoModel(photo)
-	return r.db.Save(model).Error
+	return dbFrom(ctx, r.db).Save(model).Error
 }
 
-func (r *vehiclePhotoRepository) FindByID(id uint) (*domain.VehiclePhoto, error) {
+func (r *vehiclePhotoRepository) FindByID(ctx context.Context, id uint) (*domain.VehiclePhoto, error) {
 	var model models.VehiclePhoto
-	result := r.db.First(&model, id)
+	result := dbFrom(ctx, r.db).First(&model, id)
 	if result.Error != nil {
-		return nil, result.Error
+		return nil, notFound(result.Error, "photo")
 	}
 	return toDomainPhoto(&model), nil
 }
 
-func (r *vehiclePhotoRepository) FindByVehicleID(vehicleID uint) ([]*domain.VehiclePhoto, error) {
+func (r *vehiclePhotoRepository) FindByVehicleID(ctx context.Context, vehicleID uint) ([]*domain.VehiclePhoto, error) {
 	var modelList []models.VehiclePhoto
-	result := r.db.Where("vehicle_id = ?", vehicleID).Order("sort_order ASC, created_at ASC").Find(&modelList)
+	result := dbFrom(ctx, r.db).Where("vehicle_id = ?", vehicleID).Order("sort_order ASC, created_at ASC").Find(&modelList)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -53,21 +55,17 @@ func (r *vehiclePhotoRepository) FindByVehicleID(vehicleID uint) ([]*domain.Vehi
 	return photos, nil
 }
 
-func (r *vehiclePhotoRepository) FindPrimaryByVehicleID(vehicleID uint) (*domain.VehiclePhoto, error) {
+func (r *vehiclePhotoRepository) FindPrimaryByVehicleID(ctx context.Context, vehicleID uint) (*domain.VehiclePhoto, error) {
 	var model models.VehiclePhoto
-	result := r.db.Where("vehicle_id = ? AND is_primary = ?", vehicleID, true).First(&model)
+	result := dbFrom(ctx, r.db).Where("vehicle_id = ? AND is_primary = ?", vehicleID, true).First(&model)
 	if result.Error != nil {
-		return nil, result.Error
+		return nil, notFound(result.Error, "photo")
 	}
 	return toDomainPhoto(&model), nil
 }
 
-func (r *vehiclePhotoRepository) Delete(id uint) error {
-	return r.db.Delete(&models.VehiclePhoto{}, id).Error
-}
-
-func (r *vehiclePhotoRepository) DeleteByVehicleID(vehicleID uint) error {
-	return r.db.Where("vehicle_id = ?", vehicleID).Delete(&models.VehiclePhoto{}).Error
+func (r *vehiclePhotoRepository) Delete(ctx context.Context, id uint) error {
+	return dbFrom(ctx, r.db).Delete(&models.VehiclePhoto{}, id).Error
 }
 
 // Mappers
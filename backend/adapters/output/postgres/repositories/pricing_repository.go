@@ -0,0 +1,206 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"torque-dms/core/inventory/domain"
+	"torque-dms/core/inventory/ports/output"
+	sharedDomain "torque-dms/core/shared/domain"
+	"torque-dms/models"
+)
+
+var priceChangeColumns = queryColumns{
+	"id":         "id",
+	"source":     "source",
+	"rule_id":    "rule_id",
+	"created_at": "created_at",
+}
+
+type repricingRuleRepository struct {
+	db *gorm.DB
+}
+
+func NewRepricingRuleRepository(db *gorm.DB) output.RepricingRuleRepository {
+	return &repricingRuleRepository{db: db}
+}
+
+func (r *repricingRuleRepository) Save(ctx context.Context, rule *domain.RepricingRule) error {
+	m := toRepricingRuleModel(rule)
+	result := dbFrom(ctx, r.db).Create(m)
+	if result.Error != nil {
+		return result.Error
+	}
+	rule.ID = m.ID
+	rule.Version = m.Version
+	return nil
+}
+
+func (r *repricingRuleRepository) Update(ctx context.Context, rule *domain.RepricingRule) error {
+	m := toRepricingRuleModel(rule)
+	m.Version = rule.Version + 1
+	if err := updateVersioned(ctx, r.db, m, rule.Version, "repricing_rule"); err != nil {
+		return err
+	}
+	rule.Version = m.Version
+	return nil
+}
+
+func (r *repricingRuleRepository) Delete(ctx context.Context, id uint) error {
+	return dbFrom(ctx, r.db).Delete(&models.RepricingRule{}, id).Error
+}
+
+func (r *repricingRuleRepository) FindByID(ctx context.Context, id uint) (*domain.RepricingRule, error) {
+	var m models.RepricingRule
+	result := dbFrom(ctx, r.db).First(&m, id)
+	if result.Error != nil {
+		return nil, notFound(result.Error, "repricing_rule")
+	}
+	return toDomainRepricingRule(&m), nil
+}
+
+func (r *repricingRuleRepository) FindAll(ctx context.Context) ([]*domain.RepricingRule, error) {
+	return r.find(dbFrom(ctx, r.db))
+}
+
+func (r *repricingRuleRepository) FindActive(ctx context.Context) ([]*domain.RepricingRule, error) {
+	return r.find(dbFrom(ctx, r.db).Where("active = ?", true))
+}
+
+func (r *repricingRuleRepository) find(db *gorm.DB) ([]*domain.RepricingRule, error) {
+	var modelList []models.RepricingRule
+	if err := db.Order("min_days_in_stock ASC, id ASC").Find(&modelList).Error; err != nil {
+		return nil, err
+	}
+
+	rules := make([]*domain.RepricingRule, len(modelList))
+	for i := range modelList {
+		rules[i] = toDomainRepricingRule(&modelList[i])
+	}
+	return rules, nil
+}
+
+type priceChangeRepository struct {
+	db *gorm.DB
+}
+
+func NewPriceChangeRepository(db *gorm.DB) output.PriceChangeRepository {
+	return &priceChangeRepository{db: db}
+}
+
+func (r *priceChangeRepository) Save(ctx context.Context, change *domain.PriceChange) error {
+	m := toPriceChangeModel(change)
+	result := dbFrom(ctx, r.db).Omit(clause.Associations).Create(m)
+	if result.Error != nil {
+		return result.Error
+	}
+	change.ID = m.ID
+	return nil
+}
+
+func (r *priceChangeRepository) FindByVehicleID(ctx context.Context, vehicleID uint, q sharedDomain.Query) (*sharedDomain.Page[*domain.PriceChange], error) {
+	db := dbFrom(ctx, r.db).Model(&models.VehiclePriceChange{}).Where("vehicle_id = ?", vehicleID)
+	return findPage(db, q, priceChangeColumns, newestFirst, toDomainPriceChange)
+}
+
+func (r *priceChangeRepository) LastRuleChanges(ctx context.Context, vehicleIDs []uint) (map[uint]time.Time, error) {
+	last := make(map[uint]time.Time)
+	if len(vehicleIDs) == 0 {
+		return last, nil
+	}
+
+	var rows []struct {
+		VehicleID uint
+		LastAt    time.Time
+	}
+	result := dbFrom(ctx, r.db).Model(&models.VehiclePriceChange{}).
+		Select("vehicle_id, MAX(created_at) AS last_at").
+		Where("source = ? AND vehicle_id IN ?", string(domain.PriceChangeRule), vehicleIDs).
+		Group("vehicle_id").
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	for _, row := range rows {
+		last[row.VehicleID] = row.LastAt
+	}
+	return last, nil
+}
+
+// Mappers
+
+func toRepricingRuleModel(r *domain.RepricingRule) *models.RepricingRule {
+	return &models.RepricingRule{
+		ID:             r.ID,
+		Name:           r.Name,
+		Condition:      optionalEnum(string(r.Condition)),
+		LotType:        optionalEnum(string(r.LotType)),
+		MinDaysInStock: r.MinDaysInStock,
+		DropPercent:    r.DropPercent,
+		IntervalDays:   r.IntervalDays,
+		FloorOverCost:  r.FloorOverCost,
+		Active:         r.Active,
+		Version:        r.Version,
+		CreatedAt:      r.CreatedAt,
+		ModifiedAt:     r.ModifiedAt,
+	}
+}
+
+func toDomainRepricingRule(m *models.RepricingRule) *domain.RepricingRule {
+	rule := &domain.RepricingRule{
+		ID:             m.ID,
+		Name:           m.Name,
+		MinDaysInStock: m.MinDaysInStock,
+		DropPercent:    m.DropPercent,
+		IntervalDays:   m.IntervalDays,
+		FloorOverCost:  m.FloorOverCost,
+		Active:         m.Active,
+		Version:        m.Version,
+		CreatedAt:      m.CreatedAt,
+		ModifiedAt:     m.ModifiedAt,
+	}
+	if m.Condition != nil {
+		rule.Condition = domain.VehicleCondition(*m.Condition)
+	}
+	if m.LotType != nil {
+		rule.LotType = domain.LotType(*m.LotType)
+	}
+	return rule
+}
+
+// optionalEnum - "todos" se guarda como NULL para que el CHECK de la columna lo acepte
+func optionalEnum(v string) *string {
+	if v == "" {
+		return nil
+	}
+	return &v
+}
+
+func toPriceChangeModel(c *domain.PriceChange) *models.VehiclePriceChange {
+	return &models.VehiclePriceChange{
+		ID:        c.ID,
+		VehicleID: c.VehicleID,
+		OldPrice:  c.OldPrice,
+		NewPrice:  c.NewPrice,
+		Source:    string(c.Source),
+		RuleID:    c.RuleID,
+		ChangedBy: c.ChangedBy,
+		CreatedAt: c.CreatedAt,
+	}
+}
+
+func toDomainPriceChange(m *models.VehiclePriceChange) *domain.PriceChange {
+	return &domain.PriceChange{
+		ID:        m.ID,
+		VehicleID: m.VehicleID,
+		OldPrice:  m.OldPrice,
+		NewPrice:  m.NewPrice,
+		Source:    domain.PriceChangeSource(m.Source),
+		RuleID:    m.RuleID,
+		ChangedBy: m.ChangedBy,
+		CreatedAt: m.CreatedAt,
+	}
+}
@@ -0,0 +1,380 @@
+package repositories
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+// queryColumns - campos que el cliente puede usar para filtrar y ordenar, con su columna.
+// Cualquier otro campo se rechaza, así nunca llega una columna arbitraria al SQL
+type queryColumns map[string]string
+
+type orderColumn struct {
+	column   string
+	desc     bool
+	field    *schema.Field
+	nullable bool
+}
+
+// Órdenes por defecto compartidos
+var (
+	newestFirst = []sharedDomain.Sort{{Field: "created_at", Desc: true}}
+	deletedSort = []sharedDomain.Sort{{Field: "deleted_at", Desc: true}}
+)
+
+var filterOperators = map[sharedDomain.FilterOp]string{
+	sharedDomain.FilterEq:  "=",
+	sharedDomain.FilterNe:  "<>",
+	sharedDomain.FilterGt:  ">",
+	sharedDomain.FilterGte: ">=",
+	sharedDomain.FilterLt:  "<",
+	sharedDomain.FilterLte: "<=",
+}
+
+// findPage - ejecuta la query sobre db (ya con Model y las condiciones propias del finder)
+// y devuelve la página con el total real. El id siempre desempata el orden
+func findPage[M any, D any](db *gorm.DB, q sharedDomain.Query, columns queryColumns, defaultSort []sharedDomain.Sort, toDomain func(*M) D) (*sharedDomain.Page[D], error) {
+	sch, err := modelSchema(db, new(M))
+	if err != nil {
+		return nil, err
+	}
+
+	db, err = applyFilters(db, q.Filters, columns, sch)
+	if err != nil {
+		return nil, err
+	}
+	db = db.Session(&gorm.Session{})
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	order, err := orderColumns(q.Sort, defaultSort, columns, sch)
+	if err != nil {
+		return nil, err
+	}
+
+	find := db
+	if q.Cursor != "" {
+		if find, err = applyCursor(find, q.Cursor, order); err != nil {
+			return nil, err
+		}
+		q.Offset = 0
+	} else if q.Offset > 0 {
+		find = find.Offset(q.Offset)
+	}
+	for _, o := range order {
+		find = find.Order(clause.OrderByColumn{Column: clause.Column{Name: o.column}, Desc: o.desc})
+	}
+	if q.Limit > 0 {
+		find = find.Limit(q.Limit)
+	}
+
+	var modelList []M
+	if err := find.Find(&modelList).Error; err != nil {
+		return nil, err
+	}
+
+	page := &sharedDomain.Page[D]{
+		Items:  make([]D, len(modelList)),
+		Total:  total,
+		Limit:  q.Limit,
+		Offset: q.Offset,
+	}
+	for i := range modelList {
+		page.Items[i] = toDomain(&modelList[i])
+	}
+
+	// Con direcciones mezcladas no hay comparación de fila posible: se pagina con offset
+	if q.Limit > 0 && len(modelList) == q.Limit && singleDirection(order) {
+		page.NextCursor, err = cursorFor(&modelList[len(modelList)-1], order)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return page, nil
+}
+
+// modelSchema - columnas del model con su tipo, para validar filtros y cursores
+func modelSchema(db *gorm.DB, model interface{}) (*schema.Schema, error) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(model); err != nil {
+		return nil, err
+	}
+	return stmt.Schema, nil
+}
+
+func lookUpColumn(sch *schema.Schema, column string) (*schema.Field, error) {
+	field := sch.LookUpField(column)
+	if field == nil {
+		return nil, fmt.Errorf("unknown column %s in %s", column, sch.Table)
+	}
+	return field, nil
+}
+
+func applyFilters(db *gorm.DB, filters []sharedDomain.Filter, columns queryColumns, sch *schema.Schema) (*gorm.DB, error) {
+	for _, f := range filters {
+		name, ok := columns[f.Field]
+		if !ok {
+			return nil, sharedDomain.Invalid("filter", fmt.Sprintf("cannot filter by %s", f.Field))
+		}
+		field, err := lookUpColumn(sch, name)
+		if err != nil {
+			return nil, err
+		}
+		column := db.Statement.Quote(name)
+		if len(f.Values) == 0 {
+			return nil, sharedDomain.Invalid("filter", fmt.Sprintf("filter on %s has no value", f.Field))
+		}
+
+		switch f.Op {
+		case sharedDomain.FilterIn:
+			values := make([]interface{}, len(f.Values))
+			for i, raw := range f.Values {
+				if values[i], err = columnValue(field, raw); err != nil {
+					return nil, sharedDomain.Invalid("filter", fmt.Sprintf("invalid value %q for %s", raw, f.Field))
+				}
+			}
+			db = db.Where(column+" IN ?", values)
+		case sharedDomain.FilterLike:
+			if field.DataType != schema.String {
+				return nil, sharedDomain.Invalid("filter", fmt.Sprintf("cannot use like on %s", f.Field))
+			}
+			db = db.Where(column+" ILIKE ?", "%"+escapeLike(f.Values[0])+"%")
+		default:
+			operator, ok := filterOperators[f.Op]
+			if !ok {
+				return nil, sharedDomain.Invalid("filter", fmt.Sprintf("invalid filter operator %q", f.Op))
+			}
+			value, err := columnValue(field, f.Values[0])
+			if err != nil {
+				return nil, sharedDomain.Invalid("filter", fmt.Sprintf("invalid value %q for %s", f.Values[0], f.Field))
+			}
+			db = db.Where(column+" "+operator+" ?", value)
+		}
+	}
+	return db, nil
+}
+
+// columnValue - convierte el texto del cliente al tipo de la columna antes de que llegue a
+// Postgres, así un valor mal formado es un 422 y no un error de la base
+func columnValue(field *schema.Field, raw string) (interface{}, error) {
+	switch field.DataType {
+	case schema.Bool:
+		return strconv.ParseBool(raw)
+	case schema.Int:
+		return strconv.ParseInt(raw, 10, 64)
+	case schema.Uint:
+		return strconv.ParseUint(raw, 10, 64)
+	case schema.Float:
+		return strconv.ParseFloat(raw, 64)
+	case schema.Time:
+		if t, err := time.Parse(time.RFC3339Nano, raw); err == nil {
+			return t, nil
+		}
+		return time.Parse("2006-01-02", raw)
+	default:
+		return raw, nil
+	}
+}
+
+func orderColumns(sorts []sharedDomain.Sort, defaultSort []sharedDomain.Sort, columns queryColumns, sch *schema.Schema) ([]orderColumn, error) {
+	if len(sorts) == 0 {
+		sorts = defaultSort
+	}
+
+	order := make([]orderColumn, 0, len(sorts)+1)
+	hasID := false
+	for _, s := range sorts {
+		column, ok := columns[s.Field]
+		if !ok {
+			return nil, sharedDomain.Invalid("sort", fmt.Sprintf("cannot sort by %s", s.Field))
+		}
+		field, err := lookUpColumn(sch, column)
+		if err != nil {
+			return nil, err
+		}
+		order = append(order, orderColumn{column: column, desc: s.Desc, field: field, nullable: isNullable(field)})
+		hasID = hasID || column == "id"
+	}
+
+	if !hasID {
+		field, err := lookUpColumn(sch, "id")
+		if err != nil {
+			return nil, err
+		}
+		desc := len(order) > 0 && order[len(order)-1].desc
+		order = append(order, orderColumn{column: "id", desc: desc, field: field})
+	}
+	return order, nil
+}
+
+var valuerType = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+
+// isNullable - punteros y tipos como gorm.DeletedAt o sql.NullTime pueden guardar NULL
+func isNullable(field *schema.Field) bool {
+	return field.FieldType.Kind() == reflect.Ptr || field.FieldType.Implements(valuerType)
+}
+
+func singleDirection(order []orderColumn) bool {
+	for _, o := range order {
+		if o.desc != order[0].desc {
+			return false
+		}
+	}
+	return true
+}
+
+// escapeLike - el texto del usuario se busca literal, sin que % o _ actúen como comodines
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// applyCursor - keyset: (c1, c2, id) < (v1, v2, id) en una sola comparación de fila, que
+// Postgres resuelve con el índice sin recorrer las filas anteriores como haría un OFFSET.
+// Postgres ordena NULL como el valor más alto (NULLS LAST en ASC, FIRST en DESC); si el
+// cursor lleva NULL o un orden ascendente puede encontrarse NULL, la comparación de fila
+// daría NULL y se corta la paginación, así que se expande columna a columna
+func applyCursor(db *gorm.DB, cursor string, order []orderColumn) (*gorm.DB, error) {
+	values, err := sharedDomain.DecodeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) != len(order) {
+		return nil, sharedDomain.Invalid("cursor", "cursor does not match the requested sort")
+	}
+	if !singleDirection(order) {
+		return nil, sharedDomain.Invalid("cursor", "cursor pagination requires a single sort direction")
+	}
+
+	desc := order[0].desc
+	names := make([]string, len(order))
+	args := make([]interface{}, len(values))
+	rowCompare := true
+	for i, o := range order {
+		names[i] = db.Statement.Quote(o.column)
+		if values[i] == nil {
+			if !o.nullable {
+				return nil, sharedDomain.Invalid("cursor", "invalid cursor")
+			}
+			rowCompare = false
+			continue
+		}
+		if args[i], err = columnValue(o.field, *values[i]); err != nil {
+			return nil, sharedDomain.Invalid("cursor", "invalid cursor")
+		}
+		rowCompare = rowCompare && (desc || !o.nullable)
+	}
+
+	if rowCompare {
+		operator := ">"
+		if desc {
+			operator = "<"
+		}
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(args)), ", ")
+		return db.Where(fmt.Sprintf("(%s) %s (%s)", strings.Join(names, ", "), operator, placeholders), args...), nil
+	}
+
+	// (c1 después de v1) OR (c1 = v1 AND c2 después de v2) OR ...
+	var branches []string
+	var branchArgs []interface{}
+	var equal []string
+	var equalArgs []interface{}
+	for i, o := range order {
+		if after, afterArgs := cursorAfter(names[i], args[i], values[i] == nil, desc, o.nullable); after != "" {
+			branch := after
+			if len(equal) > 0 {
+				branch = "(" + strings.Join(equal, " AND ") + " AND " + after + ")"
+			}
+			branches = append(branches, branch)
+			branchArgs = append(append(branchArgs, equalArgs...), afterArgs...)
+		}
+		if values[i] == nil {
+			equal = append(equal, names[i]+" IS NULL")
+		} else {
+			equal = append(equal, names[i]+" = ?")
+			equalArgs = append(equalArgs, args[i])
+		}
+	}
+	if len(branches) == 0 {
+		return db.Where("FALSE"), nil
+	}
+	return db.Where(strings.Join(branches, " OR "), branchArgs...), nil
+}
+
+// cursorAfter - condición de "viene después del valor del cursor" para una columna; vacía si
+// ninguna fila puede ir después (NULL en orden ascendente ya es lo último)
+func cursorAfter(name string, arg interface{}, isNull bool, desc bool, nullable bool) (string, []interface{}) {
+	switch {
+	case isNull && desc:
+		return name + " IS NOT NULL", nil
+	case isNull:
+		return "", nil
+	case desc:
+		return name + " < ?", []interface{}{arg}
+	case nullable:
+		return "(" + name + " > ? OR " + name + " IS NULL)", []interface{}{arg}
+	default:
+		return name + " > ?", []interface{}{arg}
+	}
+}
+
+// cursorValue - valor de una columna de orden tal como lo lee Postgres; false si es NULL.
+// gorm.DeletedAt y sql.NullTime se desenvuelven con su propio Value
+func cursorValue(value interface{}) (string, bool, error) {
+	v := reflect.ValueOf(value)
+	if !v.IsValid() {
+		return "", false, nil
+	}
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return "", false, nil
+		}
+		return cursorValue(v.Elem().Interface())
+	}
+
+	if valuer, ok := value.(driver.Valuer); ok {
+		plain, err := valuer.Value()
+		if err != nil {
+			return "", false, err
+		}
+		return cursorValue(plain)
+	}
+
+	switch t := value.(type) {
+	case time.Time:
+		return t.Format(time.RFC3339Nano), true, nil
+	default:
+		return fmt.Sprint(t), true, nil
+	}
+}
+
+// cursorFor - valores de orden de la fila; un NULL viaja como null en el cursor
+func cursorFor[M any](model *M, order []orderColumn) (string, error) {
+	rv := reflect.ValueOf(model).Elem()
+	values := make([]*string, len(order))
+	for i, o := range order {
+		value, _ := o.field.ValueOf(context.Background(), rv)
+		v, ok, err := cursorValue(value)
+		if err != nil {
+			return "", err
+		}
+		if ok {
+			values[i] = &v
+		}
+	}
+	return sharedDomain.EncodeCursor(values)
+}
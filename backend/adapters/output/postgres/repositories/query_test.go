@@ -0,0 +1,250 @@
+package repositories
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	sharedDomain "torque-dms/core/shared/domain"
+	"torque-dms/models"
+)
+
+// dryRunDB - genera el SQL sin conectarse a Postgres
+func dryRunDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost dbname=test"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	if err != nil {
+		t.Fatalf("gorm.Open() error = %v", err)
+	}
+	return db
+}
+
+func vehicleOrder(t *testing.T, db *gorm.DB, sorts []sharedDomain.Sort) []orderColumn {
+	t.Helper()
+	sch, err := modelSchema(db, &models.Vehicle{})
+	if err != nil {
+		t.Fatalf("modelSchema() error = %v", err)
+	}
+	order, err := orderColumns(nil, sorts, vehicleColumns, sch)
+	if err != nil {
+		t.Fatalf("orderColumns() error = %v", err)
+	}
+	return order
+}
+
+// cursorSQL - WHERE de la página siguiente a la fila dada
+func cursorSQL(t *testing.T, db *gorm.DB, vehicle *models.Vehicle, order []orderColumn) (string, []interface{}) {
+	t.Helper()
+	cursor, err := cursorFor(vehicle, order)
+	if err != nil || cursor == "" {
+		t.Fatalf("cursorFor() = %q, %v", cursor, err)
+	}
+	next, err := applyCursor(db.Model(&models.Vehicle{}), cursor, order)
+	if err != nil {
+		t.Fatalf("applyCursor() error = %v", err)
+	}
+	stmt := next.Find(&[]models.Vehicle{}).Statement
+	return stmt.SQL.String(), stmt.Vars
+}
+
+func TestCursorForDeletedAt(t *testing.T) {
+	db := dryRunDB(t)
+	deletedAt := time.Date(2026, 5, 4, 10, 30, 0, 123000000, time.UTC)
+	order := vehicleOrder(t, db, deletedSort)
+
+	vehicle := &models.Vehicle{ID: 42, DeletedAt: gorm.DeletedAt{Time: deletedAt, Valid: true}}
+	cursor, err := cursorFor(vehicle, order)
+	if err != nil || cursor == "" {
+		t.Fatalf("cursorFor() = %q, %v", cursor, err)
+	}
+
+	values, err := sharedDomain.DecodeCursor(cursor)
+	if err != nil {
+		t.Fatalf("DecodeCursor() error = %v", err)
+	}
+	if values[0] == nil {
+		t.Fatal("cursor deleted_at = null")
+	}
+	if got, err := time.Parse(time.RFC3339Nano, *values[0]); err != nil || !got.Equal(deletedAt) {
+		t.Errorf("cursor deleted_at = %q, want %s", *values[0], deletedAt.Format(time.RFC3339Nano))
+	}
+	if values[1] == nil || *values[1] != "42" {
+		t.Errorf("cursor id = %v, want 42", values[1])
+	}
+
+	// La página siguiente compara la fila entera contra el cursor
+	next, err := applyCursor(db.Model(&models.Vehicle{}), cursor, order)
+	if err != nil {
+		t.Fatalf("applyCursor() error = %v", err)
+	}
+	stmt := next.Find(&[]models.Vehicle{}).Statement
+	if sql := stmt.SQL.String(); !strings.Contains(sql, `("deleted_at", "id") < ($1, $2)`) {
+		t.Errorf("SQL = %s", sql)
+	}
+	if len(stmt.Vars) != 2 {
+		t.Fatalf("Vars = %v", stmt.Vars)
+	}
+	if got, ok := stmt.Vars[0].(time.Time); !ok || !got.Equal(deletedAt) {
+		t.Errorf("Vars[0] = %v, want %s", stmt.Vars[0], deletedAt)
+	}
+	if stmt.Vars[1] != uint64(42) {
+		t.Errorf("Vars[1] = %#v, want 42", stmt.Vars[1])
+	}
+}
+
+func TestCursorNullSortValue(t *testing.T) {
+	db := dryRunDB(t)
+	acquired := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		sort    sharedDomain.Sort
+		vehicle *models.Vehicle
+		want    string
+	}{
+		{
+			// Los NULL van al final en ASC: después de un valor vienen los mayores y los NULL
+			name:    "asc after value",
+			sort:    sharedDomain.Sort{Field: "deleted_at"},
+			vehicle: &models.Vehicle{ID: 7, DeletedAt: gorm.DeletedAt{Time: acquired, Valid: true}},
+			want:    `(("deleted_at" > $1 OR "deleted_at" IS NULL) OR ("deleted_at" = $2 AND "id" > $3))`,
+		},
+		{
+			name:    "asc after null",
+			sort:    sharedDomain.Sort{Field: "deleted_at"},
+			vehicle: &models.Vehicle{ID: 7},
+			want:    `(("deleted_at" IS NULL AND "id" > $1))`,
+		},
+		{
+			// Los NULL van primero en DESC: después de un NULL vienen todos los valores
+			name:    "desc after null",
+			sort:    sharedDomain.Sort{Field: "deleted_at", Desc: true},
+			vehicle: &models.Vehicle{ID: 7},
+			want:    `("deleted_at" IS NOT NULL OR ("deleted_at" IS NULL AND "id" < $1))`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := vehicleOrder(t, db, []sharedDomain.Sort{tt.sort})
+			sql, _ := cursorSQL(t, db, tt.vehicle, order)
+			if !strings.Contains(sql, tt.want) {
+				t.Errorf("SQL = %s\nwant %s", sql, tt.want)
+			}
+		})
+	}
+}
+
+func TestCursorMixedDirection(t *testing.T) {
+	db := dryRunDB(t)
+	order := vehicleOrder(t, db, []sharedDomain.Sort{{Field: "year", Desc: true}, {Field: "make"}})
+	if singleDirection(order) {
+		t.Fatal("singleDirection() = true for year desc, make asc")
+	}
+
+	cursor, err := cursorFor(&models.Vehicle{ID: 1, Year: 2020, Make: "Ford"}, order)
+	if err != nil {
+		t.Fatalf("cursorFor() error = %v", err)
+	}
+	if _, err := applyCursor(db.Model(&models.Vehicle{}), cursor, order); err == nil {
+		t.Error("applyCursor() expected error for mixed directions")
+	}
+}
+
+func TestCursorTampered(t *testing.T) {
+	db := dryRunDB(t)
+	order := vehicleOrder(t, db, newestFirst)
+
+	bad, id := "yesterday", "1"
+	cursor, _ := sharedDomain.EncodeCursor([]*string{&bad, &id})
+	if _, err := applyCursor(db.Model(&models.Vehicle{}), cursor, order); !isInvalid(err) {
+		t.Errorf("applyCursor() error = %v, want invalid", err)
+	}
+
+	// created_at no admite NULL
+	cursor, _ = sharedDomain.EncodeCursor([]*string{nil, &id})
+	if _, err := applyCursor(db.Model(&models.Vehicle{}), cursor, order); !isInvalid(err) {
+		t.Errorf("applyCursor() error = %v, want invalid", err)
+	}
+}
+
+func TestApplyFiltersTypedValues(t *testing.T) {
+	db := dryRunDB(t)
+	sch, err := modelSchema(db, &models.Vehicle{})
+	if err != nil {
+		t.Fatalf("modelSchema() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		filter  sharedDomain.Filter
+		want    interface{}
+		wantErr bool
+	}{
+		{"int", sharedDomain.Filter{Field: "year", Op: sharedDomain.FilterGte, Values: []string{"2020"}}, int64(2020), false},
+		{"float", sharedDomain.Filter{Field: "asking_price", Op: sharedDomain.FilterLt, Values: []string{"15000.5"}}, 15000.5, false},
+		{"date", sharedDomain.Filter{Field: "created_at", Op: sharedDomain.FilterGt, Values: []string{"2026-01-02"}}, time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC), false},
+		{"text", sharedDomain.Filter{Field: "make", Op: sharedDomain.FilterEq, Values: []string{"Ford"}}, "Ford", false},
+		{"bad int", sharedDomain.Filter{Field: "year", Op: sharedDomain.FilterEq, Values: []string{"abc"}}, nil, true},
+		{"bad in", sharedDomain.Filter{Field: "location_id", Op: sharedDomain.FilterIn, Values: []string{"1", "-2"}}, nil, true},
+		{"bad time", sharedDomain.Filter{Field: "created_at", Op: sharedDomain.FilterLt, Values: []string{"soon"}}, nil, true},
+		{"like on number", sharedDomain.Filter{Field: "mileage", Op: sharedDomain.FilterLike, Values: []string{"1"}}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filtered, err := applyFilters(db.Model(&models.Vehicle{}), []sharedDomain.Filter{tt.filter}, vehicleColumns, sch)
+			if tt.wantErr {
+				if !isInvalid(err) {
+					t.Errorf("applyFilters() error = %v, want invalid", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("applyFilters() error = %v", err)
+			}
+			vars := filtered.Find(&[]models.Vehicle{}).Statement.Vars
+			if len(vars) != 1 || vars[0] != tt.want {
+				t.Errorf("Vars = %#v, want %#v", vars, tt.want)
+			}
+		})
+	}
+}
+
+func isInvalid(err error) bool {
+	domainErr, ok := sharedDomain.AsError(err)
+	return ok && domainErr.Kind == sharedDomain.KindValidation
+}
+
+func TestCursorValue(t *testing.T) {
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	id := uint(7)
+
+	tests := []struct {
+		name   string
+		value  interface{}
+		want   string
+		wantOK bool
+	}{
+		{"time", at, "2026-01-02T03:04:05Z", true},
+		{"deleted at", gorm.DeletedAt{Time: at, Valid: true}, "2026-01-02T03:04:05Z", true},
+		{"not deleted", gorm.DeletedAt{}, "", false},
+		{"pointer", &id, "7", true},
+		{"nil pointer", (*time.Time)(nil), "", false},
+		{"string", "ABC-1", "ABC-1", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok, err := cursorValue(tt.value)
+			if err != nil || ok != tt.wantOK || got != tt.want {
+				t.Errorf("cursorValue() = %q, %v, %v, want %q, %v", got, ok, err, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
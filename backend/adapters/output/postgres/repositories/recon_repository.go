@@ -0,0 +1,230 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"torque-dms/core/inventory/domain"
+	"torque-dms/core/inventory/ports/output"
+	sharedDomain "torque-dms/core/shared/domain"
+	"torque-dms/models"
+)
+
+var reconOrderColumns = queryColumns{
+	"id":         "id",
+	"vehicle_id": "vehicle_id",
+	"status":     "status",
+	"opened_at":  "opened_at",
+	"closed_at":  "closed_at",
+}
+
+var reconOpenedFirst = []sharedDomain.Sort{{Field: "opened_at", Desc: true}}
+
+type reconOrderRepository struct {
+	db *gorm.DB
+}
+
+func NewReconOrderRepository(db *gorm.DB) output.ReconOrderRepository {
+	return &reconOrderRepository{db: db}
+}
+
+func (r *reconOrderRepository) Save(ctx context.Context, order *domain.ReconOrder) error {
+	m := toReconOrderModel(order)
+	result := dbFrom(ctx, r.db).Omit(clause.Associations).Create(m)
+	if result.Error != nil {
+		return result.Error
+	}
+	order.ID = m.ID
+	order.Version = m.Version
+	return nil
+}
+
+func (r *reconOrderRepository) Update(ctx context.Context, order *domain.ReconOrder) error {
+	m := toReconOrderModel(order)
+	m.Version = order.Version + 1
+	if err := updateVersioned(ctx, r.db, m, order.Version, "recon_order"); err != nil {
+		return err
+	}
+	order.Version = m.Version
+	return nil
+}
+
+func (r *reconOrderRepository) SaveTask(ctx context.Context, task *domain.ReconTask) error {
+	m := toReconTaskModel(task)
+	result := dbFrom(ctx, r.db).Create(m)
+	if result.Error != nil {
+		return result.Error
+	}
+	task.ID = m.ID
+	task.Version = m.Version
+	return nil
+}
+
+func (r *reconOrderRepository) UpdateTask(ctx context.Context, task *domain.ReconTask) error {
+	m := toReconTaskModel(task)
+	m.Version = task.Version + 1
+	if err := updateVersioned(ctx, r.db, m, task.Version, "recon_task"); err != nil {
+		return err
+	}
+	task.Version = m.Version
+	return nil
+}
+
+func (r *reconOrderRepository) FindByID(ctx context.Context, id uint) (*domain.ReconOrder, error) {
+	var m models.ReconOrder
+	result := withReconTasks(dbFrom(ctx, r.db)).First(&m, id)
+	if result.Error != nil {
+		return nil, notFound(result.Error, "recon_order")
+	}
+	return toDomainReconOrder(&m), nil
+}
+
+func (r *reconOrderRepository) FindOpenByVehicleID(ctx context.Context, vehicleID uint) (*domain.ReconOrder, error) {
+	var m models.ReconOrder
+	result := withReconTasks(dbFrom(ctx, r.db)).
+		Where("vehicle_id = ? AND status = ?", vehicleID, string(domain.ReconOrderOpen)).
+		First(&m)
+	if result.Error != nil {
+		return nil, notFound(result.Error, "recon_order")
+	}
+	return toDomainReconOrder(&m), nil
+}
+
+func (r *reconOrderRepository) FindByVehicleID(ctx context.Context, vehicleID uint) ([]*domain.ReconOrder, error) {
+	var modelList []models.ReconOrder
+	result := withReconTasks(dbFrom(ctx, r.db)).
+		Where("vehicle_id = ?", vehicleID).
+		Order("opened_at DESC, id DESC").
+		Find(&modelList)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return toDomainReconOrders(modelList), nil
+}
+
+func (r *reconOrderRepository) FindAll(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.ReconOrder], error) {
+	db := withReconTasks(dbFrom(ctx, r.db)).Model(&models.ReconOrder{})
+	return findPage(db, q, reconOrderColumns, reconOpenedFirst, toDomainReconOrder)
+}
+
+func (r *reconOrderRepository) FindClosedBetween(ctx context.Context, from time.Time, to time.Time) ([]*domain.ReconOrder, error) {
+	var modelList []models.ReconOrder
+	result := withReconTasks(dbFrom(ctx, r.db)).
+		Where("status = ? AND closed_at >= ? AND closed_at < ?", string(domain.ReconOrderClosed), from, to).
+		Find(&modelList)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return toDomainReconOrders(modelList), nil
+}
+
+func (r *reconOrderRepository) FindOpen(ctx context.Context) ([]*domain.ReconOrder, error) {
+	var modelList []models.ReconOrder
+	result := dbFrom(ctx, r.db).Where("status = ?", string(domain.ReconOrderOpen)).Find(&modelList)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return toDomainReconOrders(modelList), nil
+}
+
+func (r *reconOrderRepository) TotalCostByVehicle(ctx context.Context, vehicleID uint) (float64, error) {
+	var total float64
+	result := dbFrom(ctx, r.db).Model(&models.ReconTask{}).
+		Joins("JOIN recon_orders ON recon_orders.id = recon_tasks.order_id").
+		Where("recon_orders.vehicle_id = ? AND recon_tasks.status = ?", vehicleID, string(domain.ReconTaskCompleted)).
+		Select("COALESCE(SUM(recon_tasks.actual_cost), 0)").
+		Scan(&total)
+	return total, result.Error
+}
+
+func withReconTasks(db *gorm.DB) *gorm.DB {
+	return db.Preload("Tasks", func(db *gorm.DB) *gorm.DB { return db.Order("created_at ASC, id ASC") })
+}
+
+// Mappers
+
+func toReconOrderModel(o *domain.ReconOrder) *models.ReconOrder {
+	return &models.ReconOrder{
+		ID:        o.ID,
+		VehicleID: o.VehicleID,
+		Status:    string(o.Status),
+		OpenedBy:  o.OpenedBy,
+		Notes:     o.Notes,
+		OpenedAt:  o.OpenedAt,
+		ClosedAt:  o.ClosedAt,
+		Version:   o.Version,
+	}
+}
+
+func toDomainReconOrder(m *models.ReconOrder) *domain.ReconOrder {
+	tasks := make([]*domain.ReconTask, len(m.Tasks))
+	for i := range m.Tasks {
+		tasks[i] = toDomainReconTask(&m.Tasks[i])
+	}
+
+	return &domain.ReconOrder{
+		ID:        m.ID,
+		VehicleID: m.VehicleID,
+		Status:    domain.ReconOrderStatus(m.Status),
+		OpenedBy:  m.OpenedBy,
+		Notes:     m.Notes,
+		Tasks:     tasks,
+		OpenedAt:  m.OpenedAt,
+		ClosedAt:  m.ClosedAt,
+		Version:   m.Version,
+	}
+}
+
+func toDomainReconOrders(modelList []models.ReconOrder) []*domain.ReconOrder {
+	orders := make([]*domain.ReconOrder, len(modelList))
+	for i := range modelList {
+		orders[i] = toDomainReconOrder(&modelList[i])
+	}
+	return orders
+}
+
+func toReconTaskModel(t *domain.ReconTask) *models.ReconTask {
+	return &models.ReconTask{
+		ID:               t.ID,
+		OrderID:          t.OrderID,
+		Type:             string(t.Type),
+		Description:      t.Description,
+		Status:           string(t.Status),
+		AssigneeID:       t.AssigneeID,
+		Vendor:           t.Vendor,
+		EstimatedCost:    t.EstimatedCost,
+		ActualCost:       t.ActualCost,
+		ApprovalRequired: t.ApprovalRequired,
+		ApprovedBy:       t.ApprovedBy,
+		ApprovedAt:       t.ApprovedAt,
+		StartedAt:        t.StartedAt,
+		CompletedAt:      t.CompletedAt,
+		CancelledAt:      t.CancelledAt,
+		Version:          t.Version,
+		CreatedAt:        t.CreatedAt,
+	}
+}
+
+func toDomainReconTask(m *models.ReconTask) *domain.ReconTask {
+	return &domain.ReconTask{
+		ID:               m.ID,
+		OrderID:          m.OrderID,
+		Type:             domain.ReconTaskType(m.Type),
+		Description:      m.Description,
+		Status:           domain.ReconTaskStatus(m.Status),
+		AssigneeID:       m.AssigneeID,
+		Vendor:           m.Vendor,
+		EstimatedCost:    m.EstimatedCost,
+		ActualCost:       m.ActualCost,
+		ApprovalRequired: m.ApprovalRequired,
+		ApprovedBy:       m.ApprovedBy,
+		ApprovedAt:       m.ApprovedAt,
+		StartedAt:        m.StartedAt,
+		CompletedAt:      m.CompletedAt,
+		CancelledAt:      m.CancelledAt,
+		Version:          m.Version,
+		CreatedAt:        m.CreatedAt,
+	}
+}
@@ -1,6 +1,8 @@
 package repositories
 
 import (
+	"context"
+
 	"gorm.io/gorm"
 	"torque-dms/core/identity/domain"
 	"torque-dms/core/identity/ports/output"
@@ -17,9 +19,9 @@ func NewResourceRepository(db *gorm.DB) output.ResourceRepository {
 
 // Resource
 
-func (r *resourceRepository) Save(resource *domain.Resource) error {
+func (r *resourceRepository) Save(ctx context.Context, resource *domain.Resource) error {
 	model := toResourceModel(resource)
-	result := r.db.Create(model)
+	result := dbFrom(ctx, r.db).Create(model)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -27,41 +29,41 @@ func (r *resourceRepository) Save(resource *domain.Resource) error {
 	return nil
 }
 
-func (r *resourceRepository) Update(resource *domain.Resource) error {
+func (r *resourceRepository) Update(ctx context.Context, resource *domain.Resource) error {
 	model := toResourceModel(resource)
-	return r.db.Save(model).Error
+	return dbFrom(ctx, r.db).Save(model).Error
 }
 
-func (r *resourceRepository) FindByID(id uint) (*domain.Resource, error) {
+func (r *resourceRepository) FindByID(ctx context.Context, id uint) (*domain.Resource, error) {
 	var model models.Resource
-	result := r.db.First(&model, id)
+	result := dbFrom(ctx, r.db).First(&model, id)
 	if result.Error != nil {
-		return nil, result.Error
+		return nil, notFound(result.Error, "resource")
 	}
 	return toDomainResource(&model), nil
 }
 
-func (r *resourceRepository) FindByCode(code string) (*domain.Resource, error) {
+func (r *resourceRepository) FindByCode(ctx context.Context, code string) (*domain.Resource, error) {
 	var model models.Resource
-	result := r.db.Where("code = ?", code).First(&model)
+	result := dbFrom(ctx, r.db).Where("code = ?", code).First(&model)
 	if result.Error != nil {
-		return nil, result.Error
+		return nil, notFound(result.Error, "resource")
 	}
 	return toDomainResource(&model), nil
 }
 
-func (r *resourceRepository) FindByMethodAndPattern(method string, urlPattern string) (*domain.Resource, error) {
+func (r *resourceRepository) FindByMethodAndPattern(ctx context.Context, method string, urlPattern string) (*domain.Resource, error) {
 	var model models.Resource
-	result := r.db.Where("method = ? AND url_pattern = ?", method, urlPattern).First(&model)
+	result := dbFrom(ctx, r.db).Where("method = ? AND url_pattern = ?", method, urlPattern).First(&model)
 	if result.Error != nil {
-		return nil, result.Error
+		return nil, notFound(result.Error, "resource")
 	}
 	return toDomainResource(&model), nil
 }
 
-func (r *resourceRepository) FindAll() ([]*domain.Resource, error) {
+func (r *resourceRepository) FindAll(ctx context.Context) ([]*domain.Resource, error) {
 	var modelList []models.Resource
-	result := r.db.Find(&modelList)
+	result := dbFrom(ctx, r.db).Find(&modelList)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -73,29 +75,29 @@ func (r *resourceRepository) FindAll() ([]*domain.Resource, error) {
 	return resources, nil
 }
 
-func (r *resourceRepository) Delete(id uint) error {
-	return r.db.Delete(&models.Resource{}, id).Error
+func (r *resourceRepository) Delete(ctx context.Context, id uint) error {
+	return dbFrom(ctx, r.db).Delete(&models.Resource{}, id).Error
 }
 
 // RoleResource
 
-func (r *resourceRepository) AssignResourceToRole(roleResource *domain.RoleResource) error {
+func (r *resourceRepository) AssignResourceToRole(ctx context.Context, roleResource *domain.RoleResource) error {
 	model := &models.RoleResource{
 		RoleID:     roleResource.RoleID,
 		ResourceID: roleResource.ResourceID,
 		Scope:      models.AccessScope(roleResource.Scope),
 		CreatedAt:  roleResource.CreatedAt,
 	}
-	return r.db.Create(model).Error
+	return dbFrom(ctx, r.db).Create(model).Error
 }
 
-func (r *resourceRepository) RemoveResourceFromRole(roleID uint, resourceID uint) error {
-	return r.db.Where("role_id = ? AND resource_id = ?", roleID, resourceID).Delete(&models.RoleResource{}).Error
+func (r *resourceRepository) RemoveResourceFromRole(ctx context.Context, roleID uint, resourceID uint) error {
+	return dbFrom(ctx, r.db).Where("role_id = ? AND resource_id = ?", roleID, resourceID).Delete(&models.RoleResource{}).Error
 }
 
-func (r *resourceRepository) FindResourcesByRoleID(roleID uint) ([]*domain.RoleResource, error) {
+func (r *resourceRepository) FindResourcesByRoleID(ctx context.Context, roleID uint) ([]*domain.RoleResource, error) {
 	var modelList []models.RoleResource
-	result := r.db.Where("role_id = ?", roleID).Find(&modelList)
+	result := dbFrom(ctx, r.db).Where("role_id = ?", roleID).Find(&modelList)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -109,7 +111,7 @@ func (r *resourceRepository) FindResourcesByRoleID(roleID uint) ([]*domain.RoleR
 
 // EntityResource
 
-func (r *resourceRepository) AssignResourceToEntity(entityResource *domain.EntityResource) error {
+func (r *resourceRepository) AssignResourceToEntity(ctx context.Context, entityResource *domain.EntityResource) error {
 	model := &models.EntityResource{
 		EntityID:   entityResource.EntityID,
 		ResourceID: entityResource.ResourceID,
@@ -119,16 +121,16 @@ func (r *resourceRepository) AssignResourceToEntity(entityResource *domain.Entit
 		ExpiresAt:  entityResource.ExpiresAt,
 		CreatedAt:  entityResource.CreatedAt,
 	}
-	return r.db.Create(model).Error
+	return dbFrom(ctx, r.db).Create(model).Error
 }
 
-func (r *resourceRepository) RemoveResourceFromEntity(entityID uint, resourceID uint) error {
-	return r.db.Where("entity_id = ? AND resource_id = ?", entityID, resourceID).Delete(&models.EntityResource{}).Error
+func (r *resourceRepository) RemoveResourceFromEntity(ctx context.Context, entityID uint, resourceID uint) error {
+	return dbFrom(ctx, r.db).Where("entity_id = ? AND resource_id = ?", entityID, resourceID).Delete(&models.EntityResource{}).Error
 }
 
-func (r *resourceRepository) FindResourcesByEntityID(entityID uint) ([]*domain.EntityResource, error) {
+func (r *resourceRepository) FindResourcesByEntityID(ctx context.Context, entityID uint) ([]*domain.EntityResource, error) {
 	var modelList []models.EntityResource
-	result := r.db.Where("entity_id = ?", entityID).Find(&modelList)
+	result := dbFrom(ctx, r.db).Where("entity_id = ?", entityID).Find(&modelList)
 	if result.Error != nil {
 		return nil, result.Error
 	}
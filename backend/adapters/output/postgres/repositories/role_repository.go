@@ -1,6 +1,8 @@
 package repositories
 
 import (
+	"context"
+
 	"gorm.io/gorm"
 	"torque-dms/core/identity/domain"
 	"torque-dms/core/identity/ports/output"
@@ -15,9 +17,9 @@ func NewRoleRepository(db *gorm.DB) output.RoleRepository {
 	return &roleRepository{db: db}
 }
 
-func (r *roleRepository) Save(role *domain.Role) error {
+func (r *roleRepository) Save(ctx context.Context, role *domain.Role) error {
 	model := toRoleModel(role)
-	result := r.db.Create(model)
+	result := dbFrom(ctx, r.db).Create(model)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -25,32 +27,32 @@ func (r *roleRepository) Save(role *domain.Role) error {
 	return nil
 }
 
-func (r *roleRepository) Update(role *domain.Role) error {
+func (r *roleRepository) Update(ctx context.Context, role *domain.Role) error {
 	model := toRoleModel(role)
-	return r.db.Save(model).Error
+	return dbFrom(ctx, r.db).Save(model).Error
 }
 
-func (r *roleRepository) FindByID(id uint) (*domain.Role, error) {
+func (r *roleRepository) FindByID(ctx context.Context, id uint) (*domain.Role, error) {
 	var model models.Role
-	result := r.db.First(&model, id)
+	result := dbFrom(ctx, r.db).First(&model, id)
 	if result.Error != nil {
-		return nil, result.Error
+		return nil, notFound(result.Error, "role")
 	}
 	return toDomainRole(&model), nil
 }
 
-func (r *roleRepository) FindByName(name string) (*domain.Role, error) {
+func (r *roleRepository) FindByName(ctx context.Context, name string) (*domain.Role, error) {
 	var model models.Role
-	result := r.db.Where("name = ?", name).First(&model)
+	result := dbFrom(ctx, r.db).Where("name = ?", name).First(&model)
 	if result.Error != nil {
-		return nil, result.Error
+		return nil, notFound(result.Error, "role")
 	}
 	return toDomainRole(&model), nil
 }
 
-func (r *roleRepository) FindAll() ([]*domain.Role, error) {
+func (r *roleRepository) FindAll(ctx context.Context) ([]*domain.Role, error) {
 	var modelList []models.Role
-	result := r.db.Find(&modelList)
+	result := dbFrom(ctx, r.db).Find(&modelList)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -62,26 +64,26 @@ func (r *roleRepository) FindAll() ([]*domain.Role, error) {
 	return roles, nil
 }
 
-func (r *roleRepository) Delete(id uint) error {
-	return r.db.Delete(&models.Role{}, id).Error
+func (r *roleRepository) Delete(ctx context.Context, id uint) error {
+	return dbFrom(ctx, r.db).Delete(&models.Role{}, id).Error
 }
 
-func (r *roleRepository) AssignRoleToEntity(entityRole *domain.EntityRole) error {
+func (r *roleRepository) AssignRoleToEntity(ctx context.Context, entityRole *domain.EntityRole) error {
 	model := &models.EntityRole{
 		EntityID:  entityRole.EntityID,
 		RoleID:    entityRole.RoleID,
 		CreatedAt: entityRole.CreatedAt,
 	}
-	return r.db.Create(model).Error
+	return dbFrom(ctx, r.db).Create(model).Error
 }
 
-func (r *roleRepository) RemoveRoleFromEntity(entityID uint, roleID uint) error {
-	return r.db.Where("entity_id = ? AND role_id = ?", entityID, roleID).Delete(&models.EntityRole{}).Error
+func (r *roleRepository) RemoveRoleFromEntity(ctx context.Context, entityID uint, roleID uint) error {
+	return dbFrom(ctx, r.db).Where("entity_id = ? AND role_id = ?", entityID, roleID).Delete(&models.EntityRole{}).Error
 }
 
-func (r *roleRepository) FindRolesByEntityID(entityID uint) ([]*domain.Role, error) {
+func (r *roleRepository) FindRolesByEntityID(ctx context.Context, entityID uint) ([]*domain.Role, error) {
 	var roleList []models.Role
-	result := r.db.
+	result := dbFrom(ctx, r.db).
 		Joins("JOIN entity_role ON entity_role.role_id = role.id").
 		Where("entity_role.entity_id = ?", entityID).
 		Find(&roleList)
@@ -96,9 +98,9 @@ func (r *roleRepository) FindRolesByEntityID(entityID uint) ([]*domain.Role, err
 	return roles, nil
 }
 
-func (r *roleRepository) FindEntitiesByRoleID(roleID uint) ([]uint, error) {
+func (r *roleRepository) FindEntitiesByRoleID(ctx context.Context, roleID uint) ([]uint, error) {
 	var entityIDs []uint
-	result := r.db.Model(&models.EntityRole{}).Where("role_id = ?", roleID).Pluck("entity_id", &entityIDs)
+	result := dbFrom(ctx, r.db).Model(&models.EntityRole{}).Where("role_id = ?", roleID).Pluck("entity_id", &entityIDs)
 	return entityIDs, result.Error
 }
 
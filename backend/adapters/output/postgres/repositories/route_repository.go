@@ -0,0 +1,190 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"torque-dms/core/inventory/domain"
+	"torque-dms/core/inventory/ports/output"
+	sharedDomain "torque-dms/core/shared/domain"
+	"torque-dms/models"
+)
+
+var routeColumns = queryColumns{
+	"id":                "id",
+	"name":              "name",
+	"from_location_id":  "from_location_id",
+	"to_location_id":    "to_location_id",
+	"distance_km":       "distance_km",
+	"estimated_minutes": "estimated_minutes",
+	"active":            "active",
+	"created_at":        "created_at",
+}
+
+// waypoint - formato de cada punto en la columna JSON waypoints
+type waypoint struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+type routeRepository struct {
+	db *gorm.DB
+}
+
+func NewRouteRepository(db *gorm.DB) output.RouteRepository {
+	return &routeRepository{db: db}
+}
+
+func (r *routeRepository) Save(ctx context.Context, route *domain.Route) error {
+	model := toRouteModel(route)
+	result := dbFrom(ctx, r.db).Create(model)
+	if result.Error != nil {
+		return result.Error
+	}
+	route.ID = model.ID
+	route.Version = model.Version
+	return nil
+}
+
+func (r *routeRepository) Update(ctx context.Context, route *domain.Route) error {
+	model := toRouteModel(route)
+	model.Version = route.Version + 1
+	if err := updateVersioned(ctx, r.db, model, route.Version, "route"); err != nil {
+		return err
+	}
+	route.Version = model.Version
+	return nil
+}
+
+func (r *routeRepository) FindByID(ctx context.Context, id uint) (*domain.Route, error) {
+	var model models.Route
+	result := dbFrom(ctx, r.db).First(&model, id)
+	if result.Error != nil {
+		return nil, notFound(result.Error, "route")
+	}
+	return toDomainRoute(&model), nil
+}
+
+func (r *routeRepository) FindByIDForUpdate(ctx context.Context, id uint) (*domain.Route, error) {
+	var model models.Route
+	result := dbFrom(ctx, r.db).Clauses(clause.Locking{Strength: "UPDATE"}).First(&model, id)
+	if result.Error != nil {
+		return nil, notFound(result.Error, "route")
+	}
+	return toDomainRoute(&model), nil
+}
+
+func (r *routeRepository) FindByIDs(ctx context.Context, ids []uint) (map[uint]*domain.Route, error) {
+	routes := make(map[uint]*domain.Route, len(ids))
+	if len(ids) == 0 {
+		return routes, nil
+	}
+
+	var modelList []models.Route
+	result := dbFrom(ctx, r.db).Where("id IN ?", ids).Find(&modelList)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	for i := range modelList {
+		routes[modelList[i].ID] = toDomainRoute(&modelList[i])
+	}
+	return routes, nil
+}
+
+func (r *routeRepository) FindAll(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.Route], error) {
+	return findPage(dbFrom(ctx, r.db).Model(&models.Route{}), q, routeColumns, byName, toDomainRoute)
+}
+
+func (r *routeRepository) FindActiveBetween(ctx context.Context, fromLocationID uint, toLocationID uint) ([]*domain.Route, error) {
+	var modelList []models.Route
+	result := dbFrom(ctx, r.db).
+		Where("from_location_id = ? AND to_location_id = ? AND active = ?", fromLocationID, toLocationID, true).
+		Order("name ASC").
+		Find(&modelList)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	routes := make([]*domain.Route, len(modelList))
+	for i, model := range modelList {
+		routes[i] = toDomainRoute(&model)
+	}
+	return routes, nil
+}
+
+func (r *routeRepository) ExistingIDs(ctx context.Context, ids []uint) (map[uint]bool, error) {
+	existing := make(map[uint]bool, len(ids))
+	if len(ids) == 0 {
+		return existing, nil
+	}
+
+	var found []uint
+	result := dbFrom(ctx, r.db).Model(&models.Route{}).Where("id IN ?", ids).Pluck("id", &found)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	for _, id := range found {
+		existing[id] = true
+	}
+	return existing, nil
+}
+
+func (r *routeRepository) Delete(ctx context.Context, id uint) error {
+	return dbFrom(ctx, r.db).Delete(&models.Route{}, id).Error
+}
+
+// Mappers
+
+func toRouteModel(r *domain.Route) *models.Route {
+	points := make([]waypoint, len(r.Waypoints))
+	for i, p := range r.Waypoints {
+		points[i] = waypoint{Latitude: p.Latitude, Longitude: p.Longitude}
+	}
+	// Un slice de structs planos siempre se puede serializar
+	waypoints, _ := json.Marshal(points)
+
+	return &models.Route{
+		ID:               r.ID,
+		Name:             r.Name,
+		FromLocationID:   r.FromLocationID,
+		ToLocationID:     r.ToLocationID,
+		DistanceKM:       r.DistanceKM,
+		EstimatedMinutes: r.EstimatedMinutes,
+		Waypoints:        waypoints,
+		Active:           r.Active,
+		Version:          r.Version,
+		CreatedAt:        r.CreatedAt,
+	}
+}
+
+// toDomainRoute - waypoints ilegibles (datos anteriores a la API) se tratan como ruta directa
+func toDomainRoute(m *models.Route) *domain.Route {
+	var points []waypoint
+	if len(m.Waypoints) > 0 {
+		if err := json.Unmarshal(m.Waypoints, &points); err != nil {
+			points = nil
+		}
+	}
+
+	waypoints := make([]sharedDomain.GeoPoint, len(points))
+	for i, p := range points {
+		waypoints[i] = sharedDomain.GeoPoint{Latitude: p.Latitude, Longitude: p.Longitude}
+	}
+
+	return &domain.Route{
+		ID:               m.ID,
+		Name:             m.Name,
+		FromLocationID:   m.FromLocationID,
+		ToLocationID:     m.ToLocationID,
+		DistanceKM:       m.DistanceKM,
+		EstimatedMinutes: m.EstimatedMinutes,
+		Waypoints:        waypoints,
+		Active:           m.Active,
+		Version:          m.Version,
+		CreatedAt:        m.CreatedAt,
+	}
+}
@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"torque-dms/core/inventory/domain"
+	"torque-dms/core/inventory/ports/output"
+	sharedDomain "torque-dms/core/shared/domain"
+	"torque-dms/models"
+)
+
+var statusHistoryColumns = queryColumns{
+	"id":          "id",
+	"from_status": "from_status",
+	"to_status":   "to_status",
+	"changed_by":  "changed_by",
+	"created_at":  "created_at",
+}
+
+type vehicleStatusHistoryRepository struct {
+	db *gorm.DB
+}
+
+func NewVehicleStatusHistoryRepository(db *gorm.DB) output.VehicleStatusHistoryRepository {
+	return &vehicleStatusHistoryRepository{db: db}
+}
+
+func (r *vehicleStatusHistoryRepository) Save(ctx context.Context, history *domain.VehicleStatusHistory) error {
+	model := toStatusHistoryModel(history)
+	result := dbFrom(ctx, r.db).Omit(clause.Associations).Create(model)
+	if result.Error != nil {
+		return result.Error
+	}
+	history.ID = model.ID
+	return nil
+}
+
+func (r *vehicleStatusHistoryRepository) FindByVehicleID(ctx context.Context, vehicleID uint, q sharedDomain.Query) (*sharedDomain.Page[*domain.VehicleStatusHistory], error) {
+	db := dbFrom(ctx, r.db).Model(&models.VehicleStatusHistory{}).Where("vehicle_id = ?", vehicleID)
+	return findPage(db, q, statusHistoryColumns, newestFirst, toDomainStatusHistory)
+}
+
+// Mappers
+
+func toStatusHistoryModel(h *domain.VehicleStatusHistory) *models.VehicleStatusHistory {
+	return &models.VehicleStatusHistory{
+		ID:         h.ID,
+		VehicleID:  h.VehicleID,
+		FromStatus: models.VehicleStatus(h.FromStatus),
+		ToStatus:   models.VehicleStatus(h.ToStatus),
+		ChangedBy:  h.ChangedBy,
+		Reason:     h.Reason,
+		CreatedAt:  h.CreatedAt,
+	}
+}
+
+func toDomainStatusHistory(m *models.VehicleStatusHistory) *domain.VehicleStatusHistory {
+	return &domain.VehicleStatusHistory{
+		ID:         m.ID,
+		VehicleID:  m.VehicleID,
+		FromStatus: domain.VehicleStatus(m.FromStatus),
+		ToStatus:   domain.VehicleStatus(m.ToStatus),
+		ChangedBy:  m.ChangedBy,
+		Reason:     m.Reason,
+		CreatedAt:  m.CreatedAt,
+	}
+}
@@ -1,14 +1,32 @@
 package repositories
 
 import (
+	"context"
+	"time"
+
 	"gorm.io/gorm"
 	"torque-dms/core/sales/domain"
 	"torque-dms/core/sales/ports/output"
+	sharedDomain "torque-dms/core/shared/domain"
 	"torque-dms/models"
 )
 
 // Preset Repository
 
+var presetColumns = queryColumns{
+	"id":         "id",
+	"code":       "code",
+	"name":       "name",
+	"sort_order": "sort_order",
+	"is_public":  "is_public",
+	"is_shared":  "is_shared",
+	"created_by": "created_by",
+	"created_at": "created_at",
+	"deleted_at": "deleted_at",
+}
+
+var presetSort = []sharedDomain.Sort{{Field: "sort_order"}, {Field: "name"}}
+
 type leadStepPresetRepository struct {
 	db *gorm.DB
 }
@@ -17,9 +35,9 @@ func NewLeadStepPresetRepository(db *gorm.DB) output.LeadStepPresetRepository {
 	return &leadStepPresetRepository{db: db}
 }
 
-func (r *leadStepPresetRepository) Save(preset *domain.LeadStepPreset) error {
+func (r *leadStepPresetRepository) Save(ctx context.Context, preset *domain.LeadStepPreset) error {
 	model := toLeadStepPresetModel(preset)
-	result := r.db.Create(model)
+	result := dbFrom(ctx, r.db).Create(model)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -27,60 +45,41 @@ func (r *leadStepPresetRepository) Save(preset *domain.LeadStepPreset) error {
 	return nil
 }
 
-func (r *leadStepPresetRepository) Update(preset *domain.LeadStepPreset) error {
+func (r *leadStepPresetRepository) Update(ctx context.Context, preset *domain.LeadStepPreset) error {
 	model := toLeadStepPresetModel(preset)
-	return r.db.Save(model).Error
+	return dbFrom(ctx, r.db).Save(model).Error
 }
 
-func (r *leadStepPresetRepository) FindByID(id uint) (*domain.LeadStepPreset, error) {
+func (r *leadStepPresetRepository) FindByID(ctx context.Context, id uint) (*domain.LeadStepPreset, error) {
 	var model models.LeadStepPreset
-	result := r.db.First(&model, id)
+	result := dbFrom(ctx, r.db).First(&model, id)
 	if result.Error != nil {
-		return nil, result.Error
+		return nil, notFound(result.Error, "preset")
 	}
 	return toDomainLeadStepPreset(&model), nil
 }
 
-func (r *leadStepPresetRepository) FindByCode(code string) (*domain.LeadStepPreset, error) {
+func (r *leadStepPresetRepository) FindByCode(ctx context.Context, code string) (*domain.LeadStepPreset, error) {
 	var model models.LeadStepPreset
-	result := r.db.Where("code = ?", code).First(&model)
+	result := dbFrom(ctx, r.db).Where("code = ?", code).First(&model)
 	if result.Error != nil {
-		return nil, result.Error
+		return nil, notFound(result.Error, "preset")
 	}
 	return toDomainLeadStepPreset(&model), nil
 }
 
-func (r *leadStepPresetRepository) FindAll() ([]*domain.LeadStepPreset, error) {
-	var modelList []models.LeadStepPreset
-	result := r.db.Order("sort_order ASC, name ASC").Find(&modelList)
-	if result.Error != nil {
-		return nil, result.Error
-	}
-
-	presets := make([]*domain.LeadStepPreset, len(modelList))
-	for i, model := range modelList {
-		presets[i] = toDomainLeadStepPreset(&model)
-	}
-	return presets, nil
+func (r *leadStepPresetRepository) FindAll(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.LeadStepPreset], error) {
+	return findPage(dbFrom(ctx, r.db).Model(&models.LeadStepPreset{}), q, presetColumns, presetSort, toDomainLeadStepPreset)
 }
 
-func (r *leadStepPresetRepository) FindPublic() ([]*domain.LeadStepPreset, error) {
-	var modelList []models.LeadStepPreset
-	result := r.db.Where("is_public = ?", true).Order("sort_order ASC, name ASC").Find(&modelList)
-	if result.Error != nil {
-		return nil, result.Error
-	}
-
-	presets := make([]*domain.LeadStepPreset, len(modelList))
-	for i, model := range modelList {
-		presets[i] = toDomainLeadStepPreset(&model)
-	}
-	return presets, nil
+func (r *leadStepPresetRepository) FindDeleted(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.LeadStepPreset], error) {
+	db := dbFrom(ctx, r.db).Unscoped().Model(&models.LeadStepPreset{}).Where("deleted_at IS NOT NULL")
+	return findPage(db, q, presetColumns, deletedSort, toDomainLeadStepPreset)
 }
 
-func (r *leadStepPresetRepository) FindByCreatedBy(entityID uint) ([]*domain.LeadStepPreset, error) {
+func (r *leadStepPresetRepository) FindDeletedBefore(ctx context.Context, before time.Time) ([]*domain.LeadStepPreset, error) {
 	var modelList []models.LeadStepPreset
-	result := r.db.Where("created_by = ?", entityID).Order("sort_order ASC, name ASC").Find(&modelList)
+	result := dbFrom(ctx, r.db).Unscoped().Where("deleted_at < ?", before).Order("deleted_at ASC").Find(&modelList)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -92,13 +91,13 @@ func (r *leadStepPresetRepository) FindByCreatedBy(entityID uint) ([]*domain.Lea
 	return presets, nil
 }
 
-func (r *leadStepPresetRepository) Delete(id uint) error {
-	return r.db.Delete(&models.LeadStepPreset{}, id).Error
+func (r *leadStepPresetRepository) Purge(ctx context.Context, id uint) error {
+	return dbFrom(ctx, r.db).Unscoped().Delete(&models.LeadStepPreset{}, id).Error
 }
 
-func (r *leadStepPresetRepository) Exists(id uint) (bool, error) {
+func (r *leadStepPresetRepository) Exists(ctx context.Context, id uint) (bool, error) {
 	var count int64
-	result := r.db.Model(&models.LeadStepPreset{}).Where("id = ?", id).Count(&count)
+	result := dbFrom(ctx, r.db).Model(&models.LeadStepPreset{}).Where("id = ?", id).Count(&count)
 	return count > 0, result.Error
 }
 
@@ -112,9 +111,9 @@ func NewLeadStepRepository(db *gorm.DB) output.LeadStepRepository {
 	return &leadStepRepository{db: db}
 }
 
-func (r *leadStepRepository) Save(step *domain.LeadStep) error {
+func (r *leadStepRepository) Save(ctx context.Context, step *domain.LeadStep) error {
 	model := toLeadStepModel(step)
-	result := r.db.Create(model)
+	result := dbFrom(ctx, r.db).Create(model)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -122,23 +121,23 @@ func (r *leadStepRepository) Save(step *domain.LeadStep) error {
 	return nil
 }
 
-func (r *leadStepRepository) Update(step *domain.LeadStep) error {
+func (r *leadStepRepository) Update(ctx context.Context, step *domain.LeadStep) error {
 	model := toLeadStepModel(step)
-	return r.db.Save(model).Error
+	return dbFrom(ctx, r.db).Save(model).Error
 }
 
-func (r *leadStepRepository) FindByID(id uint) (*domain.LeadStep, error) {
+func (r *leadStepRepository) FindByID(ctx context.Context, id uint) (*domain.LeadStep, error) {
 	var model models.LeadStep
-	result := r.db.First(&model, id)
+	result := dbFrom(ctx, r.db).First(&model, id)
 	if result.Error != nil {
-		return nil, result.Error
+		return nil, notFound(result.Error, "step")
 	}
 	return toDomainLeadStep(&model), nil
 }
 
-func (r *leadStepRepository) FindByPresetID(presetID uint) ([]*domain.LeadStep, error) {
+func (r *leadStepRepository) FindByPresetID(ctx context.Context, presetID uint) ([]*domain.LeadStep, error) {
 	var modelList []models.LeadStep
-	result := r.db.Where("preset_id = ?", presetID).Order("sort_order ASC").Find(&modelList)
+	result := dbFrom(ctx, r.db).Where("preset_id = ?", presetID).Order("sort_order ASC").Find(&modelList)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -150,9 +149,9 @@ func (r *leadStepRepository) FindByPresetID(presetID uint) ([]*domain.LeadStep,
 	return steps, nil
 }
 
-func (r *leadStepRepository) FindActiveByPresetID(presetID uint) ([]*domain.LeadStep, error) {
+func (r *leadStepRepository) FindActiveByPresetID(ctx context.Context, presetID uint) ([]*domain.LeadStep, error) {
 	var modelList []models.LeadStep
-	result := r.db.Where("preset_id = ? AND active = ?", presetID, true).Order("sort_order ASC").Find(&modelList)
+	result := dbFrom(ctx, r.db).Where("preset_id = ? AND active = ?", presetID, true).Order("sort_order ASC").Find(&modelList)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -164,13 +163,13 @@ func (r *leadStepRepository) FindActiveByPresetID(presetID uint) ([]*domain.Lead
 	return steps, nil
 }
 
-func (r *leadStepRepository) Delete(id uint) error {
-	return r.db.Delete(&models.LeadStep{}, id).Error
+func (r *leadStepRepository) Delete(ctx context.Context, id uint) error {
+	return dbFrom(ctx, r.db).Delete(&models.LeadStep{}, id).Error
 }
 
-func (r *leadStepRepository) Exists(id uint) (bool, error) {
+func (r *leadStepRepository) Exists(ctx context.Context, id uint) (bool, error) {
 	var count int64
-	result := r.db.Model(&models.LeadStep{}).Where("id = ?", id).Count(&count)
+	result := dbFrom(ctx, r.db).Model(&models.LeadStep{}).Where("id = ?", id).Count(&count)
 	return count > 0, result.Error
 }
 
@@ -184,9 +183,9 @@ func NewLeadStepProgressRepository(db *gorm.DB) output.LeadStepProgressRepositor
 	return &leadStepProgressRepository{db: db}
 }
 
-func (r *leadStepProgressRepository) Save(progress *domain.LeadStepProgress) error {
+func (r *leadStepProgressRepository) Save(ctx context.Context, progress *domain.LeadStepProgress) error {
 	model := toLeadStepProgressModel(progress)
-	result := r.db.Create(model)
+	result := dbFrom(ctx, r.db).Create(model)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -194,23 +193,23 @@ func (r *leadStepProgressRepository) Save(progress *domain.LeadStepProgress) err
 	return nil
 }
 
-func (r *leadStepProgressRepository) Update(progress *domain.LeadStepProgress) error {
+func (r *leadStepProgressRepository) Update(ctx context.Context, progress *domain.LeadStepProgress) error {
 	model := toLeadStepProgressModel(progress)
-	return r.db.Save(model).Error
+	return dbFrom(ctx, r.db).Save(model).Error
 }
 
-func (r *leadStepProgressRepository) FindByID(id uint) (*domain.LeadStepProgress, error) {
+func (r *leadStepProgressRepository) FindByID(ctx context.Context, id uint) (*domain.LeadStepProgress, error) {
 	var model models.LeadStepProgress
-	result := r.db.First(&model, id)
+	result := dbFrom(ctx, r.db).First(&model, id)
 	if result.Error != nil {
-		return nil, result.Error
+		return nil, notFound(result.Error, "progress")
 	}
 	return toDomainLeadStepProgress(&model), nil
 }
 
-func (r *leadStepProgressRepository) FindByLeadID(leadID uint) ([]*domain.LeadStepProgress, error) {
+func (r *leadStepProgressRepository) FindByLeadID(ctx context.Context, leadID uint) ([]*domain.LeadStepProgress, error) {
 	var modelList []models.LeadStepProgress
-	result := r.db.Where("lead_id = ?", leadID).Find(&modelList)
+	result := dbFrom(ctx, r.db).Where("lead_id = ?", leadID).Find(&modelList)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -222,17 +221,17 @@ func (r *leadStepProgressRepository) FindByLeadID(leadID uint) ([]*domain.LeadSt
 	return progressList, nil
 }
 
-func (r *leadStepProgressRepository) FindByLeadIDAndStepID(leadID uint, stepID uint) (*domain.LeadStepProgress, error) {
+func (r *leadStepProgressRepository) FindByLeadIDAndStepID(ctx context.Context, leadID uint, stepID uint) (*domain.LeadStepProgress, error) {
 	var model models.LeadStepProgress
-	result := r.db.Where("lead_id = ? AND step_id = ?", leadID, stepID).First(&model)
+	result := dbFrom(ctx, r.db).Where("lead_id = ? AND step_id = ?", leadID, stepID).First(&model)
 	if result.Error != nil {
-		return nil, result.Error
+		return nil, notFound(result.Error, "progress")
 	}
 	return toDomainLeadStepProgress(&model), nil
 }
 
-func (r *leadStepProgressRepository) Delete(id uint) error {
-	return r.db.Delete(&models.LeadStepProgress{}, id).Error
+func (r *leadStepProgressRepository) Delete(ctx context.Context, id uint) error {
+	return dbFrom(ctx, r.db).Delete(&models.LeadStepProgress{}, id).Error
 }
 
 // Mappers
@@ -247,6 +246,8 @@ func toLeadStepPresetModel(p *domain.LeadStepPreset) *models.LeadStepPreset {
 		IsPublic:    p.IsPublic,
 		IsShared:    p.IsShared,
 		CreatedBy:   p.CreatedBy,
+		DeletedAt:   toDeletedAt(p.DeletedAt),
+		DeletedBy:   p.DeletedBy,
 		CreatedAt:   p.CreatedAt,
 	}
 }
@@ -261,6 +262,8 @@ func toDomainLeadStepPreset(m *models.LeadStepPreset) *domain.LeadStepPreset {
 		IsPublic:    m.IsPublic,
 		IsShared:    m.IsShared,
 		CreatedBy:   m.CreatedBy,
+		DeletedAt:   fromDeletedAt(m.DeletedAt),
+		DeletedBy:   m.DeletedBy,
 		CreatedAt:   m.CreatedAt,
 	}
 }
@@ -1,6 +1,8 @@
 package repositories
 
 import (
+	"context"
+
 	"gorm.io/gorm"
 	"torque-dms/core/identity/domain"
 	"torque-dms/core/identity/ports/output"
@@ -15,9 +17,9 @@ func NewUserRepository(db *gorm.DB) output.UserRepository {
 	return &userRepository{db: db}
 }
 
-func (r *userRepository) Save(user *domain.UserAccount) error {
+func (r *userRepository) Save(ctx context.Context, user *domain.UserAccount) error {
 	model := toUserModel(user)
-	result := r.db.Create(model)
+	result := dbFrom(ctx, r.db).Create(model)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -25,45 +27,45 @@ func (r *userRepository) Save(user *domain.UserAccount) error {
 	return nil
 }
 
-func (r *userRepository) Update(user *domain.UserAccount) error {
+func (r *userRepository) Update(ctx context.Context, user *domain.UserAccount) error {
 	model := toUserModel(user)
-	return r.db.Save(model).Error
+	return dbFrom(ctx, r.db).Save(model).Error
 }
 
-func (r *userRepository) FindByID(id uint) (*domain.UserAccount, error) {
+func (r *userRepository) FindByID(ctx context.Context, id uint) (*domain.UserAccount, error) {
 	var model models.UserAccount
-	result := r.db.First(&model, id)
+	result := dbFrom(ctx, r.db).First(&model, id)
 	if result.Error != nil {
-		return nil, result.Error
+		return nil, notFound(result.Error, "user")
 	}
 	return toDomainUser(&model), nil
 }
 
-func (r *userRepository) FindByEntityID(entityID uint) (*domain.UserAccount, error) {
+func (r *userRepository) FindByEntityID(ctx context.Context, entityID uint) (*domain.UserAccount, error) {
 	var model models.UserAccount
-	result := r.db.Where("entity_id = ?", entityID).First(&model)
+	result := dbFrom(ctx, r.db).Where("entity_id = ?", entityID).First(&model)
 	if result.Error != nil {
-		return nil, result.Error
+		return nil, notFound(result.Error, "user")
 	}
 	return toDomainUser(&model), nil
 }
 
-func (r *userRepository) FindByUsername(username string) (*domain.UserAccount, error) {
+func (r *userRepository) FindByUsername(ctx context.Context, username string) (*domain.UserAccount, error) {
 	var model models.UserAccount
-	result := r.db.Where("username = ?", username).First(&model)
+	result := dbFrom(ctx, r.db).Where("username = ?", username).First(&model)
 	if result.Error != nil {
-		return nil, result.Error
+		return nil, notFound(result.Error, "user")
 	}
 	return toDomainUser(&model), nil
 }
 
-func (r *userRepository) Delete(id uint) error {
-	return r.db.Delete(&models.UserAccount{}, id).Error
+func (r *userRepository) Delete(ctx context.Context, id uint) error {
+	return dbFrom(ctx, r.db).Delete(&models.UserAccount{}, id).Error
 }
 
-func (r *userRepository) Exists(username string) (bool, error) {
+func (r *userRepository) Exists(ctx context.Context, username string) (bool, error) {
 	var count int64
-	result := r.db.Model(&models.UserAccount{}).Where("username = ?", username).Count(&count)
+	result := dbFrom(ctx, r.db).Model(&models.UserAccount{}).Where("username = ?", username).Count(&count)
 	return count > 0, result.Error
 }
 
@@ -1,12 +1,39 @@
 package repositories
 
 import (
+	"context"
+	"time"
+
 	"gorm.io/gorm"
 	"torque-dms/core/inventory/domain"
 	"torque-dms/core/inventory/ports/output"
+	sharedDomain "torque-dms/core/shared/domain"
 	"torque-dms/models"
 )
 
+var vehicleColumns = queryColumns{
+	"id":                 "id",
+	"stock_number":       "stock_number",
+	"vin":                "vin",
+	"make":               "make",
+	"model":              "model",
+	"trim":               "trim",
+	"year":               "year",
+	"mileage":            "mileage",
+	"exterior_color":     "exterior_color",
+	"condition":          "condition",
+	"status":             "status",
+	"lot_type":           "lot_type",
+	"location_id":        "location_id",
+	"msrp":               "msrp",
+	"asking_price":       "asking_price",
+	"acquisition_source": "acquisition_source",
+	"acquisition_date":   "acquisition_date",
+	"created_at":         "created_at",
+	"modified_at":        "modified_at",
+	"deleted_at":         "deleted_at",
+}
+
 type vehicleRepository struct {
 	db *gorm.DB
 }
@@ -15,51 +42,61 @@ func NewVehicleRepository(db *gorm.DB) output.VehicleRepository {
 	return &vehicleRepository{db: db}
 }
 
-func (r *vehicleRepository) Save(vehicle *domain.Vehicle) error {
+func (r *vehicleRepository) Save(ctx context.Context, vehicle *domain.Vehicle) error {
 	model := toVehicleModel(vehicle)
-	result := r.db.Create(model)
+	result := dbFrom(ctx, r.db).Create(model)
 	if result.Error != nil {
 		return result.Error
 	}
 	vehicle.ID = model.ID
+	vehicle.Version = model.Version
 	return nil
 }
 
-func (r *vehicleRepository) Update(vehicle *domain.Vehicle) error {
+func (r *vehicleRepository) Update(ctx context.Context, vehicle *domain.Vehicle) error {
 	model := toVehicleModel(vehicle)
-	return r.db.Save(model).Error
+	model.Version = vehicle.Version + 1
+	if err := updateVersioned(ctx, r.db, model, vehicle.Version, "vehicle"); err != nil {
+		return err
+	}
+	vehicle.Version = model.Version
+	return nil
 }
 
-func (r *vehicleRepository) FindByID(id uint) (*domain.Vehicle, error) {
+func (r *vehicleRepository) FindByID(ctx context.Context, id uint) (*domain.Vehicle, error) {
 	var model models.Vehicle
-	result := r.db.First(&model, id)
+	result := dbFrom(ctx, r.db).First(&model, id)
 	if result.Error != nil {
-		return nil, result.Error
+		return nil, notFound(result.Error, "vehicle")
 	}
 	return toDomainVehicle(&model), nil
 }
 
-func (r *vehicleRepository) FindByVIN(vin string) (*domain.Vehicle, error) {
+func (r *vehicleRepository) FindByVIN(ctx context.Context, vin string) (*domain.Vehicle, error) {
 	var model models.Vehicle
-	result := r.db.Where("vin = ?", vin).First(&model)
+	result := dbFrom(ctx, r.db).Where("vin = ?", vin).First(&model)
 	if result.Error != nil {
-		return nil, result.Error
+		return nil, notFound(result.Error, "vehicle")
 	}
 	return toDomainVehicle(&model), nil
 }
 
-func (r *vehicleRepository) FindByStockNumber(stockNumber string) (*domain.Vehicle, error) {
+func (r *vehicleRepository) FindByStockNumber(ctx context.Context, stockNumber string) (*domain.Vehicle, error) {
 	var model models.Vehicle
-	result := r.db.Where("stock_number = ?", stockNumber).First(&model)
+	result := dbFrom(ctx, r.db).Where("stock_number = ?", stockNumber).First(&model)
 	if result.Error != nil {
-		return nil, result.Error
+		return nil, notFound(result.Error, "vehicle")
 	}
 	return toDomainVehicle(&model), nil
 }
 
-func (r *vehicleRepository) FindAll(limit int, offset int) ([]*domain.Vehicle, error) {
+func (r *vehicleRepository) FindAll(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.Vehicle], error) {
+	return findPage(dbFrom(ctx, r.db).Model(&models.Vehicle{}), q, vehicleColumns, newestFirst, toDomainVehicle)
+}
+
+func (r *vehicleRepository) FindByLocationID(ctx context.Context, locationID uint) ([]*domain.Vehicle, error) {
 	var modelList []models.Vehicle
-	result := r.db.Limit(limit).Offset(offset).Order("created_at DESC").Find(&modelList)
+	result := dbFrom(ctx, r.db).Where("location_id = ?", locationID).Order("created_at DESC").Find(&modelList)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -71,37 +108,63 @@ func (r *vehicleRepository) FindAll(limit int, offset int) ([]*domain.Vehicle, e
 	return vehicles, nil
 }
 
-func (r *vehicleRepository) FindByStatus(status domain.VehicleStatus, limit int, offset int) ([]*domain.Vehicle, error) {
+func (r *vehicleRepository) FindByStatuses(ctx context.Context, statuses []domain.VehicleStatus) ([]*domain.Vehicle, error) {
+	values := make([]string, len(statuses))
+	for i, s := range statuses {
+		values[i] = string(s)
+	}
+
 	var modelList []models.Vehicle
-	result := r.db.Where("status = ?", status).Limit(limit).Offset(offset).Order("created_at DESC").Find(&modelList)
+	result := dbFrom(ctx, r.db).Where("status IN ?", values).Order(inStockSinceSQL + " ASC, id ASC").Find(&modelList)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 
 	vehicles := make([]*domain.Vehicle, len(modelList))
-	for i, model := range modelList {
-		vehicles[i] = toDomainVehicle(&model)
+	for i := range modelList {
+		vehicles[i] = toDomainVehicle(&modelList[i])
 	}
 	return vehicles, nil
 }
 
-func (r *vehicleRepository) FindByLocationID(locationID uint) ([]*domain.Vehicle, error) {
-	var modelList []models.Vehicle
-	result := r.db.Where("location_id = ?", locationID).Order("created_at DESC").Find(&modelList)
+func (r *vehicleRepository) CountStockByLocation(ctx context.Context, locationIDs []uint) ([]domain.StockCount, error) {
+	var rows []struct {
+		LocationID uint
+		Status     string
+		LotType    string
+		Count      int64
+	}
+	db := dbFrom(ctx, r.db).Model(&models.Vehicle{})
+	if locationIDs != nil {
+		db = db.Where("location_id IN ?", locationIDs)
+	}
+	result := db.Select("location_id, status, lot_type, COUNT(*) AS count").
+		Group("location_id, status, lot_type").
+		Scan(&rows)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 
-	vehicles := make([]*domain.Vehicle, len(modelList))
-	for i, model := range modelList {
-		vehicles[i] = toDomainVehicle(&model)
+	counts := make([]domain.StockCount, len(rows))
+	for i, row := range rows {
+		counts[i] = domain.StockCount{
+			LocationID: row.LocationID,
+			Status:     domain.VehicleStatus(row.Status),
+			LotType:    domain.LotType(row.LotType),
+			Count:      row.Count,
+		}
 	}
-	return vehicles, nil
+	return counts, nil
 }
 
-func (r *vehicleRepository) FindAvailable(limit int, offset int) ([]*domain.Vehicle, error) {
+func (r *vehicleRepository) FindDeleted(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.Vehicle], error) {
+	db := dbFrom(ctx, r.db).Unscoped().Model(&models.Vehicle{}).Where("deleted_at IS NOT NULL")
+	return findPage(db, q, vehicleColumns, deletedSort, toDomainVehicle)
+}
+
+func (r *vehicleRepository) FindDeletedBefore(ctx context.Context, before time.Time) ([]*domain.Vehicle, error) {
 	var modelList []models.Vehicle
-	result := r.db.Where("status = ?", domain.VehicleStatusReadyForSale).Limit(limit).Offset(offset).Order("created_at DESC").Find(&modelList)
+	result := dbFrom(ctx, r.db).Unscoped().Where("deleted_at < ?", before).Order("deleted_at ASC").Find(&modelList)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -113,22 +176,73 @@ func (r *vehicleRepository) FindAvailable(limit int, offset int) ([]*domain.Vehi
 	return vehicles, nil
 }
 
-func (r *vehicleRepository) Delete(id uint) error {
-	return r.db.Delete(&models.Vehicle{}, id).Error
+func (r *vehicleRepository) Purge(ctx context.Context, id uint) error {
+	return dbFrom(ctx, r.db).Unscoped().Delete(&models.Vehicle{}, id).Error
 }
 
-func (r *vehicleRepository) Exists(id uint) (bool, error) {
+func (r *vehicleRepository) Exists(ctx context.Context, id uint) (bool, error) {
 	var count int64
-	result := r.db.Model(&models.Vehicle{}).Where("id = ?", id).Count(&count)
+	result := dbFrom(ctx, r.db).Model(&models.Vehicle{}).Where("id = ?", id).Count(&count)
 	return count > 0, result.Error
 }
 
-func (r *vehicleRepository) ExistsByVIN(vin string) (bool, error) {
+// ExistsByVIN - incluye la papelera: el VIN sigue reservado mientras el vehicle se pueda restaurar
+func (r *vehicleRepository) ExistsByVIN(ctx context.Context, vin string) (bool, error) {
 	var count int64
-	result := r.db.Model(&models.Vehicle{}).Where("vin = ?", vin).Count(&count)
+	result := dbFrom(ctx, r.db).Unscoped().Model(&models.Vehicle{}).Where("vin = ?", vin).Count(&count)
 	return count > 0, result.Error
 }
 
+// ExistsByStockNumber - como el VIN, el número de stock de un vehicle en la papelera sigue ocupado
+func (r *vehicleRepository) ExistsByStockNumber(ctx context.Context, stockNumber string) (bool, error) {
+	var count int64
+	result := dbFrom(ctx, r.db).Unscoped().Model(&models.Vehicle{}).Where("stock_number = ?", stockNumber).Count(&count)
+	return count > 0, result.Error
+}
+
+// FindIDsByVIN - ids de los vehicles con esos VIN, indexados por VIN
+func (r *vehicleRepository) FindIDsByVIN(ctx context.Context, vins []string) (map[string]uint, error) {
+	return r.findIDsBy(ctx, "vin", vins)
+}
+
+// FindIDsByTrackingDevice - ids de los vehicles con esos dispositivos, indexados por dispositivo
+func (r *vehicleRepository) FindIDsByTrackingDevice(ctx context.Context, deviceIDs []string) (map[string]uint, error) {
+	return r.findIDsBy(ctx, "tracking_device_id", deviceIDs)
+}
+
+func (r *vehicleRepository) FindByTrackingDevice(ctx context.Context, deviceID string) (*domain.Vehicle, error) {
+	var model models.Vehicle
+	result := dbFrom(ctx, r.db).Where("tracking_device_id = ?", deviceID).First(&model)
+	if result.Error != nil {
+		return nil, notFound(result.Error, "vehicle")
+	}
+	return toDomainVehicle(&model), nil
+}
+
+func (r *vehicleRepository) findIDsBy(ctx context.Context, column string, keys []string) (map[string]uint, error) {
+	ids := make(map[string]uint, len(keys))
+	if len(keys) == 0 {
+		return ids, nil
+	}
+
+	var rows []struct {
+		Key string
+		ID  uint
+	}
+	result := dbFrom(ctx, r.db).Model(&models.Vehicle{}).
+		Select(column+" AS key, id").
+		Where(column+" IN ?", keys).
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	for _, row := range rows {
+		ids[row.Key] = row.ID
+	}
+	return ids, nil
+}
+
 // Mappers
 
 func toVehicleModel(v *domain.Vehicle) *models.Vehicle {
@@ -154,7 +268,16 @@ func toVehicleModel(v *domain.Vehicle) *models.Vehicle {
 		AcquisitionSource: models.AcquisitionSource(v.AcquisitionSource),
 		AcquisitionDate:   v.AcquisitionDate,
 		AcquisitionCost:   v.AcquisitionCost,
+		ReconCost:         v.ReconCost,
+		AdditionalCost:    v.AdditionalCost,
+		SalePrice:         v.SalePrice,
+		SoldAt:            v.SoldAt,
+		SoldLeadID:        v.SoldLeadID,
 		Model3DID:         v.Model3DID,
+		TrackingDeviceID:  v.TrackingDeviceID,
+		DeletedAt:         toDeletedAt(v.DeletedAt),
+		DeletedBy:         v.DeletedBy,
+		Version:           v.Version,
 		CreatedAt:         v.CreatedAt,
 		ModifiedAt:        v.ModifiedAt,
 	}
@@ -183,7 +306,16 @@ func toDomainVehicle(m *models.Vehicle) *domain.Vehicle {
 		AcquisitionSource: domain.AcquisitionSource(m.AcquisitionSource),
 		AcquisitionDate:   m.AcquisitionDate,
 		AcquisitionCost:   m.AcquisitionCost,
+		ReconCost:         m.ReconCost,
+		AdditionalCost:    m.AdditionalCost,
+		SalePrice:         m.SalePrice,
+		SoldAt:            m.SoldAt,
+		SoldLeadID:        m.SoldLeadID,
 		Model3DID:         m.Model3DID,
+		TrackingDeviceID:  m.TrackingDeviceID,
+		DeletedAt:         fromDeletedAt(m.DeletedAt),
+		DeletedBy:         m.DeletedBy,
+		Version:           m.Version,
 		CreatedAt:         m.CreatedAt,
 		ModifiedAt:        m.ModifiedAt,
 	}
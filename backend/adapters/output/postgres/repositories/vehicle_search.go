@@ -0,0 +1,144 @@
+package repositories
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"torque-dms/core/inventory/domain"
+	sharedDomain "torque-dms/core/shared/domain"
+	"torque-dms/models"
+)
+
+const facetLimit = 50
+
+// inStockSinceSQL - inicio de los días en stock, como Vehicle.DaysInStock: la adquisición o,
+// si no hay (se guarda el cero de Go), el alta
+const inStockSinceSQL = `COALESCE(NULLIF("acquisition_date", '0001-01-01 00:00:00+00'), "created_at")`
+
+func (r *vehicleRepository) Search(ctx context.Context, criteria domain.VehicleSearch, q sharedDomain.Query) (*sharedDomain.Page[*domain.Vehicle], error) {
+	db := applyVehicleSearch(dbFrom(ctx, r.db).Model(&models.Vehicle{}), criteria, time.Now())
+	return findPage(db, q, vehicleColumns, newestFirst, toDomainVehicle)
+}
+
+// Facets - cada conteo se calcula sin el filtro de su propia dimensión
+func (r *vehicleRepository) Facets(ctx context.Context, criteria domain.VehicleSearch) (*domain.VehicleFacets, error) {
+	now := time.Now()
+
+	withoutMakes := criteria
+	withoutMakes.Makes = nil
+	makes, err := r.facet(ctx, withoutMakes, "make", now)
+	if err != nil {
+		return nil, err
+	}
+
+	withoutModels := criteria
+	withoutModels.Models = nil
+	modelCounts, err := r.facet(ctx, withoutModels, "model", now)
+	if err != nil {
+		return nil, err
+	}
+
+	withoutYears := criteria
+	withoutYears.YearMin, withoutYears.YearMax = nil, nil
+	years, err := r.facet(ctx, withoutYears, "year", now)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.VehicleFacets{Makes: makes, Models: modelCounts, Years: years}, nil
+}
+
+func (r *vehicleRepository) facet(ctx context.Context, criteria domain.VehicleSearch, column string, now time.Time) ([]domain.FacetCount, error) {
+	var rows []struct {
+		Value string
+		Count int64
+	}
+	result := applyVehicleSearch(dbFrom(ctx, r.db).Model(&models.Vehicle{}), criteria, now).
+		Select(column + "::text AS value, COUNT(*) AS count").
+		Group(column).
+		Order("count DESC, value ASC").
+		Limit(facetLimit).
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	counts := make([]domain.FacetCount, len(rows))
+	for i, row := range rows {
+		counts[i] = domain.FacetCount{Value: row.Value, Count: row.Count}
+	}
+	return counts, nil
+}
+
+func applyVehicleSearch(db *gorm.DB, s domain.VehicleSearch, now time.Time) *gorm.DB {
+	if text := strings.TrimSpace(s.Text); text != "" {
+		pattern := "%" + escapeLike(text) + "%"
+		db = db.Where("(stock_number ILIKE ? OR vin ILIKE ? OR plate ILIKE ?)", pattern, pattern, pattern)
+	}
+
+	if len(s.Makes) > 0 {
+		db = db.Where("LOWER(make) IN ?", lowerAll(s.Makes))
+	}
+	if len(s.Models) > 0 {
+		db = db.Where("LOWER(model) IN ?", lowerAll(s.Models))
+	}
+	if len(s.Trims) > 0 {
+		db = db.Where(`LOWER("trim") IN ?`, lowerAll(s.Trims))
+	}
+	if len(s.Colors) > 0 {
+		db = db.Where("LOWER(exterior_color) IN ?", lowerAll(s.Colors))
+	}
+	if len(s.Conditions) > 0 {
+		db = db.Where("condition IN ?", s.Conditions)
+	}
+	if len(s.LotTypes) > 0 {
+		db = db.Where("lot_type IN ?", s.LotTypes)
+	}
+	if len(s.Statuses) > 0 {
+		db = db.Where("status IN ?", s.Statuses)
+	}
+	if len(s.AcquisitionSources) > 0 {
+		db = db.Where("acquisition_source IN ?", s.AcquisitionSources)
+	}
+	if len(s.LocationIDs) > 0 {
+		db = db.Where("location_id IN ?", s.LocationIDs)
+	}
+
+	if s.YearMin != nil {
+		db = db.Where("year >= ?", *s.YearMin)
+	}
+	if s.YearMax != nil {
+		db = db.Where("year <= ?", *s.YearMax)
+	}
+	if s.PriceMin != nil {
+		db = db.Where("asking_price >= ?", *s.PriceMin)
+	}
+	if s.PriceMax != nil {
+		db = db.Where("asking_price <= ?", *s.PriceMax)
+	}
+	if s.MileageMin != nil {
+		db = db.Where("mileage >= ?", *s.MileageMin)
+	}
+	if s.MileageMax != nil {
+		db = db.Where("mileage <= ?", *s.MileageMax)
+	}
+
+	from, to := s.AcquiredBetween(now)
+	if from != nil {
+		db = db.Where(inStockSinceSQL+" >= ?", *from)
+	}
+	if to != nil {
+		db = db.Where(inStockSinceSQL+" <= ?", *to)
+	}
+	return db
+}
+
+func lowerAll(values []string) []string {
+	out := make([]string, len(values))
+	for i, v := range values {
+		out[i] = strings.ToLower(strings.TrimSpace(v))
+	}
+	return out
+}
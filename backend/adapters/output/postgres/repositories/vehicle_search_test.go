@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"torque-dms/core/inventory/domain"
+	"torque-dms/models"
+)
+
+func TestVehicleSearchDaysInStockFallsBackToCreatedAt(t *testing.T) {
+	db := dryRunDB(t)
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	min, max := 30, 90
+
+	criteria := domain.VehicleSearch{DaysInStockMin: &min, DaysInStockMax: &max}
+	stmt := applyVehicleSearch(db.Model(&models.Vehicle{}), criteria, now).Find(&[]models.Vehicle{}).Statement
+
+	sql := stmt.SQL.String()
+	for _, want := range []string{inStockSinceSQL + " >= $1", inStockSinceSQL + " <= $2"} {
+		if !strings.Contains(sql, want) {
+			t.Errorf("SQL = %s\nwant %s", sql, want)
+		}
+	}
+	if strings.Contains(sql, `"acquisition_date" >=`) || strings.Contains(sql, "acquisition_date <=") {
+		t.Errorf("SQL compares raw acquisition_date: %s", sql)
+	}
+}
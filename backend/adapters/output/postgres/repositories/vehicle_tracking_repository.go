@@ -0,0 +1,140 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"torque-dms/core/inventory/domain"
+	"torque-dms/core/inventory/ports/output"
+	sharedDomain "torque-dms/core/shared/domain"
+	"torque-dms/models"
+)
+
+var trackingColumns = queryColumns{
+	"id":          "id",
+	"route_id":    "route_id",
+	"recorded_at": "recorded_at",
+}
+
+var oldestRecordedFirst = []sharedDomain.Sort{{Field: "recorded_at"}}
+
+// Se queda con el primer punto de cada vehicle e intervalo; los intervalos se alinean a la
+// época, así repetir la reducción nunca deja menos de un punto por intervalo
+const downsampleSQL = `DELETE FROM "vehicle_trackings" WHERE "id" IN (
+	SELECT "id" FROM (
+		SELECT "id", ROW_NUMBER() OVER (
+			PARTITION BY "vehicle_id", FLOOR(EXTRACT(EPOCH FROM "recorded_at") / ?)
+			ORDER BY "recorded_at", "id"
+		) AS "rn"
+		FROM "vehicle_trackings"
+		WHERE "recorded_at" < ?
+	) AS "ranked"
+	WHERE "rn" > 1
+)`
+
+// Última posición de cada vehicle con una sola lectura del índice (vehicle_id, recorded_at)
+const latestByVehicleSQL = `SELECT DISTINCT ON ("vehicle_id") * FROM "vehicle_trackings"
+	WHERE "vehicle_id" IN ?
+	ORDER BY "vehicle_id", "recorded_at" DESC`
+
+type vehicleTrackingRepository struct {
+	db *gorm.DB
+}
+
+func NewVehicleTrackingRepository(db *gorm.DB) output.VehicleTrackingRepository {
+	return &vehicleTrackingRepository{db: db}
+}
+
+func (r *vehicleTrackingRepository) SaveBatch(ctx context.Context, points []*domain.TrackingPoint) (int, error) {
+	if len(points) == 0 {
+		return 0, nil
+	}
+
+	modelList := make([]*models.VehicleTracking, len(points))
+	for i, p := range points {
+		modelList[i] = toTrackingModel(p)
+	}
+
+	result := dbFrom(ctx, r.db).Omit(clause.Associations).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "vehicle_id"}, {Name: "recorded_at"}},
+			DoNothing: true,
+		}).
+		Create(&modelList)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	// Con DO NOTHING el RETURNING omite los duplicados y los ids no se corresponden con el
+	// slice, por eso no se copian a los puntos
+	return int(result.RowsAffected), nil
+}
+
+func (r *vehicleTrackingRepository) FindLatest(ctx context.Context, vehicleID uint) (*domain.TrackingPoint, error) {
+	var model models.VehicleTracking
+	result := dbFrom(ctx, r.db).Where("vehicle_id = ?", vehicleID).Order("recorded_at DESC").First(&model)
+	if result.Error != nil {
+		return nil, notFound(result.Error, "position")
+	}
+	return toDomainTrackingPoint(&model), nil
+}
+
+func (r *vehicleTrackingRepository) FindLatestByVehicleIDs(ctx context.Context, vehicleIDs []uint) (map[uint]*domain.TrackingPoint, error) {
+	points := make(map[uint]*domain.TrackingPoint, len(vehicleIDs))
+	if len(vehicleIDs) == 0 {
+		return points, nil
+	}
+
+	var modelList []models.VehicleTracking
+	result := dbFrom(ctx, r.db).Raw(latestByVehicleSQL, vehicleIDs).Scan(&modelList)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	for i := range modelList {
+		points[modelList[i].VehicleID] = toDomainTrackingPoint(&modelList[i])
+	}
+	return points, nil
+}
+
+func (r *vehicleTrackingRepository) FindTrack(ctx context.Context, vehicleID uint, from time.Time, to time.Time, q sharedDomain.Query) (*sharedDomain.Page[*domain.TrackingPoint], error) {
+	db := dbFrom(ctx, r.db).Model(&models.VehicleTracking{}).
+		Where("vehicle_id = ? AND recorded_at >= ? AND recorded_at <= ?", vehicleID, from, to)
+	return findPage(db, q, trackingColumns, oldestRecordedFirst, toDomainTrackingPoint)
+}
+
+func (r *vehicleTrackingRepository) Downsample(ctx context.Context, before time.Time, interval time.Duration) (int, error) {
+	result := dbFrom(ctx, r.db).Exec(downsampleSQL, interval.Seconds(), before)
+	return int(result.RowsAffected), result.Error
+}
+
+func (r *vehicleTrackingRepository) DeleteBefore(ctx context.Context, before time.Time) (int, error) {
+	result := dbFrom(ctx, r.db).Where("recorded_at < ?", before).Delete(&models.VehicleTracking{})
+	return int(result.RowsAffected), result.Error
+}
+
+// Mappers
+
+func toTrackingModel(p *domain.TrackingPoint) *models.VehicleTracking {
+	return &models.VehicleTracking{
+		ID:         p.ID,
+		VehicleID:  p.VehicleID,
+		Latitude:   p.Latitude,
+		Longitude:  p.Longitude,
+		RouteID:    p.RouteID,
+		RecordedAt: p.RecordedAt,
+	}
+}
+
+func toDomainTrackingPoint(m *models.VehicleTracking) *domain.TrackingPoint {
+	return &domain.TrackingPoint{
+		ID:         m.ID,
+		VehicleID:  m.VehicleID,
+		Latitude:   m.Latitude,
+		Longitude:  m.Longitude,
+		RouteID:    m.RouteID,
+		RecordedAt: m.RecordedAt,
+	}
+}
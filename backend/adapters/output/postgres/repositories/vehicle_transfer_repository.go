@@ -0,0 +1,136 @@
+package repositories
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"torque-dms/core/inventory/domain"
+	"torque-dms/core/inventory/ports/output"
+	"torque-dms/models"
+)
+
+type vehicleTransferRepository struct {
+	db *gorm.DB
+}
+
+func NewVehicleTransferRepository(db *gorm.DB) output.VehicleTransferRepository {
+	return &vehicleTransferRepository{db: db}
+}
+
+func (r *vehicleTransferRepository) Save(ctx context.Context, transfer *domain.VehicleTransfer) error {
+	model := toTransferModel(transfer)
+	result := dbFrom(ctx, r.db).Create(model)
+	if result.Error != nil {
+		return result.Error
+	}
+	transfer.ID = model.ID
+	return nil
+}
+
+func (r *vehicleTransferRepository) Update(ctx context.Context, transfer *domain.VehicleTransfer) error {
+	model := toTransferModel(transfer)
+	return dbFrom(ctx, r.db).Omit(clause.Associations).Save(model).Error
+}
+
+func (r *vehicleTransferRepository) FindPendingByVehicleID(ctx context.Context, vehicleID uint) (*domain.VehicleTransfer, error) {
+	var model models.VehicleTransfer
+	result := dbFrom(ctx, r.db).Where("vehicle_id = ? AND status = ?", vehicleID, models.TransferPending).First(&model)
+	if result.Error != nil {
+		return nil, notFound(result.Error, "transfer")
+	}
+	return toDomainTransfer(&model), nil
+}
+
+func (r *vehicleTransferRepository) FindPendingByVehicleIDs(ctx context.Context, vehicleIDs []uint) ([]*domain.VehicleTransfer, error) {
+	if len(vehicleIDs) == 0 {
+		return nil, nil
+	}
+	return r.findPending(dbFrom(ctx, r.db).Where("vehicle_id IN ?", vehicleIDs))
+}
+
+func (r *vehicleTransferRepository) FindPendingByDestination(ctx context.Context, locationID uint) ([]*domain.VehicleTransfer, error) {
+	return r.findPending(dbFrom(ctx, r.db).Where("to_location_id = ?", locationID))
+}
+
+func (r *vehicleTransferRepository) ExistsPendingByRoute(ctx context.Context, routeID uint) (bool, error) {
+	var count int64
+	result := dbFrom(ctx, r.db).Model(&models.VehicleTransfer{}).
+		Where("route_id = ? AND status = ?", routeID, models.TransferPending).
+		Count(&count)
+	return count > 0, result.Error
+}
+
+func (r *vehicleTransferRepository) findPending(db *gorm.DB) ([]*domain.VehicleTransfer, error) {
+	var modelList []models.VehicleTransfer
+	result := db.Where("status = ?", models.TransferPending).Order("created_at ASC").Find(&modelList)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	transfers := make([]*domain.VehicleTransfer, len(modelList))
+	for i, model := range modelList {
+		transfers[i] = toDomainTransfer(&model)
+	}
+	return transfers, nil
+}
+
+// CountPendingByDestination - vehicles en camino por ubicación destino; ya tienen su plaza reservada
+func (r *vehicleTransferRepository) CountPendingByDestination(ctx context.Context, locationIDs []uint) (map[uint]int64, error) {
+	var rows []struct {
+		ToLocationID uint
+		Count        int64
+	}
+	db := dbFrom(ctx, r.db).Model(&models.VehicleTransfer{}).Where("status = ?", models.TransferPending)
+	if locationIDs != nil {
+		db = db.Where("to_location_id IN ?", locationIDs)
+	}
+	result := db.Select("to_location_id, COUNT(*) AS count").Group("to_location_id").Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	counts := make(map[uint]int64, len(rows))
+	for _, row := range rows {
+		counts[row.ToLocationID] = row.Count
+	}
+	return counts, nil
+}
+
+// Mappers
+
+func toTransferModel(t *domain.VehicleTransfer) *models.VehicleTransfer {
+	return &models.VehicleTransfer{
+		ID:                t.ID,
+		VehicleID:         t.VehicleID,
+		FromLocationID:    t.FromLocationID,
+		TransitLocationID: t.TransitLocationID,
+		ToLocationID:      t.ToLocationID,
+		RouteID:           t.RouteID,
+		PreviousStatus:    models.VehicleStatus(t.PreviousStatus),
+		Status:            models.TransferStatus(t.Status),
+		Reason:            t.Reason,
+		RequestedBy:       t.RequestedBy,
+		ReceivedBy:        t.ReceivedBy,
+		CreatedAt:         t.CreatedAt,
+		CompletedAt:       t.CompletedAt,
+	}
+}
+
+func toDomainTransfer(m *models.VehicleTransfer) *domain.VehicleTransfer {
+	return &domain.VehicleTransfer{
+		ID:                m.ID,
+		VehicleID:         m.VehicleID,
+		FromLocationID:    m.FromLocationID,
+		TransitLocationID: m.TransitLocationID,
+		ToLocationID:      m.ToLocationID,
+		RouteID:           m.RouteID,
+		PreviousStatus:    domain.VehicleStatus(m.PreviousStatus),
+		Status:            domain.TransferStatus(m.Status),
+		Reason:            m.Reason,
+		RequestedBy:       m.RequestedBy,
+		ReceivedBy:        m.ReceivedBy,
+		CreatedAt:         m.CreatedAt,
+		CompletedAt:       m.CompletedAt,
+	}
+}
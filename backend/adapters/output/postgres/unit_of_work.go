@@ -0,0 +1,41 @@
+package postgres
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"torque-dms/core/shared/ports/output"
+)
+
+type txKey struct{}
+
+// WithTx - deja la transacción en el context para que los repositories la tomen
+func WithTx(ctx context.Context, tx *gorm.DB) context.Context {
+	return context.WithValue(ctx, txKey{}, tx)
+}
+
+// TxFromContext - transacción en curso o nil si no hay ninguna
+func TxFromContext(ctx context.Context) *gorm.DB {
+	tx, _ := ctx.Value(txKey{}).(*gorm.DB)
+	return tx
+}
+
+type unitOfWork struct {
+	db *gorm.DB
+}
+
+func NewUnitOfWork(db *gorm.DB) output.UnitOfWork {
+	return &unitOfWork{db: db}
+}
+
+func (u *unitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	// Si ya hay una transacción abierta, fn se suma a ella
+	if TxFromContext(ctx) != nil {
+		return fn(ctx)
+	}
+
+	// La transacción hereda el ctx: si el request se cancela, Postgres aborta y se hace rollback
+	return u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(WithTx(ctx, tx))
+	})
+}
@@ -0,0 +1,44 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLocalStoragePutDelete(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewLocalStorage(dir, "/files/")
+	if err != nil {
+		t.Fatalf("NewLocalStorage() error = %v", err)
+	}
+	ctx := context.Background()
+
+	url, err := s.Put(ctx, "models/1/file.glb", "model/gltf-binary", strings.NewReader("glTF"))
+	if err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if url != "/files/models/1/file.glb" {
+		t.Errorf("Put() url = %q", url)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "models", "1", "file.glb"))
+	if err != nil || string(data) != "glTF" {
+		t.Fatalf("stored file = %q, %v", data, err)
+	}
+
+	if _, err := s.Put(ctx, "../escape.glb", "model/gltf-binary", strings.NewReader("x")); err == nil {
+		t.Error("Put() expected error for key outside the directory")
+	}
+
+	if err := s.Delete(ctx, "https://cdn.example.com/model.glb"); err != nil {
+		t.Errorf("Delete() external url error = %v", err)
+	}
+	if err := s.Delete(ctx, url); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "models", "1", "file.glb")); !os.IsNotExist(err) {
+		t.Errorf("file still exists after Delete(): %v", err)
+	}
+}
@@ -0,0 +1,128 @@
+package vindata
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+	"torque-dms/core/inventory/domain"
+	"torque-dms/core/inventory/ports/output"
+)
+
+//go:embed vin_data.yml
+var embeddedData []byte
+
+type manufacturer struct {
+	Manufacturer string `yaml:"manufacturer"`
+	Make         string `yaml:"make"`
+}
+
+// vehicleModel - VDS es un prefijo de las posiciones 4-8; gana el prefijo más largo
+// dentro del rango de años
+type vehicleModel struct {
+	WMI      string `yaml:"wmi"`
+	VDS      string `yaml:"vds"`
+	Model    string `yaml:"model"`
+	BodyType string `yaml:"body_type"`
+	YearFrom int    `yaml:"year_from"`
+	YearTo   int    `yaml:"year_to"`
+}
+
+type dataset struct {
+	Manufacturers map[string]manufacturer      `yaml:"manufacturers"`
+	Models        []vehicleModel               `yaml:"models"`
+	Plants        map[string]map[string]string `yaml:"plants"`
+}
+
+type decoder struct {
+	data dataset
+}
+
+// NewDecoder - carga el dataset embebido; si path no está vacío, su contenido se añade
+// encima (mismos WMI y plantas se sobrescriben) para actualizarlo sin recompilar
+func NewDecoder(path string) (output.VINDecoder, error) {
+	var data dataset
+	if err := yaml.Unmarshal(embeddedData, &data); err != nil {
+		return nil, fmt.Errorf("failed to parse embedded VIN data: %w", err)
+	}
+
+	if path != "" {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read VIN data: %w", err)
+		}
+		var extra dataset
+		if err := yaml.Unmarshal(raw, &extra); err != nil {
+			return nil, fmt.Errorf("failed to parse VIN data: %w", err)
+		}
+		data.merge(extra)
+	}
+
+	return &decoder{data: data}, nil
+}
+
+func (d *decoder) Decode(ctx context.Context, vin string) (*domain.VINDecode, error) {
+	decoded, err := domain.DecodeVIN(vin, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	m, ok := d.data.Manufacturers[decoded.WMI]
+	if !ok {
+		return decoded, nil
+	}
+	decoded.Manufacturer = m.Manufacturer
+	decoded.Make = m.Make
+	decoded.Plant = d.data.Plants[m.Make][decoded.PlantCode]
+
+	if model := d.data.findModel(decoded); model != nil {
+		decoded.Model = model.Model
+		decoded.BodyType = model.BodyType
+	}
+	return decoded, nil
+}
+
+func (data *dataset) findModel(decoded *domain.VINDecode) *vehicleModel {
+	var best *vehicleModel
+	for i := range data.Models {
+		m := &data.Models[i]
+		if m.WMI != decoded.WMI || !strings.HasPrefix(decoded.VDS, m.VDS) {
+			continue
+		}
+		if (m.YearFrom != 0 && decoded.ModelYear < m.YearFrom) || (m.YearTo != 0 && decoded.ModelYear > m.YearTo) {
+			continue
+		}
+		if best == nil || len(m.VDS) > len(best.VDS) {
+			best = m
+		}
+	}
+	return best
+}
+
+func (data *dataset) merge(extra dataset) {
+	if data.Manufacturers == nil {
+		data.Manufacturers = map[string]manufacturer{}
+	}
+	for wmi, m := range extra.Manufacturers {
+		data.Manufacturers[wmi] = m
+	}
+
+	// Los modelos nuevos van delante para que ganen a igual longitud de prefijo
+	data.Models = append(extra.Models, data.Models...)
+
+	if data.Plants == nil {
+		data.Plants = map[string]map[string]string{}
+	}
+	for make, plants := range extra.Plants {
+		if data.Plants[make] == nil {
+			data.Plants[make] = map[string]string{}
+		}
+		for code, plant := range plants {
+			data.Plants[make][code] = plant
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package vindata
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDecodeWithEmbeddedData(t *testing.T) {
+	d, err := NewDecoder("")
+	if err != nil {
+		t.Fatalf("NewDecoder() error = %v", err)
+	}
+
+	decoded, err := d.Decode(context.Background(), "1HGCM82633A004352")
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if decoded.Make != "Honda" || decoded.Model != "Accord" || decoded.BodyType != "sedan" || decoded.Plant != "Marysville, Ohio" {
+		t.Errorf("Decode() = %+v", decoded)
+	}
+}
+
+func TestDecodeUnknownManufacturer(t *testing.T) {
+	d, err := NewDecoder("")
+	if err != nil {
+		t.Fatalf("NewDecoder() error = %v", err)
+	}
+
+	decoded, err := d.Decode(context.Background(), "11111111111111111")
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if decoded.Make != "" || decoded.ModelYear == 0 {
+		t.Errorf("Decode() = %+v, want structural decode only", decoded)
+	}
+}
+
+func TestDecodeWithOverrideFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vin.yml")
+	data := `
+manufacturers:
+  "111": { manufacturer: Test Motors, make: Testa }
+models:
+  - { wmi: "111", vds: "111", model: Roadster, body_type: convertible }
+`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := NewDecoder(path)
+	if err != nil {
+		t.Fatalf("NewDecoder() error = %v", err)
+	}
+
+	decoded, err := d.Decode(context.Background(), "11111111111111111")
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if decoded.Make != "Testa" || decoded.Model != "Roadster" {
+		t.Errorf("Decode() = %+v, want override data", decoded)
+	}
+
+	// El dataset embebido sigue disponible
+	decoded, err = d.Decode(context.Background(), "1HGCM82633A004352")
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if decoded.Make != "Honda" {
+		t.Errorf("Decode() make = %s, want Honda", decoded.Make)
+	}
+}
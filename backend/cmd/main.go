@@ -1,20 +1,32 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"time"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 
 	"torque-dms/adapters/input/http"
+	"torque-dms/adapters/input/scheduler"
+	"torque-dms/adapters/output/geodata"
+	torquePostgres "torque-dms/adapters/output/postgres"
+	"torque-dms/adapters/output/postgres/migrations"
 	"torque-dms/adapters/output/postgres/repositories"
+	"torque-dms/adapters/output/storage"
+	"torque-dms/adapters/output/vindata"
+	auditServices "torque-dms/core/audit/services"
 	identityServices "torque-dms/core/identity/services"
+	inventoryDomain "torque-dms/core/inventory/domain"
 	inventoryServices "torque-dms/core/inventory/services"
+	privacyDomain "torque-dms/core/privacy/domain"
+	privacyServices "torque-dms/core/privacy/services"
 	salesServices "torque-dms/core/sales/services"
 	sharedDomain "torque-dms/core/shared/domain"
-	"torque-dms/models"
 )
 
 func main() {
@@ -26,6 +38,51 @@ func main() {
 	dbPort := getEnv("DB_PORT", "5432")
 	webPort := getEnv("WEB_PORT", "8080")
 	jwtSecret := getEnv("JWT_SECRET", "your-super-secret-key-change-in-production")
+	retentionYears, err := strconv.Atoi(getEnv("LEGAL_HOLD_RETENTION_YEARS", "7"))
+	if err != nil {
+		log.Fatal("Invalid LEGAL_HOLD_RETENTION_YEARS:", err)
+	}
+	requestTimeout, err := time.ParseDuration(getEnv("REQUEST_TIMEOUT", "30s"))
+	if err != nil {
+		log.Fatal("Invalid REQUEST_TIMEOUT:", err)
+	}
+	trashRetentionDays, err := strconv.Atoi(getEnv("TRASH_RETENTION_DAYS", "30"))
+	if err != nil {
+		log.Fatal("Invalid TRASH_RETENTION_DAYS:", err)
+	}
+	trashPurgeInterval, err := time.ParseDuration(getEnv("TRASH_PURGE_INTERVAL", "24h"))
+	if err != nil {
+		log.Fatal("Invalid TRASH_PURGE_INTERVAL:", err)
+	}
+	trackingDownsampleAfterDays, err := strconv.Atoi(getEnv("TRACKING_DOWNSAMPLE_AFTER_DAYS", "30"))
+	if err != nil {
+		log.Fatal("Invalid TRACKING_DOWNSAMPLE_AFTER_DAYS:", err)
+	}
+	trackingDownsampleMinutes, err := strconv.Atoi(getEnv("TRACKING_DOWNSAMPLE_MINUTES", "5"))
+	if err != nil {
+		log.Fatal("Invalid TRACKING_DOWNSAMPLE_MINUTES:", err)
+	}
+	trackingRetentionDays, err := strconv.Atoi(getEnv("TRACKING_RETENTION_DAYS", "365"))
+	if err != nil {
+		log.Fatal("Invalid TRACKING_RETENTION_DAYS:", err)
+	}
+	trackingCompactInterval, err := time.ParseDuration(getEnv("TRACKING_COMPACT_INTERVAL", "6h"))
+	if err != nil {
+		log.Fatal("Invalid TRACKING_COMPACT_INTERVAL:", err)
+	}
+	offRouteKM, err := strconv.ParseFloat(getEnv("ROUTE_DEVIATION_KM", "1"), 64)
+	if err != nil {
+		log.Fatal("Invalid ROUTE_DEVIATION_KM:", err)
+	}
+	repricingInterval, err := time.ParseDuration(getEnv("REPRICING_INTERVAL", "24h"))
+	if err != nil {
+		log.Fatal("Invalid REPRICING_INTERVAL:", err)
+	}
+	reconApprovalThreshold, err := strconv.ParseFloat(getEnv("RECON_APPROVAL_THRESHOLD", "500"), 64)
+	if err != nil {
+		log.Fatal("Invalid RECON_APPROVAL_THRESHOLD:", err)
+	}
+	reconRequireClosedTasks := getEnv("RECON_REQUIRE_CLOSED_TASKS", "false") == "true"
 
 	// Construir DATABASE_URL
 	databaseURL := fmt.Sprintf(
@@ -33,6 +90,14 @@ func main() {
 		dbHost, dbUser, dbPassword, dbName, dbPort,
 	)
 
+	// Subcomando: go run ./cmd migrate up|down|status|create
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(os.Args[2:], databaseURL); err != nil {
+			log.Fatal("Migration failed: ", err)
+		}
+		return
+	}
+
 	// Cargar reglas de validación
 	if err := sharedDomain.LoadValidationRules("settings/validation_rules.yml"); err != nil {
 		log.Fatal("Failed to load validation rules:", err)
@@ -40,17 +105,23 @@ func main() {
 	log.Println("Validation rules loaded")
 
 	// Conectar a la base de datos
-	db, err := gorm.Open(postgres.Open(databaseURL), &gorm.Config{})
+	db, err := gorm.Open(postgres.Open(databaseURL), &gorm.Config{TranslateError: true})
 	if err != nil {
 		log.Fatal("Failed to connect to database:", err)
 	}
+	if err := torquePostgres.RegisterErrorTranslation(db); err != nil {
+		log.Fatal("Failed to register error translation:", err)
+	}
 	log.Println("Connected to database")
 
-	// Auto-migrar modelos
-	if err := autoMigrate(db); err != nil {
-		log.Fatal("Failed to migrate database:", err)
+	// Verificar migraciones; el esquema ya no se toca al arrancar salvo que se pida
+	if err := checkMigrations(db, getEnv("MIGRATE_ON_START", "false") == "true"); err != nil {
+		log.Fatal("Failed to migrate database: ", err)
 	}
-	log.Println("Database migrated")
+	log.Println("Database schema up to date")
+
+	// Crear repositories - Audit
+	auditRepo := repositories.NewAuditRepository(db)
 
 	// Crear repositories - Identity
 	entityRepo := repositories.NewEntityRepository(db)
@@ -63,6 +134,21 @@ func main() {
 	vehicleRepo := repositories.NewVehicleRepository(db)
 	photoRepo := repositories.NewVehiclePhotoRepository(db)
 	locationRepo := repositories.NewLocationRepository(db)
+	locationHistoryRepo := repositories.NewVehicleLocationHistoryRepository(db)
+	statusHistoryRepo := repositories.NewVehicleStatusHistoryRepository(db)
+	transferRepo := repositories.NewVehicleTransferRepository(db)
+	trackingRepo := repositories.NewVehicleTrackingRepository(db)
+	routeRepo := repositories.NewRouteRepository(db)
+	geofenceEventRepo := repositories.NewGeofenceEventRepository(db)
+	model3DRepo := repositories.NewVehicleModel3DRepository(db)
+	modelZoneRepo := repositories.NewVehicleModelZoneRepository(db)
+	zoneMarkRepo := repositories.NewVehicleZoneMarkRepository(db)
+	inspectionTemplateRepo := repositories.NewInspectionTemplateRepository(db)
+	inspectionRepo := repositories.NewInspectionRepository(db)
+	reconRepo := repositories.NewReconOrderRepository(db)
+	costEntryRepo := repositories.NewCostEntryRepository(db)
+	repricingRuleRepo := repositories.NewRepricingRuleRepository(db)
+	priceChangeRepo := repositories.NewPriceChangeRepository(db)
 
 	// Crear repositories - Sales
 	leadRepo := repositories.NewLeadRepository(db)
@@ -74,14 +160,65 @@ func main() {
 	leadStepRepo := repositories.NewLeadStepRepository(db)
 	leadStepProgressRepo := repositories.NewLeadStepProgressRepository(db)
 
+	// Decodificador de VIN offline; VIN_DATA_PATH amplía el dataset embebido
+	vinDecoder, err := vindata.NewDecoder(getEnv("VIN_DATA_PATH", ""))
+	if err != nil {
+		log.Fatal("Failed to load VIN data:", err)
+	}
+
+	// Geocoder offline de códigos postales; POSTAL_CODES_PATH amplía el dataset embebido
+	geocoder, err := geodata.NewGeocoder(getEnv("POSTAL_CODES_PATH", ""))
+	if err != nil {
+		log.Fatal("Failed to load postal codes:", err)
+	}
+
+	// Búsquedas por distancia: haversine en Go por defecto, PostGIS si está instalado
+	locationGeoRepo := repositories.NewLocationGeoRepository(db)
+	if getEnv("GEO_BACKEND", "haversine") == "postgis" {
+		locationGeoRepo = repositories.NewLocationPostGISRepository(db)
+	}
+
+	// Archivos subidos (modelos 3D); se sirven bajo STORAGE_BASE_URL
+	storagePath := getEnv("STORAGE_PATH", "./uploads")
+	storageBaseURL := getEnv("STORAGE_BASE_URL", "/files")
+	fileStorage, err := storage.NewLocalStorage(storagePath, storageBaseURL)
+	if err != nil {
+		log.Fatal("Failed to initialize file storage:", err)
+	}
+
+	// Unit of work para operaciones que tocan varios repositories
+	uow := torquePostgres.NewUnitOfWork(db)
+
+	// Crear services - Audit
+	auditService := auditServices.NewAuditService(auditRepo)
+
 	// Crear services - Identity
-	entityService := identityServices.NewEntityService(entityRepo, phoneRepo)
-	authService := identityServices.NewAuthService(entityRepo, userRepo, phoneRepo, jwtSecret)
-	permissionService := identityServices.NewPermissionService(roleRepo, resourceRepo)
+	entityService := identityServices.NewEntityService(entityRepo, phoneRepo, auditService, uow)
+	authService := identityServices.NewAuthService(entityRepo, userRepo, phoneRepo, auditService, uow, jwtSecret)
+	permissionService := identityServices.NewPermissionService(roleRepo, resourceRepo, auditService, uow)
 
 	// Crear services - Inventory
-	vehicleService := inventoryServices.NewVehicleService(vehicleRepo, photoRepo, locationRepo)
-	locationService := inventoryServices.NewLocationService(locationRepo, vehicleRepo)
+	geoService := inventoryServices.NewGeoService(locationRepo, locationGeoRepo, geocoder)
+	reconPolicy, err := inventoryDomain.NewReconPolicy(reconApprovalThreshold, reconRequireClosedTasks)
+	if err != nil {
+		log.Fatal("Invalid recon policy:", err)
+	}
+	vehicleService := inventoryServices.NewVehicleService(vehicleRepo, photoRepo, locationRepo, locationHistoryRepo, statusHistoryRepo, priceChangeRepo, transferRepo, routeRepo, model3DRepo, inspectionRepo, reconRepo, leadRepo, vinDecoder, reconPolicy, geoService, auditService, uow)
+	locationService := inventoryServices.NewLocationService(locationRepo, vehicleRepo, auditService, uow)
+	occupancyService := inventoryServices.NewOccupancyService(locationRepo, vehicleRepo, transferRepo)
+	trackingRetention, err := inventoryDomain.NewTrackingRetention(trackingDownsampleAfterDays, trackingDownsampleMinutes, trackingRetentionDays)
+	if err != nil {
+		log.Fatal("Invalid tracking retention:", err)
+	}
+	geofenceService := inventoryServices.NewGeofenceService(vehicleService, vehicleRepo, locationRepo, locationHistoryRepo, transferRepo, geofenceEventRepo)
+	trackingService := inventoryServices.NewTrackingService(vehicleRepo, trackingRepo, routeRepo, transferRepo, trackingRetention, geofenceService, auditService, uow)
+	routeService := inventoryServices.NewRouteService(routeRepo, locationRepo, transferRepo, trackingRepo, offRouteKM, auditService, uow)
+	model3DService := inventoryServices.NewModel3DService(model3DRepo, modelZoneRepo, vehicleRepo, fileStorage, auditService, uow)
+	damageService := inventoryServices.NewDamageService(zoneMarkRepo, vehicleRepo, modelZoneRepo, photoRepo, auditService, uow)
+	inspectionService := inventoryServices.NewInspectionService(inspectionTemplateRepo, inspectionRepo, vehicleRepo, photoRepo, auditService, uow)
+	reconService := inventoryServices.NewReconService(reconRepo, vehicleRepo, reconPolicy, auditService, uow)
+	costService := inventoryServices.NewCostService(costEntryRepo, vehicleRepo, reconRepo, auditService, uow)
+	pricingService := inventoryServices.NewPricingService(repricingRuleRepo, priceChangeRepo, vehicleRepo, auditService, uow)
 
 	// Crear services - Sales
 	leadService := salesServices.NewLeadService(
@@ -90,13 +227,66 @@ func main() {
 		leadAssignmentRepo,
 		leadNoteRepo,
 		leadActivityRepo,
+		auditService,
+		uow,
 	)
 	stepService := salesServices.NewStepService(
 		leadStepPresetRepo,
 		leadStepRepo,
 		leadStepProgressRepo,
 		leadRepo,
+		auditService,
+		uow,
+	)
+
+	// Crear services - Privacy
+	retentionPolicy, err := privacyDomain.NewRetentionPolicy(retentionYears)
+	if err != nil {
+		log.Fatal("Invalid retention policy:", err)
+	}
+	privacyService := privacyServices.NewPrivacyService(
+		entityRepo,
+		phoneRepo,
+		userRepo,
+		leadRepo,
+		leadNoteRepo,
+		leadActivityRepo,
+		leadAssignmentRepo,
+		leadStepProgressRepo,
+		vehicleRepo,
+		retentionPolicy,
+		auditService,
+		uow,
+	)
+
+	// Vaciar la papelera: leads primero, las entities al final porque otros las referencian
+	purgeJob := scheduler.NewPurgeJob(
+		time.Duration(trashRetentionDays)*24*time.Hour,
+		trashPurgeInterval,
+		scheduler.PurgeTarget{Name: "leads", Purge: leadService.PurgeDeleted},
+		scheduler.PurgeTarget{Name: "presets", Purge: stepService.PurgeDeletedPresets},
+		scheduler.PurgeTarget{Name: "vehicles", Purge: vehicleService.PurgeDeleted},
+		scheduler.PurgeTarget{Name: "entities", Purge: entityService.PurgeDeleted},
+	)
+	go purgeJob.Run(context.Background())
+
+	// Telemetría: reducir y borrar puntos antiguos; el corte lo calcula la política de retención
+	trackingJob := scheduler.NewPeriodicJob(
+		"Tracking compaction",
+		"Compacted %d tracking points",
+		trackingCompactInterval,
+		trackingService.Compact,
+	)
+	go trackingJob.Run(context.Background())
+
+	// Rebajas automáticas: las reglas activas se aplican a fecha de cada pasada
+	repricingJob := scheduler.NewPeriodicJob(
+		"Repricing",
+		"Repriced %d vehicles",
+		repricingInterval,
+		pricingService.ApplyRepricing,
 	)
+	go repricingJob.Run(context.Background())
 
 	// Crear router
 	router := http.NewRouter(
@@ -105,11 +295,27 @@ func main() {
 		permissionService,
 		vehicleService,
 		locationService,
+		occupancyService,
+		geoService,
+		trackingService,
+		routeService,
+		geofenceService,
+		model3DService,
+		damageService,
+		inspectionService,
+		reconService,
+		costService,
+		pricingService,
 		leadService,
 		stepService,
+		privacyService,
+		auditService,
 		jwtSecret,
+		requestTimeout,
 	)
 
+	router.ServeFiles(storageBaseURL, storagePath)
+
 	// Iniciar servidor
 	log.Printf("Server starting on port %s", webPort)
 	if err := router.Run(":" + webPort); err != nil {
@@ -124,40 +330,33 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
-func autoMigrate(db *gorm.DB) error {
-	return db.AutoMigrate(
-		// Geo
-		&models.Country{},
-		&models.Location{},
-		&models.Route{},
-
-		// Users
-		&models.Entity{},
-		&models.UserAccount{},
-		&models.EntityPhone{},
-		&models.Resource{},
-		&models.Role{},
-		&models.RoleResource{},
-		&models.EntityResource{},
-		&models.EntityRole{},
-
-		// Inventory
-		&models.VehicleModel3D{},
-		&models.VehicleModelZone{},
-		&models.Vehicle{},
-		&models.VehicleLocationHistory{},
-		&models.VehicleTracking{},
-		&models.VehiclePhoto{},
-		&models.VehicleZoneMark{},
-
-		// Leads
-		&models.LeadSource{},
-		&models.LeadStepPreset{},
-		&models.LeadStep{},
-		&models.Lead{},
-		&models.LeadStepProgress{},
-		&models.LeadAssignment{},
-		&models.LeadNote{},
-		&models.LeadActivity{},
-	)
-}
\ No newline at end of file
+func checkMigrations(db *gorm.DB, migrateOnStart bool) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+
+	migrator, err := migrations.NewMigrator(sqlDB)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	if migrateOnStart {
+		applied, err := migrator.Up(ctx)
+		for _, m := range applied {
+			log.Printf("Applied migration %04d_%s", m.Version, m.Name)
+		}
+		return err
+	}
+
+	pending, err := migrator.Pending(ctx)
+	if err != nil {
+		return err
+	}
+	if pending > 0 {
+		return fmt.Errorf("%d pending migrations, run `migrate up` first", pending)
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+package domain
+
+import (
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+type Action string
+
+const (
+	ActionCreate   Action = "create"
+	ActionUpdate   Action = "update"
+	ActionDelete   Action = "delete"
+	ActionAssign   Action = "assign"
+	ActionUnassign Action = "unassign"
+	ActionErase    Action = "erase"
+	ActionRestore  Action = "restore"
+	ActionPurge    Action = "purge"
+)
+
+// Campos que nunca se guardan en claro en el log
+var redactedFields = map[string]bool{
+	"PasswordHash": true,
+}
+
+// PersonalData - structs con datos personales. Esos campos se registran como cambiados pero
+// nunca con su valor: el log es append-only y un borrado GDPR no podría limpiarlos después
+type PersonalData interface {
+	PersonalFields() []string
+}
+
+// Campos que cambian en cada escritura y no aportan nada al diff
+var ignoredFields = map[string]bool{
+	"ModifiedAt": true,
+}
+
+const redactedValue = "[redacted]"
+
+type Change struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// AuditEntry - registro inmutable de una operación que modificó datos
+type AuditEntry struct {
+	ID            uint
+	ActorID       *uint
+	Action        Action
+	AggregateType string
+	AggregateID   uint
+	Changes       map[string]Change
+	IP            string
+	RequestID     string
+	CreatedAt     time.Time
+}
+
+func NewAuditEntry(actor sharedDomain.Actor, action Action, aggregateType string, aggregateID uint, before interface{}, after interface{}) (*AuditEntry, error) {
+	if action == "" {
+		return nil, sharedDomain.Invalid("action", "action is required")
+	}
+	if aggregateType == "" {
+		return nil, sharedDomain.Invalid("aggregate_type", "aggregate type is required")
+	}
+
+	entry := &AuditEntry{
+		Action:        action,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		Changes:       Diff(before, after),
+		IP:            actor.IP,
+		RequestID:     actor.RequestID,
+		CreatedAt:     time.Now(),
+	}
+	if actor.EntityID != 0 {
+		actorID := actor.EntityID
+		entry.ActorID = &actorID
+	}
+
+	return entry, nil
+}
+
+// Diff - compara los campos exportados de dos structs (o punteros a struct).
+// Un lado nil representa un create (before) o un delete (after).
+func Diff(before interface{}, after interface{}) map[string]Change {
+	changes := map[string]Change{}
+
+	b := structValue(before)
+	a := structValue(after)
+	if !b.IsValid() && !a.IsValid() {
+		return changes
+	}
+
+	t := a.Type()
+	if !a.IsValid() {
+		t = b.Type()
+	}
+	personal := personalFields(before, after)
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || ignoredFields[field.Name] {
+			continue
+		}
+
+		var from, to interface{}
+		if b.IsValid() {
+			from = plainValue(b.Field(i))
+		}
+		if a.IsValid() {
+			to = plainValue(a.Field(i))
+		}
+
+		if reflect.DeepEqual(from, to) {
+			continue
+		}
+		if !b.IsValid() && isZero(to) {
+			continue
+		}
+
+		if redactedFields[field.Name] || personal[field.Name] {
+			from, to = redactedValue, redactedValue
+		}
+
+		changes[snakeCase(field.Name)] = Change{From: from, To: to}
+	}
+
+	return changes
+}
+
+// Helpers
+
+// personalFields - los services pasan el before por valor; una copia direccionable deja ver
+// también los métodos con receiver puntero
+func personalFields(values ...interface{}) map[string]bool {
+	fields := map[string]bool{}
+	for _, v := range values {
+		rv := structValue(v)
+		if !rv.IsValid() {
+			continue
+		}
+		ptr := reflect.New(rv.Type())
+		ptr.Elem().Set(rv)
+		if pd, ok := ptr.Interface().(PersonalData); ok {
+			for _, f := range pd.PersonalFields() {
+				fields[f] = true
+			}
+		}
+	}
+	return fields
+}
+
+func structValue(v interface{}) reflect.Value {
+	if v == nil {
+		return reflect.Value{}
+	}
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return reflect.Value{}
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+	return rv
+}
+
+func plainValue(v reflect.Value) interface{} {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if t, ok := v.Interface().(time.Time); ok {
+		// Sin reloj monotónico para que DeepEqual compare solo el instante
+		return t.Round(0).UTC()
+	}
+	return v.Interface()
+}
+
+func isZero(v interface{}) bool {
+	return v == nil || reflect.ValueOf(v).IsZero()
+}
+
+func snakeCase(name string) string {
+	var sb strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			prevLower := i > 0 && unicode.IsLower(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if i > 0 && (prevLower || (nextLower && unicode.IsUpper(runes[i-1]))) {
+				sb.WriteRune('_')
+			}
+			sb.WriteRune(unicode.ToLower(r))
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
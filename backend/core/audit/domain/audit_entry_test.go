@@ -0,0 +1,140 @@
+package domain
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	identityDomain "torque-dms/core/identity/domain"
+	salesDomain "torque-dms/core/sales/domain"
+)
+
+type auditSample struct {
+	ID           uint
+	AskingPrice  float64
+	Email        string
+	PasswordHash string
+	LocationID   *uint
+	ModifiedAt   time.Time
+}
+
+func TestDiff(t *testing.T) {
+	locationA := uint(1)
+	locationB := uint(2)
+
+	tests := []struct {
+		name   string
+		before interface{}
+		after  interface{}
+		want   map[string]Change
+	}{
+		{
+			"price drop",
+			&auditSample{ID: 7, AskingPrice: 25000},
+			&auditSample{ID: 7, AskingPrice: 22000, ModifiedAt: time.Now()},
+			map[string]Change{"asking_price": {From: 25000.0, To: 22000.0}},
+		},
+		{
+			"pointer fields compare by value",
+			auditSample{LocationID: &locationA},
+			auditSample{LocationID: &locationB},
+			map[string]Change{"location_id": {From: uint(1), To: uint(2)}},
+		},
+		{
+			"password hash is redacted",
+			auditSample{PasswordHash: "old"},
+			auditSample{PasswordHash: "new"},
+			map[string]Change{"password_hash": {From: redactedValue, To: redactedValue}},
+		},
+		{
+			"create skips zero values",
+			nil,
+			&auditSample{ID: 3, Email: "a@b.com"},
+			map[string]Change{"id": {From: nil, To: uint(3)}, "email": {From: nil, To: "a@b.com"}},
+		},
+		{
+			"no changes",
+			auditSample{Email: "a@b.com"},
+			auditSample{Email: "a@b.com"},
+			map[string]Change{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Diff(tt.before, tt.after)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Diff() = %v, want %v", got, tt.want)
+			}
+			for field, want := range tt.want {
+				if got[field] != want {
+					t.Errorf("Diff()[%q] = %v, want %v", field, got[field], want)
+				}
+			}
+		})
+	}
+}
+
+func TestDiffKeepsPersonalDataOutOfLog(t *testing.T) {
+	entity, err := identityDomain.NewEntity(identityDomain.EntityTypePerson, "", "jane.doe@example.com")
+	if err != nil {
+		t.Fatalf("NewEntity() error = %v", err)
+	}
+	entity.ID = 4
+	entity.SetField("first_name", "Jane")
+	entity.SetField("last_name", "Doe")
+	entity.SetField("tax_id", "X1234567")
+	entity.SetField("address", "12 Elm Street")
+
+	logged := []map[string]Change{Diff(nil, entity)}
+
+	before := *entity
+	entity.SetField("city", "Springfield")
+	logged = append(logged, Diff(before, entity))
+
+	before = *entity
+	if err := entity.Anonymize(); err != nil {
+		t.Fatalf("Anonymize() error = %v", err)
+	}
+	erase := Diff(before, entity)
+	logged = append(logged, erase)
+
+	note := &salesDomain.LeadNote{ID: 1, Content: "Jane prefers calls after 6pm"}
+	logged = append(logged, Diff(nil, note))
+
+	raw, _ := json.Marshal(logged)
+	for _, pii := range []string{"jane.doe@example.com", "Jane", "Doe", "X1234567", "12 Elm Street", "Springfield"} {
+		if strings.Contains(string(raw), pii) {
+			t.Errorf("audit diffs contain %q: %s", pii, raw)
+		}
+	}
+
+	// El campo sigue constando como cambiado
+	if got := erase["first_name"]; got.From != redactedValue || got.To != redactedValue {
+		t.Errorf("Diff()[first_name] = %v, want redacted change", got)
+	}
+	if _, ok := erase["status"]; !ok {
+		t.Error("Diff() dropped non personal field status")
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"AskingPrice", "asking_price"},
+		{"VIN", "vin"},
+		{"LocationID", "location_id"},
+		{"MSRP", "msrp"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := snakeCase(tt.in); got != tt.want {
+				t.Errorf("snakeCase(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package input
+
+import (
+	"context"
+
+	"torque-dms/core/audit/domain"
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+type AuditService interface {
+	// before es nil en un create y after es nil en un delete
+	Record(ctx context.Context, action domain.Action, aggregateType string, aggregateID uint, before interface{}, after interface{}) error
+	ListByAggregate(ctx context.Context, aggregateType string, aggregateID uint, q sharedDomain.Query) (*sharedDomain.Page[*domain.AuditEntry], error)
+	ListByActor(ctx context.Context, actorID uint, q sharedDomain.Query) (*sharedDomain.Page[*domain.AuditEntry], error)
+}
@@ -0,0 +1,16 @@
+package output
+
+import (
+	"context"
+
+	"torque-dms/core/audit/domain"
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+// AuditRepository - solo inserciones; el log no se modifica ni se borra
+type AuditRepository interface {
+	// Append - solo dentro de un unit of work, junto al cambio que registra
+	Append(ctx context.Context, entry *domain.AuditEntry) error
+	FindByAggregate(ctx context.Context, aggregateType string, aggregateID uint, q sharedDomain.Query) (*sharedDomain.Page[*domain.AuditEntry], error)
+	FindByActor(ctx context.Context, actorID uint, q sharedDomain.Query) (*sharedDomain.Page[*domain.AuditEntry], error)
+}
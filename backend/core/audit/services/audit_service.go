@@ -0,0 +1,49 @@
+package services
+
+import (
+	"context"
+
+	"torque-dms/core/audit/domain"
+	"torque-dms/core/audit/ports/input"
+	"torque-dms/core/audit/ports/output"
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+type auditService struct {
+	auditRepo output.AuditRepository
+}
+
+func NewAuditService(auditRepo output.AuditRepository) input.AuditService {
+	return &auditService{auditRepo: auditRepo}
+}
+
+func (s *auditService) Record(ctx context.Context, action domain.Action, aggregateType string, aggregateID uint, before interface{}, after interface{}) error {
+	entry, err := domain.NewAuditEntry(
+		sharedDomain.ActorFromContext(ctx),
+		action,
+		aggregateType,
+		aggregateID,
+		before,
+		after,
+	)
+	if err != nil {
+		return err
+	}
+
+	// Un update que no cambió nada no deja rastro
+	if action == domain.ActionUpdate && len(entry.Changes) == 0 {
+		return nil
+	}
+
+	return s.auditRepo.Append(ctx, entry)
+}
+
+func (s *auditService) ListByAggregate(ctx context.Context, aggregateType string, aggregateID uint, q sharedDomain.Query) (*sharedDomain.Page[*domain.AuditEntry], error) {
+	q = q.Normalize(50, 200)
+	return s.auditRepo.FindByAggregate(ctx, aggregateType, aggregateID, q)
+}
+
+func (s *auditService) ListByActor(ctx context.Context, actorID uint, q sharedDomain.Query) (*sharedDomain.Page[*domain.AuditEntry], error) {
+	q = q.Normalize(50, 200)
+	return s.auditRepo.FindByActor(ctx, actorID, q)
+}
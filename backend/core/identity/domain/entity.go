@@ -1,10 +1,11 @@
 package domain
 
 import (
-	"errors"
 	"strings"
 	"time"
 	"regexp"
+
+	sharedDomain "torque-dms/core/shared/domain"
 )
 
 // Tipos
@@ -17,6 +18,24 @@ const (
 	EntityTypeOrganization EntityType = "organization"
 )
 
+func EntityTypes() []EntityType {
+	return []EntityType{
+		EntityTypePerson,
+		EntityTypeCompany,
+		EntityTypeDealer,
+		EntityTypeOrganization,
+	}
+}
+
+func (e EntityType) IsValid() bool {
+	for _, v := range EntityTypes() {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
 type EntityStatus string
 
 const (
@@ -25,6 +44,23 @@ const (
 	EntityStatusSuspended EntityStatus = "suspended"
 )
 
+func EntityStatuses() []EntityStatus {
+	return []EntityStatus{
+		EntityStatusActive,
+		EntityStatusInactive,
+		EntityStatusSuspended,
+	}
+}
+
+func (e EntityStatus) IsValid() bool {
+	for _, v := range EntityStatuses() {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
 // La entidad de dominio - representa qué ES un Entity en tu negocio
 type Entity struct {
 	ID             uint
@@ -43,26 +79,33 @@ type Entity struct {
 	IsInternal     bool
 	ParentEntityID *uint
 	Status         EntityStatus
+	ErasedAt       *time.Time
+	DeletedAt      *time.Time
+	DeletedBy      *uint
+	Version        uint
 	CreatedAt      time.Time
 	ModifiedAt     time.Time
 }
 
 // Constructor - crea un Entity validando las reglas de negocio
 func NewEntity(entityType EntityType, phone string, email string) (*Entity, error) {
+	if !entityType.IsValid() {
+		return nil, sharedDomain.Invalid("type", "invalid entity type")
+	}
 
 	// Si ambos están vacíos
 	if email == "" && phone == "" {
-		return nil, errors.New("either email or phone is required")
+		return nil, sharedDomain.Invalid("email", "either email or phone is required")
 	}
 
 	// Si hay email pero es inválido
 	if email != "" && !isValidEmail(email) {
-		return nil, errors.New("invalid email format")
+		return nil, sharedDomain.Invalid("email", "invalid email format")
 	}
 
 	// Si hay phone pero es inválido
 	if phone != "" && !isValidPhone(phone) {
-		return nil, errors.New("invalid phone format")
+		return nil, sharedDomain.Invalid("phone", "invalid phone format")
 	}
 
 	return &Entity{
@@ -88,7 +131,7 @@ func (e *Entity) SetField(field string, value string) error {
 
 	for key, ptr := range fields {
 		if key == "email" && value != "" && !isValidEmail(value) {
-			return errors.New("invalid email format")
+			return sharedDomain.Invalid("email", "invalid email format")
 		}
 
 		if key == field {
@@ -98,7 +141,7 @@ func (e *Entity) SetField(field string, value string) error {
 		}
 	}
 
-	return errors.New("invalid field")
+	return sharedDomain.Invalid("field", "invalid field")
 }
 
 func (e *Entity) SetAsSystemUser() {
@@ -113,7 +156,7 @@ func (e *Entity) SetAsInternal() {
 
 func (e *Entity) Suspend() error {
 	if e.Status == EntityStatusSuspended {
-		return errors.New("entity is already suspended")
+		return sharedDomain.Invariant("entity_already_suspended", "entity is already suspended")
 	}
 	e.Status = EntityStatusSuspended
 	e.ModifiedAt = time.Now()
@@ -122,7 +165,7 @@ func (e *Entity) Suspend() error {
 
 func (e *Entity) Activate() error {
 	if e.Status == EntityStatusActive {
-		return errors.New("entity is already active")
+		return sharedDomain.Invariant("entity_already_active", "entity is already active")
 	}
 	e.Status = EntityStatusActive
 	e.ModifiedAt = time.Now()
@@ -131,15 +174,47 @@ func (e *Entity) Activate() error {
 
 func (e *Entity) Deactivate() error {
 	if e.Status == EntityStatusInactive {
-		return errors.New("entity is already inactive")
+		return sharedDomain.Invariant("entity_already_inactive", "entity is already inactive")
 	}
 	e.Status = EntityStatusInactive
 	e.ModifiedAt = time.Now()
 	return nil
 }
 
+// Anonymize - borra los datos personales (derecho al olvido) dejando el registro
+// para que los históricos de ventas sigan apuntando a él
+func (e *Entity) Anonymize() error {
+	if e.IsErased() {
+		return sharedDomain.Invariant("entity_already_erased", "entity is already erased")
+	}
+	now := time.Now()
+	e.FirstName = ""
+	e.LastName = ""
+	e.BusinessName = ""
+	e.TaxID = ""
+	e.Email = ""
+	e.Address = ""
+	e.City = ""
+	e.State = ""
+	e.Zip = ""
+	e.IsSystemUser = false
+	e.Status = EntityStatusInactive
+	e.ErasedAt = &now
+	e.ModifiedAt = now
+	return nil
+}
+
+// PersonalFields - lo que Anonymize borra; el audit log no guarda estos valores
+func (e *Entity) PersonalFields() []string {
+	return []string{"FirstName", "LastName", "BusinessName", "TaxID", "Email", "Address", "City", "State", "Zip"}
+}
+
 // Consultas de estado
 
+func (e *Entity) IsErased() bool {
+	return e.ErasedAt != nil
+}
+
 func (e *Entity) IsActive() bool {
 	return e.Status == EntityStatusActive
 }
@@ -167,4 +242,27 @@ func isValidPhone(phone string) bool {
 	if phone == "" {return false}
 	phoneRegex := regexp.MustCompile(`^\+\d{1,4}\s\d{6,14}$`)
 	return phoneRegex.MatchString(phone)
+}
+
+// SoftDelete - la entity queda en la papelera hasta que se restaure o se purgue
+func (e *Entity) SoftDelete(deletedBy uint) {
+	now := time.Now()
+	e.DeletedAt = &now
+	e.DeletedBy = nil
+	if deletedBy != 0 {
+		e.DeletedBy = &deletedBy
+	}
+}
+
+func (e *Entity) Restore() error {
+	if !e.IsDeleted() {
+		return sharedDomain.Invariant("entity_not_deleted", "entity is not deleted")
+	}
+	e.DeletedAt = nil
+	e.DeletedBy = nil
+	return nil
+}
+
+func (e *Entity) IsDeleted() bool {
+	return e.DeletedAt != nil
 }
\ No newline at end of file
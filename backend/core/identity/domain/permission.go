@@ -1,8 +1,9 @@
 package domain
 
 import (
-	"errors"
 	"time"
+
+	sharedDomain "torque-dms/core/shared/domain"
 )
 
 type AccessScope string
@@ -14,6 +15,24 @@ const (
 	AccessScopeNone AccessScope = "none"
 )
 
+func AccessScopes() []AccessScope {
+	return []AccessScope{
+		AccessScopeAll,
+		AccessScopeOwn,
+		AccessScopeTeam,
+		AccessScopeNone,
+	}
+}
+
+func (a AccessScope) IsValid() bool {
+	for _, v := range AccessScopes() {
+		if a == v {
+			return true
+		}
+	}
+	return false
+}
+
 // Resource - representa una URL/acción del sistema
 type Resource struct {
 	ID             uint
@@ -28,13 +47,13 @@ type Resource struct {
 
 func NewResource(code string, name string, urlPattern string, method string, module string) (*Resource, error) {
 	if code == "" {
-		return nil, errors.New("code is required")
+		return nil, sharedDomain.Invalid("code", "code is required")
 	}
 	if urlPattern == "" {
-		return nil, errors.New("url pattern is required")
+		return nil, sharedDomain.Invalid("url_pattern", "url pattern is required")
 	}
 	if method == "" {
-		return nil, errors.New("method is required")
+		return nil, sharedDomain.Invalid("method", "method is required")
 	}
 
 	return &Resource{
@@ -66,7 +85,7 @@ type Role struct {
 
 func NewRole(name string, description string) (*Role, error) {
 	if name == "" {
-		return nil, errors.New("name is required")
+		return nil, sharedDomain.Invalid("name", "name is required")
 	}
 
 	return &Role{
@@ -91,13 +110,13 @@ type RoleResource struct {
 
 func NewRoleResource(roleID uint, resourceID uint, scope AccessScope) (*RoleResource, error) {
 	if roleID == 0 {
-		return nil, errors.New("role is required")
+		return nil, sharedDomain.Invalid("role_id", "role is required")
 	}
 	if resourceID == 0 {
-		return nil, errors.New("resource is required")
+		return nil, sharedDomain.Invalid("resource_id", "resource is required")
 	}
-	if !isValidScope(scope) {
-		return nil, errors.New("invalid scope")
+	if !scope.IsValid() {
+		return nil, sharedDomain.Invalid("scope", "invalid scope")
 	}
 
 	return &RoleResource{
@@ -122,16 +141,16 @@ type EntityResource struct {
 
 func NewEntityResource(entityID uint, resourceID uint, scope AccessScope, assignedBy uint, reason string) (*EntityResource, error) {
 	if entityID == 0 {
-		return nil, errors.New("entity is required")
+		return nil, sharedDomain.Invalid("entity_id", "entity is required")
 	}
 	if resourceID == 0 {
-		return nil, errors.New("resource is required")
+		return nil, sharedDomain.Invalid("resource_id", "resource is required")
 	}
-	if !isValidScope(scope) {
-		return nil, errors.New("invalid scope")
+	if !scope.IsValid() {
+		return nil, sharedDomain.Invalid("scope", "invalid scope")
 	}
 	if assignedBy == 0 {
-		return nil, errors.New("assigned_by is required")
+		return nil, sharedDomain.Invalid("assigned_by", "assigned_by is required")
 	}
 
 	return &EntityResource{
@@ -165,10 +184,10 @@ type EntityRole struct {
 
 func NewEntityRole(entityID uint, roleID uint) (*EntityRole, error) {
 	if entityID == 0 {
-		return nil, errors.New("entity is required")
+		return nil, sharedDomain.Invalid("entity_id", "entity is required")
 	}
 	if roleID == 0 {
-		return nil, errors.New("role is required")
+		return nil, sharedDomain.Invalid("role_id", "role is required")
 	}
 
 	return &EntityRole{
@@ -178,14 +197,6 @@ func NewEntityRole(entityID uint, roleID uint) (*EntityRole, error) {
 	}, nil
 }
 
-// Helper
-func isValidScope(scope AccessScope) bool {
-	return scope == AccessScopeAll ||
-		scope == AccessScopeOwn ||
-		scope == AccessScopeTeam ||
-		scope == AccessScopeNone
-}
-
 // PermissionChecker - lógica para verificar permisos
 type PermissionChecker struct {
 	entityRoles     []EntityRole
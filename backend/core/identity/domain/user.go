@@ -55,11 +55,11 @@ func (u *UserAccount) CheckPassword(password string) bool {
 
 func (u *UserAccount) ChangePassword(oldPassword string, newPassword string) error {
 	if !u.CheckPassword(oldPassword) {
-		return errors.New("incorrect current password")
+		return sharedDomain.Invalid("old_password", "incorrect current password")
 	}
 
 	if len(newPassword) < 8 {
-		return errors.New("new password must be at least 8 characters")
+		return sharedDomain.Invalid("new_password", "new password must be at least 8 characters")
 	}
 
 	hash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
@@ -85,4 +85,16 @@ func (u *UserAccount) Suspend() {
 
 func (u *UserAccount) Activate() {
 	u.Status = EntityStatusActive
+}
+
+// Anonymize - reemplaza el username y bloquea la cuenta de forma permanente
+// PersonalFields - el username suele ser el nombre o el email de la persona
+func (u *UserAccount) PersonalFields() []string {
+	return []string{"Username"}
+}
+
+func (u *UserAccount) Anonymize() {
+	u.Username = fmt.Sprintf("erased_%d", u.ID)
+	u.PasswordHash = ""
+	u.Status = EntityStatusSuspended
 }
\ No newline at end of file
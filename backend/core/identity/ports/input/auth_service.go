@@ -1,6 +1,10 @@
 package input
 
-import "torque-dms/core/identity/domain"
+import (
+	"context"
+
+	"torque-dms/core/identity/domain"
+)
 
 type RegisterInput struct {
 	Type         string
@@ -30,8 +34,8 @@ type ChangePasswordInput struct {
 }
 
 type AuthService interface {
-	Register(input RegisterInput) (*domain.Entity, *domain.UserAccount, error)
-	Login(input LoginInput) (*LoginOutput, error)
-	ChangePassword(input ChangePasswordInput) error
-	Logout(userID uint) error
+	Register(ctx context.Context, input RegisterInput) (*domain.Entity, *domain.UserAccount, error)
+	Login(ctx context.Context, input LoginInput) (*LoginOutput, error)
+	ChangePassword(ctx context.Context, input ChangePasswordInput) error
+	Logout(ctx context.Context, userID uint) error
 }
\ No newline at end of file
@@ -1,6 +1,12 @@
 package input
 
-import "torque-dms/core/identity/domain"
+import (
+	"context"
+	"time"
+
+	"torque-dms/core/identity/domain"
+	sharedDomain "torque-dms/core/shared/domain"
+)
 
 type CreateEntityInput struct {
 	Type         string
@@ -18,17 +24,23 @@ type CreateEntityInput struct {
 }
 
 type UpdateEntityInput struct {
-	Field string
-	Value string
+	Field   string
+	Value   string
+	Version *uint // versión que leyó el cliente (If-Match), opcional
 }
 
 type EntityService interface {
-	Create(input CreateEntityInput) (*domain.Entity, error)
-	GetByID(id uint) (*domain.Entity, error)
-	GetByEmail(email string) (*domain.Entity, error)
-	Update(id uint, input UpdateEntityInput) (*domain.Entity, error)
-	Delete(id uint) error
-	List(limit int, offset int) ([]*domain.Entity, error)
-	Suspend(id uint) error
-	Activate(id uint) error
+	Create(ctx context.Context, input CreateEntityInput) (*domain.Entity, error)
+	GetByID(ctx context.Context, id uint) (*domain.Entity, error)
+	GetByEmail(ctx context.Context, email string) (*domain.Entity, error)
+	Update(ctx context.Context, id uint, input UpdateEntityInput) (*domain.Entity, error)
+	Delete(ctx context.Context, id uint) error
+	List(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.Entity], error)
+	Suspend(ctx context.Context, id uint) error
+	Activate(ctx context.Context, id uint) error
+
+	// Trash
+	ListDeleted(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.Entity], error)
+	Restore(ctx context.Context, id uint) (*domain.Entity, error)
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int, error)
 }
\ No newline at end of file
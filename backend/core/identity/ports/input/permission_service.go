@@ -1,6 +1,10 @@
 package input
 
-import "torque-dms/core/identity/domain"
+import (
+	"context"
+
+	"torque-dms/core/identity/domain"
+)
 
 type AssignRoleInput struct {
 	EntityID uint
@@ -22,19 +26,19 @@ type CheckPermissionInput struct {
 
 type PermissionService interface {
 	// Roles
-	CreateRole(name string, description string) (*domain.Role, error)
-	GetRoles() ([]*domain.Role, error)
-	AssignRole(input AssignRoleInput) error
-	RemoveRole(entityID uint, roleID uint) error
-	GetEntityRoles(entityID uint) ([]*domain.Role, error)
+	CreateRole(ctx context.Context, name string, description string) (*domain.Role, error)
+	GetRoles(ctx context.Context) ([]*domain.Role, error)
+	AssignRole(ctx context.Context, input AssignRoleInput) error
+	RemoveRole(ctx context.Context, entityID uint, roleID uint) error
+	GetEntityRoles(ctx context.Context, entityID uint) ([]*domain.Role, error)
 
 	// Resources
-	CreateResource(code string, name string, urlPattern string, method string, module string) (*domain.Resource, error)
-	GetResources() ([]*domain.Resource, error)
-	AssignResourceToRole(roleID uint, resourceID uint, scope string) error
-	AssignResourceToEntity(input AssignResourceInput) error
+	CreateResource(ctx context.Context, code string, name string, urlPattern string, method string, module string) (*domain.Resource, error)
+	GetResources(ctx context.Context) ([]*domain.Resource, error)
+	AssignResourceToRole(ctx context.Context, roleID uint, resourceID uint, scope string) error
+	AssignResourceToEntity(ctx context.Context, input AssignResourceInput) error
 
 	// Check
-	CanAccess(input CheckPermissionInput) (bool, error)
-	GetScope(entityID uint, resourceID uint) (domain.AccessScope, error)
+	CanAccess(ctx context.Context, input CheckPermissionInput) (bool, error)
+	GetScope(ctx context.Context, entityID uint, resourceID uint) (domain.AccessScope, error)
 }
\ No newline at end of file
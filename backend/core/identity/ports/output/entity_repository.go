@@ -1,13 +1,21 @@
 package output
 
-import "torque-dms/core/identity/domain"
+import (
+	"context"
+	"time"
+
+	"torque-dms/core/identity/domain"
+	sharedDomain "torque-dms/core/shared/domain"
+)
 
 type EntityRepository interface {
-	Save(entity *domain.Entity) error
-	Update(entity *domain.Entity) error
-	FindByID(id uint) (*domain.Entity, error)
-	FindByEmail(email string) (*domain.Entity, error)
-	FindAll(limit int, offset int) ([]*domain.Entity, error)
-	Delete(id uint) error
-	Exists(id uint) (bool, error)
+	Save(ctx context.Context, entity *domain.Entity) error
+	Update(ctx context.Context, entity *domain.Entity) error
+	FindByID(ctx context.Context, id uint) (*domain.Entity, error)
+	FindByEmail(ctx context.Context, email string) (*domain.Entity, error)
+	FindAll(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.Entity], error)
+	FindDeleted(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.Entity], error)
+	FindDeletedBefore(ctx context.Context, before time.Time) ([]*domain.Entity, error)
+	Purge(ctx context.Context, id uint) error
+	Exists(ctx context.Context, id uint) (bool, error)
 }
\ No newline at end of file
@@ -1,5 +1,7 @@
 package output
 
+import "context"
+
 // import "torque-dms/core/identity/domain"
 
 type Phone struct {
@@ -13,10 +15,10 @@ type Phone struct {
 }
 
 type PhoneRepository interface {
-	Save(phone *Phone) error
-	Update(phone *Phone) error
-	FindByID(id uint) (*Phone, error)
-	FindByEntityID(entityID uint) ([]*Phone, error)
-	FindPrimaryByEntityID(entityID uint) (*Phone, error)
-	Delete(id uint) error
+	Save(ctx context.Context, phone *Phone) error
+	Update(ctx context.Context, phone *Phone) error
+	FindByID(ctx context.Context, id uint) (*Phone, error)
+	FindByEntityID(ctx context.Context, entityID uint) ([]*Phone, error)
+	FindPrimaryByEntityID(ctx context.Context, entityID uint) (*Phone, error)
+	Delete(ctx context.Context, id uint) error
 }
\ No newline at end of file
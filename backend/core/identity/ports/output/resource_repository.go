@@ -1,24 +1,28 @@
 package output
 
-import "torque-dms/core/identity/domain"
+import (
+	"context"
+
+	"torque-dms/core/identity/domain"
+)
 
 type ResourceRepository interface {
 	// Resource
-	Save(resource *domain.Resource) error
-	Update(resource *domain.Resource) error
-	FindByID(id uint) (*domain.Resource, error)
-	FindByCode(code string) (*domain.Resource, error)
-	FindByMethodAndPattern(method string, urlPattern string) (*domain.Resource, error)
-	FindAll() ([]*domain.Resource, error)
-	Delete(id uint) error
+	Save(ctx context.Context, resource *domain.Resource) error
+	Update(ctx context.Context, resource *domain.Resource) error
+	FindByID(ctx context.Context, id uint) (*domain.Resource, error)
+	FindByCode(ctx context.Context, code string) (*domain.Resource, error)
+	FindByMethodAndPattern(ctx context.Context, method string, urlPattern string) (*domain.Resource, error)
+	FindAll(ctx context.Context) ([]*domain.Resource, error)
+	Delete(ctx context.Context, id uint) error
 
 	// RoleResource
-	AssignResourceToRole(roleResource *domain.RoleResource) error
-	RemoveResourceFromRole(roleID uint, resourceID uint) error
-	FindResourcesByRoleID(roleID uint) ([]*domain.RoleResource, error)
+	AssignResourceToRole(ctx context.Context, roleResource *domain.RoleResource) error
+	RemoveResourceFromRole(ctx context.Context, roleID uint, resourceID uint) error
+	FindResourcesByRoleID(ctx context.Context, roleID uint) ([]*domain.RoleResource, error)
 
 	// EntityResource
-	AssignResourceToEntity(entityResource *domain.EntityResource) error
-	RemoveResourceFromEntity(entityID uint, resourceID uint) error
-	FindResourcesByEntityID(entityID uint) ([]*domain.EntityResource, error)
+	AssignResourceToEntity(ctx context.Context, entityResource *domain.EntityResource) error
+	RemoveResourceFromEntity(ctx context.Context, entityID uint, resourceID uint) error
+	FindResourcesByEntityID(ctx context.Context, entityID uint) ([]*domain.EntityResource, error)
 }
\ No newline at end of file
@@ -1,19 +1,23 @@
 package output
 
-import "torque-dms/core/identity/domain"
+import (
+	"context"
+
+	"torque-dms/core/identity/domain"
+)
 
 type RoleRepository interface {
 	// Role
-	Save(role *domain.Role) error
-	Update(role *domain.Role) error
-	FindByID(id uint) (*domain.Role, error)
-	FindByName(name string) (*domain.Role, error)
-	FindAll() ([]*domain.Role, error)
-	Delete(id uint) error
+	Save(ctx context.Context, role *domain.Role) error
+	Update(ctx context.Context, role *domain.Role) error
+	FindByID(ctx context.Context, id uint) (*domain.Role, error)
+	FindByName(ctx context.Context, name string) (*domain.Role, error)
+	FindAll(ctx context.Context) ([]*domain.Role, error)
+	Delete(ctx context.Context, id uint) error
 
 	// EntityRole
-	AssignRoleToEntity(entityRole *domain.EntityRole) error
-	RemoveRoleFromEntity(entityID uint, roleID uint) error
-	FindRolesByEntityID(entityID uint) ([]*domain.Role, error)
-	FindEntitiesByRoleID(roleID uint) ([]uint, error)
+	AssignRoleToEntity(ctx context.Context, entityRole *domain.EntityRole) error
+	RemoveRoleFromEntity(ctx context.Context, entityID uint, roleID uint) error
+	FindRolesByEntityID(ctx context.Context, entityID uint) ([]*domain.Role, error)
+	FindEntitiesByRoleID(ctx context.Context, roleID uint) ([]uint, error)
 }
\ No newline at end of file
@@ -1,13 +1,17 @@
 package output
 
-import "torque-dms/core/identity/domain"
+import (
+	"context"
+
+	"torque-dms/core/identity/domain"
+)
 
 type UserRepository interface {
-	Save(user *domain.UserAccount) error
-	Update(user *domain.UserAccount) error
-	FindByID(id uint) (*domain.UserAccount, error)
-	FindByEntityID(entityID uint) (*domain.UserAccount, error)
-	FindByUsername(username string) (*domain.UserAccount, error)
-	Delete(id uint) error
-	Exists(username string) (bool, error)
+	Save(ctx context.Context, user *domain.UserAccount) error
+	Update(ctx context.Context, user *domain.UserAccount) error
+	FindByID(ctx context.Context, id uint) (*domain.UserAccount, error)
+	FindByEntityID(ctx context.Context, entityID uint) (*domain.UserAccount, error)
+	FindByUsername(ctx context.Context, username string) (*domain.UserAccount, error)
+	Delete(ctx context.Context, id uint) error
+	Exists(ctx context.Context, username string) (bool, error)
 }
\ No newline at end of file
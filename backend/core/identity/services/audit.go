@@ -0,0 +1,9 @@
+package services
+
+// Tipos de agregado con los que identity escribe en el log de auditoría
+const (
+	entityAggregate      = "entity"
+	userAccountAggregate = "user_account"
+	roleAggregate        = "role"
+	resourceAggregate    = "resource"
+)
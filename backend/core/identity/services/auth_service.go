@@ -1,98 +1,139 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	auditDomain "torque-dms/core/audit/domain"
+	auditInput "torque-dms/core/audit/ports/input"
 	"torque-dms/core/identity/domain"
 	"torque-dms/core/identity/ports/input"
 	"torque-dms/core/identity/ports/output"
+	sharedDomain "torque-dms/core/shared/domain"
+	sharedOutput "torque-dms/core/shared/ports/output"
 )
 
 type authService struct {
-	entityRepo output.EntityRepository
-	userRepo   output.UserRepository
-	phoneRepo  output.PhoneRepository
-	jwtSecret  string
+	entityRepo   output.EntityRepository
+	userRepo     output.UserRepository
+	phoneRepo    output.PhoneRepository
+	auditService auditInput.AuditService
+	uow          sharedOutput.UnitOfWork
+	jwtSecret    string
 }
 
 func NewAuthService(
 	entityRepo output.EntityRepository,
 	userRepo output.UserRepository,
 	phoneRepo output.PhoneRepository,
+	auditService auditInput.AuditService,
+	uow sharedOutput.UnitOfWork,
 	jwtSecret string,
 ) input.AuthService {
 	return &authService{
-		entityRepo: entityRepo,
-		userRepo:   userRepo,
-		phoneRepo:  phoneRepo,
-		jwtSecret:  jwtSecret,
+		entityRepo:   entityRepo,
+		userRepo:     userRepo,
+		phoneRepo:    phoneRepo,
+		auditService: auditService,
+		uow:          uow,
+		jwtSecret:    jwtSecret,
 	}
 }
 
-func (s *authService) Register(inp input.RegisterInput) (*domain.Entity, *domain.UserAccount, error) {
-	// Verificar que username no exista
-	exists, err := s.userRepo.Exists(inp.Username)
-	if err != nil {
-		return nil, nil, err
-	}
-	if exists {
-		return nil, nil, errors.New("username already exists")
-	}
+func (s *authService) Register(ctx context.Context, inp input.RegisterInput) (*domain.Entity, *domain.UserAccount, error) {
+	var entity *domain.Entity
+	var user *domain.UserAccount
 
-	// Crear entity
-	entity, err := domain.NewEntity(domain.EntityType(inp.Type), inp.Phone, inp.Email)
-	if err != nil {
-		return nil, nil, err
-	}
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		// Verificar que username no exista
+		exists, err := s.userRepo.Exists(ctx, inp.Username)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return sharedDomain.Conflict("username_taken", "username already exists")
+		}
 
-	entity.SetField("first_name", inp.FirstName)
-	entity.SetField("last_name", inp.LastName)
-	entity.SetField("business_name", inp.BusinessName)
-	entity.SetAsSystemUser()
+		// Crear entity
+		entity, err = domain.NewEntity(domain.EntityType(inp.Type), inp.Phone, inp.Email)
+		if err != nil {
+			return err
+		}
 
-	if err := s.entityRepo.Save(entity); err != nil {
-		return nil, nil, err
-	}
+		entity.SetField("first_name", inp.FirstName)
+		entity.SetField("last_name", inp.LastName)
+		entity.SetField("business_name", inp.BusinessName)
+		entity.SetAsSystemUser()
 
-	// Si hay teléfono, guardarlo
-	if inp.Phone != "" {
-		phone := &output.Phone{
-			EntityID:  entity.ID,
-			Number:    inp.Phone,
-			IsPrimary: true,
+		if err := s.entityRepo.Save(ctx, entity); err != nil {
+			return err
 		}
-		if err := s.phoneRepo.Save(phone); err != nil {
-			return nil, nil, err
+
+		// Si hay teléfono, guardarlo
+		if inp.Phone != "" {
+			phone := &output.Phone{
+				EntityID:  entity.ID,
+				Number:    inp.Phone,
+				IsPrimary: true,
+			}
+			if err := s.phoneRepo.Save(ctx, phone); err != nil {
+				return err
+			}
 		}
-	}
 
-	// Crear user account
-	user, err := domain.NewUserAccount(entity.ID, inp.Username, inp.Password)
-	if err != nil {
-		return nil, nil, err
-	}
+		// Crear user account
+		user, err = domain.NewUserAccount(entity.ID, inp.Username, inp.Password)
+		if err != nil {
+			return err
+		}
 
-	if err := s.userRepo.Save(user); err != nil {
+		if err := s.userRepo.Save(ctx, user); err != nil {
+			return err
+		}
+
+		// En el registro el actor es el propio usuario recién creado
+		actor := sharedDomain.ActorFromContext(ctx)
+		actor.EntityID = entity.ID
+		ctx = sharedDomain.WithActor(ctx, actor)
+
+		if err := s.auditService.Record(ctx, auditDomain.ActionCreate, entityAggregate, entity.ID, nil, entity); err != nil {
+			return err
+		}
+		return s.auditService.Record(ctx, auditDomain.ActionCreate, userAccountAggregate, user.ID, nil, user)
+	})
+	if err != nil {
 		return nil, nil, err
 	}
 
 	return entity, user, nil
 }
 
-func (s *authService) Login(inp input.LoginInput) (*input.LoginOutput, error) {
-	user, err := s.userRepo.FindByUsername(inp.Username)
+func (s *authService) Login(ctx context.Context, inp input.LoginInput) (*input.LoginOutput, error) {
+	user, err := s.userRepo.FindByUsername(ctx, inp.Username)
+	if sharedDomain.IsNotFound(err) {
+		return nil, sharedDomain.Unauthorized("invalid_credentials", "invalid credentials")
+	}
 	if err != nil {
-		return nil, errors.New("invalid credentials")
+		return nil, err
 	}
 
 	if !user.CheckPassword(inp.Password) {
-		return nil, errors.New("invalid credentials")
+		return nil, sharedDomain.Unauthorized("invalid_credentials", "invalid credentials")
 	}
 
 	if !user.IsActive() {
-		return nil, errors.New("account is not active")
+		return nil, sharedDomain.Forbidden("account_inactive", "account is not active")
+	}
+
+	// Una entity en la papelera no puede iniciar sesión
+	exists, err := s.entityRepo.Exists(ctx, user.EntityID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, sharedDomain.Forbidden("account_inactive", "account is not active")
 	}
 
 	// Generar JWT
@@ -103,7 +144,7 @@ func (s *authService) Login(inp input.LoginInput) (*input.LoginOutput, error) {
 
 	// Registrar login
 	user.RecordLogin()
-	s.userRepo.Update(user)
+	s.userRepo.Update(ctx, user)
 
 	return &input.LoginOutput{
 		User:  user,
@@ -111,20 +152,27 @@ func (s *authService) Login(inp input.LoginInput) (*input.LoginOutput, error) {
 	}, nil
 }
 
-func (s *authService) ChangePassword(inp input.ChangePasswordInput) error {
-	user, err := s.userRepo.FindByID(inp.UserID)
-	if err != nil {
-		return errors.New("user not found")
-	}
+func (s *authService) ChangePassword(ctx context.Context, inp input.ChangePasswordInput) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		user, err := s.userRepo.FindByID(ctx, inp.UserID)
+		if err != nil {
+			return err
+		}
+		before := *user
 
-	if err := user.ChangePassword(inp.OldPassword, inp.NewPassword); err != nil {
-		return err
-	}
+		if err := user.ChangePassword(inp.OldPassword, inp.NewPassword); err != nil {
+			return err
+		}
+
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			return err
+		}
 
-	return s.userRepo.Update(user)
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, userAccountAggregate, user.ID, before, user)
+	})
 }
 
-func (s *authService) Logout(userID uint) error {
+func (s *authService) Logout(ctx context.Context, userID uint) error {
 	// Por ahora solo retornamos nil
 	// En el futuro podrías invalidar el token en una blacklist
 	return nil
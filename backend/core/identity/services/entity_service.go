@@ -1,151 +1,265 @@
 package services
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"time"
 
+	auditDomain "torque-dms/core/audit/domain"
+	auditInput "torque-dms/core/audit/ports/input"
 	"torque-dms/core/identity/domain"
 	"torque-dms/core/identity/ports/input"
 	"torque-dms/core/identity/ports/output"
+	sharedDomain "torque-dms/core/shared/domain"
+	sharedOutput "torque-dms/core/shared/ports/output"
 )
 
 type entityService struct {
-	entityRepo output.EntityRepository
-	phoneRepo  output.PhoneRepository
+	entityRepo   output.EntityRepository
+	phoneRepo    output.PhoneRepository
+	auditService auditInput.AuditService
+	uow          sharedOutput.UnitOfWork
 }
 
-func NewEntityService(entityRepo output.EntityRepository, phoneRepo output.PhoneRepository) input.EntityService {
+func NewEntityService(
+	entityRepo output.EntityRepository,
+	phoneRepo output.PhoneRepository,
+	auditService auditInput.AuditService,
+	uow sharedOutput.UnitOfWork,
+) input.EntityService {
 	return &entityService{
-		entityRepo: entityRepo,
-		phoneRepo:  phoneRepo,
+		entityRepo:   entityRepo,
+		phoneRepo:    phoneRepo,
+		auditService: auditService,
+		uow:          uow,
 	}
 }
 
-func (s *entityService) Create(inp input.CreateEntityInput) (*domain.Entity, error) {
-	// Crear entity en domain
-	entity, err := domain.NewEntity(domain.EntityType(inp.Type), inp.Phone, inp.Email)
-	if err != nil {
-		return nil, err
-	}
+func (s *entityService) Create(ctx context.Context, inp input.CreateEntityInput) (*domain.Entity, error) {
+	var entity *domain.Entity
 
-	// Setear campos opcionales
-	if inp.FirstName != "" {
-		entity.SetField("first_name", inp.FirstName)
-	}
-	if inp.LastName != "" {
-		entity.SetField("last_name", inp.LastName)
-	}
-	if inp.BusinessName != "" {
-		entity.SetField("business_name", inp.BusinessName)
-	}
-	if inp.TaxID != "" {
-		entity.SetField("tax_id", inp.TaxID)
-	}
-	if inp.Address != "" {
-		entity.SetField("address", inp.Address)
-	}
-	if inp.City != "" {
-		entity.SetField("city", inp.City)
-	}
-	if inp.State != "" {
-		entity.SetField("state", inp.State)
-	}
-	if inp.Zip != "" {
-		entity.SetField("zip", inp.Zip)
-	}
-	if inp.CountryID != nil {
-		entity.CountryID = inp.CountryID
-	}
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		// Crear entity en domain
+		var err error
+		entity, err = domain.NewEntity(domain.EntityType(inp.Type), inp.Phone, inp.Email)
+		if err != nil {
+			return err
+		}
 
-	// Guardar entity
-	if err := s.entityRepo.Save(entity); err != nil {
-		return nil, err
-	}
+		// Setear campos opcionales
+		if inp.FirstName != "" {
+			entity.SetField("first_name", inp.FirstName)
+		}
+		if inp.LastName != "" {
+			entity.SetField("last_name", inp.LastName)
+		}
+		if inp.BusinessName != "" {
+			entity.SetField("business_name", inp.BusinessName)
+		}
+		if inp.TaxID != "" {
+			entity.SetField("tax_id", inp.TaxID)
+		}
+		if inp.Address != "" {
+			entity.SetField("address", inp.Address)
+		}
+		if inp.City != "" {
+			entity.SetField("city", inp.City)
+		}
+		if inp.State != "" {
+			entity.SetField("state", inp.State)
+		}
+		if inp.Zip != "" {
+			entity.SetField("zip", inp.Zip)
+		}
+		if inp.CountryID != nil {
+			entity.CountryID = inp.CountryID
+		}
 
-	// Si hay teléfono, guardarlo
-	if inp.Phone != "" {
-		phone := &output.Phone{
-			EntityID:  entity.ID,
-			Number:    inp.Phone,
-			IsPrimary: true,
+		// Guardar entity
+		if err := s.entityRepo.Save(ctx, entity); err != nil {
+			return err
 		}
-		if err := s.phoneRepo.Save(phone); err != nil {
-			return nil, err
+
+		// Si hay teléfono, guardarlo
+		if inp.Phone != "" {
+			phone := &output.Phone{
+				EntityID:  entity.ID,
+				Number:    inp.Phone,
+				IsPrimary: true,
+			}
+			if err := s.phoneRepo.Save(ctx, phone); err != nil {
+				return err
+			}
 		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionCreate, entityAggregate, entity.ID, nil, entity)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return entity, nil
 }
 
-func (s *entityService) GetByID(id uint) (*domain.Entity, error) {
-	return s.entityRepo.FindByID(id)
+func (s *entityService) GetByID(ctx context.Context, id uint) (*domain.Entity, error) {
+	return s.entityRepo.FindByID(ctx, id)
 }
 
-func (s *entityService) GetByEmail(email string) (*domain.Entity, error) {
-	return s.entityRepo.FindByEmail(email)
+func (s *entityService) GetByEmail(ctx context.Context, email string) (*domain.Entity, error) {
+	return s.entityRepo.FindByEmail(ctx, email)
 }
 
-func (s *entityService) Update(id uint, inp input.UpdateEntityInput) (*domain.Entity, error) {
-	entity, err := s.entityRepo.FindByID(id)
-	if err != nil {
-		return nil, errors.New("entity not found")
-	}
+func (s *entityService) Update(ctx context.Context, id uint, inp input.UpdateEntityInput) (*domain.Entity, error) {
+	var entity *domain.Entity
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		entity, err = s.entityRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := sharedDomain.CheckVersion(inp.Version, entity.Version); err != nil {
+			return err
+		}
+		before := *entity
 
-	if err := entity.SetField(inp.Field, inp.Value); err != nil {
-		return nil, err
-	}
+		if err := entity.SetField(inp.Field, inp.Value); err != nil {
+			return err
+		}
 
-	if err := s.entityRepo.Update(entity); err != nil {
+		if err := s.entityRepo.Update(ctx, entity); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, entityAggregate, entity.ID, before, entity)
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return entity, nil
 }
 
-func (s *entityService) Delete(id uint) error {
-	exists, err := s.entityRepo.Exists(id)
-	if err != nil {
-		return err
-	}
-	if !exists {
-		return errors.New("entity not found")
-	}
+func (s *entityService) Delete(ctx context.Context, id uint) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		entity, err := s.entityRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		before := *entity
+
+		entity.SoftDelete(sharedDomain.ActorFromContext(ctx).EntityID)
+		if err := s.entityRepo.Update(ctx, entity); err != nil {
+			return err
+		}
 
-	return s.entityRepo.Delete(id)
+		return s.auditService.Record(ctx, auditDomain.ActionDelete, entityAggregate, id, before, nil)
+	})
 }
 
-func (s *entityService) List(limit int, offset int) ([]*domain.Entity, error) {
-	if limit <= 0 {
-		limit = 10
-	}
-	if limit > 100 {
-		limit = 100
-	}
+func (s *entityService) List(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.Entity], error) {
+	q = q.Normalize(10, 100)
+	return s.entityRepo.FindAll(ctx, q)
+}
 
-	return s.entityRepo.FindAll(limit, offset)
+func (s *entityService) Suspend(ctx context.Context, id uint) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		entity, err := s.entityRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		before := *entity
+
+		if err := entity.Suspend(); err != nil {
+			return err
+		}
+
+		if err := s.entityRepo.Update(ctx, entity); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, entityAggregate, id, before, entity)
+	})
 }
 
-func (s *entityService) Suspend(id uint) error {
-	entity, err := s.entityRepo.FindByID(id)
-	if err != nil {
-		return errors.New("entity not found")
-	}
+func (s *entityService) Activate(ctx context.Context, id uint) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		entity, err := s.entityRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		before := *entity
+
+		if err := entity.Activate(); err != nil {
+			return err
+		}
+
+		if err := s.entityRepo.Update(ctx, entity); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, entityAggregate, id, before, entity)
+	})
+}
+
+func (s *entityService) ListDeleted(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.Entity], error) {
+	q = q.Normalize(10, 100)
+	return s.entityRepo.FindDeleted(ctx, q)
+}
+
+func (s *entityService) Restore(ctx context.Context, id uint) (*domain.Entity, error) {
+	ctx = sharedDomain.WithDeleted(ctx)
+
+	var entity *domain.Entity
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		entity, err = s.entityRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		before := *entity
 
-	if err := entity.Suspend(); err != nil {
-		return err
+		if err := entity.Restore(); err != nil {
+			return err
+		}
+
+		if err := s.entityRepo.Update(ctx, entity); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionRestore, entityAggregate, id, before, entity)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return s.entityRepo.Update(entity)
+	return entity, nil
 }
 
-func (s *entityService) Activate(id uint) error {
-	entity, err := s.entityRepo.FindByID(id)
+// PurgeDeleted - una entity que sigue referenciada (leads, notas, presets) no se puede borrar;
+// se salta y se sigue con el resto, devolviendo todos los errores juntos.
+func (s *entityService) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int, error) {
+	deleted, err := s.entityRepo.FindDeletedBefore(ctx, deletedBefore)
 	if err != nil {
-		return errors.New("entity not found")
+		return 0, err
 	}
 
-	if err := entity.Activate(); err != nil {
-		return err
+	purged := 0
+	var errs []error
+	for _, entity := range deleted {
+		err := s.uow.Do(ctx, func(ctx context.Context) error {
+			if err := s.entityRepo.Purge(ctx, entity.ID); err != nil {
+				return err
+			}
+			return s.auditService.Record(ctx, auditDomain.ActionPurge, entityAggregate, entity.ID, nil, nil)
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("entity %d: %w", entity.ID, err))
+			continue
+		}
+		purged++
 	}
 
-	return s.entityRepo.Update(entity)
-}
\ No newline at end of file
+	return purged, errors.Join(errs...)
+}
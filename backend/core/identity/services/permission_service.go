@@ -1,118 +1,171 @@
 package services
 
 import (
+	"context"
+
 	// "errors"
 
+	auditDomain "torque-dms/core/audit/domain"
+	auditInput "torque-dms/core/audit/ports/input"
 	"torque-dms/core/identity/domain"
 	"torque-dms/core/identity/ports/input"
 	"torque-dms/core/identity/ports/output"
+	sharedOutput "torque-dms/core/shared/ports/output"
 )
 
 type permissionService struct {
 	roleRepo     output.RoleRepository
 	resourceRepo output.ResourceRepository
+	auditService auditInput.AuditService
+	uow          sharedOutput.UnitOfWork
 }
 
 func NewPermissionService(
 	roleRepo output.RoleRepository,
 	resourceRepo output.ResourceRepository,
+	auditService auditInput.AuditService,
+	uow sharedOutput.UnitOfWork,
 ) input.PermissionService {
 	return &permissionService{
 		roleRepo:     roleRepo,
 		resourceRepo: resourceRepo,
+		auditService: auditService,
+		uow:          uow,
 	}
 }
 
 // Roles
 
-func (s *permissionService) CreateRole(name string, description string) (*domain.Role, error) {
-	role, err := domain.NewRole(name, description)
-	if err != nil {
-		return nil, err
-	}
+func (s *permissionService) CreateRole(ctx context.Context, name string, description string) (*domain.Role, error) {
+	var role *domain.Role
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		role, err = domain.NewRole(name, description)
+		if err != nil {
+			return err
+		}
+
+		if err := s.roleRepo.Save(ctx, role); err != nil {
+			return err
+		}
 
-	if err := s.roleRepo.Save(role); err != nil {
+		return s.auditService.Record(ctx, auditDomain.ActionCreate, roleAggregate, role.ID, nil, role)
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return role, nil
 }
 
-func (s *permissionService) GetRoles() ([]*domain.Role, error) {
-	return s.roleRepo.FindAll()
+func (s *permissionService) GetRoles(ctx context.Context) ([]*domain.Role, error) {
+	return s.roleRepo.FindAll(ctx)
 }
 
-func (s *permissionService) AssignRole(inp input.AssignRoleInput) error {
-	entityRole, err := domain.NewEntityRole(inp.EntityID, inp.RoleID)
-	if err != nil {
-		return err
-	}
+func (s *permissionService) AssignRole(ctx context.Context, inp input.AssignRoleInput) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		entityRole, err := domain.NewEntityRole(inp.EntityID, inp.RoleID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.roleRepo.AssignRoleToEntity(ctx, entityRole); err != nil {
+			return err
+		}
 
-	return s.roleRepo.AssignRoleToEntity(entityRole)
+		return s.auditService.Record(ctx, auditDomain.ActionAssign, entityAggregate, inp.EntityID, nil, entityRole)
+	})
 }
 
-func (s *permissionService) RemoveRole(entityID uint, roleID uint) error {
-	return s.roleRepo.RemoveRoleFromEntity(entityID, roleID)
+func (s *permissionService) RemoveRole(ctx context.Context, entityID uint, roleID uint) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.roleRepo.RemoveRoleFromEntity(ctx, entityID, roleID); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionUnassign, entityAggregate, entityID, domain.EntityRole{EntityID: entityID, RoleID: roleID}, nil)
+	})
 }
 
-func (s *permissionService) GetEntityRoles(entityID uint) ([]*domain.Role, error) {
-	return s.roleRepo.FindRolesByEntityID(entityID)
+func (s *permissionService) GetEntityRoles(ctx context.Context, entityID uint) ([]*domain.Role, error) {
+	return s.roleRepo.FindRolesByEntityID(ctx, entityID)
 }
 
 // Resources
 
-func (s *permissionService) CreateResource(code string, name string, urlPattern string, method string, module string) (*domain.Resource, error) {
-	resource, err := domain.NewResource(code, name, urlPattern, method, module)
-	if err != nil {
-		return nil, err
-	}
+func (s *permissionService) CreateResource(ctx context.Context, code string, name string, urlPattern string, method string, module string) (*domain.Resource, error) {
+	var resource *domain.Resource
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		resource, err = domain.NewResource(code, name, urlPattern, method, module)
+		if err != nil {
+			return err
+		}
+
+		if err := s.resourceRepo.Save(ctx, resource); err != nil {
+			return err
+		}
 
-	if err := s.resourceRepo.Save(resource); err != nil {
+		return s.auditService.Record(ctx, auditDomain.ActionCreate, resourceAggregate, resource.ID, nil, resource)
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return resource, nil
 }
 
-func (s *permissionService) GetResources() ([]*domain.Resource, error) {
-	return s.resourceRepo.FindAll()
+func (s *permissionService) GetResources(ctx context.Context) ([]*domain.Resource, error) {
+	return s.resourceRepo.FindAll(ctx)
 }
 
-func (s *permissionService) AssignResourceToRole(roleID uint, resourceID uint, scope string) error {
-	roleResource, err := domain.NewRoleResource(roleID, resourceID, domain.AccessScope(scope))
-	if err != nil {
-		return err
-	}
+func (s *permissionService) AssignResourceToRole(ctx context.Context, roleID uint, resourceID uint, scope string) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		roleResource, err := domain.NewRoleResource(roleID, resourceID, domain.AccessScope(scope))
+		if err != nil {
+			return err
+		}
+
+		if err := s.resourceRepo.AssignResourceToRole(ctx, roleResource); err != nil {
+			return err
+		}
 
-	return s.resourceRepo.AssignResourceToRole(roleResource)
+		return s.auditService.Record(ctx, auditDomain.ActionAssign, roleAggregate, roleID, nil, roleResource)
+	})
 }
 
-func (s *permissionService) AssignResourceToEntity(inp input.AssignResourceInput) error {
-	entityResource, err := domain.NewEntityResource(
-		inp.EntityID,
-		inp.ResourceID,
-		domain.AccessScope(inp.Scope),
-		inp.EntityID, // assigned by self for now
-		inp.Reason,
-	)
-	if err != nil {
-		return err
-	}
+func (s *permissionService) AssignResourceToEntity(ctx context.Context, inp input.AssignResourceInput) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		entityResource, err := domain.NewEntityResource(
+			inp.EntityID,
+			inp.ResourceID,
+			domain.AccessScope(inp.Scope),
+			inp.EntityID, // assigned by self for now
+			inp.Reason,
+		)
+		if err != nil {
+			return err
+		}
+
+		if err := s.resourceRepo.AssignResourceToEntity(ctx, entityResource); err != nil {
+			return err
+		}
 
-	return s.resourceRepo.AssignResourceToEntity(entityResource)
+		return s.auditService.Record(ctx, auditDomain.ActionAssign, entityAggregate, inp.EntityID, nil, entityResource)
+	})
 }
 
 // Check
 
-func (s *permissionService) CanAccess(inp input.CheckPermissionInput) (bool, error) {
+func (s *permissionService) CanAccess(ctx context.Context, inp input.CheckPermissionInput) (bool, error) {
 	// Obtener roles del entity
-	roles, err := s.roleRepo.FindRolesByEntityID(inp.EntityID)
+	roles, err := s.roleRepo.FindRolesByEntityID(ctx, inp.EntityID)
 	if err != nil {
 		return false, err
 	}
 
 	// Obtener permisos directos
-	entityResources, err := s.resourceRepo.FindResourcesByEntityID(inp.EntityID)
+	entityResources, err := s.resourceRepo.FindResourcesByEntityID(ctx, inp.EntityID)
 	if err != nil {
 		return false, err
 	}
@@ -120,7 +173,7 @@ func (s *permissionService) CanAccess(inp input.CheckPermissionInput) (bool, err
 	// Obtener permisos por rol
 	var roleResources []*domain.RoleResource
 	for _, role := range roles {
-		rr, err := s.resourceRepo.FindResourcesByRoleID(role.ID)
+		rr, err := s.resourceRepo.FindResourcesByRoleID(ctx, role.ID)
 		if err != nil {
 			return false, err
 		}
@@ -155,21 +208,21 @@ func (s *permissionService) CanAccess(inp input.CheckPermissionInput) (bool, err
 	return checker.CanAccess(inp.EntityID, inp.ResourceID), nil
 }
 
-func (s *permissionService) GetScope(entityID uint, resourceID uint) (domain.AccessScope, error) {
+func (s *permissionService) GetScope(ctx context.Context, entityID uint, resourceID uint) (domain.AccessScope, error) {
 	// Similar a CanAccess pero retorna el scope
-	roles, err := s.roleRepo.FindRolesByEntityID(entityID)
+	roles, err := s.roleRepo.FindRolesByEntityID(ctx, entityID)
 	if err != nil {
 		return domain.AccessScopeNone, err
 	}
 
-	entityResources, err := s.resourceRepo.FindResourcesByEntityID(entityID)
+	entityResources, err := s.resourceRepo.FindResourcesByEntityID(ctx, entityID)
 	if err != nil {
 		return domain.AccessScopeNone, err
 	}
 
 	var roleResources []*domain.RoleResource
 	for _, role := range roles {
-		rr, err := s.resourceRepo.FindResourcesByRoleID(role.ID)
+		rr, err := s.resourceRepo.FindResourcesByRoleID(ctx, role.ID)
 		if err != nil {
 			return domain.AccessScopeNone, err
 		}
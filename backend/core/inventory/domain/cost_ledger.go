@@ -0,0 +1,214 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+type CostEntryType string
+
+const (
+	CostEntryAcquisition CostEntryType = "acquisition"
+	CostEntryTransport   CostEntryType = "transport"
+	CostEntryReconParts  CostEntryType = "recon_parts"
+	CostEntryReconLabor  CostEntryType = "recon_labor"
+	CostEntryDetail      CostEntryType = "detail"
+	CostEntryFee         CostEntryType = "fee"
+	CostEntryPack        CostEntryType = "pack"
+)
+
+func CostEntryTypes() []CostEntryType {
+	return []CostEntryType{
+		CostEntryAcquisition,
+		CostEntryTransport,
+		CostEntryReconParts,
+		CostEntryReconLabor,
+		CostEntryDetail,
+		CostEntryFee,
+		CostEntryPack,
+	}
+}
+
+func (t CostEntryType) IsValid() bool {
+	for _, v := range CostEntryTypes() {
+		if t == v {
+			return true
+		}
+	}
+	return false
+}
+
+// CostEntry - un coste cargado a mano en el ledger del vehicle. Las entradas no se editan:
+// un error se corrige anulando la entrada y cargando otra
+type CostEntry struct {
+	ID         uint
+	VehicleID  uint
+	Type       CostEntryType
+	Amount     float64
+	Vendor     string
+	InvoiceRef string
+	IncurredOn time.Time
+	Notes      string
+	CreatedBy  uint
+	VoidedAt   *time.Time
+	VoidedBy   *uint
+	CreatedAt  time.Time
+}
+
+// NewCostEntry - el precio de compra vive en los datos de adquisición del vehicle, aquí
+// solo entran costes de adquisición adicionales (comisión de subasta, gestoría...)
+func NewCostEntry(vehicle *Vehicle, entryType CostEntryType, amount float64, vendor string, invoiceRef string, incurredOn time.Time, notes string, createdBy uint) (*CostEntry, error) {
+	if vehicle.IsDeleted() {
+		return nil, sharedDomain.Invariant("vehicle_deleted", "cannot add costs to deleted vehicle")
+	}
+	if !entryType.IsValid() {
+		return nil, sharedDomain.Invalid("type", "invalid cost entry type")
+	}
+	if amount <= 0 {
+		return nil, sharedDomain.Invalid("amount", "amount must be positive")
+	}
+	now := time.Now()
+	if incurredOn.IsZero() {
+		incurredOn = now
+	}
+	if incurredOn.After(now) {
+		return nil, sharedDomain.Invalid("incurred_on", "incurred_on cannot be in the future")
+	}
+
+	return &CostEntry{
+		VehicleID:  vehicle.ID,
+		Type:       entryType,
+		Amount:     amount,
+		Vendor:     strings.TrimSpace(vendor),
+		InvoiceRef: strings.TrimSpace(invoiceRef),
+		IncurredOn: incurredOn,
+		Notes:      strings.TrimSpace(notes),
+		CreatedBy:  createdBy,
+		CreatedAt:  now,
+	}, nil
+}
+
+func (e *CostEntry) IsVoided() bool {
+	return e.VoidedAt != nil
+}
+
+func (e *CostEntry) Void(voidedBy uint) error {
+	if e.IsVoided() {
+		return sharedDomain.Invariant("cost_entry_voided", "cost entry is already voided")
+	}
+	now := time.Now()
+	e.VoidedAt = &now
+	e.VoidedBy = &voidedBy
+	return nil
+}
+
+// CostLedgerLine - una línea del ledger. EntryID indica una entrada cargada a mano y
+// ReconTaskID una tarea de recon completada; sin ninguno es la compra del vehicle
+type CostLedgerLine struct {
+	Type         CostEntryType
+	Amount       float64
+	Vendor       string
+	InvoiceRef   string
+	IncurredOn   time.Time
+	Notes        string
+	EntryID      *uint
+	ReconTaskID  *uint
+	RunningTotal float64
+}
+
+// CostLedger - coste total del vehicle línea a línea, en orden cronológico. TotalCost
+// coincide con Vehicle.CostBasis
+type CostLedger struct {
+	VehicleID   uint
+	Lines       []CostLedgerLine
+	TotalByType map[CostEntryType]float64
+	TotalCost   float64
+	SalePrice   *float64
+	Profit      float64
+	Margin      float64
+}
+
+// NewCostLedger - junta la compra del vehicle, las tareas de recon completadas (el detallado
+// como detail, el resto como mano de obra de recon) y las entradas no anuladas
+func NewCostLedger(vehicle *Vehicle, entries []*CostEntry, reconOrders []*ReconOrder) *CostLedger {
+	var lines []CostLedgerLine
+
+	if vehicle.AcquisitionCost > 0 {
+		date := vehicle.AcquisitionDate
+		if date.IsZero() {
+			date = vehicle.CreatedAt
+		}
+		lines = append(lines, CostLedgerLine{
+			Type:       CostEntryAcquisition,
+			Amount:     vehicle.AcquisitionCost,
+			Vendor:     string(vehicle.AcquisitionSource),
+			IncurredOn: date,
+		})
+	}
+
+	for _, o := range reconOrders {
+		for _, t := range o.Tasks {
+			if t.Status != ReconTaskCompleted || t.ActualCost == nil || *t.ActualCost == 0 {
+				continue
+			}
+			entryType := CostEntryReconLabor
+			if t.Type == ReconTaskDetail {
+				entryType = CostEntryDetail
+			}
+			taskID := t.ID
+			lines = append(lines, CostLedgerLine{
+				Type:        entryType,
+				Amount:      *t.ActualCost,
+				Vendor:      t.Vendor,
+				IncurredOn:  *t.CompletedAt,
+				Notes:       t.Description,
+				ReconTaskID: &taskID,
+			})
+		}
+	}
+
+	for _, e := range entries {
+		if e.IsVoided() {
+			continue
+		}
+		entryID := e.ID
+		lines = append(lines, CostLedgerLine{
+			Type:       e.Type,
+			Amount:     e.Amount,
+			Vendor:     e.Vendor,
+			InvoiceRef: e.InvoiceRef,
+			IncurredOn: e.IncurredOn,
+			Notes:      e.Notes,
+			EntryID:    &entryID,
+		})
+	}
+
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lines[i].IncurredOn.Before(lines[j].IncurredOn)
+	})
+
+	ledger := &CostLedger{
+		VehicleID:   vehicle.ID,
+		Lines:       lines,
+		TotalByType: map[CostEntryType]float64{},
+		SalePrice:   vehicle.SalePrice,
+	}
+	for i := range ledger.Lines {
+		ledger.TotalCost += ledger.Lines[i].Amount
+		ledger.Lines[i].RunningTotal = ledger.TotalCost
+		ledger.TotalByType[ledger.Lines[i].Type] += ledger.Lines[i].Amount
+	}
+	ledger.Profit, ledger.Margin = profitAndMargin(vehicle.ProfitPrice(), ledger.TotalCost)
+	return ledger
+}
+
+func profitAndMargin(price float64, cost float64) (float64, float64) {
+	profit := price - cost
+	if price == 0 {
+		return profit, 0
+	}
+	return profit, (profit / price) * 100
+}
@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewCostEntry(t *testing.T) {
+	vehicle := &Vehicle{ID: 1}
+	tests := []struct {
+		name       string
+		entryType  CostEntryType
+		amount     float64
+		incurredOn time.Time
+		wantErr    bool
+	}{
+		{"valid", CostEntryTransport, 350, time.Now().Add(-time.Hour), false},
+		{"defaults to today", CostEntryPack, 500, time.Time{}, false},
+		{"invalid type", CostEntryType("tip"), 10, time.Time{}, true},
+		{"zero amount", CostEntryFee, 0, time.Time{}, true},
+		{"future date", CostEntryFee, 10, time.Now().Add(48 * time.Hour), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry, err := NewCostEntry(vehicle, tt.entryType, tt.amount, " Haulers ", "INV-1", tt.incurredOn, "", 9)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewCostEntry() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (entry.IncurredOn.IsZero() || entry.Vendor != "Haulers") {
+				t.Errorf("NewCostEntry() = %+v", entry)
+			}
+		})
+	}
+}
+
+func TestCostEntryVoid(t *testing.T) {
+	entry, _ := NewCostEntry(&Vehicle{ID: 1}, CostEntryFee, 75, "", "", time.Time{}, "", 9)
+	if err := entry.Void(9); err != nil {
+		t.Fatalf("Void() error = %v", err)
+	}
+	if err := entry.Void(9); err == nil {
+		t.Error("Void() expected error for voided entry")
+	}
+}
+
+func TestNewCostLedger(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC) }
+	cost := func(v float64) *float64 { return &v }
+	completed := day(5)
+
+	vehicle := &Vehicle{
+		ID:                1,
+		AskingPrice:       20000,
+		AcquisitionSource: AcquisitionSourceAuction,
+		AcquisitionDate:   day(1),
+		AcquisitionCost:   15000,
+		ReconCost:         800,
+		AdditionalCost:    850,
+	}
+	orders := []*ReconOrder{{Tasks: []*ReconTask{
+		{ID: 3, Type: ReconTaskMechanical, Status: ReconTaskCompleted, ActualCost: cost(600), CompletedAt: &completed},
+		{ID: 4, Type: ReconTaskDetail, Status: ReconTaskCompleted, ActualCost: cost(200), CompletedAt: &completed},
+		{ID: 5, Type: ReconTaskBody, Status: ReconTaskCancelled},
+	}}}
+	voided := day(4)
+	entries := []*CostEntry{
+		{ID: 1, Type: CostEntryTransport, Amount: 350, IncurredOn: day(2)},
+		{ID: 2, Type: CostEntryFee, Amount: 99, IncurredOn: day(3), VoidedAt: &voided},
+		{ID: 3, Type: CostEntryPack, Amount: 500, IncurredOn: day(6)},
+	}
+
+	ledger := NewCostLedger(vehicle, entries, orders)
+	if len(ledger.Lines) != 5 {
+		t.Fatalf("len(Lines) = %d, want 5", len(ledger.Lines))
+	}
+	if ledger.Lines[0].Type != CostEntryAcquisition || ledger.Lines[1].Type != CostEntryTransport {
+		t.Errorf("Lines not in date order: %+v", ledger.Lines)
+	}
+	if got := ledger.Lines[1].RunningTotal; got != 15350 {
+		t.Errorf("RunningTotal = %v, want 15350", got)
+	}
+	if ledger.TotalCost != vehicle.CostBasis() {
+		t.Errorf("TotalCost = %v, want CostBasis %v", ledger.TotalCost, vehicle.CostBasis())
+	}
+	if ledger.TotalByType[CostEntryReconLabor] != 600 || ledger.TotalByType[CostEntryDetail] != 200 {
+		t.Errorf("TotalByType = %v", ledger.TotalByType)
+	}
+	if ledger.Profit != 20000-16650 {
+		t.Errorf("Profit = %v, want expected profit at asking price", ledger.Profit)
+	}
+}
+
+func TestVehicleProfitUsesSalePrice(t *testing.T) {
+	v := &Vehicle{Status: VehicleStatusReadyForSale, AskingPrice: 20000, AcquisitionCost: 15000, ReconCost: 1000}
+	if err := v.SetSalePrice(19000); err == nil {
+		t.Error("SetSalePrice() expected error for unsold vehicle")
+	}
+	if got := v.Profit(); got != 4000 {
+		t.Errorf("Profit() = %v, want 4000", got)
+	}
+
+	if err := v.MarkAsSold(); err != nil {
+		t.Fatalf("MarkAsSold() error = %v", err)
+	}
+	if err := v.SetSalePrice(19000); err != nil {
+		t.Fatalf("SetSalePrice() error = %v", err)
+	}
+	if got := v.Profit(); got != 3000 {
+		t.Errorf("Profit() = %v, want 3000", got)
+	}
+	if got := v.Margin(); got < 15.78 || got > 15.79 {
+		t.Errorf("Margin() = %v, want ~15.79", got)
+	}
+
+	if err := v.UnwindSale(VehicleStatusReadyForSale); err != nil {
+		t.Fatalf("UnwindSale() error = %v", err)
+	}
+	if v.SalePrice != nil {
+		t.Error("SalePrice kept after unwinding the sale")
+	}
+}
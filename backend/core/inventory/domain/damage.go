@@ -0,0 +1,259 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+type DamageType string
+
+const (
+	DamageTypeScratch DamageType = "scratch"
+	DamageTypeDent    DamageType = "dent"
+	DamageTypeChip    DamageType = "chip"
+	DamageTypeCrack   DamageType = "crack"
+	DamageTypeRust    DamageType = "rust"
+	DamageTypeScuff   DamageType = "scuff"
+	DamageTypeBroken  DamageType = "broken"
+	DamageTypeMissing DamageType = "missing"
+	DamageTypeStain   DamageType = "stain"
+	DamageTypeTear    DamageType = "tear"
+	DamageTypeOther   DamageType = "other"
+)
+
+func DamageTypes() []DamageType {
+	return []DamageType{
+		DamageTypeScratch,
+		DamageTypeDent,
+		DamageTypeChip,
+		DamageTypeCrack,
+		DamageTypeRust,
+		DamageTypeScuff,
+		DamageTypeBroken,
+		DamageTypeMissing,
+		DamageTypeStain,
+		DamageTypeTear,
+		DamageTypeOther,
+	}
+}
+
+func (d DamageType) IsValid() bool {
+	for _, v := range DamageTypes() {
+		if d == v {
+			return true
+		}
+	}
+	return false
+}
+
+type DamageSeverity string
+
+const (
+	DamageSeverityMinor    DamageSeverity = "minor"
+	DamageSeverityModerate DamageSeverity = "moderate"
+	DamageSeveritySevere   DamageSeverity = "severe"
+)
+
+func DamageSeverities() []DamageSeverity {
+	return []DamageSeverity{
+		DamageSeverityMinor,
+		DamageSeverityModerate,
+		DamageSeveritySevere,
+	}
+}
+
+func (s DamageSeverity) IsValid() bool {
+	for _, v := range DamageSeverities() {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Weight - puntos que suma cada daño pendiente al score del condition report
+func (s DamageSeverity) Weight() int {
+	switch s {
+	case DamageSeveritySevere:
+		return 40
+	case DamageSeverityModerate:
+		return 15
+	default:
+		return 5
+	}
+}
+
+// VehicleZoneMark - daño marcado sobre una zona del modelo 3D del vehicle
+type VehicleZoneMark struct {
+	ID          uint
+	VehicleID   uint
+	ZoneID      uint
+	Type        DamageType
+	Severity    DamageSeverity
+	Description string
+	PhotoID     *uint
+	ReportedBy  uint
+	Resolved    bool
+	ResolvedBy  *uint
+	ResolvedAt  *time.Time
+	CreatedAt   time.Time
+}
+
+// NewVehicleZoneMark - la zona tiene que ser del modelo asignado al vehicle; si no, el visor
+// no podría ubicar el daño
+func NewVehicleZoneMark(vehicle *Vehicle, zone *VehicleModelZone, damageType DamageType, severity DamageSeverity, description string, reportedBy uint) (*VehicleZoneMark, error) {
+	if vehicle.Model3DID == nil {
+		return nil, sharedDomain.Invariant("vehicle_without_model", "assign a 3D model to the vehicle before marking damage")
+	}
+	if zone.Model3DID != *vehicle.Model3DID {
+		return nil, sharedDomain.Invalid("zone_id", "zone does not belong to the vehicle's 3D model")
+	}
+	if !damageType.IsValid() {
+		return nil, sharedDomain.Invalid("type", "invalid damage type")
+	}
+	if !severity.IsValid() {
+		return nil, sharedDomain.Invalid("severity", "invalid damage severity")
+	}
+	if reportedBy == 0 {
+		return nil, sharedDomain.Invalid("reported_by", "reported_by is required")
+	}
+
+	return &VehicleZoneMark{
+		VehicleID:   vehicle.ID,
+		ZoneID:      zone.ID,
+		Type:        damageType,
+		Severity:    severity,
+		Description: strings.TrimSpace(description),
+		ReportedBy:  reportedBy,
+		CreatedAt:   time.Now(),
+	}, nil
+}
+
+// AttachPhoto - solo fotos del mismo vehicle tomadas como evidencia de daño
+func (m *VehicleZoneMark) AttachPhoto(photo *VehiclePhoto) error {
+	if photo.VehicleID != m.VehicleID {
+		return sharedDomain.Invalid("photo_id", "photo belongs to another vehicle")
+	}
+	if photo.Purpose != PhotoPurposeDamage {
+		return sharedDomain.Invalid("photo_id", "photo purpose must be damage")
+	}
+	id := photo.ID
+	m.PhotoID = &id
+	return nil
+}
+
+func (m *VehicleZoneMark) Resolve(resolvedBy uint) error {
+	if m.Resolved {
+		return sharedDomain.Invariant("mark_already_resolved", "damage mark is already resolved")
+	}
+	if resolvedBy == 0 {
+		return sharedDomain.Invalid("resolved_by", "resolved_by is required")
+	}
+	now := time.Now()
+	m.Resolved = true
+	m.ResolvedBy = &resolvedBy
+	m.ResolvedAt = &now
+	return nil
+}
+
+// ConditionGrade - lectura rápida del score para tasación y entrega
+type ConditionGrade string
+
+const (
+	ConditionGradeExcellent ConditionGrade = "excellent"
+	ConditionGradeGood      ConditionGrade = "good"
+	ConditionGradeFair      ConditionGrade = "fair"
+	ConditionGradePoor      ConditionGrade = "poor"
+)
+
+const MaxSeverityScore = 100
+
+func conditionGrade(score int) ConditionGrade {
+	switch {
+	case score == 0:
+		return ConditionGradeExcellent
+	case score <= 15:
+		return ConditionGradeGood
+	case score <= 40:
+		return ConditionGradeFair
+	default:
+		return ConditionGradePoor
+	}
+}
+
+// ZoneDamage - daños pendientes de una zona; Zone es nil si la zona ya no se encuentra
+type ZoneDamage struct {
+	ZoneID uint
+	Zone   *VehicleModelZone
+	Marks  []*VehicleZoneMark
+	Score  int
+}
+
+// ConditionReport - mapa de daños pendientes del vehicle. El score suma el peso de cada
+// daño pendiente con tope en MaxSeverityScore
+type ConditionReport struct {
+	VehicleID     uint
+	Model3DID     *uint
+	Zones         []ZoneDamage
+	Outstanding   int
+	Resolved      int
+	BySeverity    map[DamageSeverity]int
+	SeverityScore int
+	Grade         ConditionGrade
+	GeneratedAt   time.Time
+}
+
+// NewConditionReport - zones puede incluir zonas sin daños; solo aparecen las que tienen
+// marcas pendientes, de la más dañada a la menos
+func NewConditionReport(vehicle *Vehicle, zones []*VehicleModelZone, marks []*VehicleZoneMark) *ConditionReport {
+	report := &ConditionReport{
+		VehicleID:   vehicle.ID,
+		Model3DID:   vehicle.Model3DID,
+		BySeverity:  make(map[DamageSeverity]int, len(DamageSeverities())),
+		GeneratedAt: time.Now(),
+	}
+	for _, s := range DamageSeverities() {
+		report.BySeverity[s] = 0
+	}
+
+	zoneByID := make(map[uint]*VehicleModelZone, len(zones))
+	for _, z := range zones {
+		zoneByID[z.ID] = z
+	}
+
+	byZone := make(map[uint]*ZoneDamage)
+	var order []uint
+	for _, m := range marks {
+		if m.Resolved {
+			report.Resolved++
+			continue
+		}
+		report.Outstanding++
+		report.BySeverity[m.Severity]++
+		report.SeverityScore += m.Severity.Weight()
+
+		zd, ok := byZone[m.ZoneID]
+		if !ok {
+			zd = &ZoneDamage{ZoneID: m.ZoneID, Zone: zoneByID[m.ZoneID]}
+			byZone[m.ZoneID] = zd
+			order = append(order, m.ZoneID)
+		}
+		zd.Marks = append(zd.Marks, m)
+		zd.Score += m.Severity.Weight()
+	}
+
+	report.Zones = make([]ZoneDamage, 0, len(order))
+	for _, id := range order {
+		report.Zones = append(report.Zones, *byZone[id])
+	}
+	sort.SliceStable(report.Zones, func(i, j int) bool { return report.Zones[i].Score > report.Zones[j].Score })
+
+	if report.SeverityScore > MaxSeverityScore {
+		report.SeverityScore = MaxSeverityScore
+	}
+	report.Grade = conditionGrade(report.SeverityScore)
+	return report
+}
@@ -0,0 +1,75 @@
+package domain
+
+import "testing"
+
+func TestNewVehicleZoneMark(t *testing.T) {
+	model := uint(3)
+	vehicle := &Vehicle{ID: 1, Model3DID: &model}
+	zone := &VehicleModelZone{ID: 7, Model3DID: 3}
+
+	if _, err := NewVehicleZoneMark(&Vehicle{ID: 1}, zone, DamageTypeDent, DamageSeverityMinor, "", 9); err == nil {
+		t.Error("NewVehicleZoneMark() expected error for vehicle without model")
+	}
+	if _, err := NewVehicleZoneMark(vehicle, &VehicleModelZone{ID: 8, Model3DID: 4}, DamageTypeDent, DamageSeverityMinor, "", 9); err == nil {
+		t.Error("NewVehicleZoneMark() expected error for zone of another model")
+	}
+	if _, err := NewVehicleZoneMark(vehicle, zone, DamageTypeDent, DamageSeverity("total"), "", 9); err == nil {
+		t.Error("NewVehicleZoneMark() expected error for invalid severity")
+	}
+
+	mark, err := NewVehicleZoneMark(vehicle, zone, DamageTypeDent, DamageSeverityModerate, " door ding ", 9)
+	if err != nil {
+		t.Fatalf("NewVehicleZoneMark() error = %v", err)
+	}
+	if mark.Description != "door ding" {
+		t.Errorf("Description = %q, want trimmed", mark.Description)
+	}
+
+	if err := mark.AttachPhoto(&VehiclePhoto{ID: 4, VehicleID: 1, Purpose: PhotoPurposeListing}); err == nil {
+		t.Error("AttachPhoto() expected error for listing photo")
+	}
+	if err := mark.AttachPhoto(&VehiclePhoto{ID: 4, VehicleID: 2, Purpose: PhotoPurposeDamage}); err == nil {
+		t.Error("AttachPhoto() expected error for photo of another vehicle")
+	}
+	if err := mark.AttachPhoto(&VehiclePhoto{ID: 4, VehicleID: 1, Purpose: PhotoPurposeDamage}); err != nil {
+		t.Fatalf("AttachPhoto() error = %v", err)
+	}
+
+	if err := mark.Resolve(5); err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if !mark.Resolved || mark.ResolvedBy == nil || mark.ResolvedAt == nil {
+		t.Errorf("Resolve() left Resolved = %v, ResolvedBy = %v, ResolvedAt = %v", mark.Resolved, mark.ResolvedBy, mark.ResolvedAt)
+	}
+	if err := mark.Resolve(5); err == nil {
+		t.Error("Resolve() expected error for resolved mark")
+	}
+}
+
+func TestNewConditionReport(t *testing.T) {
+	vehicle := &Vehicle{ID: 1}
+	hood := &VehicleModelZone{ID: 1, Code: "hood"}
+	door := &VehicleModelZone{ID: 2, Code: "front_left_door"}
+	marks := []*VehicleZoneMark{
+		{ZoneID: 1, Severity: DamageSeverityMinor},
+		{ZoneID: 2, Severity: DamageSeveritySevere},
+		{ZoneID: 1, Severity: DamageSeverityModerate},
+		{ZoneID: 2, Severity: DamageSeveritySevere, Resolved: true},
+	}
+
+	report := NewConditionReport(vehicle, []*VehicleModelZone{hood, door}, marks)
+	if report.Outstanding != 3 || report.Resolved != 1 {
+		t.Errorf("Outstanding = %d, Resolved = %d, want 3 and 1", report.Outstanding, report.Resolved)
+	}
+	if report.SeverityScore != 60 || report.Grade != ConditionGradePoor {
+		t.Errorf("SeverityScore = %d, Grade = %s, want 60 and poor", report.SeverityScore, report.Grade)
+	}
+	if len(report.Zones) != 2 || report.Zones[0].Zone != door || report.Zones[1].Score != 20 {
+		t.Errorf("Zones = %+v, want door first and hood with score 20", report.Zones)
+	}
+
+	clean := NewConditionReport(vehicle, nil, nil)
+	if clean.SeverityScore != 0 || clean.Grade != ConditionGradeExcellent || len(clean.Zones) != 0 {
+		t.Errorf("clean report = %+v", clean)
+	}
+}
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"strings"
+
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+// Radio máximo de las búsquedas por distancia
+const MaxSearchRadiusKM = 1000
+
+// GeoOrigin - punto de partida de una búsqueda: coordenadas, un código postal o una de
+// nuestras ubicaciones. Se usa el primero que venga informado
+type GeoOrigin struct {
+	Point      *sharedDomain.GeoPoint
+	PostalCode string
+	Country    string
+	LocationID uint
+}
+
+func (o GeoOrigin) IsZero() bool {
+	return o.Point == nil && strings.TrimSpace(o.PostalCode) == "" && o.LocationID == 0
+}
+
+type LocationDistance struct {
+	Location   *Location
+	DistanceKM float64
+}
+
+func ValidateRadius(radiusKM float64) error {
+	if radiusKM <= 0 || radiusKM > MaxSearchRadiusKM {
+		return sharedDomain.Invalid("radius_km", "radius must be between 0 and 1000 km")
+	}
+	return nil
+}
@@ -0,0 +1,206 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+const (
+	MaxGeofenceRadiusM  = 5000
+	MaxGeofenceVertices = 100
+
+	// Histéresis: para salir hay que alejarse este margen del borde, así el ruido del GPS
+	// junto a la valla no genera entradas y salidas seguidas
+	geofenceExitMarginM = 25.0
+)
+
+// Geofence - zona de una ubicación: un círculo alrededor de sus coordenadas o un polígono
+type Geofence struct {
+	RadiusM float64
+	Polygon []sharedDomain.GeoPoint
+}
+
+func NewGeofence(radiusM float64, polygon []sharedDomain.GeoPoint) (*Geofence, error) {
+	if radiusM != 0 && len(polygon) > 0 {
+		return nil, sharedDomain.Invalid("geofence", "use either a radius or a polygon")
+	}
+
+	if len(polygon) > 0 {
+		if len(polygon) < 3 || len(polygon) > MaxGeofenceVertices {
+			return nil, sharedDomain.Invalid("polygon", "polygon needs between 3 and 100 vertices")
+		}
+		for _, p := range polygon {
+			if _, err := sharedDomain.NewGeoPoint(p.Latitude, p.Longitude); err != nil {
+				return nil, sharedDomain.Invalid("polygon", "invalid vertex")
+			}
+		}
+		return &Geofence{Polygon: polygon}, nil
+	}
+
+	if math.IsNaN(radiusM) || radiusM <= 0 || radiusM > MaxGeofenceRadiusM {
+		return nil, sharedDomain.Invalid("radius_m", "radius must be between 0 and 5000 meters")
+	}
+	return &Geofence{RadiusM: radiusM}, nil
+}
+
+// contains - marginM ensancha la zona; center solo se usa en los círculos
+func (g *Geofence) contains(center sharedDomain.GeoPoint, p sharedDomain.GeoPoint, marginM float64) bool {
+	if len(g.Polygon) > 0 {
+		return sharedDomain.InPolygon(g.Polygon, p) ||
+			(marginM > 0 && sharedDomain.DistanceToRingKM(g.Polygon, p)*1000 <= marginM)
+	}
+	return center.DistanceKM(p)*1000 <= g.RadiusM+marginM
+}
+
+type GeofenceEventType string
+
+const (
+	GeofenceEventEnter GeofenceEventType = "enter"
+	GeofenceEventExit  GeofenceEventType = "exit"
+)
+
+func GeofenceEventTypes() []GeofenceEventType {
+	return []GeofenceEventType{
+		GeofenceEventEnter,
+		GeofenceEventExit,
+	}
+}
+
+// GeofenceOutcome - qué se hizo con el vehicle al cruzar la geocerca
+type GeofenceOutcome string
+
+const (
+	GeofenceOutcomeTransferCompleted GeofenceOutcome = "transfer_completed"
+	GeofenceOutcomeMoved             GeofenceOutcome = "moved"
+	GeofenceOutcomeRecorded          GeofenceOutcome = "recorded"
+	GeofenceOutcomeIgnored           GeofenceOutcome = "ignored"
+)
+
+func GeofenceOutcomes() []GeofenceOutcome {
+	return []GeofenceOutcome{
+		GeofenceOutcomeTransferCompleted,
+		GeofenceOutcomeMoved,
+		GeofenceOutcomeRecorded,
+		GeofenceOutcomeIgnored,
+	}
+}
+
+// GeofenceEvent - cruce de la geocerca de una ubicación detectado en los puntos GPS.
+// Note explica por qué un cruce se ignoró
+type GeofenceEvent struct {
+	ID         uint
+	VehicleID  uint
+	LocationID uint
+	Type       GeofenceEventType
+	Latitude   float64
+	Longitude  float64
+	RecordedAt time.Time
+	Outcome    GeofenceOutcome
+	Note       string
+	CreatedAt  time.Time
+}
+
+func newGeofenceEvent(point *TrackingPoint, locationID uint, eventType GeofenceEventType) *GeofenceEvent {
+	return &GeofenceEvent{
+		VehicleID:  point.VehicleID,
+		LocationID: locationID,
+		Type:       eventType,
+		Latitude:   point.Latitude,
+		Longitude:  point.Longitude,
+		RecordedAt: point.RecordedAt,
+		Outcome:    GeofenceOutcomeIgnored,
+		CreatedAt:  time.Now(),
+	}
+}
+
+// Resolve - deja constancia de lo que se hizo; un error de dominio queda como nota
+func (e *GeofenceEvent) Resolve(outcome GeofenceOutcome, note string) {
+	e.Outcome = outcome
+	e.Note = note
+}
+
+// GeofencePosition - geocerca en la que estaba el vehicle según lo ya procesado (0 fuera de
+// todas). Known es false para su primer punto, que fija la posición sin generar eventos
+type GeofencePosition struct {
+	LocationID uint
+	Since      time.Time
+	Known      bool
+}
+
+// NewGeofencePosition - el último cruce registrado manda; sin cruces se evalúa el último
+// punto guardado. Sin ninguno de los dos la posición es desconocida
+func NewGeofencePosition(fences []*Location, previous *TrackingPoint, last *GeofenceEvent) GeofencePosition {
+	var position GeofencePosition
+	if previous != nil {
+		position = GeofencePosition{LocationID: FenceAt(fences, 0, previous.Point()), Since: previous.RecordedAt, Known: true}
+	}
+	if last != nil {
+		position.LocationID = 0
+		if last.Type == GeofenceEventEnter {
+			position.LocationID = last.LocationID
+		}
+		if last.RecordedAt.After(position.Since) {
+			position.Since = last.RecordedAt
+		}
+		position.Known = true
+	}
+	return position
+}
+
+// DetectCrossings - recorre en orden los puntos de un vehicle y devuelve las salidas y
+// entradas. Los puntos que llegan tarde (anteriores a position.Since) no se evalúan
+func DetectCrossings(fences []*Location, position GeofencePosition, points []*TrackingPoint) []*GeofenceEvent {
+	sorted := make([]*TrackingPoint, 0, len(points))
+	for _, p := range points {
+		if !position.Known || p.RecordedAt.After(position.Since) {
+			sorted = append(sorted, p)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].RecordedAt.Before(sorted[j].RecordedAt) })
+
+	var events []*GeofenceEvent
+	current := position.LocationID
+	for i, p := range sorted {
+		next := FenceAt(fences, current, p.Point())
+		if i == 0 && !position.Known {
+			current = next
+			continue
+		}
+		if next == current {
+			continue
+		}
+		if current != 0 {
+			events = append(events, newGeofenceEvent(p, current, GeofenceEventExit))
+		}
+		if next != 0 {
+			events = append(events, newGeofenceEvent(p, next, GeofenceEventEnter))
+		}
+		current = next
+	}
+	return events
+}
+
+// FenceAt - ubicación cuya geocerca contiene p, 0 si ninguna. El vehicle sigue en current
+// mientras no supere el margen de salida; si varias geocercas se solapan gana la más cercana
+func FenceAt(fences []*Location, current uint, p sharedDomain.GeoPoint) uint {
+	for _, l := range fences {
+		if l.ID == current && l.InGeofence(p, geofenceExitMarginM) {
+			return current
+		}
+	}
+
+	var nearest uint
+	nearestKM := math.Inf(1)
+	for _, l := range fences {
+		if !l.InGeofence(p, 0) {
+			continue
+		}
+		if d := l.geofenceCenter().DistanceKM(p); d < nearestKM {
+			nearest, nearestKM = l.ID, d
+		}
+	}
+	return nearest
+}
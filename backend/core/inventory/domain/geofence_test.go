@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+func TestNewGeofence(t *testing.T) {
+	if _, err := NewGeofence(0, nil); err == nil {
+		t.Error("NewGeofence() expected error without radius or polygon")
+	}
+	if _, err := NewGeofence(100, []sharedDomain.GeoPoint{{Latitude: 1, Longitude: 1}, {Latitude: 1, Longitude: 2}, {Latitude: 2, Longitude: 2}}); err == nil {
+		t.Error("NewGeofence() expected error with radius and polygon")
+	}
+	if _, err := NewGeofence(0, []sharedDomain.GeoPoint{{Latitude: 1, Longitude: 1}, {Latitude: 1, Longitude: 2}}); err == nil {
+		t.Error("NewGeofence() expected error for polygon with two vertices")
+	}
+
+	location := &Location{ID: 1, Type: LocationTypeSalesLot}
+	fence, _ := NewGeofence(100, nil)
+	if err := location.SetGeofence(fence); err == nil {
+		t.Error("SetGeofence() expected error for radius without coordinates")
+	}
+}
+
+func TestDetectCrossings(t *testing.T) {
+	// Lote con radio de 100 m; 0.001 grados de latitud son unos 111 m
+	lot := &Location{ID: 7, Latitude: 40, Longitude: -3, Geofence: &Geofence{RadiusM: 100}}
+	start := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	point := func(minute int, dLat float64) *TrackingPoint {
+		return &TrackingPoint{VehicleID: 3, Latitude: 40 + dLat, Longitude: -3, RecordedAt: start.Add(time.Duration(minute) * time.Minute)}
+	}
+	points := []*TrackingPoint{
+		point(3, 0.0011), // junto a la valla: dentro del margen de salida
+		point(1, 0.002),
+		point(2, 0.0005),
+		point(4, 0.002),
+	}
+
+	events := DetectCrossings([]*Location{lot}, GeofencePosition{Known: true}, points)
+	if len(events) != 2 {
+		t.Fatalf("len(events) = %d, want 2", len(events))
+	}
+	if events[0].Type != GeofenceEventEnter || !events[0].RecordedAt.Equal(start.Add(2*time.Minute)) {
+		t.Errorf("events[0] = %s at %v, want enter at minute 2", events[0].Type, events[0].RecordedAt)
+	}
+	if events[1].Type != GeofenceEventExit || !events[1].RecordedAt.Equal(start.Add(4*time.Minute)) {
+		t.Errorf("events[1] = %s at %v, want exit at minute 4", events[1].Type, events[1].RecordedAt)
+	}
+
+	// El primer punto de un vehicle solo fija su posición
+	if got := DetectCrossings([]*Location{lot}, GeofencePosition{}, points[2:3]); len(got) != 0 {
+		t.Errorf("first point len(events) = %d, want 0", len(got))
+	}
+
+	// Los puntos anteriores a lo ya procesado llegan tarde y se ignoran
+	late := GeofencePosition{LocationID: 7, Since: start.Add(5 * time.Minute), Known: true}
+	if got := DetectCrossings([]*Location{lot}, late, points); len(got) != 0 {
+		t.Errorf("late points len(events) = %d, want 0", len(got))
+	}
+}
+
+func TestNewGeofencePosition(t *testing.T) {
+	lot := &Location{ID: 7, Latitude: 40, Longitude: -3, Geofence: &Geofence{RadiusM: 100}}
+	start := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	inside := &TrackingPoint{Latitude: 40, Longitude: -3, RecordedAt: start}
+
+	if got := NewGeofencePosition([]*Location{lot}, nil, nil); got.Known {
+		t.Error("NewGeofencePosition() without history should be unknown")
+	}
+	if got := NewGeofencePosition([]*Location{lot}, inside, nil); got.LocationID != 7 {
+		t.Errorf("LocationID = %d, want 7", got.LocationID)
+	}
+
+	exit := &GeofenceEvent{LocationID: 7, Type: GeofenceEventExit, RecordedAt: start.Add(-time.Minute)}
+	got := NewGeofencePosition([]*Location{lot}, inside, exit)
+	if got.LocationID != 0 || !got.Since.Equal(start) {
+		t.Errorf("position = %d since %v, want 0 since %v", got.LocationID, got.Since, start)
+	}
+}
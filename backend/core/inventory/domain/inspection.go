@@ -0,0 +1,389 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+const MaxInspectionItems = 500
+
+// InspectionKind - para qué sirve la plantilla; las de tipo cpo son las que exige el programa
+// del fabricante antes de poner a la venta un vehicle certificado
+type InspectionKind string
+
+const (
+	InspectionKindCPO     InspectionKind = "cpo"
+	InspectionKindIntake  InspectionKind = "intake"
+	InspectionKindGeneral InspectionKind = "general"
+)
+
+func InspectionKinds() []InspectionKind {
+	return []InspectionKind{
+		InspectionKindCPO,
+		InspectionKindIntake,
+		InspectionKindGeneral,
+	}
+}
+
+func (k InspectionKind) IsValid() bool {
+	for _, v := range InspectionKinds() {
+		if k == v {
+			return true
+		}
+	}
+	return false
+}
+
+type ItemResult string
+
+const (
+	ItemResultPass ItemResult = "pass"
+	ItemResultFail ItemResult = "fail"
+	ItemResultNA   ItemResult = "na"
+)
+
+func ItemResults() []ItemResult {
+	return []ItemResult{
+		ItemResultPass,
+		ItemResultFail,
+		ItemResultNA,
+	}
+}
+
+func (r ItemResult) IsValid() bool {
+	for _, v := range ItemResults() {
+		if r == v {
+			return true
+		}
+	}
+	return false
+}
+
+// InspectionItem - punto a revisar. Con Unit es una medición (dibujo del neumático, pastillas
+// en mm); MinValue y MaxValue marcan el rango aceptable
+type InspectionItem struct {
+	ID            uint
+	SectionID     uint
+	Label         string
+	Required      bool
+	PhotoRequired bool
+	Unit          string
+	MinValue      *float64
+	MaxValue      *float64
+	SortOrder     int
+}
+
+func (i *InspectionItem) IsMeasured() bool {
+	return i.Unit != ""
+}
+
+func (i *InspectionItem) hasRange() bool {
+	return i.MinValue != nil || i.MaxValue != nil
+}
+
+// Evaluate - resultado de una medición según el rango del item
+func (i *InspectionItem) Evaluate(value float64) ItemResult {
+	if (i.MinValue != nil && value < *i.MinValue) || (i.MaxValue != nil && value > *i.MaxValue) {
+		return ItemResultFail
+	}
+	return ItemResultPass
+}
+
+func (i *InspectionItem) validate() error {
+	i.Label = strings.TrimSpace(i.Label)
+	i.Unit = strings.TrimSpace(i.Unit)
+	if i.Label == "" {
+		return sharedDomain.Invalid("label", "item label is required")
+	}
+	if i.hasRange() && !i.IsMeasured() {
+		return sharedDomain.Invalid("unit", fmt.Sprintf("item %q has a range but no unit", i.Label))
+	}
+	if i.MinValue != nil && i.MaxValue != nil && *i.MinValue > *i.MaxValue {
+		return sharedDomain.Invalid("min_value", fmt.Sprintf("item %q has min_value above max_value", i.Label))
+	}
+	return nil
+}
+
+type InspectionSection struct {
+	ID         uint
+	TemplateID uint
+	Name       string
+	SortOrder  int
+	Items      []*InspectionItem
+}
+
+// InspectionTemplate - checklist configurable (CPO de 150 puntos, entrada de usados...).
+// Una vez usada su estructura no cambia: los resultados apuntan a sus items
+type InspectionTemplate struct {
+	ID          uint
+	Name        string
+	Kind        InspectionKind
+	Description string
+	Active      bool
+	Sections    []*InspectionSection
+	Version     uint
+	CreatedAt   time.Time
+}
+
+func NewInspectionTemplate(name string, kind InspectionKind, description string, sections []*InspectionSection) (*InspectionTemplate, error) {
+	if name == "" {
+		return nil, sharedDomain.Invalid("name", "name is required")
+	}
+	if !kind.IsValid() {
+		return nil, sharedDomain.Invalid("kind", "invalid inspection kind")
+	}
+
+	template := &InspectionTemplate{
+		Name:        name,
+		Kind:        kind,
+		Description: description,
+		Active:      true,
+		CreatedAt:   time.Now(),
+	}
+	if err := template.SetStructure(sections); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// SetStructure - el orden de secciones e items es el de la lista recibida
+func (t *InspectionTemplate) SetStructure(sections []*InspectionSection) error {
+	if len(sections) == 0 {
+		return sharedDomain.Invalid("sections", "template needs at least one section")
+	}
+
+	total := 0
+	for i, section := range sections {
+		section.Name = strings.TrimSpace(section.Name)
+		if section.Name == "" {
+			return sharedDomain.Invalid("sections", "section name is required")
+		}
+		if len(section.Items) == 0 {
+			return sharedDomain.Invalid("sections", fmt.Sprintf("section %q has no items", section.Name))
+		}
+		section.SortOrder = i
+		for j, item := range section.Items {
+			if err := item.validate(); err != nil {
+				return err
+			}
+			item.SortOrder = j
+		}
+		total += len(section.Items)
+	}
+	if total > MaxInspectionItems {
+		return sharedDomain.Invalid("sections", fmt.Sprintf("template cannot have more than %d items", MaxInspectionItems))
+	}
+
+	t.Sections = sections
+	return nil
+}
+
+func (t *InspectionTemplate) Items() []*InspectionItem {
+	var items []*InspectionItem
+	for _, section := range t.Sections {
+		items = append(items, section.Items...)
+	}
+	return items
+}
+
+func (t *InspectionTemplate) Item(id uint) *InspectionItem {
+	for _, item := range t.Items() {
+		if item.ID == id {
+			return item
+		}
+	}
+	return nil
+}
+
+func (t *InspectionTemplate) Deactivate() {
+	t.Active = false
+}
+
+func (t *InspectionTemplate) Activate() {
+	t.Active = true
+}
+
+type InspectionStatus string
+
+const (
+	InspectionStatusInProgress InspectionStatus = "in_progress"
+	InspectionStatusCompleted  InspectionStatus = "completed"
+)
+
+func InspectionStatuses() []InspectionStatus {
+	return []InspectionStatus{
+		InspectionStatusInProgress,
+		InspectionStatusCompleted,
+	}
+}
+
+type InspectionResult struct {
+	ID           uint
+	InspectionID uint
+	ItemID       uint
+	Result       ItemResult
+	Value        *float64
+	PhotoID      *uint
+	Notes        string
+	RecordedAt   time.Time
+}
+
+// Inspection - checklist hecho sobre un vehicle. Passed solo tiene sentido completada:
+// ningún item obligatorio falló
+type Inspection struct {
+	ID          uint
+	VehicleID   uint
+	TemplateID  uint
+	Kind        InspectionKind
+	InspectorID uint
+	Status      InspectionStatus
+	Passed      bool
+	Notes       string
+	Results     []*InspectionResult
+	StartedAt   time.Time
+	CompletedAt *time.Time
+	Version     uint
+}
+
+func NewInspection(vehicle *Vehicle, template *InspectionTemplate, inspectorID uint) (*Inspection, error) {
+	if !template.Active {
+		return nil, sharedDomain.Invariant("template_inactive", "inspection template is inactive")
+	}
+	if inspectorID == 0 {
+		return nil, sharedDomain.Invalid("inspector_id", "inspector is required")
+	}
+
+	return &Inspection{
+		VehicleID:   vehicle.ID,
+		TemplateID:  template.ID,
+		Kind:        template.Kind,
+		InspectorID: inspectorID,
+		Status:      InspectionStatusInProgress,
+		StartedAt:   time.Now(),
+	}, nil
+}
+
+func (i *Inspection) IsCompleted() bool {
+	return i.Status == InspectionStatusCompleted
+}
+
+func (i *Inspection) Result(itemID uint) *InspectionResult {
+	for _, r := range i.Results {
+		if r.ItemID == itemID {
+			return r
+		}
+	}
+	return nil
+}
+
+// Record - guarda o reemplaza el resultado de un item. En las mediciones con rango el
+// resultado sale del valor; result solo hace falta para marcarlas como na
+func (i *Inspection) Record(item *InspectionItem, result ItemResult, value *float64, photo *VehiclePhoto, notes string) (*InspectionResult, error) {
+	if i.IsCompleted() {
+		return nil, sharedDomain.Invariant("inspection_completed", "inspection is already completed")
+	}
+
+	if result != ItemResultNA && item.IsMeasured() {
+		if value == nil {
+			return nil, sharedDomain.Invalid("value", fmt.Sprintf("item %q needs a measured value", item.Label))
+		}
+		if item.hasRange() {
+			result = item.Evaluate(*value)
+		}
+	}
+	if !result.IsValid() {
+		return nil, sharedDomain.Invalid("result", "result must be pass, fail or na")
+	}
+	if result == ItemResultNA {
+		value = nil
+	}
+
+	entry := &InspectionResult{
+		InspectionID: i.ID,
+		ItemID:       item.ID,
+		Result:       result,
+		Value:        value,
+		Notes:        strings.TrimSpace(notes),
+		RecordedAt:   time.Now(),
+	}
+	if photo != nil {
+		if photo.VehicleID != i.VehicleID {
+			return nil, sharedDomain.Invalid("photo_id", "photo belongs to another vehicle")
+		}
+		if photo.Purpose != PhotoPurposeInspection && photo.Purpose != PhotoPurposeDamage {
+			return nil, sharedDomain.Invalid("photo_id", "photo purpose must be inspection or damage")
+		}
+		id := photo.ID
+		entry.PhotoID = &id
+	}
+
+	if previous := i.Result(item.ID); previous != nil {
+		entry.ID = previous.ID
+		*previous = *entry
+		return previous, nil
+	}
+	i.Results = append(i.Results, entry)
+	return entry, nil
+}
+
+// Complete - exige respuesta en los items obligatorios y foto en los que la piden (salvo na)
+func (i *Inspection) Complete(template *InspectionTemplate, notes string) error {
+	if i.IsCompleted() {
+		return sharedDomain.Invariant("inspection_completed", "inspection is already completed")
+	}
+
+	var missing, missingPhotos []string
+	for _, item := range template.Items() {
+		r := i.Result(item.ID)
+		if r == nil {
+			if item.Required {
+				missing = append(missing, item.Label)
+			}
+			continue
+		}
+		if item.PhotoRequired && r.Result != ItemResultNA && r.PhotoID == nil {
+			missingPhotos = append(missingPhotos, item.Label)
+		}
+	}
+	if len(missing) > 0 {
+		return sharedDomain.Invariant("inspection_incomplete", "required items without result: "+strings.Join(missing, ", "))
+	}
+	if len(missingPhotos) > 0 {
+		return sharedDomain.Invariant("inspection_missing_photos", "items without required photo: "+strings.Join(missingPhotos, ", "))
+	}
+
+	now := time.Now()
+	i.Status = InspectionStatusCompleted
+	i.Passed = len(i.FailedRequired(template)) == 0
+	i.Notes = strings.TrimSpace(notes)
+	i.CompletedAt = &now
+	return nil
+}
+
+func (i *Inspection) FailedRequired(template *InspectionTemplate) []*InspectionItem {
+	var failed []*InspectionItem
+	for _, item := range template.Items() {
+		if r := i.Result(item.ID); item.Required && r != nil && r.Result == ItemResultFail {
+			failed = append(failed, item)
+		}
+	}
+	return failed
+}
+
+// CheckCPOInspection - un vehicle CPO solo sale a la venta con su última inspección CPO
+// completada y sin items obligatorios fallidos
+func CheckCPOInspection(vehicle *Vehicle, latest *Inspection) error {
+	if vehicle.LotType != LotTypeCPO {
+		return nil
+	}
+	if latest == nil {
+		return sharedDomain.Invariant("cpo_inspection_required", "CPO vehicle needs a completed CPO inspection")
+	}
+	if !latest.Passed {
+		return sharedDomain.Invariant("cpo_inspection_failed", "latest CPO inspection has failed required items")
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package domain
+
+import "testing"
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
+
+func inspectionTestTemplate() *InspectionTemplate {
+	return &InspectionTemplate{
+		ID:     1,
+		Kind:   InspectionKindCPO,
+		Active: true,
+		Sections: []*InspectionSection{
+			{ID: 1, Name: "Wheels", Items: []*InspectionItem{
+				{ID: 10, Label: "Tread depth", Required: true, Unit: "mm", MinValue: floatPtr(4)},
+				{ID: 11, Label: "Rims", Required: true, PhotoRequired: true},
+			}},
+			{ID: 2, Name: "Interior", Items: []*InspectionItem{
+				{ID: 20, Label: "Floor mats"},
+			}},
+		},
+	}
+}
+
+func TestNewInspectionTemplate(t *testing.T) {
+	items := func(list ...*InspectionItem) []*InspectionSection {
+		return []*InspectionSection{{Name: "Brakes", Items: list}}
+	}
+
+	if _, err := NewInspectionTemplate("CPO", InspectionKind("full"), "", items(&InspectionItem{Label: "Pads"})); err == nil {
+		t.Error("NewInspectionTemplate() expected error for invalid kind")
+	}
+	if _, err := NewInspectionTemplate("CPO", InspectionKindCPO, "", nil); err == nil {
+		t.Error("NewInspectionTemplate() expected error without sections")
+	}
+	if _, err := NewInspectionTemplate("CPO", InspectionKindCPO, "", items(&InspectionItem{Label: "Pads", MinValue: floatPtr(3)})); err == nil {
+		t.Error("NewInspectionTemplate() expected error for range without unit")
+	}
+	if _, err := NewInspectionTemplate("CPO", InspectionKindCPO, "", items(&InspectionItem{Label: "Pads", Unit: "mm", MinValue: floatPtr(5), MaxValue: floatPtr(2)})); err == nil {
+		t.Error("NewInspectionTemplate() expected error for min above max")
+	}
+
+	tooMany := make([]*InspectionItem, MaxInspectionItems+1)
+	for i := range tooMany {
+		tooMany[i] = &InspectionItem{Label: "Point"}
+	}
+	if _, err := NewInspectionTemplate("CPO", InspectionKindCPO, "", items(tooMany...)); err == nil {
+		t.Error("NewInspectionTemplate() expected error above item limit")
+	}
+
+	template, err := NewInspectionTemplate("CPO", InspectionKindCPO, "", []*InspectionSection{
+		{Name: "Brakes", Items: []*InspectionItem{{Label: "Pads"}, {Label: "Rotors"}}},
+		{Name: "Lights", Items: []*InspectionItem{{Label: "Headlights"}}},
+	})
+	if err != nil {
+		t.Fatalf("NewInspectionTemplate() error = %v", err)
+	}
+	if template.Sections[1].SortOrder != 1 || template.Sections[0].Items[1].SortOrder != 1 {
+		t.Error("NewInspectionTemplate() did not keep the received order")
+	}
+	if len(template.Items()) != 3 {
+		t.Errorf("Items() = %d, want 3", len(template.Items()))
+	}
+}
+
+func TestInspectionRecord(t *testing.T) {
+	template := inspectionTestTemplate()
+	vehicle := &Vehicle{ID: 1}
+
+	template.Deactivate()
+	if _, err := NewInspection(vehicle, template, 9); err == nil {
+		t.Error("NewInspection() expected error for inactive template")
+	}
+	template.Activate()
+
+	inspection, err := NewInspection(vehicle, template, 9)
+	if err != nil {
+		t.Fatalf("NewInspection() error = %v", err)
+	}
+
+	tread := template.Item(10)
+	if _, err := inspection.Record(tread, ItemResultPass, nil, nil, ""); err == nil {
+		t.Error("Record() expected error for measured item without value")
+	}
+
+	result, err := inspection.Record(tread, ItemResultPass, floatPtr(2.5), nil, "")
+	if err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if result.Result != ItemResultFail {
+		t.Errorf("Result = %s, want fail for value below range", result.Result)
+	}
+
+	if _, err := inspection.Record(tread, "", floatPtr(6), nil, ""); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if len(inspection.Results) != 1 || inspection.Result(10).Result != ItemResultPass {
+		t.Error("Record() should replace the previous result of the item")
+	}
+
+	rims := template.Item(11)
+	if _, err := inspection.Record(rims, ItemResultPass, nil, &VehiclePhoto{ID: 3, VehicleID: 1, Purpose: PhotoPurposeListing}, ""); err == nil {
+		t.Error("Record() expected error for listing photo")
+	}
+	if _, err := inspection.Record(rims, ItemResultPass, nil, &VehiclePhoto{ID: 3, VehicleID: 2, Purpose: PhotoPurposeInspection}, ""); err == nil {
+		t.Error("Record() expected error for photo of another vehicle")
+	}
+}
+
+func TestInspectionComplete(t *testing.T) {
+	template := inspectionTestTemplate()
+	inspection, _ := NewInspection(&Vehicle{ID: 1}, template, 9)
+
+	inspection.Record(template.Item(10), "", floatPtr(3), nil, "")
+	if err := inspection.Complete(template, ""); err == nil {
+		t.Error("Complete() expected error with required items pending")
+	}
+
+	inspection.Record(template.Item(11), ItemResultPass, nil, nil, "")
+	if err := inspection.Complete(template, ""); err == nil {
+		t.Error("Complete() expected error with required photo missing")
+	}
+
+	inspection.Record(template.Item(11), ItemResultPass, nil, &VehiclePhoto{ID: 3, VehicleID: 1, Purpose: PhotoPurposeInspection}, "")
+	if err := inspection.Complete(template, " tires due "); err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	if inspection.Passed {
+		t.Error("Passed = true, want false with a failed required item")
+	}
+	if !inspection.IsCompleted() || inspection.CompletedAt == nil || inspection.Notes != "tires due" {
+		t.Errorf("Complete() left Status = %s, CompletedAt = %v, Notes = %q", inspection.Status, inspection.CompletedAt, inspection.Notes)
+	}
+
+	if _, err := inspection.Record(template.Item(20), ItemResultPass, nil, nil, ""); err == nil {
+		t.Error("Record() expected error on completed inspection")
+	}
+}
+
+func TestCheckCPOInspection(t *testing.T) {
+	retail := &Vehicle{ID: 1}
+	cpo := &Vehicle{ID: 1, LotType: LotTypeCPO}
+
+	if err := CheckCPOInspection(retail, nil); err != nil {
+		t.Errorf("CheckCPOInspection() error = %v for non CPO vehicle", err)
+	}
+	if err := CheckCPOInspection(cpo, nil); err == nil {
+		t.Error("CheckCPOInspection() expected error without inspection")
+	}
+	if err := CheckCPOInspection(cpo, &Inspection{Status: InspectionStatusCompleted}); err == nil {
+		t.Error("CheckCPOInspection() expected error for failed inspection")
+	}
+	if err := CheckCPOInspection(cpo, &Inspection{Status: InspectionStatusCompleted, Passed: true}); err != nil {
+		t.Errorf("CheckCPOInspection() error = %v", err)
+	}
+}
@@ -1,8 +1,9 @@
 package domain
 
 import (
-	"errors"
 	"time"
+
+	sharedDomain "torque-dms/core/shared/domain"
 )
 
 type LocationType string
@@ -15,6 +16,25 @@ const (
 	LocationTypeInTransit LocationType = "in_transit"
 )
 
+func LocationTypes() []LocationType {
+	return []LocationType{
+		LocationTypeSalesLot,
+		LocationTypeStorage,
+		LocationTypeService,
+		LocationTypeOffsite,
+		LocationTypeInTransit,
+	}
+}
+
+func (l LocationType) IsValid() bool {
+	for _, v := range LocationTypes() {
+		if l == v {
+			return true
+		}
+	}
+	return false
+}
+
 type Location struct {
 	ID        uint
 	Name      string
@@ -27,13 +47,18 @@ type Location struct {
 	Latitude  float64
 	Longitude float64
 	Capacity  int
+	Geofence  *Geofence
 	Active    bool
+	Version   uint
 	CreatedAt time.Time
 }
 
 func NewLocation(name string, locationType LocationType) (*Location, error) {
 	if name == "" {
-		return nil, errors.New("name is required")
+		return nil, sharedDomain.Invalid("name", "name is required")
+	}
+	if !locationType.IsValid() {
+		return nil, sharedDomain.Invalid("type", "invalid location type")
 	}
 
 	return &Location{
@@ -54,10 +79,10 @@ func (l *Location) SetAddress(address string, city string, state string, zip str
 
 func (l *Location) SetCoordinates(latitude float64, longitude float64) error {
 	if latitude < -90 || latitude > 90 {
-		return errors.New("invalid latitude")
+		return sharedDomain.Invalid("latitude", "invalid latitude")
 	}
 	if longitude < -180 || longitude > 180 {
-		return errors.New("invalid longitude")
+		return sharedDomain.Invalid("longitude", "invalid longitude")
 	}
 	l.Latitude = latitude
 	l.Longitude = longitude
@@ -66,12 +91,59 @@ func (l *Location) SetCoordinates(latitude float64, longitude float64) error {
 
 func (l *Location) SetCapacity(capacity int) error {
 	if capacity < 0 {
-		return errors.New("capacity cannot be negative")
+		return sharedDomain.Invalid("capacity", "capacity cannot be negative")
 	}
 	l.Capacity = capacity
 	return nil
 }
 
+// Point - false si la ubicación no tiene coordenadas (0,0 es el valor sin rellenar)
+func (l *Location) Point() (sharedDomain.GeoPoint, bool) {
+	if l.Latitude == 0 && l.Longitude == 0 {
+		return sharedDomain.GeoPoint{}, false
+	}
+	return sharedDomain.GeoPoint{Latitude: l.Latitude, Longitude: l.Longitude}, true
+}
+
+// SetGeofence - nil quita la geocerca. Un círculo se centra en las coordenadas de la ubicación
+func (l *Location) SetGeofence(geofence *Geofence) error {
+	if geofence != nil {
+		if l.IsInTransit() {
+			return sharedDomain.Invalid("geofence", "in_transit locations cannot have a geofence")
+		}
+		if _, ok := l.Point(); !ok && len(geofence.Polygon) == 0 {
+			return sharedDomain.Invalid("radius_m", "location needs coordinates for a radius geofence")
+		}
+	}
+	l.Geofence = geofence
+	return nil
+}
+
+// InGeofence - marginM ensancha la zona para la histéresis de salida
+func (l *Location) InGeofence(p sharedDomain.GeoPoint, marginM float64) bool {
+	if l.Geofence == nil {
+		return false
+	}
+	center, _ := l.Point()
+	return l.Geofence.contains(center, p, marginM)
+}
+
+// geofenceCenter - coordenadas de la ubicación o, si no tiene, la media de los vértices
+func (l *Location) geofenceCenter() sharedDomain.GeoPoint {
+	if center, ok := l.Point(); ok || l.Geofence == nil || len(l.Geofence.Polygon) == 0 {
+		return center
+	}
+	var center sharedDomain.GeoPoint
+	for _, v := range l.Geofence.Polygon {
+		center.Latitude += v.Latitude
+		center.Longitude += v.Longitude
+	}
+	n := float64(len(l.Geofence.Polygon))
+	center.Latitude /= n
+	center.Longitude /= n
+	return center
+}
+
 func (l *Location) Deactivate() {
 	l.Active = false
 }
@@ -82,4 +154,20 @@ func (l *Location) Activate() {
 
 func (l *Location) IsSalesLot() bool {
 	return l.Type == LocationTypeSalesLot
+}
+
+func (l *Location) IsInTransit() bool {
+	return l.Type == LocationTypeInTransit
+}
+
+// CanReceive - occupancy son los vehicles que ya ocupan plaza, incluidos los que vienen
+// en camino. Capacity 0 significa sin límite
+func (l *Location) CanReceive(occupancy int64) error {
+	if !l.Active {
+		return sharedDomain.Invariant("location_inactive", "location is inactive")
+	}
+	if l.Capacity > 0 && occupancy >= int64(l.Capacity) {
+		return sharedDomain.Invariant("location_full", "location is at full capacity")
+	}
+	return nil
 }
\ No newline at end of file
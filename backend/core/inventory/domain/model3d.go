@@ -0,0 +1,221 @@
+package domain
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+type BodyType string
+
+const (
+	BodyTypeSedan       BodyType = "sedan"
+	BodyTypeSUV         BodyType = "suv"
+	BodyTypeTruck       BodyType = "truck"
+	BodyTypeCoupe       BodyType = "coupe"
+	BodyTypeVan         BodyType = "van"
+	BodyTypeHatchback   BodyType = "hatchback"
+	BodyTypeConvertible BodyType = "convertible"
+	BodyTypeWagon       BodyType = "wagon"
+)
+
+func BodyTypes() []BodyType {
+	return []BodyType{
+		BodyTypeSedan,
+		BodyTypeSUV,
+		BodyTypeTruck,
+		BodyTypeCoupe,
+		BodyTypeVan,
+		BodyTypeHatchback,
+		BodyTypeConvertible,
+		BodyTypeWagon,
+	}
+}
+
+func (b BodyType) IsValid() bool {
+	for _, v := range BodyTypes() {
+		if b == v {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeBodyType - el dataset de VINs y los clientes no siempre respetan mayúsculas
+func NormalizeBodyType(bodyType string) BodyType {
+	return BodyType(strings.ToLower(strings.TrimSpace(bodyType)))
+}
+
+// ModelFileKind - archivo de un modelo 3D: la malla o su miniatura
+type ModelFileKind string
+
+const (
+	ModelFileMesh      ModelFileKind = "file"
+	ModelFileThumbnail ModelFileKind = "thumbnail"
+)
+
+// Límites de subida; una malla glTF de un coche ronda los 5-20 MB
+const (
+	MaxModelFileBytes      = 50 << 20
+	MaxModelThumbnailBytes = 5 << 20
+)
+
+// Extensiones aceptadas y su content type
+var modelFileTypes = map[ModelFileKind]map[string]string{
+	ModelFileMesh: {
+		".glb":  "model/gltf-binary",
+		".gltf": "model/gltf+json",
+	},
+	ModelFileThumbnail: {
+		".png":  "image/png",
+		".jpg":  "image/jpeg",
+		".jpeg": "image/jpeg",
+		".webp": "image/webp",
+	},
+}
+
+// ModelUpload - archivo validado, listo para guardar en el storage
+type ModelUpload struct {
+	Kind        ModelFileKind
+	Extension   string
+	ContentType string
+	Size        int64
+}
+
+// MaxModelUploadBytes - tamaño máximo del archivo según su tipo
+func MaxModelUploadBytes(kind ModelFileKind) int64 {
+	if kind == ModelFileThumbnail {
+		return MaxModelThumbnailBytes
+	}
+	return MaxModelFileBytes
+}
+
+// NewModelUpload - el tipo se decide por la extensión, no por lo que declare el cliente
+func NewModelUpload(kind ModelFileKind, filename string, size int64) (*ModelUpload, error) {
+	types, ok := modelFileTypes[kind]
+	if !ok {
+		return nil, sharedDomain.Invalid("kind", "invalid file kind")
+	}
+
+	ext := strings.ToLower(filepath.Ext(filename))
+	contentType, ok := types[ext]
+	if !ok {
+		return nil, sharedDomain.Invalid("file", "unsupported file type "+ext)
+	}
+
+	if size <= 0 {
+		return nil, sharedDomain.Invalid("file", "file is empty")
+	}
+	if size > MaxModelUploadBytes(kind) {
+		return nil, sharedDomain.Invalid("file", "file is too large")
+	}
+
+	return &ModelUpload{Kind: kind, Extension: ext, ContentType: contentType, Size: size}, nil
+}
+
+// VehicleModel3D - modelo genérico por tipo de carrocería para el visor de inspección.
+// Como mucho uno por tipo es el predeterminado que reciben los vehicles nuevos
+type VehicleModel3D struct {
+	ID           uint
+	Name         string
+	BodyType     BodyType
+	FileURL      string
+	ThumbnailURL string
+	IsDefault    bool
+	Active       bool
+	Version      uint
+	CreatedAt    time.Time
+}
+
+func NewVehicleModel3D(name string, bodyType BodyType) (*VehicleModel3D, error) {
+	if name == "" {
+		return nil, sharedDomain.Invalid("name", "name is required")
+	}
+	if !bodyType.IsValid() {
+		return nil, sharedDomain.Invalid("body_type", "invalid body type")
+	}
+
+	return &VehicleModel3D{
+		Name:      name,
+		BodyType:  bodyType,
+		Active:    true,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// SetFile - devuelve la URL anterior para borrar el archivo reemplazado
+func (m *VehicleModel3D) SetFile(kind ModelFileKind, url string) string {
+	var previous string
+	if kind == ModelFileThumbnail {
+		previous, m.ThumbnailURL = m.ThumbnailURL, url
+	} else {
+		previous, m.FileURL = m.FileURL, url
+	}
+	return previous
+}
+
+// MakeDefault - sin malla el visor no tiene nada que mostrar
+func (m *VehicleModel3D) MakeDefault() error {
+	if !m.Active {
+		return sharedDomain.Invariant("model_inactive", "inactive model cannot be the default")
+	}
+	if m.FileURL == "" {
+		return sharedDomain.Invariant("model_without_file", "upload the model file before making it the default")
+	}
+	m.IsDefault = true
+	return nil
+}
+
+func (m *VehicleModel3D) Deactivate() {
+	m.Active = false
+	m.IsDefault = false
+}
+
+func (m *VehicleModel3D) Activate() {
+	m.Active = true
+}
+
+var zoneCodePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_]*$`)
+
+// VehicleModelZone - parte del modelo donde se marcan daños; MeshID es el nombre del nodo
+// en el archivo glTF que el visor resalta
+type VehicleModelZone struct {
+	ID        uint
+	Model3DID uint
+	Code      string
+	Name      string
+	MeshID    string
+	CreatedAt time.Time
+}
+
+func NewVehicleModelZone(model3DID uint, code string, name string, meshID string) (*VehicleModelZone, error) {
+	if model3DID == 0 {
+		return nil, sharedDomain.Invalid("model_3d_id", "model is required")
+	}
+	zone := &VehicleModelZone{Model3DID: model3DID, CreatedAt: time.Now()}
+	if err := zone.Rename(code, name, meshID); err != nil {
+		return nil, err
+	}
+	return zone, nil
+}
+
+// Rename - el código se normaliza a minúsculas; es la clave estable que usa el frontend
+func (z *VehicleModelZone) Rename(code string, name string, meshID string) error {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if !zoneCodePattern.MatchString(code) {
+		return sharedDomain.Invalid("code", "code must be lowercase letters, digits and underscores")
+	}
+	if name == "" {
+		return sharedDomain.Invalid("name", "name is required")
+	}
+	if strings.TrimSpace(meshID) == "" {
+		return sharedDomain.Invalid("mesh_id", "mesh_id is required")
+	}
+	z.Code = code
+	z.Name = name
+	z.MeshID = strings.TrimSpace(meshID)
+	return nil
+}
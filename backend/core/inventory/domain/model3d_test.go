@@ -0,0 +1,89 @@
+package domain
+
+import "testing"
+
+func TestNewModelUpload(t *testing.T) {
+	tests := []struct {
+		name     string
+		kind     ModelFileKind
+		filename string
+		size     int64
+		wantType string
+		wantErr  bool
+	}{
+		{"binary glTF", ModelFileMesh, "sedan.GLB", 1 << 20, "model/gltf-binary", false},
+		{"thumbnail", ModelFileThumbnail, "sedan.jpg", 1 << 10, "image/jpeg", false},
+		{"image as mesh", ModelFileMesh, "sedan.png", 1 << 10, "", true},
+		{"mesh too large", ModelFileMesh, "sedan.glb", MaxModelFileBytes + 1, "", true},
+		{"thumbnail too large", ModelFileThumbnail, "sedan.png", MaxModelThumbnailBytes + 1, "", true},
+		{"empty file", ModelFileMesh, "sedan.glb", 0, "", true},
+		{"unknown kind", ModelFileKind("texture"), "sedan.png", 1 << 10, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upload, err := NewModelUpload(tt.kind, tt.filename, tt.size)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewModelUpload() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && upload.ContentType != tt.wantType {
+				t.Errorf("ContentType = %q, want %q", upload.ContentType, tt.wantType)
+			}
+		})
+	}
+}
+
+func TestVehicleModel3DMakeDefault(t *testing.T) {
+	model, err := NewVehicleModel3D("Generic sedan", NormalizeBodyType(" Sedan "))
+	if err != nil {
+		t.Fatalf("NewVehicleModel3D() error = %v", err)
+	}
+	if err := model.MakeDefault(); err == nil {
+		t.Error("MakeDefault() expected error for model without file")
+	}
+
+	model.SetFile(ModelFileMesh, "/files/sedan.glb")
+	if err := model.MakeDefault(); err != nil {
+		t.Fatalf("MakeDefault() error = %v", err)
+	}
+
+	model.Deactivate()
+	if model.IsDefault {
+		t.Error("IsDefault = true after Deactivate()")
+	}
+	if err := model.MakeDefault(); err == nil {
+		t.Error("MakeDefault() expected error for inactive model")
+	}
+}
+
+func TestNewVehicleModelZone(t *testing.T) {
+	zone, err := NewVehicleModelZone(1, " Front_Bumper ", "Front bumper", "Bumper_F")
+	if err != nil {
+		t.Fatalf("NewVehicleModelZone() error = %v", err)
+	}
+	if zone.Code != "front_bumper" {
+		t.Errorf("Code = %q, want front_bumper", zone.Code)
+	}
+	if _, err := NewVehicleModelZone(1, "front bumper", "Front bumper", "Bumper_F"); err == nil {
+		t.Error("NewVehicleModelZone() expected error for code with spaces")
+	}
+	if _, err := NewVehicleModelZone(1, "hood", "Hood", ""); err == nil {
+		t.Error("NewVehicleModelZone() expected error for empty mesh id")
+	}
+}
+
+func TestVehicleAssignModel3D(t *testing.T) {
+	vehicle := &Vehicle{}
+	if err := vehicle.AssignModel3D(&VehicleModel3D{ID: 2}); err == nil {
+		t.Error("AssignModel3D() expected error for inactive model")
+	}
+	if err := vehicle.AssignModel3D(&VehicleModel3D{ID: 2, Active: true}); err != nil {
+		t.Fatalf("AssignModel3D() error = %v", err)
+	}
+	if vehicle.Model3DID == nil || *vehicle.Model3DID != 2 {
+		t.Errorf("Model3DID = %v, want 2", vehicle.Model3DID)
+	}
+	if err := vehicle.AssignModel3D(nil); err != nil || vehicle.Model3DID != nil {
+		t.Errorf("AssignModel3D(nil) = %v, Model3DID = %v", err, vehicle.Model3DID)
+	}
+}
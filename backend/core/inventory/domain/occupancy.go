@@ -0,0 +1,123 @@
+package domain
+
+import "math"
+
+// A partir de este porcentaje la ubicación se marca como casi llena
+const nearCapacityPercent = 90
+
+type OccupancyAlert string
+
+const (
+	OccupancyAlertNone         OccupancyAlert = ""
+	OccupancyAlertNearCapacity OccupancyAlert = "near_capacity"
+	OccupancyAlertFull         OccupancyAlert = "full"
+	OccupancyAlertOverCapacity OccupancyAlert = "over_capacity"
+)
+
+// StockCount - vehicles asignados a una ubicación agrupados por estado y tipo de lote
+type StockCount struct {
+	LocationID uint
+	Status     VehicleStatus
+	LotType    LotType
+	Count      int64
+}
+
+// LocationOccupancy - plazas ocupadas de una ubicación. Los vendidos no ocupan plaza aunque
+// sigan asignados; los que vienen en camino sí, porque su plaza ya está reservada
+type LocationOccupancy struct {
+	Location  *Location
+	Parked    int64
+	Inbound   int64
+	ByStatus  map[VehicleStatus]int64
+	ByLotType map[LotType]int64
+}
+
+func NewLocationOccupancy(location *Location, counts []StockCount, inbound int64) *LocationOccupancy {
+	occupancy := &LocationOccupancy{
+		Location:  location,
+		Inbound:   inbound,
+		ByStatus:  make(map[VehicleStatus]int64),
+		ByLotType: make(map[LotType]int64),
+	}
+	for _, c := range counts {
+		if c.LocationID != location.ID || c.Status == VehicleStatusSold {
+			continue
+		}
+		occupancy.Parked += c.Count
+		occupancy.ByStatus[c.Status] += c.Count
+		occupancy.ByLotType[c.LotType] += c.Count
+	}
+	return occupancy
+}
+
+func (o *LocationOccupancy) Occupied() int64 {
+	return o.Parked + o.Inbound
+}
+
+// Utilization - porcentaje con un decimal; 0 si la ubicación no tiene capacidad definida
+func (o *LocationOccupancy) Utilization() float64 {
+	if o.Location.Capacity <= 0 {
+		return 0
+	}
+	return math.Round(float64(o.Occupied())*1000/float64(o.Location.Capacity)) / 10
+}
+
+// Overflow - vehicles por encima de la capacidad, los que hay que aparcar en otro sitio
+func (o *LocationOccupancy) Overflow() int64 {
+	if o.Location.Capacity <= 0 {
+		return 0
+	}
+	return max(o.Occupied()-int64(o.Location.Capacity), 0)
+}
+
+func (o *LocationOccupancy) Alert() OccupancyAlert {
+	capacity := int64(o.Location.Capacity)
+	switch {
+	case capacity <= 0:
+		return OccupancyAlertNone
+	case o.Occupied() > capacity:
+		return OccupancyAlertOverCapacity
+	case o.Occupied() == capacity:
+		return OccupancyAlertFull
+	case o.Utilization() >= nearCapacityPercent:
+		return OccupancyAlertNearCapacity
+	default:
+		return OccupancyAlertNone
+	}
+}
+
+// OccupancySummary - todas las ubicaciones. La utilización global solo considera las que
+// tienen capacidad definida
+type OccupancySummary struct {
+	Locations []*LocationOccupancy
+}
+
+func (s *OccupancySummary) Totals() (capacity int64, occupied int64, overflow int64) {
+	for _, o := range s.Locations {
+		if o.Location.Capacity <= 0 {
+			continue
+		}
+		capacity += int64(o.Location.Capacity)
+		occupied += o.Occupied()
+		overflow += o.Overflow()
+	}
+	return capacity, occupied, overflow
+}
+
+func (s *OccupancySummary) Utilization() float64 {
+	capacity, occupied, _ := s.Totals()
+	if capacity == 0 {
+		return 0
+	}
+	return math.Round(float64(occupied)*1000/float64(capacity)) / 10
+}
+
+func (s *OccupancySummary) Alerts() []*LocationOccupancy {
+	var alerts []*LocationOccupancy
+	for _, o := range s.Locations {
+		if o.Alert() != OccupancyAlertNone {
+			alerts = append(alerts, o)
+		}
+	}
+	return alerts
+}
@@ -0,0 +1,34 @@
+package domain
+
+import "testing"
+
+func TestLocationOccupancy(t *testing.T) {
+	location := &Location{ID: 1, Capacity: 10, Active: true}
+	counts := []StockCount{
+		{LocationID: 1, Status: VehicleStatusReadyForSale, LotType: LotTypeUsed, Count: 5},
+		{LocationID: 1, Status: VehicleStatusInRecon, LotType: LotTypeNew, Count: 2},
+		{LocationID: 1, Status: VehicleStatusSold, LotType: LotTypeUsed, Count: 40},
+		{LocationID: 2, Status: VehicleStatusReadyForSale, LotType: LotTypeUsed, Count: 3},
+	}
+
+	occupancy := NewLocationOccupancy(location, counts, 2)
+	if occupancy.Parked != 7 || occupancy.Occupied() != 9 {
+		t.Errorf("Parked = %d, Occupied() = %d, want 7 and 9", occupancy.Parked, occupancy.Occupied())
+	}
+	if occupancy.ByLotType[LotTypeUsed] != 5 || occupancy.ByStatus[VehicleStatusSold] != 0 {
+		t.Errorf("breakdown = %v %v", occupancy.ByStatus, occupancy.ByLotType)
+	}
+	if occupancy.Utilization() != 90 || occupancy.Alert() != OccupancyAlertNearCapacity {
+		t.Errorf("Utilization() = %v, Alert() = %q", occupancy.Utilization(), occupancy.Alert())
+	}
+
+	occupancy.Inbound = 6
+	if occupancy.Overflow() != 3 || occupancy.Alert() != OccupancyAlertOverCapacity {
+		t.Errorf("Overflow() = %d, Alert() = %q", occupancy.Overflow(), occupancy.Alert())
+	}
+
+	unlimited := NewLocationOccupancy(&Location{ID: 2}, counts, 0)
+	if unlimited.Utilization() != 0 || unlimited.Alert() != OccupancyAlertNone {
+		t.Errorf("unlimited location: Utilization() = %v, Alert() = %q", unlimited.Utilization(), unlimited.Alert())
+	}
+}
@@ -1,8 +1,9 @@
 package domain
 
 import (
-	"errors"
 	"time"
+
+	sharedDomain "torque-dms/core/shared/domain"
 )
 
 type PhotoPerspective string
@@ -18,6 +19,28 @@ const (
 	PhotoPerspectiveDamage        PhotoPerspective = "damage"
 )
 
+func PhotoPerspectives() []PhotoPerspective {
+	return []PhotoPerspective{
+		PhotoPerspectiveFront,
+		PhotoPerspectiveRear,
+		PhotoPerspectiveLeftSide,
+		PhotoPerspectiveRightSide,
+		PhotoPerspectiveInterior,
+		PhotoPerspectiveDashboard,
+		PhotoPerspectiveEngine,
+		PhotoPerspectiveDamage,
+	}
+}
+
+func (p PhotoPerspective) IsValid() bool {
+	for _, v := range PhotoPerspectives() {
+		if p == v {
+			return true
+		}
+	}
+	return false
+}
+
 type PhotoPurpose string
 
 const (
@@ -28,6 +51,25 @@ const (
 	PhotoPurposeInternal   PhotoPurpose = "internal"
 )
 
+func PhotoPurposes() []PhotoPurpose {
+	return []PhotoPurpose{
+		PhotoPurposeListing,
+		PhotoPurposeService,
+		PhotoPurposeInspection,
+		PhotoPurposeDamage,
+		PhotoPurposeInternal,
+	}
+}
+
+func (p PhotoPurpose) IsValid() bool {
+	for _, v := range PhotoPurposes() {
+		if p == v {
+			return true
+		}
+	}
+	return false
+}
+
 type VehiclePhoto struct {
 	ID          uint
 	VehicleID   uint
@@ -42,13 +84,19 @@ type VehiclePhoto struct {
 
 func NewVehiclePhoto(vehicleID uint, url string, perspective PhotoPerspective, purpose PhotoPurpose, uploadedBy uint) (*VehiclePhoto, error) {
 	if vehicleID == 0 {
-		return nil, errors.New("vehicle is required")
+		return nil, sharedDomain.Invalid("vehicle_id", "vehicle is required")
 	}
 	if url == "" {
-		return nil, errors.New("url is required")
+		return nil, sharedDomain.Invalid("url", "url is required")
+	}
+	if !perspective.IsValid() {
+		return nil, sharedDomain.Invalid("perspective", "invalid perspective")
+	}
+	if !purpose.IsValid() {
+		return nil, sharedDomain.Invalid("purpose", "invalid purpose")
 	}
 	if uploadedBy == 0 {
-		return nil, errors.New("uploaded_by is required")
+		return nil, sharedDomain.Invalid("uploaded_by", "uploaded_by is required")
 	}
 
 	return &VehiclePhoto{
@@ -0,0 +1,335 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+// InStockStatuses - vehicles que siguen siendo inventario del concesionario; desde la
+// fecha de adquisición cuentan días en stock
+func InStockStatuses() []VehicleStatus {
+	return []VehicleStatus{
+		VehicleStatusInTransit,
+		VehicleStatusInRecon,
+		VehicleStatusReadyForSale,
+		VehicleStatusPendingSale,
+	}
+}
+
+// DaysInStock - días completos desde la adquisición (o el alta si no hay fecha)
+func (v *Vehicle) DaysInStock(now time.Time) int {
+	since := v.AcquisitionDate
+	if since.IsZero() {
+		since = v.CreatedAt
+	}
+	if since.IsZero() || now.Before(since) {
+		return 0
+	}
+	return int(now.Sub(since).Hours() / 24)
+}
+
+type AgeBucket string
+
+const (
+	AgeBucket0To30  AgeBucket = "0_30"
+	AgeBucket31To60 AgeBucket = "31_60"
+	AgeBucket61To90 AgeBucket = "61_90"
+	AgeBucketOver90 AgeBucket = "over_90"
+)
+
+func AgeBuckets() []AgeBucket {
+	return []AgeBucket{
+		AgeBucket0To30,
+		AgeBucket31To60,
+		AgeBucket61To90,
+		AgeBucketOver90,
+	}
+}
+
+func AgeBucketFor(days int) AgeBucket {
+	switch {
+	case days <= 30:
+		return AgeBucket0To30
+	case days <= 60:
+		return AgeBucket31To60
+	case days <= 90:
+		return AgeBucket61To90
+	}
+	return AgeBucketOver90
+}
+
+type AgeBucketStats struct {
+	Bucket      AgeBucket
+	Vehicles    int
+	TotalCost   float64
+	TotalAsking float64
+	AvgDays     float64
+}
+
+// InventoryAging - foto del stock por tramos de antigüedad. Los tramos vacíos también
+// aparecen, en orden
+type InventoryAging struct {
+	AsOf     time.Time
+	Vehicles int
+	AvgDays  float64
+	Buckets  []AgeBucketStats
+}
+
+func NewInventoryAging(vehicles []*Vehicle, now time.Time) *InventoryAging {
+	aging := &InventoryAging{AsOf: now, Vehicles: len(vehicles)}
+
+	index := map[AgeBucket]int{}
+	for i, b := range AgeBuckets() {
+		aging.Buckets = append(aging.Buckets, AgeBucketStats{Bucket: b})
+		index[b] = i
+	}
+
+	totalDays := 0
+	for _, v := range vehicles {
+		days := v.DaysInStock(now)
+		totalDays += days
+
+		stats := &aging.Buckets[index[AgeBucketFor(days)]]
+		stats.Vehicles++
+		stats.TotalCost += v.CostBasis()
+		stats.TotalAsking += v.AskingPrice
+		stats.AvgDays += float64(days)
+	}
+
+	for i := range aging.Buckets {
+		if aging.Buckets[i].Vehicles > 0 {
+			aging.Buckets[i].AvgDays /= float64(aging.Buckets[i].Vehicles)
+		}
+	}
+	if len(vehicles) > 0 {
+		aging.AvgDays = float64(totalDays) / float64(len(vehicles))
+	}
+	return aging
+}
+
+// RepricingRule - bajada programada del precio publicado, p. ej. "usados con más de 45 días,
+// bajar un 3% cada 7 días sin bajar de coste + 500". Condition y LotType vacíos valen para todos
+type RepricingRule struct {
+	ID             uint
+	Name           string
+	Condition      VehicleCondition
+	LotType        LotType
+	MinDaysInStock int
+	DropPercent    float64
+	IntervalDays   int
+	FloorOverCost  float64
+	Active         bool
+	Version        uint
+	CreatedAt      time.Time
+	ModifiedAt     time.Time
+}
+
+func NewRepricingRule(name string, condition VehicleCondition, lotType LotType, minDaysInStock int, dropPercent float64, intervalDays int, floorOverCost float64) (*RepricingRule, error) {
+	r := &RepricingRule{Active: true, CreatedAt: time.Now()}
+	if err := r.Configure(name, condition, lotType, minDaysInStock, dropPercent, intervalDays, floorOverCost); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *RepricingRule) Configure(name string, condition VehicleCondition, lotType LotType, minDaysInStock int, dropPercent float64, intervalDays int, floorOverCost float64) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return sharedDomain.Invalid("name", "name is required")
+	}
+	if condition != "" && !condition.IsValid() {
+		return sharedDomain.Invalid("condition", "invalid condition")
+	}
+	if lotType != "" && !lotType.IsValid() {
+		return sharedDomain.Invalid("lot_type", "invalid lot type")
+	}
+	if minDaysInStock < 0 {
+		return sharedDomain.Invalid("min_days_in_stock", "min days in stock cannot be negative")
+	}
+	if dropPercent <= 0 || dropPercent > 50 {
+		return sharedDomain.Invalid("drop_percent", "drop percent must be between 0 and 50")
+	}
+	if intervalDays < 1 {
+		return sharedDomain.Invalid("interval_days", "interval must be at least one day")
+	}
+	if floorOverCost < 0 {
+		return sharedDomain.Invalid("floor_over_cost", "floor over cost cannot be negative")
+	}
+
+	r.Name = name
+	r.Condition = condition
+	r.LotType = lotType
+	r.MinDaysInStock = minDaysInStock
+	r.DropPercent = dropPercent
+	r.IntervalDays = intervalDays
+	r.FloorOverCost = floorOverCost
+	r.ModifiedAt = time.Now()
+	return nil
+}
+
+func (r *RepricingRule) Activate() {
+	r.Active = true
+	r.ModifiedAt = time.Now()
+}
+
+func (r *RepricingRule) Deactivate() {
+	r.Active = false
+	r.ModifiedAt = time.Now()
+}
+
+// Matches - solo se rebajan vehicles a la venta; los que están en recon o con un trato
+// pendiente conservan su precio
+func (r *RepricingRule) Matches(v *Vehicle, now time.Time) bool {
+	if v.IsDeleted() || v.Status != VehicleStatusReadyForSale {
+		return false
+	}
+	if r.Condition != "" && v.Condition != r.Condition {
+		return false
+	}
+	if r.LotType != "" && v.LotType != r.LotType {
+		return false
+	}
+	return v.DaysInStock(now) >= r.MinDaysInStock
+}
+
+// Floor - precio mínimo al que la regla puede llevar el vehicle
+func (r *RepricingRule) Floor(v *Vehicle) float64 {
+	return v.CostBasis() + r.FloorOverCost
+}
+
+// RepricingProposal - lo que la regla haría con un vehicle; es el resultado del dry run y
+// lo que el scheduler aplica
+type RepricingProposal struct {
+	VehicleID    uint
+	StockNumber  string
+	RuleID       uint
+	RuleName     string
+	DaysInStock  int
+	CurrentPrice float64
+	NewPrice     float64
+	Floor        float64
+	AtFloor      bool
+}
+
+// Propose - nil si la regla no aplica, si aún no toca según el intervalo desde la última
+// bajada automática o si el precio ya está en el suelo
+func (r *RepricingRule) Propose(v *Vehicle, lastDrop *time.Time, now time.Time) *RepricingProposal {
+	if !r.Matches(v, now) || v.AskingPrice <= 0 {
+		return nil
+	}
+	if lastDrop != nil && now.Sub(*lastDrop) < time.Duration(r.IntervalDays)*24*time.Hour {
+		return nil
+	}
+
+	floor := r.Floor(v)
+	price := math.Round(v.AskingPrice * (1 - r.DropPercent/100))
+	atFloor := false
+	if price <= floor {
+		price = floor
+		atFloor = true
+	}
+	if price >= v.AskingPrice {
+		return nil
+	}
+
+	return &RepricingProposal{
+		VehicleID:    v.ID,
+		StockNumber:  v.StockNumber,
+		RuleID:       r.ID,
+		RuleName:     r.Name,
+		DaysInStock:  v.DaysInStock(now),
+		CurrentPrice: v.AskingPrice,
+		NewPrice:     price,
+		Floor:        floor,
+		AtFloor:      atFloor,
+	}
+}
+
+// PlanRepricing - a cada vehicle le aplica, de las reglas dadas, la más exigente en antigüedad
+// que encaje (a igualdad, la más antigua). lastDrops guarda la última bajada automática de cada vehicle
+func PlanRepricing(rules []*RepricingRule, vehicles []*Vehicle, lastDrops map[uint]time.Time, now time.Time) []*RepricingProposal {
+	ordered := append([]*RepricingRule(nil), rules...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].MinDaysInStock != ordered[j].MinDaysInStock {
+			return ordered[i].MinDaysInStock > ordered[j].MinDaysInStock
+		}
+		return ordered[i].ID < ordered[j].ID
+	})
+
+	var proposals []*RepricingProposal
+	for _, v := range vehicles {
+		var lastDrop *time.Time
+		if t, ok := lastDrops[v.ID]; ok {
+			lastDrop = &t
+		}
+		for _, r := range ordered {
+			if !r.Matches(v, now) {
+				continue
+			}
+			if p := r.Propose(v, lastDrop, now); p != nil {
+				proposals = append(proposals, p)
+			}
+			break
+		}
+	}
+	return proposals
+}
+
+type PriceChangeSource string
+
+const (
+	PriceChangeManual PriceChangeSource = "manual"
+	PriceChangeRule   PriceChangeSource = "rule"
+)
+
+func PriceChangeSources() []PriceChangeSource {
+	return []PriceChangeSource{
+		PriceChangeManual,
+		PriceChangeRule,
+	}
+}
+
+// PriceChange - un cambio del precio publicado. Los automáticos llevan la regla y no
+// tienen usuario
+type PriceChange struct {
+	ID        uint
+	VehicleID uint
+	OldPrice  float64
+	NewPrice  float64
+	Source    PriceChangeSource
+	RuleID    *uint
+	ChangedBy *uint
+	CreatedAt time.Time
+}
+
+func NewManualPriceChange(vehicleID uint, oldPrice float64, newPrice float64, changedBy uint) *PriceChange {
+	return &PriceChange{
+		VehicleID: vehicleID,
+		OldPrice:  oldPrice,
+		NewPrice:  newPrice,
+		Source:    PriceChangeManual,
+		ChangedBy: &changedBy,
+		CreatedAt: time.Now(),
+	}
+}
+
+func NewRulePriceChange(p *RepricingProposal, now time.Time) *PriceChange {
+	ruleID := p.RuleID
+	return &PriceChange{
+		VehicleID: p.VehicleID,
+		OldPrice:  p.CurrentPrice,
+		NewPrice:  p.NewPrice,
+		Source:    PriceChangeRule,
+		RuleID:    &ruleID,
+		CreatedAt: now,
+	}
+}
+
+// Reprice - cambia solo el precio publicado
+func (v *Vehicle) Reprice(askingPrice float64) error {
+	return v.SetPricing(v.MSRP, v.InvoicePrice, askingPrice)
+}
@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAgeBucketFor(t *testing.T) {
+	tests := []struct {
+		days int
+		want AgeBucket
+	}{
+		{0, AgeBucket0To30},
+		{30, AgeBucket0To30},
+		{31, AgeBucket31To60},
+		{90, AgeBucket61To90},
+		{91, AgeBucketOver90},
+	}
+
+	for _, tt := range tests {
+		if got := AgeBucketFor(tt.days); got != tt.want {
+			t.Errorf("AgeBucketFor(%d) = %s, want %s", tt.days, got, tt.want)
+		}
+	}
+}
+
+func TestNewInventoryAging(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	vehicles := []*Vehicle{
+		{AcquisitionDate: now.AddDate(0, 0, -10), AcquisitionCost: 10000, AskingPrice: 12000},
+		{AcquisitionDate: now.AddDate(0, 0, -20), AcquisitionCost: 15000, AskingPrice: 18000},
+		{AcquisitionDate: now.AddDate(0, 0, -120), AcquisitionCost: 8000, AskingPrice: 9000},
+	}
+
+	aging := NewInventoryAging(vehicles, now)
+	if len(aging.Buckets) != len(AgeBuckets()) {
+		t.Fatalf("len(Buckets) = %d, want every bucket", len(aging.Buckets))
+	}
+	first := aging.Buckets[0]
+	if first.Vehicles != 2 || first.AvgDays != 15 || first.TotalCost != 25000 {
+		t.Errorf("Buckets[0] = %+v", first)
+	}
+	if aging.Buckets[3].Vehicles != 1 {
+		t.Errorf("Buckets[3].Vehicles = %d, want 1", aging.Buckets[3].Vehicles)
+	}
+	if aging.AvgDays != 50 {
+		t.Errorf("AvgDays = %v, want 50", aging.AvgDays)
+	}
+}
+
+func TestNewRepricingRule(t *testing.T) {
+	tests := []struct {
+		name    string
+		drop    float64
+		days    int
+		cond    VehicleCondition
+		wantErr bool
+	}{
+		{"valid", 3, 7, VehicleConditionUsed, false},
+		{"any condition", 3, 7, "", false},
+		{"no drop", 0, 7, "", true},
+		{"drop too big", 60, 7, "", true},
+		{"no interval", 3, 0, "", true},
+		{"invalid condition", 3, 7, VehicleCondition("junk"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewRepricingRule("aged used", tt.cond, "", 45, tt.drop, tt.days, 500)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewRepricingRule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRepricingRulePropose(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	rule, _ := NewRepricingRule("aged used", VehicleConditionUsed, "", 45, 3, 7, 500)
+	vehicle := func(asking float64, days int) *Vehicle {
+		return &Vehicle{
+			ID:              1,
+			Status:          VehicleStatusReadyForSale,
+			Condition:       VehicleConditionUsed,
+			AskingPrice:     asking,
+			AcquisitionCost: 15000,
+			AcquisitionDate: now.AddDate(0, 0, -days),
+		}
+	}
+
+	p := rule.Propose(vehicle(20000, 50), nil, now)
+	if p == nil || p.NewPrice != 19400 || p.AtFloor {
+		t.Fatalf("Propose() = %+v, want 3%% drop to 19400", p)
+	}
+
+	if p := rule.Propose(vehicle(15600, 50), nil, now); p == nil || p.NewPrice != 15500 || !p.AtFloor {
+		t.Errorf("Propose() = %+v, want floor at cost + 500", p)
+	}
+	if p := rule.Propose(vehicle(15500, 50), nil, now); p != nil {
+		t.Errorf("Propose() = %+v, want nil at floor", p)
+	}
+	if p := rule.Propose(vehicle(20000, 30), nil, now); p != nil {
+		t.Errorf("Propose() = %+v, want nil before min days", p)
+	}
+
+	lastDrop := now.AddDate(0, 0, -3)
+	if p := rule.Propose(vehicle(20000, 50), &lastDrop, now); p != nil {
+		t.Errorf("Propose() = %+v, want nil inside interval", p)
+	}
+
+	pending := vehicle(20000, 50)
+	pending.Status = VehicleStatusPendingSale
+	if p := rule.Propose(pending, nil, now); p != nil {
+		t.Errorf("Propose() = %+v, want nil for pending sale", p)
+	}
+}
+
+func TestPlanRepricing(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	mild, _ := NewRepricingRule("30 days", "", "", 30, 2, 7, 0)
+	mild.ID = 1
+	steep, _ := NewRepricingRule("90 days", "", "", 90, 5, 7, 0)
+	steep.ID = 2
+
+	vehicles := []*Vehicle{
+		{ID: 10, Status: VehicleStatusReadyForSale, AskingPrice: 10000, AcquisitionDate: now.AddDate(0, 0, -40)},
+		{ID: 11, Status: VehicleStatusReadyForSale, AskingPrice: 10000, AcquisitionDate: now.AddDate(0, 0, -100)},
+		{ID: 12, Status: VehicleStatusReadyForSale, AskingPrice: 10000, AcquisitionDate: now.AddDate(0, 0, -100)},
+	}
+	lastDrops := map[uint]time.Time{12: now.AddDate(0, 0, -1)}
+
+	proposals := PlanRepricing([]*RepricingRule{mild, steep}, vehicles, lastDrops, now)
+	if len(proposals) != 2 {
+		t.Fatalf("len(proposals) = %d, want 2", len(proposals))
+	}
+	if proposals[0].RuleID != mild.ID || proposals[1].RuleID != steep.ID {
+		t.Errorf("rules = %d, %d, want the most aged rule per vehicle", proposals[0].RuleID, proposals[1].RuleID)
+	}
+}
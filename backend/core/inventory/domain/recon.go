@@ -0,0 +1,376 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+type ReconTaskType string
+
+const (
+	ReconTaskMechanical ReconTaskType = "mechanical"
+	ReconTaskBody       ReconTaskType = "body"
+	ReconTaskDetail     ReconTaskType = "detail"
+	ReconTaskPhotos     ReconTaskType = "photos"
+)
+
+func ReconTaskTypes() []ReconTaskType {
+	return []ReconTaskType{
+		ReconTaskMechanical,
+		ReconTaskBody,
+		ReconTaskDetail,
+		ReconTaskPhotos,
+	}
+}
+
+func (t ReconTaskType) IsValid() bool {
+	for _, v := range ReconTaskTypes() {
+		if t == v {
+			return true
+		}
+	}
+	return false
+}
+
+type ReconTaskStatus string
+
+const (
+	ReconTaskPending    ReconTaskStatus = "pending"
+	ReconTaskInProgress ReconTaskStatus = "in_progress"
+	ReconTaskCompleted  ReconTaskStatus = "completed"
+	ReconTaskCancelled  ReconTaskStatus = "cancelled"
+)
+
+func ReconTaskStatuses() []ReconTaskStatus {
+	return []ReconTaskStatus{
+		ReconTaskPending,
+		ReconTaskInProgress,
+		ReconTaskCompleted,
+		ReconTaskCancelled,
+	}
+}
+
+type ReconOrderStatus string
+
+const (
+	ReconOrderOpen   ReconOrderStatus = "open"
+	ReconOrderClosed ReconOrderStatus = "closed"
+)
+
+func ReconOrderStatuses() []ReconOrderStatus {
+	return []ReconOrderStatus{
+		ReconOrderOpen,
+		ReconOrderClosed,
+	}
+}
+
+// ReconPolicy - las tareas con un estimado por encima de ApprovalThreshold necesitan
+// aprobación antes de empezar (0 desactiva las aprobaciones). Con RequireClosedTasks un
+// vehicle no sale a la venta con tareas de recon abiertas
+type ReconPolicy struct {
+	ApprovalThreshold  float64
+	RequireClosedTasks bool
+}
+
+func NewReconPolicy(approvalThreshold float64, requireClosedTasks bool) (*ReconPolicy, error) {
+	if approvalThreshold < 0 {
+		return nil, sharedDomain.Invalid("recon_policy", "approval threshold cannot be negative")
+	}
+	return &ReconPolicy{
+		ApprovalThreshold:  approvalThreshold,
+		RequireClosedTasks: requireClosedTasks,
+	}, nil
+}
+
+func (p *ReconPolicy) RequiresApproval(estimatedCost float64) bool {
+	return p.ApprovalThreshold > 0 && estimatedCost > p.ApprovalThreshold
+}
+
+type ReconTask struct {
+	ID               uint
+	OrderID          uint
+	Type             ReconTaskType
+	Description      string
+	Status           ReconTaskStatus
+	AssigneeID       *uint
+	Vendor           string
+	EstimatedCost    float64
+	ActualCost       *float64
+	ApprovalRequired bool
+	ApprovedBy       *uint
+	ApprovedAt       *time.Time
+	StartedAt        *time.Time
+	CompletedAt      *time.Time
+	CancelledAt      *time.Time
+	Version          uint
+	CreatedAt        time.Time
+}
+
+func (t *ReconTask) IsOpen() bool {
+	return t.Status == ReconTaskPending || t.Status == ReconTaskInProgress
+}
+
+func (t *ReconTask) IsApproved() bool {
+	return !t.ApprovalRequired || t.ApprovedAt != nil
+}
+
+// Assign - responsable interno y/o proveedor externo; vacíos dejan la tarea sin asignar
+func (t *ReconTask) Assign(assigneeID *uint, vendor string) error {
+	if !t.IsOpen() {
+		return sharedDomain.Invariant("recon_task_closed", "recon task is already closed")
+	}
+	t.AssigneeID = assigneeID
+	t.Vendor = strings.TrimSpace(vendor)
+	return nil
+}
+
+// SetEstimate - un estimado nuevo anula la aprobación anterior si vuelve a pasar el umbral
+func (t *ReconTask) SetEstimate(cost float64, policy *ReconPolicy) error {
+	if t.Status != ReconTaskPending {
+		return sharedDomain.Invariant("recon_task_started", "estimate can only change before the task starts")
+	}
+	if cost < 0 {
+		return sharedDomain.Invalid("estimated_cost", "estimated cost cannot be negative")
+	}
+	if cost != t.EstimatedCost {
+		t.ApprovedBy = nil
+		t.ApprovedAt = nil
+	}
+	t.EstimatedCost = cost
+	t.ApprovalRequired = policy.RequiresApproval(cost)
+	return nil
+}
+
+func (t *ReconTask) Approve(approvedBy uint) error {
+	if t.Status != ReconTaskPending {
+		return sharedDomain.Invariant("recon_task_started", "only pending tasks can be approved")
+	}
+	if !t.ApprovalRequired {
+		return sharedDomain.Invariant("recon_approval_not_required", "recon task does not need approval")
+	}
+	if t.ApprovedAt != nil {
+		return sharedDomain.Invariant("recon_task_approved", "recon task is already approved")
+	}
+	now := time.Now()
+	t.ApprovedBy = &approvedBy
+	t.ApprovedAt = &now
+	return nil
+}
+
+func (t *ReconTask) Start() error {
+	if t.Status != ReconTaskPending {
+		return sharedDomain.Invariant("recon_task_started", "recon task is not pending")
+	}
+	if !t.IsApproved() {
+		return sharedDomain.Invariant("recon_approval_required", "recon task estimate needs approval")
+	}
+	now := time.Now()
+	t.Status = ReconTaskInProgress
+	t.StartedAt = &now
+	return nil
+}
+
+// Complete - una tarea pendiente se puede cerrar directamente (p. ej. fotos), pero
+// también necesita su aprobación
+func (t *ReconTask) Complete(actualCost float64) error {
+	if !t.IsOpen() {
+		return sharedDomain.Invariant("recon_task_closed", "recon task is already closed")
+	}
+	if !t.IsApproved() {
+		return sharedDomain.Invariant("recon_approval_required", "recon task estimate needs approval")
+	}
+	if actualCost < 0 {
+		return sharedDomain.Invalid("actual_cost", "actual cost cannot be negative")
+	}
+	now := time.Now()
+	if t.StartedAt == nil {
+		t.StartedAt = &now
+	}
+	t.Status = ReconTaskCompleted
+	t.ActualCost = &actualCost
+	t.CompletedAt = &now
+	return nil
+}
+
+func (t *ReconTask) Cancel() error {
+	if !t.IsOpen() {
+		return sharedDomain.Invariant("recon_task_closed", "recon task is already closed")
+	}
+	now := time.Now()
+	t.Status = ReconTaskCancelled
+	t.CancelledAt = &now
+	return nil
+}
+
+// ReconOrder - una pasada del vehicle por recon. El tiempo entre apertura y cierre es el
+// ciclo de recon: días en los que el vehicle no se puede vender
+type ReconOrder struct {
+	ID        uint
+	VehicleID uint
+	Status    ReconOrderStatus
+	OpenedBy  uint
+	Notes     string
+	Tasks     []*ReconTask
+	OpenedAt  time.Time
+	ClosedAt  *time.Time
+	Version   uint
+}
+
+func NewReconOrder(vehicle *Vehicle, openedBy uint, notes string) (*ReconOrder, error) {
+	if vehicle.IsSold() {
+		return nil, sharedDomain.Invariant("vehicle_sold", "cannot send sold vehicle to recon")
+	}
+	return &ReconOrder{
+		VehicleID: vehicle.ID,
+		Status:    ReconOrderOpen,
+		OpenedBy:  openedBy,
+		Notes:     strings.TrimSpace(notes),
+		OpenedAt:  time.Now(),
+	}, nil
+}
+
+func (o *ReconOrder) IsOpen() bool {
+	return o.Status == ReconOrderOpen
+}
+
+func (o *ReconOrder) AddTask(taskType ReconTaskType, description string, assigneeID *uint, vendor string, estimatedCost float64, policy *ReconPolicy) (*ReconTask, error) {
+	if !o.IsOpen() {
+		return nil, sharedDomain.Invariant("recon_order_closed", "recon order is closed")
+	}
+	if !taskType.IsValid() {
+		return nil, sharedDomain.Invalid("type", "invalid recon task type")
+	}
+
+	task := &ReconTask{
+		OrderID:     o.ID,
+		Type:        taskType,
+		Description: strings.TrimSpace(description),
+		Status:      ReconTaskPending,
+		CreatedAt:   time.Now(),
+	}
+	if err := task.Assign(assigneeID, vendor); err != nil {
+		return nil, err
+	}
+	if err := task.SetEstimate(estimatedCost, policy); err != nil {
+		return nil, err
+	}
+
+	o.Tasks = append(o.Tasks, task)
+	return task, nil
+}
+
+func (o *ReconOrder) Task(id uint) *ReconTask {
+	for _, t := range o.Tasks {
+		if t.ID == id {
+			return t
+		}
+	}
+	return nil
+}
+
+func (o *ReconOrder) OpenTasks() []*ReconTask {
+	var open []*ReconTask
+	for _, t := range o.Tasks {
+		if t.IsOpen() {
+			open = append(open, t)
+		}
+	}
+	return open
+}
+
+func (o *ReconOrder) Close() error {
+	if !o.IsOpen() {
+		return sharedDomain.Invariant("recon_order_closed", "recon order is already closed")
+	}
+	if len(o.OpenTasks()) > 0 {
+		return sharedDomain.Invariant("recon_tasks_open", "recon order has open tasks")
+	}
+	now := time.Now()
+	o.Status = ReconOrderClosed
+	o.ClosedAt = &now
+	return nil
+}
+
+// EstimatedCost - suma de los estimados de las tareas no canceladas
+func (o *ReconOrder) EstimatedCost() float64 {
+	total := 0.0
+	for _, t := range o.Tasks {
+		if t.Status != ReconTaskCancelled {
+			total += t.EstimatedCost
+		}
+	}
+	return total
+}
+
+// ActualCost - solo cuenta lo gastado en tareas completadas
+func (o *ReconOrder) ActualCost() float64 {
+	total := 0.0
+	for _, t := range o.Tasks {
+		if t.Status == ReconTaskCompleted && t.ActualCost != nil {
+			total += *t.ActualCost
+		}
+	}
+	return total
+}
+
+// CycleTime - una orden abierta sigue sumando tiempo hasta now
+func (o *ReconOrder) CycleTime(now time.Time) time.Duration {
+	end := now
+	if o.ClosedAt != nil {
+		end = *o.ClosedAt
+	}
+	return end.Sub(o.OpenedAt)
+}
+
+// ReconCycleStats - ciclo medio de las órdenes cerradas en un periodo y antigüedad de las
+// que siguen abiertas
+type ReconCycleStats struct {
+	From           time.Time
+	To             time.Time
+	ClosedOrders   int
+	AvgCycleTime   time.Duration
+	MaxCycleTime   time.Duration
+	OpenOrders     int
+	AvgOpenAge     time.Duration
+	TotalReconCost float64
+}
+
+func NewReconCycleStats(from time.Time, to time.Time, closed []*ReconOrder, open []*ReconOrder, now time.Time) *ReconCycleStats {
+	stats := &ReconCycleStats{From: from, To: to, ClosedOrders: len(closed), OpenOrders: len(open)}
+
+	var total time.Duration
+	for _, o := range closed {
+		cycle := o.CycleTime(now)
+		total += cycle
+		if cycle > stats.MaxCycleTime {
+			stats.MaxCycleTime = cycle
+		}
+		stats.TotalReconCost += o.ActualCost()
+	}
+	if len(closed) > 0 {
+		stats.AvgCycleTime = total / time.Duration(len(closed))
+	}
+
+	var age time.Duration
+	for _, o := range open {
+		age += o.CycleTime(now)
+	}
+	if len(open) > 0 {
+		stats.AvgOpenAge = age / time.Duration(len(open))
+	}
+	return stats
+}
+
+// ReleaseForSale - al poner el vehicle a la venta se cierra su orden abierta. Con tareas
+// abiertas la orden sigue abierta, salvo que la política exija cerrarlas antes
+func (o *ReconOrder) ReleaseForSale(policy *ReconPolicy) (bool, error) {
+	if len(o.OpenTasks()) == 0 {
+		return true, o.Close()
+	}
+	if policy.RequireClosedTasks {
+		return false, sharedDomain.Invariant("recon_tasks_open", "vehicle has open recon tasks")
+	}
+	return false, nil
+}
@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReconTaskApproval(t *testing.T) {
+	policy, err := NewReconPolicy(500, false)
+	if err != nil {
+		t.Fatalf("NewReconPolicy() error = %v", err)
+	}
+	if _, err := NewReconPolicy(-1, false); err == nil {
+		t.Error("NewReconPolicy() expected error for negative threshold")
+	}
+
+	order, err := NewReconOrder(&Vehicle{ID: 1}, 9, "")
+	if err != nil {
+		t.Fatalf("NewReconOrder() error = %v", err)
+	}
+	if _, err := order.AddTask(ReconTaskType("paint"), "", nil, "", 0, policy); err == nil {
+		t.Error("AddTask() expected error for invalid type")
+	}
+
+	detail, _ := order.AddTask(ReconTaskDetail, "full detail", nil, "Shine Co", 150, policy)
+	if detail.ApprovalRequired {
+		t.Error("ApprovalRequired = true for estimate under threshold")
+	}
+	if err := detail.Approve(9); err == nil {
+		t.Error("Approve() expected error when approval is not required")
+	}
+
+	body, _ := order.AddTask(ReconTaskBody, "bumper", nil, "", 1200, policy)
+	if !body.ApprovalRequired {
+		t.Fatal("ApprovalRequired = false for estimate above threshold")
+	}
+	if err := body.Start(); err == nil {
+		t.Error("Start() expected error without approval")
+	}
+	if err := body.Approve(9); err != nil {
+		t.Fatalf("Approve() error = %v", err)
+	}
+
+	// Un estimado nuevo por encima del umbral necesita otra aprobación
+	if err := body.SetEstimate(1500, policy); err != nil {
+		t.Fatalf("SetEstimate() error = %v", err)
+	}
+	if body.IsApproved() {
+		t.Error("IsApproved() = true after estimate change")
+	}
+	body.Approve(9)
+	if err := body.Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if err := body.SetEstimate(100, policy); err == nil {
+		t.Error("SetEstimate() expected error for started task")
+	}
+}
+
+func TestReconOrderCostAndClose(t *testing.T) {
+	policy := &ReconPolicy{ApprovalThreshold: 0, RequireClosedTasks: true}
+	vehicle := &Vehicle{ID: 1, AcquisitionCost: 10000, AskingPrice: 14000}
+
+	if _, err := NewReconOrder(&Vehicle{ID: 2, Status: VehicleStatusSold}, 9, ""); err == nil {
+		t.Error("NewReconOrder() expected error for sold vehicle")
+	}
+
+	order, _ := NewReconOrder(vehicle, 9, "")
+	mechanical, _ := order.AddTask(ReconTaskMechanical, "brakes", nil, "", 400, policy)
+	photos, _ := order.AddTask(ReconTaskPhotos, "", nil, "", 0, policy)
+	extra, _ := order.AddTask(ReconTaskBody, "", nil, "", 900, policy)
+
+	if _, err := order.ReleaseForSale(policy); err == nil {
+		t.Error("ReleaseForSale() expected error with open tasks")
+	}
+	if closed, err := order.ReleaseForSale(&ReconPolicy{}); err != nil || closed {
+		t.Errorf("ReleaseForSale() = %v, %v; want order left open", closed, err)
+	}
+
+	if err := mechanical.Complete(-1); err == nil {
+		t.Error("Complete() expected error for negative cost")
+	}
+	mechanical.Complete(450)
+	photos.Complete(0)
+	extra.Cancel()
+
+	if order.EstimatedCost() != 400 {
+		t.Errorf("EstimatedCost() = %v, want 400", order.EstimatedCost())
+	}
+	if order.ActualCost() != 450 {
+		t.Errorf("ActualCost() = %v, want 450", order.ActualCost())
+	}
+
+	closed, err := order.ReleaseForSale(policy)
+	if err != nil || !closed || order.IsOpen() {
+		t.Fatalf("ReleaseForSale() = %v, %v; want order closed", closed, err)
+	}
+	if _, err := order.AddTask(ReconTaskDetail, "", nil, "", 0, policy); err == nil {
+		t.Error("AddTask() expected error on closed order")
+	}
+
+	vehicle.SetReconCost(order.ActualCost())
+	if vehicle.CostBasis() != 10450 || vehicle.Profit() != 3550 {
+		t.Errorf("CostBasis() = %v, Profit() = %v", vehicle.CostBasis(), vehicle.Profit())
+	}
+}
+
+func TestReconCycleStats(t *testing.T) {
+	now := time.Now()
+	closedAt := now.Add(-24 * time.Hour)
+	closed := []*ReconOrder{
+		{OpenedAt: closedAt.Add(-48 * time.Hour), ClosedAt: &closedAt},
+		{OpenedAt: closedAt.Add(-96 * time.Hour), ClosedAt: &closedAt},
+	}
+	open := []*ReconOrder{{OpenedAt: now.Add(-10 * time.Hour)}}
+
+	stats := NewReconCycleStats(now.Add(-time.Hour*24*30), now, closed, open, now)
+	if stats.AvgCycleTime != 72*time.Hour || stats.MaxCycleTime != 96*time.Hour {
+		t.Errorf("AvgCycleTime = %v, MaxCycleTime = %v", stats.AvgCycleTime, stats.MaxCycleTime)
+	}
+	if stats.OpenOrders != 1 || stats.AvgOpenAge != 10*time.Hour {
+		t.Errorf("OpenOrders = %d, AvgOpenAge = %v", stats.OpenOrders, stats.AvgOpenAge)
+	}
+}
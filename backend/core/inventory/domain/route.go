@@ -0,0 +1,168 @@
+package domain
+
+import (
+	"math"
+	"time"
+
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+// Velocidad media para estimar la llegada cuando la ruta no tiene tiempo estimado
+const defaultAverageSpeedKMH = 60.0
+
+// Route - recorrido habitual entre dos ubicaciones. Los waypoints son los puntos intermedios;
+// los extremos salen de las coordenadas de las ubicaciones
+type Route struct {
+	ID               uint
+	Name             string
+	FromLocationID   uint
+	ToLocationID     uint
+	DistanceKM       float64
+	EstimatedMinutes int
+	Waypoints        []sharedDomain.GeoPoint
+	Active           bool
+	Version          uint
+	CreatedAt        time.Time
+}
+
+func NewRoute(name string, fromLocationID uint, toLocationID uint) (*Route, error) {
+	if name == "" {
+		return nil, sharedDomain.Invalid("name", "name is required")
+	}
+	if fromLocationID == 0 {
+		return nil, sharedDomain.Invalid("from_location_id", "origin is required")
+	}
+	if toLocationID == 0 {
+		return nil, sharedDomain.Invalid("to_location_id", "destination is required")
+	}
+	if fromLocationID == toLocationID {
+		return nil, sharedDomain.Invalid("to_location_id", "origin and destination must be different")
+	}
+
+	return &Route{
+		Name:           name,
+		FromLocationID: fromLocationID,
+		ToLocationID:   toLocationID,
+		Active:         true,
+		CreatedAt:      time.Now(),
+	}, nil
+}
+
+func (r *Route) SetEstimate(distanceKM float64, estimatedMinutes int) error {
+	if distanceKM < 0 {
+		return sharedDomain.Invalid("distance_km", "distance cannot be negative")
+	}
+	if estimatedMinutes < 0 {
+		return sharedDomain.Invalid("estimated_minutes", "estimated minutes cannot be negative")
+	}
+	r.DistanceKM = distanceKM
+	r.EstimatedMinutes = estimatedMinutes
+	return nil
+}
+
+func (r *Route) SetWaypoints(waypoints []sharedDomain.GeoPoint) error {
+	for _, w := range waypoints {
+		if _, err := sharedDomain.NewGeoPoint(w.Latitude, w.Longitude); err != nil {
+			return sharedDomain.Invalid("waypoints", "invalid waypoint")
+		}
+	}
+	r.Waypoints = waypoints
+	return nil
+}
+
+// Path - polilínea completa; un extremo sin coordenadas se omite
+func (r *Route) Path(from *Location, to *Location) []sharedDomain.GeoPoint {
+	path := make([]sharedDomain.GeoPoint, 0, len(r.Waypoints)+2)
+	if point, ok := from.Point(); ok {
+		path = append(path, point)
+	}
+	path = append(path, r.Waypoints...)
+	if point, ok := to.Point(); ok {
+		path = append(path, point)
+	}
+	return path
+}
+
+// StraightLineKM - longitud del camino por los waypoints; por carretera será algo mayor
+func (r *Route) StraightLineKM(from *Location, to *Location) float64 {
+	return roundTenth(sharedDomain.PathLengthKM(r.Path(from, to)))
+}
+
+func (r *Route) Connects(fromLocationID uint, toLocationID uint) bool {
+	return r.FromLocationID == fromLocationID && r.ToLocationID == toLocationID
+}
+
+func (r *Route) Deactivate() {
+	r.Active = false
+}
+
+func (r *Route) Activate() {
+	r.Active = true
+}
+
+// RouteProgress - seguimiento en vivo de un traslado. Sin ruta solo se conoce la posición;
+// sin posición se asume que el vehicle sigue en el origen
+type RouteProgress struct {
+	Transfer         *VehicleTransfer
+	Route            *Route
+	Position         *TrackingPoint
+	TotalKM          float64
+	CoveredKM        float64
+	RemainingKM      float64
+	PercentComplete  float64
+	RemainingMinutes *int
+	ETA              *time.Time
+	DeviationKM      float64
+	OffRoute         bool
+}
+
+// NewRouteProgress - la posición se proyecta sobre el camino para saber qué fracción se ha
+// recorrido; los km y minutos salen de la distancia y el tiempo de la ruta, que siguen la
+// carretera y no la línea recta
+func NewRouteProgress(transfer *VehicleTransfer, route *Route, path []sharedDomain.GeoPoint, position *TrackingPoint, offRouteKM float64) *RouteProgress {
+	progress := &RouteProgress{Transfer: transfer, Route: route, Position: position}
+	if route == nil {
+		return progress
+	}
+
+	pathKM := sharedDomain.PathLengthKM(path)
+	progress.TotalKM = route.DistanceKM
+	if progress.TotalKM == 0 {
+		progress.TotalKM = pathKM
+	}
+
+	fraction := 0.0
+	since := transfer.CreatedAt
+	if position != nil && len(path) > 0 {
+		projection := sharedDomain.ProjectOnPath(path, position.Point())
+		if pathKM > 0 {
+			fraction = projection.AlongKM / pathKM
+		}
+		progress.DeviationKM = roundTenth(projection.OffsetKM)
+		progress.OffRoute = projection.OffsetKM > offRouteKM
+		since = position.RecordedAt
+	}
+
+	progress.PercentComplete = roundTenth(fraction * 100)
+	progress.CoveredKM = roundTenth(fraction * progress.TotalKM)
+	progress.RemainingKM = roundTenth(progress.TotalKM - fraction*progress.TotalKM)
+
+	var minutes float64
+	switch {
+	case route.EstimatedMinutes > 0:
+		minutes = float64(route.EstimatedMinutes) * (1 - fraction)
+	case progress.TotalKM > 0:
+		minutes = progress.RemainingKM / defaultAverageSpeedKMH * 60
+	default:
+		return progress
+	}
+	remaining := int(math.Ceil(minutes))
+	eta := since.Add(time.Duration(remaining) * time.Minute)
+	progress.RemainingMinutes = &remaining
+	progress.ETA = &eta
+	return progress
+}
+
+func roundTenth(v float64) float64 {
+	return math.Round(v*10) / 10
+}
@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+func TestNewRoute(t *testing.T) {
+	if _, err := NewRoute("", 1, 2); err == nil {
+		t.Error("NewRoute() expected error for empty name")
+	}
+	if _, err := NewRoute("Shuttle", 1, 1); err == nil {
+		t.Error("NewRoute() expected error for same origin and destination")
+	}
+	route, err := NewRoute("Shuttle", 1, 2)
+	if err != nil {
+		t.Fatalf("NewRoute() error = %v", err)
+	}
+	if err := route.SetWaypoints([]sharedDomain.GeoPoint{{Latitude: 95}}); err == nil {
+		t.Error("SetWaypoints() expected error for invalid waypoint")
+	}
+}
+
+func TestTransferAssignRoute(t *testing.T) {
+	from := uint(1)
+	transfer := &VehicleTransfer{FromLocationID: &from, ToLocationID: 2}
+
+	if err := transfer.AssignRoute(&Route{ID: 5, FromLocationID: 2, ToLocationID: 1, Active: true}); err == nil {
+		t.Error("AssignRoute() expected error for reversed route")
+	}
+	if err := transfer.AssignRoute(&Route{ID: 5, FromLocationID: 1, ToLocationID: 2}); err == nil {
+		t.Error("AssignRoute() expected error for inactive route")
+	}
+	if err := transfer.AssignRoute(&Route{ID: 5, FromLocationID: 1, ToLocationID: 2, Active: true}); err != nil {
+		t.Fatalf("AssignRoute() error = %v", err)
+	}
+	if transfer.RouteID == nil || *transfer.RouteID != 5 {
+		t.Errorf("RouteID = %v, want 5", transfer.RouteID)
+	}
+}
+
+func TestNewRouteProgress(t *testing.T) {
+	departed := time.Date(2025, 6, 1, 8, 0, 0, 0, time.UTC)
+	transfer := &VehicleTransfer{ID: 1, VehicleID: 3, ToLocationID: 2, CreatedAt: departed}
+	from := &Location{Latitude: 0, Longitude: 0.0001}
+	to := &Location{Latitude: 1, Longitude: 0.0001}
+	route := &Route{FromLocationID: 1, ToLocationID: 2, DistanceKM: 120, EstimatedMinutes: 100}
+	path := route.Path(from, to)
+
+	at := func(lat, lng float64) *TrackingPoint {
+		return &TrackingPoint{VehicleID: 3, Latitude: lat, Longitude: lng, RecordedAt: departed.Add(time.Hour)}
+	}
+
+	t.Run("halfway", func(t *testing.T) {
+		p := NewRouteProgress(transfer, route, path, at(0.5, 0.0001), 1)
+		if p.PercentComplete != 50 || p.CoveredKM != 60 || p.RemainingKM != 60 {
+			t.Errorf("progress = %v%%, %v km covered, %v km remaining", p.PercentComplete, p.CoveredKM, p.RemainingKM)
+		}
+		if p.RemainingMinutes == nil || *p.RemainingMinutes != 50 {
+			t.Fatalf("RemainingMinutes = %v, want 50", p.RemainingMinutes)
+		}
+		if want := departed.Add(time.Hour + 50*time.Minute); !p.ETA.Equal(want) {
+			t.Errorf("ETA = %v, want %v", p.ETA, want)
+		}
+		if p.OffRoute {
+			t.Error("OffRoute = true, want false")
+		}
+	})
+
+	t.Run("off route", func(t *testing.T) {
+		p := NewRouteProgress(transfer, route, path, at(0.5, 0.05), 1)
+		if !p.OffRoute || p.DeviationKM < 5 {
+			t.Errorf("OffRoute = %v, DeviationKM = %v", p.OffRoute, p.DeviationKM)
+		}
+	})
+
+	t.Run("no position yet", func(t *testing.T) {
+		p := NewRouteProgress(transfer, route, path, nil, 1)
+		if p.PercentComplete != 0 || !p.ETA.Equal(departed.Add(100*time.Minute)) {
+			t.Errorf("progress = %v%%, ETA = %v", p.PercentComplete, p.ETA)
+		}
+	})
+
+	t.Run("without route", func(t *testing.T) {
+		p := NewRouteProgress(transfer, nil, nil, at(0.5, 0), 1)
+		if p.ETA != nil || p.Position == nil {
+			t.Errorf("ETA = %v, Position = %v", p.ETA, p.Position)
+		}
+	})
+}
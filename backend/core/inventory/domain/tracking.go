@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+const (
+	// MaxPingBatch - pings por request de ingesta
+	MaxPingBatch = 1000
+
+	// Margen para dispositivos con el reloj adelantado
+	maxPingClockSkew = 5 * time.Minute
+)
+
+// TrackingPoint - posición GPS de un vehicle. (VehicleID, RecordedAt) lo identifica, así
+// un dispositivo puede reenviar el mismo lote sin duplicar puntos
+type TrackingPoint struct {
+	ID         uint
+	VehicleID  uint
+	Latitude   float64
+	Longitude  float64
+	RouteID    *uint
+	RecordedAt time.Time
+}
+
+func NewTrackingPoint(vehicleID uint, latitude float64, longitude float64, routeID *uint, recordedAt time.Time, now time.Time) (*TrackingPoint, error) {
+	point, err := sharedDomain.NewGeoPoint(latitude, longitude)
+	if err != nil {
+		return nil, err
+	}
+	if recordedAt.IsZero() {
+		return nil, sharedDomain.Invalid("recorded_at", "recorded_at is required")
+	}
+	if recordedAt.After(now.Add(maxPingClockSkew)) {
+		return nil, sharedDomain.Invalid("recorded_at", "recorded_at is in the future")
+	}
+
+	return &TrackingPoint{
+		VehicleID: vehicleID,
+		Latitude:  point.Latitude,
+		Longitude: point.Longitude,
+		RouteID:   routeID,
+		// Postgres guarda microsegundos; truncar aquí mantiene estable la clave de idempotencia
+		RecordedAt: recordedAt.UTC().Truncate(time.Microsecond),
+	}, nil
+}
+
+func (p *TrackingPoint) Point() sharedDomain.GeoPoint {
+	return sharedDomain.GeoPoint{Latitude: p.Latitude, Longitude: p.Longitude}
+}
+
+// Ping - posición tal como llega del camión o de la caja telemática, identificada por
+// VIN o por el id del dispositivo asignado al vehicle
+type Ping struct {
+	VIN        string
+	DeviceID   string
+	Latitude   float64
+	Longitude  float64
+	RouteID    *uint
+	RecordedAt time.Time
+}
+
+func NormalizeDeviceID(deviceID string) string {
+	return strings.TrimSpace(deviceID)
+}
+
+type PingRejection struct {
+	Index  int
+	Reason string
+}
+
+// IngestResult - un lote se acepta parcialmente: los pings inválidos se rechazan uno a uno.
+// Crossings son los cruces de geocerca detectados en los puntos nuevos
+type IngestResult struct {
+	Accepted   int
+	Duplicates int
+	Rejected   []PingRejection
+	Crossings  int
+}
+
+// TrackingRetention - los puntos más antiguos que DownsampleAfter se reducen a uno por
+// DownsampleInterval y vehicle; los más antiguos que DeleteAfter se borran. 0 desactiva cada paso
+type TrackingRetention struct {
+	DownsampleAfter    time.Duration
+	DownsampleInterval time.Duration
+	DeleteAfter        time.Duration
+}
+
+func NewTrackingRetention(downsampleAfterDays int, downsampleMinutes int, deleteAfterDays int) (*TrackingRetention, error) {
+	if downsampleAfterDays < 0 || downsampleMinutes < 0 || deleteAfterDays < 0 {
+		return nil, sharedDomain.Invalid("tracking_retention", "tracking retention values cannot be negative")
+	}
+	if downsampleAfterDays > 0 && downsampleMinutes == 0 {
+		return nil, sharedDomain.Invalid("tracking_retention", "downsampling needs an interval")
+	}
+	if deleteAfterDays > 0 && downsampleAfterDays >= deleteAfterDays {
+		return nil, sharedDomain.Invalid("tracking_retention", "points must be downsampled before they are deleted")
+	}
+
+	return &TrackingRetention{
+		DownsampleAfter:    time.Duration(downsampleAfterDays) * 24 * time.Hour,
+		DownsampleInterval: time.Duration(downsampleMinutes) * time.Minute,
+		DeleteAfter:        time.Duration(deleteAfterDays) * 24 * time.Hour,
+	}, nil
+}
+
+// DeleteBefore - corte de borrado; false si los puntos se conservan para siempre
+func (r *TrackingRetention) DeleteBefore(now time.Time) (time.Time, bool) {
+	if r.DeleteAfter == 0 {
+		return time.Time{}, false
+	}
+	return now.Add(-r.DeleteAfter), true
+}
+
+// DownsampleBefore - corte de reducción; false si no se reduce
+func (r *TrackingRetention) DownsampleBefore(now time.Time) (time.Time, bool) {
+	if r.DownsampleAfter == 0 {
+		return time.Time{}, false
+	}
+	return now.Add(-r.DownsampleAfter), true
+}
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewTrackingPoint(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		lat, lng   float64
+		recordedAt time.Time
+		wantErr    bool
+	}{
+		{"valid", 19.4326, -99.1332, now.Add(-time.Minute), false},
+		{"small clock skew", 19.4326, -99.1332, now.Add(2 * time.Minute), false},
+		{"future", 19.4326, -99.1332, now.Add(time.Hour), true},
+		{"missing time", 19.4326, -99.1332, time.Time{}, true},
+		{"invalid latitude", 91, -99.1332, now, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewTrackingPoint(1, tt.lat, tt.lng, nil, tt.recordedAt, now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewTrackingPoint() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTrackingPointNormalizesTime(t *testing.T) {
+	local := time.FixedZone("CST", -6*3600)
+	recordedAt := time.Date(2025, 6, 1, 6, 0, 0, 123456789, local)
+
+	point, err := NewTrackingPoint(1, 0, 0, nil, recordedAt, recordedAt)
+	if err != nil {
+		t.Fatalf("NewTrackingPoint() error = %v", err)
+	}
+	want := time.Date(2025, 6, 1, 12, 0, 0, 123456000, time.UTC)
+	if !point.RecordedAt.Equal(want) || point.RecordedAt.Location() != time.UTC {
+		t.Errorf("RecordedAt = %v, want %v", point.RecordedAt, want)
+	}
+}
+
+func TestNewTrackingRetention(t *testing.T) {
+	tests := []struct {
+		name                  string
+		after, minutes, purge int
+		wantErr               bool
+	}{
+		{"downsample then delete", 30, 5, 365, false},
+		{"keep forever", 30, 5, 0, false},
+		{"disabled", 0, 0, 0, false},
+		{"negative", -1, 5, 365, true},
+		{"no interval", 30, 0, 365, true},
+		{"delete before downsample", 30, 5, 7, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewTrackingRetention(tt.after, tt.minutes, tt.purge)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewTrackingRetention() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTrackingRetentionCutoffs(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	retention, _ := NewTrackingRetention(30, 5, 0)
+
+	if _, ok := retention.DeleteBefore(now); ok {
+		t.Error("DeleteBefore() expected no cutoff when points are kept forever")
+	}
+	before, ok := retention.DownsampleBefore(now)
+	if !ok || !before.Equal(now.AddDate(0, 0, -30)) {
+		t.Errorf("DownsampleBefore() = %v, %v", before, ok)
+	}
+}
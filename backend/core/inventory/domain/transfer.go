@@ -0,0 +1,131 @@
+package domain
+
+import (
+	"time"
+
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+// VehicleLocationHistory - un movimiento del vehicle; FromLocationID es nil si no tenía ubicación
+// y ToLocationID si salió de la geocerca sin que se sepa adónde va
+type VehicleLocationHistory struct {
+	ID             uint
+	VehicleID      uint
+	FromLocationID *uint
+	ToLocationID   *uint
+	MovedBy        uint
+	Reason         string
+	CreatedAt      time.Time
+}
+
+func NewVehicleLocationHistory(vehicleID uint, fromLocationID uint, toLocationID uint, movedBy uint, reason string) *VehicleLocationHistory {
+	history := &VehicleLocationHistory{
+		VehicleID: vehicleID,
+		MovedBy:   movedBy,
+		Reason:    reason,
+		CreatedAt: time.Now(),
+	}
+	if fromLocationID != 0 {
+		history.FromLocationID = &fromLocationID
+	}
+	if toLocationID != 0 {
+		history.ToLocationID = &toLocationID
+	}
+	return history
+}
+
+type TransferStatus string
+
+const (
+	TransferStatusPending   TransferStatus = "pending"
+	TransferStatusCompleted TransferStatus = "completed"
+)
+
+func TransferStatuses() []TransferStatus {
+	return []TransferStatus{
+		TransferStatusPending,
+		TransferStatusCompleted,
+	}
+}
+
+// VehicleTransfer - traslado entre ubicaciones. Mientras está pendiente el vehicle queda en
+// una ubicación de tipo in_transit y ocupa plaza en el destino
+type VehicleTransfer struct {
+	ID                uint
+	VehicleID         uint
+	FromLocationID    *uint
+	TransitLocationID uint
+	ToLocationID      uint
+	RouteID           *uint
+	PreviousStatus    VehicleStatus
+	Status            TransferStatus
+	Reason            string
+	RequestedBy       uint
+	ReceivedBy        *uint
+	CreatedAt         time.Time
+	CompletedAt       *time.Time
+}
+
+func NewVehicleTransfer(vehicle *Vehicle, transit *Location, toLocationID uint, requestedBy uint, reason string) (*VehicleTransfer, error) {
+	if toLocationID == 0 {
+		return nil, sharedDomain.Invalid("to_location_id", "destination is required")
+	}
+	if toLocationID == vehicle.LocationID {
+		return nil, sharedDomain.Invalid("to_location_id", "vehicle is already at this location")
+	}
+	if transit.Type != LocationTypeInTransit {
+		return nil, sharedDomain.Invalid("transit_location_id", "transit location must be of type in_transit")
+	}
+
+	transfer := &VehicleTransfer{
+		VehicleID:         vehicle.ID,
+		TransitLocationID: transit.ID,
+		ToLocationID:      toLocationID,
+		PreviousStatus:    vehicle.Status,
+		Status:            TransferStatusPending,
+		Reason:            reason,
+		RequestedBy:       requestedBy,
+		CreatedAt:         time.Now(),
+	}
+	if vehicle.LocationID != 0 {
+		from := vehicle.LocationID
+		transfer.FromLocationID = &from
+	}
+	return transfer, nil
+}
+
+// AssignRoute - la ruta tiene que unir el origen y el destino del traslado
+func (t *VehicleTransfer) AssignRoute(route *Route) error {
+	if !route.Active {
+		return sharedDomain.Invariant("route_inactive", "route is inactive")
+	}
+	if t.FromLocationID == nil || !route.Connects(*t.FromLocationID, t.ToLocationID) {
+		return sharedDomain.Invalid("route_id", "route does not connect origin and destination")
+	}
+	routeID := route.ID
+	t.RouteID = &routeID
+	return nil
+}
+
+func (t *VehicleTransfer) Complete(receivedBy uint) error {
+	if t.Status != TransferStatusPending {
+		return sharedDomain.Invariant("transfer_not_pending", "transfer is not pending")
+	}
+	now := time.Now()
+	t.Status = TransferStatusCompleted
+	t.CompletedAt = &now
+	t.ReceivedBy = nil
+	if receivedBy != 0 {
+		t.ReceivedBy = &receivedBy
+	}
+	return nil
+}
+
+// ArrivalStatus - el vehicle recupera el estado que tenía antes del traslado; uno que ya
+// venía en tránsito (recién adquirido) entra a recon al llegar
+func (t *VehicleTransfer) ArrivalStatus() VehicleStatus {
+	if t.PreviousStatus == VehicleStatusInTransit {
+		return VehicleStatusInRecon
+	}
+	return t.PreviousStatus
+}
@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLocationCanReceive(t *testing.T) {
+	tests := []struct {
+		name      string
+		location  Location
+		occupancy int64
+		wantErr   bool
+	}{
+		{"unlimited", Location{Active: true}, 500, false},
+		{"free space", Location{Active: true, Capacity: 10}, 9, false},
+		{"full", Location{Active: true, Capacity: 10}, 10, true},
+		{"inactive", Location{Capacity: 10}, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.location.CanReceive(tt.occupancy)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CanReceive() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVehicleTransferLifecycle(t *testing.T) {
+	vehicle := &Vehicle{ID: 1, LocationID: 2, Status: VehicleStatusReadyForSale}
+	transit := &Location{ID: 9, Type: LocationTypeInTransit, Active: true}
+
+	if _, err := NewVehicleTransfer(vehicle, transit, 2, 7, ""); err == nil {
+		t.Error("NewVehicleTransfer() expected error for current location")
+	}
+	if _, err := NewVehicleTransfer(vehicle, &Location{ID: 4, Type: LocationTypeStorage}, 3, 7, ""); err == nil {
+		t.Error("NewVehicleTransfer() expected error for non in_transit location")
+	}
+
+	transfer, err := NewVehicleTransfer(vehicle, transit, 3, 7, "rebalance")
+	if err != nil {
+		t.Fatalf("NewVehicleTransfer() error = %v", err)
+	}
+	if transfer.FromLocationID == nil || *transfer.FromLocationID != 2 {
+		t.Errorf("FromLocationID = %v, want 2", transfer.FromLocationID)
+	}
+
+	if err := vehicle.StartTransit(transit.ID); err != nil {
+		t.Fatalf("StartTransit() error = %v", err)
+	}
+	if vehicle.Status != VehicleStatusInTransit || vehicle.LocationID != transit.ID {
+		t.Errorf("StartTransit() = status %s location %d", vehicle.Status, vehicle.LocationID)
+	}
+
+	if err := transfer.Complete(8); err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	if transfer.ArrivalStatus() != VehicleStatusReadyForSale {
+		t.Errorf("ArrivalStatus() = %s, want %s", transfer.ArrivalStatus(), VehicleStatusReadyForSale)
+	}
+	if err := transfer.Complete(8); err == nil {
+		t.Error("Complete() expected error for completed transfer")
+	}
+}
+
+func TestVehicleStartTransit(t *testing.T) {
+	deletedAt := time.Now()
+	tests := []struct {
+		name    string
+		vehicle Vehicle
+		wantErr bool
+	}{
+		{"in recon", Vehicle{Status: VehicleStatusInRecon}, false},
+		{"ready for sale", Vehicle{Status: VehicleStatusReadyForSale}, false},
+		{"already in transit", Vehicle{Status: VehicleStatusInTransit}, false},
+		{"pending sale", Vehicle{Status: VehicleStatusPendingSale}, true},
+		{"sold", Vehicle{Status: VehicleStatusSold}, true},
+		{"wholesale", Vehicle{Status: VehicleStatusWholesale}, true},
+		{"deleted", Vehicle{Status: VehicleStatusInRecon, DeletedAt: &deletedAt}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vehicle := tt.vehicle
+			err := vehicle.StartTransit(9)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("StartTransit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && vehicle.Status != tt.vehicle.Status {
+				t.Errorf("StartTransit() changed status to %s on error", vehicle.Status)
+			}
+		})
+	}
+}
+
+func TestVehicleArrive(t *testing.T) {
+	tests := []struct {
+		name    string
+		vehicle Vehicle
+		status  VehicleStatus
+		wantErr bool
+	}{
+		{"to recon", Vehicle{Status: VehicleStatusInTransit}, VehicleStatusInRecon, false},
+		{"to ready with price", Vehicle{Status: VehicleStatusInTransit, AskingPrice: 20000}, VehicleStatusReadyForSale, false},
+		{"to ready without price", Vehicle{Status: VehicleStatusInTransit}, VehicleStatusReadyForSale, true},
+		{"to sold", Vehicle{Status: VehicleStatusInTransit, AskingPrice: 20000}, VehicleStatusSold, true},
+		{"to pending sale", Vehicle{Status: VehicleStatusInTransit, AskingPrice: 20000}, VehicleStatusPendingSale, true},
+		{"not in transit", Vehicle{Status: VehicleStatusInRecon}, VehicleStatusReadyForSale, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vehicle := tt.vehicle
+			err := vehicle.Arrive(4, tt.status)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Arrive() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (vehicle.Status != tt.status || vehicle.LocationID != 4) {
+				t.Errorf("Arrive() = status %s location %d", vehicle.Status, vehicle.LocationID)
+			}
+			if err != nil && vehicle.Status != tt.vehicle.Status {
+				t.Errorf("Arrive() changed status to %s on error", vehicle.Status)
+			}
+		})
+	}
+}
@@ -1,8 +1,10 @@
 package domain
 
 import (
-	"errors"
+	"fmt"
 	"time"
+
+	sharedDomain "torque-dms/core/shared/domain"
 )
 
 type VehicleCondition string
@@ -13,16 +15,54 @@ const (
 	VehicleConditionCertified VehicleCondition = "certified"
 )
 
+func VehicleConditions() []VehicleCondition {
+	return []VehicleCondition{
+		VehicleConditionNew,
+		VehicleConditionUsed,
+		VehicleConditionCertified,
+	}
+}
+
+func (c VehicleCondition) IsValid() bool {
+	for _, v := range VehicleConditions() {
+		if c == v {
+			return true
+		}
+	}
+	return false
+}
+
 type VehicleStatus string
 
 const (
 	VehicleStatusInTransit    VehicleStatus = "in_transit"
 	VehicleStatusInRecon      VehicleStatus = "in_recon"
 	VehicleStatusReadyForSale VehicleStatus = "ready_for_sale"
+	VehicleStatusPendingSale  VehicleStatus = "pending_sale"
 	VehicleStatusSold         VehicleStatus = "sold"
 	VehicleStatusWholesale    VehicleStatus = "wholesale"
 )
 
+func VehicleStatuses() []VehicleStatus {
+	return []VehicleStatus{
+		VehicleStatusInTransit,
+		VehicleStatusInRecon,
+		VehicleStatusReadyForSale,
+		VehicleStatusPendingSale,
+		VehicleStatusSold,
+		VehicleStatusWholesale,
+	}
+}
+
+func (s VehicleStatus) IsValid() bool {
+	for _, v := range VehicleStatuses() {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
 type LotType string
 
 const (
@@ -32,6 +72,24 @@ const (
 	LotTypeWholesale LotType = "wholesale"
 )
 
+func LotTypes() []LotType {
+	return []LotType{
+		LotTypeNew,
+		LotTypeUsed,
+		LotTypeCPO,
+		LotTypeWholesale,
+	}
+}
+
+func (l LotType) IsValid() bool {
+	for _, v := range LotTypes() {
+		if l == v {
+			return true
+		}
+	}
+	return false
+}
+
 type AcquisitionSource string
 
 const (
@@ -42,6 +100,25 @@ const (
 	AcquisitionSourceConsignment    AcquisitionSource = "consignment"
 )
 
+func AcquisitionSources() []AcquisitionSource {
+	return []AcquisitionSource{
+		AcquisitionSourceFactory,
+		AcquisitionSourceTradeIn,
+		AcquisitionSourceAuction,
+		AcquisitionSourceDealerTransfer,
+		AcquisitionSourceConsignment,
+	}
+}
+
+func (a AcquisitionSource) IsValid() bool {
+	for _, v := range AcquisitionSources() {
+		if a == v {
+			return true
+		}
+	}
+	return false
+}
+
 type Vehicle struct {
 	ID                uint
 	StockNumber       string
@@ -64,29 +141,39 @@ type Vehicle struct {
 	AcquisitionSource AcquisitionSource
 	AcquisitionDate   time.Time
 	AcquisitionCost   float64
+	ReconCost         float64
+	AdditionalCost    float64
+	SalePrice         *float64
+	SoldAt            *time.Time
+	SoldLeadID        *uint
 	Model3DID         *uint
+	TrackingDeviceID  *string
+	DeletedAt         *time.Time
+	DeletedBy         *uint
+	Version           uint
 	CreatedAt         time.Time
 	ModifiedAt        time.Time
 }
 
 func NewVehicle(stockNumber string, vin string, make string, model string, year int) (*Vehicle, error) {
 	if stockNumber == "" {
-		return nil, errors.New("stock number is required")
+		return nil, sharedDomain.Invalid("stock_number", "stock number is required")
 	}
+	vin = NormalizeVIN(vin)
 	if vin == "" {
-		return nil, errors.New("VIN is required")
+		return nil, sharedDomain.Invalid("vin", "VIN is required")
 	}
-	if len(vin) != 17 {
-		return nil, errors.New("VIN must be 17 characters")
+	if err := ValidateVIN(vin); err != nil {
+		return nil, err
 	}
 	if make == "" {
-		return nil, errors.New("make is required")
+		return nil, sharedDomain.Invalid("make", "make is required")
 	}
 	if model == "" {
-		return nil, errors.New("model is required")
+		return nil, sharedDomain.Invalid("model", "model is required")
 	}
 	if year < 1900 || year > time.Now().Year()+2 {
-		return nil, errors.New("invalid year")
+		return nil, sharedDomain.Invalid("year", "invalid year")
 	}
 
 	return &Vehicle{
@@ -104,7 +191,7 @@ func NewVehicle(stockNumber string, vin string, make string, model string, year
 
 func (v *Vehicle) SetPricing(msrp float64, invoicePrice float64, askingPrice float64) error {
 	if msrp < 0 || invoicePrice < 0 || askingPrice < 0 {
-		return errors.New("prices cannot be negative")
+		return sharedDomain.Invalid("prices", "prices cannot be negative")
 	}
 	v.MSRP = msrp
 	v.InvoicePrice = invoicePrice
@@ -114,8 +201,11 @@ func (v *Vehicle) SetPricing(msrp float64, invoicePrice float64, askingPrice flo
 }
 
 func (v *Vehicle) SetAcquisition(source AcquisitionSource, cost float64, date time.Time) error {
+	if !source.IsValid() {
+		return sharedDomain.Invalid("acquisition_source", "invalid acquisition source")
+	}
 	if cost < 0 {
-		return errors.New("acquisition cost cannot be negative")
+		return sharedDomain.Invalid("acquisition_cost", "acquisition cost cannot be negative")
 	}
 	v.AcquisitionSource = source
 	v.AcquisitionCost = cost
@@ -124,17 +214,16 @@ func (v *Vehicle) SetAcquisition(source AcquisitionSource, cost float64, date ti
 	return nil
 }
 
-func (v *Vehicle) SetCondition(condition VehicleCondition) {
+func (v *Vehicle) SetCondition(condition VehicleCondition) error {
+	if !condition.IsValid() {
+		return sharedDomain.Invalid("condition", "invalid condition")
+	}
 	v.Condition = condition
 	if condition == VehicleConditionNew {
 		v.LotType = LotTypeNew
 	}
 	v.ModifiedAt = time.Now()
-}
-
-func (v *Vehicle) SetStatus(status VehicleStatus) {
-	v.Status = status
-	v.ModifiedAt = time.Now()
+	return nil
 }
 
 func (v *Vehicle) SetLocation(locationID uint) {
@@ -142,33 +231,98 @@ func (v *Vehicle) SetLocation(locationID uint) {
 	v.ModifiedAt = time.Now()
 }
 
-func (v *Vehicle) MarkAsSold() error {
-	if v.Status == VehicleStatusSold {
-		return errors.New("vehicle is already sold")
+// transitStatuses - estados desde los que se puede mandar el vehicle a otra ubicación; lo
+// vendido, lo que tiene un trato pendiente y lo dado de baja ya no se mueve
+var transitStatuses = map[VehicleStatus]bool{
+	VehicleStatusInRecon:      true,
+	VehicleStatusReadyForSale: true,
+	VehicleStatusInTransit:    true,
+}
+
+// StartTransit - el vehicle pasa a la ubicación de tránsito hasta que se confirme la llegada
+func (v *Vehicle) StartTransit(transitLocationID uint) error {
+	if v.IsDeleted() {
+		return sharedDomain.Invariant("vehicle_deleted", "cannot transfer deleted vehicle")
+	}
+	if !transitStatuses[v.Status] {
+		return sharedDomain.Invariant("invalid_status_transition", fmt.Sprintf("cannot transfer vehicle in status %s", v.Status))
 	}
-	v.Status = VehicleStatusSold
+	v.Status = VehicleStatusInTransit
+	v.LocationID = transitLocationID
 	v.ModifiedAt = time.Now()
 	return nil
 }
 
-func (v *Vehicle) MarkAsReadyForSale() error {
-	if v.Status == VehicleStatusSold {
-		return errors.New("cannot change status of sold vehicle")
+// CanArriveAs - al salir de tránsito el vehicle solo puede quedar en recon o a la venta, y
+// a la venta con las mismas reglas que un cambio de estado manual
+func (v *Vehicle) CanArriveAs(status VehicleStatus) error {
+	if v.Status != VehicleStatusInTransit {
+		return sharedDomain.Invariant("vehicle_not_in_transit", "vehicle is not in transit")
+	}
+	if status != VehicleStatusInRecon && status != VehicleStatusReadyForSale {
+		return sharedDomain.Invariant("invalid_status_transition", fmt.Sprintf("cannot change status from %s to %s", v.Status, status))
+	}
+	return v.checkTargetRules(status)
+}
+
+func (v *Vehicle) Arrive(locationID uint, status VehicleStatus) error {
+	if err := v.CanArriveAs(status); err != nil {
+		return err
 	}
-	v.Status = VehicleStatusReadyForSale
+	v.Status = status
+	v.LocationID = locationID
 	v.ModifiedAt = time.Now()
 	return nil
 }
 
-func (v *Vehicle) SendToRecon() error {
-	if v.Status == VehicleStatusSold {
-		return errors.New("cannot send sold vehicle to recon")
+// AssignTrackingDevice - un id vacío desasigna el dispositivo
+func (v *Vehicle) AssignTrackingDevice(deviceID string) {
+	v.TrackingDeviceID = nil
+	if deviceID = NormalizeDeviceID(deviceID); deviceID != "" {
+		v.TrackingDeviceID = &deviceID
+	}
+	v.ModifiedAt = time.Now()
+}
+
+// AssignModel3D - nil deja el vehicle sin modelo para el visor
+func (v *Vehicle) AssignModel3D(model *VehicleModel3D) error {
+	if model == nil {
+		v.Model3DID = nil
+	} else {
+		if !model.Active {
+			return sharedDomain.Invariant("model_inactive", "model is inactive")
+		}
+		id := model.ID
+		v.Model3DID = &id
 	}
-	v.Status = VehicleStatusInRecon
 	v.ModifiedAt = time.Now()
 	return nil
 }
 
+func (v *Vehicle) MarkAsSold() error {
+	if v.Status == VehicleStatusSold {
+		return sharedDomain.Invariant("vehicle_already_sold", "vehicle is already sold")
+	}
+	return v.TransitionTo(VehicleStatusSold)
+}
+
+// MarkAsPendingSale - hay un trato firmado pendiente de entrega o financiación
+func (v *Vehicle) MarkAsPendingSale() error {
+	return v.TransitionTo(VehicleStatusPendingSale)
+}
+
+func (v *Vehicle) MarkAsWholesale() error {
+	return v.TransitionTo(VehicleStatusWholesale)
+}
+
+func (v *Vehicle) MarkAsReadyForSale() error {
+	return v.TransitionTo(VehicleStatusReadyForSale)
+}
+
+func (v *Vehicle) SendToRecon() error {
+	return v.TransitionTo(VehicleStatusInRecon)
+}
+
 func (v *Vehicle) IsAvailable() bool {
 	return v.Status == VehicleStatusReadyForSale
 }
@@ -177,13 +331,87 @@ func (v *Vehicle) IsSold() bool {
 	return v.Status == VehicleStatusSold
 }
 
+// SetReconCost - lo gastado en tareas de recon completadas, en todas las pasadas por recon
+func (v *Vehicle) SetReconCost(cost float64) {
+	v.ReconCost = cost
+	v.ModifiedAt = time.Now()
+}
+
+// SetAdditionalCost - suma de las entradas no anuladas del ledger de costes
+func (v *Vehicle) SetAdditionalCost(cost float64) {
+	v.AdditionalCost = cost
+	v.ModifiedAt = time.Now()
+}
+
+// SetSalePrice - precio real del trato, una vez firmado
+func (v *Vehicle) SetSalePrice(price float64) error {
+	if v.Status != VehicleStatusPendingSale && v.Status != VehicleStatusSold {
+		return sharedDomain.Invariant("vehicle_not_sold", "vehicle has no sale")
+	}
+	if price <= 0 {
+		return sharedDomain.Invalid("sale_price", "sale price must be positive")
+	}
+	v.SalePrice = &price
+	v.ModifiedAt = time.Now()
+	return nil
+}
+
+// SetSoldLead - lead del comprador; es el único lead de la venta que queda bajo retención legal
+func (v *Vehicle) SetSoldLead(leadID uint) error {
+	if v.Status != VehicleStatusPendingSale && v.Status != VehicleStatusSold {
+		return sharedDomain.Invariant("vehicle_not_sold", "vehicle has no sale")
+	}
+	if leadID == 0 {
+		return sharedDomain.Invalid("lead_id", "invalid lead")
+	}
+	v.SoldLeadID = &leadID
+	v.ModifiedAt = time.Now()
+	return nil
+}
+
+// CostBasis - coste total del vehicle: adquisición, recon y el resto del ledger de costes
+func (v *Vehicle) CostBasis() float64 {
+	return v.AcquisitionCost + v.ReconCost + v.AdditionalCost
+}
+
+// ProfitPrice - precio de venta real si lo hay; si no, el beneficio es el esperado al
+// precio publicado
+func (v *Vehicle) ProfitPrice() float64 {
+	if v.SalePrice != nil {
+		return *v.SalePrice
+	}
+	return v.AskingPrice
+}
+
 func (v *Vehicle) Profit() float64 {
-	return v.AskingPrice - v.AcquisitionCost
+	profit, _ := profitAndMargin(v.ProfitPrice(), v.CostBasis())
+	return profit
 }
 
 func (v *Vehicle) Margin() float64 {
-	if v.AskingPrice == 0 {
-		return 0
+	_, margin := profitAndMargin(v.ProfitPrice(), v.CostBasis())
+	return margin
+}
+
+// SoftDelete - las fotos se conservan para poder restaurar el vehicle completo
+func (v *Vehicle) SoftDelete(deletedBy uint) {
+	now := time.Now()
+	v.DeletedAt = &now
+	v.DeletedBy = nil
+	if deletedBy != 0 {
+		v.DeletedBy = &deletedBy
 	}
-	return (v.Profit() / v.AskingPrice) * 100
+}
+
+func (v *Vehicle) Restore() error {
+	if !v.IsDeleted() {
+		return sharedDomain.Invariant("vehicle_not_deleted", "vehicle is not deleted")
+	}
+	v.DeletedAt = nil
+	v.DeletedBy = nil
+	return nil
+}
+
+func (v *Vehicle) IsDeleted() bool {
+	return v.DeletedAt != nil
 }
\ No newline at end of file
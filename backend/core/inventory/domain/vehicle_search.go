@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"time"
+
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+// VehicleSearch - criterios de búsqueda de inventario. Los slices son "cualquiera de",
+// los rangos son inclusivos y nil significa sin límite
+type VehicleSearch struct {
+	Text               string
+	Makes              []string
+	Models             []string
+	Trims              []string
+	Colors             []string
+	Conditions         []VehicleCondition
+	LotTypes           []LotType
+	Statuses           []VehicleStatus
+	AcquisitionSources []AcquisitionSource
+	LocationIDs        []uint
+	YearMin            *int
+	YearMax            *int
+	PriceMin           *float64
+	PriceMax           *float64
+	MileageMin         *int
+	MileageMax         *int
+	DaysInStockMin     *int
+	DaysInStockMax     *int
+	// Near + RadiusKM limita a los vehicles aparcados en ubicaciones dentro del radio
+	Near     *GeoOrigin
+	RadiusKM *float64
+}
+
+func (s VehicleSearch) Validate() error {
+	for _, c := range s.Conditions {
+		if !c.IsValid() {
+			return sharedDomain.Invalid("condition", "invalid condition")
+		}
+	}
+	for _, l := range s.LotTypes {
+		if !l.IsValid() {
+			return sharedDomain.Invalid("lot_type", "invalid lot type")
+		}
+	}
+	for _, st := range s.Statuses {
+		if !st.IsValid() {
+			return sharedDomain.Invalid("status", "invalid status")
+		}
+	}
+	for _, a := range s.AcquisitionSources {
+		if !a.IsValid() {
+			return sharedDomain.Invalid("acquisition_source", "invalid acquisition source")
+		}
+	}
+
+	if s.YearMin != nil && s.YearMax != nil && *s.YearMin > *s.YearMax {
+		return sharedDomain.Invalid("year", "year_min cannot be greater than year_max")
+	}
+	if s.PriceMin != nil && s.PriceMax != nil && *s.PriceMin > *s.PriceMax {
+		return sharedDomain.Invalid("price", "price_min cannot be greater than price_max")
+	}
+	if s.MileageMin != nil && s.MileageMax != nil && *s.MileageMin > *s.MileageMax {
+		return sharedDomain.Invalid("mileage", "mileage_min cannot be greater than mileage_max")
+	}
+	if (s.DaysInStockMin != nil && *s.DaysInStockMin < 0) || (s.DaysInStockMax != nil && *s.DaysInStockMax < 0) {
+		return sharedDomain.Invalid("days_in_stock", "days in stock cannot be negative")
+	}
+	if s.DaysInStockMin != nil && s.DaysInStockMax != nil && *s.DaysInStockMin > *s.DaysInStockMax {
+		return sharedDomain.Invalid("days_in_stock", "days_in_stock_min cannot be greater than days_in_stock_max")
+	}
+	if (s.Near != nil) != (s.RadiusKM != nil) {
+		return sharedDomain.Invalid("radius_km", "distance filter needs both an origin and radius_km")
+	}
+	if s.RadiusKM != nil {
+		if err := ValidateRadius(*s.RadiusKM); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RestrictToLocations - intersección con el filtro de ubicaciones que ya hubiera. Devuelve
+// false si no queda ninguna, en cuyo caso la búsqueda no puede tener resultados
+func (s *VehicleSearch) RestrictToLocations(ids []uint) bool {
+	if len(s.LocationIDs) == 0 {
+		s.LocationIDs = ids
+		return len(ids) > 0
+	}
+
+	allowed := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		allowed[id] = true
+	}
+	var kept []uint
+	for _, id := range s.LocationIDs {
+		if allowed[id] {
+			kept = append(kept, id)
+		}
+	}
+	s.LocationIDs = kept
+	return len(kept) > 0
+}
+
+// AcquiredBetween - traduce los días en stock a un rango de fechas de adquisición:
+// más días en stock significa una fecha de adquisición más antigua
+func (s VehicleSearch) AcquiredBetween(now time.Time) (from *time.Time, to *time.Time) {
+	if s.DaysInStockMax != nil {
+		t := now.AddDate(0, 0, -*s.DaysInStockMax)
+		from = &t
+	}
+	if s.DaysInStockMin != nil {
+		t := now.AddDate(0, 0, -*s.DaysInStockMin)
+		to = &t
+	}
+	return from, to
+}
+
+type FacetCount struct {
+	Value string
+	Count int64
+}
+
+// VehicleFacets - conteos para la barra lateral. Cada faceta ignora su propio filtro
+// para que el usuario vea las alternativas al seleccionar un valor
+type VehicleFacets struct {
+	Makes  []FacetCount
+	Models []FacetCount
+	Years  []FacetCount
+}
+
+// VehicleSearchResult - Distances (km por location_id) solo viene con filtro de distancia
+type VehicleSearchResult struct {
+	Vehicles  *sharedDomain.Page[*Vehicle]
+	Facets    *VehicleFacets
+	Distances map[uint]float64
+}
@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestVehicleSearchValidate(t *testing.T) {
+	n := func(v int) *int { return &v }
+	f := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name    string
+		search  VehicleSearch
+		wantErr bool
+	}{
+		{"empty", VehicleSearch{}, false},
+		{"valid enums", VehicleSearch{Conditions: []VehicleCondition{VehicleConditionUsed}, Statuses: []VehicleStatus{VehicleStatusReadyForSale}}, false},
+		{"invalid condition", VehicleSearch{Conditions: []VehicleCondition{"broken"}}, true},
+		{"invalid status", VehicleSearch{Statuses: []VehicleStatus{"lost"}}, true},
+		{"invalid lot type", VehicleSearch{LotTypes: []LotType{"roof"}}, true},
+		{"inverted year range", VehicleSearch{YearMin: n(2022), YearMax: n(2020)}, true},
+		{"inverted price range", VehicleSearch{PriceMin: f(30000), PriceMax: f(20000)}, true},
+		{"negative days in stock", VehicleSearch{DaysInStockMin: n(-1)}, true},
+		{"radius without origin", VehicleSearch{RadiusKM: f(50)}, true},
+		{"origin without radius", VehicleSearch{Near: &GeoOrigin{PostalCode: "10001"}}, true},
+		{"radius too large", VehicleSearch{Near: &GeoOrigin{PostalCode: "10001"}, RadiusKM: f(5000)}, true},
+		{"distance filter", VehicleSearch{Near: &GeoOrigin{PostalCode: "10001"}, RadiusKM: f(50)}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.search.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVehicleSearchAcquiredBetween(t *testing.T) {
+	n := func(v int) *int { return &v }
+	now := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+
+	from, to := VehicleSearch{DaysInStockMin: n(30), DaysInStockMax: n(60)}.AcquiredBetween(now)
+	if from == nil || !from.Equal(now.AddDate(0, 0, -60)) {
+		t.Errorf("AcquiredBetween() from = %v, want %v", from, now.AddDate(0, 0, -60))
+	}
+	if to == nil || !to.Equal(now.AddDate(0, 0, -30)) {
+		t.Errorf("AcquiredBetween() to = %v, want %v", to, now.AddDate(0, 0, -30))
+	}
+
+	if from, to := (VehicleSearch{}).AcquiredBetween(now); from != nil || to != nil {
+		t.Errorf("AcquiredBetween() = %v, %v, want no bounds", from, to)
+	}
+}
+
+func TestVehicleSearchRestrictToLocations(t *testing.T) {
+	s := VehicleSearch{}
+	if !s.RestrictToLocations([]uint{1, 2}) || len(s.LocationIDs) != 2 {
+		t.Errorf("RestrictToLocations() without previous filter = %v", s.LocationIDs)
+	}
+
+	s = VehicleSearch{LocationIDs: []uint{2, 3}}
+	if !s.RestrictToLocations([]uint{1, 2}) || len(s.LocationIDs) != 1 || s.LocationIDs[0] != 2 {
+		t.Errorf("RestrictToLocations() = %v, want [2]", s.LocationIDs)
+	}
+
+	s = VehicleSearch{LocationIDs: []uint{3}}
+	if s.RestrictToLocations([]uint{1, 2}) {
+		t.Errorf("RestrictToLocations() = true with no common locations")
+	}
+	if (&VehicleSearch{}).RestrictToLocations(nil) {
+		t.Errorf("RestrictToLocations(nil) = true, want no results")
+	}
+}
@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+// vehicleTransitions - cambios de estado manuales permitidos. in_transit no aparece como
+// destino manual: se entra con un traslado (StartTransit) y se sale al llegar (Arrive).
+// Volver de pending_sale, sold o wholesale es deshacer la venta
+var vehicleTransitions = map[VehicleStatus][]VehicleStatus{
+	VehicleStatusInTransit:    {},
+	VehicleStatusInRecon:      {VehicleStatusReadyForSale, VehicleStatusWholesale},
+	VehicleStatusReadyForSale: {VehicleStatusInRecon, VehicleStatusPendingSale, VehicleStatusSold, VehicleStatusWholesale},
+	VehicleStatusPendingSale:  {VehicleStatusSold, VehicleStatusReadyForSale, VehicleStatusInRecon},
+	VehicleStatusSold:         {VehicleStatusReadyForSale, VehicleStatusInRecon},
+	VehicleStatusWholesale:    {VehicleStatusReadyForSale, VehicleStatusInRecon},
+}
+
+// IsSaleUnwind - la transición deshace una venta en curso o cerrada
+func IsSaleUnwind(from VehicleStatus, to VehicleStatus) bool {
+	switch from {
+	case VehicleStatusPendingSale, VehicleStatusSold, VehicleStatusWholesale:
+		return to == VehicleStatusReadyForSale || to == VehicleStatusInRecon
+	}
+	return false
+}
+
+// CanTransitionTo - tabla de transiciones más las reglas propias de cada destino
+func (v *Vehicle) CanTransitionTo(to VehicleStatus) error {
+	if !to.IsValid() {
+		return sharedDomain.Invalid("status", "invalid status")
+	}
+	if v.IsDeleted() {
+		return sharedDomain.Invariant("vehicle_deleted", "cannot change status of deleted vehicle")
+	}
+	if v.Status == to {
+		return sharedDomain.Invariant("status_unchanged", fmt.Sprintf("vehicle is already %s", to))
+	}
+
+	allowed := false
+	for _, s := range vehicleTransitions[v.Status] {
+		if s == to {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return sharedDomain.Invariant("invalid_status_transition", fmt.Sprintf("cannot change status from %s to %s", v.Status, to))
+	}
+	return v.checkTargetRules(to)
+}
+
+// checkTargetRules - lo que exige el estado destino, se llegue por un cambio manual o al
+// terminar un traslado
+func (v *Vehicle) checkTargetRules(to VehicleStatus) error {
+	if to == VehicleStatusReadyForSale && v.AskingPrice <= 0 {
+		return sharedDomain.Invariant("asking_price_required", "vehicle needs an asking price to be ready for sale")
+	}
+	return nil
+}
+
+// AllowedTransitions - destinos a los que el vehicle puede pasar ahora mismo
+func (v *Vehicle) AllowedTransitions() []VehicleStatus {
+	allowed := []VehicleStatus{}
+	for _, to := range vehicleTransitions[v.Status] {
+		if v.CanTransitionTo(to) == nil {
+			allowed = append(allowed, to)
+		}
+	}
+	return allowed
+}
+
+func (v *Vehicle) TransitionTo(to VehicleStatus) error {
+	if err := v.CanTransitionTo(to); err != nil {
+		return err
+	}
+	now := time.Now()
+	// Un trato deshecho deja de tener precio, fecha y comprador
+	if IsSaleUnwind(v.Status, to) {
+		v.SalePrice = nil
+		v.SoldAt = nil
+		v.SoldLeadID = nil
+	}
+	if to == VehicleStatusSold {
+		v.SoldAt = &now
+	}
+	v.Status = to
+	v.ModifiedAt = now
+	return nil
+}
+
+// UnwindSale - el trato se cae: el vehicle vuelve a la venta o pasa antes por recon
+func (v *Vehicle) UnwindSale(to VehicleStatus) error {
+	if v.Status != VehicleStatusPendingSale && v.Status != VehicleStatusSold && v.Status != VehicleStatusWholesale {
+		return sharedDomain.Invariant("vehicle_not_sold", "vehicle has no sale to unwind")
+	}
+	if !IsSaleUnwind(v.Status, to) {
+		return sharedDomain.Invalid("status", "an unwound vehicle goes back to ready_for_sale or in_recon")
+	}
+	return v.TransitionTo(to)
+}
+
+// VehicleStatusHistory - un cambio de estado del vehicle, con quién y por qué
+type VehicleStatusHistory struct {
+	ID         uint
+	VehicleID  uint
+	FromStatus VehicleStatus
+	ToStatus   VehicleStatus
+	ChangedBy  uint
+	Reason     string
+	CreatedAt  time.Time
+}
+
+func NewVehicleStatusHistory(vehicleID uint, from VehicleStatus, to VehicleStatus, changedBy uint, reason string) *VehicleStatusHistory {
+	return &VehicleStatusHistory{
+		VehicleID:  vehicleID,
+		FromStatus: from,
+		ToStatus:   to,
+		ChangedBy:  changedBy,
+		Reason:     strings.TrimSpace(reason),
+		CreatedAt:  time.Now(),
+	}
+}
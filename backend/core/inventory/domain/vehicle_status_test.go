@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestVehicleTransitionTo(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    VehicleStatus
+		to      VehicleStatus
+		price   float64
+		wantErr bool
+	}{
+		{"recon to ready", VehicleStatusInRecon, VehicleStatusReadyForSale, 20000, false},
+		{"ready without price", VehicleStatusInRecon, VehicleStatusReadyForSale, 0, true},
+		{"ready to pending sale", VehicleStatusReadyForSale, VehicleStatusPendingSale, 20000, false},
+		{"pending sale to sold", VehicleStatusPendingSale, VehicleStatusSold, 20000, false},
+		{"recon straight to sold", VehicleStatusInRecon, VehicleStatusSold, 20000, true},
+		{"manual transit", VehicleStatusReadyForSale, VehicleStatusInTransit, 20000, true},
+		{"leave transit manually", VehicleStatusInTransit, VehicleStatusInRecon, 20000, true},
+		{"same status", VehicleStatusInRecon, VehicleStatusInRecon, 20000, true},
+		{"invalid status", VehicleStatusInRecon, VehicleStatus("lost"), 20000, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &Vehicle{Status: tt.from, AskingPrice: tt.price}
+			err := v.TransitionTo(tt.to)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TransitionTo() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && v.Status != tt.to {
+				t.Errorf("Status = %s, want %s", v.Status, tt.to)
+			}
+			if err != nil && v.Status != tt.from {
+				t.Errorf("Status changed to %s on error", v.Status)
+			}
+		})
+	}
+}
+
+func TestVehicleTransitionDeleted(t *testing.T) {
+	now := time.Now()
+	v := &Vehicle{Status: VehicleStatusInRecon, AskingPrice: 20000, DeletedAt: &now}
+	if err := v.TransitionTo(VehicleStatusReadyForSale); err == nil {
+		t.Error("TransitionTo() expected error for deleted vehicle")
+	}
+	if got := v.AllowedTransitions(); len(got) != 0 {
+		t.Errorf("AllowedTransitions() = %v, want none", got)
+	}
+}
+
+func TestVehicleAllowedTransitions(t *testing.T) {
+	v := &Vehicle{Status: VehicleStatusInRecon}
+	got := v.AllowedTransitions()
+	if len(got) != 1 || got[0] != VehicleStatusWholesale {
+		t.Errorf("AllowedTransitions() without price = %v, want [wholesale]", got)
+	}
+
+	v.AskingPrice = 20000
+	if got := v.AllowedTransitions(); len(got) != 2 {
+		t.Errorf("AllowedTransitions() = %v, want 2 statuses", got)
+	}
+}
+
+func TestVehicleUnwindSale(t *testing.T) {
+	v := &Vehicle{Status: VehicleStatusReadyForSale, AskingPrice: 20000}
+	if err := v.UnwindSale(VehicleStatusReadyForSale); err == nil {
+		t.Error("UnwindSale() expected error for vehicle without sale")
+	}
+
+	if err := v.MarkAsSold(); err != nil {
+		t.Fatalf("MarkAsSold() error = %v", err)
+	}
+	if v.SoldAt == nil {
+		t.Error("SoldAt not set on sale")
+	}
+	if err := v.SetSoldLead(5); err != nil {
+		t.Fatalf("SetSoldLead() error = %v", err)
+	}
+	if err := v.MarkAsSold(); err == nil {
+		t.Error("MarkAsSold() expected error for sold vehicle")
+	}
+	if err := v.UnwindSale(VehicleStatusWholesale); err == nil {
+		t.Error("UnwindSale() expected error for wholesale target")
+	}
+	if err := v.UnwindSale(VehicleStatusInRecon); err != nil {
+		t.Fatalf("UnwindSale() error = %v", err)
+	}
+	if v.Status != VehicleStatusInRecon {
+		t.Errorf("Status = %s, want in_recon", v.Status)
+	}
+	if v.SoldAt != nil || v.SoldLeadID != nil {
+		t.Error("sale record kept after unwinding the sale")
+	}
+	if err := v.SetSoldLead(5); err == nil {
+		t.Error("SetSoldLead() expected error for unsold vehicle")
+	}
+}
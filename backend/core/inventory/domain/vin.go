@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+// ISO 3779: 17 caracteres, sin I, O ni Q para no confundirlos con 1 y 0
+const vinLength = 17
+
+var vinTransliteration = map[byte]int{
+	'A': 1, 'B': 2, 'C': 3, 'D': 4, 'E': 5, 'F': 6, 'G': 7, 'H': 8,
+	'J': 1, 'K': 2, 'L': 3, 'M': 4, 'N': 5, 'P': 7, 'R': 9,
+	'S': 2, 'T': 3, 'U': 4, 'V': 5, 'W': 6, 'X': 7, 'Y': 8, 'Z': 9,
+}
+
+var vinWeights = [vinLength]int{8, 7, 6, 5, 4, 3, 2, 10, 0, 9, 8, 7, 6, 5, 4, 3, 2}
+
+// Códigos de año (posición 10); el ciclo se repite cada 30 años empezando en 1980
+const vinYearCodes = "ABCDEFGHJKLMNPRSTVWXY123456789"
+
+// VINDecode - lo que se puede saber de un VIN sin consultar servicios externos.
+// Los campos que dependen del dataset quedan vacíos si el fabricante no está en él
+type VINDecode struct {
+	VIN          string
+	WMI          string
+	VDS          string
+	VIS          string
+	Region       string
+	Manufacturer string
+	Make         string
+	Model        string
+	BodyType     string
+	ModelYear    int
+	PlantCode    string
+	Plant        string
+	SerialNumber string
+}
+
+func NormalizeVIN(vin string) string {
+	return strings.ToUpper(strings.TrimSpace(vin))
+}
+
+// ValidateVIN - espera el VIN ya normalizado. El dígito de control solo es obligatorio
+// en Norteamérica; el resto del mundo no lo usa de forma consistente
+func ValidateVIN(vin string) error {
+	if len(vin) != vinLength {
+		return sharedDomain.Invalid("vin", "VIN must be 17 characters")
+	}
+	for i := 0; i < vinLength; i++ {
+		if _, ok := vinCharValue(vin[i]); !ok {
+			return sharedDomain.Invalid("vin", "VIN contains invalid characters")
+		}
+	}
+	if !strings.ContainsRune(vinYearCodes, rune(vin[9])) {
+		return sharedDomain.Invalid("vin", "VIN has an invalid model year code")
+	}
+	if isNorthAmericanVIN(vin) && vin[8] != VINCheckDigit(vin) {
+		return sharedDomain.Invalid("vin", "VIN check digit does not match")
+	}
+	return nil
+}
+
+// VINCheckDigit - el dígito que corresponde a la posición 9 según el resto del VIN
+func VINCheckDigit(vin string) byte {
+	sum := 0
+	for i := 0; i < vinLength; i++ {
+		value, _ := vinCharValue(vin[i])
+		sum += value * vinWeights[i]
+	}
+	if sum%11 == 10 {
+		return 'X'
+	}
+	return byte('0' + sum%11)
+}
+
+// DecodeVIN - decodificación estructural: región, WMI/VDS/VIS, año, planta y serie
+func DecodeVIN(vin string, now time.Time) (*VINDecode, error) {
+	vin = NormalizeVIN(vin)
+	if err := ValidateVIN(vin); err != nil {
+		return nil, err
+	}
+
+	return &VINDecode{
+		VIN:          vin,
+		WMI:          vin[0:3],
+		VDS:          vin[3:9],
+		VIS:          vin[9:17],
+		Region:       vinRegion(vin[0]),
+		ModelYear:    vinModelYear(vin, now),
+		PlantCode:    vin[10:11],
+		SerialNumber: vin[11:17],
+	}, nil
+}
+
+func vinCharValue(c byte) (int, bool) {
+	if c >= '0' && c <= '9' {
+		return int(c - '0'), true
+	}
+	value, ok := vinTransliteration[c]
+	return value, ok
+}
+
+func isNorthAmericanVIN(vin string) bool {
+	return vin[0] >= '1' && vin[0] <= '5'
+}
+
+// vinModelYear - en Norteamérica una letra en la posición 7 indica el ciclo 2010-2039;
+// fuera de ahí se toma el año más reciente que no sea posterior al próximo model year
+func vinModelYear(vin string, now time.Time) int {
+	year := 1980 + strings.IndexByte(vinYearCodes, vin[9])
+
+	if isNorthAmericanVIN(vin) {
+		if vin[6] < '0' || vin[6] > '9' {
+			year += 30
+		}
+		return year
+	}
+
+	for year+30 <= now.Year()+1 {
+		year += 30
+	}
+	return year
+}
+
+func vinRegion(c byte) string {
+	switch {
+	case c >= '1' && c <= '5':
+		return "North America"
+	case c >= '6' && c <= '7':
+		return "Oceania"
+	case c >= '8' && c <= '9':
+		return "South America"
+	case c >= 'A' && c <= 'H':
+		return "Africa"
+	case c >= 'J' && c <= 'R':
+		return "Asia"
+	default:
+		return "Europe"
+	}
+}
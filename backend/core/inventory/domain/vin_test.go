@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestValidateVIN(t *testing.T) {
+	tests := []struct {
+		name    string
+		vin     string
+		wantErr bool
+	}{
+		{"valid north american", "1M8GDM9AXKP042788", false},
+		{"valid honda", "1HGCM82633A004352", false},
+		{"wrong length", "1HGCM82633A00435", true},
+		{"forbidden letter", "1HGCM82633A0O4352", true},
+		{"bad check digit", "1HGCM82643A004352", true},
+		{"invalid year code", "1HGCM8263UA004352", true},
+		{"check digit not required outside north america", "WBA3A5C51CF256551", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateVIN(tt.vin)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateVIN(%s) error = %v, wantErr %v", tt.vin, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDecodeVIN(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	decoded, err := DecodeVIN(" 1hgcm82633a004352 ", now)
+	if err != nil {
+		t.Fatalf("DecodeVIN() error = %v", err)
+	}
+	if decoded.VIN != "1HGCM82633A004352" || decoded.WMI != "1HG" || decoded.PlantCode != "A" || decoded.SerialNumber != "004352" {
+		t.Errorf("DecodeVIN() = %+v", decoded)
+	}
+	// Posición 7 numérica: ciclo 1980-2009
+	if decoded.ModelYear != 2003 {
+		t.Errorf("DecodeVIN() model year = %d, want 2003", decoded.ModelYear)
+	}
+	if decoded.Region != "North America" {
+		t.Errorf("DecodeVIN() region = %s, want North America", decoded.Region)
+	}
+}
+
+func TestVINModelYearCycles(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		vin  string
+		want int
+	}{
+		{"1FA6P8TH0J5100001", 2018}, // posición 7 letra: ciclo 2010-2039
+		{"1HGCM82633A004352", 2003},
+		{"WBA3A5C51CF256551", 2012}, // fuera de Norteamérica: el más reciente posible
+		{"WBA3A5C51YF256551", 2000},
+	}
+
+	for _, tt := range tests {
+		if got := vinModelYear(tt.vin, now); got != tt.want {
+			t.Errorf("vinModelYear(%s) = %d, want %d", tt.vin, got, tt.want)
+		}
+	}
+}
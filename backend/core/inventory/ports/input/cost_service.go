@@ -0,0 +1,27 @@
+package input
+
+import (
+	"context"
+	"time"
+
+	"torque-dms/core/inventory/domain"
+)
+
+// AddCostEntryInput - IncurredOn vacío es hoy
+type AddCostEntryInput struct {
+	Type       string
+	Amount     float64
+	Vendor     string
+	InvoiceRef string
+	IncurredOn time.Time
+	Notes      string
+}
+
+// CostService - ledger de costes del vehicle. La compra y las tareas de recon entran solas;
+// el resto de costes se cargan aquí
+type CostService interface {
+	GetLedger(ctx context.Context, vehicleID uint) (*domain.CostLedger, error)
+	ListEntries(ctx context.Context, vehicleID uint) ([]*domain.CostEntry, error)
+	AddEntry(ctx context.Context, vehicleID uint, input AddCostEntryInput) (*domain.CostEntry, error)
+	VoidEntry(ctx context.Context, vehicleID uint, entryID uint) (*domain.CostEntry, error)
+}
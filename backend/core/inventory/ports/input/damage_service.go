@@ -0,0 +1,28 @@
+package input
+
+import (
+	"context"
+
+	"torque-dms/core/inventory/domain"
+)
+
+// RecordDamageInput - PhotoID opcional, una foto del vehicle con purpose damage
+type RecordDamageInput struct {
+	VehicleID   uint
+	ZoneID      uint
+	Type        string
+	Severity    string
+	Description string
+	PhotoID     *uint
+	ReportedBy  uint
+}
+
+// DamageService - mapa de daños del vehicle sobre las zonas de su modelo 3D; lo comparten
+// tasación, recon y entrega
+type DamageService interface {
+	RecordMark(ctx context.Context, input RecordDamageInput) (*domain.VehicleZoneMark, error)
+	AttachPhoto(ctx context.Context, vehicleID uint, markID uint, photoID uint) (*domain.VehicleZoneMark, error)
+	ResolveMark(ctx context.Context, vehicleID uint, markID uint, resolvedBy uint) (*domain.VehicleZoneMark, error)
+	ListMarks(ctx context.Context, vehicleID uint, includeResolved bool) ([]*domain.VehicleZoneMark, error)
+	GetConditionReport(ctx context.Context, vehicleID uint) (*domain.ConditionReport, error)
+}
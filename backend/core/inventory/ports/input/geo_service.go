@@ -0,0 +1,15 @@
+package input
+
+import (
+	"context"
+
+	"torque-dms/core/inventory/domain"
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+type GeoService interface {
+	ResolveOrigin(ctx context.Context, origin domain.GeoOrigin) (sharedDomain.GeoPoint, error)
+	NearestLocations(ctx context.Context, origin domain.GeoOrigin, types []string, limit int) ([]*domain.LocationDistance, error)
+	LocationsWithin(ctx context.Context, origin sharedDomain.GeoPoint, radiusKM float64) ([]*domain.LocationDistance, error)
+	DistanceToLocation(ctx context.Context, locationID uint, origin domain.GeoOrigin) (*domain.LocationDistance, error)
+}
@@ -0,0 +1,16 @@
+package input
+
+import (
+	"context"
+
+	"torque-dms/core/inventory/domain"
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+type GeofenceService interface {
+	// Process - detecta los cruces en los puntos recién guardados y mueve los vehicles.
+	// previous es el último punto de cada vehicle antes del lote
+	Process(ctx context.Context, previous map[uint]*domain.TrackingPoint, points []*domain.TrackingPoint) ([]*domain.GeofenceEvent, error)
+	ListByVehicle(ctx context.Context, vehicleID uint, q sharedDomain.Query) (*sharedDomain.Page[*domain.GeofenceEvent], error)
+	ListByLocation(ctx context.Context, locationID uint, q sharedDomain.Query) (*sharedDomain.Page[*domain.GeofenceEvent], error)
+}
@@ -0,0 +1,73 @@
+package input
+
+import (
+	"context"
+
+	"torque-dms/core/inventory/domain"
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+// InspectionItemInput - con Unit el item es una medición; MinValue/MaxValue su rango aceptable
+type InspectionItemInput struct {
+	Label         string
+	Required      bool
+	PhotoRequired bool
+	Unit          string
+	MinValue      *float64
+	MaxValue      *float64
+}
+
+type InspectionSectionInput struct {
+	Name  string
+	Items []InspectionItemInput
+}
+
+type CreateInspectionTemplateInput struct {
+	Name        string
+	Kind        string
+	Description string
+	Sections    []InspectionSectionInput
+}
+
+// UpdateInspectionTemplateInput - Sections reemplaza la estructura completa; solo se admite
+// mientras ninguna inspección use la plantilla
+type UpdateInspectionTemplateInput struct {
+	Name        *string
+	Description *string
+	Sections    *[]InspectionSectionInput
+	Version     *uint
+}
+
+type StartInspectionInput struct {
+	VehicleID   uint
+	TemplateID  uint
+	InspectorID uint
+}
+
+// RecordResultInput - en las mediciones con rango Result se calcula a partir de Value
+type RecordResultInput struct {
+	ItemID  uint
+	Result  string
+	Value   *float64
+	PhotoID *uint
+	Notes   string
+}
+
+type InspectionService interface {
+	// Templates
+	CreateTemplate(ctx context.Context, input CreateInspectionTemplateInput) (*domain.InspectionTemplate, error)
+	GetTemplate(ctx context.Context, id uint) (*domain.InspectionTemplate, error)
+	ListTemplates(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.InspectionTemplate], error)
+	UpdateTemplate(ctx context.Context, id uint, input UpdateInspectionTemplateInput) (*domain.InspectionTemplate, error)
+	DeleteTemplate(ctx context.Context, id uint) error
+	DeactivateTemplate(ctx context.Context, id uint) error
+	ActivateTemplate(ctx context.Context, id uint) error
+
+	// Inspections
+	Start(ctx context.Context, input StartInspectionInput) (*domain.Inspection, error)
+	GetByID(ctx context.Context, id uint) (*domain.Inspection, error)
+	ListByVehicle(ctx context.Context, vehicleID uint) ([]*domain.Inspection, error)
+	RecordResults(ctx context.Context, inspectionID uint, results []RecordResultInput) (*domain.Inspection, error)
+	// Complete - falla si quedan items obligatorios sin resultado o fotos obligatorias sin subir
+	Complete(ctx context.Context, id uint, notes string) (*domain.Inspection, error)
+}
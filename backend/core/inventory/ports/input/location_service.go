@@ -1,6 +1,11 @@
 package input
 
-import "torque-dms/core/inventory/domain"
+import (
+	"context"
+
+	"torque-dms/core/inventory/domain"
+	sharedDomain "torque-dms/core/shared/domain"
+)
 
 type CreateLocationInput struct {
 	Name      string
@@ -24,16 +29,25 @@ type UpdateLocationInput struct {
 	Latitude  *float64
 	Longitude *float64
 	Capacity  *int
+	Version   *uint
+}
+
+// SetGeofenceInput - radio o polígono; los dos vacíos quitan la geocerca
+type SetGeofenceInput struct {
+	RadiusM float64
+	Polygon []sharedDomain.GeoPoint
+	Version *uint
 }
 
 type LocationService interface {
-	Create(input CreateLocationInput) (*domain.Location, error)
-	GetByID(id uint) (*domain.Location, error)
-	Update(id uint, input UpdateLocationInput) (*domain.Location, error)
-	Delete(id uint) error
-	List() ([]*domain.Location, error)
-	ListByType(locationType string) ([]*domain.Location, error)
-	ListActive() ([]*domain.Location, error)
-	Deactivate(id uint) error
-	Activate(id uint) error
+	Create(ctx context.Context, input CreateLocationInput) (*domain.Location, error)
+	GetByID(ctx context.Context, id uint) (*domain.Location, error)
+	Update(ctx context.Context, id uint, input UpdateLocationInput) (*domain.Location, error)
+	Delete(ctx context.Context, id uint) error
+	List(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.Location], error)
+	ListByType(ctx context.Context, locationType string) ([]*domain.Location, error)
+	ListActive(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.Location], error)
+	Deactivate(ctx context.Context, id uint) error
+	Activate(ctx context.Context, id uint) error
+	SetGeofence(ctx context.Context, id uint, input SetGeofenceInput) (*domain.Location, error)
 }
\ No newline at end of file
@@ -0,0 +1,54 @@
+package input
+
+import (
+	"context"
+	"io"
+
+	"torque-dms/core/inventory/domain"
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+type CreateModel3DInput struct {
+	Name     string
+	BodyType string
+}
+
+type UpdateModel3DInput struct {
+	Name    *string
+	Version *uint
+}
+
+// UploadModelFileInput - Kind es file (la malla glTF) o thumbnail
+type UploadModelFileInput struct {
+	Model3DID uint
+	Kind      string
+	Filename  string
+	Size      int64
+	Content   io.Reader
+}
+
+type ModelZoneInput struct {
+	Code   string
+	Name   string
+	MeshID string
+}
+
+type Model3DService interface {
+	Create(ctx context.Context, input CreateModel3DInput) (*domain.VehicleModel3D, error)
+	GetByID(ctx context.Context, id uint) (*domain.VehicleModel3D, error)
+	Update(ctx context.Context, id uint, input UpdateModel3DInput) (*domain.VehicleModel3D, error)
+	Delete(ctx context.Context, id uint) error
+	List(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.VehicleModel3D], error)
+	Deactivate(ctx context.Context, id uint) error
+	Activate(ctx context.Context, id uint) error
+	// SetDefault - el modelo pasa a ser el que reciben los vehicles nuevos de su carrocería
+	SetDefault(ctx context.Context, id uint) (*domain.VehicleModel3D, error)
+	UploadFile(ctx context.Context, input UploadModelFileInput) (*domain.VehicleModel3D, error)
+	// AssignToVehicle - model3DID 0 deja el vehicle sin modelo
+	AssignToVehicle(ctx context.Context, vehicleID uint, model3DID uint) (*domain.Vehicle, error)
+
+	ListZones(ctx context.Context, model3DID uint) ([]*domain.VehicleModelZone, error)
+	AddZone(ctx context.Context, model3DID uint, input ModelZoneInput) (*domain.VehicleModelZone, error)
+	UpdateZone(ctx context.Context, model3DID uint, zoneID uint, input ModelZoneInput) (*domain.VehicleModelZone, error)
+	DeleteZone(ctx context.Context, model3DID uint, zoneID uint) error
+}
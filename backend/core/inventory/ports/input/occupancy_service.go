@@ -0,0 +1,12 @@
+package input
+
+import (
+	"context"
+
+	"torque-dms/core/inventory/domain"
+)
+
+type OccupancyService interface {
+	GetOccupancy(ctx context.Context, locationID uint) (*domain.LocationOccupancy, error)
+	GetSummary(ctx context.Context) (*domain.OccupancySummary, error)
+}
@@ -0,0 +1,43 @@
+package input
+
+import (
+	"context"
+	"time"
+
+	"torque-dms/core/inventory/domain"
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+// RepricingRuleInput - Condition y LotType vacíos valen para todos los vehicles
+type RepricingRuleInput struct {
+	Name           string
+	Condition      string
+	LotType        string
+	MinDaysInStock int
+	DropPercent    float64
+	IntervalDays   int
+	FloorOverCost  float64
+	Version        *uint
+}
+
+// PricingService - antigüedad del stock y reglas de rebaja automática del precio publicado.
+// Los cambios manuales de precio se hacen con VehicleService.Update y quedan en el mismo historial
+type PricingService interface {
+	GetAging(ctx context.Context) (*domain.InventoryAging, error)
+	GetPriceHistory(ctx context.Context, vehicleID uint, q sharedDomain.Query) (*sharedDomain.Page[*domain.PriceChange], error)
+
+	CreateRule(ctx context.Context, input RepricingRuleInput) (*domain.RepricingRule, error)
+	UpdateRule(ctx context.Context, id uint, input RepricingRuleInput) (*domain.RepricingRule, error)
+	ActivateRule(ctx context.Context, id uint) (*domain.RepricingRule, error)
+	DeactivateRule(ctx context.Context, id uint) (*domain.RepricingRule, error)
+	DeleteRule(ctx context.Context, id uint) error
+	GetRule(ctx context.Context, id uint) (*domain.RepricingRule, error)
+	ListRules(ctx context.Context) ([]*domain.RepricingRule, error)
+
+	// PreviewRepricing - dry run sin cambiar nada. Sin ruleID simula las reglas activas; con
+	// ruleID, esa regla sola aunque esté inactiva
+	PreviewRepricing(ctx context.Context, ruleID *uint) ([]*domain.RepricingProposal, error)
+	// ApplyRepricing - aplica las reglas activas a fecha asOf; devuelve los vehicles rebajados.
+	// Tiene la firma de un target del scheduler
+	ApplyRepricing(ctx context.Context, asOf time.Time) (int, error)
+}
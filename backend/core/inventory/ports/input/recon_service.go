@@ -0,0 +1,45 @@
+package input
+
+import (
+	"context"
+	"time"
+
+	"torque-dms/core/inventory/domain"
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+// AddReconTaskInput - AssigneeID y Vendor son opcionales; el estimado decide si hace falta aprobación
+type AddReconTaskInput struct {
+	Type          string
+	Description   string
+	AssigneeID    *uint
+	Vendor        string
+	EstimatedCost float64
+}
+
+// UpdateReconTaskInput - AssigneeID 0 deja la tarea sin responsable
+type UpdateReconTaskInput struct {
+	Description   *string
+	AssigneeID    *uint
+	Vendor        *string
+	EstimatedCost *float64
+	Version       *uint
+}
+
+// ReconService - órdenes de recon abiertas con VehicleService.SendToRecon; aquí se gestionan
+// sus tareas, el cierre y el tiempo de ciclo
+type ReconService interface {
+	GetByID(ctx context.Context, id uint) (*domain.ReconOrder, error)
+	List(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.ReconOrder], error)
+	ListByVehicle(ctx context.Context, vehicleID uint) ([]*domain.ReconOrder, error)
+	Close(ctx context.Context, id uint) (*domain.ReconOrder, error)
+	GetCycleStats(ctx context.Context, from time.Time, to time.Time) (*domain.ReconCycleStats, error)
+
+	AddTask(ctx context.Context, orderID uint, input AddReconTaskInput) (*domain.ReconTask, error)
+	UpdateTask(ctx context.Context, orderID uint, taskID uint, input UpdateReconTaskInput) (*domain.ReconTask, error)
+	ApproveTask(ctx context.Context, orderID uint, taskID uint) (*domain.ReconTask, error)
+	StartTask(ctx context.Context, orderID uint, taskID uint) (*domain.ReconTask, error)
+	// CompleteTask - el coste real pasa al coste total del vehicle
+	CompleteTask(ctx context.Context, orderID uint, taskID uint, actualCost float64) (*domain.ReconTask, error)
+	CancelTask(ctx context.Context, orderID uint, taskID uint) (*domain.ReconTask, error)
+}
@@ -0,0 +1,40 @@
+package input
+
+import (
+	"context"
+
+	"torque-dms/core/inventory/domain"
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+// CreateRouteInput - DistanceKM 0 se calcula en línea recta por los waypoints
+type CreateRouteInput struct {
+	Name             string
+	FromLocationID   uint
+	ToLocationID     uint
+	DistanceKM       float64
+	EstimatedMinutes int
+	Waypoints        []sharedDomain.GeoPoint
+}
+
+type UpdateRouteInput struct {
+	Name             *string
+	DistanceKM       *float64
+	EstimatedMinutes *int
+	Waypoints        *[]sharedDomain.GeoPoint
+	Version          *uint
+}
+
+type RouteService interface {
+	Create(ctx context.Context, input CreateRouteInput) (*domain.Route, error)
+	GetByID(ctx context.Context, id uint) (*domain.Route, error)
+	Update(ctx context.Context, id uint, input UpdateRouteInput) (*domain.Route, error)
+	Delete(ctx context.Context, id uint) error
+	List(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.Route], error)
+	Deactivate(ctx context.Context, id uint, version *uint) error
+	Activate(ctx context.Context, id uint, version *uint) error
+	// GetProgress - traslado pendiente del vehicle con su posición y llegada estimada
+	GetProgress(ctx context.Context, vehicleID uint) (*domain.RouteProgress, error)
+	// ListInbound - traslados pendientes hacia la ubicación, para el lote que los recibe
+	ListInbound(ctx context.Context, locationID uint) ([]*domain.RouteProgress, error)
+}
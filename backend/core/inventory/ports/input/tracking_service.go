@@ -0,0 +1,18 @@
+package input
+
+import (
+	"context"
+	"time"
+
+	"torque-dms/core/inventory/domain"
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+type TrackingService interface {
+	Ingest(ctx context.Context, pings []domain.Ping) (*domain.IngestResult, error)
+	AssignDevice(ctx context.Context, vehicleID uint, deviceID string) (*domain.Vehicle, error)
+	GetLastPosition(ctx context.Context, vehicleID uint) (*domain.TrackingPoint, error)
+	// GetTrack - from y to en cero son las últimas 24 horas
+	GetTrack(ctx context.Context, vehicleID uint, from time.Time, to time.Time, q sharedDomain.Query) (*sharedDomain.Page[*domain.TrackingPoint], error)
+	Compact(ctx context.Context, now time.Time) (int, error)
+}
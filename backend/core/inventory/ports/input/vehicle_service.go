@@ -1,6 +1,12 @@
 package input
 
-import "torque-dms/core/inventory/domain"
+import (
+	"context"
+	"time"
+
+	"torque-dms/core/inventory/domain"
+	sharedDomain "torque-dms/core/shared/domain"
+)
 
 type CreateVehicleInput struct {
 	StockNumber       string
@@ -20,6 +26,8 @@ type CreateVehicleInput struct {
 	LocationID        uint
 	AcquisitionSource string
 	AcquisitionCost   float64
+	// BodyType - elige el modelo 3D predeterminado; vacío se deduce del VIN
+	BodyType string
 }
 
 type UpdateVehicleInput struct {
@@ -31,6 +39,7 @@ type UpdateVehicleInput struct {
 	MSRP          *float64
 	InvoicePrice  *float64
 	AskingPrice   *float64
+	Version       *uint
 }
 
 type AddPhotoInput struct {
@@ -42,26 +51,61 @@ type AddPhotoInput struct {
 	IsPrimary   bool
 }
 
+// StartTransferInput - TransitLocationID 0 usa la primera ubicación in_transit activa.
+// RouteID 0 usa la ruta activa entre origen y destino si solo hay una
+type StartTransferInput struct {
+	VehicleID         uint
+	ToLocationID      uint
+	TransitLocationID uint
+	RouteID           uint
+	Reason            string
+}
+
+// MarkAsSoldInput - SalePrice es el precio real del trato; sin él el beneficio sigue
+// calculándose sobre el precio publicado. LeadID es el lead del comprador
+type MarkAsSoldInput struct {
+	SalePrice *float64
+	LeadID    *uint
+}
+
 type VehicleService interface {
-	Create(input CreateVehicleInput) (*domain.Vehicle, error)
-	GetByID(id uint) (*domain.Vehicle, error)
-	GetByVIN(vin string) (*domain.Vehicle, error)
-	Update(id uint, input UpdateVehicleInput) (*domain.Vehicle, error)
-	Delete(id uint) error
-	List(limit int, offset int) ([]*domain.Vehicle, error)
-	ListAvailable(limit int, offset int) ([]*domain.Vehicle, error)
-	ListByStatus(status string, limit int, offset int) ([]*domain.Vehicle, error)
-	ListByLocation(locationID uint) ([]*domain.Vehicle, error)
+	Create(ctx context.Context, input CreateVehicleInput) (*domain.Vehicle, error)
+	GetByID(ctx context.Context, id uint) (*domain.Vehicle, error)
+	GetByVIN(ctx context.Context, vin string) (*domain.Vehicle, error)
+	DecodeVIN(ctx context.Context, vin string) (*domain.VINDecode, error)
+	Update(ctx context.Context, id uint, input UpdateVehicleInput) (*domain.Vehicle, error)
+	Delete(ctx context.Context, id uint) error
+	List(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.Vehicle], error)
+	ListAvailable(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.Vehicle], error)
+	ListByStatus(ctx context.Context, status string, q sharedDomain.Query) (*sharedDomain.Page[*domain.Vehicle], error)
+	ListByLocation(ctx context.Context, locationID uint) ([]*domain.Vehicle, error)
+	Search(ctx context.Context, criteria domain.VehicleSearch, q sharedDomain.Query) (*domain.VehicleSearchResult, error)
 
 	// Status changes
-	MarkAsSold(id uint) error
-	MarkAsReadyForSale(id uint) error
-	SendToRecon(id uint) error
-	ChangeLocation(id uint, locationID uint) error
+	MarkAsSold(ctx context.Context, id uint, inp MarkAsSoldInput) error
+	MarkAsReadyForSale(ctx context.Context, id uint) error
+	SendToRecon(ctx context.Context, id uint) error
+	// ChangeStatus - cualquier transición de la máquina de estados salvo entrar en tránsito,
+	// que se hace con un traslado
+	ChangeStatus(ctx context.Context, id uint, status string, reason string) (*domain.Vehicle, error)
+	// UnwindSale - status ready_for_sale (por defecto) o in_recon; reason es obligatorio
+	UnwindSale(ctx context.Context, id uint, status string, reason string) (*domain.Vehicle, error)
+	GetStatusHistory(ctx context.Context, vehicleID uint, q sharedDomain.Query) (*sharedDomain.Page[*domain.VehicleStatusHistory], error)
+	ChangeLocation(ctx context.Context, id uint, locationID uint, reason string) error
+
+	// Transfers
+	StartTransfer(ctx context.Context, input StartTransferInput) (*domain.VehicleTransfer, error)
+	ConfirmArrival(ctx context.Context, vehicleID uint) (*domain.VehicleTransfer, error)
+	GetLocationHistory(ctx context.Context, vehicleID uint, q sharedDomain.Query) (*sharedDomain.Page[*domain.VehicleLocationHistory], error)
 
 	// Photos
-	AddPhoto(input AddPhotoInput) (*domain.VehiclePhoto, error)
-	GetPhotos(vehicleID uint) ([]*domain.VehiclePhoto, error)
-	SetPrimaryPhoto(vehicleID uint, photoID uint) error
-	DeletePhoto(photoID uint) error
+	AddPhoto(ctx context.Context, input AddPhotoInput) (*domain.VehiclePhoto, error)
+	GetPhotos(ctx context.Context, vehicleID uint) ([]*domain.VehiclePhoto, error)
+	SetPrimaryPhoto(ctx context.Context, vehicleID uint, photoID uint) error
+	DeletePhoto(ctx context.Context, photoID uint) error
+
+	// Trash
+	ListDeleted(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.Vehicle], error)
+	Restore(ctx context.Context, id uint) (*domain.Vehicle, error)
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int, error)
 }
\ No newline at end of file
@@ -0,0 +1,17 @@
+package output
+
+import (
+	"context"
+
+	"torque-dms/core/inventory/domain"
+)
+
+type CostEntryRepository interface {
+	Save(ctx context.Context, entry *domain.CostEntry) error
+	Update(ctx context.Context, entry *domain.CostEntry) error
+	FindByID(ctx context.Context, id uint) (*domain.CostEntry, error)
+	// FindByVehicleID - incluye las entradas anuladas, por fecha del coste
+	FindByVehicleID(ctx context.Context, vehicleID uint) ([]*domain.CostEntry, error)
+	// TotalByVehicle - suma de las entradas no anuladas del vehicle
+	TotalByVehicle(ctx context.Context, vehicleID uint) (float64, error)
+}
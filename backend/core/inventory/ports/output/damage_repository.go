@@ -0,0 +1,18 @@
+package output
+
+import (
+	"context"
+
+	"torque-dms/core/inventory/domain"
+)
+
+type VehicleZoneMarkRepository interface {
+	Save(ctx context.Context, mark *domain.VehicleZoneMark) error
+	// Update - no toca la resolución, que solo cambia con Resolve
+	Update(ctx context.Context, mark *domain.VehicleZoneMark) error
+	// Resolve - solo si sigue pendiente; si otro la resolvió antes devuelve mark_already_resolved
+	Resolve(ctx context.Context, mark *domain.VehicleZoneMark) error
+	FindByID(ctx context.Context, id uint) (*domain.VehicleZoneMark, error)
+	// FindByVehicleID - más recientes primero; includeResolved false deja solo los pendientes
+	FindByVehicleID(ctx context.Context, vehicleID uint, includeResolved bool) ([]*domain.VehicleZoneMark, error)
+}
@@ -0,0 +1,13 @@
+package output
+
+import (
+	"context"
+
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+// Geocoder - centroide de un código postal. country es el ISO de dos letras; vacío solo
+// vale si el código postal no se repite entre países
+type Geocoder interface {
+	GeocodePostalCode(ctx context.Context, country string, postalCode string) (sharedDomain.GeoPoint, error)
+}
@@ -0,0 +1,16 @@
+package output
+
+import (
+	"context"
+
+	"torque-dms/core/inventory/domain"
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+type GeofenceEventRepository interface {
+	Save(ctx context.Context, event *domain.GeofenceEvent) error
+	// FindLatestByVehicleIDs - último cruce de cada vehicle; los que no tienen ninguno no aparecen
+	FindLatestByVehicleIDs(ctx context.Context, vehicleIDs []uint) (map[uint]*domain.GeofenceEvent, error)
+	FindByVehicleID(ctx context.Context, vehicleID uint, q sharedDomain.Query) (*sharedDomain.Page[*domain.GeofenceEvent], error)
+	FindByLocationID(ctx context.Context, locationID uint, q sharedDomain.Query) (*sharedDomain.Page[*domain.GeofenceEvent], error)
+}
@@ -0,0 +1,33 @@
+package output
+
+import (
+	"context"
+
+	"torque-dms/core/inventory/domain"
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+type InspectionTemplateRepository interface {
+	// Save - guarda la plantilla con sus secciones e items
+	Save(ctx context.Context, template *domain.InspectionTemplate) error
+	// Update - solo los datos de la plantilla; la estructura se cambia con ReplaceStructure
+	Update(ctx context.Context, template *domain.InspectionTemplate) error
+	ReplaceStructure(ctx context.Context, template *domain.InspectionTemplate) error
+	FindByID(ctx context.Context, id uint) (*domain.InspectionTemplate, error)
+	// FindAll - sin secciones, para los listados
+	FindAll(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.InspectionTemplate], error)
+	IsInUse(ctx context.Context, id uint) (bool, error)
+	Delete(ctx context.Context, id uint) error
+}
+
+type InspectionRepository interface {
+	Save(ctx context.Context, inspection *domain.Inspection) error
+	Update(ctx context.Context, inspection *domain.Inspection) error
+	// SaveResults - inserta o reemplaza el resultado de cada item
+	SaveResults(ctx context.Context, results []*domain.InspectionResult) error
+	FindByID(ctx context.Context, id uint) (*domain.Inspection, error)
+	// FindByVehicleID - más recientes primero, sin resultados
+	FindByVehicleID(ctx context.Context, vehicleID uint) ([]*domain.Inspection, error)
+	// FindLatestCompleted - NotFound si el vehicle no tiene ninguna completada de ese tipo
+	FindLatestCompleted(ctx context.Context, vehicleID uint, kind domain.InspectionKind) (*domain.Inspection, error)
+}
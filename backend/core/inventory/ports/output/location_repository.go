@@ -1,15 +1,32 @@
 package output
 
-import "torque-dms/core/inventory/domain"
+import (
+	"context"
+
+	"torque-dms/core/inventory/domain"
+	sharedDomain "torque-dms/core/shared/domain"
+)
 
 type LocationRepository interface {
-	Save(location *domain.Location) error
-	Update(location *domain.Location) error
-	FindByID(id uint) (*domain.Location, error)
-	FindByName(name string) (*domain.Location, error)
-	FindAll() ([]*domain.Location, error)
-	FindByType(locationType domain.LocationType) ([]*domain.Location, error)
-	FindActive() ([]*domain.Location, error)
-	Delete(id uint) error
-	Exists(id uint) (bool, error)
+	Save(ctx context.Context, location *domain.Location) error
+	Update(ctx context.Context, location *domain.Location) error
+	FindByID(ctx context.Context, id uint) (*domain.Location, error)
+	// FindByIDForUpdate - bloquea la fila hasta el fin de la transacción
+	FindByIDForUpdate(ctx context.Context, id uint) (*domain.Location, error)
+	FindByName(ctx context.Context, name string) (*domain.Location, error)
+	FindAll(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.Location], error)
+	FindByType(ctx context.Context, locationType domain.LocationType) ([]*domain.Location, error)
+	// FindWithGeofence - ubicaciones activas con geocerca
+	FindWithGeofence(ctx context.Context) ([]*domain.Location, error)
+	Delete(ctx context.Context, id uint) error
+	Exists(ctx context.Context, id uint) (bool, error)
+	// HasHistory - rutas, traslados o historial de ubicaciones que apuntan a la location
+	HasHistory(ctx context.Context, id uint) (bool, error)
+}
+
+// LocationGeoRepository - búsquedas por distancia sobre las ubicaciones activas con
+// coordenadas, de la más cercana a la más lejana. types vacío no filtra por tipo
+type LocationGeoRepository interface {
+	FindNearest(ctx context.Context, origin sharedDomain.GeoPoint, types []domain.LocationType, limit int) ([]*domain.LocationDistance, error)
+	FindWithin(ctx context.Context, origin sharedDomain.GeoPoint, radiusKM float64) ([]*domain.LocationDistance, error)
 }
\ No newline at end of file
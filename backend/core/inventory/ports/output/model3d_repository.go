@@ -0,0 +1,33 @@
+package output
+
+import (
+	"context"
+
+	"torque-dms/core/inventory/domain"
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+type VehicleModel3DRepository interface {
+	Save(ctx context.Context, model *domain.VehicleModel3D) error
+	Update(ctx context.Context, model *domain.VehicleModel3D) error
+	FindByID(ctx context.Context, id uint) (*domain.VehicleModel3D, error)
+	FindAll(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.VehicleModel3D], error)
+	// FindDefault - modelo predeterminado activo de la carrocería; NotFound si no hay
+	FindDefault(ctx context.Context, bodyType domain.BodyType) (*domain.VehicleModel3D, error)
+	// ClearDefault - quita la marca al predeterminado actual de la carrocería, si lo hay
+	ClearDefault(ctx context.Context, bodyType domain.BodyType) error
+	// HasMarks - true si alguna zona del modelo tiene marcas de daño
+	HasMarks(ctx context.Context, id uint) (bool, error)
+	Delete(ctx context.Context, id uint) error
+}
+
+type VehicleModelZoneRepository interface {
+	Save(ctx context.Context, zone *domain.VehicleModelZone) error
+	Update(ctx context.Context, zone *domain.VehicleModelZone) error
+	FindByID(ctx context.Context, id uint) (*domain.VehicleModelZone, error)
+	FindByModelID(ctx context.Context, model3DID uint) ([]*domain.VehicleModelZone, error)
+	FindByIDs(ctx context.Context, ids []uint) ([]*domain.VehicleModelZone, error)
+	ExistsByCode(ctx context.Context, model3DID uint, code string) (bool, error)
+	HasMarks(ctx context.Context, id uint) (bool, error)
+	Delete(ctx context.Context, id uint) error
+}
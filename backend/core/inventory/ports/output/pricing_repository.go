@@ -0,0 +1,25 @@
+package output
+
+import (
+	"context"
+	"time"
+
+	"torque-dms/core/inventory/domain"
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+type RepricingRuleRepository interface {
+	Save(ctx context.Context, rule *domain.RepricingRule) error
+	Update(ctx context.Context, rule *domain.RepricingRule) error
+	Delete(ctx context.Context, id uint) error
+	FindByID(ctx context.Context, id uint) (*domain.RepricingRule, error)
+	FindAll(ctx context.Context) ([]*domain.RepricingRule, error)
+	FindActive(ctx context.Context) ([]*domain.RepricingRule, error)
+}
+
+type PriceChangeRepository interface {
+	Save(ctx context.Context, change *domain.PriceChange) error
+	FindByVehicleID(ctx context.Context, vehicleID uint, q sharedDomain.Query) (*sharedDomain.Page[*domain.PriceChange], error)
+	// LastRuleChanges - fecha de la última bajada automática de cada vehicle que tenga alguna
+	LastRuleChanges(ctx context.Context, vehicleIDs []uint) (map[uint]time.Time, error)
+}
@@ -0,0 +1,28 @@
+package output
+
+import (
+	"context"
+	"time"
+
+	"torque-dms/core/inventory/domain"
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+type ReconOrderRepository interface {
+	// Save - solo la orden; las tareas se guardan con SaveTask
+	Save(ctx context.Context, order *domain.ReconOrder) error
+	Update(ctx context.Context, order *domain.ReconOrder) error
+	SaveTask(ctx context.Context, task *domain.ReconTask) error
+	UpdateTask(ctx context.Context, task *domain.ReconTask) error
+	FindByID(ctx context.Context, id uint) (*domain.ReconOrder, error)
+	// FindOpenByVehicleID - NotFound si el vehicle no tiene orden abierta
+	FindOpenByVehicleID(ctx context.Context, vehicleID uint) (*domain.ReconOrder, error)
+	// FindByVehicleID - más recientes primero, con sus tareas
+	FindByVehicleID(ctx context.Context, vehicleID uint) ([]*domain.ReconOrder, error)
+	FindAll(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.ReconOrder], error)
+	// FindClosedBetween - órdenes cerradas en [from, to), con sus tareas
+	FindClosedBetween(ctx context.Context, from time.Time, to time.Time) ([]*domain.ReconOrder, error)
+	FindOpen(ctx context.Context) ([]*domain.ReconOrder, error)
+	// TotalCostByVehicle - coste real de las tareas completadas en todas las órdenes del vehicle
+	TotalCostByVehicle(ctx context.Context, vehicleID uint) (float64, error)
+}
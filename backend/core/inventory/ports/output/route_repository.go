@@ -0,0 +1,22 @@
+package output
+
+import (
+	"context"
+
+	"torque-dms/core/inventory/domain"
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+type RouteRepository interface {
+	Save(ctx context.Context, route *domain.Route) error
+	Update(ctx context.Context, route *domain.Route) error
+	FindByID(ctx context.Context, id uint) (*domain.Route, error)
+	// FindByIDForUpdate - bloquea la fila hasta el fin de la transacción
+	FindByIDForUpdate(ctx context.Context, id uint) (*domain.Route, error)
+	FindByIDs(ctx context.Context, ids []uint) (map[uint]*domain.Route, error)
+	FindAll(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.Route], error)
+	FindActiveBetween(ctx context.Context, fromLocationID uint, toLocationID uint) ([]*domain.Route, error)
+	// ExistingIDs - los ids de la lista que existen
+	ExistingIDs(ctx context.Context, ids []uint) (map[uint]bool, error)
+	Delete(ctx context.Context, id uint) error
+}
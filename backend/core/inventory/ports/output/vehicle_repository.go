@@ -1,28 +1,74 @@
 package output
 
-import "torque-dms/core/inventory/domain"
+import (
+	"context"
+	"time"
+
+	"torque-dms/core/inventory/domain"
+	sharedDomain "torque-dms/core/shared/domain"
+)
 
 type VehicleRepository interface {
-	Save(vehicle *domain.Vehicle) error
-	Update(vehicle *domain.Vehicle) error
-	FindByID(id uint) (*domain.Vehicle, error)
-	FindByVIN(vin string) (*domain.Vehicle, error)
-	FindByStockNumber(stockNumber string) (*domain.Vehicle, error)
-	FindAll(limit int, offset int) ([]*domain.Vehicle, error)
-	FindByStatus(status domain.VehicleStatus, limit int, offset int) ([]*domain.Vehicle, error)
-	FindByLocationID(locationID uint) ([]*domain.Vehicle, error)
-	FindAvailable(limit int, offset int) ([]*domain.Vehicle, error)
-	Delete(id uint) error
-	Exists(id uint) (bool, error)
-	ExistsByVIN(vin string) (bool, error)
+	Save(ctx context.Context, vehicle *domain.Vehicle) error
+	Update(ctx context.Context, vehicle *domain.Vehicle) error
+	FindByID(ctx context.Context, id uint) (*domain.Vehicle, error)
+	FindByVIN(ctx context.Context, vin string) (*domain.Vehicle, error)
+	FindByStockNumber(ctx context.Context, stockNumber string) (*domain.Vehicle, error)
+	FindByTrackingDevice(ctx context.Context, deviceID string) (*domain.Vehicle, error)
+	FindIDsByVIN(ctx context.Context, vins []string) (map[string]uint, error)
+	FindIDsByTrackingDevice(ctx context.Context, deviceIDs []string) (map[string]uint, error)
+	FindAll(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.Vehicle], error)
+	FindByLocationID(ctx context.Context, locationID uint) ([]*domain.Vehicle, error)
+	FindByStatuses(ctx context.Context, statuses []domain.VehicleStatus) ([]*domain.Vehicle, error)
+	// CountStockByLocation - nil cuenta todas las ubicaciones
+	CountStockByLocation(ctx context.Context, locationIDs []uint) ([]domain.StockCount, error)
+	FindDeleted(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.Vehicle], error)
+	FindDeletedBefore(ctx context.Context, before time.Time) ([]*domain.Vehicle, error)
+	Search(ctx context.Context, criteria domain.VehicleSearch, q sharedDomain.Query) (*sharedDomain.Page[*domain.Vehicle], error)
+	Facets(ctx context.Context, criteria domain.VehicleSearch) (*domain.VehicleFacets, error)
+	Purge(ctx context.Context, id uint) error
+	Exists(ctx context.Context, id uint) (bool, error)
+	ExistsByVIN(ctx context.Context, vin string) (bool, error)
+	ExistsByStockNumber(ctx context.Context, stockNumber string) (bool, error)
 }
 
 type VehiclePhotoRepository interface {
-	Save(photo *domain.VehiclePhoto) error
-	Update(photo *domain.VehiclePhoto) error
-	FindByID(id uint) (*domain.VehiclePhoto, error)
-	FindByVehicleID(vehicleID uint) ([]*domain.VehiclePhoto, error)
-	FindPrimaryByVehicleID(vehicleID uint) (*domain.VehiclePhoto, error)
-	Delete(id uint) error
-	DeleteByVehicleID(vehicleID uint) error
+	Save(ctx context.Context, photo *domain.VehiclePhoto) error
+	Update(ctx context.Context, photo *domain.VehiclePhoto) error
+	FindByID(ctx context.Context, id uint) (*domain.VehiclePhoto, error)
+	FindByVehicleID(ctx context.Context, vehicleID uint) ([]*domain.VehiclePhoto, error)
+	FindPrimaryByVehicleID(ctx context.Context, vehicleID uint) (*domain.VehiclePhoto, error)
+	Delete(ctx context.Context, id uint) error
+}
+
+type VehicleLocationHistoryRepository interface {
+	Save(ctx context.Context, history *domain.VehicleLocationHistory) error
+	FindByVehicleID(ctx context.Context, vehicleID uint, q sharedDomain.Query) (*sharedDomain.Page[*domain.VehicleLocationHistory], error)
+}
+
+type VehicleStatusHistoryRepository interface {
+	Save(ctx context.Context, history *domain.VehicleStatusHistory) error
+	FindByVehicleID(ctx context.Context, vehicleID uint, q sharedDomain.Query) (*sharedDomain.Page[*domain.VehicleStatusHistory], error)
+}
+
+type VehicleTransferRepository interface {
+	Save(ctx context.Context, transfer *domain.VehicleTransfer) error
+	Update(ctx context.Context, transfer *domain.VehicleTransfer) error
+	FindPendingByVehicleID(ctx context.Context, vehicleID uint) (*domain.VehicleTransfer, error)
+	FindPendingByVehicleIDs(ctx context.Context, vehicleIDs []uint) ([]*domain.VehicleTransfer, error)
+	FindPendingByDestination(ctx context.Context, locationID uint) ([]*domain.VehicleTransfer, error)
+	ExistsPendingByRoute(ctx context.Context, routeID uint) (bool, error)
+	CountPendingByDestination(ctx context.Context, locationIDs []uint) (map[uint]int64, error)
+}
+
+type VehicleTrackingRepository interface {
+	// SaveBatch - ignora los puntos que ya existen y devuelve cuántos se insertaron
+	SaveBatch(ctx context.Context, points []*domain.TrackingPoint) (int, error)
+	FindLatest(ctx context.Context, vehicleID uint) (*domain.TrackingPoint, error)
+	// FindLatestByVehicleIDs - última posición de cada vehicle, indexada por vehicle
+	FindLatestByVehicleIDs(ctx context.Context, vehicleIDs []uint) (map[uint]*domain.TrackingPoint, error)
+	FindTrack(ctx context.Context, vehicleID uint, from time.Time, to time.Time, q sharedDomain.Query) (*sharedDomain.Page[*domain.TrackingPoint], error)
+	// Downsample - deja un punto por vehicle e intervalo entre los anteriores a before
+	Downsample(ctx context.Context, before time.Time, interval time.Duration) (int, error)
+	DeleteBefore(ctx context.Context, before time.Time) (int, error)
 }
\ No newline at end of file
@@ -0,0 +1,13 @@
+package output
+
+import (
+	"context"
+
+	"torque-dms/core/inventory/domain"
+)
+
+// VINDecoder - decodifica un VIN sin salir del proceso; un fabricante desconocido no es
+// un error, simplemente deja vacíos los campos que no se pueden deducir
+type VINDecoder interface {
+	Decode(ctx context.Context, vin string) (*domain.VINDecode, error)
+}
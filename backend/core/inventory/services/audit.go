@@ -0,0 +1,19 @@
+package services
+
+// Tipos de agregado con los que inventory escribe en el log de auditoría
+const (
+	vehicleAggregate            = "vehicle"
+	vehiclePhotoAggregate       = "vehicle_photo"
+	vehicleTransferAggregate    = "vehicle_transfer"
+	locationAggregate           = "location"
+	routeAggregate              = "route"
+	model3DAggregate            = "vehicle_model_3d"
+	modelZoneAggregate          = "vehicle_model_zone"
+	damageMarkAggregate         = "vehicle_zone_mark"
+	inspectionTemplateAggregate = "inspection_template"
+	inspectionAggregate         = "inspection"
+	reconOrderAggregate         = "recon_order"
+	reconTaskAggregate          = "recon_task"
+	costEntryAggregate          = "vehicle_cost_entry"
+	repricingRuleAggregate      = "repricing_rule"
+)
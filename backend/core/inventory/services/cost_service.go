@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+
+	auditDomain "torque-dms/core/audit/domain"
+	auditInput "torque-dms/core/audit/ports/input"
+	"torque-dms/core/inventory/domain"
+	"torque-dms/core/inventory/ports/input"
+	"torque-dms/core/inventory/ports/output"
+	sharedDomain "torque-dms/core/shared/domain"
+	sharedOutput "torque-dms/core/shared/ports/output"
+)
+
+type costService struct {
+	entryRepo    output.CostEntryRepository
+	vehicleRepo  output.VehicleRepository
+	reconRepo    output.ReconOrderRepository
+	auditService auditInput.AuditService
+	uow          sharedOutput.UnitOfWork
+}
+
+func NewCostService(
+	entryRepo output.CostEntryRepository,
+	vehicleRepo output.VehicleRepository,
+	reconRepo output.ReconOrderRepository,
+	auditService auditInput.AuditService,
+	uow sharedOutput.UnitOfWork,
+) input.CostService {
+	return &costService{
+		entryRepo:    entryRepo,
+		vehicleRepo:  vehicleRepo,
+		reconRepo:    reconRepo,
+		auditService: auditService,
+		uow:          uow,
+	}
+}
+
+func (s *costService) GetLedger(ctx context.Context, vehicleID uint) (*domain.CostLedger, error) {
+	vehicle, err := s.vehicleRepo.FindByID(ctx, vehicleID)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := s.entryRepo.FindByVehicleID(ctx, vehicleID)
+	if err != nil {
+		return nil, err
+	}
+	orders, err := s.reconRepo.FindByVehicleID(ctx, vehicleID)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewCostLedger(vehicle, entries, orders), nil
+}
+
+func (s *costService) ListEntries(ctx context.Context, vehicleID uint) ([]*domain.CostEntry, error) {
+	if _, err := s.vehicleRepo.FindByID(ctx, vehicleID); err != nil {
+		return nil, err
+	}
+	return s.entryRepo.FindByVehicleID(ctx, vehicleID)
+}
+
+func (s *costService) AddEntry(ctx context.Context, vehicleID uint, inp input.AddCostEntryInput) (*domain.CostEntry, error) {
+	var entry *domain.CostEntry
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		vehicle, err := s.vehicleRepo.FindByID(ctx, vehicleID)
+		if err != nil {
+			return err
+		}
+
+		entry, err = domain.NewCostEntry(vehicle, domain.CostEntryType(inp.Type), inp.Amount, inp.Vendor, inp.InvoiceRef, inp.IncurredOn, inp.Notes, sharedDomain.ActorFromContext(ctx).EntityID)
+		if err != nil {
+			return err
+		}
+		if err := s.entryRepo.Save(ctx, entry); err != nil {
+			return err
+		}
+		if err := s.auditService.Record(ctx, auditDomain.ActionCreate, costEntryAggregate, entry.ID, nil, entry); err != nil {
+			return err
+		}
+
+		return s.refreshAdditionalCost(ctx, vehicle)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (s *costService) VoidEntry(ctx context.Context, vehicleID uint, entryID uint) (*domain.CostEntry, error) {
+	var entry *domain.CostEntry
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		vehicle, err := s.vehicleRepo.FindByID(ctx, vehicleID)
+		if err != nil {
+			return err
+		}
+
+		entry, err = s.entryRepo.FindByID(ctx, entryID)
+		if err != nil {
+			return err
+		}
+		if entry.VehicleID != vehicle.ID {
+			return sharedDomain.NotFound("cost_entry")
+		}
+		before := *entry
+
+		if err := entry.Void(sharedDomain.ActorFromContext(ctx).EntityID); err != nil {
+			return err
+		}
+		if err := s.entryRepo.Update(ctx, entry); err != nil {
+			return err
+		}
+		if err := s.auditService.Record(ctx, auditDomain.ActionUpdate, costEntryAggregate, entry.ID, before, entry); err != nil {
+			return err
+		}
+
+		return s.refreshAdditionalCost(ctx, vehicle)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// refreshAdditionalCost - el total se recalcula desde las entradas en la misma transacción,
+// así vehicles.additional_cost no se desvía del ledger
+func (s *costService) refreshAdditionalCost(ctx context.Context, vehicle *domain.Vehicle) error {
+	total, err := s.entryRepo.TotalByVehicle(ctx, vehicle.ID)
+	if err != nil {
+		return err
+	}
+	before := *vehicle
+
+	vehicle.SetAdditionalCost(total)
+	if err := s.vehicleRepo.Update(ctx, vehicle); err != nil {
+		return err
+	}
+
+	return s.auditService.Record(ctx, auditDomain.ActionUpdate, vehicleAggregate, vehicle.ID, before, vehicle)
+}
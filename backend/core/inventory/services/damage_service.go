@@ -0,0 +1,194 @@
+package services
+
+import (
+	"context"
+
+	auditDomain "torque-dms/core/audit/domain"
+	auditInput "torque-dms/core/audit/ports/input"
+	"torque-dms/core/inventory/domain"
+	"torque-dms/core/inventory/ports/input"
+	"torque-dms/core/inventory/ports/output"
+	sharedDomain "torque-dms/core/shared/domain"
+	sharedOutput "torque-dms/core/shared/ports/output"
+)
+
+type damageService struct {
+	markRepo     output.VehicleZoneMarkRepository
+	vehicleRepo  output.VehicleRepository
+	zoneRepo     output.VehicleModelZoneRepository
+	photoRepo    output.VehiclePhotoRepository
+	auditService auditInput.AuditService
+	uow          sharedOutput.UnitOfWork
+}
+
+func NewDamageService(
+	markRepo output.VehicleZoneMarkRepository,
+	vehicleRepo output.VehicleRepository,
+	zoneRepo output.VehicleModelZoneRepository,
+	photoRepo output.VehiclePhotoRepository,
+	auditService auditInput.AuditService,
+	uow sharedOutput.UnitOfWork,
+) input.DamageService {
+	return &damageService{
+		markRepo:     markRepo,
+		vehicleRepo:  vehicleRepo,
+		zoneRepo:     zoneRepo,
+		photoRepo:    photoRepo,
+		auditService: auditService,
+		uow:          uow,
+	}
+}
+
+func (s *damageService) RecordMark(ctx context.Context, inp input.RecordDamageInput) (*domain.VehicleZoneMark, error) {
+	var mark *domain.VehicleZoneMark
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		vehicle, err := s.vehicleRepo.FindByID(ctx, inp.VehicleID)
+		if err != nil {
+			return err
+		}
+
+		zone, err := s.zoneRepo.FindByID(ctx, inp.ZoneID)
+		if err != nil {
+			return err
+		}
+
+		mark, err = domain.NewVehicleZoneMark(
+			vehicle,
+			zone,
+			domain.DamageType(inp.Type),
+			domain.DamageSeverity(inp.Severity),
+			inp.Description,
+			inp.ReportedBy,
+		)
+		if err != nil {
+			return err
+		}
+
+		if inp.PhotoID != nil {
+			photo, err := s.photoRepo.FindByID(ctx, *inp.PhotoID)
+			if err != nil {
+				return err
+			}
+			if err := mark.AttachPhoto(photo); err != nil {
+				return err
+			}
+		}
+
+		if err := s.markRepo.Save(ctx, mark); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionCreate, damageMarkAggregate, mark.ID, nil, mark)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mark, nil
+}
+
+func (s *damageService) AttachPhoto(ctx context.Context, vehicleID uint, markID uint, photoID uint) (*domain.VehicleZoneMark, error) {
+	var mark *domain.VehicleZoneMark
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		mark, err = s.findMark(ctx, vehicleID, markID)
+		if err != nil {
+			return err
+		}
+		before := *mark
+
+		photo, err := s.photoRepo.FindByID(ctx, photoID)
+		if err != nil {
+			return err
+		}
+		if err := mark.AttachPhoto(photo); err != nil {
+			return err
+		}
+
+		if err := s.markRepo.Update(ctx, mark); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, damageMarkAggregate, mark.ID, before, mark)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mark, nil
+}
+
+func (s *damageService) ResolveMark(ctx context.Context, vehicleID uint, markID uint, resolvedBy uint) (*domain.VehicleZoneMark, error) {
+	var mark *domain.VehicleZoneMark
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		mark, err = s.findMark(ctx, vehicleID, markID)
+		if err != nil {
+			return err
+		}
+		before := *mark
+
+		if err := mark.Resolve(resolvedBy); err != nil {
+			return err
+		}
+
+		if err := s.markRepo.Resolve(ctx, mark); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, damageMarkAggregate, mark.ID, before, mark)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mark, nil
+}
+
+func (s *damageService) ListMarks(ctx context.Context, vehicleID uint, includeResolved bool) ([]*domain.VehicleZoneMark, error) {
+	if _, err := s.vehicleRepo.FindByID(ctx, vehicleID); err != nil {
+		return nil, err
+	}
+	return s.markRepo.FindByVehicleID(ctx, vehicleID, includeResolved)
+}
+
+// GetConditionReport - las zonas se buscan por las marcas y no por el modelo actual, así
+// los daños marcados antes de cambiar de modelo siguen apareciendo
+func (s *damageService) GetConditionReport(ctx context.Context, vehicleID uint) (*domain.ConditionReport, error) {
+	vehicle, err := s.vehicleRepo.FindByID(ctx, vehicleID)
+	if err != nil {
+		return nil, err
+	}
+
+	marks, err := s.markRepo.FindByVehicleID(ctx, vehicleID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[uint]bool)
+	var zoneIDs []uint
+	for _, m := range marks {
+		if !m.Resolved && !seen[m.ZoneID] {
+			seen[m.ZoneID] = true
+			zoneIDs = append(zoneIDs, m.ZoneID)
+		}
+	}
+	zones, err := s.zoneRepo.FindByIDs(ctx, zoneIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewConditionReport(vehicle, zones, marks), nil
+}
+
+// findMark - una marca de otro vehicle se trata como inexistente
+func (s *damageService) findMark(ctx context.Context, vehicleID uint, markID uint) (*domain.VehicleZoneMark, error) {
+	mark, err := s.markRepo.FindByID(ctx, markID)
+	if err != nil {
+		return nil, err
+	}
+	if mark.VehicleID != vehicleID {
+		return nil, sharedDomain.NotFound("damage_mark")
+	}
+	return mark, nil
+}
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+
+	"torque-dms/core/inventory/domain"
+	"torque-dms/core/inventory/ports/input"
+	"torque-dms/core/inventory/ports/output"
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+type geoService struct {
+	locationRepo    output.LocationRepository
+	locationGeoRepo output.LocationGeoRepository
+	geocoder        output.Geocoder
+}
+
+func NewGeoService(
+	locationRepo output.LocationRepository,
+	locationGeoRepo output.LocationGeoRepository,
+	geocoder output.Geocoder,
+) input.GeoService {
+	return &geoService{
+		locationRepo:    locationRepo,
+		locationGeoRepo: locationGeoRepo,
+		geocoder:        geocoder,
+	}
+}
+
+func (s *geoService) ResolveOrigin(ctx context.Context, origin domain.GeoOrigin) (sharedDomain.GeoPoint, error) {
+	switch {
+	case origin.Point != nil:
+		return sharedDomain.NewGeoPoint(origin.Point.Latitude, origin.Point.Longitude)
+	case origin.PostalCode != "":
+		return s.geocoder.GeocodePostalCode(ctx, origin.Country, origin.PostalCode)
+	case origin.LocationID != 0:
+		location, err := s.locationRepo.FindByID(ctx, origin.LocationID)
+		if err != nil {
+			return sharedDomain.GeoPoint{}, err
+		}
+		point, ok := location.Point()
+		if !ok {
+			return sharedDomain.GeoPoint{}, sharedDomain.Invariant("location_without_coordinates", "location has no coordinates")
+		}
+		return point, nil
+	default:
+		return sharedDomain.GeoPoint{}, sharedDomain.Invalid("origin", "coordinates, postal code or location are required")
+	}
+}
+
+func (s *geoService) NearestLocations(ctx context.Context, origin domain.GeoOrigin, types []string, limit int) ([]*domain.LocationDistance, error) {
+	point, err := s.ResolveOrigin(ctx, origin)
+	if err != nil {
+		return nil, err
+	}
+
+	locationTypes := make([]domain.LocationType, len(types))
+	for i, t := range types {
+		locationTypes[i] = domain.LocationType(t)
+		if !locationTypes[i].IsValid() {
+			return nil, sharedDomain.Invalid("type", "invalid location type")
+		}
+	}
+
+	if limit <= 0 {
+		limit = 5
+	}
+	return s.locationGeoRepo.FindNearest(ctx, point, locationTypes, min(limit, 50))
+}
+
+func (s *geoService) LocationsWithin(ctx context.Context, origin sharedDomain.GeoPoint, radiusKM float64) ([]*domain.LocationDistance, error) {
+	if err := domain.ValidateRadius(radiusKM); err != nil {
+		return nil, err
+	}
+	return s.locationGeoRepo.FindWithin(ctx, origin, radiusKM)
+}
+
+func (s *geoService) DistanceToLocation(ctx context.Context, locationID uint, origin domain.GeoOrigin) (*domain.LocationDistance, error) {
+	location, err := s.locationRepo.FindByID(ctx, locationID)
+	if err != nil {
+		return nil, err
+	}
+	target, ok := location.Point()
+	if !ok {
+		return nil, sharedDomain.Invariant("location_without_coordinates", "location has no coordinates")
+	}
+
+	point, err := s.ResolveOrigin(ctx, origin)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.LocationDistance{Location: location, DistanceKM: point.DistanceKM(target)}, nil
+}
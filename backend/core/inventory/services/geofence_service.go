@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+
+	"torque-dms/core/inventory/domain"
+	"torque-dms/core/inventory/ports/input"
+	"torque-dms/core/inventory/ports/output"
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+// Motivos con los que se registran en el historial los movimientos automáticos
+const (
+	geofenceArrivalReason = "geofence_arrival"
+	geofenceEnterReason   = "geofence_enter"
+	geofenceExitReason    = "geofence_exit"
+)
+
+type geofenceService struct {
+	vehicleService input.VehicleService
+	vehicleRepo    output.VehicleRepository
+	locationRepo   output.LocationRepository
+	historyRepo    output.VehicleLocationHistoryRepository
+	transferRepo   output.VehicleTransferRepository
+	eventRepo      output.GeofenceEventRepository
+}
+
+// NewGeofenceService - las llegadas pasan por los mismos casos de uso que el botón de la app,
+// con sus comprobaciones de capacidad y su auditoría
+func NewGeofenceService(
+	vehicleService input.VehicleService,
+	vehicleRepo output.VehicleRepository,
+	locationRepo output.LocationRepository,
+	historyRepo output.VehicleLocationHistoryRepository,
+	transferRepo output.VehicleTransferRepository,
+	eventRepo output.GeofenceEventRepository,
+) input.GeofenceService {
+	return &geofenceService{
+		vehicleService: vehicleService,
+		vehicleRepo:    vehicleRepo,
+		locationRepo:   locationRepo,
+		historyRepo:    historyRepo,
+		transferRepo:   transferRepo,
+		eventRepo:      eventRepo,
+	}
+}
+
+func (s *geofenceService) Process(ctx context.Context, previous map[uint]*domain.TrackingPoint, points []*domain.TrackingPoint) ([]*domain.GeofenceEvent, error) {
+	if len(points) == 0 {
+		return nil, nil
+	}
+
+	fences, err := s.locationRepo.FindWithGeofence(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(fences) == 0 {
+		return nil, nil
+	}
+
+	// Los vehicles se procesan en el orden en que aparecen en el lote
+	var vehicleIDs []uint
+	byVehicle := make(map[uint][]*domain.TrackingPoint)
+	for _, p := range points {
+		if _, ok := byVehicle[p.VehicleID]; !ok {
+			vehicleIDs = append(vehicleIDs, p.VehicleID)
+		}
+		byVehicle[p.VehicleID] = append(byVehicle[p.VehicleID], p)
+	}
+
+	latest, err := s.eventRepo.FindLatestByVehicleIDs(ctx, vehicleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []*domain.GeofenceEvent
+	for _, vehicleID := range vehicleIDs {
+		position := domain.NewGeofencePosition(fences, previous[vehicleID], latest[vehicleID])
+		for _, event := range domain.DetectCrossings(fences, position, byVehicle[vehicleID]) {
+			if err := s.apply(ctx, event); err != nil {
+				return nil, err
+			}
+			if err := s.eventRepo.Save(ctx, event); err != nil {
+				return nil, err
+			}
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+// apply - una regla de negocio que impide el movimiento (ubicación llena, vehicle borrado...)
+// no detiene la ingesta: el cruce queda como ignorado con el motivo. Esas reglas se comprueban
+// antes de escribir nada; un conflicto de versión sí deshace el lote para que se reintente
+func (s *geofenceService) apply(ctx context.Context, event *domain.GeofenceEvent) error {
+	var outcome domain.GeofenceOutcome
+	var err error
+	if event.Type == domain.GeofenceEventEnter {
+		outcome, err = s.enter(ctx, event)
+	} else {
+		outcome, err = s.exit(ctx, event)
+	}
+
+	if domainErr, ok := sharedDomain.AsError(err); ok && domainErr.Kind != sharedDomain.KindConflict {
+		event.Resolve(domain.GeofenceOutcomeIgnored, domainErr.Message)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	event.Resolve(outcome, "")
+	return nil
+}
+
+// enter - cierra el traslado que llega a esta ubicación o mueve el vehicle si nadie lo hizo
+func (s *geofenceService) enter(ctx context.Context, event *domain.GeofenceEvent) (domain.GeofenceOutcome, error) {
+	transfer, err := s.transferRepo.FindPendingByVehicleID(ctx, event.VehicleID)
+	if err != nil && !sharedDomain.IsNotFound(err) {
+		return "", err
+	}
+	if transfer != nil {
+		if transfer.ToLocationID != event.LocationID {
+			return "", sharedDomain.Invariant("transfer_pending", "vehicle has a pending transfer to another location")
+		}
+		if _, err := s.vehicleService.ConfirmArrival(ctx, event.VehicleID); err != nil {
+			return "", err
+		}
+		return domain.GeofenceOutcomeTransferCompleted, nil
+	}
+
+	vehicle, err := s.vehicleRepo.FindByID(ctx, event.VehicleID)
+	if err != nil {
+		return "", err
+	}
+	if vehicle.LocationID == event.LocationID {
+		return domain.GeofenceOutcomeRecorded, s.recordMove(ctx, event.VehicleID, 0, event.LocationID, geofenceEnterReason)
+	}
+
+	if err := s.vehicleService.ChangeLocation(ctx, event.VehicleID, event.LocationID, geofenceArrivalReason); err != nil {
+		return "", err
+	}
+	return domain.GeofenceOutcomeMoved, nil
+}
+
+// exit - solo se registra la salida de la ubicación asignada; la de un traslado ya quedó
+// registrada al iniciarlo
+func (s *geofenceService) exit(ctx context.Context, event *domain.GeofenceEvent) (domain.GeofenceOutcome, error) {
+	vehicle, err := s.vehicleRepo.FindByID(ctx, event.VehicleID)
+	if err != nil {
+		return "", err
+	}
+	if vehicle.LocationID != event.LocationID {
+		return "", sharedDomain.Invariant("location_mismatch", "vehicle is not assigned to this location")
+	}
+	return domain.GeofenceOutcomeRecorded, s.recordMove(ctx, event.VehicleID, event.LocationID, 0, geofenceExitReason)
+}
+
+func (s *geofenceService) recordMove(ctx context.Context, vehicleID uint, fromLocationID uint, toLocationID uint, reason string) error {
+	history := domain.NewVehicleLocationHistory(vehicleID, fromLocationID, toLocationID, sharedDomain.ActorFromContext(ctx).EntityID, reason)
+	return s.historyRepo.Save(ctx, history)
+}
+
+func (s *geofenceService) ListByVehicle(ctx context.Context, vehicleID uint, q sharedDomain.Query) (*sharedDomain.Page[*domain.GeofenceEvent], error) {
+	exists, err := s.vehicleRepo.Exists(ctx, vehicleID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, sharedDomain.NotFound("vehicle")
+	}
+	return s.eventRepo.FindByVehicleID(ctx, vehicleID, q.Normalize(50, 200))
+}
+
+func (s *geofenceService) ListByLocation(ctx context.Context, locationID uint, q sharedDomain.Query) (*sharedDomain.Page[*domain.GeofenceEvent], error) {
+	exists, err := s.locationRepo.Exists(ctx, locationID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, sharedDomain.NotFound("location")
+	}
+	return s.eventRepo.FindByLocationID(ctx, locationID, q.Normalize(50, 200))
+}
@@ -0,0 +1,333 @@
+package services
+
+import (
+	"context"
+
+	auditDomain "torque-dms/core/audit/domain"
+	auditInput "torque-dms/core/audit/ports/input"
+	"torque-dms/core/inventory/domain"
+	"torque-dms/core/inventory/ports/input"
+	"torque-dms/core/inventory/ports/output"
+	sharedDomain "torque-dms/core/shared/domain"
+	sharedOutput "torque-dms/core/shared/ports/output"
+)
+
+type inspectionService struct {
+	templateRepo   output.InspectionTemplateRepository
+	inspectionRepo output.InspectionRepository
+	vehicleRepo    output.VehicleRepository
+	photoRepo      output.VehiclePhotoRepository
+	auditService   auditInput.AuditService
+	uow            sharedOutput.UnitOfWork
+}
+
+func NewInspectionService(
+	templateRepo output.InspectionTemplateRepository,
+	inspectionRepo output.InspectionRepository,
+	vehicleRepo output.VehicleRepository,
+	photoRepo output.VehiclePhotoRepository,
+	auditService auditInput.AuditService,
+	uow sharedOutput.UnitOfWork,
+) input.InspectionService {
+	return &inspectionService{
+		templateRepo:   templateRepo,
+		inspectionRepo: inspectionRepo,
+		vehicleRepo:    vehicleRepo,
+		photoRepo:      photoRepo,
+		auditService:   auditService,
+		uow:            uow,
+	}
+}
+
+// Templates
+
+func (s *inspectionService) CreateTemplate(ctx context.Context, inp input.CreateInspectionTemplateInput) (*domain.InspectionTemplate, error) {
+	template, err := domain.NewInspectionTemplate(inp.Name, domain.InspectionKind(inp.Kind), inp.Description, toInspectionSections(inp.Sections))
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.templateRepo.Save(ctx, template); err != nil {
+			return err
+		}
+		return s.auditService.Record(ctx, auditDomain.ActionCreate, inspectionTemplateAggregate, template.ID, nil, template)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+func (s *inspectionService) GetTemplate(ctx context.Context, id uint) (*domain.InspectionTemplate, error) {
+	return s.templateRepo.FindByID(ctx, id)
+}
+
+func (s *inspectionService) ListTemplates(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.InspectionTemplate], error) {
+	return s.templateRepo.FindAll(ctx, q.Normalize(50, 100))
+}
+
+func (s *inspectionService) UpdateTemplate(ctx context.Context, id uint, inp input.UpdateInspectionTemplateInput) (*domain.InspectionTemplate, error) {
+	var template *domain.InspectionTemplate
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		template, err = s.templateRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := sharedDomain.CheckVersion(inp.Version, template.Version); err != nil {
+			return err
+		}
+		before := *template
+
+		if inp.Name != nil {
+			if *inp.Name == "" {
+				return sharedDomain.Invalid("name", "name is required")
+			}
+			template.Name = *inp.Name
+		}
+		if inp.Description != nil {
+			template.Description = *inp.Description
+		}
+
+		if inp.Sections != nil {
+			// Los resultados guardados apuntan a los items: una plantilla usada se desactiva
+			// y se crea otra
+			inUse, err := s.templateRepo.IsInUse(ctx, id)
+			if err != nil {
+				return err
+			}
+			if inUse {
+				return sharedDomain.Invariant("template_in_use", "template has inspections, create a new one instead")
+			}
+			if err := template.SetStructure(toInspectionSections(*inp.Sections)); err != nil {
+				return err
+			}
+			if err := s.templateRepo.ReplaceStructure(ctx, template); err != nil {
+				return err
+			}
+		}
+
+		if err := s.templateRepo.Update(ctx, template); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, inspectionTemplateAggregate, id, before, template)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+func (s *inspectionService) DeleteTemplate(ctx context.Context, id uint) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		template, err := s.templateRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		inUse, err := s.templateRepo.IsInUse(ctx, id)
+		if err != nil {
+			return err
+		}
+		if inUse {
+			return sharedDomain.Invariant("template_in_use", "template has inspections, deactivate it instead")
+		}
+
+		if err := s.templateRepo.Delete(ctx, id); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionDelete, inspectionTemplateAggregate, id, template, nil)
+	})
+}
+
+func (s *inspectionService) DeactivateTemplate(ctx context.Context, id uint) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		template, err := s.templateRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		before := *template
+
+		template.Deactivate()
+		if err := s.templateRepo.Update(ctx, template); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, inspectionTemplateAggregate, id, before, template)
+	})
+}
+
+func (s *inspectionService) ActivateTemplate(ctx context.Context, id uint) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		template, err := s.templateRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		before := *template
+
+		template.Activate()
+		if err := s.templateRepo.Update(ctx, template); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, inspectionTemplateAggregate, id, before, template)
+	})
+}
+
+// Inspections
+
+func (s *inspectionService) Start(ctx context.Context, inp input.StartInspectionInput) (*domain.Inspection, error) {
+	var inspection *domain.Inspection
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		vehicle, err := s.vehicleRepo.FindByID(ctx, inp.VehicleID)
+		if err != nil {
+			return err
+		}
+
+		template, err := s.templateRepo.FindByID(ctx, inp.TemplateID)
+		if err != nil {
+			return err
+		}
+
+		inspection, err = domain.NewInspection(vehicle, template, inp.InspectorID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.inspectionRepo.Save(ctx, inspection); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionCreate, inspectionAggregate, inspection.ID, nil, inspection)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return inspection, nil
+}
+
+func (s *inspectionService) GetByID(ctx context.Context, id uint) (*domain.Inspection, error) {
+	return s.inspectionRepo.FindByID(ctx, id)
+}
+
+func (s *inspectionService) ListByVehicle(ctx context.Context, vehicleID uint) ([]*domain.Inspection, error) {
+	if _, err := s.vehicleRepo.FindByID(ctx, vehicleID); err != nil {
+		return nil, err
+	}
+	return s.inspectionRepo.FindByVehicleID(ctx, vehicleID)
+}
+
+// RecordResults - sube la versión de la inspección para que un Complete concurrente no
+// cierre con resultados que no ha visto
+func (s *inspectionService) RecordResults(ctx context.Context, inspectionID uint, results []input.RecordResultInput) (*domain.Inspection, error) {
+	if len(results) == 0 {
+		return nil, sharedDomain.Invalid("results", "at least one result is required")
+	}
+
+	var inspection *domain.Inspection
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		inspection, err = s.inspectionRepo.FindByID(ctx, inspectionID)
+		if err != nil {
+			return err
+		}
+		template, err := s.templateRepo.FindByID(ctx, inspection.TemplateID)
+		if err != nil {
+			return err
+		}
+
+		// La auditoría guarda solo los resultados tocados, no el checklist entero
+		var previous []domain.InspectionResult
+		recorded := make([]*domain.InspectionResult, 0, len(results))
+		for _, r := range results {
+			item := template.Item(r.ItemID)
+			if item == nil {
+				return sharedDomain.Invalid("item_id", "item does not belong to the inspection template")
+			}
+			if existing := inspection.Result(item.ID); existing != nil {
+				previous = append(previous, *existing)
+			}
+
+			var photo *domain.VehiclePhoto
+			if r.PhotoID != nil {
+				photo, err = s.photoRepo.FindByID(ctx, *r.PhotoID)
+				if err != nil {
+					return err
+				}
+			}
+
+			result, err := inspection.Record(item, domain.ItemResult(r.Result), r.Value, photo, r.Notes)
+			if err != nil {
+				return err
+			}
+			recorded = append(recorded, result)
+		}
+
+		if err := s.inspectionRepo.Update(ctx, inspection); err != nil {
+			return err
+		}
+		if err := s.inspectionRepo.SaveResults(ctx, recorded); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, inspectionAggregate, inspection.ID, previous, recorded)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return inspection, nil
+}
+
+func (s *inspectionService) Complete(ctx context.Context, id uint, notes string) (*domain.Inspection, error) {
+	var inspection *domain.Inspection
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		inspection, err = s.inspectionRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		template, err := s.templateRepo.FindByID(ctx, inspection.TemplateID)
+		if err != nil {
+			return err
+		}
+		before := *inspection
+
+		if err := inspection.Complete(template, notes); err != nil {
+			return err
+		}
+
+		if err := s.inspectionRepo.Update(ctx, inspection); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, inspectionAggregate, inspection.ID, before, inspection)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return inspection, nil
+}
+
+func toInspectionSections(list []input.InspectionSectionInput) []*domain.InspectionSection {
+	sections := make([]*domain.InspectionSection, len(list))
+	for i, s := range list {
+		items := make([]*domain.InspectionItem, len(s.Items))
+		for j, item := range s.Items {
+			items[j] = &domain.InspectionItem{
+				Label:         item.Label,
+				Required:      item.Required,
+				PhotoRequired: item.PhotoRequired,
+				Unit:          item.Unit,
+				MinValue:      item.MinValue,
+				MaxValue:      item.MaxValue,
+			}
+		}
+		sections[i] = &domain.InspectionSection{Name: s.Name, Items: items}
+	}
+	return sections
+}
@@ -1,139 +1,242 @@
 package services
 
 import (
-	"errors"
+	"context"
 
+	auditDomain "torque-dms/core/audit/domain"
+	auditInput "torque-dms/core/audit/ports/input"
 	"torque-dms/core/inventory/domain"
 	"torque-dms/core/inventory/ports/input"
 	"torque-dms/core/inventory/ports/output"
+	sharedDomain "torque-dms/core/shared/domain"
+	sharedOutput "torque-dms/core/shared/ports/output"
 )
 
 type locationService struct {
 	locationRepo output.LocationRepository
 	vehicleRepo  output.VehicleRepository
+	auditService auditInput.AuditService
+	uow          sharedOutput.UnitOfWork
 }
 
 func NewLocationService(
 	locationRepo output.LocationRepository,
 	vehicleRepo output.VehicleRepository,
+	auditService auditInput.AuditService,
+	uow sharedOutput.UnitOfWork,
 ) input.LocationService {
 	return &locationService{
 		locationRepo: locationRepo,
 		vehicleRepo:  vehicleRepo,
+		auditService: auditService,
+		uow:          uow,
 	}
 }
 
-func (s *locationService) Create(inp input.CreateLocationInput) (*domain.Location, error) {
-	location, err := domain.NewLocation(inp.Name, domain.LocationType(inp.Type))
-	if err != nil {
-		return nil, err
-	}
+func (s *locationService) Create(ctx context.Context, inp input.CreateLocationInput) (*domain.Location, error) {
+	var location *domain.Location
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		location, err = domain.NewLocation(inp.Name, domain.LocationType(inp.Type))
+		if err != nil {
+			return err
+		}
 
-	location.SetAddress(inp.Address, inp.City, inp.State, inp.Zip, inp.CountryID)
+		location.SetAddress(inp.Address, inp.City, inp.State, inp.Zip, inp.CountryID)
 
-	if inp.Latitude != 0 || inp.Longitude != 0 {
-		if err := location.SetCoordinates(inp.Latitude, inp.Longitude); err != nil {
-			return nil, err
+		if inp.Latitude != 0 || inp.Longitude != 0 {
+			if err := location.SetCoordinates(inp.Latitude, inp.Longitude); err != nil {
+				return err
+			}
 		}
-	}
 
-	if inp.Capacity > 0 {
-		if err := location.SetCapacity(inp.Capacity); err != nil {
-			return nil, err
+		if inp.Capacity > 0 {
+			if err := location.SetCapacity(inp.Capacity); err != nil {
+				return err
+			}
+		}
+
+		if err := s.locationRepo.Save(ctx, location); err != nil {
+			return err
 		}
-	}
 
-	if err := s.locationRepo.Save(location); err != nil {
+		return s.auditService.Record(ctx, auditDomain.ActionCreate, locationAggregate, location.ID, nil, location)
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return location, nil
 }
 
-func (s *locationService) GetByID(id uint) (*domain.Location, error) {
-	return s.locationRepo.FindByID(id)
+func (s *locationService) GetByID(ctx context.Context, id uint) (*domain.Location, error) {
+	return s.locationRepo.FindByID(ctx, id)
 }
 
-func (s *locationService) Update(id uint, inp input.UpdateLocationInput) (*domain.Location, error) {
-	location, err := s.locationRepo.FindByID(id)
-	if err != nil {
-		return nil, errors.New("location not found")
-	}
+func (s *locationService) Update(ctx context.Context, id uint, inp input.UpdateLocationInput) (*domain.Location, error) {
+	var location *domain.Location
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		location, err = s.locationRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := sharedDomain.CheckVersion(inp.Version, location.Version); err != nil {
+			return err
+		}
+		before := *location
 
-	if inp.Name != nil {
-		location.Name = *inp.Name
-	}
-	if inp.Address != nil {
-		location.Address = *inp.Address
-	}
-	if inp.City != nil {
-		location.City = *inp.City
-	}
-	if inp.State != nil {
-		location.State = *inp.State
-	}
-	if inp.Zip != nil {
-		location.Zip = *inp.Zip
-	}
-	if inp.Latitude != nil && inp.Longitude != nil {
-		if err := location.SetCoordinates(*inp.Latitude, *inp.Longitude); err != nil {
-			return nil, err
+		if inp.Name != nil {
+			location.Name = *inp.Name
 		}
-	}
-	if inp.Capacity != nil {
-		if err := location.SetCapacity(*inp.Capacity); err != nil {
-			return nil, err
+		if inp.Address != nil {
+			location.Address = *inp.Address
+		}
+		if inp.City != nil {
+			location.City = *inp.City
+		}
+		if inp.State != nil {
+			location.State = *inp.State
+		}
+		if inp.Zip != nil {
+			location.Zip = *inp.Zip
+		}
+		if inp.Latitude != nil && inp.Longitude != nil {
+			if err := location.SetCoordinates(*inp.Latitude, *inp.Longitude); err != nil {
+				return err
+			}
+		}
+		if inp.Capacity != nil {
+			if err := location.SetCapacity(*inp.Capacity); err != nil {
+				return err
+			}
+		}
+
+		if err := s.locationRepo.Update(ctx, location); err != nil {
+			return err
 		}
-	}
 
-	if err := s.locationRepo.Update(location); err != nil {
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, locationAggregate, location.ID, before, location)
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return location, nil
 }
 
-func (s *locationService) Delete(id uint) error {
-	// Verificar que no haya vehículos en esta ubicación
-	vehicles, err := s.vehicleRepo.FindByLocationID(id)
-	if err != nil {
-		return err
-	}
-	if len(vehicles) > 0 {
-		return errors.New("cannot delete location with vehicles")
-	}
+func (s *locationService) Delete(ctx context.Context, id uint) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		location, err := s.locationRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		// Verificar que no haya vehículos en esta ubicación, tampoco vendidos ni en la papelera
+		counts, err := s.vehicleRepo.CountStockByLocation(sharedDomain.WithDeleted(ctx), []uint{id})
+		if err != nil {
+			return err
+		}
+		if len(counts) > 0 {
+			return sharedDomain.Invariant("location_in_use", "cannot delete location with vehicles")
+		}
+
+		// Rutas, traslados e historial se conservan; una location con pasado se desactiva
+		hasHistory, err := s.locationRepo.HasHistory(ctx, id)
+		if err != nil {
+			return err
+		}
+		if hasHistory {
+			return sharedDomain.Invariant("location_has_history", "location is referenced by routes, transfers or location history; deactivate it instead")
+		}
 
-	return s.locationRepo.Delete(id)
+		if err := s.locationRepo.Delete(ctx, id); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionDelete, locationAggregate, id, location, nil)
+	})
 }
 
-func (s *locationService) List() ([]*domain.Location, error) {
-	return s.locationRepo.FindAll()
+func (s *locationService) List(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.Location], error) {
+	return s.locationRepo.FindAll(ctx, q.Normalize(50, 100))
 }
 
-func (s *locationService) ListByType(locationType string) ([]*domain.Location, error) {
-	return s.locationRepo.FindByType(domain.LocationType(locationType))
+func (s *locationService) ListByType(ctx context.Context, locationType string) ([]*domain.Location, error) {
+	return s.locationRepo.FindByType(ctx, domain.LocationType(locationType))
 }
 
-func (s *locationService) ListActive() ([]*domain.Location, error) {
-	return s.locationRepo.FindActive()
+func (s *locationService) ListActive(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.Location], error) {
+	return s.locationRepo.FindAll(ctx, q.Normalize(50, 100).Where("active", sharedDomain.FilterEq, "true"))
 }
 
-func (s *locationService) Deactivate(id uint) error {
-	location, err := s.locationRepo.FindByID(id)
-	if err != nil {
-		return errors.New("location not found")
-	}
+func (s *locationService) Deactivate(ctx context.Context, id uint) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		location, err := s.locationRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		before := *location
+
+		location.Deactivate()
+		if err := s.locationRepo.Update(ctx, location); err != nil {
+			return err
+		}
 
-	location.Deactivate()
-	return s.locationRepo.Update(location)
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, locationAggregate, id, before, location)
+	})
 }
 
-func (s *locationService) Activate(id uint) error {
-	location, err := s.locationRepo.FindByID(id)
+func (s *locationService) Activate(ctx context.Context, id uint) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		location, err := s.locationRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		before := *location
+
+		location.Activate()
+		if err := s.locationRepo.Update(ctx, location); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, locationAggregate, id, before, location)
+	})
+}
+
+func (s *locationService) SetGeofence(ctx context.Context, id uint, inp input.SetGeofenceInput) (*domain.Location, error) {
+	var location *domain.Location
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		location, err = s.locationRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := sharedDomain.CheckVersion(inp.Version, location.Version); err != nil {
+			return err
+		}
+		before := *location
+
+		var geofence *domain.Geofence
+		if inp.RadiusM != 0 || len(inp.Polygon) > 0 {
+			geofence, err = domain.NewGeofence(inp.RadiusM, inp.Polygon)
+			if err != nil {
+				return err
+			}
+		}
+		if err := location.SetGeofence(geofence); err != nil {
+			return err
+		}
+
+		if err := s.locationRepo.Update(ctx, location); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, locationAggregate, id, before, location)
+	})
 	if err != nil {
-		return errors.New("location not found")
+		return nil, err
 	}
 
-	location.Activate()
-	return s.locationRepo.Update(location)
+	return location, nil
 }
\ No newline at end of file
@@ -0,0 +1,398 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	auditDomain "torque-dms/core/audit/domain"
+	auditInput "torque-dms/core/audit/ports/input"
+	"torque-dms/core/inventory/domain"
+	"torque-dms/core/inventory/ports/input"
+	"torque-dms/core/inventory/ports/output"
+	sharedDomain "torque-dms/core/shared/domain"
+	sharedOutput "torque-dms/core/shared/ports/output"
+)
+
+type model3DService struct {
+	modelRepo    output.VehicleModel3DRepository
+	zoneRepo     output.VehicleModelZoneRepository
+	vehicleRepo  output.VehicleRepository
+	storage      sharedOutput.FileStorage
+	auditService auditInput.AuditService
+	uow          sharedOutput.UnitOfWork
+}
+
+func NewModel3DService(
+	modelRepo output.VehicleModel3DRepository,
+	zoneRepo output.VehicleModelZoneRepository,
+	vehicleRepo output.VehicleRepository,
+	storage sharedOutput.FileStorage,
+	auditService auditInput.AuditService,
+	uow sharedOutput.UnitOfWork,
+) input.Model3DService {
+	return &model3DService{
+		modelRepo:    modelRepo,
+		zoneRepo:     zoneRepo,
+		vehicleRepo:  vehicleRepo,
+		storage:      storage,
+		auditService: auditService,
+		uow:          uow,
+	}
+}
+
+func (s *model3DService) Create(ctx context.Context, inp input.CreateModel3DInput) (*domain.VehicleModel3D, error) {
+	var model *domain.VehicleModel3D
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		model, err = domain.NewVehicleModel3D(inp.Name, domain.NormalizeBodyType(inp.BodyType))
+		if err != nil {
+			return err
+		}
+
+		if err := s.modelRepo.Save(ctx, model); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionCreate, model3DAggregate, model.ID, nil, model)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+func (s *model3DService) GetByID(ctx context.Context, id uint) (*domain.VehicleModel3D, error) {
+	return s.modelRepo.FindByID(ctx, id)
+}
+
+func (s *model3DService) Update(ctx context.Context, id uint, inp input.UpdateModel3DInput) (*domain.VehicleModel3D, error) {
+	var model *domain.VehicleModel3D
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		model, err = s.modelRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := sharedDomain.CheckVersion(inp.Version, model.Version); err != nil {
+			return err
+		}
+		before := *model
+
+		if inp.Name != nil {
+			if *inp.Name == "" {
+				return sharedDomain.Invalid("name", "name is required")
+			}
+			model.Name = *inp.Name
+		}
+
+		if err := s.modelRepo.Update(ctx, model); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, model3DAggregate, id, before, model)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// Delete - las zonas se borran en cascada y los vehicles se quedan sin modelo; un modelo con
+// daños marcados se conserva para no perder dónde estaban
+func (s *model3DService) Delete(ctx context.Context, id uint) error {
+	var model *domain.VehicleModel3D
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		model, err = s.modelRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		inUse, err := s.modelRepo.HasMarks(ctx, id)
+		if err != nil {
+			return err
+		}
+		if inUse {
+			return sharedDomain.Invariant("model_in_use", "model has damage marks, deactivate it instead")
+		}
+
+		if err := s.modelRepo.Delete(ctx, id); err != nil {
+			return err
+		}
+		return s.auditService.Record(ctx, auditDomain.ActionDelete, model3DAggregate, id, model, nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	// El borrado ya está hecho; un archivo huérfano no debe convertirlo en error
+	s.removeFile(ctx, model.FileURL)
+	s.removeFile(ctx, model.ThumbnailURL)
+	return nil
+}
+
+func (s *model3DService) List(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.VehicleModel3D], error) {
+	return s.modelRepo.FindAll(ctx, q.Normalize(50, 100))
+}
+
+func (s *model3DService) Deactivate(ctx context.Context, id uint) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		model, err := s.modelRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		before := *model
+
+		model.Deactivate()
+		if err := s.modelRepo.Update(ctx, model); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, model3DAggregate, id, before, model)
+	})
+}
+
+func (s *model3DService) Activate(ctx context.Context, id uint) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		model, err := s.modelRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		before := *model
+
+		model.Activate()
+		if err := s.modelRepo.Update(ctx, model); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, model3DAggregate, id, before, model)
+	})
+}
+
+func (s *model3DService) SetDefault(ctx context.Context, id uint) (*domain.VehicleModel3D, error) {
+	var model *domain.VehicleModel3D
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		model, err = s.modelRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if model.IsDefault {
+			return nil
+		}
+		before := *model
+
+		if err := model.MakeDefault(); err != nil {
+			return err
+		}
+		// El índice único admite un solo predeterminado por carrocería
+		if err := s.modelRepo.ClearDefault(ctx, model.BodyType); err != nil {
+			return err
+		}
+		if err := s.modelRepo.Update(ctx, model); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, model3DAggregate, id, before, model)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return model, nil
+}
+
+// UploadFile - el archivo se guarda con un nombre nuevo en cada subida, así las cachés del
+// visor nunca sirven la versión anterior
+func (s *model3DService) UploadFile(ctx context.Context, inp input.UploadModelFileInput) (*domain.VehicleModel3D, error) {
+	upload, err := domain.NewModelUpload(domain.ModelFileKind(inp.Kind), inp.Filename, inp.Size)
+	if err != nil {
+		return nil, err
+	}
+
+	model, err := s.modelRepo.FindByID(ctx, inp.Model3DID)
+	if err != nil {
+		return nil, err
+	}
+	before := *model
+
+	key := fmt.Sprintf("models-3d/%d/%s-%d%s", model.ID, upload.Kind, time.Now().UnixNano(), upload.Extension)
+	url, err := s.storage.Put(ctx, key, upload.ContentType, inp.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := model.SetFile(upload.Kind, url)
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.modelRepo.Update(ctx, model); err != nil {
+			return err
+		}
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, model3DAggregate, model.ID, before, model)
+	})
+	if err != nil {
+		s.removeFile(ctx, url)
+		return nil, err
+	}
+
+	s.removeFile(ctx, previous)
+	return model, nil
+}
+
+func (s *model3DService) AssignToVehicle(ctx context.Context, vehicleID uint, model3DID uint) (*domain.Vehicle, error) {
+	var vehicle *domain.Vehicle
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		vehicle, err = s.vehicleRepo.FindByID(ctx, vehicleID)
+		if err != nil {
+			return err
+		}
+		before := *vehicle
+
+		var model *domain.VehicleModel3D
+		if model3DID != 0 {
+			model, err = s.modelRepo.FindByID(ctx, model3DID)
+			if err != nil {
+				return err
+			}
+		}
+		if err := vehicle.AssignModel3D(model); err != nil {
+			return err
+		}
+
+		if err := s.vehicleRepo.Update(ctx, vehicle); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, vehicleAggregate, vehicle.ID, before, vehicle)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return vehicle, nil
+}
+
+// removeFile - limpieza de archivos reemplazados; un fallo solo deja un archivo huérfano
+func (s *model3DService) removeFile(ctx context.Context, url string) {
+	if url != "" {
+		_ = s.storage.Delete(ctx, url)
+	}
+}
+
+// Zones
+
+func (s *model3DService) ListZones(ctx context.Context, model3DID uint) ([]*domain.VehicleModelZone, error) {
+	if _, err := s.modelRepo.FindByID(ctx, model3DID); err != nil {
+		return nil, err
+	}
+	return s.zoneRepo.FindByModelID(ctx, model3DID)
+}
+
+func (s *model3DService) AddZone(ctx context.Context, model3DID uint, inp input.ModelZoneInput) (*domain.VehicleModelZone, error) {
+	var zone *domain.VehicleModelZone
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		if _, err := s.modelRepo.FindByID(ctx, model3DID); err != nil {
+			return err
+		}
+
+		var err error
+		zone, err = domain.NewVehicleModelZone(model3DID, inp.Code, inp.Name, inp.MeshID)
+		if err != nil {
+			return err
+		}
+		if err := s.ensureZoneCodeFree(ctx, model3DID, zone.Code); err != nil {
+			return err
+		}
+
+		if err := s.zoneRepo.Save(ctx, zone); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionCreate, modelZoneAggregate, zone.ID, nil, zone)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return zone, nil
+}
+
+func (s *model3DService) UpdateZone(ctx context.Context, model3DID uint, zoneID uint, inp input.ModelZoneInput) (*domain.VehicleModelZone, error) {
+	var zone *domain.VehicleModelZone
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		zone, err = s.findZone(ctx, model3DID, zoneID)
+		if err != nil {
+			return err
+		}
+		before := *zone
+
+		if err := zone.Rename(inp.Code, inp.Name, inp.MeshID); err != nil {
+			return err
+		}
+		if zone.Code != before.Code {
+			if err := s.ensureZoneCodeFree(ctx, model3DID, zone.Code); err != nil {
+				return err
+			}
+		}
+
+		if err := s.zoneRepo.Update(ctx, zone); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, modelZoneAggregate, zone.ID, before, zone)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return zone, nil
+}
+
+func (s *model3DService) DeleteZone(ctx context.Context, model3DID uint, zoneID uint) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		zone, err := s.findZone(ctx, model3DID, zoneID)
+		if err != nil {
+			return err
+		}
+
+		inUse, err := s.zoneRepo.HasMarks(ctx, zoneID)
+		if err != nil {
+			return err
+		}
+		if inUse {
+			return sharedDomain.Invariant("zone_in_use", "zone has damage marks")
+		}
+
+		if err := s.zoneRepo.Delete(ctx, zoneID); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionDelete, modelZoneAggregate, zoneID, zone, nil)
+	})
+}
+
+// findZone - una zona de otro modelo se trata como inexistente
+func (s *model3DService) findZone(ctx context.Context, model3DID uint, zoneID uint) (*domain.VehicleModelZone, error) {
+	zone, err := s.zoneRepo.FindByID(ctx, zoneID)
+	if err != nil {
+		return nil, err
+	}
+	if zone.Model3DID != model3DID {
+		return nil, sharedDomain.NotFound("zone")
+	}
+	return zone, nil
+}
+
+func (s *model3DService) ensureZoneCodeFree(ctx context.Context, model3DID uint, code string) error {
+	exists, err := s.zoneRepo.ExistsByCode(ctx, model3DID, code)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return sharedDomain.Conflict("zone_code_taken", "model already has a zone with this code")
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+
+	"torque-dms/core/inventory/domain"
+	"torque-dms/core/inventory/ports/input"
+	"torque-dms/core/inventory/ports/output"
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+type occupancyService struct {
+	locationRepo output.LocationRepository
+	vehicleRepo  output.VehicleRepository
+	transferRepo output.VehicleTransferRepository
+}
+
+func NewOccupancyService(
+	locationRepo output.LocationRepository,
+	vehicleRepo output.VehicleRepository,
+	transferRepo output.VehicleTransferRepository,
+) input.OccupancyService {
+	return &occupancyService{
+		locationRepo: locationRepo,
+		vehicleRepo:  vehicleRepo,
+		transferRepo: transferRepo,
+	}
+}
+
+func (s *occupancyService) GetOccupancy(ctx context.Context, locationID uint) (*domain.LocationOccupancy, error) {
+	location, err := s.locationRepo.FindByID(ctx, locationID)
+	if err != nil {
+		return nil, err
+	}
+	return loadOccupancy(ctx, s.vehicleRepo, s.transferRepo, location)
+}
+
+// GetSummary - dos consultas agrupadas para todas las ubicaciones, no una por ubicación
+func (s *occupancyService) GetSummary(ctx context.Context) (*domain.OccupancySummary, error) {
+	locations, err := s.locationRepo.FindAll(ctx, sharedDomain.Query{})
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := s.vehicleRepo.CountStockByLocation(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	inbound, err := s.transferRepo.CountPendingByDestination(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &domain.OccupancySummary{Locations: make([]*domain.LocationOccupancy, len(locations.Items))}
+	for i, location := range locations.Items {
+		summary.Locations[i] = domain.NewLocationOccupancy(location, counts, inbound[location.ID])
+	}
+	return summary, nil
+}
+
+// loadOccupancy - la misma cuenta que usan el dashboard y las comprobaciones de capacidad
+// de los movimientos
+func loadOccupancy(ctx context.Context, vehicleRepo output.VehicleRepository, transferRepo output.VehicleTransferRepository, location *domain.Location) (*domain.LocationOccupancy, error) {
+	ids := []uint{location.ID}
+
+	counts, err := vehicleRepo.CountStockByLocation(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	inbound, err := transferRepo.CountPendingByDestination(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewLocationOccupancy(location, counts, inbound[location.ID]), nil
+}
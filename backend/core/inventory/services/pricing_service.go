@@ -0,0 +1,239 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	auditDomain "torque-dms/core/audit/domain"
+	auditInput "torque-dms/core/audit/ports/input"
+	"torque-dms/core/inventory/domain"
+	"torque-dms/core/inventory/ports/input"
+	"torque-dms/core/inventory/ports/output"
+	sharedDomain "torque-dms/core/shared/domain"
+	sharedOutput "torque-dms/core/shared/ports/output"
+)
+
+type pricingService struct {
+	ruleRepo     output.RepricingRuleRepository
+	priceRepo    output.PriceChangeRepository
+	vehicleRepo  output.VehicleRepository
+	auditService auditInput.AuditService
+	uow          sharedOutput.UnitOfWork
+}
+
+func NewPricingService(
+	ruleRepo output.RepricingRuleRepository,
+	priceRepo output.PriceChangeRepository,
+	vehicleRepo output.VehicleRepository,
+	auditService auditInput.AuditService,
+	uow sharedOutput.UnitOfWork,
+) input.PricingService {
+	return &pricingService{
+		ruleRepo:     ruleRepo,
+		priceRepo:    priceRepo,
+		vehicleRepo:  vehicleRepo,
+		auditService: auditService,
+		uow:          uow,
+	}
+}
+
+func (s *pricingService) GetAging(ctx context.Context) (*domain.InventoryAging, error) {
+	vehicles, err := s.vehicleRepo.FindByStatuses(ctx, domain.InStockStatuses())
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewInventoryAging(vehicles, time.Now()), nil
+}
+
+func (s *pricingService) GetPriceHistory(ctx context.Context, vehicleID uint, q sharedDomain.Query) (*sharedDomain.Page[*domain.PriceChange], error) {
+	if _, err := s.vehicleRepo.FindByID(ctx, vehicleID); err != nil {
+		return nil, err
+	}
+	return s.priceRepo.FindByVehicleID(ctx, vehicleID, q.Normalize(20, 100))
+}
+
+// Rules
+
+func (s *pricingService) CreateRule(ctx context.Context, inp input.RepricingRuleInput) (*domain.RepricingRule, error) {
+	var rule *domain.RepricingRule
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		rule, err = domain.NewRepricingRule(inp.Name, domain.VehicleCondition(inp.Condition), domain.LotType(inp.LotType), inp.MinDaysInStock, inp.DropPercent, inp.IntervalDays, inp.FloorOverCost)
+		if err != nil {
+			return err
+		}
+
+		if err := s.ruleRepo.Save(ctx, rule); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionCreate, repricingRuleAggregate, rule.ID, nil, rule)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (s *pricingService) UpdateRule(ctx context.Context, id uint, inp input.RepricingRuleInput) (*domain.RepricingRule, error) {
+	return s.changeRule(ctx, id, inp.Version, func(rule *domain.RepricingRule) error {
+		return rule.Configure(inp.Name, domain.VehicleCondition(inp.Condition), domain.LotType(inp.LotType), inp.MinDaysInStock, inp.DropPercent, inp.IntervalDays, inp.FloorOverCost)
+	})
+}
+
+func (s *pricingService) ActivateRule(ctx context.Context, id uint) (*domain.RepricingRule, error) {
+	return s.changeRule(ctx, id, nil, func(rule *domain.RepricingRule) error {
+		rule.Activate()
+		return nil
+	})
+}
+
+func (s *pricingService) DeactivateRule(ctx context.Context, id uint) (*domain.RepricingRule, error) {
+	return s.changeRule(ctx, id, nil, func(rule *domain.RepricingRule) error {
+		rule.Deactivate()
+		return nil
+	})
+}
+
+// DeleteRule - el historial de precios conserva los cambios hechos por la regla, sin referencia
+func (s *pricingService) DeleteRule(ctx context.Context, id uint) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		rule, err := s.ruleRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.ruleRepo.Delete(ctx, id); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionDelete, repricingRuleAggregate, id, rule, nil)
+	})
+}
+
+func (s *pricingService) GetRule(ctx context.Context, id uint) (*domain.RepricingRule, error) {
+	return s.ruleRepo.FindByID(ctx, id)
+}
+
+func (s *pricingService) ListRules(ctx context.Context) ([]*domain.RepricingRule, error) {
+	return s.ruleRepo.FindAll(ctx)
+}
+
+func (s *pricingService) changeRule(ctx context.Context, id uint, version *uint, change func(*domain.RepricingRule) error) (*domain.RepricingRule, error) {
+	var rule *domain.RepricingRule
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		rule, err = s.ruleRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := sharedDomain.CheckVersion(version, rule.Version); err != nil {
+			return err
+		}
+		before := *rule
+
+		if err := change(rule); err != nil {
+			return err
+		}
+
+		if err := s.ruleRepo.Update(ctx, rule); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, repricingRuleAggregate, rule.ID, before, rule)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// Repricing
+
+func (s *pricingService) PreviewRepricing(ctx context.Context, ruleID *uint) ([]*domain.RepricingProposal, error) {
+	var rules []*domain.RepricingRule
+	if ruleID != nil {
+		rule, err := s.ruleRepo.FindByID(ctx, *ruleID)
+		if err != nil {
+			return nil, err
+		}
+		rules = []*domain.RepricingRule{rule}
+	} else {
+		var err error
+		if rules, err = s.ruleRepo.FindActive(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	proposals, _, err := s.plan(ctx, rules, time.Now())
+	return proposals, err
+}
+
+// ApplyRepricing - cada vehicle va en su propia transacción: uno que falla (p. ej. porque
+// alguien cambió el precio mientras tanto) no frena al resto y se reintenta en la siguiente pasada
+func (s *pricingService) ApplyRepricing(ctx context.Context, asOf time.Time) (int, error) {
+	rules, err := s.ruleRepo.FindActive(ctx)
+	if err != nil || len(rules) == 0 {
+		return 0, err
+	}
+
+	proposals, vehicles, err := s.plan(ctx, rules, asOf)
+	if err != nil {
+		return 0, err
+	}
+
+	applied := 0
+	var firstErr error
+	for _, p := range proposals {
+		if err := s.apply(ctx, vehicles[p.VehicleID], p, asOf); err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("vehicle %d: %w", p.VehicleID, err)
+			}
+			continue
+		}
+		applied++
+	}
+	return applied, firstErr
+}
+
+func (s *pricingService) plan(ctx context.Context, rules []*domain.RepricingRule, now time.Time) ([]*domain.RepricingProposal, map[uint]*domain.Vehicle, error) {
+	list, err := s.vehicleRepo.FindByStatuses(ctx, []domain.VehicleStatus{domain.VehicleStatusReadyForSale})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	vehicles := make(map[uint]*domain.Vehicle, len(list))
+	ids := make([]uint, len(list))
+	for i, v := range list {
+		vehicles[v.ID] = v
+		ids[i] = v.ID
+	}
+
+	lastDrops, err := s.priceRepo.LastRuleChanges(ctx, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	return domain.PlanRepricing(rules, list, lastDrops, now), vehicles, nil
+}
+
+// apply - usa el vehicle leído al planificar, así un cambio manual posterior da conflicto de versión
+func (s *pricingService) apply(ctx context.Context, vehicle *domain.Vehicle, p *domain.RepricingProposal, asOf time.Time) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		before := *vehicle
+
+		if err := vehicle.Reprice(p.NewPrice); err != nil {
+			return err
+		}
+		if err := s.vehicleRepo.Update(ctx, vehicle); err != nil {
+			return err
+		}
+		if err := s.priceRepo.Save(ctx, domain.NewRulePriceChange(p, asOf)); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, vehicleAggregate, vehicle.ID, before, vehicle)
+	})
+}
@@ -0,0 +1,252 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	auditDomain "torque-dms/core/audit/domain"
+	auditInput "torque-dms/core/audit/ports/input"
+	"torque-dms/core/inventory/domain"
+	"torque-dms/core/inventory/ports/input"
+	"torque-dms/core/inventory/ports/output"
+	sharedDomain "torque-dms/core/shared/domain"
+	sharedOutput "torque-dms/core/shared/ports/output"
+)
+
+const defaultReconStatsWindow = 30 * 24 * time.Hour
+
+type reconService struct {
+	orderRepo    output.ReconOrderRepository
+	vehicleRepo  output.VehicleRepository
+	policy       *domain.ReconPolicy
+	auditService auditInput.AuditService
+	uow          sharedOutput.UnitOfWork
+}
+
+func NewReconService(
+	orderRepo output.ReconOrderRepository,
+	vehicleRepo output.VehicleRepository,
+	policy *domain.ReconPolicy,
+	auditService auditInput.AuditService,
+	uow sharedOutput.UnitOfWork,
+) input.ReconService {
+	return &reconService{
+		orderRepo:    orderRepo,
+		vehicleRepo:  vehicleRepo,
+		policy:       policy,
+		auditService: auditService,
+		uow:          uow,
+	}
+}
+
+func (s *reconService) GetByID(ctx context.Context, id uint) (*domain.ReconOrder, error) {
+	return s.orderRepo.FindByID(ctx, id)
+}
+
+func (s *reconService) List(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.ReconOrder], error) {
+	return s.orderRepo.FindAll(ctx, q.Normalize(50, 100))
+}
+
+func (s *reconService) ListByVehicle(ctx context.Context, vehicleID uint) ([]*domain.ReconOrder, error) {
+	if _, err := s.vehicleRepo.FindByID(ctx, vehicleID); err != nil {
+		return nil, err
+	}
+	return s.orderRepo.FindByVehicleID(ctx, vehicleID)
+}
+
+func (s *reconService) Close(ctx context.Context, id uint) (*domain.ReconOrder, error) {
+	var order *domain.ReconOrder
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		order, err = s.orderRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		before := *order
+
+		if err := order.Close(); err != nil {
+			return err
+		}
+
+		if err := s.orderRepo.Update(ctx, order); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, reconOrderAggregate, order.ID, before, order)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+func (s *reconService) GetCycleStats(ctx context.Context, from time.Time, to time.Time) (*domain.ReconCycleStats, error) {
+	if to.IsZero() {
+		to = time.Now()
+	}
+	if from.IsZero() {
+		from = to.Add(-defaultReconStatsWindow)
+	}
+	if from.After(to) {
+		return nil, sharedDomain.Invalid("from", "from must be before to")
+	}
+
+	closed, err := s.orderRepo.FindClosedBetween(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	open, err := s.orderRepo.FindOpen(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewReconCycleStats(from, to, closed, open, time.Now()), nil
+}
+
+// Tasks
+
+func (s *reconService) AddTask(ctx context.Context, orderID uint, inp input.AddReconTaskInput) (*domain.ReconTask, error) {
+	var task *domain.ReconTask
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		order, err := s.orderRepo.FindByID(ctx, orderID)
+		if err != nil {
+			return err
+		}
+
+		task, err = order.AddTask(domain.ReconTaskType(inp.Type), inp.Description, inp.AssigneeID, inp.Vendor, inp.EstimatedCost, s.policy)
+		if err != nil {
+			return err
+		}
+
+		if err := s.orderRepo.SaveTask(ctx, task); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionCreate, reconTaskAggregate, task.ID, nil, task)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+func (s *reconService) UpdateTask(ctx context.Context, orderID uint, taskID uint, inp input.UpdateReconTaskInput) (*domain.ReconTask, error) {
+	return s.changeTask(ctx, orderID, taskID, inp.Version, func(task *domain.ReconTask) error {
+		if inp.Description != nil {
+			task.Description = strings.TrimSpace(*inp.Description)
+		}
+		if inp.AssigneeID != nil || inp.Vendor != nil {
+			assigneeID, vendor := task.AssigneeID, task.Vendor
+			if inp.AssigneeID != nil {
+				assigneeID = nil
+				if *inp.AssigneeID != 0 {
+					assigneeID = inp.AssigneeID
+				}
+			}
+			if inp.Vendor != nil {
+				vendor = *inp.Vendor
+			}
+			if err := task.Assign(assigneeID, vendor); err != nil {
+				return err
+			}
+		}
+		if inp.EstimatedCost != nil {
+			return task.SetEstimate(*inp.EstimatedCost, s.policy)
+		}
+		return nil
+	})
+}
+
+func (s *reconService) ApproveTask(ctx context.Context, orderID uint, taskID uint) (*domain.ReconTask, error) {
+	return s.changeTask(ctx, orderID, taskID, nil, func(task *domain.ReconTask) error {
+		return task.Approve(sharedDomain.ActorFromContext(ctx).EntityID)
+	})
+}
+
+func (s *reconService) StartTask(ctx context.Context, orderID uint, taskID uint) (*domain.ReconTask, error) {
+	return s.changeTask(ctx, orderID, taskID, nil, func(task *domain.ReconTask) error {
+		return task.Start()
+	})
+}
+
+func (s *reconService) CancelTask(ctx context.Context, orderID uint, taskID uint) (*domain.ReconTask, error) {
+	return s.changeTask(ctx, orderID, taskID, nil, func(task *domain.ReconTask) error {
+		return task.Cancel()
+	})
+}
+
+// CompleteTask - el recon_cost del vehicle se recalcula sumando todas sus tareas completadas,
+// en la misma transacción que cierra la tarea
+func (s *reconService) CompleteTask(ctx context.Context, orderID uint, taskID uint, actualCost float64) (*domain.ReconTask, error) {
+	var task *domain.ReconTask
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		task, err = s.changeTask(ctx, orderID, taskID, nil, func(task *domain.ReconTask) error {
+			return task.Complete(actualCost)
+		})
+		if err != nil {
+			return err
+		}
+
+		order, err := s.orderRepo.FindByID(ctx, orderID)
+		if err != nil {
+			return err
+		}
+		vehicle, err := s.vehicleRepo.FindByID(ctx, order.VehicleID)
+		if err != nil {
+			return err
+		}
+		total, err := s.orderRepo.TotalCostByVehicle(ctx, vehicle.ID)
+		if err != nil {
+			return err
+		}
+		before := *vehicle
+
+		vehicle.SetReconCost(total)
+		if err := s.vehicleRepo.Update(ctx, vehicle); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, vehicleAggregate, vehicle.ID, before, vehicle)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// changeTask - las tareas solo se tocan a través de su orden, así una tarea de otra orden no existe
+func (s *reconService) changeTask(ctx context.Context, orderID uint, taskID uint, version *uint, change func(*domain.ReconTask) error) (*domain.ReconTask, error) {
+	var task *domain.ReconTask
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		order, err := s.orderRepo.FindByID(ctx, orderID)
+		if err != nil {
+			return err
+		}
+		task = order.Task(taskID)
+		if task == nil {
+			return sharedDomain.NotFound("recon_task")
+		}
+		if err := sharedDomain.CheckVersion(version, task.Version); err != nil {
+			return err
+		}
+		before := *task
+
+		if err := change(task); err != nil {
+			return err
+		}
+
+		if err := s.orderRepo.UpdateTask(ctx, task); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, reconTaskAggregate, task.ID, before, task)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
@@ -0,0 +1,305 @@
+package services
+
+import (
+	"context"
+
+	auditDomain "torque-dms/core/audit/domain"
+	auditInput "torque-dms/core/audit/ports/input"
+	"torque-dms/core/inventory/domain"
+	"torque-dms/core/inventory/ports/input"
+	"torque-dms/core/inventory/ports/output"
+	sharedDomain "torque-dms/core/shared/domain"
+	sharedOutput "torque-dms/core/shared/ports/output"
+)
+
+type routeService struct {
+	routeRepo    output.RouteRepository
+	locationRepo output.LocationRepository
+	transferRepo output.VehicleTransferRepository
+	trackingRepo output.VehicleTrackingRepository
+	offRouteKM   float64
+	auditService auditInput.AuditService
+	uow          sharedOutput.UnitOfWork
+}
+
+// NewRouteService - offRouteKM es la distancia al camino a partir de la cual un vehicle se
+// considera fuera de ruta
+func NewRouteService(
+	routeRepo output.RouteRepository,
+	locationRepo output.LocationRepository,
+	transferRepo output.VehicleTransferRepository,
+	trackingRepo output.VehicleTrackingRepository,
+	offRouteKM float64,
+	auditService auditInput.AuditService,
+	uow sharedOutput.UnitOfWork,
+) input.RouteService {
+	return &routeService{
+		routeRepo:    routeRepo,
+		locationRepo: locationRepo,
+		transferRepo: transferRepo,
+		trackingRepo: trackingRepo,
+		offRouteKM:   offRouteKM,
+		auditService: auditService,
+		uow:          uow,
+	}
+}
+
+func (s *routeService) Create(ctx context.Context, inp input.CreateRouteInput) (*domain.Route, error) {
+	var route *domain.Route
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		route, err = domain.NewRoute(inp.Name, inp.FromLocationID, inp.ToLocationID)
+		if err != nil {
+			return err
+		}
+		if err := route.SetWaypoints(inp.Waypoints); err != nil {
+			return err
+		}
+
+		from, err := s.locationRepo.FindByID(ctx, inp.FromLocationID)
+		if err != nil {
+			return err
+		}
+		to, err := s.locationRepo.FindByID(ctx, inp.ToLocationID)
+		if err != nil {
+			return err
+		}
+
+		distanceKM := inp.DistanceKM
+		if distanceKM == 0 {
+			distanceKM = route.StraightLineKM(from, to)
+		}
+		if err := route.SetEstimate(distanceKM, inp.EstimatedMinutes); err != nil {
+			return err
+		}
+
+		if err := s.routeRepo.Save(ctx, route); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionCreate, routeAggregate, route.ID, nil, route)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return route, nil
+}
+
+func (s *routeService) GetByID(ctx context.Context, id uint) (*domain.Route, error) {
+	return s.routeRepo.FindByID(ctx, id)
+}
+
+func (s *routeService) Update(ctx context.Context, id uint, inp input.UpdateRouteInput) (*domain.Route, error) {
+	var route *domain.Route
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		route, err = s.routeRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := sharedDomain.CheckVersion(inp.Version, route.Version); err != nil {
+			return err
+		}
+		before := *route
+
+		if inp.Name != nil {
+			if *inp.Name == "" {
+				return sharedDomain.Invalid("name", "name is required")
+			}
+			route.Name = *inp.Name
+		}
+		if inp.Waypoints != nil {
+			if err := route.SetWaypoints(*inp.Waypoints); err != nil {
+				return err
+			}
+		}
+		if inp.DistanceKM != nil || inp.EstimatedMinutes != nil {
+			distanceKM, minutes := route.DistanceKM, route.EstimatedMinutes
+			if inp.DistanceKM != nil {
+				distanceKM = *inp.DistanceKM
+			}
+			if inp.EstimatedMinutes != nil {
+				minutes = *inp.EstimatedMinutes
+			}
+			if err := route.SetEstimate(distanceKM, minutes); err != nil {
+				return err
+			}
+		}
+
+		if err := s.routeRepo.Update(ctx, route); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, routeAggregate, route.ID, before, route)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return route, nil
+}
+
+// Delete - los traslados terminados y los puntos GPS conservan el recorrido sin la ruta.
+// La fila queda bloqueada para que no entre un traslado entre la comprobación y el borrado
+func (s *routeService) Delete(ctx context.Context, id uint) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		route, err := s.routeRepo.FindByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		inUse, err := s.transferRepo.ExistsPendingByRoute(ctx, id)
+		if err != nil {
+			return err
+		}
+		if inUse {
+			return sharedDomain.Invariant("route_in_use", "cannot delete route with pending transfers")
+		}
+
+		if err := s.routeRepo.Delete(ctx, id); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionDelete, routeAggregate, id, route, nil)
+	})
+}
+
+func (s *routeService) List(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.Route], error) {
+	return s.routeRepo.FindAll(ctx, q.Normalize(50, 100))
+}
+
+func (s *routeService) Deactivate(ctx context.Context, id uint, version *uint) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		route, err := s.routeRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := sharedDomain.CheckVersion(version, route.Version); err != nil {
+			return err
+		}
+		before := *route
+
+		route.Deactivate()
+		if err := s.routeRepo.Update(ctx, route); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, routeAggregate, id, before, route)
+	})
+}
+
+func (s *routeService) Activate(ctx context.Context, id uint, version *uint) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		route, err := s.routeRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := sharedDomain.CheckVersion(version, route.Version); err != nil {
+			return err
+		}
+		before := *route
+
+		route.Activate()
+		if err := s.routeRepo.Update(ctx, route); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, routeAggregate, id, before, route)
+	})
+}
+
+func (s *routeService) GetProgress(ctx context.Context, vehicleID uint) (*domain.RouteProgress, error) {
+	transfer, err := s.transferRepo.FindPendingByVehicleID(ctx, vehicleID)
+	if err != nil {
+		if sharedDomain.IsNotFound(err) {
+			return nil, sharedDomain.Invariant("vehicle_not_in_transit", "vehicle has no pending transfer")
+		}
+		return nil, err
+	}
+
+	progress, err := s.progress(ctx, []*domain.VehicleTransfer{transfer})
+	if err != nil {
+		return nil, err
+	}
+	return progress[0], nil
+}
+
+func (s *routeService) ListInbound(ctx context.Context, locationID uint) ([]*domain.RouteProgress, error) {
+	exists, err := s.locationRepo.Exists(ctx, locationID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, sharedDomain.NotFound("location")
+	}
+
+	transfers, err := s.transferRepo.FindPendingByDestination(ctx, locationID)
+	if err != nil {
+		return nil, err
+	}
+	return s.progress(ctx, transfers)
+}
+
+// progress - rutas y posiciones se leen en bloque; las ubicaciones se repiten mucho entre
+// traslados y se cachean
+func (s *routeService) progress(ctx context.Context, transfers []*domain.VehicleTransfer) ([]*domain.RouteProgress, error) {
+	var routeIDs, vehicleIDs []uint
+	for _, t := range transfers {
+		vehicleIDs = append(vehicleIDs, t.VehicleID)
+		if t.RouteID != nil {
+			routeIDs = append(routeIDs, *t.RouteID)
+		}
+	}
+
+	routes, err := s.routeRepo.FindByIDs(ctx, routeIDs)
+	if err != nil {
+		return nil, err
+	}
+	positions, err := s.trackingRepo.FindLatestByVehicleIDs(ctx, vehicleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	locations := make(map[uint]*domain.Location)
+	location := func(id uint) (*domain.Location, error) {
+		if l, ok := locations[id]; ok {
+			return l, nil
+		}
+		l, err := s.locationRepo.FindByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		locations[id] = l
+		return l, nil
+	}
+
+	progress := make([]*domain.RouteProgress, len(transfers))
+	for i, t := range transfers {
+		// Un punto anterior a la salida es de otro viaje
+		position := positions[t.VehicleID]
+		if position != nil && position.RecordedAt.Before(t.CreatedAt) {
+			position = nil
+		}
+
+		var route *domain.Route
+		var path []sharedDomain.GeoPoint
+		if t.RouteID != nil {
+			route = routes[*t.RouteID]
+		}
+		if route != nil {
+			from, err := location(route.FromLocationID)
+			if err != nil {
+				return nil, err
+			}
+			to, err := location(route.ToLocationID)
+			if err != nil {
+				return nil, err
+			}
+			path = route.Path(from, to)
+		}
+
+		progress[i] = domain.NewRouteProgress(t, route, path, position, s.offRouteKM)
+	}
+	return progress, nil
+}
@@ -0,0 +1,307 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	auditDomain "torque-dms/core/audit/domain"
+	auditInput "torque-dms/core/audit/ports/input"
+	"torque-dms/core/inventory/domain"
+	"torque-dms/core/inventory/ports/input"
+	"torque-dms/core/inventory/ports/output"
+	sharedDomain "torque-dms/core/shared/domain"
+	sharedOutput "torque-dms/core/shared/ports/output"
+)
+
+// Ventana del recorrido cuando el cliente no indica fechas
+const defaultTrackWindow = 24 * time.Hour
+
+type trackingService struct {
+	vehicleRepo     output.VehicleRepository
+	trackingRepo    output.VehicleTrackingRepository
+	routeRepo       output.RouteRepository
+	transferRepo    output.VehicleTransferRepository
+	retention       *domain.TrackingRetention
+	geofenceService input.GeofenceService
+	auditService    auditInput.AuditService
+	uow             sharedOutput.UnitOfWork
+}
+
+func NewTrackingService(
+	vehicleRepo output.VehicleRepository,
+	trackingRepo output.VehicleTrackingRepository,
+	routeRepo output.RouteRepository,
+	transferRepo output.VehicleTransferRepository,
+	retention *domain.TrackingRetention,
+	geofenceService input.GeofenceService,
+	auditService auditInput.AuditService,
+	uow sharedOutput.UnitOfWork,
+) input.TrackingService {
+	return &trackingService{
+		vehicleRepo:     vehicleRepo,
+		trackingRepo:    trackingRepo,
+		routeRepo:       routeRepo,
+		transferRepo:    transferRepo,
+		retention:       retention,
+		geofenceService: geofenceService,
+		auditService:    auditService,
+		uow:             uow,
+	}
+}
+
+// Ingest - los pings no se auditan, el volumen lo haría inservible. Un ping inválido se
+// rechaza sin tumbar el lote; los ya recibidos cuentan como duplicados. Los cruces de
+// geocerca sí dejan auditoría a través de los movimientos que provocan
+func (s *trackingService) Ingest(ctx context.Context, pings []domain.Ping) (*domain.IngestResult, error) {
+	if len(pings) == 0 {
+		return nil, sharedDomain.Invalid("pings", "pings are required")
+	}
+	if len(pings) > domain.MaxPingBatch {
+		return nil, sharedDomain.Invalid("pings", fmt.Sprintf("at most %d pings per batch", domain.MaxPingBatch))
+	}
+
+	var vins, deviceIDs []string
+	var routeIDs []uint
+	for i := range pings {
+		pings[i].VIN = domain.NormalizeVIN(pings[i].VIN)
+		pings[i].DeviceID = domain.NormalizeDeviceID(pings[i].DeviceID)
+		if pings[i].VIN != "" {
+			vins = append(vins, pings[i].VIN)
+		} else if pings[i].DeviceID != "" {
+			deviceIDs = append(deviceIDs, pings[i].DeviceID)
+		}
+		if pings[i].RouteID != nil {
+			routeIDs = append(routeIDs, *pings[i].RouteID)
+		}
+	}
+
+	byVIN, err := s.vehicleRepo.FindIDsByVIN(ctx, vins)
+	if err != nil {
+		return nil, err
+	}
+	byDevice, err := s.vehicleRepo.FindIDsByTrackingDevice(ctx, deviceIDs)
+	if err != nil {
+		return nil, err
+	}
+	routes, err := s.routeRepo.ExistingIDs(ctx, routeIDs)
+	if err != nil {
+		return nil, err
+	}
+	transferRoutes, err := s.pendingRoutes(ctx, byVIN, byDevice)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.IngestResult{}
+	reject := func(index int, reason string) {
+		result.Rejected = append(result.Rejected, domain.PingRejection{Index: index, Reason: reason})
+	}
+
+	now := time.Now()
+	points := make([]*domain.TrackingPoint, 0, len(pings))
+	// El mismo punto repetido dentro del lote también es un duplicado
+	seen := make(map[uint]map[time.Time]bool)
+	for i, ping := range pings {
+		var vehicleID uint
+		switch {
+		case ping.VIN != "":
+			vehicleID = byVIN[ping.VIN]
+		case ping.DeviceID != "":
+			vehicleID = byDevice[ping.DeviceID]
+		default:
+			reject(i, "vin or device_id is required")
+			continue
+		}
+		if vehicleID == 0 {
+			reject(i, "unknown vehicle")
+			continue
+		}
+		if ping.RouteID != nil && !routes[*ping.RouteID] {
+			reject(i, "unknown route")
+			continue
+		}
+
+		// Sin ruta explícita el punto se asocia a la del traslado en curso
+		routeID := ping.RouteID
+		if routeID == nil {
+			routeID = transferRoutes[vehicleID]
+		}
+
+		point, err := domain.NewTrackingPoint(vehicleID, ping.Latitude, ping.Longitude, routeID, ping.RecordedAt, now)
+		if err != nil {
+			reject(i, err.Error())
+			continue
+		}
+
+		if seen[vehicleID] == nil {
+			seen[vehicleID] = make(map[time.Time]bool)
+		}
+		if seen[vehicleID][point.RecordedAt] {
+			result.Duplicates++
+			continue
+		}
+		seen[vehicleID][point.RecordedAt] = true
+		points = append(points, point)
+	}
+
+	// Los puntos y los movimientos por geocerca van juntos: si algo falla el dispositivo
+	// reenvía el lote y los cruces se vuelven a detectar
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		previous, err := s.trackingRepo.FindLatestByVehicleIDs(ctx, vehicleIDsOf(points))
+		if err != nil {
+			return err
+		}
+
+		inserted, err := s.trackingRepo.SaveBatch(ctx, points)
+		if err != nil {
+			return err
+		}
+		result.Accepted = inserted
+		result.Duplicates += len(points) - inserted
+
+		crossings, err := s.geofenceService.Process(ctx, previous, points)
+		if err != nil {
+			return err
+		}
+		result.Crossings = len(crossings)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func vehicleIDsOf(points []*domain.TrackingPoint) []uint {
+	seen := make(map[uint]bool)
+	var ids []uint
+	for _, p := range points {
+		if !seen[p.VehicleID] {
+			seen[p.VehicleID] = true
+			ids = append(ids, p.VehicleID)
+		}
+	}
+	return ids
+}
+
+// pendingRoutes - ruta del traslado pendiente de cada vehicle del lote que la tenga
+func (s *trackingService) pendingRoutes(ctx context.Context, idMaps ...map[string]uint) (map[uint]*uint, error) {
+	var vehicleIDs []uint
+	for _, ids := range idMaps {
+		for _, id := range ids {
+			vehicleIDs = append(vehicleIDs, id)
+		}
+	}
+
+	transfers, err := s.transferRepo.FindPendingByVehicleIDs(ctx, vehicleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	routes := make(map[uint]*uint, len(transfers))
+	for _, t := range transfers {
+		if t.RouteID != nil {
+			routes[t.VehicleID] = t.RouteID
+		}
+	}
+	return routes, nil
+}
+
+func (s *trackingService) AssignDevice(ctx context.Context, vehicleID uint, deviceID string) (*domain.Vehicle, error) {
+	var vehicle *domain.Vehicle
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		vehicle, err = s.vehicleRepo.FindByID(ctx, vehicleID)
+		if err != nil {
+			return err
+		}
+		before := *vehicle
+
+		vehicle.AssignTrackingDevice(deviceID)
+		if vehicle.TrackingDeviceID != nil {
+			other, err := s.vehicleRepo.FindByTrackingDevice(ctx, *vehicle.TrackingDeviceID)
+			if err != nil && !sharedDomain.IsNotFound(err) {
+				return err
+			}
+			if other != nil && other.ID != vehicle.ID {
+				return sharedDomain.Conflict("device_taken", "tracking device is assigned to another vehicle")
+			}
+		}
+
+		// Dos asignaciones simultáneas pasan las dos la búsqueda; uq_vehicles_tracking_device
+		// rechaza la segunda y es el único índice único que toca este cambio
+		if err := s.vehicleRepo.Update(ctx, vehicle); err != nil {
+			if errors.Is(err, sharedDomain.ErrDuplicateKey) {
+				return sharedDomain.Conflict("device_taken", "tracking device is assigned to another vehicle")
+			}
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, vehicleAggregate, vehicle.ID, before, vehicle)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return vehicle, nil
+}
+
+func (s *trackingService) GetLastPosition(ctx context.Context, vehicleID uint) (*domain.TrackingPoint, error) {
+	if err := s.ensureVehicle(ctx, vehicleID); err != nil {
+		return nil, err
+	}
+	return s.trackingRepo.FindLatest(ctx, vehicleID)
+}
+
+func (s *trackingService) GetTrack(ctx context.Context, vehicleID uint, from time.Time, to time.Time, q sharedDomain.Query) (*sharedDomain.Page[*domain.TrackingPoint], error) {
+	if to.IsZero() {
+		to = time.Now()
+	}
+	if from.IsZero() {
+		from = to.Add(-defaultTrackWindow)
+	}
+	if from.After(to) {
+		return nil, sharedDomain.Invalid("from", "from must be before to")
+	}
+
+	if err := s.ensureVehicle(ctx, vehicleID); err != nil {
+		return nil, err
+	}
+	return s.trackingRepo.FindTrack(ctx, vehicleID, from, to, q.Normalize(500, 5000))
+}
+
+// Compact - reduce y borra los puntos antiguos según la política de retención
+func (s *trackingService) Compact(ctx context.Context, now time.Time) (int, error) {
+	removed := 0
+	var errs []error
+
+	if before, ok := s.retention.DeleteBefore(now); ok {
+		n, err := s.trackingRepo.DeleteBefore(ctx, before)
+		removed += n
+		if err != nil {
+			errs = append(errs, fmt.Errorf("delete: %w", err))
+		}
+	}
+
+	if before, ok := s.retention.DownsampleBefore(now); ok {
+		n, err := s.trackingRepo.Downsample(ctx, before, s.retention.DownsampleInterval)
+		removed += n
+		if err != nil {
+			errs = append(errs, fmt.Errorf("downsample: %w", err))
+		}
+	}
+
+	return removed, errors.Join(errs...)
+}
+
+func (s *trackingService) ensureVehicle(ctx context.Context, vehicleID uint) error {
+	exists, err := s.vehicleRepo.Exists(ctx, vehicleID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return sharedDomain.NotFound("vehicle")
+	}
+	return nil
+}
@@ -1,50 +1,140 @@
 package services
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
+	auditDomain "torque-dms/core/audit/domain"
+	auditInput "torque-dms/core/audit/ports/input"
 	"torque-dms/core/inventory/domain"
 	"torque-dms/core/inventory/ports/input"
 	"torque-dms/core/inventory/ports/output"
+	salesOutput "torque-dms/core/sales/ports/output"
+	sharedDomain "torque-dms/core/shared/domain"
+	sharedOutput "torque-dms/core/shared/ports/output"
 )
 
 type vehicleService struct {
-	vehicleRepo output.VehicleRepository
-	photoRepo   output.VehiclePhotoRepository
-	locationRepo output.LocationRepository
+	vehicleRepo       output.VehicleRepository
+	photoRepo         output.VehiclePhotoRepository
+	locationRepo      output.LocationRepository
+	historyRepo       output.VehicleLocationHistoryRepository
+	statusHistoryRepo output.VehicleStatusHistoryRepository
+	priceRepo         output.PriceChangeRepository
+	transferRepo      output.VehicleTransferRepository
+	routeRepo         output.RouteRepository
+	model3DRepo       output.VehicleModel3DRepository
+	inspectionRepo    output.InspectionRepository
+	reconRepo         output.ReconOrderRepository
+	leadRepo          salesOutput.LeadRepository
+	vinDecoder        output.VINDecoder
+	reconPolicy       *domain.ReconPolicy
+	geoService        input.GeoService
+	auditService      auditInput.AuditService
+	uow               sharedOutput.UnitOfWork
 }
 
 func NewVehicleService(
 	vehicleRepo output.VehicleRepository,
 	photoRepo output.VehiclePhotoRepository,
 	locationRepo output.LocationRepository,
+	historyRepo output.VehicleLocationHistoryRepository,
+	statusHistoryRepo output.VehicleStatusHistoryRepository,
+	priceRepo output.PriceChangeRepository,
+	transferRepo output.VehicleTransferRepository,
+	routeRepo output.RouteRepository,
+	model3DRepo output.VehicleModel3DRepository,
+	inspectionRepo output.InspectionRepository,
+	reconRepo output.ReconOrderRepository,
+	leadRepo salesOutput.LeadRepository,
+	vinDecoder output.VINDecoder,
+	reconPolicy *domain.ReconPolicy,
+	geoService input.GeoService,
+	auditService auditInput.AuditService,
+	uow sharedOutput.UnitOfWork,
 ) input.VehicleService {
 	return &vehicleService{
-		vehicleRepo:  vehicleRepo,
-		photoRepo:    photoRepo,
-		locationRepo: locationRepo,
+		vehicleRepo:       vehicleRepo,
+		photoRepo:         photoRepo,
+		locationRepo:      locationRepo,
+		historyRepo:       historyRepo,
+		statusHistoryRepo: statusHistoryRepo,
+		priceRepo:         priceRepo,
+		transferRepo:      transferRepo,
+		routeRepo:         routeRepo,
+		model3DRepo:       model3DRepo,
+		inspectionRepo:    inspectionRepo,
+		reconRepo:         reconRepo,
+		leadRepo:          leadRepo,
+		vinDecoder:        vinDecoder,
+		reconPolicy:       reconPolicy,
+		geoService:        geoService,
+		auditService:      auditService,
+		uow:               uow,
 	}
 }
 
-func (s *vehicleService) Create(inp input.CreateVehicleInput) (*domain.Vehicle, error) {
+func (s *vehicleService) Create(ctx context.Context, inp input.CreateVehicleInput) (*domain.Vehicle, error) {
+	inp.VIN = domain.NormalizeVIN(inp.VIN)
+	if err := domain.ValidateVIN(inp.VIN); err != nil {
+		return nil, err
+	}
+
 	// Verificar que VIN no exista
-	exists, err := s.vehicleRepo.ExistsByVIN(inp.VIN)
+	exists, err := s.vehicleRepo.ExistsByVIN(ctx, inp.VIN)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, sharedDomain.Conflict("vin_taken", "vehicle with this VIN already exists")
+	}
+
+	exists, err = s.vehicleRepo.ExistsByStockNumber(ctx, inp.StockNumber)
 	if err != nil {
 		return nil, err
 	}
 	if exists {
-		return nil, errors.New("vehicle with this VIN already exists")
+		return nil, sharedDomain.Conflict("stock_number_taken", "vehicle with this stock number already exists")
 	}
 
 	// Verificar que location exista
 	if inp.LocationID != 0 {
-		locationExists, err := s.locationRepo.Exists(inp.LocationID)
+		locationExists, err := s.locationRepo.Exists(ctx, inp.LocationID)
 		if err != nil {
 			return nil, err
 		}
 		if !locationExists {
-			return nil, errors.New("location not found")
+			return nil, sharedDomain.NotFound("location")
+		}
+	}
+
+	// La carrocería indicada por el usuario debe ser válida; la del dataset de VINs se ignora
+	// si no es una de las conocidas
+	bodyType := domain.NormalizeBodyType(inp.BodyType)
+	if bodyType != "" && !bodyType.IsValid() {
+		return nil, sharedDomain.Invalid("body_type", "invalid body type")
+	}
+
+	// Completar con el VIN lo que el usuario no haya escrito
+	if inp.Make == "" || inp.Model == "" || inp.Year == 0 || bodyType == "" {
+		decoded, err := s.vinDecoder.Decode(ctx, inp.VIN)
+		if err != nil {
+			return nil, err
+		}
+		if inp.Make == "" {
+			inp.Make = decoded.Make
+		}
+		if inp.Model == "" {
+			inp.Model = decoded.Model
+		}
+		if inp.Year == 0 {
+			inp.Year = decoded.ModelYear
+		}
+		if bodyType == "" {
+			bodyType = domain.NormalizeBodyType(decoded.BodyType)
 		}
 	}
 
@@ -71,7 +161,9 @@ func (s *vehicleService) Create(inp input.CreateVehicleInput) (*domain.Vehicle,
 
 	// Setear condición
 	if inp.Condition != "" {
-		vehicle.SetCondition(domain.VehicleCondition(inp.Condition))
+		if err := vehicle.SetCondition(domain.VehicleCondition(inp.Condition)); err != nil {
+			return nil, err
+		}
 	}
 
 	// Setear adquisición
@@ -85,270 +177,875 @@ func (s *vehicleService) Create(inp input.CreateVehicleInput) (*domain.Vehicle,
 		}
 	}
 
+	// Modelo 3D predeterminado de la carrocería para el visor de inspección
+	if bodyType.IsValid() {
+		model, err := s.model3DRepo.FindDefault(ctx, bodyType)
+		if err != nil && !sharedDomain.IsNotFound(err) {
+			return nil, err
+		}
+		if model != nil {
+			if err := vehicle.AssignModel3D(model); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	// Guardar
-	if err := s.vehicleRepo.Save(vehicle); err != nil {
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.vehicleRepo.Save(ctx, vehicle); err != nil {
+			return err
+		}
+		return s.auditService.Record(ctx, auditDomain.ActionCreate, vehicleAggregate, vehicle.ID, nil, vehicle)
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return vehicle, nil
 }
 
-func (s *vehicleService) GetByID(id uint) (*domain.Vehicle, error) {
-	return s.vehicleRepo.FindByID(id)
+func (s *vehicleService) GetByID(ctx context.Context, id uint) (*domain.Vehicle, error) {
+	return s.vehicleRepo.FindByID(ctx, id)
 }
 
-func (s *vehicleService) GetByVIN(vin string) (*domain.Vehicle, error) {
-	return s.vehicleRepo.FindByVIN(vin)
+func (s *vehicleService) DecodeVIN(ctx context.Context, vin string) (*domain.VINDecode, error) {
+	return s.vinDecoder.Decode(ctx, vin)
 }
 
-func (s *vehicleService) Update(id uint, inp input.UpdateVehicleInput) (*domain.Vehicle, error) {
-	vehicle, err := s.vehicleRepo.FindByID(id)
+func (s *vehicleService) GetByVIN(ctx context.Context, vin string) (*domain.Vehicle, error) {
+	return s.vehicleRepo.FindByVIN(ctx, domain.NormalizeVIN(vin))
+}
+
+func (s *vehicleService) Update(ctx context.Context, id uint, inp input.UpdateVehicleInput) (*domain.Vehicle, error) {
+	var vehicle *domain.Vehicle
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		vehicle, err = s.vehicleRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := sharedDomain.CheckVersion(inp.Version, vehicle.Version); err != nil {
+			return err
+		}
+
+		if vehicle.IsSold() {
+			return sharedDomain.Invariant("vehicle_sold", "cannot update sold vehicle")
+		}
+		before := *vehicle
+
+		// Actualizar campos
+		if inp.Plate != nil {
+			vehicle.Plate = *inp.Plate
+		}
+		if inp.Trim != nil {
+			vehicle.Trim = *inp.Trim
+		}
+		if inp.Mileage != nil {
+			vehicle.Mileage = *inp.Mileage
+		}
+		if inp.ExteriorColor != nil {
+			vehicle.ExteriorColor = *inp.ExteriorColor
+		}
+		if inp.InteriorColor != nil {
+			vehicle.InteriorColor = *inp.InteriorColor
+		}
+
+		// Actualizar precios
+		msrp := vehicle.MSRP
+		invoicePrice := vehicle.InvoicePrice
+		askingPrice := vehicle.AskingPrice
+
+		if inp.MSRP != nil {
+			msrp = *inp.MSRP
+		}
+		if inp.InvoicePrice != nil {
+			invoicePrice = *inp.InvoicePrice
+		}
+		if inp.AskingPrice != nil {
+			askingPrice = *inp.AskingPrice
+		}
+
+		if err := vehicle.SetPricing(msrp, invoicePrice, askingPrice); err != nil {
+			return err
+		}
+
+		vehicle.ModifiedAt = time.Now()
+
+		if err := s.vehicleRepo.Update(ctx, vehicle); err != nil {
+			return err
+		}
+
+		// Los cambios manuales del precio publicado quedan en el mismo historial que las rebajas automáticas
+		if vehicle.AskingPrice != before.AskingPrice {
+			change := domain.NewManualPriceChange(vehicle.ID, before.AskingPrice, vehicle.AskingPrice, sharedDomain.ActorFromContext(ctx).EntityID)
+			if err := s.priceRepo.Save(ctx, change); err != nil {
+				return err
+			}
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, vehicleAggregate, vehicle.ID, before, vehicle)
+	})
 	if err != nil {
-		return nil, errors.New("vehicle not found")
+		return nil, err
 	}
+	return vehicle, nil
+}
 
-	if vehicle.IsSold() {
-		return nil, errors.New("cannot update sold vehicle")
-	}
+func (s *vehicleService) Delete(ctx context.Context, id uint) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		vehicle, err := s.vehicleRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
 
-	// Actualizar campos
-	if inp.Plate != nil {
-		vehicle.Plate = *inp.Plate
-	}
-	if inp.Trim != nil {
-		vehicle.Trim = *inp.Trim
+		if vehicle.IsSold() {
+			return sharedDomain.Invariant("vehicle_sold", "cannot delete sold vehicle")
+		}
+		before := *vehicle
+
+		vehicle.SoftDelete(sharedDomain.ActorFromContext(ctx).EntityID)
+		if err := s.vehicleRepo.Update(ctx, vehicle); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionDelete, vehicleAggregate, id, before, nil)
+	})
+}
+
+func (s *vehicleService) List(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.Vehicle], error) {
+	q = q.Normalize(10, 100)
+	return s.vehicleRepo.FindAll(ctx, q)
+}
+
+func (s *vehicleService) ListAvailable(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.Vehicle], error) {
+	q = q.Normalize(10, 100)
+	return s.vehicleRepo.FindAll(ctx, q.Where("status", sharedDomain.FilterEq, string(domain.VehicleStatusReadyForSale)))
+}
+
+func (s *vehicleService) ListByStatus(ctx context.Context, status string, q sharedDomain.Query) (*sharedDomain.Page[*domain.Vehicle], error) {
+	q = q.Normalize(10, 100)
+	return s.vehicleRepo.FindAll(ctx, q.Where("status", sharedDomain.FilterEq, status))
+}
+
+func (s *vehicleService) ListByLocation(ctx context.Context, locationID uint) ([]*domain.Vehicle, error) {
+	return s.vehicleRepo.FindByLocationID(ctx, locationID)
+}
+
+func (s *vehicleService) Search(ctx context.Context, criteria domain.VehicleSearch, q sharedDomain.Query) (*domain.VehicleSearchResult, error) {
+	if err := criteria.Validate(); err != nil {
+		return nil, err
 	}
-	if inp.Mileage != nil {
-		vehicle.Mileage = *inp.Mileage
+	q = q.Normalize(20, 100)
+
+	// La distancia se mide hasta la ubicación donde está aparcado cada vehicle
+	var distances map[uint]float64
+	if criteria.Near != nil {
+		origin, err := s.geoService.ResolveOrigin(ctx, *criteria.Near)
+		if err != nil {
+			return nil, err
+		}
+		nearby, err := s.geoService.LocationsWithin(ctx, origin, *criteria.RadiusKM)
+		if err != nil {
+			return nil, err
+		}
+
+		distances = make(map[uint]float64, len(nearby))
+		ids := make([]uint, len(nearby))
+		for i, d := range nearby {
+			distances[d.Location.ID] = d.DistanceKM
+			ids[i] = d.Location.ID
+		}
+		if !criteria.RestrictToLocations(ids) {
+			return &domain.VehicleSearchResult{
+				Vehicles:  &sharedDomain.Page[*domain.Vehicle]{Items: []*domain.Vehicle{}, Limit: q.Limit, Offset: q.Offset},
+				Facets:    &domain.VehicleFacets{},
+				Distances: distances,
+			}, nil
+		}
 	}
-	if inp.ExteriorColor != nil {
-		vehicle.ExteriorColor = *inp.ExteriorColor
+
+	vehicles, err := s.vehicleRepo.Search(ctx, criteria, q)
+	if err != nil {
+		return nil, err
 	}
-	if inp.InteriorColor != nil {
-		vehicle.InteriorColor = *inp.InteriorColor
+
+	facets, err := s.vehicleRepo.Facets(ctx, criteria)
+	if err != nil {
+		return nil, err
 	}
 
-	// Actualizar precios
-	msrp := vehicle.MSRP
-	invoicePrice := vehicle.InvoicePrice
-	askingPrice := vehicle.AskingPrice
+	return &domain.VehicleSearchResult{Vehicles: vehicles, Facets: facets, Distances: distances}, nil
+}
+
+// Status changes
+
+func (s *vehicleService) MarkAsSold(ctx context.Context, id uint, inp input.MarkAsSoldInput) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		// El lead del comprador es el que queda bajo retención legal: tiene que ser de este vehicle
+		if inp.LeadID != nil {
+			lead, err := s.leadRepo.FindByID(ctx, *inp.LeadID)
+			if err != nil {
+				return err
+			}
+			if !lead.HasVehicleInterest() || *lead.VehicleID != id {
+				return sharedDomain.Invariant("lead_vehicle_mismatch", "lead is not for this vehicle")
+			}
+		}
+
+		_, err := s.changeStatus(ctx, id, domain.VehicleStatusSold, "", func(v *domain.Vehicle) error {
+			if err := v.MarkAsSold(); err != nil {
+				return err
+			}
+			if inp.SalePrice != nil {
+				if err := v.SetSalePrice(*inp.SalePrice); err != nil {
+					return err
+				}
+			}
+			if inp.LeadID != nil {
+				return v.SetSoldLead(*inp.LeadID)
+			}
+			return nil
+		})
+		return err
+	})
+}
+
+func (s *vehicleService) MarkAsReadyForSale(ctx context.Context, id uint) error {
+	_, err := s.changeStatus(ctx, id, domain.VehicleStatusReadyForSale, "", (*domain.Vehicle).MarkAsReadyForSale)
+	return err
+}
+
+func (s *vehicleService) SendToRecon(ctx context.Context, id uint) error {
+	_, err := s.changeStatus(ctx, id, domain.VehicleStatusInRecon, "", (*domain.Vehicle).SendToRecon)
+	return err
+}
 
-	if inp.MSRP != nil {
-		msrp = *inp.MSRP
+func (s *vehicleService) ChangeStatus(ctx context.Context, id uint, status string, reason string) (*domain.Vehicle, error) {
+	to := domain.VehicleStatus(status)
+	if to == domain.VehicleStatusInTransit {
+		return nil, sharedDomain.Invalid("status", "use a transfer to move a vehicle into transit")
 	}
-	if inp.InvoicePrice != nil {
-		invoicePrice = *inp.InvoicePrice
+	return s.changeStatus(ctx, id, to, reason, func(v *domain.Vehicle) error {
+		// Deshacer una venta borra el precio y el comprador: va por UnwindSale, que exige motivo
+		if domain.IsSaleUnwind(v.Status, to) {
+			return sharedDomain.Invariant("sale_unwind_required", "use /vehicles/:id/unwind-sale to reverse a sale")
+		}
+		return v.TransitionTo(to)
+	})
+}
+
+func (s *vehicleService) UnwindSale(ctx context.Context, id uint, status string, reason string) (*domain.Vehicle, error) {
+	if strings.TrimSpace(reason) == "" {
+		return nil, sharedDomain.Invalid("reason", "reason is required to unwind a sale")
 	}
-	if inp.AskingPrice != nil {
-		askingPrice = *inp.AskingPrice
+	to := domain.VehicleStatus(status)
+	if to == "" {
+		to = domain.VehicleStatusReadyForSale
 	}
+	return s.changeStatus(ctx, id, to, reason, func(v *domain.Vehicle) error {
+		return v.UnwindSale(to)
+	})
+}
 
-	if err := vehicle.SetPricing(msrp, invoicePrice, askingPrice); err != nil {
+func (s *vehicleService) GetStatusHistory(ctx context.Context, vehicleID uint, q sharedDomain.Query) (*sharedDomain.Page[*domain.VehicleStatusHistory], error) {
+	if _, err := s.vehicleRepo.FindByID(ctx, vehicleID); err != nil {
 		return nil, err
 	}
 
-	vehicle.ModifiedAt = time.Now()
+	q = q.Normalize(20, 100)
+	return s.statusHistoryRepo.FindByVehicleID(ctx, vehicleID, q)
+}
+
+// changeStatus - punto común de los cambios de estado manuales: las reglas que dependen de
+// otros agregados (inspección CPO, recon), el historial y la auditoría van en la misma transacción
+func (s *vehicleService) changeStatus(ctx context.Context, id uint, to domain.VehicleStatus, reason string, apply func(*domain.Vehicle) error) (*domain.Vehicle, error) {
+	var vehicle *domain.Vehicle
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		vehicle, err = s.vehicleRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		before := *vehicle
+
+		// Mandar a recon un vehicle que ya está en recon solo asegura su orden abierta
+		if to == domain.VehicleStatusInRecon && vehicle.Status == domain.VehicleStatusInRecon {
+			return s.openRecon(ctx, vehicle)
+		}
+
+		if err := apply(vehicle); err != nil {
+			return err
+		}
+
+		switch to {
+		case domain.VehicleStatusReadyForSale:
+			if err := s.checkCPO(ctx, vehicle); err != nil {
+				return err
+			}
+			if err := s.releaseRecon(ctx, id); err != nil {
+				return err
+			}
+		case domain.VehicleStatusInRecon:
+			if err := s.openRecon(ctx, vehicle); err != nil {
+				return err
+			}
+		}
+
+		if err := s.vehicleRepo.Update(ctx, vehicle); err != nil {
+			return err
+		}
+
+		if err := s.recordStatusChange(ctx, id, before.Status, vehicle.Status, reason); err != nil {
+			return err
+		}
 
-	if err := s.vehicleRepo.Update(vehicle); err != nil {
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, vehicleAggregate, id, before, vehicle)
+	})
+	if err != nil {
 		return nil, err
 	}
-
 	return vehicle, nil
 }
 
-func (s *vehicleService) Delete(id uint) error {
-	vehicle, err := s.vehicleRepo.FindByID(id)
-	if err != nil {
-		return errors.New("vehicle not found")
+// openRecon - abre la orden de recon del vehicle si no tiene una abierta; el ciclo de
+// recon se mide desde aquí
+func (s *vehicleService) openRecon(ctx context.Context, vehicle *domain.Vehicle) error {
+	if _, err := s.reconRepo.FindOpenByVehicleID(ctx, vehicle.ID); !sharedDomain.IsNotFound(err) {
+		return err
 	}
 
-	if vehicle.IsSold() {
-		return errors.New("cannot delete sold vehicle")
+	order, err := domain.NewReconOrder(vehicle, sharedDomain.ActorFromContext(ctx).EntityID, "")
+	if err != nil {
+		return err
 	}
-
-	// Eliminar fotos primero
-	if err := s.photoRepo.DeleteByVehicleID(id); err != nil {
+	if err := s.reconRepo.Save(ctx, order); err != nil {
 		return err
 	}
 
-	return s.vehicleRepo.Delete(id)
+	return s.auditService.Record(ctx, auditDomain.ActionCreate, reconOrderAggregate, order.ID, nil, order)
 }
 
-func (s *vehicleService) List(limit int, offset int) ([]*domain.Vehicle, error) {
-	if limit <= 0 {
-		limit = 10
+// releaseRecon - cierra la orden de recon abierta, o la deja abierta si aún tiene tareas
+// y la política lo permite
+func (s *vehicleService) releaseRecon(ctx context.Context, vehicleID uint) error {
+	order, err := s.reconRepo.FindOpenByVehicleID(ctx, vehicleID)
+	if sharedDomain.IsNotFound(err) {
+		return nil
 	}
-	if limit > 100 {
-		limit = 100
+	if err != nil {
+		return err
+	}
+	before := *order
+
+	closed, err := order.ReleaseForSale(s.reconPolicy)
+	if err != nil || !closed {
+		return err
 	}
-	return s.vehicleRepo.FindAll(limit, offset)
-}
 
-func (s *vehicleService) ListAvailable(limit int, offset int) ([]*domain.Vehicle, error) {
-	if limit <= 0 {
-		limit = 10
+	if err := s.reconRepo.Update(ctx, order); err != nil {
+		return err
 	}
-	if limit > 100 {
-		limit = 100
+
+	return s.auditService.Record(ctx, auditDomain.ActionUpdate, reconOrderAggregate, order.ID, before, order)
+}
+
+// recordStatusChange - no deja rastro si el estado no cambió (p. ej. un traslado de un
+// vehicle que ya venía en tránsito)
+func (s *vehicleService) recordStatusChange(ctx context.Context, vehicleID uint, from domain.VehicleStatus, to domain.VehicleStatus, reason string) error {
+	if from == to {
+		return nil
 	}
-	return s.vehicleRepo.FindAvailable(limit, offset)
+	history := domain.NewVehicleStatusHistory(vehicleID, from, to, sharedDomain.ActorFromContext(ctx).EntityID, reason)
+	return s.statusHistoryRepo.Save(ctx, history)
 }
 
-func (s *vehicleService) ListByStatus(status string, limit int, offset int) ([]*domain.Vehicle, error) {
-	if limit <= 0 {
-		limit = 10
+// checkCPO - el programa CPO exige la inspección del fabricante sin fallos en items obligatorios
+func (s *vehicleService) checkCPO(ctx context.Context, vehicle *domain.Vehicle) error {
+	if vehicle.LotType != domain.LotTypeCPO {
+		return nil
 	}
-	if limit > 100 {
-		limit = 100
+	latest, err := s.inspectionRepo.FindLatestCompleted(ctx, vehicle.ID, domain.InspectionKindCPO)
+	if err != nil && !sharedDomain.IsNotFound(err) {
+		return err
 	}
-	return s.vehicleRepo.FindByStatus(domain.VehicleStatus(status), limit, offset)
+	return domain.CheckCPOInspection(vehicle, latest)
 }
 
-func (s *vehicleService) ListByLocation(locationID uint) ([]*domain.Vehicle, error) {
-	return s.vehicleRepo.FindByLocationID(locationID)
+func (s *vehicleService) ChangeLocation(ctx context.Context, id uint, locationID uint, reason string) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		vehicle, err := s.vehicleRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if vehicle.LocationID == locationID {
+			return nil
+		}
+
+		if err := s.ensureNoPendingTransfer(ctx, id); err != nil {
+			return err
+		}
+
+		location, err := s.lockForArrival(ctx, locationID, 0)
+		if err != nil {
+			return err
+		}
+		if location.IsInTransit() {
+			return sharedDomain.Invalid("location_id", "use a transfer to move a vehicle into transit")
+		}
+
+		before := *vehicle
+		// Un vehicle recién adquirido entra a recon al llegar, igual que al cerrar un traslado
+		if vehicle.Status == domain.VehicleStatusInTransit {
+			if err := vehicle.Arrive(locationID, domain.VehicleStatusInRecon); err != nil {
+				return err
+			}
+		} else {
+			vehicle.SetLocation(locationID)
+		}
+		if err := s.vehicleRepo.Update(ctx, vehicle); err != nil {
+			return err
+		}
+
+		if err := s.recordMove(ctx, vehicle.ID, before.LocationID, locationID, reason); err != nil {
+			return err
+		}
+		if err := s.recordArrivalStatus(ctx, vehicle, before.Status, reason); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, vehicleAggregate, id, before, vehicle)
+	})
 }
 
-// Status changes
+// Transfers
+
+func (s *vehicleService) StartTransfer(ctx context.Context, inp input.StartTransferInput) (*domain.VehicleTransfer, error) {
+	var transfer *domain.VehicleTransfer
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		vehicle, err := s.vehicleRepo.FindByID(ctx, inp.VehicleID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.ensureNoPendingTransfer(ctx, vehicle.ID); err != nil {
+			return err
+		}
+
+		transitID, err := s.transitLocationID(ctx, inp.TransitLocationID)
+		if err != nil {
+			return err
+		}
+		transit, err := s.lockForArrival(ctx, transitID, 0)
+		if err != nil {
+			return err
+		}
+
+		transfer, err = domain.NewVehicleTransfer(vehicle, transit, inp.ToLocationID, sharedDomain.ActorFromContext(ctx).EntityID, inp.Reason)
+		if err != nil {
+			return err
+		}
+		if err := s.assignRoute(ctx, transfer, inp.RouteID); err != nil {
+			return err
+		}
+
+		// La plaza en destino se reserva ahora, no al llegar
+		destination, err := s.lockForArrival(ctx, inp.ToLocationID, 0)
+		if err != nil {
+			return err
+		}
+		if destination.IsInTransit() {
+			return sharedDomain.Invalid("to_location_id", "destination cannot be an in_transit location")
+		}
+
+		before := *vehicle
+		if err := vehicle.StartTransit(transit.ID); err != nil {
+			return err
+		}
+		if err := s.vehicleRepo.Update(ctx, vehicle); err != nil {
+			return err
+		}
 
-func (s *vehicleService) MarkAsSold(id uint) error {
-	vehicle, err := s.vehicleRepo.FindByID(id)
+		if err := s.transferRepo.Save(ctx, transfer); err != nil {
+			return err
+		}
+
+		if err := s.recordMove(ctx, vehicle.ID, before.LocationID, transit.ID, inp.Reason); err != nil {
+			return err
+		}
+		if err := s.recordStatusChange(ctx, vehicle.ID, before.Status, vehicle.Status, inp.Reason); err != nil {
+			return err
+		}
+
+		if err := s.auditService.Record(ctx, auditDomain.ActionCreate, vehicleTransferAggregate, transfer.ID, nil, transfer); err != nil {
+			return err
+		}
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, vehicleAggregate, vehicle.ID, before, vehicle)
+	})
 	if err != nil {
-		return errors.New("vehicle not found")
+		return nil, err
 	}
 
-	if err := vehicle.MarkAsSold(); err != nil {
-		return err
+	return transfer, nil
+}
+
+// assignRoute - con routeID 0 la ruta solo se asigna si no hay ambigüedad
+func (s *vehicleService) assignRoute(ctx context.Context, transfer *domain.VehicleTransfer, routeID uint) error {
+	if routeID != 0 {
+		route, err := s.routeRepo.FindByID(ctx, routeID)
+		if err != nil {
+			return err
+		}
+		return transfer.AssignRoute(route)
 	}
 
-	return s.vehicleRepo.Update(vehicle)
+	if transfer.FromLocationID == nil {
+		return nil
+	}
+	routes, err := s.routeRepo.FindActiveBetween(ctx, *transfer.FromLocationID, transfer.ToLocationID)
+	if err != nil {
+		return err
+	}
+	if len(routes) != 1 {
+		return nil
+	}
+	return transfer.AssignRoute(routes[0])
 }
 
-func (s *vehicleService) MarkAsReadyForSale(id uint) error {
-	vehicle, err := s.vehicleRepo.FindByID(id)
+func (s *vehicleService) ConfirmArrival(ctx context.Context, vehicleID uint) (*domain.VehicleTransfer, error) {
+	var transfer *domain.VehicleTransfer
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		transfer, err = s.transferRepo.FindPendingByVehicleID(ctx, vehicleID)
+		if err != nil {
+			return err
+		}
+
+		vehicle, err := s.vehicleRepo.FindByID(ctx, vehicleID)
+		if err != nil {
+			return err
+		}
+
+		// El propio traslado ya ocupa una de las plazas contadas
+		if _, err := s.lockForArrival(ctx, transfer.ToLocationID, 1); err != nil {
+			return err
+		}
+
+		transferBefore := *transfer
+		if err := transfer.Complete(sharedDomain.ActorFromContext(ctx).EntityID); err != nil {
+			return err
+		}
+
+		before := *vehicle
+		status, reason, err := s.arrivalStatus(ctx, vehicle, transfer)
+		if err != nil {
+			return err
+		}
+		if err := vehicle.Arrive(transfer.ToLocationID, status); err != nil {
+			return err
+		}
+		if err := s.vehicleRepo.Update(ctx, vehicle); err != nil {
+			return err
+		}
+
+		if err := s.transferRepo.Update(ctx, transfer); err != nil {
+			return err
+		}
+
+		if err := s.recordMove(ctx, vehicle.ID, before.LocationID, transfer.ToLocationID, transfer.Reason); err != nil {
+			return err
+		}
+		if err := s.recordArrivalStatus(ctx, vehicle, before.Status, reason); err != nil {
+			return err
+		}
+
+		if err := s.auditService.Record(ctx, auditDomain.ActionUpdate, vehicleTransferAggregate, transfer.ID, transferBefore, transfer); err != nil {
+			return err
+		}
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, vehicleAggregate, vehicle.ID, before, vehicle)
+	})
 	if err != nil {
-		return errors.New("vehicle not found")
+		return nil, err
 	}
 
-	if err := vehicle.MarkAsReadyForSale(); err != nil {
-		return err
+	return transfer, nil
+}
+
+// arrivalStatus - el vehicle vuelve al estado que tenía antes del traslado, pero a la venta
+// solo si sigue cumpliendo las reglas de un cambio manual (precio, inspección CPO). Si ya no
+// las cumple la llegada no se bloquea: entra a recon y el motivo queda en el historial
+func (s *vehicleService) arrivalStatus(ctx context.Context, vehicle *domain.Vehicle, transfer *domain.VehicleTransfer) (domain.VehicleStatus, string, error) {
+	status := transfer.ArrivalStatus()
+	if status != domain.VehicleStatusReadyForSale {
+		return status, transfer.Reason, nil
+	}
+
+	err := vehicle.CanArriveAs(status)
+	if err == nil {
+		err = s.checkCPO(ctx, vehicle)
+	}
+	if err == nil {
+		return status, transfer.Reason, nil
+	}
+	if _, ok := sharedDomain.AsError(err); !ok {
+		return "", "", err
+	}
+	return domain.VehicleStatusInRecon, err.Error(), nil
+}
+
+func (s *vehicleService) GetLocationHistory(ctx context.Context, vehicleID uint, q sharedDomain.Query) (*sharedDomain.Page[*domain.VehicleLocationHistory], error) {
+	if _, err := s.vehicleRepo.FindByID(ctx, vehicleID); err != nil {
+		return nil, err
 	}
 
-	return s.vehicleRepo.Update(vehicle)
+	q = q.Normalize(20, 100)
+	return s.historyRepo.FindByVehicleID(ctx, vehicleID, q)
 }
 
-func (s *vehicleService) SendToRecon(id uint) error {
-	vehicle, err := s.vehicleRepo.FindByID(id)
+// lockForArrival - bloquea la ubicación hasta el commit para que dos movimientos
+// simultáneos no ocupen la misma última plaza. reserved son las plazas ya contadas
+// que pertenecen al propio movimiento
+func (s *vehicleService) lockForArrival(ctx context.Context, locationID uint, reserved int64) (*domain.Location, error) {
+	location, err := s.locationRepo.FindByIDForUpdate(ctx, locationID)
 	if err != nil {
-		return errors.New("vehicle not found")
+		return nil, err
 	}
 
-	if err := vehicle.SendToRecon(); err != nil {
-		return err
+	var occupied int64
+	if location.Capacity > 0 {
+		occupancy, err := loadOccupancy(ctx, s.vehicleRepo, s.transferRepo, location)
+		if err != nil {
+			return nil, err
+		}
+		occupied = occupancy.Occupied() - reserved
 	}
 
-	return s.vehicleRepo.Update(vehicle)
+	if err := location.CanReceive(occupied); err != nil {
+		return nil, err
+	}
+	return location, nil
 }
 
-func (s *vehicleService) ChangeLocation(id uint, locationID uint) error {
-	vehicle, err := s.vehicleRepo.FindByID(id)
-	if err != nil {
-		return errors.New("vehicle not found")
+func (s *vehicleService) ensureNoPendingTransfer(ctx context.Context, vehicleID uint) error {
+	_, err := s.transferRepo.FindPendingByVehicleID(ctx, vehicleID)
+	if err == nil {
+		return sharedDomain.Invariant("transfer_pending", "vehicle has a pending transfer")
+	}
+	if sharedDomain.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// transitLocationID - sin ubicación indicada se usa la primera in_transit activa
+func (s *vehicleService) transitLocationID(ctx context.Context, requested uint) (uint, error) {
+	if requested != 0 {
+		return requested, nil
 	}
 
-	// Verificar que location exista
-	locationExists, err := s.locationRepo.Exists(locationID)
+	locations, err := s.locationRepo.FindByType(ctx, domain.LocationTypeInTransit)
 	if err != nil {
+		return 0, err
+	}
+	for _, location := range locations {
+		if location.Active {
+			return location.ID, nil
+		}
+	}
+	return 0, sharedDomain.Invariant("no_transit_location", "no active in_transit location configured")
+}
+
+// recordArrivalStatus - al llegar el vehicle recupera su estado; si entra a recon se abre
+// su orden para que el ciclo empiece a contar
+func (s *vehicleService) recordArrivalStatus(ctx context.Context, vehicle *domain.Vehicle, from domain.VehicleStatus, reason string) error {
+	if err := s.recordStatusChange(ctx, vehicle.ID, from, vehicle.Status, reason); err != nil {
 		return err
 	}
-	if !locationExists {
-		return errors.New("location not found")
+	if from != vehicle.Status && vehicle.Status == domain.VehicleStatusInRecon {
+		return s.openRecon(ctx, vehicle)
 	}
+	return nil
+}
 
-	vehicle.SetLocation(locationID)
-	return s.vehicleRepo.Update(vehicle)
+func (s *vehicleService) recordMove(ctx context.Context, vehicleID uint, fromLocationID uint, toLocationID uint, reason string) error {
+	history := domain.NewVehicleLocationHistory(vehicleID, fromLocationID, toLocationID, sharedDomain.ActorFromContext(ctx).EntityID, reason)
+	return s.historyRepo.Save(ctx, history)
 }
 
 // Photos
 
-func (s *vehicleService) AddPhoto(inp input.AddPhotoInput) (*domain.VehiclePhoto, error) {
-	// Verificar que vehicle exista
-	exists, err := s.vehicleRepo.Exists(inp.VehicleID)
-	if err != nil {
-		return nil, err
-	}
-	if !exists {
-		return nil, errors.New("vehicle not found")
-	}
+func (s *vehicleService) AddPhoto(ctx context.Context, inp input.AddPhotoInput) (*domain.VehiclePhoto, error) {
+	var photo *domain.VehiclePhoto
+
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		// Verificar que vehicle exista
+		exists, err := s.vehicleRepo.Exists(ctx, inp.VehicleID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return sharedDomain.NotFound("vehicle")
+		}
+
+		photo, err = domain.NewVehiclePhoto(
+			inp.VehicleID,
+			inp.URL,
+			domain.PhotoPerspective(inp.Perspective),
+			domain.PhotoPurpose(inp.Purpose),
+			inp.UploadedBy,
+		)
+		if err != nil {
+			return err
+		}
 
-	photo, err := domain.NewVehiclePhoto(
-		inp.VehicleID,
-		inp.URL,
-		domain.PhotoPerspective(inp.Perspective),
-		domain.PhotoPurpose(inp.Purpose),
-		inp.UploadedBy,
-	)
+		if inp.IsPrimary {
+			// Quitar primary de otras fotos
+			existingPhotos, err := s.photoRepo.FindByVehicleID(ctx, inp.VehicleID)
+			if err != nil {
+				return err
+			}
+			for _, p := range existingPhotos {
+				if p.IsPrimary {
+					previous := *p
+					p.RemovePrimary()
+					if err := s.photoRepo.Update(ctx, p); err != nil {
+						return err
+					}
+					if err := s.auditService.Record(ctx, auditDomain.ActionUpdate, vehiclePhotoAggregate, p.ID, previous, p); err != nil {
+						return err
+					}
+				}
+			}
+			photo.SetAsPrimary()
+		}
+
+		if err := s.photoRepo.Save(ctx, photo); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionCreate, vehiclePhotoAggregate, photo.ID, nil, photo)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	if inp.IsPrimary {
+	return photo, nil
+}
+
+func (s *vehicleService) GetPhotos(ctx context.Context, vehicleID uint) ([]*domain.VehiclePhoto, error) {
+	return s.photoRepo.FindByVehicleID(ctx, vehicleID)
+}
+
+func (s *vehicleService) SetPrimaryPhoto(ctx context.Context, vehicleID uint, photoID uint) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		photo, err := s.photoRepo.FindByID(ctx, photoID)
+		if err != nil {
+			return err
+		}
+
+		if photo.VehicleID != vehicleID {
+			return sharedDomain.Invariant("photo_vehicle_mismatch", "photo does not belong to this vehicle")
+		}
+
 		// Quitar primary de otras fotos
-		existingPhotos, err := s.photoRepo.FindByVehicleID(inp.VehicleID)
+		existingPhotos, err := s.photoRepo.FindByVehicleID(ctx, vehicleID)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		for _, p := range existingPhotos {
-			if p.IsPrimary {
+			if p.IsPrimary && p.ID != photoID {
+				previous := *p
 				p.RemovePrimary()
-				s.photoRepo.Update(p)
+				if err := s.photoRepo.Update(ctx, p); err != nil {
+					return err
+				}
+				if err := s.auditService.Record(ctx, auditDomain.ActionUpdate, vehiclePhotoAggregate, p.ID, previous, p); err != nil {
+					return err
+				}
 			}
 		}
+
+		before := *photo
 		photo.SetAsPrimary()
-	}
+		if err := s.photoRepo.Update(ctx, photo); err != nil {
+			return err
+		}
 
-	if err := s.photoRepo.Save(photo); err != nil {
-		return nil, err
-	}
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, vehiclePhotoAggregate, photoID, before, photo)
+	})
+}
 
-	return photo, nil
+func (s *vehicleService) DeletePhoto(ctx context.Context, photoID uint) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		photo, err := s.photoRepo.FindByID(ctx, photoID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.photoRepo.Delete(ctx, photoID); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionDelete, vehiclePhotoAggregate, photoID, photo, nil)
+	})
 }
 
-func (s *vehicleService) GetPhotos(vehicleID uint) ([]*domain.VehiclePhoto, error) {
-	return s.photoRepo.FindByVehicleID(vehicleID)
+func (s *vehicleService) ListDeleted(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.Vehicle], error) {
+	q = q.Normalize(10, 100)
+	return s.vehicleRepo.FindDeleted(ctx, q)
 }
 
-func (s *vehicleService) SetPrimaryPhoto(vehicleID uint, photoID uint) error {
-	photo, err := s.photoRepo.FindByID(photoID)
-	if err != nil {
-		return errors.New("photo not found")
-	}
+func (s *vehicleService) Restore(ctx context.Context, id uint) (*domain.Vehicle, error) {
+	ctx = sharedDomain.WithDeleted(ctx)
 
-	if photo.VehicleID != vehicleID {
-		return errors.New("photo does not belong to this vehicle")
-	}
+	var vehicle *domain.Vehicle
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		vehicle, err = s.vehicleRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		before := *vehicle
 
-	// Quitar primary de otras fotos
-	existingPhotos, err := s.photoRepo.FindByVehicleID(vehicleID)
-	if err != nil {
-		return err
-	}
-	for _, p := range existingPhotos {
-		if p.IsPrimary && p.ID != photoID {
-			p.RemovePrimary()
-			s.photoRepo.Update(p)
+		if err := vehicle.Restore(); err != nil {
+			return err
 		}
+
+		if err := s.vehicleRepo.Update(ctx, vehicle); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionRestore, vehicleAggregate, id, before, vehicle)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	photo.SetAsPrimary()
-	return s.photoRepo.Update(photo)
+	return vehicle, nil
 }
 
-func (s *vehicleService) DeletePhoto(photoID uint) error {
-	exists, err := s.photoRepo.FindByID(photoID)
+// PurgeDeleted - las fotos se borran en cascada con el vehicle
+func (s *vehicleService) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int, error) {
+	deleted, err := s.vehicleRepo.FindDeletedBefore(ctx, deletedBefore)
 	if err != nil {
-		return errors.New("photo not found")
+		return 0, err
 	}
-	_ = exists
 
-	return s.photoRepo.Delete(photoID)
-}
\ No newline at end of file
+	purged := 0
+	var errs []error
+	for _, vehicle := range deleted {
+		// Cada purga va con su entrada de auditoría; un fallo solo deja fuera ese vehicle
+		err := s.uow.Do(ctx, func(ctx context.Context) error {
+			if err := s.vehicleRepo.Purge(ctx, vehicle.ID); err != nil {
+				return err
+			}
+			return s.auditService.Record(ctx, auditDomain.ActionPurge, vehicleAggregate, vehicle.ID, nil, nil)
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("vehicle %d: %w", vehicle.ID, err))
+			continue
+		}
+		purged++
+	}
+
+	return purged, errors.Join(errs...)
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	sharedDomain "torque-dms/core/shared/domain"
+)
+
+var ErrLegalHold = sharedDomain.Conflict("legal_hold", "entity is under legal hold")
+
+type LegalHoldReason string
+
+const (
+	LegalHoldSoldVehicle LegalHoldReason = "sold_vehicle"
+)
+
+// LegalHold - motivo por el que no se pueden borrar los datos de una entity
+type LegalHold struct {
+	Reason    LegalHoldReason
+	LeadID    uint
+	VehicleID uint
+	Until     time.Time
+}
+
+func (h LegalHold) String() string {
+	return fmt.Sprintf("%s (vehicle %d, lead %d) until %s", h.Reason, h.VehicleID, h.LeadID, h.Until.Format("2006-01-02"))
+}
+
+// RetentionPolicy - cuánto tiempo hay que conservar los datos de una venta
+type RetentionPolicy struct {
+	SoldVehicleYears int
+}
+
+func NewRetentionPolicy(soldVehicleYears int) (*RetentionPolicy, error) {
+	if soldVehicleYears < 0 {
+		return nil, sharedDomain.Invalid("retention_years", "retention years cannot be negative")
+	}
+	return &RetentionPolicy{SoldVehicleYears: soldVehicleYears}, nil
+}
+
+// VehicleSale - venta de un vehicle en el que la entity tuvo interés. BuyerLeadID es nil en
+// ventas sin comprador identificado
+type VehicleSale struct {
+	VehicleID   uint
+	BuyerLeadID *uint
+	SoldAt      time.Time
+}
+
+// SoldVehicleHold - devuelve el hold si el lead es el del comprador y la venta sigue dentro
+// de la ventana de retención. Sin comprador identificado se retiene a todos los interesados
+func (p *RetentionPolicy) SoldVehicleHold(leadID uint, sale VehicleSale, now time.Time) *LegalHold {
+	if sale.BuyerLeadID != nil && *sale.BuyerLeadID != leadID {
+		return nil
+	}
+	until := sale.SoldAt.AddDate(p.SoldVehicleYears, 0, 0)
+	if now.After(until) {
+		return nil
+	}
+	return &LegalHold{
+		Reason:    LegalHoldSoldVehicle,
+		LeadID:    leadID,
+		VehicleID: sale.VehicleID,
+		Until:     until,
+	}
+}
+
+// LegalHoldError - envuelve ErrLegalHold con el detalle de los holds activos
+type LegalHoldError struct {
+	Holds []LegalHold
+}
+
+func (e *LegalHoldError) Error() string {
+	reasons := make([]string, len(e.Holds))
+	for i, h := range e.Holds {
+		reasons[i] = h.String()
+	}
+	return fmt.Sprintf("%s: %s", ErrLegalHold.Error(), strings.Join(reasons, "; "))
+}
+
+func (e *LegalHoldError) Unwrap() error {
+	return ErrLegalHold
+}
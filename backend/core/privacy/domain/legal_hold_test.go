@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewRetentionPolicy(t *testing.T) {
+	if _, err := NewRetentionPolicy(-1); err == nil {
+		t.Error("NewRetentionPolicy() expected error for negative years")
+	}
+	if _, err := NewRetentionPolicy(0); err != nil {
+		t.Errorf("NewRetentionPolicy() error = %v", err)
+	}
+}
+
+func TestSoldVehicleHold(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	buyer := uint(7)
+	other := uint(8)
+
+	tests := []struct {
+		name     string
+		years    int
+		leadID   uint
+		buyer    *uint
+		soldAt   time.Time
+		wantHold bool
+	}{
+		{"buyer inside window", 5, buyer, &buyer, now.AddDate(-1, 0, 0), true},
+		{"buyer window expired", 5, buyer, &buyer, now.AddDate(-6, 0, 0), false},
+		{"buyer last day", 5, buyer, &buyer, now.AddDate(-5, 0, 0), true},
+		{"interested prospect", 5, other, &buyer, now.AddDate(-1, 0, 0), false},
+		{"unknown buyer holds every lead", 5, other, nil, now.AddDate(-1, 0, 0), true},
+		{"no retention", 0, buyer, &buyer, now.AddDate(0, 0, -1), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, _ := NewRetentionPolicy(tt.years)
+			sale := VehicleSale{VehicleID: 3, BuyerLeadID: tt.buyer, SoldAt: tt.soldAt}
+
+			hold := policy.SoldVehicleHold(tt.leadID, sale, now)
+			if (hold != nil) != tt.wantHold {
+				t.Fatalf("SoldVehicleHold() = %+v, wantHold %v", hold, tt.wantHold)
+			}
+			if hold != nil {
+				if hold.LeadID != tt.leadID || hold.VehicleID != 3 || hold.Reason != LegalHoldSoldVehicle {
+					t.Errorf("SoldVehicleHold() = %+v", hold)
+				}
+				if want := tt.soldAt.AddDate(tt.years, 0, 0); !hold.Until.Equal(want) {
+					t.Errorf("Until = %v, want %v", hold.Until, want)
+				}
+			}
+		})
+	}
+}
+
+func TestLegalHoldError(t *testing.T) {
+	err := &LegalHoldError{Holds: []LegalHold{{Reason: LegalHoldSoldVehicle, LeadID: 1, VehicleID: 2}}}
+	if !errors.Is(err, ErrLegalHold) {
+		t.Error("LegalHoldError does not unwrap to ErrLegalHold")
+	}
+}
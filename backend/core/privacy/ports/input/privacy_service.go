@@ -0,0 +1,38 @@
+package input
+
+import (
+	"context"
+	"time"
+
+	identityDomain "torque-dms/core/identity/domain"
+	identityOutput "torque-dms/core/identity/ports/output"
+	salesDomain "torque-dms/core/sales/domain"
+)
+
+// DataExport - todo lo que guardamos sobre una entity (GDPR art. 15 / CCPA)
+type DataExport struct {
+	Entity      *identityDomain.Entity
+	Phones      []*identityOutput.Phone
+	Account     *identityDomain.UserAccount
+	Leads       []*salesDomain.Lead
+	Notes       []*salesDomain.LeadNote
+	Activities  []*salesDomain.LeadActivity
+	Assignments []*salesDomain.LeadAssignment
+	Progress    []*salesDomain.LeadStepProgress
+	GeneratedAt time.Time
+}
+
+type ErasureResult struct {
+	EntityID           uint
+	PhonesDeleted      int
+	AccountAnonymized  bool
+	LeadsRedacted      int
+	NotesRedacted      int
+	ActivitiesRedacted int
+	ErasedAt           time.Time
+}
+
+type PrivacyService interface {
+	Export(ctx context.Context, entityID uint) (*DataExport, error)
+	Erase(ctx context.Context, entityID uint) (*ErasureResult, error)
+}
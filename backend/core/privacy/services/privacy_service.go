@@ -0,0 +1,284 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	auditDomain "torque-dms/core/audit/domain"
+	auditInput "torque-dms/core/audit/ports/input"
+	identityOutput "torque-dms/core/identity/ports/output"
+	inventoryOutput "torque-dms/core/inventory/ports/output"
+	"torque-dms/core/privacy/domain"
+	"torque-dms/core/privacy/ports/input"
+	salesDomain "torque-dms/core/sales/domain"
+	salesOutput "torque-dms/core/sales/ports/output"
+	sharedDomain "torque-dms/core/shared/domain"
+	sharedOutput "torque-dms/core/shared/ports/output"
+)
+
+// El borrado se registra sobre el agregado de identity
+const entityAggregate = "entity"
+
+type privacyService struct {
+	entityRepo     identityOutput.EntityRepository
+	phoneRepo      identityOutput.PhoneRepository
+	userRepo       identityOutput.UserRepository
+	leadRepo       salesOutput.LeadRepository
+	noteRepo       salesOutput.LeadNoteRepository
+	activityRepo   salesOutput.LeadActivityRepository
+	assignmentRepo salesOutput.LeadAssignmentRepository
+	progressRepo   salesOutput.LeadStepProgressRepository
+	vehicleRepo    inventoryOutput.VehicleRepository
+	retention      *domain.RetentionPolicy
+	auditService   auditInput.AuditService
+	uow            sharedOutput.UnitOfWork
+}
+
+func NewPrivacyService(
+	entityRepo identityOutput.EntityRepository,
+	phoneRepo identityOutput.PhoneRepository,
+	userRepo identityOutput.UserRepository,
+	leadRepo salesOutput.LeadRepository,
+	noteRepo salesOutput.LeadNoteRepository,
+	activityRepo salesOutput.LeadActivityRepository,
+	assignmentRepo salesOutput.LeadAssignmentRepository,
+	progressRepo salesOutput.LeadStepProgressRepository,
+	vehicleRepo inventoryOutput.VehicleRepository,
+	retention *domain.RetentionPolicy,
+	auditService auditInput.AuditService,
+	uow sharedOutput.UnitOfWork,
+) input.PrivacyService {
+	return &privacyService{
+		entityRepo:     entityRepo,
+		phoneRepo:      phoneRepo,
+		userRepo:       userRepo,
+		leadRepo:       leadRepo,
+		noteRepo:       noteRepo,
+		activityRepo:   activityRepo,
+		assignmentRepo: assignmentRepo,
+		progressRepo:   progressRepo,
+		vehicleRepo:    vehicleRepo,
+		retention:      retention,
+		auditService:   auditService,
+		uow:            uow,
+	}
+}
+
+func (s *privacyService) Export(ctx context.Context, entityID uint) (*input.DataExport, error) {
+	// Lo que está en la papelera también son datos personales del titular
+	ctx = sharedDomain.WithDeleted(ctx)
+
+	entity, err := s.entityRepo.FindByID(ctx, entityID)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &input.DataExport{
+		Entity:      entity,
+		GeneratedAt: time.Now(),
+	}
+
+	if export.Phones, err = s.phoneRepo.FindByEntityID(ctx, entityID); err != nil {
+		return nil, err
+	}
+
+	// La entity puede no tener cuenta de usuario
+	account, err := s.userRepo.FindByEntityID(ctx, entityID)
+	if err != nil && !sharedDomain.IsNotFound(err) {
+		return nil, err
+	}
+	export.Account = account
+
+	if export.Leads, err = s.leadRepo.FindByEntityID(ctx, entityID); err != nil {
+		return nil, err
+	}
+
+	seenAssignments := map[uint]bool{}
+	for _, lead := range export.Leads {
+		notes, err := s.noteRepo.FindByLeadID(ctx, lead.ID)
+		if err != nil {
+			return nil, err
+		}
+		export.Notes = append(export.Notes, notes...)
+
+		activities, err := s.activityRepo.FindByLeadID(ctx, lead.ID)
+		if err != nil {
+			return nil, err
+		}
+		export.Activities = append(export.Activities, activities...)
+
+		assignments, err := s.assignmentRepo.FindByLeadID(ctx, lead.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range assignments {
+			seenAssignments[a.ID] = true
+		}
+		export.Assignments = append(export.Assignments, assignments...)
+
+		progress, err := s.progressRepo.FindByLeadID(ctx, lead.ID)
+		if err != nil {
+			return nil, err
+		}
+		export.Progress = append(export.Progress, progress...)
+	}
+
+	// Asignaciones donde la entity es el vendedor asignado
+	assigned, err := s.assignmentRepo.FindByEntityID(ctx, entityID)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range assigned {
+		if !seenAssignments[a.ID] {
+			export.Assignments = append(export.Assignments, a)
+		}
+	}
+
+	return export, nil
+}
+
+func (s *privacyService) Erase(ctx context.Context, entityID uint) (*input.ErasureResult, error) {
+	var result *input.ErasureResult
+
+	ctx = sharedDomain.WithDeleted(ctx)
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		entity, err := s.entityRepo.FindByID(ctx, entityID)
+		if err != nil {
+			return err
+		}
+
+		leads, err := s.leadRepo.FindByEntityID(ctx, entityID)
+		if err != nil {
+			return err
+		}
+
+		// Verificar legal hold antes de tocar nada
+		holds, err := s.legalHolds(ctx, leads)
+		if err != nil {
+			return err
+		}
+		if len(holds) > 0 {
+			return &domain.LegalHoldError{Holds: holds}
+		}
+
+		if err := entity.Anonymize(); err != nil {
+			return err
+		}
+		if err := s.entityRepo.Update(ctx, entity); err != nil {
+			return err
+		}
+
+		result = &input.ErasureResult{
+			EntityID: entityID,
+			ErasedAt: *entity.ErasedAt,
+		}
+
+		phones, err := s.phoneRepo.FindByEntityID(ctx, entityID)
+		if err != nil {
+			return err
+		}
+		for _, phone := range phones {
+			if err := s.phoneRepo.Delete(ctx, phone.ID); err != nil {
+				return err
+			}
+			result.PhonesDeleted++
+		}
+
+		account, err := s.userRepo.FindByEntityID(ctx, entityID)
+		if err != nil && !sharedDomain.IsNotFound(err) {
+			return err
+		}
+		if account != nil {
+			account.Anonymize()
+			if err := s.userRepo.Update(ctx, account); err != nil {
+				return err
+			}
+			result.AccountAnonymized = true
+		}
+
+		// Los leads se conservan para los reportes de ventas, solo se limpia el texto libre
+		for _, lead := range leads {
+			if err := s.redactLead(ctx, lead, result); err != nil {
+				return err
+			}
+		}
+
+		// Solo se registran los contadores; guardar el diff dejaría los datos borrados en el log
+		return s.auditService.Record(ctx, auditDomain.ActionErase, entityAggregate, entityID, nil, result)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *privacyService) redactLead(ctx context.Context, lead *salesDomain.Lead, result *input.ErasureResult) error {
+	lead.Redact()
+	if err := s.leadRepo.Update(ctx, lead); err != nil {
+		return err
+	}
+	result.LeadsRedacted++
+
+	notes, err := s.noteRepo.FindByLeadID(ctx, lead.ID)
+	if err != nil {
+		return err
+	}
+	for _, note := range notes {
+		note.Redact()
+		if err := s.noteRepo.Update(ctx, note); err != nil {
+			return err
+		}
+		result.NotesRedacted++
+	}
+
+	activities, err := s.activityRepo.FindByLeadID(ctx, lead.ID)
+	if err != nil {
+		return err
+	}
+	for _, activity := range activities {
+		activity.Redact()
+		if err := s.activityRepo.Update(ctx, activity); err != nil {
+			return err
+		}
+		result.ActivitiesRedacted++
+	}
+
+	return nil
+}
+
+// legalHolds - un vehículo vendido a la entity bloquea el borrado durante la ventana de retención.
+// Un fallo al leer el vehículo aborta el borrado: no se puede dar por hecho que no hay hold
+func (s *privacyService) legalHolds(ctx context.Context, leads []*salesDomain.Lead) ([]domain.LegalHold, error) {
+	// Un vehículo vendido que está en la papelera sigue contando
+	ctx = sharedDomain.WithDeleted(ctx)
+	now := time.Now()
+
+	var holds []domain.LegalHold
+	for _, lead := range leads {
+		if !lead.HasVehicleInterest() {
+			continue
+		}
+
+		vehicle, err := s.vehicleRepo.FindByID(ctx, *lead.VehicleID)
+		if err != nil {
+			if sharedDomain.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		if !vehicle.IsSold() {
+			continue
+		}
+
+		// Sin fecha de venta registrada la ventana empieza hoy
+		sale := domain.VehicleSale{VehicleID: vehicle.ID, BuyerLeadID: vehicle.SoldLeadID, SoldAt: now}
+		if vehicle.SoldAt != nil {
+			sale.SoldAt = *vehicle.SoldAt
+		}
+		if hold := s.retention.SoldVehicleHold(lead.ID, sale, now); hold != nil {
+			holds = append(holds, *hold)
+		}
+	}
+	return holds, nil
+}
@@ -1,8 +1,9 @@
 package domain
 
 import (
-	"errors"
 	"time"
+
+	sharedDomain "torque-dms/core/shared/domain"
 )
 
 type ActivityType string
@@ -22,6 +23,32 @@ const (
 	ActivityTypeOther                ActivityType = "other"
 )
 
+func ActivityTypes() []ActivityType {
+	return []ActivityType{
+		ActivityTypeCallOutbound,
+		ActivityTypeCallInbound,
+		ActivityTypeEmailSent,
+		ActivityTypeEmailReceived,
+		ActivityTypeSMSSent,
+		ActivityTypeSMSReceived,
+		ActivityTypeAppointmentScheduled,
+		ActivityTypeAppointmentCompleted,
+		ActivityTypeAppointmentCancelled,
+		ActivityTypeDemo,
+		ActivityTypeQuoteSent,
+		ActivityTypeOther,
+	}
+}
+
+func (a ActivityType) IsValid() bool {
+	for _, v := range ActivityTypes() {
+		if a == v {
+			return true
+		}
+	}
+	return false
+}
+
 type LeadActivity struct {
 	ID          uint
 	LeadID      uint
@@ -38,10 +65,13 @@ type LeadActivity struct {
 
 func NewLeadActivity(leadID uint, activityType ActivityType, performedBy uint) (*LeadActivity, error) {
 	if leadID == 0 {
-		return nil, errors.New("lead is required")
+		return nil, sharedDomain.Invalid("lead_id", "lead is required")
+	}
+	if !activityType.IsValid() {
+		return nil, sharedDomain.Invalid("type", "invalid activity type")
 	}
 	if performedBy == 0 {
-		return nil, errors.New("performed_by is required")
+		return nil, sharedDomain.Invalid("performed_by", "performed_by is required")
 	}
 
 	return &LeadActivity{
@@ -77,6 +107,18 @@ func (a *LeadActivity) Complete() {
 	a.CompletedAt = &now
 }
 
+// Redact - elimina los datos de contacto y el texto libre de la actividad
+func (a *LeadActivity) Redact() {
+	a.Description = ""
+	a.Email = ""
+	a.PhoneID = nil
+}
+
+// PersonalFields - el texto libre y el contacto que Redact borra
+func (a *LeadActivity) PersonalFields() []string {
+	return []string{"Description", "Outcome", "Email"}
+}
+
 func (a *LeadActivity) IsCompleted() bool {
 	return a.CompletedAt != nil
 }
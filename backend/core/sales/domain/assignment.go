@@ -1,8 +1,9 @@
 package domain
 
 import (
-	"errors"
 	"time"
+
+	sharedDomain "torque-dms/core/shared/domain"
 )
 
 type AssignmentRole string
@@ -14,6 +15,24 @@ const (
 	AssignmentRoleCloser      AssignmentRole = "closer"
 )
 
+func AssignmentRoles() []AssignmentRole {
+	return []AssignmentRole{
+		AssignmentRoleSalesperson,
+		AssignmentRoleManager,
+		AssignmentRoleFinance,
+		AssignmentRoleCloser,
+	}
+}
+
+func (a AssignmentRole) IsValid() bool {
+	for _, v := range AssignmentRoles() {
+		if a == v {
+			return true
+		}
+	}
+	return false
+}
+
 type LeadAssignment struct {
 	ID         uint
 	LeadID     uint
@@ -27,13 +46,16 @@ type LeadAssignment struct {
 
 func NewLeadAssignment(leadID uint, entityID uint, role AssignmentRole, assignedBy uint) (*LeadAssignment, error) {
 	if leadID == 0 {
-		return nil, errors.New("lead is required")
+		return nil, sharedDomain.Invalid("lead_id", "lead is required")
 	}
 	if entityID == 0 {
-		return nil, errors.New("entity is required")
+		return nil, sharedDomain.Invalid("entity_id", "entity is required")
+	}
+	if !role.IsValid() {
+		return nil, sharedDomain.Invalid("role", "invalid assignment role")
 	}
 	if assignedBy == 0 {
-		return nil, errors.New("assigned_by is required")
+		return nil, sharedDomain.Invalid("assigned_by", "assigned_by is required")
 	}
 
 	return &LeadAssignment{
@@ -1,8 +1,9 @@
 package domain
 
 import (
-	"errors"
 	"time"
+
+	sharedDomain "torque-dms/core/shared/domain"
 )
 
 type Lead struct {
@@ -17,16 +18,19 @@ type Lead struct {
 	SourceID      uint
 	SourceDetail  string
 	PresetID      *uint
+	DeletedAt     *time.Time
+	DeletedBy     *uint
+	Version       uint
 	CreatedAt     time.Time
 	ModifiedAt    time.Time
 }
 
 func NewLead(entityID uint, sourceID uint) (*Lead, error) {
 	if entityID == 0 {
-		return nil, errors.New("entity is required")
+		return nil, sharedDomain.Invalid("entity_id", "entity is required")
 	}
 	if sourceID == 0 {
-		return nil, errors.New("source is required")
+		return nil, sharedDomain.Invalid("source_id", "source is required")
 	}
 
 	return &Lead{
@@ -45,10 +49,10 @@ func (l *Lead) SetInterest(interestType string, make string, model string) {
 
 func (l *Lead) SetBudget(min float64, max float64) error {
 	if min < 0 || max < 0 {
-		return errors.New("budget cannot be negative")
+		return sharedDomain.Invalid("budget", "budget cannot be negative")
 	}
 	if min > max && max > 0 {
-		return errors.New("min budget cannot exceed max budget")
+		return sharedDomain.Invalid("budget_min", "min budget cannot exceed max budget")
 	}
 	l.BudgetMin = min
 	l.BudgetMax = max
@@ -71,10 +75,44 @@ func (l *Lead) SetPreset(presetID uint) {
 	l.ModifiedAt = time.Now()
 }
 
+// Redact - elimina el texto libre que puede contener datos personales,
+// conservando los datos agregados del lead
+func (l *Lead) Redact() {
+	l.SourceDetail = ""
+	l.ModifiedAt = time.Now()
+}
+
+func (l *Lead) PersonalFields() []string {
+	return []string{"SourceDetail"}
+}
+
 func (l *Lead) IsHighValue() bool {
 	return l.BudgetMax >= 50000
 }
 
 func (l *Lead) HasVehicleInterest() bool {
 	return l.VehicleID != nil
+}
+
+// SoftDelete - notas, actividades y asignaciones se conservan; el purge las borra en cascada
+func (l *Lead) SoftDelete(deletedBy uint) {
+	now := time.Now()
+	l.DeletedAt = &now
+	l.DeletedBy = nil
+	if deletedBy != 0 {
+		l.DeletedBy = &deletedBy
+	}
+}
+
+func (l *Lead) Restore() error {
+	if !l.IsDeleted() {
+		return sharedDomain.Invariant("lead_not_deleted", "lead is not deleted")
+	}
+	l.DeletedAt = nil
+	l.DeletedBy = nil
+	return nil
+}
+
+func (l *Lead) IsDeleted() bool {
+	return l.DeletedAt != nil
 }
\ No newline at end of file
@@ -1,8 +1,9 @@
 package domain
 
 import (
-	"errors"
 	"time"
+
+	sharedDomain "torque-dms/core/shared/domain"
 )
 
 type LeadSource struct {
@@ -16,10 +17,10 @@ type LeadSource struct {
 
 func NewLeadSource(code string, name string, isExternal bool) (*LeadSource, error) {
 	if code == "" {
-		return nil, errors.New("code is required")
+		return nil, sharedDomain.Invalid("code", "code is required")
 	}
 	if name == "" {
-		return nil, errors.New("name is required")
+		return nil, sharedDomain.Invalid("name", "name is required")
 	}
 
 	return &LeadSource{
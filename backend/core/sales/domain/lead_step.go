@@ -1,8 +1,9 @@
 package domain
 
 import (
-	"errors"
 	"time"
+
+	sharedDomain "torque-dms/core/shared/domain"
 )
 
 type StepStatus string
@@ -14,6 +15,24 @@ const (
 	StepStatusFailed    StepStatus = "failed"
 )
 
+func StepStatuses() []StepStatus {
+	return []StepStatus{
+		StepStatusPending,
+		StepStatusCompleted,
+		StepStatusSkipped,
+		StepStatusFailed,
+	}
+}
+
+func (s StepStatus) IsValid() bool {
+	for _, v := range StepStatuses() {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
 type LeadStepPreset struct {
 	ID          uint
 	Code        string
@@ -23,18 +42,20 @@ type LeadStepPreset struct {
 	IsPublic    bool
 	IsShared    bool
 	CreatedBy   uint
+	DeletedAt   *time.Time
+	DeletedBy   *uint
 	CreatedAt   time.Time
 }
 
 func NewLeadStepPreset(code string, name string, createdBy uint) (*LeadStepPreset, error) {
 	if code == "" {
-		return nil, errors.New("code is required")
+		return nil, sharedDomain.Invalid("code", "code is required")
 	}
 	if name == "" {
-		return nil, errors.New("name is required")
+		return nil, sharedDomain.Invalid("name", "name is required")
 	}
 	if createdBy == 0 {
-		return nil, errors.New("created_by is required")
+		return nil, sharedDomain.Invalid("created_by", "created_by is required")
 	}
 
 	return &LeadStepPreset{
@@ -60,6 +81,28 @@ func (p *LeadStepPreset) MakePrivate() {
 	p.IsShared = false
 }
 
+func (p *LeadStepPreset) SoftDelete(deletedBy uint) {
+	now := time.Now()
+	p.DeletedAt = &now
+	p.DeletedBy = nil
+	if deletedBy != 0 {
+		p.DeletedBy = &deletedBy
+	}
+}
+
+func (p *LeadStepPreset) Restore() error {
+	if !p.IsDeleted() {
+		return sharedDomain.Invariant("preset_not_deleted", "preset is not deleted")
+	}
+	p.DeletedAt = nil
+	p.DeletedBy = nil
+	return nil
+}
+
+func (p *LeadStepPreset) IsDeleted() bool {
+	return p.DeletedAt != nil
+}
+
 type LeadStep struct {
 	ID        uint
 	PresetID  uint
@@ -73,13 +116,13 @@ type LeadStep struct {
 
 func NewLeadStep(presetID uint, code string, name string, sortOrder int) (*LeadStep, error) {
 	if presetID == 0 {
-		return nil, errors.New("preset is required")
+		return nil, sharedDomain.Invalid("preset_id", "preset is required")
 	}
 	if code == "" {
-		return nil, errors.New("code is required")
+		return nil, sharedDomain.Invalid("code", "code is required")
 	}
 	if name == "" {
-		return nil, errors.New("name is required")
+		return nil, sharedDomain.Invalid("name", "name is required")
 	}
 
 	return &LeadStep{
@@ -118,10 +161,10 @@ type LeadStepProgress struct {
 
 func NewLeadStepProgress(leadID uint, stepID uint) (*LeadStepProgress, error) {
 	if leadID == 0 {
-		return nil, errors.New("lead is required")
+		return nil, sharedDomain.Invalid("lead_id", "lead is required")
 	}
 	if stepID == 0 {
-		return nil, errors.New("step is required")
+		return nil, sharedDomain.Invalid("step_id", "step is required")
 	}
 
 	now := time.Now()
@@ -1,8 +1,9 @@
 package domain
 
 import (
-	"errors"
 	"time"
+
+	sharedDomain "torque-dms/core/shared/domain"
 )
 
 type LeadNote struct {
@@ -16,13 +17,13 @@ type LeadNote struct {
 
 func NewLeadNote(leadID uint, content string, createdBy uint) (*LeadNote, error) {
 	if leadID == 0 {
-		return nil, errors.New("lead is required")
+		return nil, sharedDomain.Invalid("lead_id", "lead is required")
 	}
 	if content == "" {
-		return nil, errors.New("content is required")
+		return nil, sharedDomain.Invalid("content", "content is required")
 	}
 	if createdBy == 0 {
-		return nil, errors.New("created_by is required")
+		return nil, sharedDomain.Invalid("created_by", "created_by is required")
 	}
 
 	now := time.Now()
@@ -37,9 +38,20 @@ func NewLeadNote(leadID uint, content string, createdBy uint) (*LeadNote, error)
 
 func (n *LeadNote) Update(content string) error {
 	if content == "" {
-		return errors.New("content is required")
+		return sharedDomain.Invalid("content", "content is required")
 	}
 	n.Content = content
 	n.ModifiedAt = time.Now()
 	return nil
-}
\ No newline at end of file
+}
+
+const RedactedContent = "[redacted]"
+
+func (n *LeadNote) Redact() {
+	n.Content = RedactedContent
+	n.ModifiedAt = time.Now()
+}
+
+func (n *LeadNote) PersonalFields() []string {
+	return []string{"Content"}
+}
@@ -1,6 +1,12 @@
 package input
 
-import "torque-dms/core/sales/domain"
+import (
+	"context"
+	"time"
+
+	"torque-dms/core/sales/domain"
+	sharedDomain "torque-dms/core/shared/domain"
+)
 
 type CreateLeadInput struct {
 	EntityID      uint
@@ -24,6 +30,7 @@ type UpdateLeadInput struct {
 	BudgetMin     *float64
 	BudgetMax     *float64
 	SourceDetail  *string
+	Version       *uint
 }
 
 type CreateLeadSourceInput struct {
@@ -59,36 +66,41 @@ type AddActivityInput struct {
 
 type LeadService interface {
 	// Lead CRUD
-	Create(input CreateLeadInput) (*domain.Lead, error)
-	GetByID(id uint) (*domain.Lead, error)
-	Update(id uint, input UpdateLeadInput) (*domain.Lead, error)
-	Delete(id uint) error
-	List(limit int, offset int) ([]*domain.Lead, error)
-	ListByEntity(entityID uint) ([]*domain.Lead, error)
+	Create(ctx context.Context, input CreateLeadInput) (*domain.Lead, error)
+	GetByID(ctx context.Context, id uint) (*domain.Lead, error)
+	Update(ctx context.Context, id uint, input UpdateLeadInput) (*domain.Lead, error)
+	Delete(ctx context.Context, id uint) error
+	List(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.Lead], error)
+	ListByEntity(ctx context.Context, entityID uint) ([]*domain.Lead, error)
+
+	// Trash
+	ListDeleted(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.Lead], error)
+	Restore(ctx context.Context, id uint) (*domain.Lead, error)
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int, error)
 
 	// Lead Sources
-	CreateSource(input CreateLeadSourceInput) (*domain.LeadSource, error)
-	GetSources() ([]*domain.LeadSource, error)
-	GetActiveSources() ([]*domain.LeadSource, error)
-	DeactivateSource(id uint) error
-	ActivateSource(id uint) error
+	CreateSource(ctx context.Context, input CreateLeadSourceInput) (*domain.LeadSource, error)
+	GetSources(ctx context.Context) ([]*domain.LeadSource, error)
+	GetActiveSources(ctx context.Context) ([]*domain.LeadSource, error)
+	DeactivateSource(ctx context.Context, id uint) error
+	ActivateSource(ctx context.Context, id uint) error
 
 	// Assignments
-	Assign(input AssignLeadInput) (*domain.LeadAssignment, error)
-	GetAssignments(leadID uint) ([]*domain.LeadAssignment, error)
-	RemoveAssignment(assignmentID uint) error
-	SetPrimaryAssignment(leadID uint, assignmentID uint) error
+	Assign(ctx context.Context, input AssignLeadInput) (*domain.LeadAssignment, error)
+	GetAssignments(ctx context.Context, leadID uint) ([]*domain.LeadAssignment, error)
+	RemoveAssignment(ctx context.Context, assignmentID uint) error
+	SetPrimaryAssignment(ctx context.Context, leadID uint, assignmentID uint) error
 
 	// Notes
-	AddNote(input AddNoteInput) (*domain.LeadNote, error)
-	GetNotes(leadID uint) ([]*domain.LeadNote, error)
-	UpdateNote(noteID uint, content string) (*domain.LeadNote, error)
-	DeleteNote(noteID uint) error
+	AddNote(ctx context.Context, input AddNoteInput) (*domain.LeadNote, error)
+	GetNotes(ctx context.Context, leadID uint) ([]*domain.LeadNote, error)
+	UpdateNote(ctx context.Context, noteID uint, content string) (*domain.LeadNote, error)
+	DeleteNote(ctx context.Context, noteID uint) error
 
 	// Activities
-	AddActivity(input AddActivityInput) (*domain.LeadActivity, error)
-	GetActivities(leadID uint) ([]*domain.LeadActivity, error)
-	CompleteActivity(activityID uint) error
-	GetScheduledActivities(entityID uint) ([]*domain.LeadActivity, error)
-	GetOverdueActivities() ([]*domain.LeadActivity, error)
+	AddActivity(ctx context.Context, input AddActivityInput) (*domain.LeadActivity, error)
+	GetActivities(ctx context.Context, leadID uint, q sharedDomain.Query) (*sharedDomain.Page[*domain.LeadActivity], error)
+	CompleteActivity(ctx context.Context, activityID uint) error
+	GetScheduledActivities(ctx context.Context, entityID uint) ([]*domain.LeadActivity, error)
+	GetOverdueActivities(ctx context.Context) ([]*domain.LeadActivity, error)
 }
\ No newline at end of file
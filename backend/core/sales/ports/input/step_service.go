@@ -1,6 +1,12 @@
 package input
 
-import "torque-dms/core/sales/domain"
+import (
+	"context"
+	"time"
+
+	"torque-dms/core/sales/domain"
+	sharedDomain "torque-dms/core/shared/domain"
+)
 
 type CreatePresetInput struct {
 	Code        string
@@ -29,29 +35,32 @@ type UpdateProgressInput struct {
 
 type StepService interface {
 	// Presets
-	CreatePreset(input CreatePresetInput) (*domain.LeadStepPreset, error)
-	GetPreset(id uint) (*domain.LeadStepPreset, error)
-	GetPresets() ([]*domain.LeadStepPreset, error)
-	GetPublicPresets() ([]*domain.LeadStepPreset, error)
-	GetMyPresets(entityID uint) ([]*domain.LeadStepPreset, error)
-	DeletePreset(id uint) error
-	MakePresetPublic(id uint) error
-	MakePresetShared(id uint) error
-	MakePresetPrivate(id uint) error
+	CreatePreset(ctx context.Context, input CreatePresetInput) (*domain.LeadStepPreset, error)
+	GetPreset(ctx context.Context, id uint) (*domain.LeadStepPreset, error)
+	GetPresets(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.LeadStepPreset], error)
+	GetPublicPresets(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.LeadStepPreset], error)
+	GetMyPresets(ctx context.Context, entityID uint, q sharedDomain.Query) (*sharedDomain.Page[*domain.LeadStepPreset], error)
+	DeletePreset(ctx context.Context, id uint) error
+	MakePresetPublic(ctx context.Context, id uint) error
+	MakePresetShared(ctx context.Context, id uint) error
+	MakePresetPrivate(ctx context.Context, id uint) error
+	ListDeletedPresets(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.LeadStepPreset], error)
+	RestorePreset(ctx context.Context, id uint) (*domain.LeadStepPreset, error)
+	PurgeDeletedPresets(ctx context.Context, deletedBefore time.Time) (int, error)
 
 	// Steps
-	CreateStep(input CreateStepInput) (*domain.LeadStep, error)
-	GetSteps(presetID uint) ([]*domain.LeadStep, error)
-	GetActiveSteps(presetID uint) ([]*domain.LeadStep, error)
-	DeactivateStep(id uint) error
-	ActivateStep(id uint) error
-	DeleteStep(id uint) error
+	CreateStep(ctx context.Context, input CreateStepInput) (*domain.LeadStep, error)
+	GetSteps(ctx context.Context, presetID uint) ([]*domain.LeadStep, error)
+	GetActiveSteps(ctx context.Context, presetID uint) ([]*domain.LeadStep, error)
+	DeactivateStep(ctx context.Context, id uint) error
+	ActivateStep(ctx context.Context, id uint) error
+	DeleteStep(ctx context.Context, id uint) error
 
 	// Progress
-	InitializeProgress(leadID uint, presetID uint) error
-	GetProgress(leadID uint) ([]*domain.LeadStepProgress, error)
-	UpdateProgress(input UpdateProgressInput) (*domain.LeadStepProgress, error)
-	CompleteStep(leadID uint, stepID uint, completedBy uint, notes string) error
-	SkipStep(leadID uint, stepID uint, completedBy uint, notes string) error
-	FailStep(leadID uint, stepID uint, completedBy uint, notes string) error
+	InitializeProgress(ctx context.Context, leadID uint, presetID uint) error
+	GetProgress(ctx context.Context, leadID uint) ([]*domain.LeadStepProgress, error)
+	UpdateProgress(ctx context.Context, input UpdateProgressInput) (*domain.LeadStepProgress, error)
+	CompleteStep(ctx context.Context, leadID uint, stepID uint, completedBy uint, notes string) error
+	SkipStep(ctx context.Context, leadID uint, stepID uint, completedBy uint, notes string) error
+	FailStep(ctx context.Context, leadID uint, stepID uint, completedBy uint, notes string) error
 }
\ No newline at end of file
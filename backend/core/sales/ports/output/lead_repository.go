@@ -1,52 +1,61 @@
 package output
 
-import "torque-dms/core/sales/domain"
+import (
+	"context"
+	"time"
+
+	"torque-dms/core/sales/domain"
+	sharedDomain "torque-dms/core/shared/domain"
+)
 
 type LeadRepository interface {
-	Save(lead *domain.Lead) error
-	Update(lead *domain.Lead) error
-	FindByID(id uint) (*domain.Lead, error)
-	FindByEntityID(entityID uint) ([]*domain.Lead, error)
-	FindAll(limit int, offset int) ([]*domain.Lead, error)
-	Delete(id uint) error
-	Exists(id uint) (bool, error)
+	Save(ctx context.Context, lead *domain.Lead) error
+	Update(ctx context.Context, lead *domain.Lead) error
+	FindByID(ctx context.Context, id uint) (*domain.Lead, error)
+	FindByEntityID(ctx context.Context, entityID uint) ([]*domain.Lead, error)
+	FindAll(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.Lead], error)
+	FindDeleted(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.Lead], error)
+	FindDeletedBefore(ctx context.Context, before time.Time) ([]*domain.Lead, error)
+	Purge(ctx context.Context, id uint) error
+	Exists(ctx context.Context, id uint) (bool, error)
 }
 
 type LeadSourceRepository interface {
-	Save(source *domain.LeadSource) error
-	Update(source *domain.LeadSource) error
-	FindByID(id uint) (*domain.LeadSource, error)
-	FindByCode(code string) (*domain.LeadSource, error)
-	FindAll() ([]*domain.LeadSource, error)
-	FindActive() ([]*domain.LeadSource, error)
-	Delete(id uint) error
-	Exists(id uint) (bool, error)
+	Save(ctx context.Context, source *domain.LeadSource) error
+	Update(ctx context.Context, source *domain.LeadSource) error
+	FindByID(ctx context.Context, id uint) (*domain.LeadSource, error)
+	FindByCode(ctx context.Context, code string) (*domain.LeadSource, error)
+	FindAll(ctx context.Context) ([]*domain.LeadSource, error)
+	FindActive(ctx context.Context) ([]*domain.LeadSource, error)
+	Delete(ctx context.Context, id uint) error
+	Exists(ctx context.Context, id uint) (bool, error)
 }
 
 type LeadAssignmentRepository interface {
-	Save(assignment *domain.LeadAssignment) error
-	Update(assignment *domain.LeadAssignment) error
-	FindByID(id uint) (*domain.LeadAssignment, error)
-	FindByLeadID(leadID uint) ([]*domain.LeadAssignment, error)
-	FindPrimaryByLeadID(leadID uint) (*domain.LeadAssignment, error)
-	FindByEntityID(entityID uint) ([]*domain.LeadAssignment, error)
-	Delete(id uint) error
+	Save(ctx context.Context, assignment *domain.LeadAssignment) error
+	Update(ctx context.Context, assignment *domain.LeadAssignment) error
+	FindByID(ctx context.Context, id uint) (*domain.LeadAssignment, error)
+	FindByLeadID(ctx context.Context, leadID uint) ([]*domain.LeadAssignment, error)
+	FindPrimaryByLeadID(ctx context.Context, leadID uint) (*domain.LeadAssignment, error)
+	FindByEntityID(ctx context.Context, entityID uint) ([]*domain.LeadAssignment, error)
+	Delete(ctx context.Context, id uint) error
 }
 
 type LeadNoteRepository interface {
-	Save(note *domain.LeadNote) error
-	Update(note *domain.LeadNote) error
-	FindByID(id uint) (*domain.LeadNote, error)
-	FindByLeadID(leadID uint) ([]*domain.LeadNote, error)
-	Delete(id uint) error
+	Save(ctx context.Context, note *domain.LeadNote) error
+	Update(ctx context.Context, note *domain.LeadNote) error
+	FindByID(ctx context.Context, id uint) (*domain.LeadNote, error)
+	FindByLeadID(ctx context.Context, leadID uint) ([]*domain.LeadNote, error)
+	Delete(ctx context.Context, id uint) error
 }
 
 type LeadActivityRepository interface {
-	Save(activity *domain.LeadActivity) error
-	Update(activity *domain.LeadActivity) error
-	FindByID(id uint) (*domain.LeadActivity, error)
-	FindByLeadID(leadID uint) ([]*domain.LeadActivity, error)
-	FindScheduledByEntityID(entityID uint) ([]*domain.LeadActivity, error)
-	FindOverdue() ([]*domain.LeadActivity, error)
-	Delete(id uint) error
+	Save(ctx context.Context, activity *domain.LeadActivity) error
+	Update(ctx context.Context, activity *domain.LeadActivity) error
+	FindByID(ctx context.Context, id uint) (*domain.LeadActivity, error)
+	FindByLeadID(ctx context.Context, leadID uint) ([]*domain.LeadActivity, error)
+	FindPageByLeadID(ctx context.Context, leadID uint, q sharedDomain.Query) (*sharedDomain.Page[*domain.LeadActivity], error)
+	FindScheduledByEntityID(ctx context.Context, entityID uint) ([]*domain.LeadActivity, error)
+	FindOverdue(ctx context.Context) ([]*domain.LeadActivity, error)
+	Delete(ctx context.Context, id uint) error
 }
\ No newline at end of file
@@ -1,34 +1,40 @@
 package output
 
-import "torque-dms/core/sales/domain"
+import (
+	"context"
+	"time"
+
+	"torque-dms/core/sales/domain"
+	sharedDomain "torque-dms/core/shared/domain"
+)
 
 type LeadStepPresetRepository interface {
-	Save(preset *domain.LeadStepPreset) error
-	Update(preset *domain.LeadStepPreset) error
-	FindByID(id uint) (*domain.LeadStepPreset, error)
-	FindByCode(code string) (*domain.LeadStepPreset, error)
-	FindAll() ([]*domain.LeadStepPreset, error)
-	FindPublic() ([]*domain.LeadStepPreset, error)
-	FindByCreatedBy(entityID uint) ([]*domain.LeadStepPreset, error)
-	Delete(id uint) error
-	Exists(id uint) (bool, error)
+	Save(ctx context.Context, preset *domain.LeadStepPreset) error
+	Update(ctx context.Context, preset *domain.LeadStepPreset) error
+	FindByID(ctx context.Context, id uint) (*domain.LeadStepPreset, error)
+	FindByCode(ctx context.Context, code string) (*domain.LeadStepPreset, error)
+	FindAll(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.LeadStepPreset], error)
+	FindDeleted(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.LeadStepPreset], error)
+	FindDeletedBefore(ctx context.Context, before time.Time) ([]*domain.LeadStepPreset, error)
+	Purge(ctx context.Context, id uint) error
+	Exists(ctx context.Context, id uint) (bool, error)
 }
 
 type LeadStepRepository interface {
-	Save(step *domain.LeadStep) error
-	Update(step *domain.LeadStep) error
-	FindByID(id uint) (*domain.LeadStep, error)
-	FindByPresetID(presetID uint) ([]*domain.LeadStep, error)
-	FindActiveByPresetID(presetID uint) ([]*domain.LeadStep, error)
-	Delete(id uint) error
-	Exists(id uint) (bool, error)
+	Save(ctx context.Context, step *domain.LeadStep) error
+	Update(ctx context.Context, step *domain.LeadStep) error
+	FindByID(ctx context.Context, id uint) (*domain.LeadStep, error)
+	FindByPresetID(ctx context.Context, presetID uint) ([]*domain.LeadStep, error)
+	FindActiveByPresetID(ctx context.Context, presetID uint) ([]*domain.LeadStep, error)
+	Delete(ctx context.Context, id uint) error
+	Exists(ctx context.Context, id uint) (bool, error)
 }
 
 type LeadStepProgressRepository interface {
-	Save(progress *domain.LeadStepProgress) error
-	Update(progress *domain.LeadStepProgress) error
-	FindByID(id uint) (*domain.LeadStepProgress, error)
-	FindByLeadID(leadID uint) ([]*domain.LeadStepProgress, error)
-	FindByLeadIDAndStepID(leadID uint, stepID uint) (*domain.LeadStepProgress, error)
-	Delete(id uint) error
+	Save(ctx context.Context, progress *domain.LeadStepProgress) error
+	Update(ctx context.Context, progress *domain.LeadStepProgress) error
+	FindByID(ctx context.Context, id uint) (*domain.LeadStepProgress, error)
+	FindByLeadID(ctx context.Context, leadID uint) ([]*domain.LeadStepProgress, error)
+	FindByLeadIDAndStepID(ctx context.Context, leadID uint, stepID uint) (*domain.LeadStepProgress, error)
+	Delete(ctx context.Context, id uint) error
 }
\ No newline at end of file
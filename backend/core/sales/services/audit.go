@@ -0,0 +1,13 @@
+package services
+
+// Tipos de agregado con los que sales escribe en el log de auditoría
+const (
+	leadAggregate           = "lead"
+	leadSourceAggregate     = "lead_source"
+	leadAssignmentAggregate = "lead_assignment"
+	leadNoteAggregate       = "lead_note"
+	leadActivityAggregate   = "lead_activity"
+	stepPresetAggregate     = "lead_step_preset"
+	stepAggregate           = "lead_step"
+	stepProgressAggregate   = "lead_step_progress"
+)
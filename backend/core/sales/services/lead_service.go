@@ -1,12 +1,18 @@
 package services
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"time"
 
+	auditDomain "torque-dms/core/audit/domain"
+	auditInput "torque-dms/core/audit/ports/input"
 	"torque-dms/core/sales/domain"
 	"torque-dms/core/sales/ports/input"
 	"torque-dms/core/sales/ports/output"
+	sharedDomain "torque-dms/core/shared/domain"
+	sharedOutput "torque-dms/core/shared/ports/output"
 )
 
 type leadService struct {
@@ -15,6 +21,8 @@ type leadService struct {
 	assignmentRepo output.LeadAssignmentRepository
 	noteRepo       output.LeadNoteRepository
 	activityRepo   output.LeadActivityRepository
+	auditService   auditInput.AuditService
+	uow            sharedOutput.UnitOfWork
 }
 
 func NewLeadService(
@@ -23,6 +31,8 @@ func NewLeadService(
 	assignmentRepo output.LeadAssignmentRepository,
 	noteRepo output.LeadNoteRepository,
 	activityRepo output.LeadActivityRepository,
+	auditService auditInput.AuditService,
+	uow sharedOutput.UnitOfWork,
 ) input.LeadService {
 	return &leadService{
 		leadRepo:       leadRepo,
@@ -30,394 +40,583 @@ func NewLeadService(
 		assignmentRepo: assignmentRepo,
 		noteRepo:       noteRepo,
 		activityRepo:   activityRepo,
+		auditService:   auditService,
+		uow:            uow,
 	}
 }
 
 // Lead CRUD
 
-func (s *leadService) Create(inp input.CreateLeadInput) (*domain.Lead, error) {
-	// Verificar que source exista
-	sourceExists, err := s.sourceRepo.Exists(inp.SourceID)
-	if err != nil {
-		return nil, err
-	}
-	if !sourceExists {
-		return nil, errors.New("source not found")
-	}
+func (s *leadService) Create(ctx context.Context, inp input.CreateLeadInput) (*domain.Lead, error) {
+	var lead *domain.Lead
 
-	lead, err := domain.NewLead(inp.EntityID, inp.SourceID)
-	if err != nil {
-		return nil, err
-	}
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		// Verificar que source exista
+		sourceExists, err := s.sourceRepo.Exists(ctx, inp.SourceID)
+		if err != nil {
+			return err
+		}
+		if !sourceExists {
+			return sharedDomain.NotFound("source")
+		}
 
-	if inp.VehicleID != nil {
-		lead.SetVehicle(*inp.VehicleID)
-	}
+		lead, err = domain.NewLead(inp.EntityID, inp.SourceID)
+		if err != nil {
+			return err
+		}
 
-	lead.SetInterest(inp.InterestType, inp.InterestMake, inp.InterestModel)
+		if inp.VehicleID != nil {
+			lead.SetVehicle(*inp.VehicleID)
+		}
+
+		lead.SetInterest(inp.InterestType, inp.InterestMake, inp.InterestModel)
 
-	if inp.BudgetMin > 0 || inp.BudgetMax > 0 {
-		if err := lead.SetBudget(inp.BudgetMin, inp.BudgetMax); err != nil {
-			return nil, err
+		if inp.BudgetMin > 0 || inp.BudgetMax > 0 {
+			if err := lead.SetBudget(inp.BudgetMin, inp.BudgetMax); err != nil {
+				return err
+			}
 		}
-	}
 
-	lead.SourceDetail = inp.SourceDetail
+		lead.SourceDetail = inp.SourceDetail
 
-	if inp.PresetID != nil {
-		lead.SetPreset(*inp.PresetID)
-	}
+		if inp.PresetID != nil {
+			lead.SetPreset(*inp.PresetID)
+		}
 
-	if err := s.leadRepo.Save(lead); err != nil {
-		return nil, err
-	}
+		if err := s.leadRepo.Save(ctx, lead); err != nil {
+			return err
+		}
 
-	// Si hay asignación inicial
-	if inp.AssignedTo > 0 {
-		assignment, err := domain.NewLeadAssignment(
-			lead.ID,
-			inp.AssignedTo,
-			domain.AssignmentRoleSalesperson,
-			inp.AssignedTo,
-		)
-		if err != nil {
-			return nil, err
+		if err := s.auditService.Record(ctx, auditDomain.ActionCreate, leadAggregate, lead.ID, nil, lead); err != nil {
+			return err
 		}
-		assignment.SetAsPrimary()
-		if err := s.assignmentRepo.Save(assignment); err != nil {
-			return nil, err
+
+		// Si hay asignación inicial
+		if inp.AssignedTo > 0 {
+			assignment, err := domain.NewLeadAssignment(
+				lead.ID,
+				inp.AssignedTo,
+				domain.AssignmentRoleSalesperson,
+				inp.AssignedTo,
+			)
+			if err != nil {
+				return err
+			}
+			assignment.SetAsPrimary()
+			if err := s.assignmentRepo.Save(ctx, assignment); err != nil {
+				return err
+			}
+			if err := s.auditService.Record(ctx, auditDomain.ActionCreate, leadAssignmentAggregate, assignment.ID, nil, assignment); err != nil {
+				return err
+			}
 		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return lead, nil
 }
 
-func (s *leadService) GetByID(id uint) (*domain.Lead, error) {
-	return s.leadRepo.FindByID(id)
+func (s *leadService) GetByID(ctx context.Context, id uint) (*domain.Lead, error) {
+	return s.leadRepo.FindByID(ctx, id)
 }
 
-func (s *leadService) Update(id uint, inp input.UpdateLeadInput) (*domain.Lead, error) {
-	lead, err := s.leadRepo.FindByID(id)
-	if err != nil {
-		return nil, errors.New("lead not found")
-	}
-
-	if inp.VehicleID != nil {
-		if *inp.VehicleID == 0 {
-			lead.RemoveVehicle()
-		} else {
-			lead.SetVehicle(*inp.VehicleID)
+func (s *leadService) Update(ctx context.Context, id uint, inp input.UpdateLeadInput) (*domain.Lead, error) {
+	var lead *domain.Lead
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		lead, err = s.leadRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
 		}
-	}
+		if err := sharedDomain.CheckVersion(inp.Version, lead.Version); err != nil {
+			return err
+		}
+		before := *lead
 
-	interestType := lead.InterestType
-	interestMake := lead.InterestMake
-	interestModel := lead.InterestModel
+		if inp.VehicleID != nil {
+			if *inp.VehicleID == 0 {
+				lead.RemoveVehicle()
+			} else {
+				lead.SetVehicle(*inp.VehicleID)
+			}
+		}
 
-	if inp.InterestType != nil {
-		interestType = *inp.InterestType
-	}
-	if inp.InterestMake != nil {
-		interestMake = *inp.InterestMake
-	}
-	if inp.InterestModel != nil {
-		interestModel = *inp.InterestModel
-	}
-	lead.SetInterest(interestType, interestMake, interestModel)
+		interestType := lead.InterestType
+		interestMake := lead.InterestMake
+		interestModel := lead.InterestModel
 
-	if inp.BudgetMin != nil || inp.BudgetMax != nil {
-		budgetMin := lead.BudgetMin
-		budgetMax := lead.BudgetMax
-		if inp.BudgetMin != nil {
-			budgetMin = *inp.BudgetMin
+		if inp.InterestType != nil {
+			interestType = *inp.InterestType
 		}
-		if inp.BudgetMax != nil {
-			budgetMax = *inp.BudgetMax
+		if inp.InterestMake != nil {
+			interestMake = *inp.InterestMake
 		}
-		if err := lead.SetBudget(budgetMin, budgetMax); err != nil {
-			return nil, err
+		if inp.InterestModel != nil {
+			interestModel = *inp.InterestModel
 		}
-	}
+		lead.SetInterest(interestType, interestMake, interestModel)
 
-	if inp.SourceDetail != nil {
-		lead.SourceDetail = *inp.SourceDetail
-	}
+		if inp.BudgetMin != nil || inp.BudgetMax != nil {
+			budgetMin := lead.BudgetMin
+			budgetMax := lead.BudgetMax
+			if inp.BudgetMin != nil {
+				budgetMin = *inp.BudgetMin
+			}
+			if inp.BudgetMax != nil {
+				budgetMax = *inp.BudgetMax
+			}
+			if err := lead.SetBudget(budgetMin, budgetMax); err != nil {
+				return err
+			}
+		}
+
+		if inp.SourceDetail != nil {
+			lead.SourceDetail = *inp.SourceDetail
+		}
 
-	lead.ModifiedAt = time.Now()
+		lead.ModifiedAt = time.Now()
 
-	if err := s.leadRepo.Update(lead); err != nil {
+		if err := s.leadRepo.Update(ctx, lead); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, leadAggregate, lead.ID, before, lead)
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return lead, nil
 }
 
-func (s *leadService) Delete(id uint) error {
-	exists, err := s.leadRepo.Exists(id)
+func (s *leadService) Delete(ctx context.Context, id uint) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		lead, err := s.leadRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		before := *lead
+
+		lead.SoftDelete(sharedDomain.ActorFromContext(ctx).EntityID)
+		if err := s.leadRepo.Update(ctx, lead); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionDelete, leadAggregate, id, before, nil)
+	})
+}
+
+func (s *leadService) List(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.Lead], error) {
+	q = q.Normalize(10, 100)
+	return s.leadRepo.FindAll(ctx, q)
+}
+
+func (s *leadService) ListByEntity(ctx context.Context, entityID uint) ([]*domain.Lead, error) {
+	return s.leadRepo.FindByEntityID(ctx, entityID)
+}
+
+func (s *leadService) ListDeleted(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.Lead], error) {
+	q = q.Normalize(10, 100)
+	return s.leadRepo.FindDeleted(ctx, q)
+}
+
+func (s *leadService) Restore(ctx context.Context, id uint) (*domain.Lead, error) {
+	ctx = sharedDomain.WithDeleted(ctx)
+
+	var lead *domain.Lead
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		lead, err = s.leadRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		before := *lead
+
+		if err := lead.Restore(); err != nil {
+			return err
+		}
+
+		if err := s.leadRepo.Update(ctx, lead); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionRestore, leadAggregate, id, before, lead)
+	})
 	if err != nil {
-		return err
-	}
-	if !exists {
-		return errors.New("lead not found")
+		return nil, err
 	}
 
-	return s.leadRepo.Delete(id)
+	return lead, nil
 }
 
-func (s *leadService) List(limit int, offset int) ([]*domain.Lead, error) {
-	if limit <= 0 {
-		limit = 10
+// PurgeDeleted - notas, actividades, asignaciones y progreso caen en cascada con el lead
+func (s *leadService) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int, error) {
+	deleted, err := s.leadRepo.FindDeletedBefore(ctx, deletedBefore)
+	if err != nil {
+		return 0, err
 	}
-	if limit > 100 {
-		limit = 100
+
+	purged := 0
+	var errs []error
+	for _, lead := range deleted {
+		err := s.uow.Do(ctx, func(ctx context.Context) error {
+			if err := s.leadRepo.Purge(ctx, lead.ID); err != nil {
+				return err
+			}
+			return s.auditService.Record(ctx, auditDomain.ActionPurge, leadAggregate, lead.ID, nil, nil)
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("lead %d: %w", lead.ID, err))
+			continue
+		}
+		purged++
 	}
-	return s.leadRepo.FindAll(limit, offset)
-}
 
-func (s *leadService) ListByEntity(entityID uint) ([]*domain.Lead, error) {
-	return s.leadRepo.FindByEntityID(entityID)
+	return purged, errors.Join(errs...)
 }
 
 // Lead Sources
 
-func (s *leadService) CreateSource(inp input.CreateLeadSourceInput) (*domain.LeadSource, error) {
-	source, err := domain.NewLeadSource(inp.Code, inp.Name, inp.IsExternal)
-	if err != nil {
-		return nil, err
-	}
+func (s *leadService) CreateSource(ctx context.Context, inp input.CreateLeadSourceInput) (*domain.LeadSource, error) {
+	var source *domain.LeadSource
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		source, err = domain.NewLeadSource(inp.Code, inp.Name, inp.IsExternal)
+		if err != nil {
+			return err
+		}
+
+		if err := s.sourceRepo.Save(ctx, source); err != nil {
+			return err
+		}
 
-	if err := s.sourceRepo.Save(source); err != nil {
+		return s.auditService.Record(ctx, auditDomain.ActionCreate, leadSourceAggregate, source.ID, nil, source)
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return source, nil
 }
 
-func (s *leadService) GetSources() ([]*domain.LeadSource, error) {
-	return s.sourceRepo.FindAll()
+func (s *leadService) GetSources(ctx context.Context) ([]*domain.LeadSource, error) {
+	return s.sourceRepo.FindAll(ctx)
 }
 
-func (s *leadService) GetActiveSources() ([]*domain.LeadSource, error) {
-	return s.sourceRepo.FindActive()
+func (s *leadService) GetActiveSources(ctx context.Context) ([]*domain.LeadSource, error) {
+	return s.sourceRepo.FindActive(ctx)
 }
 
-func (s *leadService) DeactivateSource(id uint) error {
-	source, err := s.sourceRepo.FindByID(id)
-	if err != nil {
-		return errors.New("source not found")
-	}
+func (s *leadService) DeactivateSource(ctx context.Context, id uint) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		source, err := s.sourceRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		before := *source
 
-	source.Deactivate()
-	return s.sourceRepo.Update(source)
+		source.Deactivate()
+		if err := s.sourceRepo.Update(ctx, source); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, leadSourceAggregate, id, before, source)
+	})
 }
 
-func (s *leadService) ActivateSource(id uint) error {
-	source, err := s.sourceRepo.FindByID(id)
-	if err != nil {
-		return errors.New("source not found")
-	}
+func (s *leadService) ActivateSource(ctx context.Context, id uint) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		source, err := s.sourceRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		before := *source
 
-	source.Activate()
-	return s.sourceRepo.Update(source)
+		source.Activate()
+		if err := s.sourceRepo.Update(ctx, source); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, leadSourceAggregate, id, before, source)
+	})
 }
 
 // Assignments
 
-func (s *leadService) Assign(inp input.AssignLeadInput) (*domain.LeadAssignment, error) {
-	// Verificar que lead exista
-	exists, err := s.leadRepo.Exists(inp.LeadID)
-	if err != nil {
-		return nil, err
-	}
-	if !exists {
-		return nil, errors.New("lead not found")
-	}
+func (s *leadService) Assign(ctx context.Context, inp input.AssignLeadInput) (*domain.LeadAssignment, error) {
+	var assignment *domain.LeadAssignment
 
-	assignment, err := domain.NewLeadAssignment(
-		inp.LeadID,
-		inp.EntityID,
-		domain.AssignmentRole(inp.Role),
-		inp.AssignedBy,
-	)
-	if err != nil {
-		return nil, err
-	}
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		// Verificar que lead exista
+		exists, err := s.leadRepo.Exists(ctx, inp.LeadID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return sharedDomain.NotFound("lead")
+		}
 
-	if inp.IsPrimary {
-		// Quitar primary de otros
-		existingAssignments, err := s.assignmentRepo.FindByLeadID(inp.LeadID)
+		assignment, err = domain.NewLeadAssignment(
+			inp.LeadID,
+			inp.EntityID,
+			domain.AssignmentRole(inp.Role),
+			inp.AssignedBy,
+		)
 		if err != nil {
-			return nil, err
+			return err
 		}
-		for _, a := range existingAssignments {
-			if a.IsPrimary {
-				a.RemovePrimary()
-				s.assignmentRepo.Update(a)
+
+		if inp.IsPrimary {
+			// Quitar primary de otros
+			existingAssignments, err := s.assignmentRepo.FindByLeadID(ctx, inp.LeadID)
+			if err != nil {
+				return err
 			}
+			for _, a := range existingAssignments {
+				if a.IsPrimary {
+					previous := *a
+					a.RemovePrimary()
+					if err := s.assignmentRepo.Update(ctx, a); err != nil {
+						return err
+					}
+					if err := s.auditService.Record(ctx, auditDomain.ActionUpdate, leadAssignmentAggregate, a.ID, previous, a); err != nil {
+						return err
+					}
+				}
+			}
+			assignment.SetAsPrimary()
+		}
+
+		if err := s.assignmentRepo.Save(ctx, assignment); err != nil {
+			return err
 		}
-		assignment.SetAsPrimary()
-	}
 
-	if err := s.assignmentRepo.Save(assignment); err != nil {
+		return s.auditService.Record(ctx, auditDomain.ActionCreate, leadAssignmentAggregate, assignment.ID, nil, assignment)
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return assignment, nil
 }
 
-func (s *leadService) GetAssignments(leadID uint) ([]*domain.LeadAssignment, error) {
-	return s.assignmentRepo.FindByLeadID(leadID)
+func (s *leadService) GetAssignments(ctx context.Context, leadID uint) ([]*domain.LeadAssignment, error) {
+	return s.assignmentRepo.FindByLeadID(ctx, leadID)
 }
 
-func (s *leadService) RemoveAssignment(assignmentID uint) error {
-	assignment, err := s.assignmentRepo.FindByID(assignmentID)
-	if err != nil {
-		return errors.New("assignment not found")
-	}
+func (s *leadService) RemoveAssignment(ctx context.Context, assignmentID uint) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		assignment, err := s.assignmentRepo.FindByID(ctx, assignmentID)
+		if err != nil {
+			return err
+		}
+		before := *assignment
 
-	assignment.Deactivate()
-	return s.assignmentRepo.Update(assignment)
+		assignment.Deactivate()
+		if err := s.assignmentRepo.Update(ctx, assignment); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, leadAssignmentAggregate, assignmentID, before, assignment)
+	})
 }
 
-func (s *leadService) SetPrimaryAssignment(leadID uint, assignmentID uint) error {
-	assignment, err := s.assignmentRepo.FindByID(assignmentID)
-	if err != nil {
-		return errors.New("assignment not found")
-	}
+func (s *leadService) SetPrimaryAssignment(ctx context.Context, leadID uint, assignmentID uint) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		assignment, err := s.assignmentRepo.FindByID(ctx, assignmentID)
+		if err != nil {
+			return err
+		}
 
-	if assignment.LeadID != leadID {
-		return errors.New("assignment does not belong to this lead")
-	}
+		if assignment.LeadID != leadID {
+			return sharedDomain.Invariant("assignment_lead_mismatch", "assignment does not belong to this lead")
+		}
 
-	// Quitar primary de otros
-	existingAssignments, err := s.assignmentRepo.FindByLeadID(leadID)
-	if err != nil {
-		return err
-	}
-	for _, a := range existingAssignments {
-		if a.IsPrimary && a.ID != assignmentID {
-			a.RemovePrimary()
-			s.assignmentRepo.Update(a)
+		// Quitar primary de otros
+		existingAssignments, err := s.assignmentRepo.FindByLeadID(ctx, leadID)
+		if err != nil {
+			return err
+		}
+		for _, a := range existingAssignments {
+			if a.IsPrimary && a.ID != assignmentID {
+				previous := *a
+				a.RemovePrimary()
+				if err := s.assignmentRepo.Update(ctx, a); err != nil {
+					return err
+				}
+				if err := s.auditService.Record(ctx, auditDomain.ActionUpdate, leadAssignmentAggregate, a.ID, previous, a); err != nil {
+					return err
+				}
+			}
 		}
-	}
 
-	assignment.SetAsPrimary()
-	return s.assignmentRepo.Update(assignment)
+		before := *assignment
+		assignment.SetAsPrimary()
+		if err := s.assignmentRepo.Update(ctx, assignment); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, leadAssignmentAggregate, assignmentID, before, assignment)
+	})
 }
 
 // Notes
 
-func (s *leadService) AddNote(inp input.AddNoteInput) (*domain.LeadNote, error) {
-	exists, err := s.leadRepo.Exists(inp.LeadID)
-	if err != nil {
-		return nil, err
-	}
-	if !exists {
-		return nil, errors.New("lead not found")
-	}
+func (s *leadService) AddNote(ctx context.Context, inp input.AddNoteInput) (*domain.LeadNote, error) {
+	var note *domain.LeadNote
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		exists, err := s.leadRepo.Exists(ctx, inp.LeadID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return sharedDomain.NotFound("lead")
+		}
 
-	note, err := domain.NewLeadNote(inp.LeadID, inp.Content, inp.CreatedBy)
-	if err != nil {
-		return nil, err
-	}
+		note, err = domain.NewLeadNote(inp.LeadID, inp.Content, inp.CreatedBy)
+		if err != nil {
+			return err
+		}
+
+		if err := s.noteRepo.Save(ctx, note); err != nil {
+			return err
+		}
 
-	if err := s.noteRepo.Save(note); err != nil {
+		return s.auditService.Record(ctx, auditDomain.ActionCreate, leadNoteAggregate, note.ID, nil, note)
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return note, nil
 }
 
-func (s *leadService) GetNotes(leadID uint) ([]*domain.LeadNote, error) {
-	return s.noteRepo.FindByLeadID(leadID)
+func (s *leadService) GetNotes(ctx context.Context, leadID uint) ([]*domain.LeadNote, error) {
+	return s.noteRepo.FindByLeadID(ctx, leadID)
 }
 
-func (s *leadService) UpdateNote(noteID uint, content string) (*domain.LeadNote, error) {
-	note, err := s.noteRepo.FindByID(noteID)
-	if err != nil {
-		return nil, errors.New("note not found")
-	}
+func (s *leadService) UpdateNote(ctx context.Context, noteID uint, content string) (*domain.LeadNote, error) {
+	var note *domain.LeadNote
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		note, err = s.noteRepo.FindByID(ctx, noteID)
+		if err != nil {
+			return err
+		}
+		before := *note
 
-	if err := note.Update(content); err != nil {
-		return nil, err
-	}
+		if err := note.Update(content); err != nil {
+			return err
+		}
+
+		if err := s.noteRepo.Update(ctx, note); err != nil {
+			return err
+		}
 
-	if err := s.noteRepo.Update(note); err != nil {
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, leadNoteAggregate, note.ID, before, note)
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return note, nil
 }
 
-func (s *leadService) DeleteNote(noteID uint) error {
-	_, err := s.noteRepo.FindByID(noteID)
-	if err != nil {
-		return errors.New("note not found")
-	}
+func (s *leadService) DeleteNote(ctx context.Context, noteID uint) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		note, err := s.noteRepo.FindByID(ctx, noteID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.noteRepo.Delete(ctx, noteID); err != nil {
+			return err
+		}
 
-	return s.noteRepo.Delete(noteID)
+		return s.auditService.Record(ctx, auditDomain.ActionDelete, leadNoteAggregate, noteID, note, nil)
+	})
 }
 
 // Activities
 
-func (s *leadService) AddActivity(inp input.AddActivityInput) (*domain.LeadActivity, error) {
-	exists, err := s.leadRepo.Exists(inp.LeadID)
-	if err != nil {
-		return nil, err
-	}
-	if !exists {
-		return nil, errors.New("lead not found")
-	}
+func (s *leadService) AddActivity(ctx context.Context, inp input.AddActivityInput) (*domain.LeadActivity, error) {
+	var activity *domain.LeadActivity
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		exists, err := s.leadRepo.Exists(ctx, inp.LeadID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return sharedDomain.NotFound("lead")
+		}
 
-	activity, err := domain.NewLeadActivity(inp.LeadID, domain.ActivityType(inp.Type), inp.PerformedBy)
-	if err != nil {
-		return nil, err
-	}
+		activity, err = domain.NewLeadActivity(inp.LeadID, domain.ActivityType(inp.Type), inp.PerformedBy)
+		if err != nil {
+			return err
+		}
 
-	activity.SetDescription(inp.Description)
-	activity.SetOutcome(inp.Outcome)
+		activity.SetDescription(inp.Description)
+		activity.SetOutcome(inp.Outcome)
 
-	if inp.PhoneID != nil {
-		activity.SetPhone(*inp.PhoneID)
-	}
+		if inp.PhoneID != nil {
+			activity.SetPhone(*inp.PhoneID)
+		}
 
-	if inp.Email != "" {
-		activity.SetEmail(inp.Email)
-	}
+		if inp.Email != "" {
+			activity.SetEmail(inp.Email)
+		}
 
-	if inp.ScheduledAt != nil {
-		scheduledAt, err := time.Parse(time.RFC3339, *inp.ScheduledAt)
-		if err != nil {
-			return nil, errors.New("invalid scheduled_at format")
+		if inp.ScheduledAt != nil {
+			scheduledAt, err := time.Parse(time.RFC3339, *inp.ScheduledAt)
+			if err != nil {
+				return sharedDomain.Invalid("scheduled_at", "invalid scheduled_at format")
+			}
+			activity.Schedule(scheduledAt)
 		}
-		activity.Schedule(scheduledAt)
-	}
 
-	if err := s.activityRepo.Save(activity); err != nil {
+		if err := s.activityRepo.Save(ctx, activity); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionCreate, leadActivityAggregate, activity.ID, nil, activity)
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return activity, nil
 }
 
-func (s *leadService) GetActivities(leadID uint) ([]*domain.LeadActivity, error) {
-	return s.activityRepo.FindByLeadID(leadID)
+func (s *leadService) GetActivities(ctx context.Context, leadID uint, q sharedDomain.Query) (*sharedDomain.Page[*domain.LeadActivity], error) {
+	return s.activityRepo.FindPageByLeadID(ctx, leadID, q.Normalize(20, 100))
 }
 
-func (s *leadService) CompleteActivity(activityID uint) error {
-	activity, err := s.activityRepo.FindByID(activityID)
-	if err != nil {
-		return errors.New("activity not found")
-	}
+func (s *leadService) CompleteActivity(ctx context.Context, activityID uint) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		activity, err := s.activityRepo.FindByID(ctx, activityID)
+		if err != nil {
+			return err
+		}
+		before := *activity
+
+		activity.Complete()
+		if err := s.activityRepo.Update(ctx, activity); err != nil {
+			return err
+		}
 
-	activity.Complete()
-	return s.activityRepo.Update(activity)
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, leadActivityAggregate, activityID, before, activity)
+	})
 }
 
-func (s *leadService) GetScheduledActivities(entityID uint) ([]*domain.LeadActivity, error) {
-	return s.activityRepo.FindScheduledByEntityID(entityID)
+func (s *leadService) GetScheduledActivities(ctx context.Context, entityID uint) ([]*domain.LeadActivity, error) {
+	return s.activityRepo.FindScheduledByEntityID(ctx, entityID)
 }
 
-func (s *leadService) GetOverdueActivities() ([]*domain.LeadActivity, error) {
-	return s.activityRepo.FindOverdue()
+func (s *leadService) GetOverdueActivities(ctx context.Context) ([]*domain.LeadActivity, error) {
+	return s.activityRepo.FindOverdue(ctx)
 }
\ No newline at end of file
@@ -1,11 +1,19 @@
 package services
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"strconv"
+	"time"
 
+	auditDomain "torque-dms/core/audit/domain"
+	auditInput "torque-dms/core/audit/ports/input"
 	"torque-dms/core/sales/domain"
 	"torque-dms/core/sales/ports/input"
 	"torque-dms/core/sales/ports/output"
+	sharedDomain "torque-dms/core/shared/domain"
+	sharedOutput "torque-dms/core/shared/ports/output"
 )
 
 type stepService struct {
@@ -13,6 +21,8 @@ type stepService struct {
 	stepRepo     output.LeadStepRepository
 	progressRepo output.LeadStepProgressRepository
 	leadRepo     output.LeadRepository
+	auditService auditInput.AuditService
+	uow          sharedOutput.UnitOfWork
 }
 
 func NewStepService(
@@ -20,250 +30,412 @@ func NewStepService(
 	stepRepo output.LeadStepRepository,
 	progressRepo output.LeadStepProgressRepository,
 	leadRepo output.LeadRepository,
+	auditService auditInput.AuditService,
+	uow sharedOutput.UnitOfWork,
 ) input.StepService {
 	return &stepService{
 		presetRepo:   presetRepo,
 		stepRepo:     stepRepo,
 		progressRepo: progressRepo,
 		leadRepo:     leadRepo,
+		auditService: auditService,
+		uow:          uow,
 	}
 }
 
 // Presets
 
-func (s *stepService) CreatePreset(inp input.CreatePresetInput) (*domain.LeadStepPreset, error) {
-	preset, err := domain.NewLeadStepPreset(inp.Code, inp.Name, inp.CreatedBy)
-	if err != nil {
-		return nil, err
-	}
+func (s *stepService) CreatePreset(ctx context.Context, inp input.CreatePresetInput) (*domain.LeadStepPreset, error) {
+	var preset *domain.LeadStepPreset
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		preset, err = domain.NewLeadStepPreset(inp.Code, inp.Name, inp.CreatedBy)
+		if err != nil {
+			return err
+		}
 
-	preset.Description = inp.Description
+		preset.Description = inp.Description
 
-	if inp.IsPublic {
-		preset.MakePublic()
-	} else if inp.IsShared {
-		preset.MakeShared()
-	}
+		if inp.IsPublic {
+			preset.MakePublic()
+		} else if inp.IsShared {
+			preset.MakeShared()
+		}
 
-	if err := s.presetRepo.Save(preset); err != nil {
+		if err := s.presetRepo.Save(ctx, preset); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionCreate, stepPresetAggregate, preset.ID, nil, preset)
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return preset, nil
 }
 
-func (s *stepService) GetPreset(id uint) (*domain.LeadStepPreset, error) {
-	return s.presetRepo.FindByID(id)
+func (s *stepService) GetPreset(ctx context.Context, id uint) (*domain.LeadStepPreset, error) {
+	return s.presetRepo.FindByID(ctx, id)
 }
 
-func (s *stepService) GetPresets() ([]*domain.LeadStepPreset, error) {
-	return s.presetRepo.FindAll()
+func (s *stepService) GetPresets(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.LeadStepPreset], error) {
+	return s.presetRepo.FindAll(ctx, q.Normalize(50, 100))
 }
 
-func (s *stepService) GetPublicPresets() ([]*domain.LeadStepPreset, error) {
-	return s.presetRepo.FindPublic()
+func (s *stepService) GetPublicPresets(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.LeadStepPreset], error) {
+	return s.presetRepo.FindAll(ctx, q.Normalize(50, 100).Where("is_public", sharedDomain.FilterEq, "true"))
 }
 
-func (s *stepService) GetMyPresets(entityID uint) ([]*domain.LeadStepPreset, error) {
-	return s.presetRepo.FindByCreatedBy(entityID)
+func (s *stepService) GetMyPresets(ctx context.Context, entityID uint, q sharedDomain.Query) (*sharedDomain.Page[*domain.LeadStepPreset], error) {
+	createdBy := strconv.FormatUint(uint64(entityID), 10)
+	return s.presetRepo.FindAll(ctx, q.Normalize(50, 100).Where("created_by", sharedDomain.FilterEq, createdBy))
 }
 
-func (s *stepService) DeletePreset(id uint) error {
-	exists, err := s.presetRepo.Exists(id)
-	if err != nil {
-		return err
-	}
-	if !exists {
-		return errors.New("preset not found")
-	}
+func (s *stepService) DeletePreset(ctx context.Context, id uint) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		preset, err := s.presetRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
 
-	return s.presetRepo.Delete(id)
+		before := *preset
+
+		preset.SoftDelete(sharedDomain.ActorFromContext(ctx).EntityID)
+		if err := s.presetRepo.Update(ctx, preset); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionDelete, stepPresetAggregate, id, before, nil)
+	})
 }
 
-func (s *stepService) MakePresetPublic(id uint) error {
-	preset, err := s.presetRepo.FindByID(id)
-	if err != nil {
-		return errors.New("preset not found")
-	}
+func (s *stepService) MakePresetPublic(ctx context.Context, id uint) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		preset, err := s.presetRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		before := *preset
+
+		preset.MakePublic()
+		if err := s.presetRepo.Update(ctx, preset); err != nil {
+			return err
+		}
 
-	preset.MakePublic()
-	return s.presetRepo.Update(preset)
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, stepPresetAggregate, id, before, preset)
+	})
 }
 
-func (s *stepService) MakePresetShared(id uint) error {
-	preset, err := s.presetRepo.FindByID(id)
-	if err != nil {
-		return errors.New("preset not found")
-	}
+func (s *stepService) MakePresetShared(ctx context.Context, id uint) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		preset, err := s.presetRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		before := *preset
+
+		preset.MakeShared()
+		if err := s.presetRepo.Update(ctx, preset); err != nil {
+			return err
+		}
 
-	preset.MakeShared()
-	return s.presetRepo.Update(preset)
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, stepPresetAggregate, id, before, preset)
+	})
 }
 
-func (s *stepService) MakePresetPrivate(id uint) error {
-	preset, err := s.presetRepo.FindByID(id)
-	if err != nil {
-		return errors.New("preset not found")
-	}
+func (s *stepService) MakePresetPrivate(ctx context.Context, id uint) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		preset, err := s.presetRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		before := *preset
+
+		preset.MakePrivate()
+		if err := s.presetRepo.Update(ctx, preset); err != nil {
+			return err
+		}
 
-	preset.MakePrivate()
-	return s.presetRepo.Update(preset)
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, stepPresetAggregate, id, before, preset)
+	})
 }
 
-// Steps
+func (s *stepService) ListDeletedPresets(ctx context.Context, q sharedDomain.Query) (*sharedDomain.Page[*domain.LeadStepPreset], error) {
+	q = q.Normalize(10, 100)
+	return s.presetRepo.FindDeleted(ctx, q)
+}
+
+func (s *stepService) RestorePreset(ctx context.Context, id uint) (*domain.LeadStepPreset, error) {
+	ctx = sharedDomain.WithDeleted(ctx)
+
+	var preset *domain.LeadStepPreset
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		preset, err = s.presetRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		before := *preset
+
+		if err := preset.Restore(); err != nil {
+			return err
+		}
 
-func (s *stepService) CreateStep(inp input.CreateStepInput) (*domain.LeadStep, error) {
-	exists, err := s.presetRepo.Exists(inp.PresetID)
+		if err := s.presetRepo.Update(ctx, preset); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionRestore, stepPresetAggregate, id, before, preset)
+	})
 	if err != nil {
 		return nil, err
 	}
-	if !exists {
-		return nil, errors.New("preset not found")
-	}
 
-	step, err := domain.NewLeadStep(inp.PresetID, inp.Code, inp.Name, inp.SortOrder)
+	return preset, nil
+}
+
+// PurgeDeletedPresets - los steps caen en cascada; si algún lead tiene progreso en ellos el preset no se puede purgar
+func (s *stepService) PurgeDeletedPresets(ctx context.Context, deletedBefore time.Time) (int, error) {
+	deleted, err := s.presetRepo.FindDeletedBefore(ctx, deletedBefore)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
 
-	if inp.IsFinal {
-		step.MarkAsFinal()
+	purged := 0
+	var errs []error
+	for _, preset := range deleted {
+		err := s.uow.Do(ctx, func(ctx context.Context) error {
+			if err := s.presetRepo.Purge(ctx, preset.ID); err != nil {
+				return err
+			}
+			return s.auditService.Record(ctx, auditDomain.ActionPurge, stepPresetAggregate, preset.ID, nil, nil)
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("preset %d: %w", preset.ID, err))
+			continue
+		}
+		purged++
 	}
 
-	if err := s.stepRepo.Save(step); err != nil {
+	return purged, errors.Join(errs...)
+}
+
+// Steps
+
+func (s *stepService) CreateStep(ctx context.Context, inp input.CreateStepInput) (*domain.LeadStep, error) {
+	var step *domain.LeadStep
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		exists, err := s.presetRepo.Exists(ctx, inp.PresetID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return sharedDomain.NotFound("preset")
+		}
+
+		step, err = domain.NewLeadStep(inp.PresetID, inp.Code, inp.Name, inp.SortOrder)
+		if err != nil {
+			return err
+		}
+
+		if inp.IsFinal {
+			step.MarkAsFinal()
+		}
+
+		if err := s.stepRepo.Save(ctx, step); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionCreate, stepAggregate, step.ID, nil, step)
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return step, nil
 }
 
-func (s *stepService) GetSteps(presetID uint) ([]*domain.LeadStep, error) {
-	return s.stepRepo.FindByPresetID(presetID)
+func (s *stepService) GetSteps(ctx context.Context, presetID uint) ([]*domain.LeadStep, error) {
+	return s.stepRepo.FindByPresetID(ctx, presetID)
 }
 
-func (s *stepService) GetActiveSteps(presetID uint) ([]*domain.LeadStep, error) {
-	return s.stepRepo.FindActiveByPresetID(presetID)
+func (s *stepService) GetActiveSteps(ctx context.Context, presetID uint) ([]*domain.LeadStep, error) {
+	return s.stepRepo.FindActiveByPresetID(ctx, presetID)
 }
 
-func (s *stepService) DeactivateStep(id uint) error {
-	step, err := s.stepRepo.FindByID(id)
-	if err != nil {
-		return errors.New("step not found")
-	}
+func (s *stepService) DeactivateStep(ctx context.Context, id uint) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		step, err := s.stepRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		before := *step
+
+		step.Deactivate()
+		if err := s.stepRepo.Update(ctx, step); err != nil {
+			return err
+		}
 
-	step.Deactivate()
-	return s.stepRepo.Update(step)
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, stepAggregate, id, before, step)
+	})
 }
 
-func (s *stepService) ActivateStep(id uint) error {
-	step, err := s.stepRepo.FindByID(id)
-	if err != nil {
-		return errors.New("step not found")
-	}
+func (s *stepService) ActivateStep(ctx context.Context, id uint) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		step, err := s.stepRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		before := *step
+
+		step.Activate()
+		if err := s.stepRepo.Update(ctx, step); err != nil {
+			return err
+		}
 
-	step.Activate()
-	return s.stepRepo.Update(step)
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, stepAggregate, id, before, step)
+	})
 }
 
-func (s *stepService) DeleteStep(id uint) error {
-	exists, err := s.stepRepo.Exists(id)
-	if err != nil {
-		return err
-	}
-	if !exists {
-		return errors.New("step not found")
-	}
+func (s *stepService) DeleteStep(ctx context.Context, id uint) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		step, err := s.stepRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
 
-	return s.stepRepo.Delete(id)
+		if err := s.stepRepo.Delete(ctx, id); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionDelete, stepAggregate, id, step, nil)
+	})
 }
 
 // Progress
 
-func (s *stepService) InitializeProgress(leadID uint, presetID uint) error {
-	// Verificar que lead exista
-	exists, err := s.leadRepo.Exists(leadID)
-	if err != nil {
-		return err
-	}
-	if !exists {
-		return errors.New("lead not found")
-	}
-
-	// Obtener pasos activos del preset
-	steps, err := s.stepRepo.FindActiveByPresetID(presetID)
-	if err != nil {
-		return err
-	}
-
-	// Crear progreso para cada paso
-	for _, step := range steps {
-		progress, err := domain.NewLeadStepProgress(leadID, step.ID)
+func (s *stepService) InitializeProgress(ctx context.Context, leadID uint, presetID uint) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		// Verificar que lead exista
+		exists, err := s.leadRepo.Exists(ctx, leadID)
 		if err != nil {
 			return err
 		}
+		if !exists {
+			return sharedDomain.NotFound("lead")
+		}
 
-		if err := s.progressRepo.Save(progress); err != nil {
+		// Obtener pasos activos del preset
+		steps, err := s.stepRepo.FindActiveByPresetID(ctx, presetID)
+		if err != nil {
 			return err
 		}
-	}
 
-	return nil
+		// Crear progreso para cada paso
+		for _, step := range steps {
+			progress, err := domain.NewLeadStepProgress(leadID, step.ID)
+			if err != nil {
+				return err
+			}
+
+			if err := s.progressRepo.Save(ctx, progress); err != nil {
+				return err
+			}
+			if err := s.auditService.Record(ctx, auditDomain.ActionCreate, stepProgressAggregate, progress.ID, nil, progress); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
 }
 
-func (s *stepService) GetProgress(leadID uint) ([]*domain.LeadStepProgress, error) {
-	return s.progressRepo.FindByLeadID(leadID)
+func (s *stepService) GetProgress(ctx context.Context, leadID uint) ([]*domain.LeadStepProgress, error) {
+	return s.progressRepo.FindByLeadID(ctx, leadID)
 }
 
-func (s *stepService) UpdateProgress(inp input.UpdateProgressInput) (*domain.LeadStepProgress, error) {
-	progress, err := s.progressRepo.FindByLeadIDAndStepID(inp.LeadID, inp.StepID)
-	if err != nil {
-		return nil, errors.New("progress not found")
-	}
+func (s *stepService) UpdateProgress(ctx context.Context, inp input.UpdateProgressInput) (*domain.LeadStepProgress, error) {
+	var progress *domain.LeadStepProgress
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		progress, err = s.progressRepo.FindByLeadIDAndStepID(ctx, inp.LeadID, inp.StepID)
+		if err != nil {
+			return err
+		}
+		before := *progress
+
+		switch domain.StepStatus(inp.Status) {
+		case domain.StepStatusCompleted:
+			progress.Complete(inp.CompletedBy, inp.Notes)
+		case domain.StepStatusSkipped:
+			progress.Skip(inp.CompletedBy, inp.Notes)
+		case domain.StepStatusFailed:
+			progress.Fail(inp.CompletedBy, inp.Notes)
+		default:
+			return sharedDomain.Invalid("status", "invalid status")
+		}
 
-	switch domain.StepStatus(inp.Status) {
-	case domain.StepStatusCompleted:
-		progress.Complete(inp.CompletedBy, inp.Notes)
-	case domain.StepStatusSkipped:
-		progress.Skip(inp.CompletedBy, inp.Notes)
-	case domain.StepStatusFailed:
-		progress.Fail(inp.CompletedBy, inp.Notes)
-	default:
-		return nil, errors.New("invalid status")
-	}
+		if err := s.progressRepo.Update(ctx, progress); err != nil {
+			return err
+		}
 
-	if err := s.progressRepo.Update(progress); err != nil {
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, stepProgressAggregate, progress.ID, before, progress)
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return progress, nil
 }
 
-func (s *stepService) CompleteStep(leadID uint, stepID uint, completedBy uint, notes string) error {
-	progress, err := s.progressRepo.FindByLeadIDAndStepID(leadID, stepID)
-	if err != nil {
-		return errors.New("progress not found")
-	}
+func (s *stepService) CompleteStep(ctx context.Context, leadID uint, stepID uint, completedBy uint, notes string) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		progress, err := s.progressRepo.FindByLeadIDAndStepID(ctx, leadID, stepID)
+		if err != nil {
+			return err
+		}
+		before := *progress
 
-	progress.Complete(completedBy, notes)
-	return s.progressRepo.Update(progress)
+		progress.Complete(completedBy, notes)
+		if err := s.progressRepo.Update(ctx, progress); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, stepProgressAggregate, progress.ID, before, progress)
+	})
 }
 
-func (s *stepService) SkipStep(leadID uint, stepID uint, completedBy uint, notes string) error {
-	progress, err := s.progressRepo.FindByLeadIDAndStepID(leadID, stepID)
-	if err != nil {
-		return errors.New("progress not found")
-	}
+func (s *stepService) SkipStep(ctx context.Context, leadID uint, stepID uint, completedBy uint, notes string) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		progress, err := s.progressRepo.FindByLeadIDAndStepID(ctx, leadID, stepID)
+		if err != nil {
+			return err
+		}
+		before := *progress
+
+		progress.Skip(completedBy, notes)
+		if err := s.progressRepo.Update(ctx, progress); err != nil {
+			return err
+		}
 
-	progress.Skip(completedBy, notes)
-	return s.progressRepo.Update(progress)
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, stepProgressAggregate, progress.ID, before, progress)
+	})
 }
 
-func (s *stepService) FailStep(leadID uint, stepID uint, completedBy uint, notes string) error {
-	progress, err := s.progressRepo.FindByLeadIDAndStepID(leadID, stepID)
-	if err != nil {
-		return errors.New("progress not found")
-	}
+func (s *stepService) FailStep(ctx context.Context, leadID uint, stepID uint, completedBy uint, notes string) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		progress, err := s.progressRepo.FindByLeadIDAndStepID(ctx, leadID, stepID)
+		if err != nil {
+			return err
+		}
+		before := *progress
+
+		progress.Fail(completedBy, notes)
+		if err := s.progressRepo.Update(ctx, progress); err != nil {
+			return err
+		}
 
-	progress.Fail(completedBy, notes)
-	return s.progressRepo.Update(progress)
+		return s.auditService.Record(ctx, auditDomain.ActionUpdate, stepProgressAggregate, progress.ID, before, progress)
+	})
 }
\ No newline at end of file
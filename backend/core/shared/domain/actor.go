@@ -0,0 +1,21 @@
+package domain
+
+import "context"
+
+// Actor - quién ejecuta la operación y desde dónde (viaja en el context del request)
+type Actor struct {
+	EntityID  uint
+	IP        string
+	RequestID string
+}
+
+type actorKey struct{}
+
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+func ActorFromContext(ctx context.Context) Actor {
+	actor, _ := ctx.Value(actorKey{}).(Actor)
+	return actor
+}
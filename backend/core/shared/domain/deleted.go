@@ -0,0 +1,15 @@
+package domain
+
+import "context"
+
+type includeDeletedKey struct{}
+
+// WithDeleted - marca el context para que los repositories incluyan los registros de la papelera
+func WithDeleted(ctx context.Context) context.Context {
+	return context.WithValue(ctx, includeDeletedKey{}, true)
+}
+
+func IncludesDeleted(ctx context.Context) bool {
+	include, _ := ctx.Value(includeDeletedKey{}).(bool)
+	return include
+}
@@ -0,0 +1,87 @@
+package domain
+
+import "errors"
+
+// ErrorKind - categoría del error; los adaptadores deciden cómo representarla (status HTTP, etc.)
+type ErrorKind string
+
+const (
+	KindNotFound     ErrorKind = "not_found"
+	KindConflict     ErrorKind = "conflict"
+	KindValidation   ErrorKind = "validation"
+	KindUnauthorized ErrorKind = "unauthorized"
+	KindForbidden    ErrorKind = "forbidden"
+	KindInvariant    ErrorKind = "invariant_violation"
+)
+
+// Error - error de dominio con un código estable que el cliente puede interpretar
+type Error struct {
+	Kind    ErrorKind
+	Code    string
+	Message string
+	Fields  map[string]string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Is - dos errores de dominio son el mismo si comparten código, aunque el mensaje varíe
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+func NotFound(resource string) *Error {
+	return &Error{
+		Kind:    KindNotFound,
+		Code:    resource + "_not_found",
+		Message: resource + " not found",
+	}
+}
+
+func Conflict(code string, message string) *Error {
+	return &Error{Kind: KindConflict, Code: code, Message: message}
+}
+
+// Invalid - dato de entrada inválido; field es el nombre del campo tal como lo ve el cliente
+func Invalid(field string, message string) *Error {
+	return &Error{
+		Kind:    KindValidation,
+		Code:    "validation_failed",
+		Message: message,
+		Fields:  map[string]string{field: message},
+	}
+}
+
+func Unauthorized(code string, message string) *Error {
+	return &Error{Kind: KindUnauthorized, Code: code, Message: message}
+}
+
+func Forbidden(code string, message string) *Error {
+	return &Error{Kind: KindForbidden, Code: code, Message: message}
+}
+
+// Invariant - la operación es válida en forma pero rompe una regla del agregado en su estado actual
+func Invariant(code string, message string) *Error {
+	return &Error{Kind: KindInvariant, Code: code, Message: message}
+}
+
+// AsError - extrae el error de dominio de una cadena de errores envueltos
+func AsError(err error) (*Error, bool) {
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr, true
+	}
+	return nil, false
+}
+
+// IsNotFound - atajo para los servicios que distinguen "no existe" de un fallo de infraestructura
+func IsNotFound(err error) bool {
+	domainErr, ok := AsError(err)
+	return ok && domainErr.Kind == KindNotFound
+}
+
+// ErrDuplicateKey - un índice único rechazó la escritura; los services que conocen el único
+// índice en juego lo traducen a su propio conflicto
+var ErrDuplicateKey = Conflict("duplicate_key", "a record with the same unique value already exists")
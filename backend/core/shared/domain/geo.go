@@ -0,0 +1,139 @@
+package domain
+
+import "math"
+
+// Radio medio de la Tierra (IUGG); el error de haversine frente al elipsoide es < 0.5%
+const earthRadiusKM = 6371.0088
+
+type GeoPoint struct {
+	Latitude  float64
+	Longitude float64
+}
+
+func NewGeoPoint(latitude float64, longitude float64) (GeoPoint, error) {
+	if math.IsNaN(latitude) || latitude < -90 || latitude > 90 {
+		return GeoPoint{}, Invalid("latitude", "invalid latitude")
+	}
+	if math.IsNaN(longitude) || longitude < -180 || longitude > 180 {
+		return GeoPoint{}, Invalid("longitude", "invalid longitude")
+	}
+	return GeoPoint{Latitude: latitude, Longitude: longitude}, nil
+}
+
+// DistanceKM - distancia en línea recta (haversine)
+func (p GeoPoint) DistanceKM(to GeoPoint) float64 {
+	lat1 := radians(p.Latitude)
+	lat2 := radians(to.Latitude)
+	dLat := lat2 - lat1
+	dLng := radians(to.Longitude - p.Longitude)
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusKM * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// BoundingBox - rectángulo que contiene el círculo de radiusKM, para descartar en SQL antes
+// de calcular distancias. Cerca de los polos o del antimeridiano la longitud cubre todo el rango
+func (p GeoPoint) BoundingBox(radiusKM float64) (southWest GeoPoint, northEast GeoPoint) {
+	dLat := degrees(radiusKM / earthRadiusKM)
+	southWest.Latitude = math.Max(p.Latitude-dLat, -90)
+	northEast.Latitude = math.Min(p.Latitude+dLat, 90)
+
+	southWest.Longitude, northEast.Longitude = -180, 180
+	if southWest.Latitude > -90 && northEast.Latitude < 90 {
+		dLng := degrees(math.Asin(math.Min(1, math.Sin(radiusKM/earthRadiusKM)/math.Cos(radians(p.Latitude)))))
+		if p.Longitude-dLng >= -180 && p.Longitude+dLng <= 180 {
+			southWest.Longitude = p.Longitude - dLng
+			northEast.Longitude = p.Longitude + dLng
+		}
+	}
+	return southWest, northEast
+}
+
+// PathProjection - posición de un punto respecto a una polilínea
+type PathProjection struct {
+	AlongKM  float64 // recorrido por el camino hasta el punto proyectado
+	OffsetKM float64 // distancia del punto al camino
+	LengthKM float64
+}
+
+func PathLengthKM(path []GeoPoint) float64 {
+	length := 0.0
+	for i := 1; i < len(path); i++ {
+		length += path[i-1].DistanceKM(path[i])
+	}
+	return length
+}
+
+// ProjectOnPath - proyecta p sobre el tramo más cercano. Cada tramo se trata como plano
+// (equirectangular), suficiente para tramos de carretera de unas decenas de km
+func ProjectOnPath(path []GeoPoint, p GeoPoint) PathProjection {
+	projection := PathProjection{LengthKM: PathLengthKM(path)}
+	if len(path) == 0 {
+		return projection
+	}
+	projection.OffsetKM = p.DistanceKM(path[0])
+
+	walked := 0.0
+	for i := 1; i < len(path); i++ {
+		a, b := path[i-1], path[i]
+		segment := a.DistanceKM(b)
+
+		closest, t := a.closestOnSegment(b, p)
+		if offset := p.DistanceKM(closest); offset < projection.OffsetKM {
+			projection.OffsetKM = offset
+			projection.AlongKM = walked + t*segment
+		}
+		walked += segment
+	}
+	return projection
+}
+
+// InPolygon - ray casting sobre lat/lng planas; vale para polígonos del tamaño de un lote.
+// El anillo se cierra solo, el último vértice no tiene que repetir el primero
+func InPolygon(polygon []GeoPoint, p GeoPoint) bool {
+	inside := false
+	for i, j := 0, len(polygon)-1; i < len(polygon); j, i = i, i+1 {
+		a, b := polygon[i], polygon[j]
+		if (a.Latitude > p.Latitude) != (b.Latitude > p.Latitude) &&
+			p.Longitude < (b.Longitude-a.Longitude)*(p.Latitude-a.Latitude)/(b.Latitude-a.Latitude)+a.Longitude {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// DistanceToRingKM - distancia de p al borde del polígono
+func DistanceToRingKM(polygon []GeoPoint, p GeoPoint) float64 {
+	if len(polygon) == 0 {
+		return math.Inf(1)
+	}
+	ring := append(append([]GeoPoint{}, polygon...), polygon[0])
+	return ProjectOnPath(ring, p).OffsetKM
+}
+
+// closestOnSegment - punto del tramo a-b más cercano a p y su fracción t del tramo
+func (a GeoPoint) closestOnSegment(b GeoPoint, p GeoPoint) (GeoPoint, float64) {
+	scale := math.Cos(radians((a.Latitude + b.Latitude) / 2))
+	dx := (b.Longitude - a.Longitude) * scale
+	dy := b.Latitude - a.Latitude
+	lengthSq := dx*dx + dy*dy
+	if lengthSq == 0 {
+		return a, 0
+	}
+
+	px := (p.Longitude - a.Longitude) * scale
+	py := p.Latitude - a.Latitude
+	t := math.Max(0, math.Min(1, (px*dx+py*dy)/lengthSq))
+	return GeoPoint{
+		Latitude:  a.Latitude + t*(b.Latitude-a.Latitude),
+		Longitude: a.Longitude + t*(b.Longitude-a.Longitude),
+	}, t
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func degrees(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
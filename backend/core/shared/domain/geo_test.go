@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGeoPointDistanceKM(t *testing.T) {
+	newYork := GeoPoint{Latitude: 40.7128, Longitude: -74.0060}
+	losAngeles := GeoPoint{Latitude: 34.0522, Longitude: -118.2437}
+
+	if got := newYork.DistanceKM(losAngeles); math.Abs(got-3936) > 5 {
+		t.Errorf("DistanceKM() = %.1f, want ~3936", got)
+	}
+	if got := newYork.DistanceKM(newYork); got != 0 {
+		t.Errorf("DistanceKM() to itself = %v, want 0", got)
+	}
+}
+
+func TestGeoPointBoundingBox(t *testing.T) {
+	center := GeoPoint{Latitude: 40, Longitude: -74}
+	southWest, northEast := center.BoundingBox(100)
+
+	// Los cuatro puntos cardinales a 100 km tienen que quedar dentro
+	for _, p := range []GeoPoint{
+		{Latitude: 40 + 0.899, Longitude: -74},
+		{Latitude: 40 - 0.899, Longitude: -74},
+		{Latitude: 40, Longitude: -74 + 1.17},
+		{Latitude: 40, Longitude: -74 - 1.17},
+	} {
+		if p.Latitude < southWest.Latitude || p.Latitude > northEast.Latitude || p.Longitude < southWest.Longitude || p.Longitude > northEast.Longitude {
+			t.Errorf("point %+v (%.1f km) outside box %+v %+v", p, center.DistanceKM(p), southWest, northEast)
+		}
+	}
+
+	southWest, northEast = GeoPoint{Latitude: 0, Longitude: 179.9}.BoundingBox(50)
+	if southWest.Longitude != -180 || northEast.Longitude != 180 {
+		t.Errorf("box crossing the antimeridian = %+v %+v, want full longitude range", southWest, northEast)
+	}
+}
+
+func TestNewGeoPoint(t *testing.T) {
+	if _, err := NewGeoPoint(91, 0); err == nil {
+		t.Error("NewGeoPoint() expected error for latitude 91")
+	}
+	if _, err := NewGeoPoint(0, -181); err == nil {
+		t.Error("NewGeoPoint() expected error for longitude -181")
+	}
+	if _, err := NewGeoPoint(19.43, -99.13); err != nil {
+		t.Errorf("NewGeoPoint() error = %v", err)
+	}
+}
+
+func TestProjectOnPath(t *testing.T) {
+	// Tramo en L: 1 grado hacia el norte y luego 1 grado hacia el este sobre el ecuador
+	path := []GeoPoint{{0, 0}, {1, 0}, {1, 1}}
+	segment := path[0].DistanceKM(path[1])
+
+	tests := []struct {
+		name       string
+		point      GeoPoint
+		wantAlong  float64
+		wantOffset float64
+	}{
+		{"start", GeoPoint{0, 0}, 0, 0},
+		{"halfway first leg", GeoPoint{0.5, 0}, segment / 2, 0},
+		{"beside first leg", GeoPoint{0.5, 0.1}, segment / 2, segment / 10},
+		{"on second leg", GeoPoint{1, 0.5}, segment * 1.5, 0},
+		{"end", GeoPoint{1, 1}, PathLengthKM(path), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ProjectOnPath(path, tt.point)
+			if math.Abs(got.AlongKM-tt.wantAlong) > 1 {
+				t.Errorf("AlongKM = %.1f, want %.1f", got.AlongKM, tt.wantAlong)
+			}
+			if math.Abs(got.OffsetKM-tt.wantOffset) > 1 {
+				t.Errorf("OffsetKM = %.1f, want %.1f", got.OffsetKM, tt.wantOffset)
+			}
+		})
+	}
+}
+
+func TestInPolygon(t *testing.T) {
+	// Cuadrado de 0.01 grados
+	square := []GeoPoint{{0, 0}, {0, 0.01}, {0.01, 0.01}, {0.01, 0}}
+
+	tests := []struct {
+		name  string
+		point GeoPoint
+		want  bool
+	}{
+		{"center", GeoPoint{0.005, 0.005}, true},
+		{"outside east", GeoPoint{0.005, 0.02}, false},
+		{"outside south", GeoPoint{-0.001, 0.005}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := InPolygon(square, tt.point); got != tt.want {
+				t.Errorf("InPolygon() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if d := DistanceToRingKM(square, GeoPoint{0.005, 0.02}); math.Abs(d-GeoPoint{0.005, 0.01}.DistanceKM(GeoPoint{0.005, 0.02})) > 0.01 {
+		t.Errorf("DistanceToRingKM() = %.3f", d)
+	}
+}
@@ -0,0 +1,144 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type FilterOp string
+
+const (
+	FilterEq   FilterOp = "eq"
+	FilterNe   FilterOp = "ne"
+	FilterGt   FilterOp = "gt"
+	FilterGte  FilterOp = "gte"
+	FilterLt   FilterOp = "lt"
+	FilterLte  FilterOp = "lte"
+	FilterIn   FilterOp = "in"
+	FilterLike FilterOp = "like"
+)
+
+func (op FilterOp) IsValid() bool {
+	switch op {
+	case FilterEq, FilterNe, FilterGt, FilterGte, FilterLt, FilterLte, FilterIn, FilterLike:
+		return true
+	}
+	return false
+}
+
+// Filter - condición sobre un campo expuesto por el repository (no sobre columnas arbitrarias)
+type Filter struct {
+	Field  string
+	Op     FilterOp
+	Values []string
+}
+
+type Sort struct {
+	Field string
+	Desc  bool
+}
+
+// Query - paginación, orden y filtros de un listado. Con Cursor la paginación es por keyset
+// y Offset/Page se ignoran
+type Query struct {
+	Limit   int
+	Offset  int
+	Page    int
+	Cursor  string
+	Sort    []Sort
+	Filters []Filter
+}
+
+// Normalize - aplica el límite por defecto y el máximo, y traduce Page a Offset
+func (q Query) Normalize(defaultLimit int, maxLimit int) Query {
+	if q.Limit <= 0 {
+		q.Limit = defaultLimit
+	}
+	if q.Limit > maxLimit {
+		q.Limit = maxLimit
+	}
+	if q.Page > 0 {
+		q.Offset = (q.Page - 1) * q.Limit
+	}
+	if q.Offset < 0 {
+		q.Offset = 0
+	}
+	return q
+}
+
+// Where - copia de la query con un filtro más; los services la usan para fijar condiciones propias
+func (q Query) Where(field string, op FilterOp, values ...string) Query {
+	filters := make([]Filter, len(q.Filters), len(q.Filters)+1)
+	copy(filters, q.Filters)
+	q.Filters = append(filters, Filter{Field: field, Op: op, Values: values})
+	return q
+}
+
+// ParseSort - "-created_at,make": el guion indica orden descendente
+func ParseSort(expr string) ([]Sort, error) {
+	var sorts []Sort
+	for _, part := range strings.Split(expr, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		sort := Sort{Field: strings.TrimPrefix(part, "-"), Desc: strings.HasPrefix(part, "-")}
+		if sort.Field == "" {
+			return nil, Invalid("sort", "invalid sort expression")
+		}
+		sorts = append(sorts, sort)
+	}
+	return sorts, nil
+}
+
+// ParseFilter - "campo:op:valor"; con "in" los valores van separados por "|"
+func ParseFilter(expr string) (Filter, error) {
+	parts := strings.SplitN(expr, ":", 3)
+	if len(parts) != 3 || parts[0] == "" {
+		return Filter{}, Invalid("filter", fmt.Sprintf("invalid filter %q, expected field:op:value", expr))
+	}
+
+	op := FilterOp(parts[1])
+	if !op.IsValid() {
+		return Filter{}, Invalid("filter", fmt.Sprintf("invalid filter operator %q", parts[1]))
+	}
+
+	values := []string{parts[2]}
+	if op == FilterIn {
+		values = strings.Split(parts[2], "|")
+	}
+	return Filter{Field: parts[0], Op: op, Values: values}, nil
+}
+
+// Page - una página de resultados; Total cuenta todo lo que cumple los filtros
+type Page[T any] struct {
+	Items      []T
+	Total      int64
+	Limit      int
+	Offset     int
+	NextCursor string
+}
+
+// EncodeCursor - los valores de orden de la última fila, opacos para el cliente. nil es NULL
+func EncodeCursor(values []*string) (string, error) {
+	data, err := json.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func DecodeCursor(cursor string) ([]*string, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, Invalid("cursor", "invalid cursor")
+	}
+
+	var values []*string
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, Invalid("cursor", "invalid cursor")
+	}
+	return values, nil
+}
@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestQueryNormalize(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      Query
+		wantLimit  int
+		wantOffset int
+	}{
+		{"default limit", Query{}, 10, 0},
+		{"limit clamped", Query{Limit: 500}, 100, 0},
+		{"page to offset", Query{Limit: 20, Page: 3}, 20, 40},
+		{"negative offset", Query{Offset: -5}, 10, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.query.Normalize(10, 100)
+			if got.Limit != tt.wantLimit || got.Offset != tt.wantOffset {
+				t.Errorf("Normalize() = limit %d offset %d, want limit %d offset %d", got.Limit, got.Offset, tt.wantLimit, tt.wantOffset)
+			}
+		})
+	}
+}
+
+func TestQueryWhereDoesNotShareFilters(t *testing.T) {
+	base := Query{Filters: make([]Filter, 0, 4)}
+	a := base.Where("status", FilterEq, "available")
+	b := base.Where("status", FilterEq, "sold")
+
+	if a.Filters[0].Values[0] != "available" || b.Filters[0].Values[0] != "sold" {
+		t.Errorf("Where() filters leaked between copies: %v, %v", a.Filters, b.Filters)
+	}
+	if len(base.Filters) != 0 {
+		t.Errorf("Where() modified the original query: %v", base.Filters)
+	}
+}
+
+func TestParseSort(t *testing.T) {
+	got, err := ParseSort("-created_at, make,")
+	if err != nil {
+		t.Fatalf("ParseSort() error = %v", err)
+	}
+	want := []Sort{{Field: "created_at", Desc: true}, {Field: "make"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseSort() = %v, want %v", got, want)
+	}
+
+	if _, err := ParseSort("-"); err == nil {
+		t.Error("ParseSort(\"-\") expected error")
+	}
+}
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		expr    string
+		want    Filter
+		wantErr bool
+	}{
+		{"status:eq:available", Filter{Field: "status", Op: FilterEq, Values: []string{"available"}}, false},
+		{"make:in:Ford|Toyota", Filter{Field: "make", Op: FilterIn, Values: []string{"Ford", "Toyota"}}, false},
+		{"notes:like:a:b", Filter{Field: "notes", Op: FilterLike, Values: []string{"a:b"}}, false},
+		{"status:between:1", Filter{}, true},
+		{"status", Filter{}, true},
+		{":eq:1", Filter{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			got, err := ParseFilter(tt.expr)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseFilter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	at, id := "2024-01-02T03:04:05Z", "42"
+	values := []*string{&at, nil, &id}
+	cursor, err := EncodeCursor(values)
+	if err != nil {
+		t.Fatalf("EncodeCursor() error = %v", err)
+	}
+
+	got, err := DecodeCursor(cursor)
+	if err != nil {
+		t.Fatalf("DecodeCursor() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, values) {
+		t.Errorf("DecodeCursor() = %v, want %v", got, values)
+	}
+
+	if _, err := DecodeCursor("not a cursor"); err == nil {
+		t.Error("DecodeCursor() expected error for garbage input")
+	}
+}
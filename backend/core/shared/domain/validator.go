@@ -43,24 +43,24 @@ func Validate(field string, value string) error {
 	}
 
 	if rule.MinLength > 0 && len(value) < rule.MinLength {
-		return fmt.Errorf("%s must be at least %d characters", field, rule.MinLength)
+		return Invalid(field, fmt.Sprintf("%s must be at least %d characters", field, rule.MinLength))
 	}
 
 	if rule.MaxLength > 0 && len(value) > rule.MaxLength {
-		return fmt.Errorf("%s must be at most %d characters", field, rule.MaxLength)
+		return Invalid(field, fmt.Sprintf("%s must be at most %d characters", field, rule.MaxLength))
 	}
 
 	for _, pattern := range rule.Blacklist {
 		regex := regexp.MustCompile(pattern)
 		if regex.MatchString(value) {
-			return fmt.Errorf("%s is not allowed", field)
+			return Invalid(field, fmt.Sprintf("%s is not allowed", field))
 		}
 	}
 
 	for _, r := range rule.Rules {
 		regex := regexp.MustCompile(r.Pattern)
 		if !regex.MatchString(value) {
-			return fmt.Errorf("%s %s", field, r.Message)
+			return Invalid(field, fmt.Sprintf("%s %s", field, r.Message))
 		}
 	}
 
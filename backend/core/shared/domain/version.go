@@ -0,0 +1,13 @@
+package domain
+
+// ErrVersionConflict - otro request modificó el registro desde que se leyó
+var ErrVersionConflict = Conflict("version_conflict", "resource was modified by another request")
+
+// CheckVersion - compara la versión que el cliente leyó (If-Match) con la actual.
+// Sin versión esperada no hay precondición y la comprobación queda en el UPDATE
+func CheckVersion(expected *uint, current uint) error {
+	if expected != nil && *expected != current {
+		return ErrVersionConflict
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckVersion(t *testing.T) {
+	v := func(n uint) *uint { return &n }
+
+	tests := []struct {
+		name     string
+		expected *uint
+		current  uint
+		wantErr  bool
+	}{
+		{"no precondition", nil, 3, false},
+		{"matching version", v(3), 3, false},
+		{"stale version", v(2), 3, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckVersion(tt.expected, tt.current)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrVersionConflict) {
+				t.Errorf("CheckVersion() error = %v, want ErrVersionConflict", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package output
+
+import (
+	"context"
+	"io"
+)
+
+// FileStorage - archivos subidos por los usuarios. key es una ruta relativa sin extensión
+// implícita; Put devuelve la URL pública con la que se sirve
+type FileStorage interface {
+	Put(ctx context.Context, key string, contentType string, r io.Reader) (string, error)
+	// Delete - una URL que no pertenece al storage (archivo alojado fuera) se ignora
+	Delete(ctx context.Context, url string) error
+}
@@ -0,0 +1,10 @@
+package output
+
+import "context"
+
+// UnitOfWork - agrupa varias llamadas a repositories en una sola transacción.
+// Los repositories usan la transacción que viaja en el ctx que recibe fn.
+type UnitOfWork interface {
+	// Do - si fn devuelve error se hace rollback de todo lo escrito dentro
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+type AuditLog struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	ActorID       *uint     `gorm:"index" json:"actor_id"`
+	Action        string    `gorm:"size:30;not null" json:"action"`
+	AggregateType string    `gorm:"size:50;not null;index:idx_audit_aggregate" json:"aggregate_type"`
+	AggregateID   uint      `gorm:"not null;index:idx_audit_aggregate" json:"aggregate_id"`
+	Changes       []byte    `gorm:"type:jsonb" json:"changes"`
+	IP            string    `gorm:"size:45" json:"ip"`
+	RequestID     string    `gorm:"size:64;index" json:"request_id"`
+	CreatedAt     time.Time `gorm:"index" json:"created_at"`
+}
@@ -89,10 +89,18 @@ const (
 	VStatusInTransit    VehicleStatus = "in_transit"
 	VStatusInRecon      VehicleStatus = "in_recon"
 	VStatusReadyForSale VehicleStatus = "ready_for_sale"
+	VStatusPendingSale  VehicleStatus = "pending_sale"
 	VStatusSold         VehicleStatus = "sold"
 	VStatusWholesale    VehicleStatus = "wholesale"
 )
 
+type TransferStatus string
+
+const (
+	TransferPending   TransferStatus = "pending"
+	TransferCompleted TransferStatus = "completed"
+)
+
 type LotType string
 
 const (
@@ -115,18 +123,14 @@ const (
 type PhotoPerspective string
 
 const (
-	PerspFront         PhotoPerspective = "front"
-	PerspRear          PhotoPerspective = "rear"
-	PerspLeftSide      PhotoPerspective = "left_side"
-	PerspRightSide     PhotoPerspective = "right_side"
-	PerspInteriorFront PhotoPerspective = "interior_front"
-	PerspInteriorRear  PhotoPerspective = "interior_rear"
-	PerspDashboard     PhotoPerspective = "dashboard"
-	PerspEngine        PhotoPerspective = "engine"
-	PerspTrunk         PhotoPerspective = "trunk"
-	PerspDetail        PhotoPerspective = "detail"
-	PerspDamage        PhotoPerspective = "damage"
-	PerspOther         PhotoPerspective = "other"
+	PerspFront     PhotoPerspective = "front"
+	PerspRear      PhotoPerspective = "rear"
+	PerspLeftSide  PhotoPerspective = "left_side"
+	PerspRightSide PhotoPerspective = "right_side"
+	PerspInterior  PhotoPerspective = "interior"
+	PerspDashboard PhotoPerspective = "dashboard"
+	PerspEngine    PhotoPerspective = "engine"
+	PerspDamage    PhotoPerspective = "damage"
 )
 
 type PhotoPurpose string
@@ -17,32 +17,36 @@ type Country struct {
 }
 
 type Location struct {
-	ID        uint         `gorm:"primaryKey" json:"id"`
-	Name      string       `json:"name"`
-	Type      LocationType `json:"type"`
-	Address   string       `json:"address"`
-	City      string       `json:"city"`
-	State     string       `json:"state"`
-	Zip       string       `json:"zip"`
-	CountryID uint         `json:"country_id"`
-	Country   Country      `gorm:"foreignKey:CountryID" json:"country"`
-	Latitude  float64      `gorm:"type:decimal(10,8)" json:"latitude"`
-	Longitude float64      `gorm:"type:decimal(11,8)" json:"longitude"`
-	Capacity  int          `json:"capacity"`
-	Active    bool         `gorm:"default:true" json:"active"`
-	CreatedAt time.Time    `json:"created_at"`
+	ID              uint            `gorm:"primaryKey" json:"id"`
+	Name            string          `json:"name"`
+	Type            LocationType    `json:"type"`
+	Address         string          `json:"address"`
+	City            string          `json:"city"`
+	State           string          `json:"state"`
+	Zip             string          `json:"zip"`
+	CountryID       uint            `json:"country_id"`
+	Country         Country         `gorm:"foreignKey:CountryID;constraint:OnDelete:RESTRICT" json:"country"`
+	Latitude        float64         `gorm:"type:decimal(10,8)" json:"latitude"`
+	Longitude       float64         `gorm:"type:decimal(11,8)" json:"longitude"`
+	Capacity        int             `json:"capacity"`
+	GeofenceRadiusM *float64        `json:"geofence_radius_m"`
+	GeofencePolygon json.RawMessage `gorm:"type:json" json:"geofence_polygon"`
+	Active          bool            `gorm:"default:true" json:"active"`
+	Version         uint            `gorm:"not null;default:1" json:"version"`
+	CreatedAt       time.Time       `json:"created_at"`
 }
 
 type Route struct {
 	ID               uint            `gorm:"primaryKey" json:"id"`
 	Name             string          `json:"name"`
 	FromLocationID   uint            `json:"from_location_id"`
-	FromLocation     Location        `gorm:"foreignKey:FromLocationID" json:"from_location"`
+	FromLocation     Location        `gorm:"foreignKey:FromLocationID;constraint:OnDelete:RESTRICT" json:"from_location"`
 	ToLocationID     uint            `json:"to_location_id"`
-	ToLocation       Location        `gorm:"foreignKey:ToLocationID" json:"to_location"`
+	ToLocation       Location        `gorm:"foreignKey:ToLocationID;constraint:OnDelete:RESTRICT" json:"to_location"`
 	DistanceKM       float64         `json:"distance_km"`
 	EstimatedMinutes int             `json:"estimated_minutes"`
 	Waypoints        json.RawMessage `gorm:"type:json" json:"waypoints"`
 	Active           bool            `gorm:"default:true" json:"active"`
+	Version          uint            `gorm:"not null;default:1" json:"version"`
 	CreatedAt        time.Time       `json:"created_at"`
 }
\ No newline at end of file
@@ -1,6 +1,10 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type VehicleModel3D struct {
 	ID           uint      `gorm:"primaryKey" json:"id"`
@@ -8,14 +12,16 @@ type VehicleModel3D struct {
 	BodyType     BodyType  `json:"body_type"`
 	FileURL      string    `json:"file_url"`
 	ThumbnailURL string    `json:"thumbnail_url"`
+	IsDefault    bool      `gorm:"not null;default:false" json:"is_default"`
 	Active       bool      `gorm:"default:true" json:"active"`
+	Version      uint      `gorm:"not null;default:1" json:"version"`
 	CreatedAt    time.Time `json:"created_at"`
 }
 
 type VehicleModelZone struct {
 	ID        uint           `gorm:"primaryKey" json:"id"`
 	Model3DID uint           `json:"model_3d_id"`
-	Model3D   VehicleModel3D `gorm:"foreignKey:Model3DID" json:"-"`
+	Model3D   VehicleModel3D `gorm:"foreignKey:Model3DID;constraint:OnDelete:CASCADE" json:"-"`
 	Code      string         `json:"code"`
 	Name      string         `json:"name"`
 	MeshID    string         `json:"mesh_id"`
@@ -38,47 +44,103 @@ type Vehicle struct {
 	InvoicePrice      float64           `json:"invoice_price"`
 	AskingPrice       float64           `json:"asking_price"`
 	Condition         VehicleCondition  `json:"condition"`
-	Status            VehicleStatus     `json:"status"`
+	Status            VehicleStatus     `gorm:"index:idx_vehicles_status_created_at,priority:1" json:"status"`
 	LotType           LotType           `json:"lot_type"`
 	LocationID        uint              `json:"location_id"`
-	Location          Location          `gorm:"foreignKey:LocationID" json:"location"`
+	Location          Location          `gorm:"foreignKey:LocationID;constraint:OnDelete:RESTRICT" json:"location"`
 	AcquisitionSource AcquisitionSource `json:"acquisition_source"`
 	AcquisitionDate   time.Time         `json:"acquisition_date"`
 	AcquisitionCost   float64           `json:"acquisition_cost"`
+	ReconCost         float64           `gorm:"default:0" json:"recon_cost"`
+	AdditionalCost    float64           `gorm:"default:0" json:"additional_cost"`
+	SalePrice         *float64          `json:"sale_price"`
+	SoldAt            *time.Time        `json:"sold_at"`
+	SoldLeadID        *uint             `json:"sold_lead_id"`
 	Model3DID         *uint             `json:"model_3d_id"`
-	Model3D           *VehicleModel3D   `gorm:"foreignKey:Model3DID" json:"model_3d,omitempty"`
-	CreatedAt         time.Time         `json:"created_at"`
+	Model3D           *VehicleModel3D   `gorm:"foreignKey:Model3DID;constraint:OnDelete:SET NULL" json:"model_3d,omitempty"`
+	TrackingDeviceID  *string           `json:"tracking_device_id"`
+	DeletedAt         gorm.DeletedAt    `gorm:"index" json:"deleted_at"`
+	DeletedBy         *uint             `json:"deleted_by"`
+	Version           uint              `gorm:"not null;default:1" json:"version"`
+	CreatedAt         time.Time         `gorm:"index:idx_vehicles_status_created_at,priority:2" json:"created_at"`
 	ModifiedAt        time.Time         `json:"modified_at"`
 }
 
+type VehicleStatusHistory struct {
+	ID         uint          `gorm:"primaryKey" json:"id"`
+	VehicleID  uint          `gorm:"index:idx_vehicle_status_histories_vehicle_created,priority:1" json:"vehicle_id"`
+	Vehicle    Vehicle       `gorm:"foreignKey:VehicleID;constraint:OnDelete:CASCADE" json:"-"`
+	FromStatus VehicleStatus `json:"from_status"`
+	ToStatus   VehicleStatus `json:"to_status"`
+	ChangedBy  uint          `json:"changed_by"`
+	Reason     string        `json:"reason"`
+	CreatedAt  time.Time     `gorm:"index:idx_vehicle_status_histories_vehicle_created,priority:2" json:"created_at"`
+}
+
 type VehicleLocationHistory struct {
 	ID             uint      `gorm:"primaryKey" json:"id"`
-	VehicleID      uint      `json:"vehicle_id"`
-	Vehicle        Vehicle   `gorm:"foreignKey:VehicleID" json:"-"`
-	FromLocationID uint      `json:"from_location_id"`
-	FromLocation   Location  `gorm:"foreignKey:FromLocationID" json:"from_location"`
-	ToLocationID   uint      `json:"to_location_id"`
-	ToLocation     Location  `gorm:"foreignKey:ToLocationID" json:"to_location"`
+	VehicleID      uint      `gorm:"index:idx_vehicle_location_histories_vehicle_created,priority:1" json:"vehicle_id"`
+	Vehicle        Vehicle   `gorm:"foreignKey:VehicleID;constraint:OnDelete:CASCADE" json:"-"`
+	FromLocationID *uint     `json:"from_location_id"`
+	FromLocation   *Location `gorm:"foreignKey:FromLocationID;constraint:OnDelete:RESTRICT" json:"from_location,omitempty"`
+	ToLocationID   *uint     `json:"to_location_id"`
+	ToLocation     *Location `gorm:"foreignKey:ToLocationID;constraint:OnDelete:RESTRICT" json:"to_location,omitempty"`
 	MovedBy        uint      `json:"moved_by"`
 	Reason         string    `json:"reason"`
-	CreatedAt      time.Time `json:"created_at"`
+	CreatedAt      time.Time `gorm:"index:idx_vehicle_location_histories_vehicle_created,priority:2" json:"created_at"`
+}
+
+type VehicleTransfer struct {
+	ID                uint           `gorm:"primaryKey" json:"id"`
+	VehicleID         uint           `json:"vehicle_id"`
+	Vehicle           Vehicle        `gorm:"foreignKey:VehicleID;constraint:OnDelete:CASCADE" json:"-"`
+	FromLocationID    *uint          `json:"from_location_id"`
+	FromLocation      *Location      `gorm:"foreignKey:FromLocationID;constraint:OnDelete:RESTRICT" json:"from_location,omitempty"`
+	TransitLocationID uint           `json:"transit_location_id"`
+	TransitLocation   Location       `gorm:"foreignKey:TransitLocationID;constraint:OnDelete:RESTRICT" json:"transit_location"`
+	ToLocationID      uint           `json:"to_location_id"`
+	ToLocation        Location       `gorm:"foreignKey:ToLocationID;constraint:OnDelete:RESTRICT" json:"to_location"`
+	RouteID           *uint          `json:"route_id"`
+	Route             *Route         `gorm:"foreignKey:RouteID;constraint:OnDelete:SET NULL" json:"route,omitempty"`
+	PreviousStatus    VehicleStatus  `json:"previous_status"`
+	Status            TransferStatus `gorm:"default:pending" json:"status"`
+	Reason            string         `json:"reason"`
+	RequestedBy       uint           `json:"requested_by"`
+	ReceivedBy        *uint          `json:"received_by"`
+	CreatedAt         time.Time      `json:"created_at"`
+	CompletedAt       *time.Time     `json:"completed_at"`
 }
 
 type VehicleTracking struct {
 	ID         uint      `gorm:"primaryKey" json:"id"`
 	VehicleID  uint      `json:"vehicle_id"`
-	Vehicle    Vehicle   `gorm:"foreignKey:VehicleID" json:"-"`
+	Vehicle    Vehicle   `gorm:"foreignKey:VehicleID;constraint:OnDelete:CASCADE" json:"-"`
 	Latitude   float64   `gorm:"type:decimal(10,8)" json:"latitude"`
 	Longitude  float64   `gorm:"type:decimal(11,8)" json:"longitude"`
 	RouteID    *uint     `json:"route_id"`
-	Route      *Route    `gorm:"foreignKey:RouteID" json:"route,omitempty"`
+	Route      *Route    `gorm:"foreignKey:RouteID;constraint:OnDelete:SET NULL" json:"route,omitempty"`
 	RecordedAt time.Time `json:"recorded_at"`
 }
 
+type GeofenceEvent struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	VehicleID  uint      `json:"vehicle_id"`
+	Vehicle    Vehicle   `gorm:"foreignKey:VehicleID;constraint:OnDelete:CASCADE" json:"-"`
+	LocationID uint      `json:"location_id"`
+	Location   Location  `gorm:"foreignKey:LocationID;constraint:OnDelete:CASCADE" json:"-"`
+	Type       string    `json:"type"`
+	Latitude   float64   `gorm:"type:decimal(10,8)" json:"latitude"`
+	Longitude  float64   `gorm:"type:decimal(11,8)" json:"longitude"`
+	RecordedAt time.Time `json:"recorded_at"`
+	Outcome    string    `json:"outcome"`
+	Note       string    `json:"note"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
 type VehiclePhoto struct {
 	ID          uint             `gorm:"primaryKey" json:"id"`
-	VehicleID   uint             `json:"vehicle_id"`
-	Vehicle     Vehicle          `gorm:"foreignKey:VehicleID" json:"-"`
+	VehicleID   uint             `gorm:"index" json:"vehicle_id"`
+	Vehicle     Vehicle          `gorm:"foreignKey:VehicleID;constraint:OnDelete:CASCADE" json:"-"`
 	URL         string           `json:"url"`
 	Perspective PhotoPerspective `json:"perspective"`
 	Purpose     PhotoPurpose     `json:"purpose"`
@@ -91,17 +153,155 @@ type VehiclePhoto struct {
 type VehicleZoneMark struct {
 	ID          uint             `gorm:"primaryKey" json:"id"`
 	VehicleID   uint             `json:"vehicle_id"`
-	Vehicle     Vehicle          `gorm:"foreignKey:VehicleID" json:"-"`
+	Vehicle     Vehicle          `gorm:"foreignKey:VehicleID;constraint:OnDelete:CASCADE" json:"-"`
 	ZoneID      uint             `json:"zone_id"`
-	Zone        VehicleModelZone `gorm:"foreignKey:ZoneID" json:"zone"`
+	Zone        VehicleModelZone `gorm:"foreignKey:ZoneID;constraint:OnDelete:RESTRICT" json:"zone"`
 	Type        string           `json:"type"`
 	Severity    string           `json:"severity"`
 	Description string           `json:"description"`
 	PhotoID     *uint            `json:"photo_id"`
-	Photo       *VehiclePhoto    `gorm:"foreignKey:PhotoID" json:"photo,omitempty"`
+	Photo       *VehiclePhoto    `gorm:"foreignKey:PhotoID;constraint:OnDelete:SET NULL" json:"photo,omitempty"`
 	ReportedBy  uint             `json:"reported_by"`
 	Resolved    bool             `gorm:"default:false" json:"resolved"`
 	ResolvedBy  *uint            `json:"resolved_by"`
 	ResolvedAt  *time.Time       `json:"resolved_at"`
 	CreatedAt   time.Time        `json:"created_at"`
-}
\ No newline at end of file
+}
+
+type InspectionTemplate struct {
+	ID          uint                `gorm:"primaryKey" json:"id"`
+	Name        string              `json:"name"`
+	Kind        string              `json:"kind"`
+	Description string              `json:"description"`
+	Active      bool                `gorm:"default:true" json:"active"`
+	Sections    []InspectionSection `gorm:"foreignKey:TemplateID;constraint:OnDelete:CASCADE" json:"sections"`
+	Version     uint                `gorm:"not null;default:1" json:"version"`
+	CreatedAt   time.Time           `json:"created_at"`
+}
+
+type InspectionSection struct {
+	ID         uint             `gorm:"primaryKey" json:"id"`
+	TemplateID uint             `json:"template_id"`
+	Name       string           `json:"name"`
+	SortOrder  int              `json:"sort_order"`
+	Items      []InspectionItem `gorm:"foreignKey:SectionID;constraint:OnDelete:CASCADE" json:"items"`
+}
+
+type InspectionItem struct {
+	ID            uint     `gorm:"primaryKey" json:"id"`
+	SectionID     uint     `json:"section_id"`
+	Label         string   `json:"label"`
+	Required      bool     `json:"required"`
+	PhotoRequired bool     `json:"photo_required"`
+	Unit          string   `json:"unit"`
+	MinValue      *float64 `json:"min_value"`
+	MaxValue      *float64 `json:"max_value"`
+	SortOrder     int      `json:"sort_order"`
+}
+
+type Inspection struct {
+	ID          uint               `gorm:"primaryKey" json:"id"`
+	VehicleID   uint               `json:"vehicle_id"`
+	Vehicle     Vehicle            `gorm:"foreignKey:VehicleID;constraint:OnDelete:CASCADE" json:"-"`
+	TemplateID  uint               `json:"template_id"`
+	Template    InspectionTemplate `gorm:"foreignKey:TemplateID;constraint:OnDelete:RESTRICT" json:"-"`
+	Kind        string             `json:"kind"`
+	InspectorID uint               `json:"inspector_id"`
+	Status      string             `json:"status"`
+	Passed      bool               `json:"passed"`
+	Notes       string             `json:"notes"`
+	Results     []InspectionResult `gorm:"foreignKey:InspectionID;constraint:OnDelete:CASCADE" json:"results"`
+	StartedAt   time.Time          `json:"started_at"`
+	CompletedAt *time.Time         `json:"completed_at"`
+	Version     uint               `gorm:"not null;default:1" json:"version"`
+}
+
+type InspectionResult struct {
+	ID           uint           `gorm:"primaryKey" json:"id"`
+	InspectionID uint           `json:"inspection_id"`
+	ItemID       uint           `json:"item_id"`
+	Item         InspectionItem `gorm:"foreignKey:ItemID;constraint:OnDelete:RESTRICT" json:"-"`
+	Result       string         `json:"result"`
+	Value        *float64       `json:"value"`
+	PhotoID      *uint          `json:"photo_id"`
+	Photo        *VehiclePhoto  `gorm:"foreignKey:PhotoID;constraint:OnDelete:SET NULL" json:"-"`
+	Notes        string         `json:"notes"`
+	RecordedAt   time.Time      `json:"recorded_at"`
+}
+
+type ReconOrder struct {
+	ID        uint        `gorm:"primaryKey" json:"id"`
+	VehicleID uint        `json:"vehicle_id"`
+	Vehicle   Vehicle     `gorm:"foreignKey:VehicleID;constraint:OnDelete:CASCADE" json:"-"`
+	Status    string      `json:"status"`
+	OpenedBy  uint        `json:"opened_by"`
+	Notes     string      `json:"notes"`
+	Tasks     []ReconTask `gorm:"foreignKey:OrderID;constraint:OnDelete:CASCADE" json:"tasks"`
+	OpenedAt  time.Time   `json:"opened_at"`
+	ClosedAt  *time.Time  `json:"closed_at"`
+	Version   uint        `gorm:"not null;default:1" json:"version"`
+}
+
+type ReconTask struct {
+	ID               uint       `gorm:"primaryKey" json:"id"`
+	OrderID          uint       `json:"order_id"`
+	Type             string     `json:"type"`
+	Description      string     `json:"description"`
+	Status           string     `json:"status"`
+	AssigneeID       *uint      `json:"assignee_id"`
+	Vendor           string     `json:"vendor"`
+	EstimatedCost    float64    `json:"estimated_cost"`
+	ActualCost       *float64   `json:"actual_cost"`
+	ApprovalRequired bool       `json:"approval_required"`
+	ApprovedBy       *uint      `json:"approved_by"`
+	ApprovedAt       *time.Time `json:"approved_at"`
+	StartedAt        *time.Time `json:"started_at"`
+	CompletedAt      *time.Time `json:"completed_at"`
+	CancelledAt      *time.Time `json:"cancelled_at"`
+	Version          uint       `gorm:"not null;default:1" json:"version"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+type VehicleCostEntry struct {
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	VehicleID  uint       `gorm:"index:idx_vehicle_cost_entries_vehicle_incurred,priority:1" json:"vehicle_id"`
+	Vehicle    Vehicle    `gorm:"foreignKey:VehicleID;constraint:OnDelete:CASCADE" json:"-"`
+	Type       string     `json:"type"`
+	Amount     float64    `json:"amount"`
+	Vendor     string     `json:"vendor"`
+	InvoiceRef string     `json:"invoice_ref"`
+	IncurredOn time.Time  `gorm:"index:idx_vehicle_cost_entries_vehicle_incurred,priority:2" json:"incurred_on"`
+	Notes      string     `json:"notes"`
+	CreatedBy  uint       `json:"created_by"`
+	VoidedAt   *time.Time `json:"voided_at"`
+	VoidedBy   *uint      `json:"voided_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type RepricingRule struct {
+	ID             uint      `gorm:"primaryKey" json:"id"`
+	Name           string    `json:"name"`
+	Condition      *string   `json:"condition"`
+	LotType        *string   `json:"lot_type"`
+	MinDaysInStock int       `json:"min_days_in_stock"`
+	DropPercent    float64   `json:"drop_percent"`
+	IntervalDays   int       `json:"interval_days"`
+	FloorOverCost  float64   `json:"floor_over_cost"`
+	Active         bool      `gorm:"default:true" json:"active"`
+	Version        uint      `gorm:"not null;default:1" json:"version"`
+	CreatedAt      time.Time `json:"created_at"`
+	ModifiedAt     time.Time `json:"modified_at"`
+}
+
+type VehiclePriceChange struct {
+	ID        uint           `gorm:"primaryKey" json:"id"`
+	VehicleID uint           `gorm:"index:idx_vehicle_price_changes_vehicle_created,priority:1" json:"vehicle_id"`
+	Vehicle   Vehicle        `gorm:"foreignKey:VehicleID;constraint:OnDelete:CASCADE" json:"-"`
+	OldPrice  float64        `json:"old_price"`
+	NewPrice  float64        `json:"new_price"`
+	Source    string         `json:"source"`
+	RuleID    *uint          `json:"rule_id"`
+	Rule      *RepricingRule `gorm:"foreignKey:RuleID;constraint:OnDelete:SET NULL" json:"-"`
+	ChangedBy *uint          `json:"changed_by"`
+	CreatedAt time.Time      `gorm:"index:idx_vehicle_price_changes_vehicle_created,priority:2" json:"created_at"`
+}
@@ -1,6 +1,10 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type LeadSource struct {
 	ID         uint      `gorm:"primaryKey" json:"id"`
@@ -14,40 +18,45 @@ type LeadSource struct {
 type Lead struct {
 	ID            uint             `gorm:"primaryKey" json:"id"`
 	EntityID      uint             `json:"entity_id"`
-	Entity        Entity           `gorm:"foreignKey:EntityID" json:"entity"`
+	Entity        Entity           `gorm:"foreignKey:EntityID;constraint:OnDelete:RESTRICT" json:"entity"`
 	VehicleID     *uint            `json:"vehicle_id"`
-	Vehicle       *Vehicle         `gorm:"foreignKey:VehicleID" json:"vehicle,omitempty"`
+	Vehicle       *Vehicle         `gorm:"foreignKey:VehicleID;constraint:OnDelete:SET NULL" json:"vehicle,omitempty"`
 	InterestType  VehicleCondition `json:"interest_type"`
 	InterestMake  string           `json:"interest_make"`
 	InterestModel string           `json:"interest_model"`
 	BudgetMin     float64          `json:"budget_min"`
 	BudgetMax     float64          `json:"budget_max"`
 	SourceID      uint             `json:"source_id"`
-	Source        LeadSource       `gorm:"foreignKey:SourceID" json:"source"`
+	Source        LeadSource       `gorm:"foreignKey:SourceID;constraint:OnDelete:RESTRICT" json:"source"`
 	SourceDetail  string           `json:"source_detail"`
 	PresetID      *uint            `json:"preset_id"`
-	Preset        *LeadStepPreset  `gorm:"foreignKey:PresetID" json:"preset,omitempty"`
+	Preset        *LeadStepPreset  `gorm:"foreignKey:PresetID;constraint:OnDelete:SET NULL" json:"preset,omitempty"`
+	DeletedAt     gorm.DeletedAt   `gorm:"index" json:"deleted_at"`
+	DeletedBy     *uint            `json:"deleted_by"`
+	Version       uint             `gorm:"not null;default:1" json:"version"`
 	CreatedAt     time.Time        `json:"created_at"`
 	ModifiedAt    time.Time        `json:"modified_at"`
 }
 
 type LeadStepPreset struct {
-	ID          uint      `gorm:"primaryKey" json:"id"`
-	Code        string    `gorm:"unique" json:"code"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	SortOrder   int       `json:"sort_order"`
-	IsPublic    bool      `gorm:"default:false" json:"is_public"`
-	IsShared    bool      `gorm:"default:false" json:"is_shared"`
-	CreatedBy   uint      `json:"created_by"`
-	Creator     Entity    `gorm:"foreignKey:CreatedBy" json:"creator"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID          uint           `gorm:"primaryKey" json:"id"`
+	Code        string         `gorm:"unique" json:"code"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	SortOrder   int            `json:"sort_order"`
+	IsPublic    bool           `gorm:"default:false" json:"is_public"`
+	IsShared    bool           `gorm:"default:false" json:"is_shared"`
+	CreatedBy   uint           `json:"created_by"`
+	Creator     Entity         `gorm:"foreignKey:CreatedBy;constraint:OnDelete:RESTRICT" json:"creator"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+	DeletedBy   *uint          `json:"deleted_by"`
+	CreatedAt   time.Time      `json:"created_at"`
 }
 
 type LeadStep struct {
 	ID        uint           `gorm:"primaryKey" json:"id"`
 	PresetID  uint           `json:"preset_id"`
-	Preset    LeadStepPreset `gorm:"foreignKey:PresetID" json:"-"`
+	Preset    LeadStepPreset `gorm:"foreignKey:PresetID;constraint:OnDelete:CASCADE" json:"-"`
 	Code      string         `json:"code"`
 	Name      string         `json:"name"`
 	SortOrder int            `json:"sort_order"`
@@ -58,10 +67,10 @@ type LeadStep struct {
 
 type LeadStepProgress struct {
 	ID          uint       `gorm:"primaryKey" json:"id"`
-	LeadID      uint       `json:"lead_id"`
-	Lead        Lead       `gorm:"foreignKey:LeadID" json:"-"`
+	LeadID      uint       `gorm:"index" json:"lead_id"`
+	Lead        Lead       `gorm:"foreignKey:LeadID;constraint:OnDelete:CASCADE" json:"-"`
 	StepID      uint       `json:"step_id"`
-	Step        LeadStep   `gorm:"foreignKey:StepID" json:"step"`
+	Step        LeadStep   `gorm:"foreignKey:StepID;constraint:OnDelete:RESTRICT" json:"step"`
 	Status      StepStatus `gorm:"default:'pending'" json:"status"`
 	StartedAt   *time.Time `json:"started_at"`
 	CompletedAt *time.Time `json:"completed_at"`
@@ -72,41 +81,41 @@ type LeadStepProgress struct {
 
 type LeadAssignment struct {
 	ID         uint      `gorm:"primaryKey" json:"id"`
-	LeadID     uint      `json:"lead_id"`
-	Lead       Lead      `gorm:"foreignKey:LeadID" json:"-"`
-	EntityID   uint      `json:"entity_id"`
-	Entity     Entity    `gorm:"foreignKey:EntityID" json:"entity"`
+	LeadID     uint      `gorm:"index" json:"lead_id"`
+	Lead       Lead      `gorm:"foreignKey:LeadID;constraint:OnDelete:CASCADE" json:"-"`
+	EntityID   uint      `gorm:"index:idx_lead_assignments_entity_active,priority:1" json:"entity_id"`
+	Entity     Entity    `gorm:"foreignKey:EntityID;constraint:OnDelete:RESTRICT" json:"entity"`
 	Role       string    `json:"role"`
 	IsPrimary  bool      `gorm:"default:false" json:"is_primary"`
 	AssignedBy uint      `json:"assigned_by"`
-	Active     bool      `gorm:"default:true" json:"active"`
+	Active     bool      `gorm:"default:true;index:idx_lead_assignments_entity_active,priority:2" json:"active"`
 	CreatedAt  time.Time `json:"created_at"`
 }
 
 type LeadNote struct {
 	ID         uint      `gorm:"primaryKey" json:"id"`
-	LeadID     uint      `json:"lead_id"`
-	Lead       Lead      `gorm:"foreignKey:LeadID" json:"-"`
+	LeadID     uint      `gorm:"index" json:"lead_id"`
+	Lead       Lead      `gorm:"foreignKey:LeadID;constraint:OnDelete:CASCADE" json:"-"`
 	Content    string    `gorm:"type:text" json:"content"`
 	CreatedBy  uint      `json:"created_by"`
-	Creator    Entity    `gorm:"foreignKey:CreatedBy" json:"creator"`
+	Creator    Entity    `gorm:"foreignKey:CreatedBy;constraint:OnDelete:RESTRICT" json:"creator"`
 	CreatedAt  time.Time `json:"created_at"`
 	ModifiedAt time.Time `json:"modified_at"`
 }
 
 type LeadActivity struct {
-	ID          uint         `gorm:"primaryKey" json:"id"`
-	LeadID      uint         `json:"lead_id"`
-	Lead        Lead         `gorm:"foreignKey:LeadID" json:"-"`
+	ID          uint         `gorm:"primaryKey;index:idx_lead_activities_lead_created,priority:3" json:"id"`
+	LeadID      uint         `gorm:"index;index:idx_lead_activities_lead_created,priority:1" json:"lead_id"`
+	Lead        Lead         `gorm:"foreignKey:LeadID;constraint:OnDelete:CASCADE" json:"-"`
 	Type        ActivityType `json:"type"`
 	Description string       `json:"description"`
 	Outcome     string       `json:"outcome"`
 	PhoneID     *uint        `json:"phone_id"`
-	Phone       *EntityPhone `gorm:"foreignKey:PhoneID" json:"phone,omitempty"`
+	Phone       *EntityPhone `gorm:"foreignKey:PhoneID;constraint:OnDelete:SET NULL" json:"phone,omitempty"`
 	Email       string       `json:"email"`
 	PerformedBy uint         `json:"performed_by"`
-	Performer   Entity       `gorm:"foreignKey:PerformedBy" json:"performer"`
-	ScheduledAt *time.Time   `json:"scheduled_at"`
-	CompletedAt *time.Time   `json:"completed_at"`
-	CreatedAt   time.Time    `json:"created_at"`
-}
\ No newline at end of file
+	Performer   Entity       `gorm:"foreignKey:PerformedBy;constraint:OnDelete:RESTRICT" json:"performer"`
+	ScheduledAt *time.Time   `gorm:"index:idx_lead_activities_schedule,priority:1" json:"scheduled_at"`
+	CompletedAt *time.Time   `gorm:"index:idx_lead_activities_schedule,priority:2" json:"completed_at"`
+	CreatedAt   time.Time    `gorm:"index:idx_lead_activities_lead_created,priority:2" json:"created_at"`
+}
@@ -1,34 +1,42 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type Entity struct {
-	ID             uint         `gorm:"primaryKey" json:"id"`
-	Type           EntityType   `json:"type"`
-	FirstName      string       `json:"first_name"`
-	LastName       string       `json:"last_name"`
-	BusinessName   string       `json:"business_name"`
-	TaxID          string       `json:"tax_id"`
-	Email          string       `json:"email"`
-	Address        string       `json:"address"`
-	City           string       `json:"city"`
-	State          string       `json:"state"`
-	Zip            string       `json:"zip"`
-	CountryID      *uint        `json:"country_id"`
-	Country        *Country     `gorm:"foreignKey:CountryID" json:"country,omitempty"`
-	IsSystemUser   bool         `gorm:"default:false" json:"is_system_user"`
-	IsInternal     bool         `gorm:"default:false" json:"is_internal"`
-	ParentEntityID *uint        `json:"parent_entity_id"`
-	ParentEntity   *Entity      `gorm:"foreignKey:ParentEntityID" json:"parent_entity,omitempty"`
-	Status         EntityStatus `gorm:"default:'active'" json:"status"`
-	CreatedAt      time.Time    `json:"created_at"`
-	ModifiedAt     time.Time    `json:"modified_at"`
+	ID             uint           `gorm:"primaryKey" json:"id"`
+	Type           EntityType     `json:"type"`
+	FirstName      string         `json:"first_name"`
+	LastName       string         `json:"last_name"`
+	BusinessName   string         `json:"business_name"`
+	TaxID          string         `json:"tax_id"`
+	Email          string         `json:"email"`
+	Address        string         `json:"address"`
+	City           string         `json:"city"`
+	State          string         `json:"state"`
+	Zip            string         `json:"zip"`
+	CountryID      *uint          `json:"country_id"`
+	Country        *Country       `gorm:"foreignKey:CountryID;constraint:OnDelete:RESTRICT" json:"country,omitempty"`
+	IsSystemUser   bool           `gorm:"default:false" json:"is_system_user"`
+	IsInternal     bool           `gorm:"default:false" json:"is_internal"`
+	ParentEntityID *uint          `json:"parent_entity_id"`
+	ParentEntity   *Entity        `gorm:"foreignKey:ParentEntityID;constraint:OnDelete:SET NULL" json:"parent_entity,omitempty"`
+	Status         EntityStatus   `gorm:"default:'active'" json:"status"`
+	ErasedAt       *time.Time     `json:"erased_at"`
+	DeletedAt      gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+	DeletedBy      *uint          `json:"deleted_by"`
+	Version        uint           `gorm:"not null;default:1" json:"version"`
+	CreatedAt      time.Time      `json:"created_at"`
+	ModifiedAt     time.Time      `json:"modified_at"`
 }
 
 type UserAccount struct {
 	ID           uint         `gorm:"primaryKey" json:"id"`
 	EntityID     uint         `gorm:"unique" json:"entity_id"`
-	Entity       Entity       `gorm:"foreignKey:EntityID" json:"entity"`
+	Entity       Entity       `gorm:"foreignKey:EntityID;constraint:OnDelete:CASCADE" json:"entity"`
 	Username     string       `gorm:"unique" json:"username"`
 	PasswordHash string       `json:"-"`
 	LastLogin    time.Time    `json:"last_login"`
@@ -38,10 +46,10 @@ type UserAccount struct {
 
 type EntityPhone struct {
 	ID        uint      `gorm:"primaryKey" json:"id"`
-	EntityID  uint      `json:"entity_id"`
-	Entity    Entity    `gorm:"foreignKey:EntityID" json:"-"`
+	EntityID  uint      `gorm:"index" json:"entity_id"`
+	Entity    Entity    `gorm:"foreignKey:EntityID;constraint:OnDelete:CASCADE" json:"-"`
 	CountryID uint      `json:"country_id"`
-	Country   Country   `gorm:"foreignKey:CountryID" json:"country"`
+	Country   Country   `gorm:"foreignKey:CountryID;constraint:OnDelete:RESTRICT" json:"country"`
 	Number    string    `json:"number"`
 	Extension string    `json:"extension"`
 	Type      PhoneType `json:"type"`
@@ -72,9 +80,9 @@ type Role struct {
 type RoleResource struct {
 	ID         uint        `gorm:"primaryKey" json:"id"`
 	RoleID     uint        `json:"role_id"`
-	Role       Role        `gorm:"foreignKey:RoleID" json:"-"`
+	Role       Role        `gorm:"foreignKey:RoleID;constraint:OnDelete:CASCADE" json:"-"`
 	ResourceID uint        `json:"resource_id"`
-	Resource   Resource    `gorm:"foreignKey:ResourceID" json:"resource"`
+	Resource   Resource    `gorm:"foreignKey:ResourceID;constraint:OnDelete:CASCADE" json:"resource"`
 	Scope      AccessScope `gorm:"default:'none'" json:"scope"`
 	CreatedAt  time.Time   `json:"created_at"`
 }
@@ -82,9 +90,9 @@ type RoleResource struct {
 type EntityResource struct {
 	ID         uint        `gorm:"primaryKey" json:"id"`
 	EntityID   uint        `json:"entity_id"`
-	Entity     Entity      `gorm:"foreignKey:EntityID" json:"-"`
+	Entity     Entity      `gorm:"foreignKey:EntityID;constraint:OnDelete:CASCADE" json:"-"`
 	ResourceID uint        `json:"resource_id"`
-	Resource   Resource    `gorm:"foreignKey:ResourceID" json:"resource"`
+	Resource   Resource    `gorm:"foreignKey:ResourceID;constraint:OnDelete:CASCADE" json:"resource"`
 	Scope      AccessScope `gorm:"default:'none'" json:"scope"`
 	AssignedBy uint        `json:"assigned_by"`
 	Reason     string      `json:"reason"`
@@ -95,8 +103,8 @@ type EntityResource struct {
 type EntityRole struct {
 	ID        uint      `gorm:"primaryKey" json:"id"`
 	EntityID  uint      `json:"entity_id"`
-	Entity    Entity    `gorm:"foreignKey:EntityID" json:"-"`
+	Entity    Entity    `gorm:"foreignKey:EntityID;constraint:OnDelete:CASCADE" json:"-"`
 	RoleID    uint      `json:"role_id"`
-	Role      Role      `gorm:"foreignKey:RoleID" json:"role"`
+	Role      Role      `gorm:"foreignKey:RoleID;constraint:OnDelete:CASCADE" json:"role"`
 	CreatedAt time.Time `json:"created_at"`
-}
\ No newline at end of file
+}